@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"time"
@@ -15,6 +16,8 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/redis/mock"
 	"github.com/ajxv/redis-tui/internal/tui"
 )
 
@@ -23,15 +26,28 @@ var version = "dev"
 
 func run() error {
 	versionFlag := flag.Bool("version", false, "Print version and exit")
+	configPath := flag.String("config", tui.DefaultConfigPath(), "Path to the config file with connection profiles")
+	profileName := flag.String("profile", "", "Connect using the named profile from the config file")
+	clientName := flag.String("client-name", tui.DefaultClientName(), "CLIENT SETNAME sent on connect, shown in CLIENT LIST (empty to skip)")
+	demo := flag.Bool("demo", false, "Start against a built-in demo server with sample data (no Redis needed)")
+	spawn := flag.Bool("spawn", false, "Start a throwaway redis-server (from $PATH) with a temp dir, and stop it on exit")
+	readOnly := flag.Bool("readonly", false, "Refuse actions that change the server (shutdown, restart, background saves)")
 
 	// Connection flags
 	host := flag.String("host", "localhost:6379", "Redis server host:port")
-	password := flag.String("password", os.Getenv("REDIS_PASSWORD"), "Redis password (default: $REDIS_PASSWORD)")
+	password := flag.String("password", "", "Redis password (default: $REDISCLI_AUTH or $REDIS_PASSWORD)")
 	username := flag.String("username", "", "Redis ACL username (Redis 6+)")
 	db := flag.Int("db", 0, "Redis database index")
-	redisURL := flag.String("url", "", "Redis URL: redis://[:pass@]host[:port][/db] or rediss://...")
+	redisURL := flag.String("url", "", "Redis URL: redis://[:pass@]host[:port][/db] or rediss://... (default: $REDIS_URL)")
 	dialTimeout := flag.Duration("dial-timeout", 5*time.Second, "TCP connection timeout (e.g. 5s, 500ms)")
 	readTimeout := flag.Duration("read-timeout", 10*time.Second, "Redis read deadline per command")
+	traceFile := flag.String("trace", "", "Append a hex dump of every RESP frame sent and received to this file")
+	auditFile := flag.String("audit", "", "Append every command that changes data, with user, profile and time, to this file as JSON lines (default: the config file's audit_log)")
+	previewWidth := flag.Int("preview-width", tui.DefaultPreviewWidth, "Cut list elements, members and hash fields longer than this many cells in the browser (0 for no limit)")
+	dates := flag.Bool("dates", true, "Show human dates next to values, scores and TTLs that look like unix timestamps (t toggles)")
+	scanCount := flag.Int("scan-count", 0, "Largest SCAN COUNT hint: scans start small and grow to it (0 for the config file's scan_count, else 1000)")
+	largeValue := flag.Int("large-value", tui.DefaultLargeValueBytes, "Ask before fetching string or hash values larger than this many bytes (0 to never ask)")
+	telemetry := flag.Duration("telemetry", 0, "Sample INFO ops/sec, memory and clients this often, as sparklines in the header (e.g. 5s; 0 for the config file's telemetry_seconds, else off)")
 
 	// TLS flags
 	tlsEnabled := flag.Bool("tls", false, "Enable TLS/SSL")
@@ -47,32 +63,101 @@ func run() error {
 		return nil
 	}
 
-	// URL overrides individual flags when provided
-	if *redisURL != "" {
-		parsed, err := tui.ParseRedisURL(*redisURL)
+	cfg, err := tui.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Printf("Config error: %v\n", err)
+		return err
+	}
+
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	// Demo mode replaces every connection setting with the in-process server.
+	if *demo {
+		srv := mock.NewServer()
+		srv.Seed()
+		addr, err := srv.Listen("127.0.0.1:0")
 		if err != nil {
-			fmt.Printf("Invalid Redis URL: %v\n", err)
+			fmt.Printf("Demo server error: %v\n", err)
 			return err
 		}
-		*host = parsed.Host
-		if parsed.Password != "" {
-			*password = parsed.Password
+		defer srv.Close()
+		*profileName, *redisURL = "", ""
+		*host, *username, *password, *db = addr, "", "", 0
+		*tlsEnabled, *tlsCert, *tlsKey, *tlsCA = false, "", "", ""
+	}
+
+	// Spawn mode likewise, with a real server that lives as long as the TUI.
+	if *spawn {
+		if *demo {
+			err := fmt.Errorf("-demo and -spawn both start a server; pick one")
+			fmt.Printf("%v\n", err)
+			return err
 		}
-		if parsed.Username != "" {
-			*username = parsed.Username
+		srv, err := tui.SpawnServer("redis-server")
+		if err != nil {
+			fmt.Printf("Spawn error: %v\n", err)
+			return err
 		}
-		*db = parsed.DB
-		if parsed.TLS {
-			*tlsEnabled = true
+		defer srv.Stop()
+		*profileName, *redisURL = "", ""
+		*host, *username, *password, *db = srv.Addr, "", "", 0
+		*tlsEnabled, *tlsCert, *tlsKey, *tlsCA = false, "", "", ""
+	}
+
+	// A profile supplies the whole connection; -url, if also given, still wins.
+	var tlsCfg *tls.Config
+	var inline, proxy, production bool
+	var proxyKeys []string
+	var onConnect []string
+	var replica string
+	if *profileName != "" {
+		p, ok := cfg.Find(*profileName)
+		if !ok {
+			err := fmt.Errorf("unknown profile %q", *profileName)
+			fmt.Printf("Config error: %v\n", err)
+			return err
+		}
+		e, err := p.Endpoint()
+		if err != nil {
+			fmt.Printf("Config error: %v\n", err)
+			return err
+		}
+		*host, *username, *password, *db, tlsCfg = e.Address, e.Username, e.Password, e.DB, e.TLSConfig
+		*readOnly = *readOnly || p.ReadOnly
+		inline = p.Inline
+		production = p.Production
+		proxy, proxyKeys = p.Proxy, p.ProxyKeys
+		onConnect = p.OnConnect
+		replica = p.Replica
+		if p.ClientName != "" {
+			*clientName = p.ClientName
 		}
 	}
 
-	// Build TLS config (nil when TLS is disabled — plain TCP)
-	tlsCfg, err := tui.BuildTLSConfig(*tlsEnabled, *tlsSkipVerify, *tlsCert, *tlsKey, *tlsCA)
+	// The environment fills in what the flags left out; a URL overrides both.
+	conn, err := tui.ResolveConnFlags(tui.ConnFlags{
+		URL: *redisURL, Host: *host, Username: *username, Password: *password,
+		DB: *db, TLS: *tlsEnabled, Set: set,
+	}, os.Getenv)
 	if err != nil {
-		fmt.Printf("TLS config error: %v\n", err)
+		fmt.Printf("Invalid Redis URL: %v\n", err)
 		return err
 	}
+	*host, *username, *password, *db, *tlsEnabled = conn.Host, conn.Username, conn.Password, conn.DB, conn.TLS
+
+	if *demo {
+		*profileName = "demo" // labels the header; there is no such profile
+	}
+
+	// Build TLS config (nil when TLS is disabled — plain TCP)
+	if tlsCfg == nil || *tlsEnabled {
+		tlsCfg, err = tui.BuildTLSConfig(*tlsEnabled, *tlsSkipVerify, *tlsCert, *tlsKey, *tlsCA)
+		if err != nil {
+			fmt.Printf("TLS config error: %v\n", err)
+			return err
+		}
+	}
 
 	// Go's tls.Client requires either ServerName or InsecureSkipVerify.
 	// Derive ServerName from the host address so certificate hostname
@@ -106,20 +191,46 @@ func run() error {
 	// separate non-selectable header items, so the cursor always lands on a command.
 	items := []list.Item{
 		tui.NewListItem("EXPLORE", "Scan, filter, inspect, edit and delete keys"),
+		tui.NewListItem("SEARCH_VALUES", "Find text or a regex in string, hash and list values across keys"),
 		tui.NewListItemInGroup("SET", "Set a key-value pair", "STRINGS"),
 		tui.NewListItem("GET", "Get the value of a key"),
+		tui.NewListItem("COUNTERS", "Live table of counter / rate-limit keys with values and TTLs"),
+		tui.NewListItem("FLAGS", "Feature flags: keys holding 0/1 or true/false, toggled after a confirmation"),
 		tui.NewListItemInGroup("HSET", "Set a hash field", "HASHES"),
 		tui.NewListItem("HGET", "Get the value of a hash field"),
 		tui.NewListItemInGroup("RPUSH", "Append a value to the end of a list", "LISTS"),
 		tui.NewListItem("LPUSH", "Prepend a value to the start of a list"),
+		tui.NewListItem("BLPOP", "Watch a list with BLPOP/BRPOP on a dedicated connection"),
+		tui.NewListItem("QUEUES", "Dashboard of list-based job queues: lengths, trend, oldest job"),
 		tui.NewListItemInGroup("SADD", "Add a member to a set", "SETS & SORTED SETS"),
 		tui.NewListItem("ZADD", "Add a scored member to a sorted set"),
+		tui.NewListItem("LEADERBOARD", "Top members of a sorted set by score with ranks, live; ZINCRBY in place"),
+		tui.NewListItemInGroup("XADD", "Add an entry to a stream: several field=value pairs, optional ID", "STREAMS"),
 		tui.NewListItemInGroup("DELETE", "Delete a key", "MANAGE"),
+		tui.NewListItem("TEMPLATE", "Create a key from a template (session hash, JSON document, …)"),
+		tui.NewListItem("CONVERT", "Rewrite a JSON string as a hash, or a hash as a JSON string"),
+		tui.NewListItem("GENERATE", "Write N test keys of a type with templated values and TTLs"),
+		tui.NewListItem("COMPARE", "Diff one key across two profiles (e.g. staging vs prod)"),
+		tui.NewListItem("DIFF_DB", "Diff keys matching a pattern between two instances"),
 		tui.NewListItem("EXPORT", "Dump a key to a file (DUMP)"),
 		tui.NewListItem("IMPORT", "Restore a key from a file (RESTORE)"),
 		tui.NewListItem("EXPORT_DB", "Export the entire database to JSON"),
 		tui.NewListItem("IMPORT_DB", "Import the entire database from JSON"),
+		tui.NewListItem("EXPIRE_ALL", "Set a TTL on every key matching a pattern, after counting them"),
+		tui.NewListItem("REPLACE_ALL", "Find and replace (literal or regex) in string values, previewed first"),
+		tui.NewListItem("TTL_FORECAST", "Histogram of when keys matching a pattern expire: minute, hour, day, never"),
+		tui.NewListItem("COLD_KEYS", "Keys idle past a threshold (OBJECT IDLETIME), to delete or expire in bulk"),
+		tui.NewListItem("SNAPSHOT", "Periodically save selected keys to timestamped JSON files"),
+		tui.NewListItem("TIMELINE", "Per-minute chart of writes, expiries and deletes for a key pattern"),
 		tui.NewListItemInGroup("INFO", "View Redis server statistics", "SERVER"),
+		tui.NewListItem("PERSISTENCE", "RDB/AOF status with BGSAVE and BGREWRITEAOF controls"),
+		tui.NewListItem("CONSOLE", "Send raw Redis commands and see the replies"),
+		tui.NewListItem("AUDIT", "Commands sent by this session, writes first, with user, profile and time"),
+		tui.NewListItem("GROWTH", "Plot DBSIZE and per-prefix key counts over time, with a growth-rate alert"),
+		tui.NewListItem("BENCHMARK", "PING/SET/GET throughput and latency percentiles"),
+		tui.NewListItem("CLUSTER", "Cluster nodes, slot coverage, per-node INFO/console, failover, reshard preview"),
+		tui.NewListItem("LIFECYCLE", "SHUTDOWN or DEBUG RESTART the server (admin profiles only)"),
+		tui.NewListItemInGroup("TOUR", "Guided tour: connecting, exploring, editing and deleting", "HELP"),
 	}
 
 	menuList := list.New(items, tui.NewGroupedMenuDelegate(), 0, 0)
@@ -127,7 +238,7 @@ func run() error {
 	tui.StyleList(&menuList)
 	menuList.FilterInput.Placeholder = "type to filter commands…"
 
-	fieldsList := list.New([]list.Item{}, tui.BrowserDelegate(), 0, 0)
+	fieldsList := list.New([]list.Item{}, tui.BrowserDelegate().WithPreviewWidth(*previewWidth), 0, 0)
 	fieldsList.Title = "Select a field"
 	tui.StyleList(&fieldsList)
 
@@ -148,6 +259,39 @@ func run() error {
 	valueInput.Placeholder = "value"
 	valueInput.CharLimit = 4096
 
+	// The trace holds everything the server sends back, values included, so
+	// it is only readable by the user.
+	var tracer *redis.Tracer
+	if *traceFile != "" {
+		f, err := os.OpenFile(*traceFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			fmt.Printf("Trace file error: %v\n", err)
+			return err
+		}
+		defer f.Close()
+		tracer = redis.NewTracer(f)
+	}
+
+	// The audit log is shared by a team, so it is opened for append by
+	// whoever runs the TUI, and readable by the group.
+	if *auditFile == "" {
+		*auditFile = cfg.AuditLog
+	}
+	var auditLog io.Writer
+	if *auditFile != "" {
+		f, err := os.OpenFile(*auditFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+		if err != nil {
+			fmt.Printf("Audit file error: %v\n", err)
+			return err
+		}
+		defer f.Close()
+		auditLog = f
+	}
+
+	if *telemetry == 0 {
+		*telemetry = time.Duration(cfg.TelemetrySeconds) * time.Second
+	}
+
 	initialModel := tui.Model{
 		CurrentState: tui.StateMenu,
 		MenuList:     menuList,
@@ -164,16 +308,42 @@ func run() error {
 		Input: tui.InputModel{
 			Input: input,
 		},
-		RedisAddress: *host,
-		Password:     *password,
-		Username:     *username,
-		DB:           *db,
-		TLSConfig:    tlsCfg,
-		DialTimeout:  *dialTimeout,
-		ReadTimeout:  *readTimeout,
+		RedisAddress:    *host,
+		Password:        *password,
+		Username:        *username,
+		DB:              *db,
+		ClientName:      *clientName,
+		OnConnect:       onConnect,
+		TLSConfig:       tlsCfg,
+		DialTimeout:     *dialTimeout,
+		ReadTimeout:     *readTimeout,
+		LargeValueBytes: *largeValue,
+		Dates:           *dates,
+		ScanCount:       *scanCount,
+		Telemetry:       tui.TelemetryModel{Interval: *telemetry},
+		Trace:           tracer,
+		Recorder:        redis.NewRecorder(tui.InspectorExchanges),
+		Audit:           redis.NewAuditor(tui.AuditEntries, auditLog, tui.OSUser()),
+		AuditFile:       *auditFile,
+		Config:          cfg,
+		ProfileName:     *profileName,
+		ReadOnly:        *readOnly,
+		Production:      production,
+		Inline:          inline,
+		Proxy:           proxy,
+		ProxyKeys:       proxyKeys,
+		ReplicaAddress:  replica,
+	}
+
+	// The tour opens by itself once, over the menu, until it's been seen.
+	initialModel.TourPath = tui.DefaultTourPath()
+	initialModel.HistoryDir = tui.DefaultHistoryDir()
+	initialModel.SearchesDir = tui.DefaultSearchesDir()
+	if !tui.TourSeen(initialModel.TourPath) {
+		initialModel = initialModel.WithTour()
 	}
 
-	p := tea.NewProgram(initialModel, tea.WithAltScreen())
+	p := tea.NewProgram(tui.NewTabs(initialModel), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("An error occurred: %v\n", err)
 		return err
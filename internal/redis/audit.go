@@ -0,0 +1,288 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Auditor keeps a log of the commands sent on the connections it wraps,
+// each tagged with when it was sent, by which OS user and over which
+// profile, so a team sharing a server can tell who changed what. Writes —
+// anything that changes data or server state — go to the log file as JSON
+// lines; reads are only kept in memory, as browsing alone sends thousands.
+// Secrets (AUTH, passwords in HELLO, CONFIG SET and ACL SETUSER) are
+// replaced before anything is kept. A write that reports what it replaced
+// (SET ... GET) gets that noted too, once the reply is in.
+type Auditor struct {
+	mu     sync.Mutex
+	max    int
+	user   string
+	w      io.Writer // nil = memory only
+	writes []AuditEntry
+	reads  []AuditEntry
+	err    error // the first failed write to w
+}
+
+// AuditEntry is one command as the audit log keeps it.
+type AuditEntry struct {
+	At      time.Time `json:"at"`
+	User    string    `json:"user"`
+	Profile string    `json:"profile,omitempty"`
+	Addr    string    `json:"addr"`
+	Command []string  `json:"command"`
+	Write   bool      `json:"write"`
+	// Replaced is the value the write replaced, when it reported one; cut
+	// like an argument.
+	Replaced string `json:"replaced,omitempty"`
+}
+
+// auditArgBytes is how much of each argument is kept; values can be large.
+const auditArgBytes = 256
+
+// NewAuditor returns an Auditor keeping the last n writes and n reads in
+// memory, and logging writes to w unless it is nil.
+func NewAuditor(n int, w io.Writer, user string) *Auditor {
+	return &Auditor{max: n, w: w, user: user}
+}
+
+// Wrap returns conn with the commands written to it audited under profile
+// and addr. It must see requests as RESP arrays, so it goes outside any
+// inline rewriting. A nil Auditor returns conn unchanged.
+func (a *Auditor) Wrap(conn net.Conn, profile, addr string) net.Conn {
+	if a == nil {
+		return conn
+	}
+	return auditConn{Conn: conn, a: a, profile: profile, addr: addr}
+}
+
+// Entries returns the audited commands, oldest first: writes only, or
+// every command kept.
+func (a *Auditor) Entries(all bool) []AuditEntry {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !all {
+		return append([]AuditEntry(nil), a.writes...)
+	}
+	out := make([]AuditEntry, 0, len(a.writes)+len(a.reads))
+	w, r := a.writes, a.reads
+	for len(w) > 0 || len(r) > 0 {
+		if len(r) == 0 || len(w) > 0 && !w[0].At.After(r[0].At) {
+			out, w = append(out, w[0]), w[1:]
+		} else {
+			out, r = append(out, r[0]), r[1:]
+		}
+	}
+	return out
+}
+
+// Err is the first error writing the log file, if any. Entries are still
+// kept in memory after one.
+func (a *Auditor) Err() error {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+func (a *Auditor) record(profile, addr string, b []byte) {
+	cmds, ok := parseMultiBulk(b)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, cmd := range cmds {
+		e := AuditEntry{At: now, User: a.user, Profile: profile, Addr: addr, Command: auditWords(cmd), Write: IsWriteCommand(cmd)}
+		if !e.Write {
+			a.reads = keepLast(append(a.reads, e), a.max)
+			continue
+		}
+		a.writes = keepLast(append(a.writes, e), a.max)
+		a.log(e)
+	}
+}
+
+// Replaced notes old as the value cmd, a write sent on profile's
+// connection, replaced: on the latest matching write kept, and in the log
+// file as a second line repeating the command with "replaced" set, as the
+// command's own line went out when it was sent.
+func (a *Auditor) Replaced(profile, addr string, cmd RedisCmd, old string) {
+	if a == nil {
+		return
+	}
+	e := AuditEntry{At: time.Now(), User: a.user, Profile: profile, Addr: addr, Command: auditWords(cmd), Write: true, Replaced: cutArg(old)}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	i := len(a.writes) - 1
+	for ; i >= 0; i-- {
+		if w := a.writes[i]; w.Profile == profile && w.Addr == addr && slices.Equal(w.Command, e.Command) {
+			a.writes[i].Replaced = e.Replaced
+			break
+		}
+	}
+	if i < 0 {
+		a.writes = keepLast(append(a.writes, e), a.max)
+	}
+	a.log(e)
+}
+
+// log appends e to the log file, if there is one and it hasn't failed.
+// a.mu is held.
+func (a *Auditor) log(e AuditEntry) {
+	if a.w == nil || a.err != nil {
+		return
+	}
+	line, _ := json.Marshal(e)
+	if _, err := a.w.Write(append(line, '\n')); err != nil {
+		a.err = err
+	}
+}
+
+func keepLast(entries []AuditEntry, n int) []AuditEntry {
+	if len(entries) > n {
+		return append(entries[:0:0], entries[len(entries)-n:]...)
+	}
+	return entries
+}
+
+// auditWords is cmd with its secrets replaced and long arguments cut.
+func auditWords(cmd RedisCmd) []string {
+	name := strings.ToUpper(cmd.Name)
+	words := append([]string{name}, cmd.Args...)
+	redact := func(i int) {
+		if i < len(words) {
+			words[i] = "(redacted)"
+		}
+	}
+	switch name {
+	case "AUTH":
+		for i := 1; i < len(words); i++ {
+			redact(i)
+		}
+	case "HELLO", "MIGRATE":
+		for i := 1; i < len(words); i++ {
+			switch strings.ToUpper(words[i]) {
+			case "AUTH":
+				redact(i + 1)
+				if name == "HELLO" {
+					redact(i + 2)
+				}
+			case "AUTH2":
+				redact(i + 2)
+			}
+		}
+	case "CONFIG":
+		if len(words) > 1 && strings.EqualFold(words[1], "SET") {
+			for i := 2; i+1 < len(words); i += 2 {
+				if p := strings.ToLower(words[i]); p == "requirepass" || p == "masterauth" {
+					redact(i + 1)
+				}
+			}
+		}
+	case "ACL":
+		if len(words) > 1 && strings.EqualFold(words[1], "SETUSER") {
+			for i := 3; i < len(words); i++ {
+				if strings.HasPrefix(words[i], ">") || strings.HasPrefix(words[i], "#") {
+					words[i] = words[i][:1] + "(redacted)"
+				}
+			}
+		}
+	}
+	for i, w := range words {
+		words[i] = cutArg(w)
+	}
+	return words
+}
+
+// cutArg is w cut to auditArgBytes, with its full size noted.
+func cutArg(w string) string {
+	if len(w) > auditArgBytes {
+		return w[:auditArgBytes] + fmt.Sprintf("… (%d bytes)", len(w))
+	}
+	return w
+}
+
+// writeCommands are the commands that change data or server state. Those
+// that only do with some subcommands list them; the rest are writes
+// whatever follows. Scripts and functions count as writes, as only the
+// server knows what they do, and so does DEBUG, most of whose subcommands
+// change something (or crash the server on purpose).
+var writeCommands = map[string][]string{
+	"APPEND": nil, "BGREWRITEAOF": nil, "BGSAVE": nil, "BITFIELD": nil, "BITOP": nil, "BLMOVE": nil,
+	"BLMPOP": nil, "BLPOP": nil, "BRPOP": nil, "BRPOPLPUSH": nil, "BZMPOP": nil, "BZPOPMAX": nil,
+	"BZPOPMIN": nil, "COPY": nil, "DEBUG": nil, "DECR": nil, "DECRBY": nil, "DEL": nil, "EVAL": nil,
+	"EVALSHA": nil, "EXPIRE": nil, "EXPIREAT": nil, "FAILOVER": nil, "FCALL": nil, "FLUSHALL": nil,
+	"FLUSHDB": nil, "GEOADD": nil, "GEORADIUS": nil, "GEORADIUSBYMEMBER": nil, "GEOSEARCHSTORE": nil,
+	"GETDEL": nil, "GETEX": nil, "GETSET": nil, "HDEL": nil, "HEXPIRE": nil, "HEXPIREAT": nil,
+	"HGETDEL": nil, "HGETEX": nil, "HINCRBY": nil, "HINCRBYFLOAT": nil, "HMSET": nil, "HPERSIST": nil,
+	"HPEXPIRE": nil, "HPEXPIREAT": nil, "HSET": nil, "HSETEX": nil, "HSETNX": nil,
+	"INCR": nil, "INCRBY": nil, "INCRBYFLOAT": nil, "LINSERT": nil, "LMOVE": nil, "LMPOP": nil,
+	"LPOP": nil, "LPUSH": nil, "LPUSHX": nil, "LREM": nil, "LSET": nil, "LTRIM": nil,
+	"MIGRATE": nil, "MOVE": nil, "MSET": nil, "MSETNX": nil, "PERSIST": nil, "PEXPIRE": nil,
+	"PEXPIREAT": nil, "PFADD": nil, "PFMERGE": nil, "PSETEX": nil, "RENAME": nil, "RENAMENX": nil,
+	"REPLICAOF": nil, "RESTORE": nil, "RPOP": nil, "RPOPLPUSH": nil, "RPUSH": nil, "RPUSHX": nil,
+	"SADD": nil, "SAVE": nil, "SDIFFSTORE": nil, "SET": nil, "SETBIT": nil, "SETEX": nil, "SETNX": nil,
+	"SETRANGE": nil, "SHUTDOWN": nil, "SINTERSTORE": nil, "SLAVEOF": nil, "SMOVE": nil, "SORT": nil,
+	"SPOP": nil, "SREM": nil, "SUNIONSTORE": nil, "SWAPDB": nil, "UNLINK": nil, "XACK": nil,
+	"XADD": nil, "XAUTOCLAIM": nil, "XCLAIM": nil, "XDEL": nil, "XREADGROUP": nil, "XSETID": nil,
+	"XTRIM": nil, "ZADD": nil, "ZDIFFSTORE": nil, "ZINCRBY": nil, "ZINTERSTORE": nil, "ZMPOP": nil,
+	"ZPOPMAX": nil, "ZPOPMIN": nil, "ZRANGESTORE": nil, "ZREM": nil, "ZREMRANGEBYLEX": nil,
+	"ZREMRANGEBYRANK": nil, "ZREMRANGEBYSCORE": nil, "ZUNIONSTORE": nil,
+	"ACL":      {"DELUSER", "LOAD", "SAVE", "SETUSER"},
+	"CLIENT":   {"KILL", "PAUSE", "UNPAUSE"},
+	"CLUSTER":  {"ADDSLOTS", "DELSLOTS", "FAILOVER", "FLUSHSLOTS", "FORGET", "MEET", "REPLICATE", "RESET", "SETSLOT"},
+	"CONFIG":   {"RESETSTAT", "REWRITE", "SET"},
+	"FUNCTION": {"DELETE", "FLUSH", "KILL", "LOAD", "RESTORE"},
+	"LATENCY":  {"RESET"},
+	"MODULE":   {"LOAD", "LOADEX", "UNLOAD"},
+	"SCRIPT":   {"FLUSH", "KILL", "LOAD"},
+	"SLOWLOG":  {"RESET"},
+	"XGROUP":   {"CREATE", "CREATECONSUMER", "DELCONSUMER", "DESTROY", "SETID"},
+}
+
+// IsWriteCommand reports whether cmd changes data or server state.
+func IsWriteCommand(cmd RedisCmd) bool {
+	subs, ok := writeCommands[strings.ToUpper(cmd.Name)]
+	if !ok {
+		return false
+	}
+	if subs == nil {
+		return true
+	}
+	if len(cmd.Args) == 0 {
+		return false
+	}
+	for _, s := range subs {
+		if strings.EqualFold(cmd.Args[0], s) {
+			return true
+		}
+	}
+	return false
+}
+
+type auditConn struct {
+	net.Conn
+	a       *Auditor
+	profile string
+	addr    string
+}
+
+func (c auditConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.a.record(c.profile, c.addr, b[:n])
+	}
+	return n, err
+}
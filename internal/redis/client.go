@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
-	"io"
 	"strconv"
 	"strings"
 )
@@ -14,24 +13,83 @@ type RedisCmd struct {
 	Args []string
 }
 
+// RedisCmdBytes is a RedisCmd whose arguments are raw bytes, for payloads
+// that are never text — RESTORE's DUMP blob, a binary value read from a file —
+// so they reach the server without a detour through string.
+type RedisCmdBytes struct {
+	Name string
+	Args [][]byte
+}
+
+// ToBytes encodes the command as a RESP multi-bulk request. Every argument
+// is length-prefixed, so any bytes — CRLF, NUL, invalid UTF-8 — pass through
+// unescaped.
 func (cmd RedisCmd) ToBytes() []byte {
-	// use a bytes buffer to avoid conversion later
-	var buf bytes.Buffer
+	return encode(cmd.Name, cmd.Args)
+}
 
-	// 1. header
-	fmt.Fprintf(&buf, "*%d\r\n", len(cmd.Args)+1)
+// ToBytes encodes the command as a RESP multi-bulk request.
+func (cmd RedisCmdBytes) ToBytes() []byte {
+	return encode(cmd.Name, cmd.Args)
+}
+
+// Validate reports a command name no server would accept: empty, or holding
+// spaces or control bytes (a typo like "GET\nKEY" that would otherwise go out
+// as a single unknown command).
+func (cmd RedisCmd) Validate() error {
+	return validateName(cmd.Name)
+}
 
-	// 2. command name
-	fmt.Fprintf(&buf, "$%d\r\n%s\r\n", len(cmd.Name), cmd.Name)
+// Validate reports a command name no server would accept; see RedisCmd.Validate.
+func (cmd RedisCmdBytes) Validate() error {
+	return validateName(cmd.Name)
+}
 
-	// 3. arguments
-	for _, arg := range cmd.Args {
-		fmt.Fprintf(&buf, "$%d\r\n%s\r\n", len(arg), arg)
+func validateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("empty command name")
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; c <= ' ' || c == 0x7f {
+			return fmt.Errorf("command name %q contains spaces or control characters", name)
+		}
 	}
+	return nil
+}
 
-	return buf.Bytes()
+func encode[T string | []byte](name string, args []T) []byte {
+	size := 16 + len(name)
+	for _, arg := range args {
+		size += 16 + len(arg)
+	}
+	buf := appendHeader(make([]byte, 0, size), '*', len(args)+1)
+	buf = appendBulk(buf, name)
+	for _, arg := range args {
+		buf = appendBulk(buf, arg)
+	}
+	return buf
 }
 
+func appendHeader(buf []byte, prefix byte, n int) []byte {
+	buf = append(buf, prefix)
+	buf = strconv.AppendInt(buf, int64(n), 10)
+	return append(buf, '\r', '\n')
+}
+
+func appendBulk[T string | []byte](buf []byte, arg T) []byte {
+	buf = appendHeader(buf, '$', len(arg))
+	buf = append(buf, arg...)
+	return append(buf, '\r', '\n')
+}
+
+// maxPrealloc caps how many array slots a header may reserve up front, so a
+// corrupt length can't allocate gigabytes before a single element is read.
+const maxPrealloc = 1 << 16
+
+// ReadResp reads one reply. It is on the hot path of every SCAN page, so it
+// avoids per-element garbage: header lines are parsed in place from the
+// bufio.Reader's buffer, bulk payloads are copied straight out of it into
+// their final string, and arrays are sized from their header.
 func ReadResp(reader *bufio.Reader) (any, error) {
 	// 1. Read the prefix byte
 	prefix, err := reader.ReadByte()
@@ -41,89 +99,109 @@ func ReadResp(reader *bufio.Reader) (any, error) {
 
 	switch prefix {
 	case '+', '-':
-		// Simple String or Error: Read until newline
-		msg, err := reader.ReadString('\n')
+		// Simple String or Error: the line itself is the value
+		line, err := readLine(reader)
 		if err != nil {
 			return "", err
 		}
-		return strings.TrimSpace(msg), nil // Clean up the result
+		return string(line), nil
 
 	case '$':
-		// Bulk String: Read length first
-		lengthStr, err := reader.ReadString('\n')
+		lengthNum, err := readLength(reader)
 		if err != nil {
 			return "", err
 		}
-
-		// Convert length to integer
-		lengthNum, err := strconv.Atoi(strings.TrimSpace(lengthStr))
-		if err != nil {
-			return "", err
-		}
-
 		if lengthNum == -1 {
 			return "(nil)", nil // Handle NULL response
 		}
 
-		// Read the exact data bytes
-		data := make([]byte, lengthNum)
-		_, err = io.ReadFull(reader, data)
+		data, err := readBulk(reader, lengthNum)
 		if err != nil {
 			return "", err
 		}
-
-		// read the trailing clrf
-		_, err = reader.ReadString('\n')
-		if err != nil {
+		// read the trailing crlf
+		if _, err := readLine(reader); err != nil {
 			return "", err
 		}
+		return data, nil
 
-		return string(data), nil
 	case '*':
-		// Bulk String: Read length first
-		lengthStr, err := reader.ReadString('\n')
+		lengthNum, err := readLength(reader)
 		if err != nil {
 			return "", err
 		}
-
-		// Convert length to integer
-		lengthNum, err := strconv.Atoi(strings.TrimSpace(lengthStr))
-		if err != nil {
-			return "", err
-		}
-
 		if lengthNum == -1 {
 			return "(nil)", nil // Handle NULL response
 		}
 
-		var items []any
-
+		items := make([]any, 0, min(max(lengthNum, 0), maxPrealloc))
 		for range lengthNum {
 			item, err := ReadResp(reader)
 			if err != nil {
 				return "", err
 			}
-
 			items = append(items, item)
-
 		}
 		return items, nil
 
 	case ':':
-		// Simple int: Read until newline
-		msg, err := reader.ReadString('\n')
+		return readLength(reader)
+
+	default:
+		return "", fmt.Errorf("unknown RESP prefix byte: %q", prefix)
+	}
+}
+
+// readLine returns the rest of the current line without its CRLF or
+// surrounding spaces. The slice points into reader's buffer and is only valid
+// until the next read.
+func readLine(reader *bufio.Reader) ([]byte, error) {
+	line, err := reader.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		// A line longer than the buffer (a huge error message): copy what
+		// was read before the next read reuses the buffer under it.
+		buf := append([]byte(nil), line...)
+		rest, err := reader.ReadBytes('\n')
 		if err != nil {
-			return "", err
+			return nil, err
 		}
+		line = append(buf, rest...)
+	} else if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(line), nil
+}
 
-		num, err := strconv.Atoi(strings.TrimSpace(msg))
+// readLength parses the integer on the rest of the line.
+func readLength(reader *bufio.Reader) (int, error) {
+	line, err := readLine(reader)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(string(line))
+}
+
+// readBulk reads an n-byte payload into a string, copying from reader's
+// buffer in place of an intermediate []byte.
+func readBulk(reader *bufio.Reader, n int) (string, error) {
+	if n <= reader.Size() {
+		data, err := reader.Peek(n)
 		if err != nil {
 			return "", err
 		}
-
-		return num, nil // Clean up the result
-
-	default:
-		return "", fmt.Errorf("unknown RESP prefix byte: %q", prefix)
+		s := string(data)
+		_, _ = reader.Discard(n)
+		return s, nil
+	}
+	var b strings.Builder
+	b.Grow(n)
+	for b.Len() < n {
+		chunk, err := reader.Peek(min(n-b.Len(), reader.Size()))
+		if len(chunk) == 0 && err != nil {
+			return "", err
+		}
+		b.Write(chunk)
+		_, _ = reader.Discard(len(chunk))
 	}
+	return b.String(), nil
 }
@@ -0,0 +1,121 @@
+package redis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CommandInfo is one entry of a COMMAND or COMMAND INFO reply.
+type CommandInfo struct {
+	Name  string // upper case
+	Arity int    // counts the name; negative means at least -Arity
+	Flags []string
+	// FirstKey, LastKey and Step locate the key arguments, counting the
+	// name as 0: every Step-th from FirstKey to LastKey, which is negative
+	// when counted back from the end. FirstKey 0 means none, or that only
+	// the server can tell (EVAL's numkeys, say).
+	FirstKey, LastKey, Step int
+	// Subcommands are the upper-case words a container command such as
+	// CONFIG takes first (GET, SET, …). Redis 7 and later report them.
+	Subcommands []string
+}
+
+// CommandTable is what a server says it implements, by upper-case name. A
+// nil table means it didn't say (COMMAND refused, or never asked), and
+// everything is assumed to be there.
+type CommandTable map[string]CommandInfo
+
+// ParseCommandTable reads a COMMAND or COMMAND INFO reply. Nulls — COMMAND
+// INFO's answer for a command the server doesn't know — are skipped.
+func ParseCommandTable(resp any) (CommandTable, error) {
+	if err := replyErr(resp); err != nil {
+		return nil, err
+	}
+	entries, ok := resp.([]any)
+	if !ok {
+		return nil, unexpected("array", resp)
+	}
+	t := CommandTable{}
+	for i, e := range entries {
+		fields, ok := e.([]any)
+		if !ok {
+			continue
+		}
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("redis: command %d: short entry", i)
+		}
+		name, err := AsString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("redis: command %d: %w", i, err)
+		}
+		arity, _ := fields[1].(int)
+		info := CommandInfo{Name: strings.ToUpper(name), Arity: arity}
+		if len(fields) > 5 {
+			info.FirstKey, _ = fields[3].(int)
+			info.LastKey, _ = fields[4].(int)
+			info.Step, _ = fields[5].(int)
+		}
+		if flags, ok := fields[2].([]any); ok {
+			for _, f := range flags {
+				if s, ok := f.(string); ok {
+					info.Flags = append(info.Flags, s)
+				}
+			}
+		}
+		if len(fields) > 9 {
+			if subs, ok := fields[9].([]any); ok {
+				for _, s := range subs {
+					sf, ok := s.([]any)
+					if !ok || len(sf) == 0 {
+						continue
+					}
+					full, _ := sf[0].(string)
+					if _, sub, ok := strings.Cut(full, "|"); ok {
+						info.Subcommands = append(info.Subcommands, strings.ToUpper(sub))
+					}
+				}
+				sort.Strings(info.Subcommands)
+			}
+		}
+		t[info.Name] = info
+	}
+	return t, nil
+}
+
+// Has reports whether the server implements name.
+func (t CommandTable) Has(name string) bool {
+	if t == nil {
+		return true
+	}
+	_, ok := t[strings.ToUpper(name)]
+	return ok
+}
+
+// IsKeyArg reports whether argument i of a command (0 being its name) is a
+// key, by the positions the server reported. ok is false when it reported
+// none, and the caller has to guess. A LastKey counted from the end is taken
+// to be still ahead, as the line is still being typed.
+func (info CommandInfo) IsKeyArg(i int) (isKey, ok bool) {
+	if info.FirstKey <= 0 {
+		return false, false
+	}
+	step := max(info.Step, 1)
+	if i < info.FirstKey || (i-info.FirstKey)%step != 0 {
+		return false, true
+	}
+	if info.LastKey >= 0 {
+		return i <= info.LastKey, true
+	}
+	return true, true
+}
+
+// Names lists the commands in the table, sorted.
+func (t CommandTable) Names() []string {
+	names := make([]string, 0, len(t))
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,245 @@
+package redis
+
+import "strconv"
+
+// Builders for the commands the TUI sends most. Each puts its arguments in
+// the order Redis expects and formats numbers itself, so call sites can't
+// swap a score and a member or forget the COUNT keyword. Commands used once,
+// or whose shape depends on user input (the console), still build RedisCmd
+// directly.
+
+func keyCmd(name, key string) RedisCmd {
+	return RedisCmd{Name: name, Args: []string{key}}
+}
+
+func Ping() RedisCmd { return RedisCmd{Name: "PING"} }
+
+// Auth authenticates with an ACL user, or with the legacy requirepass
+// password when username is empty.
+func Auth(username, password string) RedisCmd {
+	if username == "" {
+		return RedisCmd{Name: "AUTH", Args: []string{password}}
+	}
+	return RedisCmd{Name: "AUTH", Args: []string{username, password}}
+}
+
+func Type(key string) RedisCmd   { return keyCmd("TYPE", key) }
+func TTL(key string) RedisCmd    { return keyCmd("TTL", key) }
+func PTTL(key string) RedisCmd   { return keyCmd("PTTL", key) }
+func Dump(key string) RedisCmd   { return keyCmd("DUMP", key) }
+func Exists(key string) RedisCmd { return keyCmd("EXISTS", key) }
+func Persist(key string) RedisCmd {
+	return keyCmd("PERSIST", key)
+}
+
+func Del(key string, more ...string) RedisCmd {
+	return RedisCmd{Name: "DEL", Args: append([]string{key}, more...)}
+}
+
+// Publish sends message to the clients subscribed to channel.
+func Publish(channel, message string) RedisCmd {
+	return RedisCmd{Name: "PUBLISH", Args: []string{channel, message}}
+}
+
+func Expire(key string, seconds int) RedisCmd {
+	return RedisCmd{Name: "EXPIRE", Args: []string{key, strconv.Itoa(seconds)}}
+}
+
+func Rename(key, newKey string) RedisCmd {
+	return RedisCmd{Name: "RENAME", Args: []string{key, newKey}}
+}
+
+// Scan asks for the next page of keys after cursor; an empty match and a
+// zero count leave those options to the server's defaults.
+func Scan(cursor, match string, count int) RedisCmd {
+	args := []string{cursor}
+	if match != "" {
+		args = append(args, "MATCH", match)
+	}
+	if count > 0 {
+		args = append(args, "COUNT", strconv.Itoa(count))
+	}
+	return RedisCmd{Name: "SCAN", Args: args}
+}
+
+func Get(key string) RedisCmd    { return keyCmd("GET", key) }
+func StrLen(key string) RedisCmd { return keyCmd("STRLEN", key) }
+
+func Set(key, value string) RedisCmd {
+	return RedisCmd{Name: "SET", Args: []string{key, value}}
+}
+
+// GetRange reads bytes start..end of a string, both inclusive.
+func GetRange(key string, start, end int) RedisCmd {
+	return RedisCmd{Name: "GETRANGE", Args: []string{key, strconv.Itoa(start), strconv.Itoa(end)}}
+}
+
+func HGet(key, field string) RedisCmd {
+	return RedisCmd{Name: "HGET", Args: []string{key, field}}
+}
+
+func HSet(key, field, value string) RedisCmd {
+	return RedisCmd{Name: "HSET", Args: []string{key, field, value}}
+}
+
+func HKeys(key string) RedisCmd   { return keyCmd("HKEYS", key) }
+func HLen(key string) RedisCmd    { return keyCmd("HLEN", key) }
+func HGetAll(key string) RedisCmd { return keyCmd("HGETALL", key) }
+
+// HRandField reads up to n distinct random fields, with their values when
+// withValues is set.
+func HRandField(key string, n int, withValues bool) RedisCmd {
+	args := []string{key, strconv.Itoa(n)}
+	if withValues {
+		args = append(args, "WITHVALUES")
+	}
+	return RedisCmd{Name: "HRANDFIELD", Args: args}
+}
+
+// HScan reads a page of fields with their values, from cursor ("0" to start).
+func HScan(key, cursor string, count int) RedisCmd {
+	return RedisCmd{Name: "HSCAN", Args: []string{key, cursor, "COUNT", strconv.Itoa(count)}}
+}
+
+// HScanFields is HScan without the values (NOVALUES): a page of field names.
+func HScanFields(key, cursor string, count int) RedisCmd {
+	return RedisCmd{Name: "HSCAN", Args: []string{key, cursor, "COUNT", strconv.Itoa(count), "NOVALUES"}}
+}
+
+func LLen(key string) RedisCmd { return keyCmd("LLEN", key) }
+
+// LRange reads elements start..stop, both inclusive; -1 is the last element.
+func LRange(key string, start, stop int) RedisCmd {
+	return RedisCmd{Name: "LRANGE", Args: []string{key, strconv.Itoa(start), strconv.Itoa(stop)}}
+}
+
+func LIndex(key string, index int) RedisCmd {
+	return RedisCmd{Name: "LINDEX", Args: []string{key, strconv.Itoa(index)}}
+}
+
+func RPush(key, value string, more ...string) RedisCmd {
+	return RedisCmd{Name: "RPUSH", Args: append([]string{key, value}, more...)}
+}
+
+func SAdd(key, member string, more ...string) RedisCmd {
+	return RedisCmd{Name: "SADD", Args: append([]string{key, member}, more...)}
+}
+
+func SCard(key string) RedisCmd    { return keyCmd("SCARD", key) }
+func SMembers(key string) RedisCmd { return keyCmd("SMEMBERS", key) }
+
+// SRandMember reads up to n distinct random members.
+func SRandMember(key string, n int) RedisCmd {
+	return RedisCmd{Name: "SRANDMEMBER", Args: []string{key, strconv.Itoa(n)}}
+}
+
+func SScan(key, cursor string, count int) RedisCmd {
+	return RedisCmd{Name: "SSCAN", Args: []string{key, cursor, "COUNT", strconv.Itoa(count)}}
+}
+
+// ZAdd adds member with score. The score stays a string: it is usually typed
+// by the user or read from a file, and Redis accepts forms like "+inf" that a
+// float64 round trip would not preserve.
+func ZAdd(key, score, member string) RedisCmd {
+	return RedisCmd{Name: "ZADD", Args: []string{key, score, member}}
+}
+
+func ZCard(key string) RedisCmd { return keyCmd("ZCARD", key) }
+
+func ZScore(key, member string) RedisCmd {
+	return RedisCmd{Name: "ZSCORE", Args: []string{key, member}}
+}
+
+// ZRandMember reads up to n distinct random members, with their scores
+// when withScores is set.
+func ZRandMember(key string, n int, withScores bool) RedisCmd {
+	args := []string{key, strconv.Itoa(n)}
+	if withScores {
+		args = append(args, "WITHSCORES")
+	}
+	return RedisCmd{Name: "ZRANDMEMBER", Args: args}
+}
+
+// ZRange reads members by rank start..stop, both inclusive.
+func ZRange(key string, start, stop int, withScores bool) RedisCmd {
+	args := []string{key, strconv.Itoa(start), strconv.Itoa(stop)}
+	if withScores {
+		args = append(args, "WITHSCORES")
+	}
+	return RedisCmd{Name: "ZRANGE", Args: args}
+}
+
+// ZRevRange reads members by rank from the highest score, start..stop
+// both inclusive.
+func ZRevRange(key string, start, stop int, withScores bool) RedisCmd {
+	cmd := ZRange(key, start, stop, withScores)
+	cmd.Name = "ZREVRANGE"
+	return cmd
+}
+
+// ZIncrBy adds increment to member's score, adding the member at that
+// score when it's missing.
+func ZIncrBy(key, increment, member string) RedisCmd {
+	return RedisCmd{Name: "ZINCRBY", Args: []string{key, increment, member}}
+}
+
+// XRevRange reads the newest count entries of a stream, newest first.
+func XRevRange(key string, count int) RedisCmd {
+	return RedisCmd{Name: "XREVRANGE", Args: []string{key, "+", "-", "COUNT", strconv.Itoa(count)}}
+}
+
+// XReadBlock waits up to blockMs for entries of key after id ("$" for only
+// those added from now on) and reads at most count of them; 0 blocks until
+// one arrives.
+func XReadBlock(key, id string, blockMs, count int) RedisCmd {
+	return RedisCmd{Name: "XREAD", Args: []string{"COUNT", strconv.Itoa(count), "BLOCK", strconv.Itoa(blockMs), "STREAMS", key, id}}
+}
+
+// XAdd appends an entry to a stream under id ("*" for one the server picks).
+func XAdd(key, id string, fields ...string) RedisCmd {
+	return RedisCmd{Name: "XADD", Args: append([]string{key, id}, fields...)}
+}
+
+func XInfoGroups(key string) RedisCmd {
+	return RedisCmd{Name: "XINFO", Args: []string{"GROUPS", key}}
+}
+
+// XGroupCreate creates group on key, reading after id ("$" for new entries
+// only, "0" for the whole stream); mkStream creates a missing stream.
+func XGroupCreate(key, group, id string, mkStream bool) RedisCmd {
+	args := []string{"CREATE", key, group, id}
+	if mkStream {
+		args = append(args, "MKSTREAM")
+	}
+	return RedisCmd{Name: "XGROUP", Args: args}
+}
+
+func XGroupDestroy(key, group string) RedisCmd {
+	return RedisCmd{Name: "XGROUP", Args: []string{"DESTROY", key, group}}
+}
+
+func XGroupSetID(key, group, id string) RedisCmd {
+	return RedisCmd{Name: "XGROUP", Args: []string{"SETID", key, group, id}}
+}
+
+// XAutoClaim hands up to count pending entries idle for at least minIdleMs,
+// from start on, to consumer.
+func XAutoClaim(key, group, consumer string, minIdleMs int, start string, count int) RedisCmd {
+	return RedisCmd{Name: "XAUTOCLAIM", Args: []string{key, group, consumer, strconv.Itoa(minIdleMs), start, "COUNT", strconv.Itoa(count)}}
+}
+
+// XPendingRange lists up to count of group's pending entries, oldest ID
+// first: ID, owner, idle ms and delivery count each.
+func XPendingRange(key, group string, count int) RedisCmd {
+	return RedisCmd{Name: "XPENDING", Args: []string{key, group, "-", "+", strconv.Itoa(count)}}
+}
+
+func XAck(key, group string, ids ...string) RedisCmd {
+	return RedisCmd{Name: "XACK", Args: append([]string{key, group}, ids...)}
+}
+
+// XClaim hands the pending entries ids to consumer, skipping any another
+// consumer picked up within the last minIdleMs.
+func XClaim(key, group, consumer string, minIdleMs int, ids ...string) RedisCmd {
+	return RedisCmd{Name: "XCLAIM", Args: append([]string{key, group, consumer, strconv.Itoa(minIdleMs)}, ids...)}
+}
@@ -0,0 +1,75 @@
+package redis
+
+// GlobMatch matches s against a Redis glob pattern, the way KEYS and SCAN
+// MATCH do: * ? [abc] [^a] [a-z] and backslash escapes.
+func GlobMatch(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if GlobMatch(pattern[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+			s = s[1:]
+		case '[':
+			if len(s) == 0 {
+				return false
+			}
+			pattern = pattern[1:]
+			not := len(pattern) > 0 && pattern[0] == '^'
+			if not {
+				pattern = pattern[1:]
+			}
+			match := false
+			for len(pattern) > 0 && pattern[0] != ']' {
+				switch {
+				case pattern[0] == '\\' && len(pattern) >= 2:
+					pattern = pattern[1:]
+					match = match || pattern[0] == s[0]
+				case len(pattern) >= 3 && pattern[1] == '-' && pattern[2] != ']':
+					lo, hi := pattern[0], pattern[2]
+					if lo > hi {
+						lo, hi = hi, lo
+					}
+					match = match || s[0] >= lo && s[0] <= hi
+					pattern = pattern[2:]
+				default:
+					match = match || pattern[0] == s[0]
+				}
+				pattern = pattern[1:]
+			}
+			if match == not {
+				return false
+			}
+			s = s[1:]
+			if len(pattern) == 0 {
+				// Unterminated class: Redis treats the end of the pattern as ']'.
+				return len(s) == 0
+			}
+		case '\\':
+			if len(pattern) >= 2 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if len(s) == 0 || pattern[0] != s[0] {
+				return false
+			}
+			s = s[1:]
+		}
+		pattern = pattern[1:]
+	}
+	return len(s) == 0
+}
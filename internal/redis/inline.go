@@ -0,0 +1,122 @@
+package redis
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ToInline encodes the command in the inline protocol (space-separated
+// words ending in CRLF) for proxies that don't accept multi-bulk requests.
+// Arguments that would not survive word splitting are double-quoted with
+// the escapes Redis' inline parser understands.
+func (cmd RedisCmd) ToInline() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(quoteInline(cmd.Name))
+	for _, arg := range cmd.Args {
+		buf.WriteByte(' ')
+		buf.WriteString(quoteInline(arg))
+	}
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
+
+// QuoteArg quotes s as ToInline would, for showing a command to the user.
+func QuoteArg(s string) string {
+	return quoteInline(s)
+}
+
+func quoteInline(s string) string {
+	plain := s != ""
+	for i := 0; i < len(s) && plain; i++ {
+		c := s[i]
+		plain = c > ' ' && c < 0x7f && c != '"' && c != '\'' && c != '\\'
+	}
+	if plain {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c == '\n':
+			b.WriteString(`\n`)
+		case c == '\r':
+			b.WriteString(`\r`)
+		case c == '\t':
+			b.WriteString(`\t`)
+		case c < ' ' || c >= 0x7f:
+			fmt.Fprintf(&b, `\x%02x`, c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// inlineConn rewrites the multi-bulk requests written to it as inline
+// commands. Replies are untouched — servers answer inline commands in RESP.
+type inlineConn struct {
+	net.Conn
+}
+
+// NewInlineConn wraps conn so every RedisCmd.ToBytes request written to it
+// goes out in the inline protocol instead. Callers keep writing ToBytes
+// output; only this one place needs to know about the compatibility mode.
+func NewInlineConn(conn net.Conn) net.Conn {
+	return inlineConn{Conn: conn}
+}
+
+func (c inlineConn) Write(b []byte) (int, error) {
+	cmds, ok := parseMultiBulk(b)
+	if !ok {
+		// Not something ToBytes produced; send it as-is rather than guess.
+		return c.Conn.Write(b)
+	}
+	var out bytes.Buffer
+	for _, cmd := range cmds {
+		out.Write(cmd.ToInline())
+	}
+	if _, err := c.Conn.Write(out.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// parseMultiBulk decodes one or more complete multi-bulk requests.
+func parseMultiBulk(b []byte) ([]RedisCmd, bool) {
+	r := bufio.NewReader(bytes.NewReader(b))
+	var cmds []RedisCmd
+	for {
+		if _, err := r.Peek(1); err != nil {
+			return cmds, len(cmds) > 0
+		}
+		v, err := ReadResp(r)
+		if err != nil {
+			return nil, false
+		}
+		parts, ok := v.([]any)
+		if !ok || len(parts) == 0 {
+			return nil, false
+		}
+		words := make([]string, len(parts))
+		for i, p := range parts {
+			switch w := p.(type) {
+			case string:
+				words[i] = w
+			case int:
+				words[i] = strconv.Itoa(w)
+			default:
+				return nil, false
+			}
+		}
+		cmds = append(cmds, RedisCmd{Name: words[0], Args: words[1:]})
+	}
+}
@@ -0,0 +1,2144 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// command is one supported command. arity is the minimum number of
+// arguments after the name; write marks commands that count as changes for
+// INFO persistence.
+type command struct {
+	fn    func(s *Server, c *client, args []string) any
+	arity int
+	write bool
+	// blocking commands wait without holding the server lock, taking it
+	// themselves as they poll.
+	blocking bool
+}
+
+var commandTable map[string]command
+
+func init() {
+	commandTable = map[string]command{
+		// connection
+		"PING":   {fn: cmdPing},
+		"ECHO":   {fn: func(_ *Server, _ *client, a []string) any { return a[1] }, arity: 1},
+		"SELECT": {fn: cmdSelect, arity: 1},
+		"AUTH":   {fn: cmdAuth, arity: 1},
+		"QUIT":   {fn: func(*Server, *client, []string) any { return status("OK") }},
+		"CLIENT": {fn: cmdClient, arity: 1},
+		// keyspace
+		"DBSIZE":   {fn: cmdDBSize},
+		"FLUSHDB":  {fn: cmdFlushDB, write: true},
+		"FLUSHALL": {fn: cmdFlushAll, write: true},
+		"KEYS":     {fn: cmdKeys, arity: 1},
+		"SCAN":     {fn: cmdScan, arity: 1},
+		"TYPE":     {fn: cmdType, arity: 1},
+		"EXISTS":   {fn: cmdExists, arity: 1},
+		"DEL":      {fn: cmdDel, arity: 1, write: true},
+		"PUBLISH":  {fn: cmdPublish, arity: 2},
+		"UNLINK":   {fn: cmdDel, arity: 1, write: true},
+		"RENAME":   {fn: cmdRename, arity: 2, write: true},
+		"TTL":      {fn: cmdTTL, arity: 1},
+		"PTTL":     {fn: cmdTTL, arity: 1},
+		"EXPIRE":   {fn: cmdExpire, arity: 2, write: true},
+		"PEXPIRE":  {fn: cmdExpire, arity: 2, write: true},
+		"PERSIST":  {fn: cmdPersist, arity: 1, write: true},
+		"OBJECT":   {fn: cmdObject, arity: 2},
+		"DUMP":     {fn: cmdDump, arity: 1},
+		"RESTORE":  {fn: cmdRestore, arity: 3, write: true},
+		// strings
+		"GET":    {fn: cmdGet, arity: 1},
+		"SET":    {fn: cmdSet, arity: 2, write: true},
+		"MGET":   {fn: cmdMGet, arity: 1},
+		"INCR":   {fn: cmdIncrBy, arity: 1, write: true},
+		"DECR":   {fn: cmdIncrBy, arity: 1, write: true},
+		"INCRBY": {fn: cmdIncrBy, arity: 2, write: true},
+		"DECRBY": {fn: cmdIncrBy, arity: 2, write: true},
+		"APPEND": {fn: cmdAppend, arity: 2, write: true},
+		"STRLEN": {fn: cmdStrlen, arity: 1},
+		// hashes
+		"HSET":       {fn: cmdHSet, arity: 3, write: true},
+		"HGET":       {fn: cmdHGet, arity: 2},
+		"HDEL":       {fn: cmdHDel, arity: 2, write: true},
+		"HKEYS":      {fn: cmdHKeys, arity: 1},
+		"HVALS":      {fn: cmdHVals, arity: 1},
+		"HGETALL":    {fn: cmdHGetAll, arity: 1},
+		"HLEN":       {fn: cmdHLen, arity: 1},
+		"HEXISTS":    {fn: cmdHExists, arity: 2},
+		"HSCAN":      {fn: cmdHScan, arity: 2},
+		"HRANDFIELD": {fn: cmdHRandField, arity: 2},
+		// lists
+		"LPUSH":  {fn: cmdPush, arity: 2, write: true},
+		"RPUSH":  {fn: cmdPush, arity: 2, write: true},
+		"LPOP":   {fn: cmdPop, arity: 1, write: true},
+		"RPOP":   {fn: cmdPop, arity: 1, write: true},
+		"LRANGE": {fn: cmdLRange, arity: 3},
+		"LLEN":   {fn: cmdLLen, arity: 1},
+		"LINDEX": {fn: cmdLIndex, arity: 2},
+		"LSET":   {fn: cmdLSet, arity: 3, write: true},
+		"LREM":   {fn: cmdLRem, arity: 3, write: true},
+		"BLPOP":  {fn: cmdBlockingPop, arity: 2, write: true, blocking: true},
+		"BRPOP":  {fn: cmdBlockingPop, arity: 2, write: true, blocking: true},
+		// sets
+		"SADD":        {fn: cmdSAdd, arity: 2, write: true},
+		"SREM":        {fn: cmdSRem, arity: 2, write: true},
+		"SMEMBERS":    {fn: cmdSMembers, arity: 1},
+		"SCARD":       {fn: cmdSCard, arity: 1},
+		"SISMEMBER":   {fn: cmdSIsMember, arity: 2},
+		"SSCAN":       {fn: cmdSScan, arity: 2},
+		"SRANDMEMBER": {fn: cmdSRandMember, arity: 2},
+		// sorted sets
+		"ZADD":        {fn: cmdZAdd, arity: 3, write: true},
+		"ZREM":        {fn: cmdZRem, arity: 2, write: true},
+		"ZRANGE":      {fn: cmdZRange, arity: 3},
+		"ZREVRANGE":   {fn: cmdZRevRange, arity: 3},
+		"ZINCRBY":     {fn: cmdZIncrBy, arity: 3, write: true},
+		"ZSCORE":      {fn: cmdZScore, arity: 2},
+		"ZCARD":       {fn: cmdZCard, arity: 1},
+		"ZRANDMEMBER": {fn: cmdZRandMember, arity: 2},
+		// streams
+		"XADD":       {fn: cmdXAdd, arity: 4, write: true},
+		"XLEN":       {fn: cmdXLen, arity: 1},
+		"XRANGE":     {fn: cmdXRange, arity: 3},
+		"XREVRANGE":  {fn: cmdXRange, arity: 3},
+		"XREAD":      {fn: cmdXRead, arity: 3, blocking: true},
+		"XGROUP":     {fn: cmdXGroup, arity: 1, write: true},
+		"XINFO":      {fn: cmdXInfo, arity: 2},
+		"XREADGROUP": {fn: cmdXReadGroup, arity: 6, write: true},
+		"XAUTOCLAIM": {fn: cmdXAutoClaim, arity: 5, write: true},
+		"XPENDING":   {fn: cmdXPending, arity: 2},
+		"XACK":       {fn: cmdXAck, arity: 3, write: true},
+		"XCLAIM":     {fn: cmdXClaim, arity: 5, write: true},
+		// server
+		"INFO":         {fn: cmdInfo},
+		"CONFIG":       {fn: cmdConfig, arity: 1},
+		"SAVE":         {fn: cmdSave},
+		"BGSAVE":       {fn: cmdBGSave},
+		"BGREWRITEAOF": {fn: cmdBGRewriteAOF},
+		"LASTSAVE":     {fn: func(s *Server, _ *client, _ []string) any { return int(s.lastSave.Unix()) }},
+		"CLUSTER":      {fn: cmdCluster, arity: 1},
+		"COMMAND":      {fn: cmdCommand},
+		"SHUTDOWN":     {fn: cmdDemoRefused},
+		"DEBUG":        {fn: cmdDemoRefused, arity: 1},
+	}
+}
+
+const (
+	errWrongType = replyErr("WRONGTYPE Operation against a key holding the wrong kind of value")
+	errNotInt    = replyErr("ERR value is not an integer or out of range")
+	errSyntax    = replyErr("ERR syntax error")
+	errNoKey     = replyErr("ERR no such key")
+)
+
+func (s *Server) exec(c *client, args []string) any {
+	name := strings.ToUpper(args[0])
+	cmd, ok := commandTable[name]
+	if !ok {
+		return replyErr(fmt.Sprintf("ERR unknown command '%s', with args beginning with: %s", args[0], strings.Join(args[1:], " ")))
+	}
+	if len(args)-1 < cmd.arity {
+		return replyErr(fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(name)))
+	}
+	if cmd.blocking {
+		return cmd.fn(s, c, args)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.last = strings.ToLower(name)
+	s.commands++
+	if !c.authed && name != "AUTH" && name != "QUIT" {
+		return replyErr("NOAUTH Authentication required.")
+	}
+	reply := cmd.fn(s, c, args)
+	if _, failed := reply.(replyErr); cmd.write && !failed {
+		s.dirty++
+	}
+	return reply
+}
+
+// lookup returns the live entry for key, expiring it first if its TTL has
+// passed. touch updates its idle time, as any read or write does in Redis.
+func (s *Server) lookup(c *client, key string, touch bool) *entry {
+	db := s.dbs[c.db]
+	e, ok := db[key]
+	if !ok {
+		return nil
+	}
+	if !e.expire.IsZero() && !time.Now().Before(e.expire) {
+		delete(db, key)
+		return nil
+	}
+	if touch {
+		e.access = time.Now()
+	}
+	return e
+}
+
+// typed returns key's entry if it holds typ (nil if missing), or a WRONGTYPE
+// error reply.
+func (s *Server) typed(c *client, key, typ string) (*entry, any) {
+	e := s.lookup(c, key, true)
+	if e != nil && e.typ != typ {
+		return nil, errWrongType
+	}
+	return e, nil
+}
+
+// create returns key's entry of type typ, creating it when missing.
+func (s *Server) create(c *client, key, typ string) (*entry, any) {
+	e, errReply := s.typed(c, key, typ)
+	if errReply != nil {
+		return nil, errReply
+	}
+	if e == nil {
+		e = newEntry(typ)
+		s.dbs[c.db][key] = e
+	}
+	return e, nil
+}
+
+// dropIfEmpty deletes a collection that lost its last element.
+func (s *Server) dropIfEmpty(c *client, key string, e *entry) {
+	if e.empty() {
+		delete(s.dbs[c.db], key)
+	}
+}
+
+func atoi(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+// ---- connection ----
+
+func cmdPing(_ *Server, _ *client, args []string) any {
+	if len(args) > 1 {
+		return args[1]
+	}
+	return status("PONG")
+}
+
+func cmdSelect(_ *Server, c *client, args []string) any {
+	n, ok := atoi(args[1])
+	if !ok || n < 0 || n >= numDBs {
+		return replyErr("ERR DB index is out of range")
+	}
+	c.db = n
+	return status("OK")
+}
+
+func cmdAuth(s *Server, c *client, args []string) any {
+	if s.Password == "" {
+		return replyErr("ERR AUTH <password> called without any password configured for the default user. Are you sure your configuration is correct?")
+	}
+	if args[len(args)-1] != s.Password {
+		return replyErr("WRONGPASS invalid username-password pair or user is disabled.")
+	}
+	c.authed = true
+	return status("OK")
+}
+
+func cmdClient(s *Server, c *client, args []string) any {
+	switch strings.ToUpper(args[1]) {
+	case "ID":
+		return c.id
+	case "SETNAME":
+		if len(args) != 3 {
+			return errSyntax
+		}
+		if strings.ContainsAny(args[2], " \n") {
+			return replyErr("ERR Client names cannot contain spaces, newlines or special characters.")
+		}
+		c.name = args[2]
+		return status("OK")
+	case "GETNAME":
+		if c.name == "" {
+			return nil
+		}
+		return c.name
+	case "LIST":
+		var b strings.Builder
+		for other := range s.clients {
+			addr := ""
+			if other.conn != nil {
+				addr = other.conn.RemoteAddr().String()
+			}
+			fmt.Fprintf(&b, "id=%d addr=%s name=%s db=%d cmd=%s\n", other.id, addr, other.name, other.db, other.last)
+		}
+		return b.String()
+	}
+	return replyErr("ERR unknown subcommand '" + args[1] + "'")
+}
+
+// ---- keyspace ----
+
+func cmdDBSize(s *Server, c *client, _ []string) any {
+	n := 0
+	for k := range s.dbs[c.db] {
+		if s.lookup(c, k, false) != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func cmdFlushDB(s *Server, c *client, _ []string) any {
+	s.dbs[c.db] = map[string]*entry{}
+	return status("OK")
+}
+
+func cmdFlushAll(s *Server, _ *client, _ []string) any {
+	for i := range s.dbs {
+		s.dbs[i] = map[string]*entry{}
+	}
+	return status("OK")
+}
+
+// liveKeys returns the unexpired keys of c's database in a stable order,
+// which is what makes SCAN cursors meaningful here.
+func (s *Server) liveKeys(c *client) []string {
+	var keys []string
+	for _, k := range sortedKeys(s.dbs[c.db]) {
+		if s.lookup(c, k, false) != nil {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func cmdKeys(s *Server, c *client, args []string) any {
+	out := []any{}
+	for _, k := range s.liveKeys(c) {
+		if redis.GlobMatch(args[1], k) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// scanOpts parses SCAN-family options: MATCH, COUNT and (SCAN only) TYPE.
+func scanOpts(opts []string, allowType bool) (match string, count int, typ string, errReply any) {
+	match, count = "*", 10
+	for i := 0; i < len(opts); i += 2 {
+		if i+1 >= len(opts) {
+			return "", 0, "", errSyntax
+		}
+		switch strings.ToUpper(opts[i]) {
+		case "MATCH":
+			match = opts[i+1]
+		case "COUNT":
+			n, ok := atoi(opts[i+1])
+			if !ok || n < 1 {
+				return "", 0, "", errNotInt
+			}
+			count = n
+		case "TYPE":
+			if !allowType {
+				return "", 0, "", errSyntax
+			}
+			typ = strings.ToLower(opts[i+1])
+		default:
+			return "", 0, "", errSyntax
+		}
+	}
+	return match, count, typ, nil
+}
+
+// scanPage walks items from cursor, examining up to count of them, and
+// returns the next cursor ("0" when done) with the matches.
+func scanPage(items []string, cursor string, count int, keep func(string) bool) (string, []string) {
+	start, ok := atoi(cursor)
+	if !ok || start < 0 {
+		start = 0
+	}
+	var out []string
+	i := start
+	for ; i < len(items) && i < start+count; i++ {
+		if keep(items[i]) {
+			out = append(out, items[i])
+		}
+	}
+	if i >= len(items) {
+		return "0", out
+	}
+	return strconv.Itoa(i), out
+}
+
+func strs(items []string) []any {
+	out := make([]any, len(items))
+	for i, s := range items {
+		out[i] = s
+	}
+	return out
+}
+
+func cmdScan(s *Server, c *client, args []string) any {
+	if _, ok := atoi(args[1]); !ok {
+		return replyErr("ERR invalid cursor")
+	}
+	match, count, typ, errReply := scanOpts(args[2:], true)
+	if errReply != nil {
+		return errReply
+	}
+	next, keys := scanPage(s.liveKeys(c), args[1], count, func(k string) bool {
+		return redis.GlobMatch(match, k) && (typ == "" || s.dbs[c.db][k].typ == typ)
+	})
+	return []any{next, strs(keys)}
+}
+
+func cmdType(s *Server, c *client, args []string) any {
+	if e := s.lookup(c, args[1], false); e != nil {
+		return status(e.typ)
+	}
+	return status("none")
+}
+
+func cmdExists(s *Server, c *client, args []string) any {
+	n := 0
+	for _, k := range args[1:] {
+		if s.lookup(c, k, false) != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func cmdDel(s *Server, c *client, args []string) any {
+	n := 0
+	for _, k := range args[1:] {
+		if s.lookup(c, k, false) != nil {
+			delete(s.dbs[c.db], k)
+			n++
+		}
+	}
+	return n
+}
+
+func cmdRename(s *Server, c *client, args []string) any {
+	e := s.lookup(c, args[1], false)
+	if e == nil {
+		return errNoKey
+	}
+	delete(s.dbs[c.db], args[1])
+	s.dbs[c.db][args[2]] = e
+	return status("OK")
+}
+
+func cmdTTL(s *Server, c *client, args []string) any {
+	e := s.lookup(c, args[1], false)
+	switch {
+	case e == nil:
+		return -2
+	case e.expire.IsZero():
+		return -1
+	}
+	left := time.Until(e.expire)
+	if strings.EqualFold(args[0], "PTTL") {
+		return int(left.Milliseconds())
+	}
+	return int((left + 500*time.Millisecond) / time.Second)
+}
+
+func cmdExpire(s *Server, c *client, args []string) any {
+	n, ok := atoi(args[2])
+	if !ok {
+		return errNotInt
+	}
+	e := s.lookup(c, args[1], false)
+	if e == nil {
+		return 0
+	}
+	unit := time.Second
+	if strings.EqualFold(args[0], "PEXPIRE") {
+		unit = time.Millisecond
+	}
+	if n <= 0 {
+		delete(s.dbs[c.db], args[1])
+		return 1
+	}
+	e.expire = time.Now().Add(time.Duration(n) * unit)
+	return 1
+}
+
+func cmdPersist(s *Server, c *client, args []string) any {
+	e := s.lookup(c, args[1], false)
+	if e == nil || e.expire.IsZero() {
+		return 0
+	}
+	e.expire = time.Time{}
+	return 1
+}
+
+func cmdObject(s *Server, c *client, args []string) any {
+	if len(args) < 3 {
+		return replyErr("ERR wrong number of arguments for 'object' command")
+	}
+	e := s.lookup(c, args[2], false)
+	if e == nil {
+		return nil
+	}
+	switch strings.ToUpper(args[1]) {
+	case "IDLETIME":
+		return int(time.Since(e.access) / time.Second)
+	case "ENCODING":
+		return e.encoding()
+	case "REFCOUNT":
+		return 1
+	}
+	return replyErr("ERR unknown subcommand '" + args[1] + "'")
+}
+
+// dumpPrefix marks the mock's own DUMP payloads. Real RDB payloads can't be
+// restored here, and these can't be restored on a real server — the same
+// "version or checksum" refusal either way.
+const dumpPrefix = "redis-tui-mock:"
+
+type dumpPayload struct {
+	Type   string             `json:"type"`
+	Str    string             `json:"str,omitempty"`
+	List   []string           `json:"list,omitempty"`
+	Hash   map[string]string  `json:"hash,omitempty"`
+	Set    []string           `json:"set,omitempty"`
+	ZSet   map[string]float64 `json:"zset,omitempty"`
+	Stream [][]string         `json:"stream,omitempty"` // id, then field/value pairs
+}
+
+func cmdDump(s *Server, c *client, args []string) any {
+	e := s.lookup(c, args[1], false)
+	if e == nil {
+		return nil
+	}
+	p := dumpPayload{Type: e.typ, Str: e.str, List: e.list, Hash: e.hash, Set: sortedKeys(e.set), ZSet: e.zset}
+	for _, se := range e.stream {
+		p.Stream = append(p.Stream, append([]string{se.id.String()}, se.fields...))
+	}
+	data, _ := json.Marshal(p)
+	return dumpPrefix + string(data)
+}
+
+func cmdRestore(s *Server, c *client, args []string) any {
+	key := args[1]
+	ttl, ok := atoi(args[2])
+	if !ok || ttl < 0 {
+		return replyErr("ERR Invalid TTL value, must be >= 0")
+	}
+	replace := false
+	for _, opt := range args[4:] {
+		if !strings.EqualFold(opt, "REPLACE") {
+			return errSyntax
+		}
+		replace = true
+	}
+	var p dumpPayload
+	payload, found := strings.CutPrefix(args[3], dumpPrefix)
+	if !found || json.Unmarshal([]byte(payload), &p) != nil {
+		return replyErr("ERR DUMP payload version or checksum are wrong")
+	}
+	if s.lookup(c, key, false) != nil && !replace {
+		return replyErr("BUSYKEY Target key name already exists.")
+	}
+	e := newEntry(p.Type)
+	e.str, e.list = p.Str, p.List
+	for k, v := range p.Hash {
+		e.hash[k] = v
+	}
+	for _, m := range p.Set {
+		e.set[m] = struct{}{}
+	}
+	for m, sc := range p.ZSet {
+		e.zset[m] = sc
+	}
+	for _, rec := range p.Stream {
+		id, _ := parseStreamID(rec[0], 0)
+		e.stream = append(e.stream, streamEntry{id: id, fields: rec[1:]})
+		e.lastID = id
+	}
+	if ttl > 0 {
+		e.expire = time.Now().Add(time.Duration(ttl) * time.Millisecond)
+	}
+	s.dbs[c.db][key] = e
+	return status("OK")
+}
+
+// ---- strings ----
+
+func cmdGet(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "string")
+	if errReply != nil {
+		return errReply
+	}
+	if e == nil {
+		return nil
+	}
+	return e.str
+}
+
+func cmdSet(s *Server, c *client, args []string) any {
+	key, val := args[1], args[2]
+	var expire time.Time
+	nx, xx, keepTTL, get := false, false, false, false
+	for i := 3; i < len(args); i++ {
+		switch opt := strings.ToUpper(args[i]); opt {
+		case "GET":
+			get = true
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "KEEPTTL":
+			keepTTL = true
+		case "EX", "PX":
+			if i+1 >= len(args) {
+				return errSyntax
+			}
+			n, ok := atoi(args[i+1])
+			if !ok || n <= 0 {
+				return replyErr("ERR invalid expire time in 'set' command")
+			}
+			unit := time.Second
+			if opt == "PX" {
+				unit = time.Millisecond
+			}
+			expire = time.Now().Add(time.Duration(n) * unit)
+			i++
+		default:
+			return errSyntax
+		}
+	}
+	old := s.lookup(c, key, false)
+	var reply any = status("OK")
+	if get {
+		if old != nil && old.typ != "string" {
+			return errWrongType
+		}
+		reply = nil
+		if old != nil {
+			reply = old.str
+		}
+	}
+	if nx && old != nil || xx && old == nil {
+		if get {
+			return reply
+		}
+		return nil
+	}
+	e := newEntry("string")
+	e.str, e.expire = val, expire
+	if keepTTL && old != nil {
+		e.expire = old.expire
+	}
+	s.dbs[c.db][key] = e
+	return reply
+}
+
+func cmdMGet(s *Server, c *client, args []string) any {
+	out := make([]any, 0, len(args)-1)
+	for _, k := range args[1:] {
+		if e := s.lookup(c, k, true); e != nil && e.typ == "string" {
+			out = append(out, e.str)
+		} else {
+			out = append(out, nil)
+		}
+	}
+	return out
+}
+
+func cmdIncrBy(s *Server, c *client, args []string) any {
+	by := 1
+	if len(args) > 2 {
+		n, ok := atoi(args[2])
+		if !ok {
+			return errNotInt
+		}
+		by = n
+	}
+	if name := strings.ToUpper(args[0]); strings.HasPrefix(name, "DECR") {
+		by = -by
+	}
+	e, errReply := s.create(c, args[1], "string")
+	if errReply != nil {
+		return errReply
+	}
+	cur := 0
+	if e.str != "" {
+		n, ok := atoi(e.str)
+		if !ok {
+			return errNotInt
+		}
+		cur = n
+	}
+	cur += by
+	e.str = strconv.Itoa(cur)
+	return cur
+}
+
+func cmdAppend(s *Server, c *client, args []string) any {
+	e, errReply := s.create(c, args[1], "string")
+	if errReply != nil {
+		return errReply
+	}
+	e.str += args[2]
+	return len(e.str)
+}
+
+func cmdStrlen(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "string")
+	if errReply != nil {
+		return errReply
+	}
+	if e == nil {
+		return 0
+	}
+	return len(e.str)
+}
+
+// ---- hashes ----
+
+func cmdHSet(s *Server, c *client, args []string) any {
+	if len(args)%2 != 0 {
+		return replyErr("ERR wrong number of arguments for 'hset' command")
+	}
+	e, errReply := s.create(c, args[1], "hash")
+	if errReply != nil {
+		return errReply
+	}
+	added := 0
+	for i := 2; i < len(args); i += 2 {
+		if _, ok := e.hash[args[i]]; !ok {
+			added++
+		}
+		e.hash[args[i]] = args[i+1]
+	}
+	return added
+}
+
+func cmdHGet(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "hash")
+	if errReply != nil {
+		return errReply
+	}
+	if e == nil {
+		return nil
+	}
+	if v, ok := e.hash[args[2]]; ok {
+		return v
+	}
+	return nil
+}
+
+func cmdHDel(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "hash")
+	if e == nil {
+		if errReply != nil {
+			return errReply
+		}
+		return 0
+	}
+	n := 0
+	for _, f := range args[2:] {
+		if _, ok := e.hash[f]; ok {
+			delete(e.hash, f)
+			n++
+		}
+	}
+	s.dropIfEmpty(c, args[1], e)
+	return n
+}
+
+func (s *Server) hash(c *client, key string) (map[string]string, any) {
+	e, errReply := s.typed(c, key, "hash")
+	if errReply != nil {
+		return nil, errReply
+	}
+	if e == nil {
+		return map[string]string{}, nil
+	}
+	return e.hash, nil
+}
+
+func cmdHKeys(s *Server, c *client, args []string) any {
+	h, errReply := s.hash(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	return strs(sortedKeys(h))
+}
+
+func cmdHVals(s *Server, c *client, args []string) any {
+	h, errReply := s.hash(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	out := []any{}
+	for _, k := range sortedKeys(h) {
+		out = append(out, h[k])
+	}
+	return out
+}
+
+func cmdHGetAll(s *Server, c *client, args []string) any {
+	h, errReply := s.hash(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	out := []any{}
+	for _, k := range sortedKeys(h) {
+		out = append(out, k, h[k])
+	}
+	return out
+}
+
+func cmdHLen(s *Server, c *client, args []string) any {
+	h, errReply := s.hash(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	return len(h)
+}
+
+func cmdHExists(s *Server, c *client, args []string) any {
+	h, errReply := s.hash(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	if _, ok := h[args[2]]; ok {
+		return 1
+	}
+	return 0
+}
+
+func cmdHScan(s *Server, c *client, args []string) any {
+	h, errReply := s.hash(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	opts := args[3:]
+	noValues := len(opts)%2 == 1 && strings.EqualFold(opts[len(opts)-1], "NOVALUES")
+	if noValues {
+		opts = opts[:len(opts)-1]
+	}
+	match, count, _, errReply := scanOpts(opts, false)
+	if errReply != nil {
+		return errReply
+	}
+	next, fields := scanPage(sortedKeys(h), args[2], count, func(f string) bool { return redis.GlobMatch(match, f) })
+	out := []any{}
+	for _, f := range fields {
+		if noValues {
+			out = append(out, f)
+		} else {
+			out = append(out, f, h[f])
+		}
+	}
+	return []any{next, out}
+}
+
+// ---- lists ----
+
+func cmdPush(s *Server, c *client, args []string) any {
+	e, errReply := s.create(c, args[1], "list")
+	if errReply != nil {
+		return errReply
+	}
+	left := strings.EqualFold(args[0], "LPUSH")
+	for _, v := range args[2:] {
+		if left {
+			e.list = append([]string{v}, e.list...)
+		} else {
+			e.list = append(e.list, v)
+		}
+	}
+	return len(e.list)
+}
+
+// popOne removes one element from the head (left) or tail of a list.
+func (s *Server) popOne(c *client, key string, e *entry, left bool) string {
+	var v string
+	if left {
+		v, e.list = e.list[0], e.list[1:]
+	} else {
+		v, e.list = e.list[len(e.list)-1], e.list[:len(e.list)-1]
+	}
+	s.dropIfEmpty(c, key, e)
+	return v
+}
+
+func cmdPop(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "list")
+	if errReply != nil {
+		return errReply
+	}
+	left := strings.EqualFold(args[0], "LPOP")
+	if len(args) == 2 {
+		if e == nil {
+			return nil
+		}
+		return s.popOne(c, args[1], e, left)
+	}
+	n, ok := atoi(args[2])
+	if !ok || n < 0 {
+		return replyErr("ERR value is out of range, must be positive")
+	}
+	if e == nil {
+		return nilArray{}
+	}
+	out := []any{}
+	for ; n > 0 && s.lookup(c, args[1], false) != nil; n-- {
+		out = append(out, s.popOne(c, args[1], e, left))
+	}
+	return out
+}
+
+func (s *Server) list(c *client, key string) ([]string, any) {
+	e, errReply := s.typed(c, key, "list")
+	if errReply != nil || e == nil {
+		return nil, errReply
+	}
+	return e.list, nil
+}
+
+func cmdLRange(s *Server, c *client, args []string) any {
+	l, errReply := s.list(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	start, ok1 := atoi(args[2])
+	stop, ok2 := atoi(args[3])
+	if !ok1 || !ok2 {
+		return errNotInt
+	}
+	lo, hi := normalizeRange(start, stop, len(l))
+	return strs(l[lo:hi])
+}
+
+func cmdLLen(s *Server, c *client, args []string) any {
+	l, errReply := s.list(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	return len(l)
+}
+
+func cmdLIndex(s *Server, c *client, args []string) any {
+	l, errReply := s.list(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	i, ok := atoi(args[2])
+	if !ok {
+		return errNotInt
+	}
+	if i < 0 {
+		i += len(l)
+	}
+	if i < 0 || i >= len(l) {
+		return nil
+	}
+	return l[i]
+}
+
+func cmdLSet(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "list")
+	if errReply != nil {
+		return errReply
+	}
+	if e == nil {
+		return errNoKey
+	}
+	i, ok := atoi(args[2])
+	if !ok {
+		return errNotInt
+	}
+	if i < 0 {
+		i += len(e.list)
+	}
+	if i < 0 || i >= len(e.list) {
+		return replyErr("ERR index out of range")
+	}
+	e.list[i] = args[3]
+	return status("OK")
+}
+
+func cmdLRem(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "list")
+	if errReply != nil {
+		return errReply
+	}
+	count, ok := atoi(args[2])
+	if !ok {
+		return errNotInt
+	}
+	if e == nil {
+		return 0
+	}
+	removed := 0
+	keep := make([]string, 0, len(e.list))
+	if count >= 0 {
+		for _, v := range e.list {
+			if v == args[3] && (count == 0 || removed < count) {
+				removed++
+				continue
+			}
+			keep = append(keep, v)
+		}
+	} else {
+		// Negative counts remove from the tail.
+		for i := len(e.list) - 1; i >= 0; i-- {
+			if v := e.list[i]; v == args[3] && removed < -count {
+				removed++
+				continue
+			}
+			keep = append([]string{e.list[i]}, keep...)
+		}
+	}
+	e.list = keep
+	s.dropIfEmpty(c, args[1], e)
+	return removed
+}
+
+// cmdBlockingPop polls its keys until one has an element, the timeout runs
+// out, or the server closes.
+func cmdBlockingPop(s *Server, c *client, args []string) any {
+	secs, err := strconv.ParseFloat(args[len(args)-1], 64)
+	if err != nil || secs < 0 || math.IsNaN(secs) {
+		return replyErr("ERR timeout is not a float or out of range")
+	}
+	keys := args[1 : len(args)-1]
+	left := strings.EqualFold(args[0], "BLPOP")
+	var deadline <-chan time.Time
+	if secs > 0 {
+		deadline = time.After(time.Duration(secs * float64(time.Second)))
+	}
+	for {
+		s.mu.Lock()
+		s.commands++
+		if !c.authed {
+			s.mu.Unlock()
+			return replyErr("NOAUTH Authentication required.")
+		}
+		for _, k := range keys {
+			e, errReply := s.typed(c, k, "list")
+			if errReply != nil {
+				s.mu.Unlock()
+				return errReply
+			}
+			if e != nil {
+				v := s.popOne(c, k, e, left)
+				s.dirty++
+				s.mu.Unlock()
+				return []any{k, v}
+			}
+		}
+		s.mu.Unlock()
+		select {
+		case <-deadline:
+			return nilArray{}
+		case <-s.done:
+			return nilArray{}
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+}
+
+// ---- sets ----
+
+func cmdSAdd(s *Server, c *client, args []string) any {
+	e, errReply := s.create(c, args[1], "set")
+	if errReply != nil {
+		return errReply
+	}
+	n := 0
+	for _, m := range args[2:] {
+		if _, ok := e.set[m]; !ok {
+			e.set[m] = struct{}{}
+			n++
+		}
+	}
+	return n
+}
+
+func cmdSRem(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "set")
+	if e == nil {
+		if errReply != nil {
+			return errReply
+		}
+		return 0
+	}
+	n := 0
+	for _, m := range args[2:] {
+		if _, ok := e.set[m]; ok {
+			delete(e.set, m)
+			n++
+		}
+	}
+	s.dropIfEmpty(c, args[1], e)
+	return n
+}
+
+func (s *Server) members(c *client, key string) ([]string, any) {
+	e, errReply := s.typed(c, key, "set")
+	if errReply != nil || e == nil {
+		return nil, errReply
+	}
+	return sortedKeys(e.set), nil
+}
+
+func cmdSMembers(s *Server, c *client, args []string) any {
+	m, errReply := s.members(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	return strs(m)
+}
+
+func cmdSCard(s *Server, c *client, args []string) any {
+	m, errReply := s.members(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	return len(m)
+}
+
+func cmdSIsMember(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "set")
+	if errReply != nil {
+		return errReply
+	}
+	if e != nil {
+		if _, ok := e.set[args[2]]; ok {
+			return 1
+		}
+	}
+	return 0
+}
+
+func cmdSScan(s *Server, c *client, args []string) any {
+	m, errReply := s.members(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	match, count, _, errReply := scanOpts(args[3:], false)
+	if errReply != nil {
+		return errReply
+	}
+	next, page := scanPage(m, args[2], count, func(v string) bool { return redis.GlobMatch(match, v) })
+	return []any{next, strs(page)}
+}
+
+// ---- sorted sets ----
+
+func cmdZAdd(s *Server, c *client, args []string) any {
+	if len(args)%2 != 0 {
+		return errSyntax
+	}
+	scores := make([]float64, 0, (len(args)-2)/2)
+	for i := 2; i < len(args); i += 2 {
+		f, err := strconv.ParseFloat(args[i], 64)
+		if err != nil || math.IsNaN(f) {
+			return replyErr("ERR value is not a valid float")
+		}
+		scores = append(scores, f)
+	}
+	e, errReply := s.create(c, args[1], "zset")
+	if errReply != nil {
+		return errReply
+	}
+	added := 0
+	for i, sc := range scores {
+		m := args[3+2*i]
+		if _, ok := e.zset[m]; !ok {
+			added++
+		}
+		e.zset[m] = sc
+	}
+	return added
+}
+
+// cmdPublish accepts a message; the mock has no subscribers to receive it.
+func cmdPublish(s *Server, c *client, args []string) any { return 0 }
+
+func cmdZIncrBy(s *Server, c *client, args []string) any {
+	incr, err := strconv.ParseFloat(args[2], 64)
+	if err != nil || math.IsNaN(incr) {
+		return replyErr("ERR value is not a valid float")
+	}
+	e, errReply := s.create(c, args[1], "zset")
+	if errReply != nil {
+		return errReply
+	}
+	score := e.zset[args[3]] + incr
+	if math.IsNaN(score) {
+		return replyErr("ERR resulting score is not a number (NaN)")
+	}
+	e.zset[args[3]] = score
+	return formatScore(score)
+}
+
+func cmdZRem(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "zset")
+	if e == nil {
+		if errReply != nil {
+			return errReply
+		}
+		return 0
+	}
+	n := 0
+	for _, m := range args[2:] {
+		if _, ok := e.zset[m]; ok {
+			delete(e.zset, m)
+			n++
+		}
+	}
+	s.dropIfEmpty(c, args[1], e)
+	return n
+}
+
+func cmdZRange(s *Server, c *client, args []string) any { return zrange(s, c, args, false) }
+
+func cmdZRevRange(s *Server, c *client, args []string) any { return zrange(s, c, args, true) }
+
+// zrange serves ZRANGE and ZREVRANGE by rank; rev counts ranks from the
+// highest score.
+func zrange(s *Server, c *client, args []string, rev bool) any {
+	e, errReply := s.typed(c, args[1], "zset")
+	if errReply != nil {
+		return errReply
+	}
+	start, ok1 := atoi(args[2])
+	stop, ok2 := atoi(args[3])
+	if !ok1 || !ok2 {
+		return errNotInt
+	}
+	withScores := false
+	for _, opt := range args[4:] {
+		if !strings.EqualFold(opt, "WITHSCORES") {
+			return errSyntax
+		}
+		withScores = true
+	}
+	out := []any{}
+	if e == nil {
+		return out
+	}
+	sorted := e.sortedZSet()
+	if rev {
+		slices.Reverse(sorted)
+	}
+	lo, hi := normalizeRange(start, stop, len(sorted))
+	for _, zm := range sorted[lo:hi] {
+		out = append(out, zm.member)
+		if withScores {
+			out = append(out, formatScore(zm.score))
+		}
+	}
+	return out
+}
+
+func cmdZScore(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "zset")
+	if errReply != nil {
+		return errReply
+	}
+	if e != nil {
+		if sc, ok := e.zset[args[2]]; ok {
+			return formatScore(sc)
+		}
+	}
+	return nil
+}
+
+func cmdZCard(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "zset")
+	if errReply != nil {
+		return errReply
+	}
+	if e == nil {
+		return 0
+	}
+	return len(e.zset)
+}
+
+// ---- streams ----
+
+func cmdXAdd(s *Server, c *client, args []string) any {
+	if len(args)%2 != 1 {
+		return replyErr("ERR wrong number of arguments for 'xadd' command")
+	}
+	e, errReply := s.typed(c, args[1], "stream")
+	if errReply != nil {
+		return errReply
+	}
+	var last streamID
+	if e != nil {
+		last = e.lastID
+	}
+	var id streamID
+	if args[2] == "*" {
+		id = streamID{ms: uint64(time.Now().UnixMilli())}
+		if id.ms <= last.ms {
+			id = streamID{last.ms, last.seq + 1}
+		}
+	} else {
+		var ok bool
+		if id, ok = parseStreamID(args[2], 0); !ok {
+			return replyErr("ERR Invalid stream ID specified as stream command argument")
+		}
+		if !last.less(id) {
+			return replyErr("ERR The ID specified in XADD is equal or smaller than the target stream top item")
+		}
+	}
+	if e == nil {
+		e = newEntry("stream")
+		s.dbs[c.db][args[1]] = e
+	}
+	e.stream = append(e.stream, streamEntry{id: id, fields: append([]string(nil), args[3:]...)})
+	e.lastID = id
+	return id.String()
+}
+
+func cmdXLen(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "stream")
+	if errReply != nil {
+		return errReply
+	}
+	if e == nil {
+		return 0
+	}
+	return len(e.stream)
+}
+
+func cmdXRange(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "stream")
+	if errReply != nil {
+		return errReply
+	}
+	bound := func(v string, lowest bool) (streamID, bool) {
+		switch v {
+		case "-":
+			return streamID{}, true
+		case "+":
+			return streamID{math.MaxUint64, math.MaxUint64}, true
+		}
+		if lowest {
+			return parseStreamID(v, 0)
+		}
+		return parseStreamID(v, math.MaxUint64)
+	}
+	// XREVRANGE takes the bounds end first and replies newest first.
+	rev := strings.EqualFold(args[0], "XREVRANGE")
+	lo, hi := args[2], args[3]
+	if rev {
+		lo, hi = hi, lo
+	}
+	from, ok1 := bound(lo, true)
+	to, ok2 := bound(hi, false)
+	if !ok1 || !ok2 {
+		return replyErr("ERR Invalid stream ID specified as stream command argument")
+	}
+	count := -1
+	if len(args) > 4 {
+		if len(args) != 6 || !strings.EqualFold(args[4], "COUNT") {
+			return errSyntax
+		}
+		n, ok := atoi(args[5])
+		if !ok {
+			return errNotInt
+		}
+		count = n
+	}
+	out := []any{}
+	if e == nil {
+		return out
+	}
+	for i := range e.stream {
+		if count >= 0 && len(out) >= count {
+			break
+		}
+		se := e.stream[i]
+		if rev {
+			se = e.stream[len(e.stream)-1-i]
+		}
+		if se.id.less(from) || to.less(se.id) {
+			continue
+		}
+		out = append(out, []any{se.id.String(), strs(se.fields)})
+	}
+	return out
+}
+
+// cmdXRead serves XREAD [COUNT n] [BLOCK ms] STREAMS key … id …, polling
+// like the blocking pops until one of the streams has an entry after its id.
+// An id of $ stands for the stream's last entry when the call was made.
+func cmdXRead(s *Server, c *client, args []string) any {
+	count, block := -1, -1
+	i := 1
+	for ; i < len(args) && !strings.EqualFold(args[i], "STREAMS"); i += 2 {
+		if i+1 >= len(args) {
+			return errSyntax
+		}
+		n, ok := atoi(args[i+1])
+		switch {
+		case !ok || n < 0:
+			return errNotInt
+		case strings.EqualFold(args[i], "COUNT"):
+			count = n
+		case strings.EqualFold(args[i], "BLOCK"):
+			block = n
+		default:
+			return errSyntax
+		}
+	}
+	streams := args[min(i+1, len(args)):]
+	if len(streams) == 0 || len(streams)%2 != 0 {
+		return replyErr("ERR Unbalanced 'xread' list of streams: for each stream key an ID or '$' must be specified.")
+	}
+	keys, ids := streams[:len(streams)/2], streams[len(streams)/2:]
+	after := make([]streamID, len(keys))
+	var deadline <-chan time.Time
+	if block > 0 {
+		deadline = time.After(time.Duration(block) * time.Millisecond)
+	}
+	for first := true; ; first = false {
+		s.mu.Lock()
+		s.commands++
+		if !c.authed {
+			s.mu.Unlock()
+			return replyErr("NOAUTH Authentication required.")
+		}
+		var out []any
+		for k, key := range keys {
+			e, errReply := s.typed(c, key, "stream")
+			if errReply != nil {
+				s.mu.Unlock()
+				return errReply
+			}
+			if first {
+				if ids[k] == "$" {
+					if e != nil {
+						after[k] = e.lastID
+					}
+				} else if id, ok := parseStreamID(ids[k], 0); ok {
+					after[k] = id
+				} else {
+					s.mu.Unlock()
+					return replyErr("ERR Invalid stream ID specified as stream command argument")
+				}
+			}
+			if e == nil {
+				continue
+			}
+			var entries []any
+			for _, se := range e.stream {
+				if count > 0 && len(entries) >= count {
+					break
+				}
+				if after[k].less(se.id) {
+					entries = append(entries, []any{se.id.String(), strs(se.fields)})
+				}
+			}
+			if len(entries) > 0 {
+				out = append(out, []any{key, entries})
+			}
+		}
+		s.mu.Unlock()
+		if len(out) > 0 {
+			return out
+		}
+		if block < 0 {
+			return nilArray{}
+		}
+		select {
+		case <-deadline:
+			return nilArray{}
+		case <-s.done:
+			return nilArray{}
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+}
+
+// ---- server ----
+
+func cmdInfo(s *Server, c *client, args []string) any {
+	want := "default"
+	if len(args) > 1 {
+		want = strings.ToLower(args[1])
+	}
+	var b strings.Builder
+	for _, sec := range s.infoSections() {
+		if want != "default" && want != "all" && want != "everything" && want != sec.name {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString("# " + strings.ToUpper(sec.name[:1]) + sec.name[1:] + "\r\n")
+		for _, kv := range sec.fields {
+			b.WriteString(kv[0] + ":" + kv[1] + "\r\n")
+		}
+	}
+	return b.String()
+}
+
+type infoSection struct {
+	name   string
+	fields [][2]string
+}
+
+func (s *Server) infoSections() []infoSection {
+	port := "0"
+	if s.listener != nil {
+		if _, p, err := net.SplitHostPort(s.listener.Addr().String()); err == nil {
+			port = p
+		}
+	}
+	var keyspace [][2]string
+	for i := range s.dbs {
+		keys, expires := 0, 0
+		for k, e := range s.dbs[i] {
+			if s.lookup(&client{db: i}, k, false) == nil {
+				continue
+			}
+			keys++
+			if !e.expire.IsZero() {
+				expires++
+			}
+		}
+		if keys > 0 {
+			keyspace = append(keyspace, [2]string{fmt.Sprintf("db%d", i), fmt.Sprintf("keys=%d,expires=%d,avg_ttl=0", keys, expires)})
+		}
+	}
+	itoa := strconv.Itoa
+	return []infoSection{
+		{"server", [][2]string{
+			{"redis_version", "7.2.0"},
+			{"redis_mode", "standalone"},
+			{"os", "redis-tui demo server"},
+			{"tcp_port", port},
+			{"uptime_in_seconds", itoa(int(time.Since(s.started).Seconds()))},
+		}},
+		{"clients", [][2]string{{"connected_clients", itoa(len(s.clients))}}},
+		{"memory", [][2]string{{"used_memory", "1048576"}, {"used_memory_human", "1.00M"}, {"maxmemory_policy", "noeviction"}}},
+		{"persistence", [][2]string{
+			{"loading", "0"},
+			{"rdb_changes_since_last_save", itoa(s.dirty)},
+			{"rdb_bgsave_in_progress", "0"},
+			{"rdb_last_save_time", itoa(int(s.lastSave.Unix()))},
+			{"rdb_last_bgsave_status", "ok"},
+			{"rdb_last_bgsave_time_sec", "0"},
+			{"aof_enabled", "0"},
+			{"aof_rewrite_in_progress", "0"},
+			{"aof_last_rewrite_time_sec", "-1"},
+			{"aof_last_bgrewrite_status", "ok"},
+		}},
+		{"stats", [][2]string{{"total_commands_processed", itoa(s.commands)}, {"instantaneous_ops_per_sec", "0"}}},
+		{"replication", [][2]string{{"role", "master"}, {"connected_slaves", "0"}}},
+		{"cluster", [][2]string{{"cluster_enabled", "0"}}},
+		{"keyspace", keyspace},
+	}
+}
+
+func cmdConfig(_ *Server, _ *client, args []string) any {
+	if strings.EqualFold(args[1], "GET") {
+		return []any{}
+	}
+	return replyErr("ERR CONFIG " + strings.ToUpper(args[1]) + " is not supported by the demo server")
+}
+
+// Saves finish instantly: there is nothing to write.
+func cmdSave(s *Server, _ *client, _ []string) any {
+	s.dirty, s.lastSave = 0, time.Now()
+	return status("OK")
+}
+
+func cmdBGSave(s *Server, c *client, args []string) any {
+	cmdSave(s, c, args)
+	return status("Background saving started")
+}
+
+func cmdBGRewriteAOF(*Server, *client, []string) any {
+	return status("Background append only file rewriting started")
+}
+
+func cmdCluster(*Server, *client, []string) any {
+	return replyErr("ERR This instance has cluster support disabled")
+}
+
+// cmdCommand answers COMMAND, COMMAND INFO, COMMAND COUNT and COMMAND LIST
+// from the table above, so clients discovering what the server implements
+// see exactly what the demo server handles.
+func cmdCommand(_ *Server, _ *client, args []string) any {
+	if len(args) == 1 {
+		names := make([]string, 0, len(commandTable))
+		for name := range commandTable {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out := []any{}
+		for _, name := range names {
+			out = append(out, commandInfo(name))
+		}
+		return out
+	}
+	switch strings.ToUpper(args[1]) {
+	case "INFO":
+		out := []any{}
+		for _, name := range args[2:] {
+			out = append(out, commandInfo(strings.ToUpper(name)))
+		}
+		return out
+	case "COUNT":
+		return len(commandTable)
+	case "LIST":
+		out := []any{}
+		for name := range commandTable {
+			out = append(out, strings.ToLower(name))
+		}
+		return out
+	}
+	return replyErr("ERR unknown subcommand '" + args[1] + "'")
+}
+
+// commandInfo is one COMMAND INFO entry: name, arity (counting the name,
+// negative for "at least"), flags, and first key, last key and step, which
+// the demo server doesn't track. Unknown commands are a null.
+func commandInfo(name string) any {
+	cmd, ok := commandTable[name]
+	if !ok {
+		return nilArray{}
+	}
+	flags := []any{}
+	if cmd.write {
+		flags = append(flags, status("write"))
+	}
+	return []any{strings.ToLower(name), -(cmd.arity + 1), flags, 0, 0, 0}
+}
+
+func cmdDemoRefused(_ *Server, _ *client, args []string) any {
+	return replyErr("ERR " + strings.ToUpper(args[0]) + " is not supported by the demo server")
+}
+
+// randomPicks reads the count of HRANDFIELD, SRANDMEMBER and ZRANDMEMBER
+// and picks that many distinct indexes below n. Negative counts, which
+// allow repeats, aren't modelled.
+func randomPicks(count string, n int) ([]int, any) {
+	k, ok := atoi(count)
+	if !ok || k < 0 {
+		return nil, errNotInt
+	}
+	return rand.Perm(n)[:min(k, n)], nil
+}
+
+// withOption reports whether the reply option opt (WITHVALUES, WITHSCORES)
+// follows the count; anything else there is a syntax error.
+func withOption(args []string, opt string) (bool, any) {
+	switch {
+	case len(args) == 3:
+		return false, nil
+	case len(args) == 4 && strings.EqualFold(args[3], opt):
+		return true, nil
+	}
+	return false, errSyntax
+}
+
+func cmdHRandField(s *Server, c *client, args []string) any {
+	h, errReply := s.hash(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	withValues, errReply := withOption(args, "WITHVALUES")
+	if errReply != nil {
+		return errReply
+	}
+	fields := sortedKeys(h)
+	picks, errReply := randomPicks(args[2], len(fields))
+	if errReply != nil {
+		return errReply
+	}
+	out := []any{}
+	for _, i := range picks {
+		out = append(out, fields[i])
+		if withValues {
+			out = append(out, h[fields[i]])
+		}
+	}
+	return out
+}
+
+func cmdSRandMember(s *Server, c *client, args []string) any {
+	m, errReply := s.members(c, args[1])
+	if errReply != nil {
+		return errReply
+	}
+	if len(args) > 3 {
+		return errSyntax
+	}
+	picks, errReply := randomPicks(args[2], len(m))
+	if errReply != nil {
+		return errReply
+	}
+	out := []any{}
+	for _, i := range picks {
+		out = append(out, m[i])
+	}
+	return out
+}
+
+func cmdZRandMember(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "zset")
+	if errReply != nil {
+		return errReply
+	}
+	withScores, errReply := withOption(args, "WITHSCORES")
+	if errReply != nil {
+		return errReply
+	}
+	var sorted []zsetMember
+	if e != nil {
+		sorted = e.sortedZSet()
+	}
+	picks, errReply := randomPicks(args[2], len(sorted))
+	if errReply != nil {
+		return errReply
+	}
+	out := []any{}
+	for _, i := range picks {
+		out = append(out, sorted[i].member)
+		if withScores {
+			out = append(out, formatScore(sorted[i].score))
+		}
+	}
+	return out
+}
+
+// ---- consumer groups ----
+
+func errNoGroup(key, group string) replyErr {
+	return replyErr(fmt.Sprintf("NOGROUP No such key '%s' or consumer group '%s'", key, group))
+}
+
+// group returns key's stream entry and its group, or the NOGROUP error.
+func (s *Server) group(c *client, key, name string) (*entry, *streamGroup, any) {
+	e, errReply := s.typed(c, key, "stream")
+	if errReply != nil {
+		return nil, nil, errReply
+	}
+	if e == nil || e.groups[name] == nil {
+		return nil, nil, errNoGroup(key, name)
+	}
+	return e, e.groups[name], nil
+}
+
+// groupStart parses the ID a group starts after: an ID, 0, or $ for the
+// stream's last entry.
+func groupStart(e *entry, s string) (streamID, bool) {
+	if s == "$" {
+		if e == nil {
+			return streamID{}, true
+		}
+		return e.lastID, true
+	}
+	return parseStreamID(s, 0)
+}
+
+func cmdXGroup(s *Server, c *client, args []string) any {
+	sub := strings.ToUpper(args[1])
+	if len(args) < 4 {
+		return replyErr(fmt.Sprintf("ERR wrong number of arguments for 'xgroup|%s' command", strings.ToLower(sub)))
+	}
+	key, name := args[2], args[3]
+	switch sub {
+	case "CREATE":
+		if len(args) < 5 {
+			return replyErr("ERR wrong number of arguments for 'xgroup|create' command")
+		}
+		e, errReply := s.typed(c, key, "stream")
+		if errReply != nil {
+			return errReply
+		}
+		if e == nil {
+			if len(args) < 6 || !strings.EqualFold(args[5], "MKSTREAM") {
+				return replyErr("ERR The XGROUP subcommand requires the key to exist. Note that for CREATE you may want to use the MKSTREAM option to create an empty stream automatically.")
+			}
+			e = newEntry("stream")
+			s.dbs[c.db][key] = e
+		}
+		if e.groups[name] != nil {
+			return replyErr("BUSYGROUP Consumer Group name already exists")
+		}
+		start, ok := groupStart(e, args[4])
+		if !ok {
+			return replyErr("ERR Invalid stream ID specified as stream command argument")
+		}
+		if e.groups == nil {
+			e.groups = map[string]*streamGroup{}
+		}
+		e.groups[name] = &streamGroup{lastID: start, consumers: map[string]time.Time{}, pending: map[streamID]*pendingEntry{}}
+		return status("OK")
+	case "DESTROY":
+		e, errReply := s.typed(c, key, "stream")
+		if errReply != nil {
+			return errReply
+		}
+		if e == nil {
+			return replyErr("ERR The XGROUP subcommand requires the key to exist.")
+		}
+		if e.groups[name] == nil {
+			return 0
+		}
+		delete(e.groups, name)
+		return 1
+	case "SETID":
+		if len(args) < 5 {
+			return replyErr("ERR wrong number of arguments for 'xgroup|setid' command")
+		}
+		e, g, errReply := s.group(c, key, name)
+		if errReply != nil {
+			return errReply
+		}
+		start, ok := groupStart(e, args[4])
+		if !ok {
+			return replyErr("ERR Invalid stream ID specified as stream command argument")
+		}
+		g.lastID = start
+		return status("OK")
+	case "CREATECONSUMER":
+		if len(args) < 5 {
+			return replyErr("ERR wrong number of arguments for 'xgroup|createconsumer' command")
+		}
+		_, g, errReply := s.group(c, key, name)
+		if errReply != nil {
+			return errReply
+		}
+		if _, ok := g.consumers[args[4]]; ok {
+			return 0
+		}
+		g.consumers[args[4]] = time.Now()
+		return 1
+	}
+	return replyErr(fmt.Sprintf("ERR unknown subcommand '%s'. Try XGROUP HELP.", args[1]))
+}
+
+func cmdXInfo(s *Server, c *client, args []string) any {
+	switch strings.ToUpper(args[1]) {
+	case "GROUPS":
+		e, errReply := s.typed(c, args[2], "stream")
+		if errReply != nil {
+			return errReply
+		}
+		if e == nil {
+			return replyErr("ERR no such key")
+		}
+		out := []any{}
+		for _, name := range sortedKeys(e.groups) {
+			g := e.groups[name]
+			lag := 0
+			for _, se := range e.stream {
+				if g.lastID.less(se.id) {
+					lag++
+				}
+			}
+			out = append(out, []any{
+				"name", name,
+				"consumers", len(g.consumers),
+				"pending", len(g.pending),
+				"last-delivered-id", g.lastID.String(),
+				"lag", lag,
+			})
+		}
+		return out
+	case "CONSUMERS":
+		if len(args) < 4 {
+			return replyErr("ERR wrong number of arguments for 'xinfo|consumers' command")
+		}
+		_, g, errReply := s.group(c, args[2], args[3])
+		if errReply != nil {
+			return errReply
+		}
+		out := []any{}
+		for _, name := range sortedKeys(g.consumers) {
+			pending := 0
+			for _, p := range g.pending {
+				if p.consumer == name {
+					pending++
+				}
+			}
+			out = append(out, []any{"name", name, "pending", pending, "idle", int(time.Since(g.consumers[name]).Milliseconds())})
+		}
+		return out
+	}
+	return replyErr(fmt.Sprintf("ERR unknown subcommand '%s'. Try XINFO HELP.", args[1]))
+}
+
+// cmdXReadGroup serves XREADGROUP GROUP g consumer [COUNT n] [BLOCK ms]
+// [NOACK] STREAMS key id for one stream, without blocking: > delivers new
+// entries, any other ID the consumer's own pending entries after it.
+func cmdXReadGroup(s *Server, c *client, args []string) any {
+	if !strings.EqualFold(args[1], "GROUP") {
+		return errSyntax
+	}
+	name, consumer := args[2], args[3]
+	count, noack := -1, false
+	i := 4
+	for ; i < len(args) && !strings.EqualFold(args[i], "STREAMS"); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "NOACK":
+			noack = true
+		case "COUNT", "BLOCK":
+			if i+1 >= len(args) {
+				return errSyntax
+			}
+			n, ok := atoi(args[i+1])
+			if !ok {
+				return errNotInt
+			}
+			if strings.EqualFold(args[i], "COUNT") {
+				count = n
+			}
+			i++
+		default:
+			return errSyntax
+		}
+	}
+	if len(args)-i != 3 {
+		return errSyntax
+	}
+	key, after := args[i+1], args[i+2]
+	e, g, errReply := s.group(c, key, name)
+	if errReply != nil {
+		return errReply
+	}
+	now := time.Now()
+	g.consumers[consumer] = now
+	entries := []any{}
+	if after == ">" {
+		for _, se := range e.stream {
+			if count > 0 && len(entries) >= count {
+				break
+			}
+			if !g.lastID.less(se.id) {
+				continue
+			}
+			entries = append(entries, []any{se.id.String(), strs(se.fields)})
+			g.lastID = se.id
+			if !noack {
+				g.pending[se.id] = &pendingEntry{consumer: consumer, delivered: now, count: 1}
+			}
+		}
+		if len(entries) == 0 {
+			return nilArray{}
+		}
+	} else {
+		from, ok := parseStreamID(after, 0)
+		if !ok {
+			return replyErr("ERR Invalid stream ID specified as stream command argument")
+		}
+		for _, id := range g.pendingIDs() {
+			if count > 0 && len(entries) >= count {
+				break
+			}
+			if p := g.pending[id]; p.consumer == consumer && from.less(id) {
+				entries = append(entries, []any{id.String(), e.entryFields(id)})
+			}
+		}
+	}
+	return []any{[]any{key, entries}}
+}
+
+// entryFields is the entry's field list, or nil once it has been deleted.
+func (e *entry) entryFields(id streamID) any {
+	for _, se := range e.stream {
+		if se.id == id {
+			return strs(se.fields)
+		}
+	}
+	return nil
+}
+
+// claim moves the pending entry id to consumer when it has been idle for
+// at least minIdle, reporting whether it did.
+func (g *streamGroup) claim(id streamID, consumer string, minIdle time.Duration, now time.Time) bool {
+	p := g.pending[id]
+	if p == nil || now.Sub(p.delivered) < minIdle {
+		return false
+	}
+	p.consumer, p.delivered = consumer, now
+	p.count++
+	g.consumers[consumer] = now
+	return true
+}
+
+// cmdXAutoClaim serves XAUTOCLAIM key group consumer min-idle start
+// [COUNT n] with the Redis 7 reply: next start, claimed entries, and the IDs
+// of entries deleted since delivery, which are dropped from the group.
+func cmdXAutoClaim(s *Server, c *client, args []string) any {
+	e, g, errReply := s.group(c, args[1], args[2])
+	if errReply != nil {
+		return errReply
+	}
+	minIdle, ok := atoi(args[4])
+	if !ok || minIdle < 0 {
+		return replyErr("ERR Invalid min-idle-time argument for XAUTOCLAIM")
+	}
+	start, ok := parseStreamID(args[5], 0)
+	if !ok {
+		return replyErr("ERR Invalid stream ID specified as stream command argument")
+	}
+	count := 100
+	if len(args) >= 8 && strings.EqualFold(args[6], "COUNT") {
+		if count, ok = atoi(args[7]); !ok || count < 1 {
+			return replyErr("ERR COUNT must be > 0")
+		}
+	}
+	now, next := time.Now(), streamID{}
+	claimed, deleted := []any{}, []any{}
+	for _, id := range g.pendingIDs() {
+		if id.less(start) {
+			continue
+		}
+		if len(claimed)+len(deleted) >= count {
+			next = id
+			break
+		}
+		if !g.claim(id, args[3], time.Duration(minIdle)*time.Millisecond, now) {
+			continue
+		}
+		if fields := e.entryFields(id); fields != nil {
+			claimed = append(claimed, []any{id.String(), fields})
+		} else {
+			delete(g.pending, id)
+			deleted = append(deleted, id.String())
+		}
+	}
+	return []any{next.String(), claimed, deleted}
+}
+
+// cmdXPending serves the summary form (key group) and the extended one
+// (key group [IDLE ms] start end count [consumer]).
+func cmdXPending(s *Server, c *client, args []string) any {
+	_, g, errReply := s.group(c, args[1], args[2])
+	if errReply != nil {
+		return errReply
+	}
+	ids := g.pendingIDs()
+	if len(args) == 3 {
+		if len(ids) == 0 {
+			return []any{0, nil, nil, nil}
+		}
+		per := map[string]int{}
+		for _, id := range ids {
+			per[g.pending[id].consumer]++
+		}
+		var consumers []any
+		for _, name := range sortedKeys(per) {
+			consumers = append(consumers, []any{name, strconv.Itoa(per[name])})
+		}
+		return []any{len(ids), ids[0].String(), ids[len(ids)-1].String(), consumers}
+	}
+	rest := args[3:]
+	minIdle := 0
+	if strings.EqualFold(rest[0], "IDLE") {
+		if len(rest) < 2 {
+			return errSyntax
+		}
+		n, ok := atoi(rest[1])
+		if !ok {
+			return errNotInt
+		}
+		minIdle, rest = n, rest[2:]
+	}
+	if len(rest) != 3 && len(rest) != 4 {
+		return errSyntax
+	}
+	from, ok1 := streamID{}, true
+	if rest[0] != "-" {
+		from, ok1 = parseStreamID(rest[0], 0)
+	}
+	to, ok2 := streamID{math.MaxUint64, math.MaxUint64}, true
+	if rest[1] != "+" {
+		to, ok2 = parseStreamID(rest[1], math.MaxUint64)
+	}
+	count, ok3 := atoi(rest[2])
+	if !ok1 || !ok2 {
+		return replyErr("ERR Invalid stream ID specified as stream command argument")
+	}
+	if !ok3 {
+		return errNotInt
+	}
+	out := []any{}
+	for _, id := range ids {
+		if len(out) >= count {
+			break
+		}
+		p := g.pending[id]
+		idle := int(time.Since(p.delivered).Milliseconds())
+		if id.less(from) || to.less(id) || idle < minIdle || len(rest) == 4 && p.consumer != rest[3] {
+			continue
+		}
+		out = append(out, []any{id.String(), p.consumer, idle, p.count})
+	}
+	return out
+}
+
+func cmdXAck(s *Server, c *client, args []string) any {
+	e, errReply := s.typed(c, args[1], "stream")
+	if errReply != nil {
+		return errReply
+	}
+	if e == nil || e.groups[args[2]] == nil {
+		return 0
+	}
+	g, acked := e.groups[args[2]], 0
+	for _, a := range args[3:] {
+		id, ok := parseStreamID(a, 0)
+		if !ok {
+			return replyErr("ERR Invalid stream ID specified as stream command argument")
+		}
+		if g.pending[id] != nil {
+			delete(g.pending, id)
+			acked++
+		}
+	}
+	return acked
+}
+
+func cmdXClaim(s *Server, c *client, args []string) any {
+	e, g, errReply := s.group(c, args[1], args[2])
+	if errReply != nil {
+		return errReply
+	}
+	minIdle, ok := atoi(args[4])
+	if !ok || minIdle < 0 {
+		return replyErr("ERR Invalid min-idle-time argument for XCLAIM")
+	}
+	now, out := time.Now(), []any{}
+	for _, a := range args[5:] {
+		id, ok := parseStreamID(a, 0)
+		if !ok {
+			break // options such as JUSTID follow the IDs
+		}
+		if !g.claim(id, args[3], time.Duration(minIdle)*time.Millisecond, now) {
+			continue
+		}
+		// As in Redis 7, an entry deleted since delivery leaves the PEL.
+		if fields := e.entryFields(id); fields != nil {
+			out = append(out, []any{id.String(), fields})
+		} else {
+			delete(g.pending, id)
+		}
+	}
+	return out
+}
@@ -0,0 +1,177 @@
+package mock
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// entry is one key's value. Only the field matching typ is used.
+type entry struct {
+	typ    string // string, list, hash, set, zset, stream
+	str    string
+	list   []string
+	hash   map[string]string
+	set    map[string]struct{}
+	zset   map[string]float64
+	stream []streamEntry
+	lastID streamID
+	groups map[string]*streamGroup // consumer groups of a stream
+	expire time.Time               // zero: no expiry
+	access time.Time               // for OBJECT IDLETIME
+}
+
+type streamEntry struct {
+	id     streamID
+	fields []string
+}
+
+// streamGroup is a consumer group: where it has read up to, the consumers
+// seen, and the entries delivered but not yet acknowledged.
+type streamGroup struct {
+	lastID    streamID
+	consumers map[string]time.Time // last seen
+	pending   map[streamID]*pendingEntry
+}
+
+type pendingEntry struct {
+	consumer  string
+	delivered time.Time
+	count     int // deliveries
+}
+
+// pendingIDs is the group's pending entries in ID order.
+func (g *streamGroup) pendingIDs() []streamID {
+	ids := make([]streamID, 0, len(g.pending))
+	for id := range g.pending {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].less(ids[j]) })
+	return ids
+}
+
+type streamID struct{ ms, seq uint64 }
+
+func (id streamID) String() string {
+	return strconv.FormatUint(id.ms, 10) + "-" + strconv.FormatUint(id.seq, 10)
+}
+
+func (id streamID) less(o streamID) bool {
+	return id.ms < o.ms || id.ms == o.ms && id.seq < o.seq
+}
+
+// parseStreamID parses "ms-seq" or "ms"; a missing seq is seqDefault.
+func parseStreamID(s string, seqDefault uint64) (streamID, bool) {
+	msPart, seqPart, hasSeq := strings.Cut(s, "-")
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return streamID{}, false
+	}
+	seq := seqDefault
+	if hasSeq {
+		if seq, err = strconv.ParseUint(seqPart, 10, 64); err != nil {
+			return streamID{}, false
+		}
+	}
+	return streamID{ms, seq}, true
+}
+
+func newEntry(typ string) *entry {
+	e := &entry{typ: typ, access: time.Now()}
+	switch typ {
+	case "hash":
+		e.hash = map[string]string{}
+	case "set":
+		e.set = map[string]struct{}{}
+	case "zset":
+		e.zset = map[string]float64{}
+	}
+	return e
+}
+
+// empty reports whether a collection has lost its last element; Redis
+// deletes such keys.
+func (e *entry) empty() bool {
+	switch e.typ {
+	case "list":
+		return len(e.list) == 0
+	case "hash":
+		return len(e.hash) == 0
+	case "set":
+		return len(e.set) == 0
+	case "zset":
+		return len(e.zset) == 0
+	}
+	return false
+}
+
+func (e *entry) encoding() string {
+	switch e.typ {
+	case "string":
+		if _, err := strconv.ParseInt(e.str, 10, 64); err == nil {
+			return "int"
+		}
+		return "raw"
+	case "list":
+		return "quicklist"
+	case "set":
+		return "hashtable"
+	case "zset":
+		return "skiplist"
+	case "stream":
+		return "stream"
+	}
+	return "hashtable"
+}
+
+// zsetMember is one sorted-set member, for ordered iteration.
+type zsetMember struct {
+	member string
+	score  float64
+}
+
+// sortedZSet returns members ordered by score, then member, as ZRANGE does.
+func (e *entry) sortedZSet() []zsetMember {
+	out := make([]zsetMember, 0, len(e.zset))
+	for m, sc := range e.zset {
+		out = append(out, zsetMember{m, sc})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].score != out[j].score {
+			return out[i].score < out[j].score
+		}
+		return out[i].member < out[j].member
+	})
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatScore(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// normalizeRange turns Redis start/stop indices (negative counts from the
+// end) into a half-open [lo, hi) slice range over n elements.
+func normalizeRange(start, stop, n int) (int, int) {
+	if start < 0 {
+		start += n
+	}
+	if stop < 0 {
+		stop += n
+	}
+	start = max(start, 0)
+	stop = min(stop, n-1)
+	if start > stop || start >= n {
+		return 0, 0
+	}
+	return start, stop + 1
+}
@@ -0,0 +1,52 @@
+package mock
+
+import "time"
+
+// Seed fills the server with sample data of every type, shaped like what
+// the TUI's tools are for: users and sessions, job queues, rate-limit
+// counters, a leaderboard, and an event stream.
+func (s *Server) Seed() {
+	do := func(db int, args ...string) { s.Do(db, args...) }
+
+	do(0, "SET", "greeting", "Hello from the redis-tui demo server!")
+	do(0, "SET", "config:app", `{"theme":"dark","features":{"beta":true,"export":true},"max_upload_mb":25}`)
+	do(0, "SET", "counter:page_views", "10482")
+	do(0, "SET", "session:9f2c1a", `{"user_id":1001,"ip":"203.0.113.7","created":"2026-01-12T09:30:00Z"}`, "EX", "3600")
+	do(0, "SET", "session:44be07", `{"user_id":1002,"ip":"198.51.100.23","created":"2026-01-12T10:02:11Z"}`, "EX", "1800")
+	// One limiter with its expiry, one that lost it — the counter
+	// inspector flags the second.
+	do(0, "SET", "ratelimit:api:user:1001", "17", "EX", "60")
+	do(0, "SET", "ratelimit:api:user:1002", "42")
+
+	do(0, "HSET", "user:1001", "name", "Ada Lovelace", "email", "ada@example.com", "plan", "pro", "signup", "2025-11-02")
+	do(0, "HSET", "user:1002", "name", "Alan Turing", "email", "alan@example.com", "plan", "free", "signup", "2026-01-08")
+	do(0, "HSET", "user:1003", "name", "Grace Hopper", "email", "grace@example.com", "plan", "team", "signup", "2025-06-19")
+
+	do(0, "RPUSH", "queue:emails",
+		`{"to":"ada@example.com","template":"welcome"}`,
+		`{"to":"alan@example.com","template":"receipt","order":5521}`,
+		`{"to":"grace@example.com","template":"digest"}`)
+	do(0, "RPUSH", "queue:thumbnails", `{"image":"uploads/cat.png","sizes":[64,256]}`)
+	do(0, "RPUSH", "recent:logins", "user:1003", "user:1001", "user:1002", "user:1001")
+
+	do(0, "SADD", "tags:popular", "redis", "go", "tui", "terminal", "cli")
+	do(0, "SADD", "online:users", "user:1001", "user:1003")
+
+	do(0, "ZADD", "leaderboard:weekly", "3120", "ada", "2875", "grace", "2410", "alan", "1990", "linus", "1204", "margaret")
+
+	for _, ev := range [][]string{
+		{"type", "order.created", "order", "5521", "total", "49.90"},
+		{"type", "order.paid", "order", "5521"},
+		{"type", "order.shipped", "order", "5521", "carrier", "DHL"},
+	} {
+		do(0, append([]string{"XADD", "events:orders", "*"}, ev...)...)
+	}
+
+	do(1, "SET", "cache:page:/home", "<html><body>cached home page</body></html>", "EX", "300")
+	do(1, "SET", "cache:page:/pricing", "<html><body>cached pricing page</body></html>", "EX", "300")
+
+	// Seeding isn't a change anyone needs to save.
+	s.mu.Lock()
+	s.dirty, s.lastSave = 0, time.Now()
+	s.mu.Unlock()
+}
@@ -0,0 +1,273 @@
+// Package mock is an in-process stand-in for a Redis server. It speaks RESP
+// over real TCP, so the client code runs unchanged against it, and implements
+// enough of the command set for every screen of the TUI. It backs -demo and
+// the end-to-end tests; it is not a general-purpose Redis replacement.
+package mock
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+const numDBs = 16
+
+// Server is the fake Redis. The zero value is not usable; call NewServer.
+type Server struct {
+	// Password, when set before Listen, is required via AUTH like
+	// requirepass; any username is accepted with it.
+	Password string
+
+	mu       sync.Mutex
+	dbs      [numDBs]map[string]*entry
+	clients  map[*client]struct{}
+	nextID   int
+	dirty    int // writes since the last save, for INFO persistence
+	lastSave time.Time
+	commands int
+	started  time.Time
+
+	listener net.Listener
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+type client struct {
+	id     int
+	conn   net.Conn
+	db     int
+	name   string
+	authed bool
+	last   string // last command, for CLIENT LIST
+}
+
+// NewServer returns an empty server with 16 databases.
+func NewServer() *Server {
+	s := &Server{clients: map[*client]struct{}{}, done: make(chan struct{})}
+	for i := range s.dbs {
+		s.dbs[i] = map[string]*entry{}
+	}
+	s.started = time.Now()
+	s.lastSave = s.started
+	return s
+}
+
+// Listen starts serving on addr ("127.0.0.1:0" picks a free port) and returns
+// the address it is listening on.
+func (s *Server) Listen(addr string) (string, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", err
+	}
+	s.listener = l
+	s.wg.Add(1)
+	go s.accept()
+	return l.Addr().String(), nil
+}
+
+// Close stops the listener and drops every client connection.
+func (s *Server) Close() error {
+	close(s.done)
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	s.mu.Lock()
+	for c := range s.clients {
+		_ = c.conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) accept() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.nextID++
+		c := &client{id: s.nextID, conn: conn, authed: s.Password == ""}
+		s.clients[c] = struct{}{}
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go s.serve(c)
+	}
+}
+
+func (s *Server) serve(c *client) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+		_ = c.conn.Close()
+	}()
+	r := bufio.NewReader(c.conn)
+	w := bufio.NewWriter(c.conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			var perr protocolError
+			if errors.As(err, &perr) {
+				writeReply(w, replyErr("ERR Protocol error: "+perr.Error()))
+				_ = w.Flush()
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		writeReply(w, s.exec(c, args))
+		if err := w.Flush(); err != nil {
+			return
+		}
+		if strings.EqualFold(args[0], "QUIT") {
+			return
+		}
+	}
+}
+
+// Do runs one command as a fresh client on database db and returns its
+// reply — for seeding data and for tests that want to check state directly.
+func (s *Server) Do(db int, args ...string) any {
+	return s.exec(&client{db: db, authed: true}, args)
+}
+
+type protocolError string
+
+func (e protocolError) Error() string { return string(e) }
+
+// readCommand reads one request: multi-bulk, or an inline command line (so
+// the inline protocol mode works against the demo server too).
+func readCommand(r *bufio.Reader) ([]string, error) {
+	b, err := r.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	if b[0] != '*' {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		return splitInline(strings.TrimRight(line, "\r\n"))
+	}
+	v, err := redis.ReadResp(r)
+	if err != nil {
+		return nil, err
+	}
+	parts, ok := v.([]any)
+	if !ok {
+		return nil, protocolError("expected an array of bulk strings")
+	}
+	args := make([]string, len(parts))
+	for i, p := range parts {
+		if args[i], ok = p.(string); !ok {
+			return nil, protocolError("expected bulk strings")
+		}
+	}
+	return args, nil
+}
+
+// splitInline splits an inline command the way Redis does: on spaces, with
+// double-quoted words supporting \n \r \t \xHH escapes and single-quoted
+// words taken literally.
+func splitInline(line string) ([]string, error) {
+	var args []string
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+		var word strings.Builder
+		switch q := line[i]; q {
+		case '"', '\'':
+			i++
+			for {
+				if i >= len(line) {
+					return nil, protocolError("unbalanced quotes in request")
+				}
+				ch := line[i]
+				if ch == q {
+					i++
+					break
+				}
+				if ch == '\\' && q == '"' && i+1 < len(line) {
+					i++
+					switch e := line[i]; e {
+					case 'n':
+						ch = '\n'
+					case 'r':
+						ch = '\r'
+					case 't':
+						ch = '\t'
+					case 'x':
+						if i+2 < len(line) {
+							if v, err := strconv.ParseUint(line[i+1:i+3], 16, 8); err == nil {
+								ch = byte(v)
+								i += 2
+								break
+							}
+						}
+						ch = e
+					default:
+						ch = e
+					}
+				}
+				word.WriteByte(ch)
+				i++
+			}
+		default:
+			for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				word.WriteByte(line[i])
+				i++
+			}
+		}
+		args = append(args, word.String())
+	}
+	return args, nil
+}
+
+// Reply types beyond the natural Go ones: string is a bulk string, int an
+// integer, nil a null bulk string, and []any an array.
+type (
+	status   string // +OK
+	replyErr string // -ERR ...
+	nilArray struct{}
+)
+
+func writeReply(w *bufio.Writer, v any) {
+	switch v := v.(type) {
+	case status:
+		fmt.Fprintf(w, "+%s\r\n", v)
+	case replyErr:
+		fmt.Fprintf(w, "-%s\r\n", v)
+	case int:
+		fmt.Fprintf(w, ":%d\r\n", v)
+	case string:
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+	case nil:
+		w.WriteString("$-1\r\n")
+	case nilArray:
+		w.WriteString("*-1\r\n")
+	case []any:
+		fmt.Fprintf(w, "*%d\r\n", len(v))
+		for _, item := range v {
+			writeReply(w, item)
+		}
+	default:
+		// The mock runs behind -demo too: a reply it can't encode is an
+		// error for the client, not a crash of the TUI serving it.
+		fmt.Fprintf(w, "-ERR mock: unsupported reply type %T\r\n", v)
+	}
+}
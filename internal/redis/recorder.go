@@ -0,0 +1,107 @@
+package redis
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// Recorder keeps the latest traffic on the connections it wraps in memory,
+// for an in-app view of the protocol. Consecutive reads (or writes) on one
+// connection are kept as one Exchange, so a pipelined batch and its replies
+// each land whole. AUTH requests are replaced by a note, as in Tracer.
+type Recorder struct {
+	mu      sync.Mutex
+	max     int
+	entries []Exchange
+}
+
+// Exchange is the bytes sent, or received, on one connection between two
+// turns of the other direction.
+type Exchange struct {
+	At    time.Time
+	Label string
+	Sent  bool
+	Data  []byte // the first recordBytes of it
+	Size  int    // bytes in all
+}
+
+// recordBytes is how much of an Exchange is kept; the rest is only counted.
+const recordBytes = 4096
+
+// NewRecorder returns a Recorder keeping the last n exchanges.
+func NewRecorder(n int) *Recorder {
+	return &Recorder{max: n}
+}
+
+// Wrap returns conn with its traffic recorded under label. A nil Recorder
+// returns conn unchanged.
+func (r *Recorder) Wrap(conn net.Conn, label string) net.Conn {
+	if r == nil {
+		return conn
+	}
+	return recordConn{Conn: conn, r: r, label: label}
+}
+
+// Exchanges returns the recorded exchanges, oldest first.
+func (r *Recorder) Exchanges() []Exchange {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]Exchange, len(r.entries))
+	for i, e := range r.entries {
+		e.Data = append([]byte(nil), e.Data...)
+		out[i] = e
+	}
+	return out
+}
+
+func (r *Recorder) record(label string, sent bool, b []byte) {
+	if sent {
+		if redacted, ok := redactAuth(b); ok {
+			b = []byte(redacted)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n := len(r.entries); n > 0 {
+		last := &r.entries[n-1]
+		if last.Label == label && last.Sent == sent {
+			last.Size += len(b)
+			if room := recordBytes - len(last.Data); room > 0 {
+				last.Data = append(last.Data, b[:min(room, len(b))]...)
+			}
+			return
+		}
+	}
+	e := Exchange{At: time.Now(), Label: label, Sent: sent, Size: len(b)}
+	e.Data = append([]byte(nil), b[:min(recordBytes, len(b))]...)
+	r.entries = append(r.entries, e)
+	if len(r.entries) > r.max {
+		r.entries = append(r.entries[:0:0], r.entries[len(r.entries)-r.max:]...)
+	}
+}
+
+type recordConn struct {
+	net.Conn
+	r     *Recorder
+	label string
+}
+
+func (c recordConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.r.record(c.label, true, b[:n])
+	}
+	return n, err
+}
+
+func (c recordConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.r.record(c.label, false, b[:n])
+	}
+	return n, err
+}
@@ -0,0 +1,132 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNil is returned by the As helpers for a null reply, so callers can tell
+// a missing key from a failed request with errors.Is.
+var ErrNil = errors.New("redis: nil reply")
+
+// ReplyError is a RESP error reply (-ERR ..., -WRONGTYPE ...) surfaced as a
+// Go error by the As helpers.
+type ReplyError string
+
+func (e ReplyError) Error() string { return string(e) }
+
+// IsErrorReply guesses whether a string reply was a RESP error. ReadResp
+// returns errors as plain strings, so this goes by Redis' convention of an
+// upper-case error code prefix.
+func IsErrorReply(s string) bool {
+	code, _, _ := strings.Cut(s, " ")
+	if len(code) < 3 || code != strings.ToUpper(code) {
+		return false
+	}
+	for _, known := range []string{"ERR", "WRONGTYPE", "NOAUTH", "NOPERM", "MOVED", "ASK", "CROSSSLOT", "READONLY", "LOADING", "BUSY", "NOSCRIPT", "OOM", "EXECABORT", "CLUSTERDOWN", "TRYAGAIN", "NOREPLICAS", "MASTERDOWN", "MISCONF", "BUSYGROUP", "NOGROUP"} {
+		if code == known {
+			return true
+		}
+	}
+	return false
+}
+
+// replyErr turns the nil and error forms ReadResp uses into errors. It returns
+// nil for anything else.
+func replyErr(v any) error {
+	switch r := v.(type) {
+	case nil:
+		return ErrNil
+	case string:
+		if r == "(nil)" {
+			return ErrNil
+		}
+		if IsErrorReply(r) {
+			return ReplyError(r)
+		}
+	}
+	return nil
+}
+
+func unexpected(want string, v any) error {
+	got := "array"
+	switch v.(type) {
+	case string:
+		got = "string"
+	case int:
+		got = "integer"
+	}
+	return fmt.Errorf("redis: expected %s reply, got %s", want, got)
+}
+
+// AsString decodes a simple or bulk string reply.
+func AsString(v any) (string, error) {
+	if err := replyErr(v); err != nil {
+		return "", err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", unexpected("string", v)
+	}
+	return s, nil
+}
+
+// AsInt decodes an integer reply. Numbers sent as bulk strings (CONFIG GET
+// values, SCAN cursors) are parsed too.
+func AsInt(v any) (int, error) {
+	if err := replyErr(v); err != nil {
+		return 0, err
+	}
+	switch r := v.(type) {
+	case int:
+		return r, nil
+	case string:
+		n, err := strconv.Atoi(r)
+		if err != nil {
+			return 0, fmt.Errorf("redis: expected integer reply, got %q", r)
+		}
+		return n, nil
+	}
+	return 0, unexpected("integer", v)
+}
+
+// AsStringSlice decodes an array of strings (KEYS, HKEYS, SMEMBERS). Null
+// elements, as MGET returns for missing keys, become "".
+func AsStringSlice(v any) ([]string, error) {
+	if err := replyErr(v); err != nil {
+		return nil, err
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, unexpected("array", v)
+	}
+	out := make([]string, len(arr))
+	for i, e := range arr {
+		s, ok := e.(string)
+		if !ok {
+			return nil, fmt.Errorf("redis: element %d: %w", i, unexpected("string", e))
+		}
+		if s != "(nil)" {
+			out[i] = s
+		}
+	}
+	return out, nil
+}
+
+// AsMap decodes a flat field/value array (HGETALL, CONFIG GET) into a map.
+func AsMap(v any) (map[string]string, error) {
+	items, err := AsStringSlice(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(items)%2 != 0 {
+		return nil, fmt.Errorf("redis: expected field/value pairs, got %d elements", len(items))
+	}
+	out := make(map[string]string, len(items)/2)
+	for i := 0; i < len(items); i += 2 {
+		out[items[i]] = items[i+1]
+	}
+	return out, nil
+}
@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Flavor is the server implementation behind a Redis-compatible endpoint.
+type Flavor string
+
+const (
+	FlavorRedis     Flavor = "redis"
+	FlavorValkey    Flavor = "valkey"
+	FlavorKeyDB     Flavor = "keydb"
+	FlavorDragonfly Flavor = "dragonfly"
+)
+
+// ServerInfo identifies a server from its INFO server section.
+type ServerInfo struct {
+	Flavor  Flavor
+	Version string // the flavor's own version, not the Redis one it emulates
+}
+
+// ParseServerInfo reads the flavor and version from an INFO reply. The
+// forks all report a redis_version for client compatibility, so their own
+// fields are checked first: Valkey sets server_name and valkey_version,
+// Dragonfly dragonfly_version, and KeyDB only gives itself away in its
+// executable's name. An empty ServerInfo means the reply wasn't INFO.
+func ParseServerInfo(info string) ServerInfo {
+	fields := map[string]string{}
+	for _, line := range strings.Split(info, "\n") {
+		if k, v, ok := strings.Cut(strings.TrimRight(line, "\r"), ":"); ok {
+			fields[k] = v
+		}
+	}
+	switch {
+	case fields["dragonfly_version"] != "":
+		return ServerInfo{FlavorDragonfly, strings.TrimPrefix(fields["dragonfly_version"], "df-v")}
+	case fields["valkey_version"] != "" || fields["server_name"] == "valkey":
+		v := fields["valkey_version"]
+		if v == "" {
+			v = fields["redis_version"]
+		}
+		return ServerInfo{FlavorValkey, v}
+	case strings.Contains(strings.ToLower(fields["executable"]), "keydb"):
+		return ServerInfo{FlavorKeyDB, fields["redis_version"]}
+	case fields["redis_version"] != "":
+		return ServerInfo{FlavorRedis, fields["redis_version"]}
+	}
+	return ServerInfo{}
+}
+
+// String renders the server as "valkey 8.0.1", or "" when unknown.
+func (s ServerInfo) String() string {
+	if s.Flavor == "" {
+		return ""
+	}
+	return strings.TrimSpace(string(s.Flavor) + " " + s.Version)
+}
+
+// Capability is a feature the TUI uses that not every flavor implements.
+type Capability string
+
+const (
+	CapIdleTime       Capability = "OBJECT IDLETIME"
+	CapDebugRestart   Capability = "DEBUG RESTART"
+	CapKeyspaceEvents Capability = "keyspace notifications for every event class"
+	CapSetGet         Capability = "SET ... GET"
+	CapScanNoValues   Capability = "HSCAN ... NOVALUES"
+)
+
+// missing lists what each flavor is known to lack. Anything not listed is
+// assumed to work, as it does on an unidentified server: the command is sent
+// and an error reply shown as usual.
+var missing = map[Flavor]map[Capability]bool{
+	FlavorDragonfly: {CapIdleTime: true, CapDebugRestart: true, CapKeyspaceEvents: true},
+}
+
+// since lists capabilities that arrived in a release, with the version of
+// each flavor that has them. Unlike the gaps above these are not assumed:
+// they ride on a command that would otherwise work, so an older or
+// unidentified server must not be sent them.
+var since = map[Capability]map[Flavor]string{
+	CapSetGet:       {FlavorRedis: "6.2", FlavorValkey: "7.2", FlavorKeyDB: "6.2", FlavorDragonfly: "1.0"},
+	CapScanNoValues: {FlavorRedis: "7.4", FlavorValkey: "8.0"},
+}
+
+// Supports reports whether the server is expected to implement c.
+func (s ServerInfo) Supports(c Capability) bool {
+	if versions, ok := since[c]; ok {
+		v, ok := versions[s.Flavor]
+		return ok && versionAtLeast(s.Version, v)
+	}
+	return !missing[s.Flavor][c]
+}
+
+// versionAtLeast compares dotted versions numerically, ignoring suffixes
+// like "-rc1". An empty or unreadable version is never at least anything.
+func versionAtLeast(version, min string) bool {
+	have, want := strings.Split(version, "."), strings.Split(min, ".")
+	for i, w := range want {
+		n, _ := strconv.Atoi(w)
+		if i >= len(have) {
+			return n == 0
+		}
+		digits := strings.TrimLeft(have[i], "0123456789")
+		h, err := strconv.Atoi(strings.TrimSuffix(have[i], digits))
+		if err != nil {
+			return false
+		}
+		if h != n {
+			return h > n
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// BulkReader reads the payload of one bulk string reply incrementally, so a
+// multi-megabyte value never has to be held in memory whole.
+type BulkReader struct {
+	Len       int // payload size in bytes
+	r         *bufio.Reader
+	remaining int
+}
+
+// ReadRespStream reads the next reply. A bulk string comes back as a
+// BulkReader positioned at its payload, which must be read to the end before
+// the connection is used again; any other reply (including a null bulk) is
+// read in full and returned as ReadResp would.
+func ReadRespStream(reader *bufio.Reader) (*BulkReader, any, error) {
+	prefix, err := reader.ReadByte()
+	if err != nil {
+		return nil, nil, err
+	}
+	if prefix != '$' {
+		_ = reader.UnreadByte()
+		v, err := ReadResp(reader)
+		return nil, v, err
+	}
+	lengthStr, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(lengthStr))
+	if err != nil {
+		return nil, nil, err
+	}
+	if n == -1 {
+		return nil, "(nil)", nil
+	}
+	if n == 0 {
+		if _, err := reader.Discard(2); err != nil {
+			return nil, nil, err
+		}
+	}
+	return &BulkReader{Len: n, r: reader, remaining: n}, nil, nil
+}
+
+// Remaining is how many payload bytes are still unread.
+func (b *BulkReader) Remaining() int {
+	return b.remaining
+}
+
+// Read reads up to len(p) payload bytes. The read that delivers the last
+// payload byte also consumes the trailing CRLF, so the connection is ready
+// for the next reply as soon as Remaining reaches 0.
+func (b *BulkReader) Read(p []byte) (int, error) {
+	if b.remaining == 0 {
+		return 0, io.EOF
+	}
+	if len(p) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.r.Read(p)
+	b.remaining -= n
+	if err == io.EOF {
+		return n, io.ErrUnexpectedEOF
+	}
+	if err == nil && b.remaining == 0 {
+		_, err = b.r.Discard(2)
+	}
+	return n, err
+}
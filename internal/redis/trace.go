@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tracer writes every byte sent and received on the connections it wraps to
+// one log, as timestamped hex dumps with the printable text alongside. It is
+// for diagnosing what a proxy does to the protocol, so it logs the bytes as
+// sent — after inline rewriting, inside TLS — except AUTH requests, which
+// are replaced by a note so passwords never reach the file.
+type Tracer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewTracer returns a Tracer logging to w.
+func NewTracer(w io.Writer) *Tracer {
+	return &Tracer{w: w}
+}
+
+// Wrap returns conn with its traffic logged under label. A nil Tracer
+// returns conn unchanged.
+func (t *Tracer) Wrap(conn net.Conn, label string) net.Conn {
+	if t == nil {
+		return conn
+	}
+	return traceConn{Conn: conn, t: t, label: label}
+}
+
+func (t *Tracer) log(label, dir string, b []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.w, "%s %s %s %d bytes\n", time.Now().Format("15:04:05.000"), label, dir, len(b))
+	if redacted, ok := redactAuth(b); ok {
+		fmt.Fprintf(t.w, "%s\n\n", redacted)
+		return
+	}
+	fmt.Fprintf(t.w, "%s\n", hex.Dump(b))
+}
+
+type traceConn struct {
+	net.Conn
+	t     *Tracer
+	label string
+}
+
+func (c traceConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.t.log(c.label, ">", b[:n])
+	}
+	return n, err
+}
+
+func (c traceConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.t.log(c.label, "<", b[:n])
+	}
+	return n, err
+}
+
+// redactAuth reports whether b is an AUTH request, in either protocol, and
+// returns a summary to log in place of its bytes.
+func redactAuth(b []byte) (string, bool) {
+	if cmds, ok := parseMultiBulk(b); ok {
+		for _, cmd := range cmds {
+			if isAuth(cmd.Name) {
+				return fmt.Sprintf("  (%s with %d argument(s), redacted)", cmd.Name, len(cmd.Args)), true
+			}
+		}
+		return "", false
+	}
+	name, _, _ := strings.Cut(strings.TrimSpace(string(b)), " ")
+	if isAuth(name) {
+		return "  (inline AUTH, redacted)", true
+	}
+	return "", false
+}
+
+func isAuth(name string) bool {
+	return strings.EqualFold(name, "AUTH")
+}
@@ -3,6 +3,8 @@ package tui
 import (
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -16,7 +18,15 @@ import (
 //   - selected item: ❯ marker + accent title + tinted full-width background
 //   - type / index / score label: color-coded and right-justified
 //   - used for both the key browser and the fields/members browser
-type browserDelegate struct{}
+type browserDelegate struct {
+	// previewWidth caps a row's name in cells, so one huge list element or
+	// field doesn't fill the row; v shows it in full. 0 leaves only the
+	// row width as the limit.
+	previewWidth int
+}
+
+// DefaultPreviewWidth is the -preview-width default, in terminal cells.
+const DefaultPreviewWidth = 80
 
 func (d browserDelegate) Height() int                             { return 1 }
 func (d browserDelegate) Spacing() int                            { return 1 }
@@ -25,10 +35,16 @@ func (d browserDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
 // BrowserDelegate returns the delegate used for key and fields lists.
 func BrowserDelegate() browserDelegate { return browserDelegate{} }
 
+// WithPreviewWidth returns d cutting names at n cells (see previewWidth).
+func (d browserDelegate) WithPreviewWidth(n int) browserDelegate {
+	d.previewWidth = n
+	return d
+}
+
 // Each item is a single row: the key/field name on the left and a color-coded
 // type / index / score label right-justified against the row's right edge.
 func (d browserDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
-	li, ok := item.(ListItem)
+	li, ok := asListItem(item)
 	if !ok {
 		return
 	}
@@ -52,6 +68,11 @@ func (d browserDelegate) Render(w io.Writer, m list.Model, index int, item list.
 		return
 	}
 
+	if li.score != "" {
+		d.renderZSetRow(w, m, li, width, isSelected)
+		return
+	}
+
 	descText, descStyle := fieldDescStyle(li.desc)
 
 	// A TTL badge only ever applies to top-level keys (li.ttl is unset — left
@@ -66,24 +87,110 @@ func (d browserDelegate) Render(w io.Writer, m list.Model, index int, item list.
 		ttlStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
 	}
 
-	// marker + name on the left, type/index/score label right-justified.
+	// marker + name on the left, type/index/score label right-justified. A
+	// name too long for the row is cut by display width so the label keeps
+	// its column, however many wide characters the name holds.
+	labelWidth := lipgloss.Width(descText) + lipgloss.Width(ttlBadge)
+	limit := max(width-2-labelWidth-3, 1)
+	if d.previewWidth > 0 {
+		limit = min(limit, d.previewWidth)
+	}
+	name := truncateText(li.title, limit)
 	var marker, title string
 	if isSelected {
 		marker = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render(pointerGlyph)
-		title = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true).Render(li.title)
+		title = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true).Render(name)
 	} else {
 		marker = "  "
-		title = lipgloss.NewStyle().Foreground(lipgloss.Color(tnText)).Render(li.title)
+		title = lipgloss.NewStyle().Foreground(lipgloss.Color(tnText)).Render(name)
 	}
 
-	labelWidth := lipgloss.Width(descText) + lipgloss.Width(ttlBadge)
-	gap := width - 2 - lipgloss.Width(li.title) - labelWidth - 2
+	gap := width - 2 - lipgloss.Width(name) - labelWidth - 2
 	if gap < 1 {
 		gap = 1
 	}
 	fmt.Fprintf(w, "%s%s%s%s%s", marker, title, strings.Repeat(" ", gap), descStyle.Render(descText), ttlStyle.Render(ttlBadge))
 }
 
+// renderZSetRow lays a sorted-set member out as rank | member | score, the
+// rank right-aligned in a column as wide as the largest rank loaded and the
+// score right-aligned against the row's edge so the digits line up.
+func (d browserDelegate) renderZSetRow(w io.Writer, m list.Model, li ListItem, width int, isSelected bool) {
+	rankW := zsetRankWidth(len(m.Items()))
+	rank := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim)).Render(fmt.Sprintf("%*d", rankW, li.index))
+	score := lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow)).Render(li.score)
+	if li.when != "" {
+		score = lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim)).Render(li.when+"  ") + score
+	}
+
+	limit := max(width-2-rankW-2-lipgloss.Width(score)-3, 1)
+	if d.previewWidth > 0 {
+		limit = min(limit, d.previewWidth)
+	}
+	name := truncateText(li.title, limit)
+	marker, title := "  ", lipgloss.NewStyle().Foreground(lipgloss.Color(tnText)).Render(name)
+	if isSelected {
+		marker = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render(pointerGlyph)
+		title = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true).Render(name)
+	}
+
+	gap := max(width-2-rankW-2-lipgloss.Width(name)-lipgloss.Width(score)-2, 1)
+	fmt.Fprintf(w, "%s%s  %s%s%s", marker, rank, title, strings.Repeat(" ", gap), score)
+}
+
+// zsetRankWidth is the rank column's width for n loaded members: wide enough
+// for the largest rank and for its "rank" heading.
+func zsetRankWidth(n int) int {
+	return max(len(strconv.Itoa(max(n-1, 0))), len("rank"))
+}
+
+// zsetHeader is the column heading above a sorted set's members, shown as
+// the list title. The column the members are ordered by is marked.
+func zsetHeader(n, width int, byMember bool) string {
+	rankW := zsetRankWidth(n)
+	member, score := "member", "score ▴"
+	if byMember {
+		member, score = "member ▴", "score"
+	}
+	// The title bar's 2-cell padding stands in for the rows' marker.
+	left := fmt.Sprintf("%*s  %s", rankW, "rank", member)
+	gap := max(width-4-lipgloss.Width(left)-lipgloss.Width(score), 1)
+	return left + strings.Repeat(" ", gap) + score
+}
+
+// annotateScores sets or clears the human date shown beside each score that
+// looks like a unix time (delayed-job queues score by due time).
+func annotateScores(items []list.Item, on bool) []list.Item {
+	out := make([]list.Item, len(items))
+	for i, it := range items {
+		li, ok := it.(ListItem)
+		if !ok {
+			out[i] = it // a field row; only sorted-set members have scores
+			continue
+		}
+		li.when = ""
+		if on && li.score != "" {
+			li.when = dateNote(li.score)
+		}
+		out[i] = li
+	}
+	return out
+}
+
+// sortZSetItems orders loaded sorted-set members by member name, or back in
+// rank (score) order, which is the order ZRANGE returned them in.
+func sortZSetItems(items []list.Item, byMember bool) []list.Item {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b list.Item) int {
+		x, y := a.(ListItem), b.(ListItem)
+		if byMember {
+			return strings.Compare(x.title, y.title)
+		}
+		return x.index - y.index
+	})
+	return sorted
+}
+
 type ListItem struct {
 	index  int
 	title  string
@@ -91,6 +198,15 @@ type ListItem struct {
 	group  string // non-empty on the first item of a menu group; renders a section label above it
 	action string // non-empty marks a special non-data row (e.g. "newkey")
 
+	// score is a sorted-set member's score, which makes the row render as
+	// rank (index) | member | score. Empty on every other row.
+	score string
+	when  string // score as a human date, when it looks like a unix time
+
+	// missing, on a menu row, is a command its tool needs that the server
+	// doesn't implement; the row renders dimmed and refuses to open.
+	missing string
+
 	// ttl is the key's remaining TTL in seconds (-1 means no expiry), set only
 	// by the top-level key scan. Every other ListItem (fields, members, the
 	// "+new key" action row...) leaves this at its zero value, but that's
@@ -119,6 +235,15 @@ func (li ListItem) Title() string       { return li.title }
 func (li ListItem) Description() string { return li.desc }
 func (li ListItem) FilterValue() string { return li.title }
 
+// Score is a sorted-set member's score, or "" for any other row.
+func (li ListItem) Score() string { return li.score }
+
+// When is the score as a human date, or "" when it isn't one or dates are off.
+func (li ListItem) When() string { return li.when }
+
+// Missing is the command a menu row's tool needs and the server lacks, or "".
+func (li ListItem) Missing() string { return li.missing }
+
 type BrowserModel struct {
 	KeyList    list.Model
 	FieldsList list.Model
@@ -132,11 +257,14 @@ type BrowserModel struct {
 	Cursor  string
 	Pattern string
 	HasMore bool
+	// ScanHint is the COUNT the next page of keys is scanned with.
+	ScanHint int
 
 	// Field-level pagination (lists, sets, sorted sets)
 	FieldCursor   string
 	FieldOffset   int
 	HasMoreFields bool
+	FromTail      bool // the list is paged from its last element; see display_rules.go
 
 	// Window dimensions available to the browser (excludes connection header).
 	Width  int
@@ -145,6 +273,9 @@ type BrowserModel struct {
 	// Type of the key currently being browsed ("hash", "list", "set", "zset", "string").
 	ActiveKeyType string
 
+	// ZSetByMember orders sorted-set members by name instead of by score.
+	ZSetByMember bool
+
 	// bubbles/help for consistent footer keybindings.
 	Help help.Model
 
@@ -154,11 +285,26 @@ type BrowserModel struct {
 	AddFieldStep int
 	FieldInput   textinput.Model
 	ValueInput   textinput.Model
+	AddErr       string // why the last enter was refused (a malformed score)
+	AddCommas    bool   // ctrl+o: the list and set form splits at commas; see multivalue.go
 
 	// Key-picker mode: the key list is a chooser of existing keys of PickerType
 	// (plus a synthetic "＋ new key…" row) for a menu collection command.
 	Picking    bool
 	PickerType string
+
+	// Highlighted is the key under the cursor, HighlightGen counts its
+	// changes, and Meta holds what was prefetched for keys; see key_meta.go.
+	Highlighted  string
+	HighlightGen int
+	Meta         map[string]KeyMeta
+
+	// Cache holds recent replies by command line; see result_cache.go.
+	Cache map[string]cachedReply
+
+	// Live is the live refresh badge, set by the parent for the view; see
+	// model_live.go.
+	Live string
 }
 
 func (m BrowserModel) Init() tea.Cmd { return nil }
@@ -189,6 +335,16 @@ type RefreshMsg struct{}
 // NewKeyRequestMsg is emitted when the "＋ new key…" picker row is chosen.
 type NewKeyRequestMsg struct{}
 
+// ExpandFieldMsg shows the selected field/member in full, however long, in
+// the output viewport.
+type ExpandFieldMsg struct {
+	Key   string
+	Field string
+}
+
+// ToggleDatesMsg turns human dates next to timestamps on or off.
+type ToggleDatesMsg struct{}
+
 // FieldExportRequestMsg exports the selected field/member to a JSON file.
 type FieldExportRequestMsg struct {
 	Field string
@@ -198,8 +354,12 @@ type FieldExportRequestMsg struct {
 // FieldImportRequestMsg imports a single field/member from a JSON file.
 type FieldImportRequestMsg struct{}
 
+// ResultExportRequestMsg asks to save the loaded keys or fields to a file.
+type ResultExportRequestMsg struct{}
+
 // AddItemMsg commits the add-item overlay. A is the field/member/value; B is the
-// value/score for the two-step hash and zset forms.
+// value/score for the two-step hash and zset forms. For sets and lists A may
+// hold several, one per line (see splitValues).
 type AddItemMsg struct {
 	Key  string
 	Type string
@@ -286,7 +446,7 @@ func (m BrowserModel) Update(msg tea.Msg) (BrowserModel, tea.Cmd) {
 
 		case "enter":
 			if m.ViewingFields {
-				if selected, ok := m.FieldsList.SelectedItem().(ListItem); ok {
+				if selected, ok := asListItem(m.FieldsList.SelectedItem()); ok {
 					return m, func() tea.Msg {
 						return SelectFieldMsg{Key: m.ActiveKey, Field: selected.Title(), Index: selected.index}
 					}
@@ -311,7 +471,7 @@ func (m BrowserModel) Update(msg tea.Msg) (BrowserModel, tea.Cmd) {
 
 		case "d":
 			if m.ViewingFields {
-				if item, ok := m.FieldsList.SelectedItem().(ListItem); ok {
+				if item, ok := asListItem(m.FieldsList.SelectedItem()); ok {
 					return m, func() tea.Msg { return DeleteRequestMsg{Key: m.ActiveKey, Field: item.Title()} }
 				}
 			} else {
@@ -320,6 +480,29 @@ func (m BrowserModel) Update(msg tea.Msg) (BrowserModel, tea.Cmd) {
 				}
 			}
 
+		case "!":
+			key := m.ActiveKey
+			if !m.ViewingFields {
+				item, ok := m.KeyList.SelectedItem().(ListItem)
+				if !ok || item.action != "" {
+					break
+				}
+				key = item.Title()
+			}
+			return m, func() tea.Msg { return MacroRequestMsg{Key: key} }
+
+		case "+":
+			if !m.ViewingFields {
+				return m, func() tea.Msg { return CreateKeyRequestMsg{} }
+			}
+
+		case "m":
+			if !m.ViewingFields {
+				if item, ok := m.KeyList.SelectedItem().(ListItem); ok && item.action == "" {
+					return m, func() tea.Msg { return KeyMenuRequestMsg{Key: item.Title()} }
+				}
+			}
+
 		case "r":
 			if !m.ViewingFields {
 				if item, ok := m.KeyList.SelectedItem().(ListItem); ok {
@@ -337,13 +520,51 @@ func (m BrowserModel) Update(msg tea.Msg) (BrowserModel, tea.Cmd) {
 		case "x":
 			// Export the selected field/member to a self-describing JSON file.
 			if m.ViewingFields {
-				if item, ok := m.FieldsList.SelectedItem().(ListItem); ok {
+				if item, ok := asListItem(m.FieldsList.SelectedItem()); ok {
 					return m, func() tea.Msg {
 						return FieldExportRequestMsg{Field: item.Title(), Index: item.index}
 					}
 				}
 			}
 
+		case "v":
+			// Show the selected row untruncated.
+			if m.ViewingFields {
+				if item, ok := asListItem(m.FieldsList.SelectedItem()); ok {
+					return m, func() tea.Msg { return ExpandFieldMsg{Key: m.ActiveKey, Field: item.Title()} }
+				}
+			}
+
+		case "s":
+			// Sorted sets: toggle between score and member order.
+			if m.ViewingFields && m.ActiveKeyType == "zset" {
+				m.ZSetByMember = !m.ZSetByMember
+				return m, m.FieldsList.SetItems(sortZSetItems(m.FieldsList.Items(), m.ZSetByMember))
+			}
+			// The key list: saved patterns and filters.
+			if !m.ViewingFields && !m.Picking {
+				return m, func() tea.Msg { return SavedSearchesRequestMsg{} }
+			}
+
+		case "R":
+			// Random elements, for keys too big to page through.
+			if m.ViewingFields && m.ActiveKeyType != "list" {
+				return m, func() tea.Msg { return SampleRequestMsg{Key: m.ActiveKey, Type: m.ActiveKeyType} }
+			}
+
+		case "t":
+			if m.ViewingFields && m.ActiveKeyType == "zset" {
+				return m, func() tea.Msg { return ToggleDatesMsg{} }
+			}
+
+		case "L":
+			if m.ViewingFields {
+				return m, func() tea.Msg { return LiveToggleMsg{Key: m.ActiveKey} }
+			}
+
+		case "w":
+			return m, func() tea.Msg { return ResultExportRequestMsg{} }
+
 		case "i":
 			// Import a single field/member from a JSON file.
 			if m.ViewingFields {
@@ -366,6 +587,7 @@ func (m BrowserModel) Update(msg tea.Msg) (BrowserModel, tea.Cmd) {
 func (m *BrowserModel) StartAdd() tea.Cmd {
 	m.AddingField = true
 	m.AddFieldStep = 0
+	m.AddCommas = false
 	m.FieldInput.SetValue("")
 	m.FieldInput.Focus()
 	m.ValueInput.SetValue("")
@@ -378,6 +600,7 @@ func (m BrowserModel) updateAddField(msg tea.Msg) (BrowserModel, tea.Cmd) {
 	twoStep := len(addStepLabels(m.ActiveKeyType)) == 2
 
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		m.AddErr = ""
 		switch keyMsg.String() {
 		case "esc":
 			m.AddingField = false
@@ -386,6 +609,12 @@ func (m BrowserModel) updateAddField(msg tea.Msg) (BrowserModel, tea.Cmd) {
 			m.ValueInput.SetValue("")
 			return m, nil
 
+		case "ctrl+o":
+			if !twoStep {
+				m.AddCommas = !m.AddCommas
+				return m, nil
+			}
+
 		case "enter":
 			// Two-step forms advance from step 0 to step 1 (blank step 0 blocks).
 			if twoStep && m.AddFieldStep == 0 {
@@ -402,7 +631,18 @@ func (m BrowserModel) updateAddField(msg tea.Msg) (BrowserModel, tea.Cmd) {
 			if a == "" {
 				return m, nil
 			}
+			if !twoStep && m.AddCommas {
+				a = commaValues(a)
+			}
 			key, keyType, b := m.ActiveKey, m.ActiveKeyType, m.ValueInput.Value()
+			if keyType == "zset" {
+				score, err := ParseNumber(scoreNumber, b)
+				if err != nil {
+					m.AddErr = err.Error()
+					return m, nil
+				}
+				b = score
+			}
 			m.AddingField = false
 			m.AddFieldStep = 0
 			return m, func() tea.Msg {
@@ -432,14 +672,30 @@ func (m BrowserModel) View() string {
 
 	var listView, helpView string
 	if m.ViewingFields {
-		listView = m.FieldsList.View()
+		fields := m.FieldsList
+		keys := otherFieldsKeys
+		if m.ActiveKeyType == "zset" {
+			fields.Title = zsetHeader(len(fields.Items()), m.Width, m.ZSetByMember)
+		} else {
+			keys.Sort.SetEnabled(false)
+			keys.Dates.SetEnabled(false)
+		}
+		keys.Sample.SetEnabled(m.ActiveKeyType != "list")
+		if m.Live != "" {
+			fields.Title += "   " + m.Live
+		}
+		listView = fields.View()
 		if m.ActiveKeyType == "hash" {
 			helpView = h.View(hashFieldsKeys)
 		} else {
-			helpView = h.View(otherFieldsKeys)
+			helpView = h.View(keys)
 		}
 	} else {
-		listView = m.KeyList.View()
+		keys := m.KeyList
+		if meta, ok := m.Meta[m.Highlighted]; ok && m.Highlighted != "" {
+			keys.Title += "   " + truncateText(m.Highlighted, 30) + ": " + metaSummary(meta)
+		}
+		listView = keys.View()
 		helpView = h.View(browserKeys)
 	}
 	return listView + "\n" + footerSep(m.Width) + "\n  " + helpView
@@ -485,6 +741,15 @@ func (m BrowserModel) addFieldOverlayView() string {
 	}
 
 	content := head + "\n\n" + s0Label + "\n" + s0Row
+	if !twoStep {
+		// Lists and sets take several elements; see multivalue.go.
+		n := countValues(strings.TrimSpace(m.FieldInput.Value()), m.AddCommas)
+		commas := "off"
+		if m.AddCommas {
+			commas = "on, \\, for a literal comma"
+		}
+		content += "\n" + dim.Render(fmt.Sprintf("split on commas for several (ctrl+o): %s · %d to add", commas, n))
+	}
 
 	// Step 1 (two-step forms only).
 	if twoStep {
@@ -504,6 +769,30 @@ func (m BrowserModel) addFieldOverlayView() string {
 		}
 		content += "\n\n" + s1Label + "\n" + vi.View()
 	}
+	if m.AddErr != "" {
+		content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render("✗ "+m.AddErr)
+	}
 
 	return indentLines(content, 2)
 }
+
+// updateBrowser runs the key and field browser, vim keys first when on.
+func updateBrowser(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if next, cmd, ok := m.vimKey(keyMsg); ok {
+			if nm, ok := next.(Model); ok {
+				return nm, tea.Batch(cmd, nm.watchHighlight())
+			}
+			return next, cmd
+		}
+	}
+	browserModel, cmd := m.Browser.Update(msg)
+	m.Browser = browserModel
+	return m, tea.Batch(cmd, m.watchHighlight())
+}
+
+func (m Model) browserView() string {
+	b := m.Browser
+	b.Live = m.liveBadge(b.ActiveKey)
+	return m.headerView() + "\n" + b.View()
+}
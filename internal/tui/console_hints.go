@@ -0,0 +1,225 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// commandSyntax is the usage line shown under the console input, in
+// redis-cli's notation. Container commands are listed per subcommand. Only
+// the commands people type most are here; any other the server reported
+// gets its arity instead.
+var commandSyntax = map[string]string{
+	"APPEND":           "APPEND key value",
+	"AUTH":             "AUTH [username] password",
+	"BLPOP":            "BLPOP key [key ...] timeout",
+	"BRPOP":            "BRPOP key [key ...] timeout",
+	"DBSIZE":           "DBSIZE",
+	"DECR":             "DECR key",
+	"DECRBY":           "DECRBY key decrement",
+	"DEL":              "DEL key [key ...]",
+	"DUMP":             "DUMP key",
+	"ECHO":             "ECHO message",
+	"EXISTS":           "EXISTS key [key ...]",
+	"EXPIRE":           "EXPIRE key seconds [NX|XX|GT|LT]",
+	"FLUSHDB":          "FLUSHDB [ASYNC|SYNC]",
+	"GET":              "GET key",
+	"GETRANGE":         "GETRANGE key start end",
+	"HDEL":             "HDEL key field [field ...]",
+	"HEXISTS":          "HEXISTS key field",
+	"HGET":             "HGET key field",
+	"HGETALL":          "HGETALL key",
+	"HINCRBY":          "HINCRBY key field increment",
+	"HKEYS":            "HKEYS key",
+	"HLEN":             "HLEN key",
+	"HMGET":            "HMGET key field [field ...]",
+	"HSCAN":            "HSCAN key cursor [MATCH pattern] [COUNT count]",
+	"HSET":             "HSET key field value [field value ...]",
+	"HVALS":            "HVALS key",
+	"INCR":             "INCR key",
+	"INCRBY":           "INCRBY key increment",
+	"INFO":             "INFO [section [section ...]]",
+	"KEYS":             "KEYS pattern",
+	"LINDEX":           "LINDEX key index",
+	"LLEN":             "LLEN key",
+	"LMOVE":            "LMOVE source destination LEFT|RIGHT LEFT|RIGHT",
+	"LPOP":             "LPOP key [count]",
+	"LPUSH":            "LPUSH key element [element ...]",
+	"LRANGE":           "LRANGE key start stop",
+	"LREM":             "LREM key count element",
+	"LSET":             "LSET key index element",
+	"LTRIM":            "LTRIM key start stop",
+	"MGET":             "MGET key [key ...]",
+	"MSET":             "MSET key value [key value ...]",
+	"PERSIST":          "PERSIST key",
+	"PEXPIRE":          "PEXPIRE key milliseconds [NX|XX|GT|LT]",
+	"PING":             "PING [message]",
+	"PTTL":             "PTTL key",
+	"PUBLISH":          "PUBLISH channel message",
+	"RENAME":           "RENAME key newkey",
+	"RESTORE":          "RESTORE key ttl serialized-value [REPLACE] [ABSTTL] [IDLETIME seconds] [FREQ frequency]",
+	"RPOP":             "RPOP key [count]",
+	"RPUSH":            "RPUSH key element [element ...]",
+	"SADD":             "SADD key member [member ...]",
+	"SCAN":             "SCAN cursor [MATCH pattern] [COUNT count] [TYPE type]",
+	"SCARD":            "SCARD key",
+	"SDIFF":            "SDIFF key [key ...]",
+	"SELECT":           "SELECT index",
+	"SET":              "SET key value [NX|XX] [GET] [EX seconds|PX milliseconds|EXAT unix-time|PXAT unix-time-ms|KEEPTTL]",
+	"SETRANGE":         "SETRANGE key offset value",
+	"SINTER":           "SINTER key [key ...]",
+	"SISMEMBER":        "SISMEMBER key member",
+	"SMEMBERS":         "SMEMBERS key",
+	"SREM":             "SREM key member [member ...]",
+	"SSCAN":            "SSCAN key cursor [MATCH pattern] [COUNT count]",
+	"STRLEN":           "STRLEN key",
+	"SUNION":           "SUNION key [key ...]",
+	"TTL":              "TTL key",
+	"TYPE":             "TYPE key",
+	"UNLINK":           "UNLINK key [key ...]",
+	"XADD":             "XADD key [NOMKSTREAM] [MAXLEN|MINID [=|~] threshold] *|id field value [field value ...]",
+	"XLEN":             "XLEN key",
+	"XRANGE":           "XRANGE key start end [COUNT count]",
+	"XREAD":            "XREAD [COUNT count] [BLOCK milliseconds] STREAMS key [key ...] id [id ...]",
+	"ZADD":             "ZADD key [NX|XX] [GT|LT] [CH] [INCR] score member [score member ...]",
+	"ZCARD":            "ZCARD key",
+	"ZCOUNT":           "ZCOUNT key min max",
+	"ZINCRBY":          "ZINCRBY key increment member",
+	"ZRANGE":           "ZRANGE key start stop [BYSCORE|BYLEX] [REV] [LIMIT offset count] [WITHSCORES]",
+	"ZRANK":            "ZRANK key member",
+	"ZREM":             "ZREM key member [member ...]",
+	"ZSCAN":            "ZSCAN key cursor [MATCH pattern] [COUNT count]",
+	"ZSCORE":           "ZSCORE key member",
+	"ACL CAT":          "ACL CAT [category]",
+	"ACL LIST":         "ACL LIST",
+	"ACL USERS":        "ACL USERS",
+	"ACL WHOAMI":       "ACL WHOAMI",
+	"CLIENT GETNAME":   "CLIENT GETNAME",
+	"CLIENT ID":        "CLIENT ID",
+	"CLIENT INFO":      "CLIENT INFO",
+	"CLIENT KILL":      "CLIENT KILL [ID client-id] [ADDR ip:port] [USER username] [SKIPME yes|no]",
+	"CLIENT LIST":      "CLIENT LIST [TYPE NORMAL|MASTER|REPLICA|PUBSUB] [ID client-id ...]",
+	"CLIENT SETNAME":   "CLIENT SETNAME connection-name",
+	"CLUSTER FAILOVER": "CLUSTER FAILOVER [FORCE|TAKEOVER]",
+	"CLUSTER INFO":     "CLUSTER INFO",
+	"CLUSTER KEYSLOT":  "CLUSTER KEYSLOT key",
+	"CLUSTER NODES":    "CLUSTER NODES",
+	"CLUSTER SHARDS":   "CLUSTER SHARDS",
+	"CLUSTER SLOTS":    "CLUSTER SLOTS",
+	"COMMAND COUNT":    "COMMAND COUNT",
+	"COMMAND DOCS":     "COMMAND DOCS [command-name [command-name ...]]",
+	"COMMAND INFO":     "COMMAND INFO [command-name [command-name ...]]",
+	"CONFIG GET":       "CONFIG GET parameter [parameter ...]",
+	"CONFIG RESETSTAT": "CONFIG RESETSTAT",
+	"CONFIG REWRITE":   "CONFIG REWRITE",
+	"CONFIG SET":       "CONFIG SET parameter value [parameter value ...]",
+	"LATENCY DOCTOR":   "LATENCY DOCTOR",
+	"LATENCY LATEST":   "LATENCY LATEST",
+	"MEMORY DOCTOR":    "MEMORY DOCTOR",
+	"MEMORY STATS":     "MEMORY STATS",
+	"MEMORY USAGE":     "MEMORY USAGE key [SAMPLES count]",
+	"OBJECT ENCODING":  "OBJECT ENCODING key",
+	"OBJECT FREQ":      "OBJECT FREQ key",
+	"OBJECT IDLETIME":  "OBJECT IDLETIME key",
+	"OBJECT REFCOUNT":  "OBJECT REFCOUNT key",
+	"SCRIPT EXISTS":    "SCRIPT EXISTS sha1 [sha1 ...]",
+	"SCRIPT FLUSH":     "SCRIPT FLUSH [ASYNC|SYNC]",
+	"SLOWLOG GET":      "SLOWLOG GET [count]",
+	"SLOWLOG LEN":      "SLOWLOG LEN",
+	"SLOWLOG RESET":    "SLOWLOG RESET",
+	"XINFO CONSUMERS":  "XINFO CONSUMERS key group",
+	"XINFO GROUPS":     "XINFO GROUPS key",
+	"XINFO STREAM":     "XINFO STREAM key [FULL [COUNT count]]",
+}
+
+// subcommands lists the subcommands of name: the server's, when it reported
+// them, otherwise those in commandSyntax.
+func subcommands(t redis.CommandTable, name string) []string {
+	if info, ok := t[name]; ok && len(info.Subcommands) > 0 {
+		return info.Subcommands
+	}
+	var subs []string
+	for k := range commandSyntax {
+		if cmd, sub, ok := strings.Cut(k, " "); ok && cmd == name {
+			subs = append(subs, sub)
+		}
+	}
+	sort.Strings(subs)
+	return subs
+}
+
+// consoleCompletions are the lines Tab can complete to: every command name,
+// and every "COMMAND SUBCOMMAND" pair. They come from the server's table,
+// or from commandSyntax until (or unless) the server has said.
+func consoleCompletions(t redis.CommandTable) []string {
+	var names []string
+	if t != nil {
+		names = t.Names()
+	} else {
+		seen := map[string]bool{}
+		for k := range commandSyntax {
+			name, _, _ := strings.Cut(k, " ")
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+	}
+	out := append([]string(nil), names...)
+	for _, name := range names {
+		for _, sub := range subcommands(t, name) {
+			out = append(out, name+" "+sub)
+		}
+	}
+	return out
+}
+
+// consoleHint is the help line for what's typed so far: the command's
+// syntax, its subcommands, or its arity as the server reported it.
+func consoleHint(t redis.CommandTable, line string) string {
+	args, err := splitCommandLine(line)
+	if err != nil {
+		args = strings.Fields(line)
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	name := strings.ToUpper(args[0])
+	if t != nil && !t.Has(name) {
+		if len(args) > 1 || strings.HasSuffix(line, " ") {
+			return name + " isn't a command this server knows"
+		}
+		return ""
+	}
+	if subs := subcommands(t, name); len(subs) > 0 {
+		if len(args) > 1 {
+			if s, ok := commandSyntax[name+" "+strings.ToUpper(args[1])]; ok {
+				return s
+			}
+		}
+		return name + " " + strings.Join(subs, "|")
+	}
+	if s, ok := commandSyntax[name]; ok {
+		return s
+	}
+	if info, ok := t[name]; ok {
+		switch n := info.Arity; {
+		case n > 0:
+			return fmt.Sprintf("%s takes %d argument%s", name, n-1, plural(n-1))
+		case n < 0:
+			return fmt.Sprintf("%s takes at least %d argument%s", name, -n-1, plural(-n-1))
+		}
+	}
+	return ""
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Console history is kept per profile (per address without one) in its own
+// file next to the config, one line per command, so what was composed on
+// prod last week is a Ctrl+R away. Lines carrying credentials stay in memory
+// only.
+
+// DefaultHistoryDir is where console history files live.
+func DefaultHistoryDir() string {
+	cfg := DefaultConfigPath()
+	if cfg == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(cfg), "history")
+}
+
+// historyPath is this session's history file, or "" when not kept.
+func (m Model) historyPath() string {
+	return m.profileFile(m.HistoryDir, "")
+}
+
+// profileFile is the file in dir kept for this session's profile (its
+// address without one), named safely for any filesystem, or "" when dir is.
+func (m Model) profileFile(dir, ext string) string {
+	if dir == "" {
+		return ""
+	}
+	name := m.ProfileName
+	if name == "" {
+		name = m.RedisAddress
+	}
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, name)
+	if name == "" || strings.Trim(name, ".") == "" {
+		return ""
+	}
+	return filepath.Join(dir, name+ext)
+}
+
+// secretLine reports whether a console line carries a password, which the
+// history file mustn't.
+func secretLine(line string) bool {
+	args, err := splitCommandLine(line)
+	if err != nil || len(args) == 0 {
+		return false
+	}
+	switch strings.ToUpper(args[0]) {
+	case "AUTH", "MIGRATE":
+		return true
+	case "HELLO":
+		for _, a := range args {
+			if strings.EqualFold(a, "AUTH") {
+				return true
+			}
+		}
+	case "ACL":
+		return len(args) > 1 && strings.EqualFold(args[1], "SETUSER")
+	case "CONFIG":
+		if len(args) > 2 && strings.EqualFold(args[1], "SET") {
+			p := strings.ToLower(args[2])
+			return strings.Contains(p, "pass") || strings.Contains(p, "auth")
+		}
+	}
+	return false
+}
+
+// ConsoleHistoryMsg delivers the history file read for Path.
+type ConsoleHistoryMsg struct {
+	Path  string
+	Lines []string
+}
+
+// loadConsoleHistory reads path's last maxConsoleHistory lines. A file
+// grown well past that is rewritten with just those, so appending stays
+// cheap and the file bounded. A missing file is an empty history.
+func loadConsoleHistory(path string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return ConsoleHistoryMsg{Path: path}
+		}
+		lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+		if len(lines) == 1 && lines[0] == "" {
+			lines = nil
+		}
+		if len(lines) > maxConsoleHistory {
+			trim := len(lines) > 2*maxConsoleHistory
+			lines = lines[len(lines)-maxConsoleHistory:]
+			if trim {
+				_ = os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600)
+			}
+		}
+		return ConsoleHistoryMsg{Path: path, Lines: lines}
+	}
+}
+
+// appendConsoleHistory adds line to path. The file is private to the user:
+// commands can hold values as sensitive as the password lines left out.
+// Failing to write only loses the line from later sessions.
+func appendConsoleHistory(path, line string) tea.Cmd {
+	if path == "" || secretLine(line) {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			return nil
+		}
+		_, _ = f.WriteString(line + "\n")
+		_ = f.Close()
+		return nil
+	}
+}
+
+func handleConsoleHistory(m Model, msg ConsoleHistoryMsg) (tea.Model, tea.Cmd) {
+	c := &m.Console
+	if msg.Path != c.HistoryPath {
+		return m, nil
+	}
+	// Lines sent before the file was read go after it.
+	c.History = append(msg.Lines, c.History...)
+	if len(c.History) > maxConsoleHistory {
+		c.History = c.History[len(c.History)-maxConsoleHistory:]
+	}
+	c.HistoryPos = len(c.History)
+	return m, nil
+}
+
+// searchHistory finds the newest line before from that contains query
+// (case-insensitively), returning its index or -1.
+func searchHistory(history []string, query string, from int) int {
+	q := strings.ToLower(query)
+	for i := min(from, len(history)) - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToLower(history[i]), q) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,249 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Tab on a key argument in the console scans the server for keys starting
+// with what's typed (SCAN MATCH prefix*) and offers them as completions: one
+// match is filled in, several are filled in as far as they agree and cycled
+// with Ctrl+N/Ctrl+P. The scan is bounded so a sparse prefix on a huge
+// keyspace answers quickly with what it found.
+
+const (
+	maxKeyCompletions = 50 // keys offered for one prefix
+	maxKeyScanPages   = 10 // SCAN round trips spent looking for them
+)
+
+// ConsoleKeysMsg delivers the keys found for Line, the input when Tab was
+// pressed. More means the scan stopped before the cursor wrapped.
+type ConsoleKeysMsg struct {
+	Gen   int
+	Line  string
+	Keys  []string
+	More  bool
+	Error error
+}
+
+// keyArgWords are the words commandSyntax uses for key arguments.
+var keyArgWords = map[string]bool{"key": true, "newkey": true, "source": true, "destination": true}
+
+// isKeyArg reports whether argument i (0 being the name) of args is a key:
+// by the positions the server reported, or by the word in commandSyntax.
+func isKeyArg(t redis.CommandTable, args []string, i int) bool {
+	if i == 0 || len(args) == 0 {
+		return false
+	}
+	name := strings.ToUpper(args[0])
+	if info, ok := t[name]; ok {
+		if isKey, known := info.IsKeyArg(i); known {
+			return isKey
+		}
+	}
+	syntax, skip := commandSyntax[name], 1
+	if len(args) > 1 {
+		if s, ok := commandSyntax[name+" "+strings.ToUpper(args[1])]; ok {
+			syntax, skip = s, 2
+		}
+	}
+	words := strings.Fields(syntax)
+	if syntax == "" || i < skip {
+		return false
+	}
+	words = words[skip:]
+	p := i - skip
+	if p >= len(words)-1 && len(words) > 1 && strings.HasPrefix(words[len(words)-1], "...") {
+		p = len(words) - 2 // "key [key ...]": the last word repeats
+	}
+	if p >= len(words) {
+		return false
+	}
+	return keyArgWords[strings.Trim(words[p], "[]")]
+}
+
+// keyWord splits a console line at the argument being typed: what precedes
+// it, the argument so far, and its index. ok is false when the argument
+// can't be completed (it's quoted, or the line doesn't parse).
+func keyWord(line string) (base, prefix string, index int, ok bool) {
+	cut := strings.LastIndexAny(line, " \t") + 1
+	base, prefix = line[:cut], line[cut:]
+	if strings.ContainsAny(prefix, `"'\`) {
+		return "", "", 0, false
+	}
+	args, err := splitCommandLine(base)
+	if err != nil || len(args) == 0 {
+		return "", "", 0, false
+	}
+	return base, prefix, len(args), true
+}
+
+// globEscape makes s match itself in a SCAN MATCH pattern.
+func globEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// quoteArg writes a key so splitCommandLine reads it back unchanged.
+func quoteArg(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n\"'\\") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// completeKeys scans for keys starting with prefix. An I/O error closes the
+// connection, as the console does for its own commands.
+func completeKeys(conn net.Conn, reader *bufio.Reader, line, prefix string, ceiling, gen int) tea.Cmd {
+	return func() tea.Msg {
+		msg := ConsoleKeysMsg{Gen: gen, Line: line}
+		if conn == nil {
+			msg.Error = fmt.Errorf("no connection to Redis")
+			return msg
+		}
+		pattern := globEscape(prefix) + "*"
+		seen := map[string]bool{}
+		cursor, count := "0", firstScanCount(ceiling)
+		for page := 0; page < maxKeyScanPages; page++ {
+			start := time.Now()
+			resp, err := readResp(conn, reader, redis.Scan(cursor, pattern, count))
+			if err != nil {
+				_ = conn.Close()
+				msg.Error = err
+				return msg
+			}
+			count = nextScanCount(count, ceiling, time.Since(start))
+			var batch []string
+			if cursor, batch, err = scanPage(resp); err != nil {
+				msg.Error = err
+				return msg
+			}
+			for _, k := range batch {
+				if !seen[k] {
+					seen[k] = true
+					msg.Keys = append(msg.Keys, k)
+				}
+			}
+			if cursor == "0" || cursor == "" {
+				break
+			}
+			if len(msg.Keys) >= maxKeyCompletions {
+				msg.More = true
+				break
+			}
+		}
+		msg.More = msg.More || (cursor != "0" && cursor != "")
+		sort.Strings(msg.Keys)
+		if len(msg.Keys) > maxKeyCompletions {
+			msg.Keys, msg.More = msg.Keys[:maxKeyCompletions], true
+		}
+		return msg
+	}
+}
+
+// startKeyCompletion sends the scan for the key argument being typed, if
+// that's what the cursor is on. It returns nil otherwise.
+func (m *Model) startKeyCompletion() tea.Cmd {
+	c := &m.Console
+	line := c.Input.Value()
+	if c.Busy || c.Input.Position() != len([]rune(line)) {
+		return nil
+	}
+	base, prefix, i, ok := keyWord(line)
+	if !ok {
+		return nil
+	}
+	args, _ := splitCommandLine(base)
+	if !isKeyArg(m.Commands, args, i) {
+		return nil
+	}
+	c.Busy, c.KeyHint = true, ""
+	conn, reader := m.consoleConn()
+	cmd := completeKeys(conn, reader, line, prefix, m.scanCountMax(), c.Gen)
+	if !c.Pinned {
+		cmd = m.exec(cmd)
+	}
+	return cmd
+}
+
+func handleConsoleKeys(m Model, msg ConsoleKeysMsg) (tea.Model, tea.Cmd) {
+	c := &m.Console
+	if msg.Gen != c.Gen {
+		return m, nil
+	}
+	c.Busy = false
+	if msg.Error != nil {
+		c.KeyHint = "key completion failed: " + msg.Error.Error()
+		return m, nil
+	}
+	if c.Input.Value() != msg.Line {
+		return m, nil // typed on meanwhile
+	}
+	base, prefix, _, _ := keyWord(msg.Line)
+	switch len(msg.Keys) {
+	case 0:
+		c.KeyHint = "no keys match " + globEscape(prefix) + "*"
+		return m, nil
+	case 1:
+		if !msg.More {
+			c.KeyHint = ""
+			c.Input.SetValue(base + quoteArg(msg.Keys[0]) + " ")
+			c.Input.CursorEnd()
+			return m, nil
+		}
+	}
+	lines := make([]string, len(msg.Keys))
+	for i, k := range msg.Keys {
+		lines[i] = base + quoteArg(k)
+	}
+	c.Input.SetSuggestions(append(consoleCompletions(m.Commands), lines...))
+	c.KeyBase = base
+	if common := keysPrefix(msg.Keys); len(common) > len(prefix) && quoteArg(common) == common {
+		c.Input.SetValue(base + common)
+		c.Input.CursorEnd()
+	}
+	more := ""
+	if msg.More {
+		more = "+"
+	}
+	c.KeyHint = fmt.Sprintf("%d%s keys: %s", len(msg.Keys), more, strings.Join(msg.Keys, "  "))
+	return m, nil
+}
+
+// dropKeyCompletions forgets the keys offered once the line has moved off
+// the argument they were for, so a later Tab scans afresh.
+func (m *Model) dropKeyCompletions() {
+	c := &m.Console
+	c.KeyHint = ""
+	if c.KeyBase != "" && !strings.HasPrefix(c.Input.Value(), c.KeyBase) {
+		c.KeyBase = ""
+		c.Input.SetSuggestions(consoleCompletions(m.Commands))
+	}
+}
+
+// keysPrefix is the longest prefix all of keys share, whole runes only.
+func keysPrefix(keys []string) string {
+	lcp := keys[0]
+	for _, k := range keys[1:] {
+		lcp = commonPrefix(lcp, k)
+	}
+	for !utf8.ValidString(lcp) {
+		lcp = lcp[:len(lcp)-1]
+	}
+	return lcp
+}
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Every confirmation is a Dialog. The screen asking fills in what it is
+// about and what each key runs; the Dialog's own Update and View pick the
+// choice a key makes and draw it, and the confirmation screen runs that
+// choice against the root model. n and esc always cancel, back to the screen
+// that asked, which it stacks before calling ask. On a production profile a
+// choice that writes is armed first and the dialog's Target typed to go
+// through (see handleProductionConfirmKey).
+
+// Dialog is a confirmation on show.
+type Dialog struct {
+	Title       string
+	Label       string // what the choices do, above Value
+	Value       string // what they do it to
+	Destructive bool   // can't be taken back: a red, warning title
+	Default     string // the choice Enter takes; "" = Enter does nothing
+	Target      string // typed to confirm on a production profile; "" = changes nothing
+	Choices     []DialogChoice
+}
+
+// DialogChoice is one key of a dialog and what it runs.
+type DialogChoice struct {
+	Key         string
+	Label       string
+	Destructive bool
+	Run         func(Model) (tea.Model, tea.Cmd)
+}
+
+// confirmChoice is the y of a dialog with nothing else to choose.
+func confirmChoice(run func(Model) (tea.Model, tea.Cmd)) []DialogChoice {
+	return []DialogChoice{{Key: "y", Label: "confirm", Destructive: true, Run: run}}
+}
+
+// ask shows d over the current screen, which must already be stacked.
+func (m *Model) ask(d Dialog) {
+	m.Dialog = d
+	m.CurrentState = StateConfirmation
+}
+
+// dialog is the dialog on show. Deletes are asked for from several screens
+// by setting SelectedOp and the active key and field alone, so one without
+// a Dialog of its own is built from those.
+func (m Model) dialog() Dialog {
+	if len(m.Dialog.Choices) > 0 {
+		return m.Dialog
+	}
+	return m.deleteDialog()
+}
+
+// DialogOutcome is what a key pressed in a dialog does.
+type DialogOutcome int
+
+const (
+	DialogIgnored   DialogOutcome = iota // the key picks nothing
+	DialogCancelled                      // n or esc
+	DialogChosen                         // the key picked the choice returned
+)
+
+// Update is what msg does to d: cancel it, pick one of its choices (enter
+// the Default, Y as y), or nothing.
+func (d Dialog) Update(msg tea.KeyMsg) (DialogChoice, DialogOutcome) {
+	key := msg.String()
+	switch key {
+	case "esc", "n", "N":
+		return DialogChoice{}, DialogCancelled
+	case "enter":
+		key = d.Default
+	}
+	if key == "" {
+		return DialogChoice{}, DialogIgnored
+	}
+	for _, c := range d.Choices {
+		if strings.EqualFold(c.Key, key) {
+			return c, DialogChosen
+		}
+	}
+	return DialogChoice{}, DialogIgnored
+}
+
+// View draws d's title, label and value, and the keys of its choices.
+func (d Dialog) View() (body, keys string) {
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true).Render(d.Title)
+	if d.Destructive {
+		title = lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Bold(true).Render("⚠  " + d.Title)
+	}
+	body = "  " + title + "\n\n"
+	if d.Label != "" {
+		body += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Render(d.Label) + "\n"
+	}
+	body += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnText)).Render(d.Value)
+
+	var parts []string
+	for _, c := range d.Choices {
+		color, key := tnGreen, c.Key
+		if c.Destructive {
+			color = tnRed
+		}
+		if c.Key == d.Default {
+			key += " / enter"
+		}
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render("["+key+"] "+c.Label))
+	}
+	parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color(tnFaint)).Render("[n / esc] cancel"))
+	return body, "  " + strings.Join(parts, "    ")
+}
+
+func handleStateConfirmationKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.productionGuarded() {
+		if !m.Prod.Passed {
+			return handleProductionConfirmKey(m, keyMsg)
+		}
+		m.Prod = ProdConfirm{}
+	}
+	c, outcome := m.dialog().Update(keyMsg)
+	switch outcome {
+	case DialogCancelled:
+		m.Dialog = Dialog{}
+		m.CurrentState = m.popState()
+		return m, nil
+	case DialogIgnored:
+		return m, nil
+	}
+	// The screen that asked stays stacked: a failed command lands in
+	// StateOutput and needs it for esc to find its way back. Success
+	// handlers (OpDel, OpHDel, … in handleRedisResult) pop it once they know
+	// the write went through.
+	m.Dialog = Dialog{}
+	return c.Run(m)
+}
+
+// dialogView draws the dialog on show, with the production prompt when the
+// profile asks for one.
+func (m Model) dialogView() string {
+	body, footer := m.dialog().View()
+	if m.productionGuarded() {
+		body += "\n\n  " + m.productionPrompt()
+		if m.Prod.Armed != "" {
+			footer = "  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render("[enter] confirm") + "    " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(tnFaint)).Render("[esc] cancel")
+		}
+	}
+	return bottomFooter(m.headerView()+"\n\n"+body, footerSep(m.WindowWidth)+"\n"+footer, m.WindowHeight)
+}
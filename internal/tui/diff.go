@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxDiffCells bounds the LCS table (len(a) * len(b)). Beyond it lineDiff
+// falls back to a positional comparison, which is cruder for insertions but
+// can't exhaust memory on two huge values.
+const maxDiffCells = 4_000_000
+
+// lineDiff returns a unified-style diff of a and b: each output line is
+// prefixed "  " (common), "- " (only in a) or "+ " (only in b).
+func lineDiff(a, b []string) []string {
+	if len(a)*len(b) > maxDiffCells {
+		return positionalDiff(a, b)
+	}
+	// lcs[i][j] = length of the LCS of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var out []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, "  "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "- "+a[i])
+			i++
+		default:
+			out = append(out, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		out = append(out, "+ "+b[j])
+	}
+	return out
+}
+
+func positionalDiff(a, b []string) []string {
+	var out []string
+	for i := 0; i < max(len(a), len(b)); i++ {
+		switch {
+		case i >= len(a):
+			out = append(out, "+ "+b[i])
+		case i >= len(b):
+			out = append(out, "- "+a[i])
+		case a[i] == b[i]:
+			out = append(out, "  "+a[i])
+		default:
+			out = append(out, "- "+a[i], "+ "+b[i])
+		}
+	}
+	return out
+}
+
+// mapDiff compares two field→value maps (hash fields, zset member→score) and
+// returns "- " / "+ " lines for one-sided fields and "~ " for changed values,
+// sorted by field.
+func mapDiff(a, b map[string]string) (lines []string, same int) {
+	fields := map[string]bool{}
+	for f := range a {
+		fields[f] = true
+	}
+	for f := range b {
+		fields[f] = true
+	}
+	sorted := make([]string, 0, len(fields))
+	for f := range fields {
+		sorted = append(sorted, f)
+	}
+	sort.Strings(sorted)
+
+	for _, f := range sorted {
+		av, inA := a[f]
+		bv, inB := b[f]
+		switch {
+		case !inB:
+			lines = append(lines, fmt.Sprintf("- %s = %s", f, av))
+		case !inA:
+			lines = append(lines, fmt.Sprintf("+ %s = %s", f, bv))
+		case av != bv:
+			lines = append(lines, fmt.Sprintf("~ %s: %s → %s", f, av, bv))
+		default:
+			same++
+		}
+	}
+	return lines, same
+}
+
+// colorizeDiff renders diff output: removals red, additions green, changes
+// yellow, headings and unchanged lines dim.
+func colorizeDiff(s string) string {
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	yellow := lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		switch {
+		case strings.HasPrefix(l, "- "):
+			lines[i] = red.Render(l)
+		case strings.HasPrefix(l, "+ "):
+			lines[i] = green.Render(l)
+		case strings.HasPrefix(l, "~ "):
+			lines[i] = yellow.Render(l)
+		case strings.HasPrefix(l, "  "):
+			lines[i] = dim.Render(l)
+		default:
+			lines[i] = text.Render(l)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
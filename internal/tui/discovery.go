@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Rather than assume every server has every command, the session asks with
+// COMMAND (the COMMAND INFO of everything the server implements) once
+// connected. Menu tools whose commands are missing are shown disabled, and
+// the console completes only what the server will accept. Until the answer
+// arrives, or if the server refuses COMMAND, nothing is disabled.
+
+// opCommands are the commands each menu tool can't work without.
+var opCommands = map[Op][]string{
+	OpExplore:     {"SCAN"},
+	OpGet:         {"GET"},
+	OpSet:         {"SET"},
+	OpCounters:    {"SCAN", "PTTL"},
+	OpFlags:       {"SCAN", "GET", "SET"},
+	OpHSet:        {"HSET"},
+	OpHGet:        {"HGET"},
+	OpRPush:       {"RPUSH"},
+	OpLPush:       {"LPUSH"},
+	OpBlockingPop: {"BLPOP", "BRPOP"},
+	OpQueues:      {"LLEN", "LINDEX"},
+	OpSAdd:        {"SADD"},
+	OpZAdd:        {"ZADD"},
+	OpLeaderboard: {"ZREVRANGE", "ZCARD"},
+	OpXAdd:        {"XADD"},
+	OpDelete:      {"DEL"},
+	OpExport:      {"DUMP"},
+	OpImport:      {"RESTORE"},
+	OpExportDB:    {"SCAN", "DUMP"},
+	OpImportDB:    {"RESTORE"},
+	OpDBDiff:      {"SCAN"},
+	OpBulkTTL:     {"SCAN", "EXPIRE"},
+	OpGrowth:      {"DBSIZE", "SCAN"},
+	OpTTLForecast: {"SCAN", "PTTL"},
+	OpColdKeys:    {"SCAN", "OBJECT"},
+	OpReplace:     {"SCAN", "GET", "SET"},
+	OpSearch:      {"SCAN", "TYPE"},
+	OpTimeline:    {"PSUBSCRIBE", "CONFIG"},
+	OpInfo:        {"INFO"},
+	OpPersistence: {"INFO", "BGSAVE"},
+	OpCluster:     {"CLUSTER"},
+	OpLifecycle:   {"SHUTDOWN"},
+}
+
+// CommandsMsg delivers the command table of the connection Conn.
+type CommandsMsg struct {
+	Conn     net.Conn
+	Commands redis.CommandTable
+}
+
+// discoverCommands asks the server what it implements. A refusal leaves the
+// table nil; an I/O error closes the connection, as the reply stream can't
+// be trusted after it, and the next command reconnects.
+func discoverCommands(conn net.Conn, reader *bufio.Reader) tea.Cmd {
+	return func() tea.Msg {
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{{Name: "COMMAND"}})
+		if err != nil {
+			_ = conn.Close()
+			return CommandsMsg{Conn: conn}
+		}
+		t, _ := redis.ParseCommandTable(replies[0])
+		return CommandsMsg{Conn: conn, Commands: t}
+	}
+}
+
+func handleCommands(m Model, msg CommandsMsg) (tea.Model, tea.Cmd) {
+	if msg.Conn != m.Conn {
+		return m, nil // a connection since replaced
+	}
+	m.Commands = msg.Commands
+	m.markUnsupportedMenu()
+	if m.CurrentState == StateConsole {
+		m.Console.Input.SetSuggestions(consoleCompletions(m.Commands))
+	}
+	return m, nil
+}
+
+// missingCommand returns the first command op needs that the server lacks,
+// or "".
+func (m Model) missingCommand(op Op) string {
+	for _, name := range opCommands[op] {
+		if !m.Commands.Has(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// commandRefusal explains why op is unavailable on this server, or returns "".
+func (m Model) commandRefusal(op Op) string {
+	if name := m.missingCommand(op); name != "" {
+		return fmt.Sprintf("%s is disabled: this server doesn't implement %s.", op, name)
+	}
+	return ""
+}
+
+// markUnsupportedMenu flags the menu rows whose tools the server can't run,
+// so they render dimmed with the missing command.
+func (m *Model) markUnsupportedMenu() {
+	// A copy: tabs cloned from this session share the backing array.
+	items := append([]list.Item(nil), m.MenuList.Items()...)
+	changed := false
+	for i, it := range items {
+		li, ok := it.(ListItem)
+		if !ok {
+			continue
+		}
+		missing := m.missingCommand(ParseOp(li.title))
+		if li.missing != missing {
+			li.missing = missing
+			items[i] = li
+			changed = true
+		}
+	}
+	if changed {
+		m.MenuList.SetItems(items)
+	}
+}
@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The config's "display" rules set how keys in a namespace open from the
+// browser, for keys whose shape is known: a queue fed by RPUSH reads best
+// newest first, a small profile hash as one table rather than a field list.
+
+// DisplayRule sets how keys matching a glob open; the first match applies.
+type DisplayRule struct {
+	Keys string `json:"keys"`
+	// ListFrom "tail" opens a list at its last element, counting back
+	// (LRANGE key -100 -1, reversed); "head", the default, at its first.
+	ListFrom string `json:"list_from,omitempty"`
+	// HashView "table" opens a hash whole (HGETALL) as a field/value table;
+	// "fields", the default, as the field list.
+	HashView string `json:"hash_view,omitempty"`
+	// ZSetView "leaderboard" opens a sorted set as its top entries by score,
+	// kept up to date; "members", the default, as the member list.
+	ZSetView string `json:"zset_view,omitempty"`
+}
+
+// validate reports what is wrong with a configured rule.
+func (r DisplayRule) validate() error {
+	switch {
+	case r.Keys == "":
+		return fmt.Errorf("every display rule needs keys")
+	case r.ListFrom != "" && r.ListFrom != "head" && r.ListFrom != "tail":
+		return fmt.Errorf("display rule %q: list_from must be head or tail", r.Keys)
+	case r.HashView != "" && r.HashView != "fields" && r.HashView != "table":
+		return fmt.Errorf("display rule %q: hash_view must be fields or table", r.Keys)
+	case r.ZSetView != "" && r.ZSetView != "members" && r.ZSetView != "leaderboard":
+		return fmt.Errorf("display rule %q: zset_view must be members or leaderboard", r.Keys)
+	}
+	return nil
+}
+
+// displayRule is the rule for key, or the zero rule (the defaults).
+func (c Config) displayRule(key string) DisplayRule {
+	for _, r := range c.Display {
+		if redis.GlobMatch(r.Keys, key) {
+			return r
+		}
+	}
+	return DisplayRule{}
+}
+
+// openList loads the first page of the active list key, from whichever end
+// its display rule says.
+func (m Model) openList() (tea.Model, tea.Cmd) {
+	m.SelectedOp = OpLRange
+	m.Browser.FromTail = m.Config.displayRule(m.ActiveKey).ListFrom == "tail"
+	return m.switchToLoadingAndExecute(m.sendRead(m.listPage()))
+}
+
+// listPage is the LRANGE for the next page of the active list.
+func (m Model) listPage() redis.RedisCmd {
+	off := m.Browser.FieldOffset
+	if m.Browser.FromTail {
+		return redis.LRange(m.ActiveKey, -(off + fieldPageSize), -(off + 1))
+	}
+	return redis.LRange(m.ActiveKey, off, off+fieldPageSize-1)
+}
+
+// hashPage reads the active hash's field names, never their values: the
+// next HSCAN NOVALUES page where the server has it (Redis 7.4, Valkey 8),
+// otherwise every field with HKEYS.
+func (m Model) hashPage() redis.RedisCmd {
+	if !m.Server.Supports(redis.CapScanNoValues) {
+		return redis.HKeys(m.ActiveKey)
+	}
+	cursor := m.Browser.FieldCursor
+	if cursor == "" {
+		cursor = "0"
+	}
+	return redis.HScanFields(m.ActiveKey, cursor, fieldPageSize)
+}
+
+// listItems lays out a page of elements read by listPage. Read from the
+// tail, they are listed last first, labelled with negative indices — which
+// LSET and LINDEX take as they are.
+func (m Model) listItems(values []string) []list.Item {
+	off := m.Browser.FieldOffset
+	if !m.Browser.FromTail {
+		return fieldItems(values, "", off)
+	}
+	values = slices.Clone(values)
+	slices.Reverse(values)
+	return tailItems(values, -(off + 1))
+}
+
+// openHashTable opens the active hash key as a table of all its fields,
+// unless it is over the size limit, which opens the field list instead.
+func (m Model) openHashTable() (tea.Model, tea.Cmd) {
+	m.SelectedOp = OpHashTable
+	return m.switchToLoadingAndExecute(guardSize(m, "hash", m.sendRead(redis.HGetAll(m.ActiveKey))))
+}
+
+// hashTableResult shows an HGETALL reply through the table renderer, as a
+// JSON object so `v` can switch it to JSON or plain text.
+func hashTableResult(r Result, pairs []string) Result {
+	object := make(map[string]string, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		object[pairs[i]] = pairs[i+1]
+	}
+	data, _ := json.Marshal(object) // strings only; can't fail
+	r.Value = tryPrettyJSON(string(data))
+	r.Stored, r.Renderer = string(data), tableRenderer{}
+	return r
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif" // register the formats image.DecodeConfig reads
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Thumbnails and other binary blobs are often kept in Redis, raw or base64
+// encoded. The image renderer recognises PNG, JPEG and GIF either way and
+// shows a badge with the format, dimensions and size instead of a hex dump
+// or a screenful of base64; "w" then offers to save the decoded bytes.
+
+// imageMagic is the signature each format starts with.
+var imageMagic = []struct{ format, prefix string }{
+	{"png", "\x89PNG\r\n\x1a\n"},
+	{"jpeg", "\xff\xd8\xff"},
+	{"gif", "GIF87a"},
+	{"gif", "GIF89a"},
+}
+
+// ImageValue is an image found in a stored value.
+type ImageValue struct {
+	Format        string // png, jpeg or gif
+	Width, Height int    // 0 when the header can't be read
+	Data          []byte // the image bytes, decoded from base64 if need be
+	Base64        bool
+}
+
+// Ext is the file extension for the image's format.
+func (img ImageValue) Ext() string {
+	if img.Format == "jpeg" {
+		return ".jpg"
+	}
+	return "." + img.Format
+}
+
+// detectImage reports whether value is an image, as raw bytes or as base64
+// (a data: URI's prefix and line breaks allowed).
+func detectImage(value string) (ImageValue, bool) {
+	if format := imageFormat(value); format != "" {
+		return imageValue(format, []byte(value), false), true
+	}
+	text := strings.TrimSpace(value)
+	if _, data, ok := strings.Cut(text, ";base64,"); ok && strings.HasPrefix(text, "data:") {
+		text = data
+	}
+	// Base64 images start with the encoded magic, so don't decode anything else.
+	if !hasAnyPrefix(text, "iVBOR", "/9j/", "_9j_", "R0lGOD") {
+		return ImageValue{}, false
+	}
+	text = strings.NewReplacer("\n", "", "\r", "", " ", "").Replace(text)
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		data, err := enc.DecodeString(text)
+		if err != nil {
+			continue
+		}
+		if format := imageFormat(string(data)); format != "" {
+			return imageValue(format, data, true), true
+		}
+		break
+	}
+	return ImageValue{}, false
+}
+
+func hasAnyPrefix(s string, prefixes ...string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func imageFormat(data string) string {
+	for _, m := range imageMagic {
+		if strings.HasPrefix(data, m.prefix) {
+			return m.format
+		}
+	}
+	return ""
+}
+
+func imageValue(format string, data []byte, b64 bool) ImageValue {
+	img := ImageValue{Format: format, Data: data, Base64: b64}
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		img.Width, img.Height = cfg.Width, cfg.Height
+	}
+	return img
+}
+
+type imageRenderer struct{}
+
+func (imageRenderer) Name() string { return "image" }
+
+func (imageRenderer) CanRender(_, _, value string) bool {
+	_, ok := detectImage(value)
+	return ok
+}
+
+func (imageRenderer) Render(value string) string {
+	img, ok := detectImage(value)
+	if !ok {
+		return hexRenderer{}.Render(value)
+	}
+	badge := strings.ToUpper(img.Format) + " image"
+	if img.Width > 0 {
+		badge += fmt.Sprintf(" · %d×%d", img.Width, img.Height)
+	} else {
+		badge += " · unreadable header"
+	}
+	badge += " · " + formatBytes(len(img.Data))
+	if img.Base64 {
+		badge += " (base64, " + formatBytes(len(value)) + " stored)"
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true).Render(badge) + "\n\n" +
+		dim.Render("w saves the image as "+img.Ext()+" · v shows the stored bytes")
+}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// F12 opens a pane above the footer of any screen with the latest traffic
+// on the session's connections: each command as sent and each reply as
+// parsed, oldest at the top. It answers "why does the screen show this?"
+// without -trace and a hex dump. Keys keep going to the screen underneath.
+
+// InspectorExchanges is how many exchanges the session's recorder keeps.
+const InspectorExchanges = 100
+
+// inspectorLines renders the recorded traffic, one line per command or
+// reply, cut to width.
+func (m Model) inspectorLines(width int) []string {
+	var lines []string
+	for _, e := range m.Recorder.Exchanges() {
+		prefix := e.At.Format("15:04:05.000") + " "
+		if e.Label != m.RedisAddress {
+			prefix += "[" + e.Label + "] "
+		}
+		arrow, style := "‹ ", lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+		if e.Sent {
+			arrow, style = "› ", lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+		}
+		items, rest := parseExchange(e.Data)
+		var texts []string
+		for _, v := range items {
+			if e.Sent {
+				texts = append(texts, commandLine(v))
+			} else {
+				texts = append(texts, replyLine(v))
+			}
+		}
+		if len(rest) > 0 {
+			texts = append(texts, strconv.Quote(string(rest)))
+		}
+		if e.Size > len(e.Data) && len(texts) > 0 {
+			texts[len(texts)-1] += fmt.Sprintf(" … (%s in all)", formatBytes(e.Size))
+		}
+		for _, t := range texts {
+			line := lipgloss.NewStyle().Foreground(lipgloss.Color(tnFaint)).Render(prefix) + style.Render(arrow+t)
+			lines = append(lines, ansi.Truncate(line, width, "…"))
+		}
+	}
+	return lines
+}
+
+// parseExchange reads the RESP values in data, returning what's left over
+// when it ends mid-value (cut short, or inline commands).
+func parseExchange(data []byte) (items []any, rest []byte) {
+	r := bufio.NewReaderSize(bytes.NewReader(data), len(data)) // all of it buffered at once
+	read := 0
+	for read < len(data) {
+		v, err := redis.ReadResp(r)
+		consumed := len(data) - r.Buffered()
+		if err != nil {
+			return items, data[read:]
+		}
+		items = append(items, v)
+		read = consumed
+	}
+	return items, nil
+}
+
+// commandLine is a request as the console would take it.
+func commandLine(v any) string {
+	words, err := redis.AsStringSlice(v)
+	if err != nil {
+		return replyLine(v)
+	}
+	for i, w := range words {
+		words[i] = redis.QuoteArg(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// replyLine is a reply on one line: strings quoted, arrays in brackets and
+// cut after a few elements.
+func replyLine(v any) string {
+	switch r := v.(type) {
+	case string:
+		if r == "(nil)" || redis.IsErrorReply(r) {
+			return r
+		}
+		return strconv.Quote(r)
+	case []any:
+		const shown = 8
+		parts := make([]string, 0, min(len(r), shown)+1)
+		for i, item := range r {
+			if i == shown {
+				parts = append(parts, fmt.Sprintf("… +%d", len(r)-shown))
+				break
+			}
+			parts = append(parts, replyLine(item))
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	return fmt.Sprint(v)
+}
+
+// withInspector draws the inspector pane over the rows above the footer.
+func (m Model) withInspector(frame string) string {
+	if !m.Inspector {
+		return frame
+	}
+	w, h := m.WindowWidth, m.WindowHeight
+	if w <= 0 {
+		w = 80
+	}
+	if h <= 0 {
+		h = 24
+	}
+	rows := max(h/3, 4)
+	title := " protocol inspector · F12 closes "
+	rule := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim)).
+		Render("──" + title + strings.Repeat("─", max(w-2-lipgloss.Width(title), 0)))
+	body := m.inspectorLines(w)
+	if len(body) == 0 {
+		body = []string{lipgloss.NewStyle().Foreground(lipgloss.Color(tnFaint)).Render("  no traffic yet")}
+	}
+	if len(body) > rows-1 {
+		body = body[len(body)-(rows-1):]
+	}
+	pane := append([]string{rule}, body...)
+	for len(pane) < rows {
+		pane = append(pane, "")
+	}
+
+	lines := strings.Split(frame, "\n")
+	for len(lines) < h {
+		lines = append(lines, "")
+	}
+	top := max(h-2-rows, 0) // keep the footer rule and key hints
+	copy(lines[top:], pane)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Session stores are full of JWTs. The jwt renderer decodes one's header
+// and claims into a table, with the registered time claims as dates and how
+// far off they are, so checking why a session expired needs no trip to a
+// decoder website. The signature isn't checked: there is no key to check it
+// with, and the claims are what's being looked at.
+
+// jwtTimeClaims are the claims holding unix times (RFC 7519 and OIDC).
+var jwtTimeClaims = map[string]bool{"exp": true, "iat": true, "nbf": true, "auth_time": true}
+
+// parseJWT splits a compact JWS into its decoded header and claims; ok is
+// false unless both are JSON objects and the header names an algorithm.
+func parseJWT(value string) (header, claims map[string]json.RawMessage, ok bool) {
+	parts := strings.Split(strings.TrimSpace(value), ".")
+	if len(parts) != 3 || len(value) > 64<<10 {
+		return nil, nil, false
+	}
+	if !decodeJWTPart(parts[0], &header) || !decodeJWTPart(parts[1], &claims) {
+		return nil, nil, false
+	}
+	if _, hasAlg := header["alg"]; !hasAlg {
+		return nil, nil, false
+	}
+	return header, claims, true
+}
+
+func decodeJWTPart(part string, into *map[string]json.RawMessage) bool {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	return err == nil && json.Unmarshal(data, into) == nil && *into != nil
+}
+
+// jwtClaimValue is a claim for the table: time claims as their date and
+// how long ago or ahead it is, anything else as tableCell shows JSON.
+func jwtClaimValue(name string, v json.RawMessage, now time.Time) string {
+	if !jwtTimeClaims[name] {
+		return tableCell(v)
+	}
+	secs, err := strconv.ParseInt(string(v), 10, 64)
+	if err != nil {
+		return tableCell(v)
+	}
+	t := time.Unix(secs, 0)
+	when := "in " + formatTTL(int(t.Sub(now).Seconds()))
+	if !t.After(now) {
+		when = formatTTL(int(now.Sub(t).Seconds())) + " ago"
+		if name == "exp" {
+			when = "expired " + when
+		}
+	}
+	return string(v) + "  " + formatDate(t) + " (" + when + ")"
+}
+
+type jwtRenderer struct{}
+
+func (jwtRenderer) Name() string { return "jwt" }
+
+func (jwtRenderer) CanRender(_, _, value string) bool {
+	_, _, ok := parseJWT(value)
+	return ok
+}
+
+func (jwtRenderer) Render(value string) string {
+	header, claims, ok := parseJWT(value)
+	if !ok {
+		return plainRenderer{}.Render(value)
+	}
+	now := time.Now()
+	var hrows, crows [][]string
+	for _, name := range sortedNames(header) {
+		hrows = append(hrows, []string{name, tableCell(header[name])})
+	}
+	for _, name := range sortedNames(claims) {
+		crows = append(crows, []string{name, jwtClaimValue(name, claims[name], now)})
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	return tableText([]string{"header", "value"}, hrows) + "\n\n" +
+		tableText([]string{"claim", "value"}, crows) + "\n\n" +
+		dim.Render("signature not verified · v shows the token")
+}
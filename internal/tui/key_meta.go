@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// A key that stays highlighted in the key list for keyMetaDelay has its
+// type, TTL and size fetched in the background. The list title shows them,
+// and opening the key within keyMetaFresh skips the TYPE round trip and the
+// large-value size check, which the prefetch already answered.
+
+const (
+	keyMetaDelay = 200 * time.Millisecond
+	keyMetaFresh = 10 * time.Second
+	keyMetaMax   = 256 // entries kept before the cache starts over
+)
+
+// KeyMeta is what the prefetch learned about a key. Bytes is STRLEN for a
+// string and MEMORY USAGE otherwise; it and Items are -1 when unknown.
+type KeyMeta struct {
+	Type  string
+	TTL   int
+	Bytes int
+	Items int
+	At    time.Time
+}
+
+// KeyHighlightMsg fires keyMetaDelay after Key was highlighted; Gen tells
+// whether the highlight has moved since.
+type KeyHighlightMsg struct {
+	Key string
+	Gen int
+}
+
+// KeyMetaMsg delivers a prefetch.
+type KeyMetaMsg struct {
+	Key   string
+	Meta  KeyMeta
+	Error error
+}
+
+// lenCmd is the command counting a key's items, by type.
+func lenCmd(keyType, key string) (redis.RedisCmd, bool) {
+	switch keyType {
+	case "hash":
+		return redis.HLen(key), true
+	case "list":
+		return redis.LLen(key), true
+	case "set":
+		return redis.SCard(key), true
+	case "zset":
+		return redis.ZCard(key), true
+	}
+	return redis.RedisCmd{}, false
+}
+
+// fetchKeyMeta reads key's type and TTL, then its size and length.
+func fetchKeyMeta(conn net.Conn, reader *bufio.Reader, key string) tea.Cmd {
+	return func() tea.Msg {
+		msg := KeyMetaMsg{Key: key}
+		if conn == nil {
+			msg.Error = fmt.Errorf("no connection to Redis")
+			return msg
+		}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{redis.Type(key), redis.TTL(key)})
+		if err != nil {
+			_ = conn.Close() // stream may be desynced mid-batch; force a reconnect
+			msg.Error = err
+			return msg
+		}
+		meta := KeyMeta{Bytes: -1, Items: -1}
+		meta.Type, _ = redis.AsString(replies[0])
+		meta.TTL, _ = redis.AsInt(replies[1])
+		var cmds []redis.RedisCmd
+		switch meta.Type {
+		case "none", "":
+		case "string":
+			cmds = []redis.RedisCmd{redis.StrLen(key)}
+		default:
+			cmds = []redis.RedisCmd{{Name: "MEMORY", Args: []string{"USAGE", key}}}
+			if c, ok := lenCmd(meta.Type, key); ok {
+				cmds = append(cmds, c)
+			}
+		}
+		if len(cmds) > 0 {
+			if replies, err = pipeline(conn, reader, cmds); err != nil {
+				_ = conn.Close()
+				msg.Error = err
+				return msg
+			}
+			if n, err := redis.AsInt(replies[0]); err == nil {
+				meta.Bytes = n
+			}
+			if len(replies) > 1 {
+				if n, err := redis.AsInt(replies[1]); err == nil {
+					meta.Items = n
+				}
+			}
+		}
+		meta.At = time.Now()
+		msg.Meta = meta
+		return msg
+	}
+}
+
+// highlightedKey is the key under the cursor in the key list, if any.
+func (m Model) highlightedKey() string {
+	if m.Browser.ViewingFields {
+		return ""
+	}
+	if li, ok := m.Browser.KeyList.SelectedItem().(ListItem); ok && li.action == "" {
+		return li.title
+	}
+	return ""
+}
+
+// watchHighlight starts the prefetch timer when the highlighted key changed.
+func (m *Model) watchHighlight() tea.Cmd {
+	key := m.highlightedKey()
+	if key == m.Browser.Highlighted {
+		return nil
+	}
+	m.Browser.Highlighted = key
+	m.Browser.HighlightGen++
+	if key == "" {
+		return nil
+	}
+	if _, ok := m.freshMeta(key); ok {
+		return nil
+	}
+	gen := m.Browser.HighlightGen
+	return tea.Tick(keyMetaDelay, func(time.Time) tea.Msg { return KeyHighlightMsg{Key: key, Gen: gen} })
+}
+
+func handleKeyHighlight(m Model, msg KeyHighlightMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.Browser.HighlightGen || m.CurrentState != StateBrowser {
+		return m, nil
+	}
+	conn, reader := m.readConn()
+	return m, m.exec(fetchKeyMeta(conn, reader, msg.Key))
+}
+
+func handleKeyMeta(m Model, msg KeyMetaMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		return m, nil // only a prefetch; opening the key reports the trouble
+	}
+	meta := make(map[string]KeyMeta, len(m.Browser.Meta)+1)
+	if len(m.Browser.Meta) < keyMetaMax {
+		// A copy: tabs cloned from this session share the map.
+		for k, v := range m.Browser.Meta {
+			meta[k] = v
+		}
+	}
+	meta[msg.Key] = msg.Meta
+	m.Browser.Meta = meta
+	return m, nil
+}
+
+// freshMeta is the prefetched metadata for key, if recent enough to act on.
+func (m Model) freshMeta(key string) (KeyMeta, bool) {
+	meta, ok := m.Browser.Meta[key]
+	return meta, ok && time.Since(meta.At) < keyMetaFresh
+}
+
+// forgetMeta drops what's known about key after it changed.
+func (m *Model) forgetMeta(key string) {
+	if _, ok := m.Browser.Meta[key]; !ok {
+		return
+	}
+	meta := make(map[string]KeyMeta, len(m.Browser.Meta))
+	for k, v := range m.Browser.Meta {
+		if k != key {
+			meta[k] = v
+		}
+	}
+	m.Browser.Meta = meta
+}
+
+// metaSummary is the one-line description of meta for the key list title,
+// e.g. "hash · 12 fields · 1.2 KiB · expires in 4m".
+func metaSummary(meta KeyMeta) string {
+	if meta.Type == "none" {
+		return "gone"
+	}
+	parts := []string{meta.Type}
+	if meta.Items >= 0 {
+		unit := map[string]string{"hash": "fields", "list": "elements"}[meta.Type]
+		if unit == "" {
+			unit = "members"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", meta.Items, unit))
+	}
+	if meta.Bytes >= 0 {
+		parts = append(parts, formatBytes(meta.Bytes))
+	}
+	if meta.TTL > 0 {
+		parts = append(parts, "expires in "+formatTTL(meta.TTL))
+	}
+	return strings.Join(parts, " · ")
+}
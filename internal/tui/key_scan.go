@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Listing keys keeps scanning, one SCAN page per step, until it has found
+// scanWant keys or the cursor wraps. A narrow pattern on a big keyspace can
+// come back empty page after page, and stopping after the first left the
+// list blank until n had been pressed enough times. Each page reports to the
+// loading screen as it lands; esc stops the scan and lists what it found,
+// with n carrying on from there.
+
+const scanWant = 100 // keys a scan looks for before it stops
+
+// KeyScanModel is the key scan in progress.
+type KeyScanModel struct {
+	Gen     int
+	Active  bool
+	Pattern string
+	Type    string // only keys of this type; "" = any
+	From    string // cursor the scan started from; "0" lists afresh
+	Cursor  string // where the next page starts
+	Keys    []list.Item
+	Pages   int
+	Started time.Time
+}
+
+// KeyScanMsg delivers one page of the scan numbered Gen.
+type KeyScanMsg struct {
+	Gen    int
+	Result RedisResultMsg
+}
+
+// startKeyScan begins listing the keys matching pattern from cursor.
+func (m *Model) startKeyScan(pattern, cursor, wantType string) tea.Cmd {
+	m.KeyScan = KeyScanModel{
+		Gen:     m.KeyScan.Gen + 1,
+		Active:  true,
+		Pattern: pattern,
+		Type:    wantType,
+		From:    cursor,
+		Cursor:  cursor,
+		Started: time.Now(),
+	}
+	return m.keyScanPage()
+}
+
+// rescanKeys lists the keys again from the top with the last pattern, after
+// a write has left the browser's list stale.
+func (m Model) rescanKeys() (tea.Model, tea.Cmd) {
+	m.SelectedOp = OpExplore
+	pattern := m.LastPattern
+	if pattern == "" {
+		pattern = "*"
+	}
+	m.Browser.Cursor = "0"
+	m.Browser.Pattern = pattern
+	scan := m.startKeyScan(pattern, "0", "")
+	return m.switchToLoadingAndExecute(scan)
+}
+
+// keyScanPage scans the next page.
+func (m Model) keyScanPage() tea.Cmd {
+	s := m.KeyScan
+	page := m.scanKeys(s.Pattern, s.Cursor, s.Type)
+	return func() tea.Msg {
+		msg, _ := page().(RedisResultMsg)
+		return KeyScanMsg{Gen: s.Gen, Result: msg}
+	}
+}
+
+func handleKeyScan(m Model, msg KeyScanMsg) (tea.Model, tea.Cmd) {
+	s := &m.KeyScan
+	if msg.Gen != s.Gen || !s.Active {
+		return m, nil // stopped; its keys were listed without this page
+	}
+	page, ok := msg.Result.Result.(ScanResult)
+	if msg.Result.Error != nil || !ok {
+		s.Active = false
+		m.SelectedOp = OpExplore
+		return handleRedisResult(m, msg.Result)
+	}
+	s.Keys = append(s.Keys, page.Keys...)
+	s.Cursor = page.Cursor
+	s.Pages++
+	if page.Count > 0 {
+		m.Browser.ScanHint = nextScanCount(page.Count, m.scanCountMax(), page.Took)
+	}
+	if len(s.Keys) < scanWant && page.Cursor != "0" && page.Cursor != "" && m.CurrentState == StateLoading {
+		return m, m.exec(m.keyScanPage())
+	}
+	return m.finishKeyScan()
+}
+
+// stopKeyScan abandons the page in flight and lists what was found.
+func (m Model) stopKeyScan() (tea.Model, tea.Cmd) {
+	m.KeyScan.Gen++
+	return m.finishKeyScan()
+}
+
+// finishKeyScan lists the keys found as if one SCAN page had returned them.
+func (m Model) finishKeyScan() (tea.Model, tea.Cmd) {
+	s := &m.KeyScan
+	s.Active = false
+	m.Browser.Cursor = s.From
+	m.SelectedOp = OpExplore
+	// Count stays 0: the hint was tuned page by page already.
+	return handleRedisResult(m, RedisResultMsg{Result: ScanResult{Cursor: s.Cursor, Keys: s.Keys}})
+}
+
+// keyScanStatus is the loading screen's line while a scan is under way.
+func (m Model) keyScanStatus() string {
+	s := m.KeyScan
+	if !s.Active || s.Pages == 0 {
+		if m.Load.Pending {
+			return "Loading… · esc cancels"
+		}
+		return "Loading…"
+	}
+	return fmt.Sprintf("Scanning %s… %d found · %d pages in %s · esc stops",
+		s.Pattern, len(s.Keys), s.Pages, time.Since(s.Started).Round(100*time.Millisecond))
+}
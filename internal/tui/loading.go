@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Every load started with switchToLoadingAndExecute is numbered, and its
+// reply comes back as a LoadedMsg carrying that number. esc on the loading
+// screen cancels the load: the screen it was started from comes back, a
+// request still queued on the worker is never sent, and a reply already on
+// its way is dropped when it lands instead of being applied under whatever
+// SelectedOp the user has moved on to; so is the reply to a load that a later
+// one replaced before it landed. A command already written can't be
+// taken back without losing the connection's place in its replies, so it is
+// read to the end and ignored.
+
+// LoadModel is the numbering of loads and the one in progress.
+type LoadModel struct {
+	ID      int64
+	Pending bool      // load ID's reply hasn't landed
+	Op      Operation // what load ID was started for
+	// cancelled is the highest cancelled ID, shared with the requests still
+	// queued so they can skip themselves.
+	cancelled *atomic.Int64
+}
+
+// LoadedMsg delivers the reply to load ID, started for Op. Msg is nil when
+// the load was cancelled before its request was sent.
+type LoadedMsg struct {
+	ID  int64
+	Op  Operation
+	Msg tea.Msg
+}
+
+// startLoad numbers a load of cmd and wraps it to report as a LoadedMsg.
+func (m *Model) startLoad(cmd tea.Cmd) tea.Cmd {
+	if m.Load.cancelled == nil {
+		m.Load.cancelled = new(atomic.Int64)
+	}
+	from := m.Load.Op.From
+	if m.CurrentState != StateLoading {
+		from = m.CurrentState
+	}
+	m.Load.ID++
+	m.Load.Pending = true
+	m.Load.Op = m.operation(from)
+	id, op, cancelled := m.Load.ID, m.Load.Op, m.Load.cancelled
+	return func() tea.Msg {
+		if cmd == nil || id <= cancelled.Load() {
+			return LoadedMsg{ID: id, Op: op}
+		}
+		return LoadedMsg{ID: id, Op: op, Msg: cmd()}
+	}
+}
+
+// handleLoaded passes a load's reply on under the operation it answers,
+// unless the load was cancelled or a later one has replaced it: the model is
+// on the later load's operation, and putting it back on an older one would
+// leave the later reply's screen showing the older key.
+func handleLoaded(m Model, msg LoadedMsg) (tea.Model, tea.Cmd) {
+	if m.Load.cancelled != nil && msg.ID <= m.Load.cancelled.Load() {
+		return m, nil
+	}
+	if msg.ID != m.Load.ID {
+		return m, nil
+	}
+	m.Load.Pending = false
+	switch inner := msg.Msg.(type) {
+	case nil:
+		return m, nil
+	case tea.BatchMsg:
+		return m, tea.Batch(inner...)
+	}
+	m.resume(msg.Op)
+	return m.Update(msg.Msg)
+}
+
+// cancelLoad gives up on the load in progress and goes back to where it was
+// started, unstacking that screen if it was pushed to come back to.
+func (m Model) cancelLoad() (tea.Model, tea.Cmd) {
+	m.Load.cancelled.Store(m.Load.ID)
+	m.Load.Pending = false
+	from := m.Load.Op.From
+	if n := len(m.StateNavigationHistory); n > 0 && m.StateNavigationHistory[n-1] == from {
+		m.popState()
+	}
+	m.CurrentState = from
+	return m, m.notify("Cancelled; a command already sent is read and its reply dropped", false)
+}
+
+func handleStateLoadingKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if keyMsg.String() == "esc" {
+		switch {
+		case m.KeyScan.Active:
+			return m.stopKeyScan()
+		case m.Load.Pending:
+			return m.cancelLoad()
+		}
+	}
+	return m, nil
+}
+
+func (m Model) loadingView() string {
+	spin := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render(m.Spinner.View())
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Render(m.keyScanStatus())
+	return m.headerView() + "\n\n  " + spin + " " + label
+}
@@ -24,13 +24,18 @@ type Model struct {
 	StateNavigationHistory []AppState
 	MenuList               list.Model
 	Input                  InputModel
-	Output                 string
+	Result                 Result
 	ActiveKey              string
 	ActiveField            string
 	ActiveIndex            int
 	ActiveValue            string
 	ActiveTTL              string
+	ActiveIdle             string    // OBJECT IDLETIME of the open string key
+	ActiveExpiry           time.Time // when the open key expires, from its TTL; zero = never
+	ActiveSize             int       // bytes in the open string value, shown with its idle time
 	PreservedTTL           int
+	CaptureOld             bool // the pending SET is an edit, sent with GET to keep what it replaces
+	EditingInt             bool // the string being edited held an integer, so a number may be typed in any base
 	CopyStatus             string
 	SelectedOp             Op
 	PickerOp               Op // original collection command driving the key picker
@@ -39,18 +44,97 @@ type Model struct {
 	Password               string
 	Username               string
 	DB                     int
+	ClientName             string             // CLIENT SETNAME sent on every (re)connect
+	ClientID               int                // CLIENT ID of Conn, shown in the header
+	Server                 redis.ServerInfo   // flavor and version, for capability checks
+	Commands               redis.CommandTable // what the server implements (COMMAND); nil = unknown
+	OnConnect              []string           // profile command lines run after every (re)connect
 	TLSConfig              *tls.Config
 	DialTimeout            time.Duration
 	ReadTimeout            time.Duration
+	LargeValueBytes        int             // ask before fetching values larger than this; 0 = never
+	Dates                  bool            // show human dates next to numbers that look like unix times
+	RawSizes               bool            // show byte counts exactly instead of in KiB/MiB
+	ScanCount              int             // ceiling for the SCAN COUNT hint; 0 = config or DefaultScanCount
+	LargeValue             LargeValue      // the value the size prompt is about
+	Trace                  *redis.Tracer   // logs the raw traffic of every connection (-trace); nil = off
+	Recorder               *redis.Recorder // keeps recent traffic for the F12 inspector; nil = off
+	Audit                  *redis.Auditor  // tags and keeps every command sent, for AUDIT; nil = off
+	AuditFile              string          // where Audit appends the writes; "" = memory only
+	Inspector              bool            // the F12 inspector pane is open
 	ReconnectAttempts      int
+	Reconnect              ReconnectModel
 	LastPattern            string
 	Reader                 *bufio.Reader
+	Worker                 *Worker // serializes commands on Conn
+	ReplicaAddress         string  // the browser reads from this replica when set, or from one found for "auto"; see replica.go
+	ReplicaFound           string  // the replica "auto" found
+	ReplicaCluster         bool    // the primary is a cluster node, so the replica is sent READONLY
+	ReplicaConn            net.Conn
+	ReplicaReader          *bufio.Reader
+	WroteAt                time.Time // last write from the browser; reads stay on the primary a moment after
 	Browser                BrowserModel
 	Spinner                spinner.Model
 	Help                   help.Model
 	Viewport               viewport.Model // scrolls the value / INFO output
+	Form                   FormModel      // multi-field form for tool screens
+	Blocking               BlockingModel
+	Tail                   TailModel
+	Live                   LiveModel
+	Groups                 GroupsModel
+	Pending                PendingModel
+	Queues                 QueuesModel
+	Counters               CountersModel
+	Leader                 LeaderboardModel
+	DBDiff                 DBDiffModel
+	Snapshot               SnapshotModel
+	Persistence            PersistenceModel
+	Cluster                ClusterModel
+	Console                ConsoleModel
+	Templates              TemplatesModel
+	Macros                 MacrosModel
+	KeyMenu                KeyMenuModel
+	CreateKey              CreateKeyModel
+	Convert                ConvertPlan // the conversion being confirmed
+	BulkTTL                BulkTTLModel
+	Growth                 GrowthModel
+	Telemetry              TelemetryModel
+	ColdKeys               ColdKeysModel
+	Replace                ReplaceModel
+	Search                 SearchModel
+	Saved                  SavedSearchesModel
+	Flags                  FlagsModel
+	AuditView              AuditModel
+	EditOnOpen             bool // open the next string key straight into its editor (key menu)
+	PromptOp               Op   // SelectedOp under a rename or TTL prompt, for quietWrite to return to
+	Generate               GenerateModel
+	Benchmark              BenchmarkModel
+	Timeline               TimelineModel
+	Stream                 StreamModel
+	KeyScan                KeyScanModel
+	Dialog                 Dialog    // the confirmation on show
+	Load                   LoadModel // the numbered load the loading screen waits on
+	Tour                   TourModel
+	Export                 ResultExport
+	VimPending             string // first key of a vim chord ("g", "d") waiting for its second
+	Toast                  Toast
+	TourPath               string // marker file recording the tour was seen; "" = don't record
+	HistoryDir             string // where console history is kept per profile; "" = don't keep it
+	SearchesDir            string // where saved searches are kept per profile; "" = nowhere
+	Config                 Config // profiles from the config file
+	ProfileName            string // profile this session connected with, if any
+	ReadOnly               bool   // refuse actions that change the server (-readonly)
+	Production             bool   // profile "production": writes need the key typed to confirm
+	Prod                   ProdConfirm
+	Inline                 bool // send commands in the inline protocol (profile "inline")
+	Proxy                  bool // behind a limited proxy (profile "proxy"); see model_proxy.go
+	ProxyKeys              []string
 	WindowWidth            int
 	WindowHeight           int
+
+	// TabBar is filled in by TabsModel when more than one session is open and
+	// replaces the address in the header line.
+	TabBar string
 }
 
 // outputVPSize returns the viewport dimensions for the output screen — the
@@ -62,6 +146,9 @@ func (m Model) outputVPSize() (w, h int) {
 		w = 10
 	}
 	h = m.WindowHeight - 9
+	if m.showsValueStats() {
+		h--
+	}
 	if h < 3 {
 		h = 3
 	}
@@ -78,13 +165,15 @@ func withOutputViewport(model tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
 	return model, cmd
 }
 
-// colorizeOutput renders m.Output for the value inspector: INFO sections dimmed,
+// colorizeOutput renders a result's value for the value inspector: INFO sections dimmed,
 // JSON syntax-highlighted, everything else plain green.
 func colorizeOutput(output string, op Op) string {
 	trimmed := strings.TrimSpace(output)
 	switch {
-	case op == OpInfo:
+	case op == OpInfo || op == OpNodeInfo:
 		return colorizeInfo(output)
+	case op == OpCompare || op == OpClusterReshard:
+		return colorizeDiff(output)
 	case len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '['):
 		return colorizeJSON(output)
 	default:
@@ -106,7 +195,7 @@ func wrapOutput(s string, w int) string {
 // are correct for key handling).
 func (m *Model) refreshOutputViewport() {
 	w, maxH := m.outputVPSize()
-	content := wrapOutput(colorizeOutput(m.Output, m.SelectedOp), w)
+	content := wrapOutput(m.renderOutput(), w)
 	m.Viewport.Width = w
 	m.Viewport.Height = outputBoxHeight(content, maxH)
 	m.Viewport.SetContent(content)
@@ -138,15 +227,35 @@ func (m Model) headerView() string {
 	app := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true).Render("redis-tui")
 	addr := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim)).
 		Render(fmt.Sprintf("%s · db%d", m.RedisAddress, m.DB))
+	if m.ProfileName != "" {
+		addr = lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Render(m.ProfileName) + " " + addr
+	}
 
 	dotColor, glyph, label := tnGreen, "●", "connected"
+	if f := m.Server.Flavor; f != "" && f != redis.FlavorRedis {
+		label += " · " + m.Server.String()
+	}
+	if m.ClientID > 0 {
+		label += fmt.Sprintf(" · id %d", m.ClientID)
+	}
 	if m.Conn == nil {
 		dotColor, glyph, label = tnRed, "○", "connecting…"
 	}
 	dot := lipgloss.NewStyle().Foreground(lipgloss.Color(dotColor)).Render(glyph)
 	status := dot + " " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim)).Render(label)
+	if m.Snapshot.Active {
+		// Snapshots run in the background, so say so wherever the user is.
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Render(fmt.Sprintf("⏺ snap %d", m.Snapshot.Count)) + "  " + status
+	}
 
 	left := "  " + app + "  " + addr
+	if m.TabBar != "" {
+		left = "  " + app + "  " + m.TabBar
+	}
+	// Telemetry goes first when the bar is too narrow for it.
+	if t := m.telemetryStatus(); t != "" && m.Conn != nil && lipgloss.Width(left)+lipgloss.Width(t)+lipgloss.Width(status)+6 <= w {
+		status = t + "  " + status
+	}
 	gap := w - lipgloss.Width(left) - lipgloss.Width(status) - 2
 	if gap < 1 {
 		gap = 1
@@ -154,6 +263,9 @@ func (m Model) headerView() string {
 	bar := left + strings.Repeat(" ", gap) + status + "  "
 
 	rule := lipgloss.NewStyle().Foreground(lipgloss.Color(tnBorder)).Render(strings.Repeat("─", w))
+	if m.Production {
+		rule = m.productionBanner(w)
+	}
 	return bar + "\n" + rule
 }
 
@@ -244,74 +356,6 @@ func applyBackground(frame string, w, h int) string {
 	return strings.ReplaceAll(wrapped, ansiReset, ansiReset+seq)
 }
 
-// menuView renders the main command menu with grouped, spaced sections exactly
-// like the redesign: a "Select a command" hint (or live filter), the promoted
-// EXPLORE row, then each type group under a dim label. The item rows scroll to
-// keep the selection visible when they exceed the available height.
-func (m Model) menuView(avail int) string {
-	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
-	faint := lipgloss.NewStyle().Foreground(lipgloss.Color(tnFaint))
-
-	var head string
-	switch m.MenuList.FilterState() {
-	case list.Filtering:
-		head = "  " + m.MenuList.FilterInput.View()
-	case list.FilterApplied:
-		// Once a filter is accepted (Enter), the input box above disappears —
-		// without this, "Select a command" would show unchanged and give no
-		// hint that a filter is still narrowing the list, or that esc clears it.
-		head = "  " + dim.Render("Filter: ") + faint.Render(m.MenuList.FilterInput.Value()) + dim.Render("  (esc to clear)")
-	default:
-		head = "  " + dim.Render("Select a command")
-	}
-
-	items := m.MenuList.VisibleItems()
-	filtering := m.MenuList.FilterState() != list.Unfiltered
-	selTitle := ""
-	if sel, ok := m.MenuList.SelectedItem().(ListItem); ok {
-		selTitle = sel.title
-	}
-
-	var lines []string
-	selLine := 0
-	lastGroup := ""
-	for _, it := range items {
-		li, ok := it.(ListItem)
-		if !ok {
-			continue
-		}
-		// Group label above the first item of each group (hidden while filtering).
-		if !filtering && li.group != "" && li.group != lastGroup {
-			lines = append(lines, "  "+dim.Render(li.group))
-			lastGroup = li.group
-		}
-		nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(commandColor(li.title))).Width(menuNameCol)
-		var row string
-		if li.title == selTitle {
-			marker := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render(pointerGlyph)
-			row = marker + nameStyle.Bold(true).Render(li.title) + " " + faint.Render(li.desc)
-			selLine = len(lines)
-		} else {
-			row = "  " + nameStyle.Render(li.title) + " " + faint.Render(li.desc)
-		}
-		lines = append(lines, row, "") // blank line between rows for breathing room
-	}
-
-	// Scroll the item rows to keep the selection visible. head + blank take 2 rows.
-	body := avail - 2
-	if body > 0 && len(lines) > body {
-		start := selLine - body/2
-		if start < 0 {
-			start = 0
-		}
-		if start > len(lines)-body {
-			start = len(lines) - body
-		}
-		lines = lines[start : start+body]
-	}
-	return head + "\n\n" + strings.Join(lines, "\n")
-}
-
 func (m *Model) pushState(state AppState) {
 	n := len(m.StateNavigationHistory)
 	if n > 0 && m.StateNavigationHistory[n-1] == state {
@@ -336,11 +380,22 @@ func (m *Model) popState() AppState {
 }
 
 func (m Model) switchToLoadingAndExecute(cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	load := m.startLoad(cmd)
 	m.CurrentState = StateLoading
 	// Re-seed the spinner tick so it animates on every loading entry.
 	// Without this, the tick chain dies after the first time we leave StateLoading,
 	// and the spinner freezes on all subsequent loads.
-	return m, tea.Batch(m.Spinner.Tick, cmd)
+	return m, tea.Batch(m.Spinner.Tick, m.exec(load))
+}
+
+// switchToLoadingAndDial is switchToLoadingAndExecute for a cmd that opens
+// its own connection. It runs on its own goroutine rather than the shared
+// connection's worker, so a slow or unreachable host holds up only this
+// screen, not every command queued behind the dial.
+func (m Model) switchToLoadingAndDial(cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	load := m.startLoad(cmd)
+	m.CurrentState = StateLoading
+	return m, tea.Batch(m.Spinner.Tick, load)
 }
 
 func (m Model) Init() tea.Cmd {
@@ -348,15 +403,28 @@ func (m Model) Init() tea.Cmd {
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.Production && m.holdForProduction(msg) {
+		return m, nil
+	}
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		// handle keyboard events
 		switch msg.String() {
 		case "ctrl+c":
 			return m, tea.Quit
+		case "f12":
+			m.Inspector = !m.Inspector
+			return m, nil
+		}
+		// Keys would act on a screen that isn't shown.
+		if m.tooSmall() {
+			return m, nil
 		}
 
 	case BackMsg:
+		if m.CurrentState == StateInputFilePath && m.SelectedOp == OpExportResult {
+			m.SelectedOp = m.Export.Op // save prompt cancelled
+		}
 		m.CurrentState = m.popState()
 		if m.CurrentState == StateMenu {
 			m.Browser.Picking = false // leaving the key picker back to the menu
@@ -394,6 +462,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case SelectKeyMsg:
+		if m.Live.Key != msg.Key {
+			m.stopLive()
+		}
 		m.ActiveKey = msg.Key
 
 		// Picker + add command: the user picked an existing collection to add to,
@@ -411,27 +482,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.Browser.Picking && m.PickerOp == OpExport {
 			// Stay in the picker (Picking stays true) so returning to the key
 			// list still exports on the next selection rather than browsing.
-			m.SelectedOp = OpExport
-			m.ActiveKey = msg.Key
-			m.pushState(m.CurrentState)
-			m.Input.Input.SetValue("./" + sanitizeFilename(msg.Key) + ".dump")
-			m.Input.Input.CursorEnd()
-			m.Input.Input.Focus()
-			m.Input.Type = InputFilePath
-			m.Input.Hint = "Destination file for " + msg.Key + ":"
-			m.CurrentState = StateInputFilePath
-			return m, nil
+			return m.promptKeyExport(msg.Key), nil
 		}
 
 		m.pushState(m.CurrentState)
 
-		cmd := redis.RedisCmd{
-			Name: "TYPE",
-			Args: []string{m.ActiveKey},
-		}
-
 		m.SelectedOp = OpCheckType
-		return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+		if meta, ok := m.freshMeta(m.ActiveKey); ok {
+			return handleRedisResult(m, RedisResultMsg{Result: meta.Type}) // prefetched
+		}
+		return m.switchToLoadingAndExecute(m.sendRead(redis.Type(m.ActiveKey)))
 
 	case InputCompleteMsg:
 		// Handle the data based on what kind of input it was
@@ -444,7 +504,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.Input.RecentPatterns = prependPattern(m.Input.RecentPatterns, m.ActiveKey)
 				m.Browser.Cursor = "0"
 				m.Browser.Pattern = m.ActiveKey
-				return m.switchToLoadingAndExecute(scanRedisKeys(m.Conn, m.Reader, m.ActiveKey, "0"))
+				scan := m.startKeyScan(m.ActiveKey, "0", "")
+				return m.switchToLoadingAndExecute(scan)
 			}
 
 		case InputKey:
@@ -462,15 +523,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// decide where to go next
 			switch m.SelectedOp {
 			case OpGet:
-				// send command
-				cmd := redis.RedisCmd{
-					Name: m.SelectedOp.String(),
-					Args: []string{m.ActiveKey},
-				}
-
-				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+				return m.openString()
 
 			case OpSet, OpRPush, OpLPush, OpSAdd:
+				m.CaptureOld, m.EditingInt = false, false
 				m.pushState(m.CurrentState)
 				m.CurrentState = StateInputValue
 				m.Input.Type = InputValue
@@ -490,14 +546,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.Input.Input.SetValue("")
 
 			case OpHGet:
-				cmd := redis.RedisCmd{
-					Name: "HKEYS",
-					Args: []string{m.ActiveKey},
-				}
-
-				m.SelectedOp = OpHKeys
-
-				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+				return m.openHash()
 
 			case OpDelete:
 				// Route through the same confirm-before-delete screen the
@@ -506,7 +555,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.ActiveField = ""
 				m.SelectedOp = OpDel
 				m.pushState(m.CurrentState)
-				m.CurrentState = StateConfirmation
+				m.ask(m.deleteDialog())
 				return m, nil
 
 			}
@@ -534,13 +583,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			switch m.SelectedOp {
 			case OpSet:
-				// send command
-				cmd := redis.RedisCmd{
-					Name: m.SelectedOp.String(),
-					Args: []string{m.ActiveKey, m.ActiveValue},
-				}
-
-				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, m.setCmd(), m.ReadTimeout))
 
 			case OpHSet, OpZAdd:
 				// send command
@@ -559,36 +602,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
 
-			case OpRPush, OpLPush, OpSAdd:
-				// send command
+			case OpRPush, OpLPush, OpSAdd, OpQueuePush:
+				values := []string{m.ActiveValue}
+				if m.SelectedOp != OpQueuePush {
+					values = splitValues(m.ActiveValue)
+				}
 				cmd := redis.RedisCmd{
 					Name: m.SelectedOp.String(),
-					Args: []string{m.ActiveKey, m.ActiveValue},
+					Args: append([]string{m.ActiveKey}, values...),
 				}
 
 				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
 
 			case OpRename:
-				cmd := redis.RedisCmd{
-					Name: "RENAME",
-					Args: []string{m.ActiveKey, m.ActiveValue},
-				}
+				cmd := redis.Rename(m.ActiveKey, m.ActiveValue)
+				m.invalidate(m.ActiveKey)
 				m.ActiveKey = m.ActiveValue // keep model in sync
 				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
 
 			case OpExpirySet:
+				m.invalidate(m.ActiveKey)
 				if m.ActiveValue == "0" {
-					cmd := redis.RedisCmd{
-						Name: "PERSIST",
-						Args: []string{m.ActiveKey},
-					}
-					return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+					return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.Persist(m.ActiveKey), m.ReadTimeout))
 				}
-				cmd := redis.RedisCmd{
-					Name: "EXPIRE",
-					Args: []string{m.ActiveKey, m.ActiveValue},
+				secs, err := strconv.Atoi(m.ActiveValue)
+				if err != nil {
+					m.Result = errorResult(fmt.Errorf("TTL must be a whole number of seconds, got %q", m.ActiveValue))
+					m.CurrentState = StateOutput
+					return withOutputViewport(m, nil)
 				}
-				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.Expire(m.ActiveKey, secs), m.ReadTimeout))
 
 			}
 
@@ -601,13 +644,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case OpImport:
 				return m.switchToLoadingAndExecute(ImportKeys(m.Conn, m.Reader, filePath))
 			case OpExportDB:
-				return m.switchToLoadingAndExecute(ExportFullDB(m.Conn, m.Reader, m.DB, filePath))
+				return m.switchToLoadingAndExecute(ExportFullDB(m.Conn, m.Reader, m.DB, m.scanCountMax(), filePath))
 			case OpImportDB:
 				return m.switchToLoadingAndExecute(ImportKeys(m.Conn, m.Reader, filePath))
 			case OpExportField:
 				return m.switchToLoadingAndExecute(ExportField(m.Conn, m.Reader, m.ActiveKey, m.Browser.ActiveKeyType, m.ActiveField, m.ActiveIndex, filePath))
 			case OpImportField:
 				return m.switchToLoadingAndExecute(ImportField(m.Conn, m.Reader, filePath, m.ActiveKey, m.Browser.ActiveKeyType))
+			case OpDBDiffExport:
+				return m.switchToLoadingAndExecute(writeDBDiffReport(m.DBDiff, filePath))
+			case OpExportResult:
+				return submitResultExport(m, filePath)
 			}
 		}
 
@@ -626,35 +673,45 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// actually being browsed.
 		switch m.Browser.ActiveKeyType {
 		case "hash":
-			cmd := redis.RedisCmd{
-				Name: "HGET",
-				Args: []string{m.ActiveKey, m.ActiveField},
-			}
 			m.SelectedOp = OpHGet
-			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+			return m.switchToLoadingAndExecute(m.sendRead(redis.HGet(m.ActiveKey, m.ActiveField)))
 
 		case "list":
 			m.SelectedOp = OpExploreList
-			m.Output = m.ActiveField
+			m.Result = m.withRenderer(textResult(m.ActiveField), m.ActiveField)
 			m.CurrentState = StateOutput
 			m.refreshOutputViewport()
 
 		case "set":
 			// Set members are their own values; display directly.
 			m.SelectedOp = OpExploreSet
-			m.Output = m.ActiveField
+			m.Result = m.withRenderer(textResult(m.ActiveField), m.ActiveField)
 			m.CurrentState = StateOutput
 			m.refreshOutputViewport()
 
 		case "zset":
-			// The score is visible in the list description but not surfaced
+			// The score is visible in the list's score column but not surfaced
 			// here — the user can copy the member name with 'c'.
 			m.SelectedOp = OpExploreZSet
-			m.Output = m.ActiveField
+			m.Result = m.withRenderer(textResult(m.ActiveField), m.ActiveField)
 			m.CurrentState = StateOutput
 			m.refreshOutputViewport()
 		}
 
+	case ToggleDatesMsg:
+		m.Dates = !m.Dates
+		return m, m.Browser.FieldsList.SetItems(annotateScores(m.Browser.FieldsList.Items(), m.Dates))
+
+	case ExpandFieldMsg:
+		m.pushState(m.CurrentState)
+		m.ActiveField = msg.Field
+		m.SelectedOp = OpFullValue
+		m.Result = textResult(msg.Field)
+		m.ActiveTTL = ""
+		m.CopyStatus = ""
+		m.CurrentState = StateOutput
+		m.refreshOutputViewport()
+
 	case DeleteRequestMsg:
 		m.ActiveKey = msg.Key
 		m.ActiveField = msg.Field
@@ -678,10 +735,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.pushState(m.CurrentState)
-		m.CurrentState = StateConfirmation
+		m.ask(m.deleteDialog())
+
+	case CreateKeyRequestMsg:
+		return m.startCreateKey()
+
+	case CreateKeyResultMsg:
+		return handleCreateKeyResult(m, msg)
+
+	case KeyMenuRequestMsg:
+		return m.startKeyMenu(msg.Key)
+
+	case MacroRequestMsg:
+		return m.startMacros(msg.Key)
+
+	case SavedSearchesRequestMsg:
+		return m.startSavedSearches()
+
+	case SavedSearchesMsg:
+		return handleSavedSearches(m, msg)
+
+	case SavedSearchesWrittenMsg:
+		return handleSavedSearchesWritten(m, msg)
+
+	case SampleRequestMsg:
+		return m.startSample(msg)
+
+	case MacroResultMsg:
+		return withOutputViewport(handleMacroResult(m, msg))
 
 	case RenameRequestMsg:
 		m.ActiveKey = msg.Key
+		m.PromptOp = m.SelectedOp
 		m.SelectedOp = OpRename
 		m.Input.Input.SetValue(msg.Key)
 		m.Input.Type = InputValue
@@ -728,13 +813,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd redis.RedisCmd
 		switch msg.Type {
 		case "hash":
-			cmd = redis.RedisCmd{Name: "HSET", Args: []string{msg.Key, msg.A, msg.B}}
+			cmd = redis.HSet(msg.Key, msg.A, msg.B)
 		case "zset":
-			cmd = redis.RedisCmd{Name: "ZADD", Args: []string{msg.Key, msg.B, msg.A}}
+			cmd = redis.ZAdd(msg.Key, msg.B, msg.A)
 		case "set":
-			cmd = redis.RedisCmd{Name: "SADD", Args: []string{msg.Key, msg.A}}
+			values := splitValues(msg.A)
+			cmd = redis.SAdd(msg.Key, values[0], values[1:]...)
 		case "list":
-			cmd = redis.RedisCmd{Name: "RPUSH", Args: []string{msg.Key, msg.A}}
+			values := splitValues(msg.A)
+			cmd = redis.RPush(msg.Key, values[0], values[1:]...)
 		default:
 			return m, nil
 		}
@@ -746,6 +833,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.WindowHeight = msg.Height
 		m.Input.Width = msg.Width
 		m.Input.Height = msg.Height
+		m.Form.Width = msg.Width
+		m.Form.Height = msg.Height
 
 		// List area fills the screen between the 2-line header and the 2-line
 		// footer (separator rule + key-hint line).
@@ -766,7 +855,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Viewport.Height = vh
 
 	case TickMsg:
-		return m, connectToRedis(m)
+		return handleReconnectTick(m, msg)
 
 	case spinner.TickMsg:
 		if m.CurrentState == StateLoading {
@@ -778,8 +867,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case RedisTTLResultMsg:
 		if msg.TTL == -1 || msg.TTL == -2 {
 			m.ActiveTTL = "no expiry"
+			m.ActiveExpiry = time.Time{}
 		} else {
 			m.ActiveTTL = strconv.Itoa(msg.TTL) + " s"
+			m.ActiveExpiry = time.Now().Add(time.Duration(msg.TTL) * time.Second)
+		}
+		m.ActiveIdle = ""
+		return m, nil
+
+	case LargeValueMsg:
+		return handleLargeValue(m, msg)
+
+	case StringInspectMsg:
+		return withOutputViewport(handleStringInspect(m, msg))
+
+	case KeyHighlightMsg:
+		return handleKeyHighlight(m, msg)
+
+	case KeyMetaMsg:
+		return handleKeyMeta(m, msg)
+
+	case ToastExpiredMsg:
+		if msg.Seq == m.Toast.Seq {
+			m.Toast = Toast{}
 		}
 		return m, nil
 
@@ -792,38 +902,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case LoadMoreKeysMsg:
 		if m.Browser.Picking && m.Browser.PickerType != "" {
-			return m.switchToLoadingAndExecute(scanRedisKeysOfType(m.Conn, m.Reader, m.Browser.Pattern, m.Browser.Cursor, m.Browser.PickerType))
+			scan := m.startKeyScan(m.Browser.Pattern, m.Browser.Cursor, m.Browser.PickerType)
+			return m.switchToLoadingAndExecute(scan)
 		}
-		return m.switchToLoadingAndExecute(scanRedisKeys(m.Conn, m.Reader, m.Browser.Pattern, m.Browser.Cursor))
+		scan := m.startKeyScan(m.Browser.Pattern, m.Browser.Cursor, "")
+		return m.switchToLoadingAndExecute(scan)
 
 	case LoadMoreFieldsMsg:
 		switch m.SelectedOp {
+		case OpHKeys:
+			return m.switchToLoadingAndExecute(m.sendRead(m.hashPage()))
 		case OpExploreList:
-			end := m.Browser.FieldOffset + fieldPageSize - 1
-			cmd := redis.RedisCmd{Name: "LRANGE", Args: []string{m.ActiveKey, strconv.Itoa(m.Browser.FieldOffset), strconv.Itoa(end)}}
 			m.SelectedOp = OpLRange
-			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+			return m.switchToLoadingAndExecute(m.sendRead(m.listPage()))
 		case OpExploreSet:
-			cmd := redis.RedisCmd{Name: "SSCAN", Args: []string{m.ActiveKey, m.Browser.FieldCursor, "COUNT", strconv.Itoa(fieldPageSize)}}
+			cmd := redis.SScan(m.ActiveKey, m.Browser.FieldCursor, fieldPageSize)
 			m.SelectedOp = OpSMembers
-			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+			return m.switchToLoadingAndExecute(m.sendRead(cmd))
 		case OpExploreZSet:
 			end := m.Browser.FieldOffset + fieldPageSize - 1
-			cmd := redis.RedisCmd{Name: "ZRANGE", Args: []string{m.ActiveKey, strconv.Itoa(m.Browser.FieldOffset), strconv.Itoa(end), "WITHSCORES"}}
+			cmd := redis.ZRange(m.ActiveKey, m.Browser.FieldOffset, end, true)
 			m.SelectedOp = OpZRange
-			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+			return m.switchToLoadingAndExecute(m.sendRead(cmd))
 		}
 
 	case RefreshMsg:
+		m.Browser.Cache = nil
 		if m.Browser.ViewingFields {
 			// Instead of trusting stale state, let's confidently check the key TYPE again.
 			// This automatically drops into OpCheckType, which correctly re-routes to
 			// OpHKeys, OpExploreList, etc., OR catches if the key was deleted in the meantime!
 			m.SelectedOp = OpCheckType
-			cmd := redis.RedisCmd{Name: "TYPE", Args: []string{m.ActiveKey}}
-			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+			cmd := redis.Type(m.ActiveKey)
+			return m.switchToLoadingAndExecute(m.sendRead(cmd))
 		} else {
 			// Top-level key scan
+			m.Browser.Meta = nil
 			m.SelectedOp = OpExplore
 			pattern := m.Browser.Pattern
 			if pattern == "" {
@@ -831,284 +945,304 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.Browser.Cursor = "0"
 			if m.Browser.Picking && m.Browser.PickerType != "" {
-				return m.switchToLoadingAndExecute(scanRedisKeysOfType(m.Conn, m.Reader, pattern, "0", m.Browser.PickerType))
+				scan := m.startKeyScan(pattern, "0", m.Browser.PickerType)
+				return m.switchToLoadingAndExecute(scan)
 			}
-			return m.switchToLoadingAndExecute(scanRedisKeys(m.Conn, m.Reader, pattern, "0"))
+			scan := m.startKeyScan(pattern, "0", "")
+			return m.switchToLoadingAndExecute(scan)
 		}
+	case LoadedMsg:
+		return handleLoaded(m, msg)
+
 	case RedisResultMsg:
 		return withOutputViewport(handleRedisResult(m, msg))
-	}
 
-	switch m.CurrentState {
-	case StateMenu:
-		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			isFiltering := m.MenuList.FilterState() == list.Filtering
-
-			switch keyMsg.String() {
-			case "enter":
-				// While the filter input is active, let the list handle Enter
-				// (it confirms the filter and moves to FilterApplied state).
-				if !isFiltering {
-					selectedItem := m.MenuList.SelectedItem()
-					if selectedItem, ok := selectedItem.(ListItem); ok {
-						m.SelectedOp = ParseOp(selectedItem.title)
-
-						// save state history
-						m.pushState(m.CurrentState)
-
-						switch m.SelectedOp {
-						case OpSet, OpGet, OpDelete:
-							m.Input.Input.Focus()
-							m.Input.Input.SetValue("") // Clear previous input
-							m.CurrentState = StateInputKey
-							m.Input.Type = InputKey
-						case OpHSet, OpHGet, OpSAdd, OpZAdd, OpRPush, OpLPush:
-							// Key picker: choose an existing key of the matching
-							// type (or "＋ new key…"). Browse via the Explore machinery.
-							m.PickerOp = m.SelectedOp
-							m.Browser.Picking = true
-							m.Browser.PickerType = collectionType(m.SelectedOp)
-							m.Browser.ViewingFields = false
-							m.Browser.Cursor = "0"
-							m.Browser.Pattern = "*"
-							m.SelectedOp = OpExplore
-							return m.switchToLoadingAndExecute(scanRedisKeysOfType(m.Conn, m.Reader, "*", "0", m.Browser.PickerType))
-						case OpExport:
-							// Key picker over all keys; selecting one pre-fills a
-							// sensible ./<key>.dump destination path.
-							m.PickerOp = OpExport
-							m.Browser.Picking = true
-							m.Browser.PickerType = "" // any type
-							m.Browser.ViewingFields = false
-							m.Browser.Cursor = "0"
-							m.Browser.Pattern = "*"
-							m.SelectedOp = OpExplore
-							return m.switchToLoadingAndExecute(scanRedisKeys(m.Conn, m.Reader, "*", "0"))
-						case OpExportDB:
-							m.Input.Input.Focus()
-							m.Input.Input.SetValue(fmt.Sprintf("./redis-db%d.json", m.DB))
-							m.Input.Input.CursorEnd()
-							m.Input.Hint = "Destination file (database → JSON):"
-							m.CurrentState = StateInputFilePath
-							m.Input.Type = InputFilePath
-						case OpImport:
-							m.Input.Input.Focus()
-							m.Input.Input.SetValue("")
-							m.Input.Hint = "Source .dump file to restore:"
-							m.CurrentState = StateInputFilePath
-							m.Input.Type = InputFilePath
-						case OpImportDB:
-							m.Input.Input.Focus()
-							m.Input.Input.SetValue("")
-							m.Input.Hint = "Source .json file to import:"
-							m.CurrentState = StateInputFilePath
-							m.Input.Type = InputFilePath
-						case OpExplore:
-							m.Input.Input.Focus()
-							m.Input.Input.SetValue("*") // Default search is everything
-							m.CurrentState = StateInputKey
-							m.Input.Type = InputPattern
-						case OpInfo:
-							cmd := redis.RedisCmd{
-								Name: "INFO",
-							}
-							return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
-						}
-					}
-				}
-			case "q":
-				// q quits directly from the main menu (no confirmation).
-				// While filtering, let the list handle it as filter input.
-				if !isFiltering {
-					return m, tea.Quit
-				}
-			case "esc":
-				// esc cancels the filter while typing, or clears an
-				// already-applied filter — either way, let the list handle
-				// it. isFiltering above only covers the actively-typing case,
-				// so it's checked separately here: without this, esc on a
-				// filtered-but-not-currently-typing menu (i.e. right after
-				// pressing Enter to accept a filter) would do nothing at all,
-				// permanently stranding the menu on the filtered subset with
-				// no way back short of quitting the app.
-				if m.MenuList.FilterState() != list.Unfiltered {
-					break
-				}
-				// On the idle, unfiltered menu esc is a no-op — the menu is
-				// the root screen, nothing to go back to.
-				return m, nil
-			default:
-				// Any printable character while the filter is not yet active
-				// automatically opens the filter input, then the character(s)
-				// are forwarded so they appear immediately in the search box.
-				// Runes can arrive batched (fast typing, paste, or laggy input)
-				// rather than one KeyMsg per character, so this must not
-				// require exactly one rune or those keystrokes are silently
-				// dropped instead of opening the filter.
-				if !isFiltering && len(keyMsg.Runes) >= 1 {
-					m.MenuList, _ = m.MenuList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
-				}
-			}
+	case FormSubmitMsg:
+		switch m.SelectedOp {
+		case OpBlockingPop:
+			return submitBlockingForm(m, msg.Values)
+		case OpQueues:
+			return withOutputViewport(submitQueuesForm(m, msg.Values))
+		case OpCounters:
+			return withOutputViewport(submitCountersForm(m, msg.Values))
+		case OpFlags:
+			return withOutputViewport(submitFlagsForm(m, msg.Values))
+		case OpLeaderboard:
+			return withOutputViewport(submitLeaderboardForm(m, msg.Values))
+		case OpLeaderIncr:
+			return submitLeaderIncrForm(m, msg.Values)
+		case OpCompare:
+			return submitCompareForm(m, msg.Values)
+		case OpDBDiff:
+			return submitDBDiffForm(m, msg.Values)
+		case OpSnapshot:
+			return submitSnapshotForm(m, msg.Values)
+		case OpLifecycle:
+			return submitLifecycleForm(m, msg.Values)
+		case OpTemplate:
+			return submitTemplateForm(m, msg.Values)
+		case OpCreateKey:
+			return submitCreateKeyForm(m, msg.Values)
+		case OpConvert:
+			return submitConvertForm(m, msg.Values)
+		case OpBulkTTL:
+			return submitBulkTTLForm(m, msg.Values)
+		case OpGrowth:
+			return withOutputViewport(submitGrowthForm(m, msg.Values))
+		case OpTTLForecast:
+			return submitTTLForecastForm(m, msg.Values)
+		case OpColdKeys:
+			return submitColdKeysForm(m, msg.Values)
+		case OpColdExpire:
+			return submitColdExpireForm(m, msg.Values)
+		case OpReplace:
+			return submitReplaceForm(m, msg.Values)
+		case OpSearch:
+			return submitSearchForm(m, msg.Values)
+		case OpSaveSearch:
+			return submitSaveSearch(m, msg.Values)
+		case OpGenerate:
+			return submitGenerateForm(m, msg.Values)
+		case OpBenchmark:
+			return submitBenchmarkForm(m, msg.Values)
+		case OpTimeline:
+			return submitTimelineForm(m, msg.Values)
+		case OpSample:
+			return submitSampleForm(m, msg.Values)
+		case OpXAdd:
+			return submitXAddForm(m, msg.Values)
+		case OpGroupCreate:
+			return submitGroupCreateForm(m, msg.Values)
+		case OpGroupSetID:
+			return submitGroupSetIDForm(m, msg.Values)
+		case OpAutoClaim:
+			return submitAutoClaimForm(m, msg.Values)
+		case OpPendingClaim:
+			return submitPendingClaimForm(m, msg.Values)
 		}
 
-		updatedModel, cmd := m.MenuList.Update(msg)
-		m.MenuList = updatedModel
-		return m, cmd
+	case BlockingConnMsg:
+		return withOutputViewport(handleBlockingConn(m, msg))
 
-	case StateInputKey, StateInputField, StateInputValue, StateInputFilePath:
-		inputModel, cmd := m.Input.Update(msg)
-		m.Input = inputModel
-		return m, cmd
+	case BlockingPopMsg:
+		return handleBlockingPop(m, msg)
 
-	case StateOutput:
-		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			return handleStateOutputKey(m, keyMsg)
-		}
+	case TailConnMsg:
+		return withOutputViewport(handleTailConn(m, msg))
 
-	case StateBrowser:
-		browserModel, cmd := m.Browser.Update((msg))
-		m.Browser = browserModel
-		return m, cmd
+	case TailReadMsg:
+		return handleTailRead(m, msg)
 
-	case StateConfirmation:
-		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			return handleStateConfirmationKey(m, keyMsg)
-		}
+	case LiveToggleMsg:
+		return m.toggleLive(msg.Key)
 
-	}
+	case LiveConnMsg:
+		return handleLiveConn(m, msg)
 
-	return m, nil
-}
+	case LiveEventMsg:
+		return handleLiveEvent(m, msg)
 
-// View paints the active screen and fills the whole terminal with the base
-// background.
-func (m Model) View() string {
-	return applyBackground(m.viewContent(), m.WindowWidth, m.WindowHeight)
-}
+	case LiveRefreshMsg:
+		return handleLiveRefresh(m, msg)
 
-func (m Model) viewContent() string {
-	header := m.headerView()
+	case LiveAgeMsg:
+		return m, nil
 
-	h := m.Help
-	h.Width = m.WindowWidth
+	case QueueSampleMsg:
+		return withOutputViewport(handleQueueSample(m, msg))
 
-	switch m.CurrentState {
-	case StateMenu:
-		avail := m.WindowHeight - 4 // header(2) + footer rule(1) + help(1)
-		body := header + "\n" + m.menuView(avail)
-		foot := footerSep(m.WindowWidth) + "\n  " + h.View(menuKeys)
-		return bottomFooter(body, foot, m.WindowHeight)
+	case QueueTickMsg:
+		return handleQueueTick(m, msg)
 
-	case StateInputKey, StateInputField, StateInputValue, StateInputFilePath:
-		return header + "\n" + m.Input.View()
+	case CounterSampleMsg:
+		return withOutputViewport(handleCounterSample(m, msg))
 
-	case StateOutput:
-		var helpView string
-		switch m.SelectedOp {
-		case OpInfo:
-			helpView = "  " + h.View(infoOutputKeys)
-		case OpExploreSet, OpExploreZSet:
-			helpView = "  " + h.View(memberOutputKeys)
-		default:
-			helpView = "  " + h.View(outputKeys)
-		}
+	case CounterTickMsg:
+		return handleCounterTick(m, msg)
 
-		// "Output: ..." label line. m.ActiveKey is stale for operations that
-		// aren't scoped to a single key (server INFO, whole-database export
-		// /import) — showing it there would just be whatever key was last
-		// browsed, so swap in a label that actually describes the screen.
-		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
-		keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
-		outputSubject := m.ActiveKey
-		switch m.SelectedOp {
-		case OpInfo:
-			outputSubject = "Server INFO"
-		case OpExportDB, OpImportDB:
-			outputSubject = fmt.Sprintf("Database %d", m.DB)
-		}
-		label := "  " + labelStyle.Render("Output: ") + keyStyle.Render(outputSubject)
-
-		// The value/INFO content lives in a scrollable viewport so long output
-		// never overflows or loses its top. Render from a local copy with the
-		// content set (the scroll offset persists on m.Viewport).
-		contentWidth := m.WindowWidth - 8
-		if contentWidth <= 0 {
-			contentWidth = 72
-		}
-		vp := m.Viewport
-		var maxH int
-		vp.Width, maxH = m.outputVPSize()
-		content := wrapOutput(colorizeOutput(m.Output, m.SelectedOp), vp.Width)
-		vp.Height = outputBoxHeight(content, maxH)
-		vp.SetContent(content)
-		box := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(tnBorder)).
-			Padding(0, 1).
-			MarginLeft(2).
-			Width(contentWidth).
-			Render(vp.View())
-
-		// Meta row — TTL on the left, copy confirmation right-justified.
-		metaLeft := ""
-		if m.ActiveTTL != "" {
-			metaLeft = labelStyle.Render("TTL: ") + keyStyle.Render(m.ActiveTTL)
-		}
-		toast := ""
-		if m.CopyStatus != "" {
-			toast = lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)).Render("✓ " + m.CopyStatus)
-		}
-		metaRow := ""
-		if metaLeft != "" || toast != "" {
-			gap := contentWidth - lipgloss.Width(metaLeft) - lipgloss.Width(toast)
-			if gap < 1 {
-				gap = 1
-			}
-			metaRow = "\n  " + metaLeft + strings.Repeat(" ", gap) + toast
-		}
+	case SessionInvalidatedMsg:
+		return handleSessionInvalidated(m, msg)
 
-		body := header + "\n\n" + label + "\n" + box + metaRow
-		foot := footerSep(m.WindowWidth) + "\n" + helpView
-		return bottomFooter(body, foot, m.WindowHeight)
+	case LeaderSampleMsg:
+		return withOutputViewport(handleLeaderSample(m, msg))
 
-	case StateBrowser:
-		return header + "\n" + m.Browser.View()
+	case LeaderTickMsg:
+		return handleLeaderTick(m, msg)
 
-	case StateLoading:
-		spin := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render(m.Spinner.View())
-		label := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Render("Loading…")
-		return header + "\n\n  " + spin + " " + label
+	case FlagsMsg:
+		return withOutputViewport(handleFlags(m, msg))
 
-	case StateConfirmation:
-		// Inline layout (v2): header on top, left-aligned content, footer bar.
-		var label, value string
-		switch m.SelectedOp {
-		case OpDel:
-			label, value = "key", m.ActiveKey
-		case OpHDel:
-			label, value = "field", m.ActiveField
-		case OpLRem:
-			label, value = "element", m.ActiveField
-		case OpSRem, OpZRem:
-			label, value = "member", m.ActiveField
-		default:
-			label, value = "", m.SelectedOp.String()
-		}
+	case TargetsMsg:
+		return msg.then(m, msg.Sides, msg.Error)
+
+	case CompareResultMsg:
+		return withOutputViewport(handleCompareResult(m, msg))
+
+	case DBDiffResultMsg:
+		return withOutputViewport(handleDBDiffResult(m, msg))
+
+	case SnapshotConnMsg:
+		return withOutputViewport(handleSnapshotConn(m, msg))
+
+	case SnapshotDoneMsg:
+		return handleSnapshotDone(m, msg)
+
+	case SnapshotTickMsg:
+		return handleSnapshotTick(m, msg)
+
+	case PersistenceSampleMsg:
+		return withOutputViewport(handlePersistenceSample(m, msg))
+
+	case PersistenceTickMsg:
+		return handlePersistenceTick(m, msg)
+
+	case LifecycleResultMsg:
+		return withOutputViewport(handleLifecycleResult(m, msg))
+
+	case TemplateResultMsg:
+		return withOutputViewport(handleTemplateResult(m, msg))
+
+	case ConvertPlanMsg:
+		return handleConvertPlan(m, msg)
+
+	case ConvertResultMsg:
+		return withOutputViewport(handleConvertResult(m, msg))
+
+	case GenerateConnMsg:
+		return withOutputViewport(handleGenerateConn(m, msg))
+
+	case BulkTTLConnMsg:
+		return withOutputViewport(handleBulkTTLConn(m, msg))
+
+	case BulkTTLPageMsg:
+		return handleBulkTTLPage(m, msg)
 
-		title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Bold(true).Render("⚠  confirm delete")
-		body := "  " + title + "\n\n"
-		if label != "" {
-			body += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Render(label) + "\n"
+	case GrowthSampleMsg:
+		return withOutputViewport(handleGrowthSample(m, msg))
+
+	case GrowthTickMsg:
+		return handleGrowthTick(m, msg)
+
+	case TelemetrySampleMsg:
+		return handleTelemetrySample(m, msg)
+
+	case TelemetryTickMsg:
+		return handleTelemetryTick(m, msg)
+
+	case TTLForecastMsg:
+		return withOutputViewport(handleTTLForecast(m, msg))
+
+	case ColdKeysMsg:
+		return handleColdKeys(m, msg)
+
+	case ColdKeysActionMsg:
+		return withOutputViewport(handleColdKeysAction(m, msg))
+
+	case ReplaceConnMsg:
+		return withOutputViewport(handleReplaceConn(m, msg))
+
+	case ReplacePageMsg:
+		return handleReplacePage(m, msg)
+
+	case SearchConnMsg:
+		return withOutputViewport(handleSearchConn(m, msg))
+
+	case SearchPageMsg:
+		return handleSearchPage(m, msg)
+
+	case GenerateBatchMsg:
+		return handleGenerateBatch(m, msg)
+
+	case BenchConnMsg:
+		return withOutputViewport(handleBenchConn(m, msg))
+
+	case BenchResultMsg:
+		return handleBenchResult(m, msg)
+
+	case StreamStartMsg:
+		return handleStreamStart(m, msg)
+
+	case StreamChunkMsg:
+		return handleStreamChunk(m, msg)
+
+	case TimelineConnMsg:
+		return withOutputViewport(handleTimelineConn(m, msg))
+
+	case TimelineEventMsg:
+		return handleTimelineEvent(m, msg)
+
+	case TimelineEnableMsg:
+		return handleTimelineEnable(m, msg)
+
+	case ClusterResultMsg:
+		return withOutputViewport(handleClusterResult(m, msg))
+
+	case ClusterFailoverMsg:
+		return handleClusterFailover(m, msg)
+
+	case NodeInfoMsg:
+		return withOutputViewport(handleNodeInfo(m, msg))
+
+	case ConsoleConnMsg:
+		return handleConsoleConn(m, msg)
+
+	case ConsoleReplyMsg:
+		return handleConsoleReply(m, msg)
+
+	case CommandsMsg:
+		return handleCommands(m, msg)
+
+	case ConsoleHistoryMsg:
+		return handleConsoleHistory(m, msg)
+
+	case ConsoleKeysMsg:
+		return handleConsoleKeys(m, msg)
+
+	case KeyScanMsg:
+		return handleKeyScan(m, msg)
+
+	case ReplicaFoundMsg:
+		return handleReplicaFound(m, msg)
+	case ReplicaConnMsg:
+		return handleReplicaConn(m, msg)
+
+	case ResultSavedMsg:
+		return handleResultSaved(m, msg)
+
+	case URLOpenedMsg:
+		return handleURLOpened(m, msg)
+
+	case ResultExportRequestMsg:
+		name := "keys"
+		if m.Browser.ViewingFields {
+			name = sanitizeFilename(m.Browser.ActiveKey)
 		}
-		body += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnText)).Render(value)
+		return m.startResultExport(m.browserExport(), name)
+	}
 
-		yPart := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render("[y] confirm")
-		nPart := lipgloss.NewStyle().Foreground(lipgloss.Color(tnFaint)).Render("[n / esc] cancel")
-		footer := "  " + yPart + "    " + nPart
+	if sc, ok := screens[m.CurrentState]; ok {
+		if sc.update != nil {
+			return sc.update(m, msg)
+		}
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return sc.key(m, keyMsg)
+		}
+	}
+	return m, nil
+}
 
-		return bottomFooter(header+"\n\n"+body, footerSep(m.WindowWidth)+"\n"+footer, m.WindowHeight)
+// View paints the active screen and fills the whole terminal with the base
+// background.
+func (m Model) View() string {
+	if m.tooSmall() {
+		return applyBackground(m.tooSmallView(), m.WindowWidth, m.WindowHeight)
+	}
+	return applyBackground(m.withToast(m.withInspector(m.viewContent())), m.WindowWidth, m.WindowHeight)
+}
 
-	default:
-		return ""
+func (m Model) viewContent() string {
+	if sc, ok := screens[m.CurrentState]; ok {
+		return sc.view(m)
 	}
+	return ""
 }
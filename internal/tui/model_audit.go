@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// AUDIT lists the commands sent on this session's connections, newest
+// first, each with who sent it and over which profile. It opens on writes
+// only — what a teammate asking "who flushed staging?" wants — and "a" adds
+// the reads. The same writes are appended to the audit file, when one is
+// set, as JSON lines.
+
+// AuditEntries is how many writes, and as many reads, the audit log keeps
+// in memory.
+const AuditEntries = 1000
+
+const (
+	auditUserCol    = 12
+	auditProfileCol = 16
+)
+
+// AuditModel is the audit viewer's state.
+type AuditModel struct {
+	All    bool // reads as well as writes
+	Offset int  // rows scrolled down from the newest
+}
+
+// OSUser is who the audit log says sent each command: the login name, or
+// $USER when the account can't be looked up.
+func OSUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
+
+func (m Model) startAudit() (tea.Model, tea.Cmd) {
+	m.AuditView = AuditModel{}
+	m.pushState(m.CurrentState)
+	m.CurrentState = StateAudit
+	return m, nil
+}
+
+func handleStateAuditKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	a := &m.AuditView
+	switch keyMsg.String() {
+	case "esc":
+		m.CurrentState = m.popState()
+	case "a":
+		a.All, a.Offset = !a.All, 0
+	case "up", "k":
+		a.Offset = max(a.Offset-1, 0)
+	case "down", "j":
+		a.Offset = min(a.Offset+1, max(len(m.Audit.Entries(a.All))-1, 0))
+	case "home", "g":
+		a.Offset = 0
+	}
+	return m, nil
+}
+
+func (m Model) auditView() string {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	write := lipgloss.NewStyle().Foreground(lipgloss.Color(tnOrange))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+
+	entries := m.Audit.Entries(m.AuditView.All)
+	scope := "writes only"
+	if m.AuditView.All {
+		scope = "all commands"
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true).Render("AUDIT") +
+		dim.Render(fmt.Sprintf(" · %s · %d kept", scope, len(entries)))
+	where := "kept in memory for this session only"
+	if m.AuditFile != "" {
+		where = "writes are appended to " + m.AuditFile
+	}
+	if err := m.Audit.Err(); err != nil {
+		where = red.Render("✗ writing " + m.AuditFile + ": " + err.Error())
+	}
+
+	var rows []string
+	switch {
+	case m.Audit == nil:
+		rows = append(rows, subtle.Render("Auditing is off for this session."))
+	case len(entries) == 0:
+		rows = append(rows, subtle.Render("Nothing sent that changes data yet. a shows every command."))
+	default:
+		rows = append(rows, dim.Render(fmt.Sprintf("%-8s  %-*s  %-*s  %s", "time", auditUserCol, "user", auditProfileCol, "profile", "command")))
+		width := max(m.WindowWidth-4-8-auditUserCol-auditProfileCol-6, 20)
+		visible := max(m.WindowHeight-12, 3)
+		for i := len(entries) - 1 - m.AuditView.Offset; i >= 0 && len(rows) <= visible; i-- {
+			e := entries[i]
+			profile := e.Profile
+			if profile == "" {
+				profile = e.Addr
+			}
+			words := make([]string, len(e.Command))
+			for j, w := range e.Command {
+				words[j] = redis.QuoteArg(w)
+			}
+			if e.Replaced != "" {
+				words = append(words, "← replaced", redis.QuoteArg(e.Replaced))
+			}
+			style := dim
+			if e.Write {
+				style = write
+			}
+			rows = append(rows, text.Render(e.At.Format("15:04:05"))+"  "+
+				subtle.Render(padCells(truncateText(e.User, auditUserCol), auditUserCol))+"  "+
+				subtle.Render(padCells(truncateText(profile, auditProfileCol), auditProfileCol))+"  "+
+				style.Render(truncateText(strings.Join(words, " "), width)))
+		}
+	}
+
+	body := "  " + title + "\n  " + subtle.Render(where) + "\n\n  " + strings.Join(rows, "\n  ")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(auditKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
@@ -0,0 +1,300 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	maxBenchOps     = 1_000_000
+	maxBenchPayload = 1 << 20
+	maxBenchDepth   = 1000
+	// benchKey is the one key the SET and GET tests touch; it is deleted
+	// when the run ends.
+	benchKey = "redis-tui:benchmark"
+)
+
+// benchTests are the tests in the order they run.
+var benchTests = []string{"PING", "SET", "GET"}
+
+// BenchSpec is what the BENCHMARK form asks for.
+type BenchSpec struct {
+	Ops     int // requests per test
+	Payload int // SET value size in bytes
+	Depth   int // requests per pipelined round trip
+}
+
+// BenchResult is one finished test. Latencies are per request: with
+// pipelining every request in a round trip waits for the whole round trip,
+// as redis-benchmark counts it.
+type BenchResult struct {
+	Test          string
+	Ops           int
+	Elapsed       time.Duration
+	P50, P95, P99 time.Duration
+	Max           time.Duration
+}
+
+// OpsPerSec is the test's throughput.
+func (r BenchResult) OpsPerSec() float64 {
+	return float64(r.Ops) / max(r.Elapsed.Seconds(), 1e-9)
+}
+
+// BenchmarkModel is the state of the benchmark screen. Tests run one at a
+// time over a dedicated connection so the results update as each finishes.
+type BenchmarkModel struct {
+	Spec    BenchSpec
+	Conn    net.Conn
+	Reader  *bufio.Reader
+	Gen     int
+	Results []BenchResult
+	Running bool
+	Err     string
+}
+
+// BenchConnMsg delivers the benchmark's dedicated connection.
+type BenchConnMsg struct {
+	Gen    int
+	Conn   net.Conn
+	Reader *bufio.Reader
+	Error  error
+}
+
+// BenchResultMsg reports one finished test.
+type BenchResultMsg struct {
+	Gen    int
+	Result BenchResult
+	Error  error
+}
+
+func (m Model) startBenchmarkForm() (tea.Model, tea.Cmd) {
+	spec := m.Benchmark.Spec
+	if spec.Ops == 0 {
+		spec = BenchSpec{Ops: 10000, Payload: 64, Depth: 16}
+	}
+	m.Form = NewForm(
+		"BENCHMARK · PING / SET / GET throughput and latency on this server",
+		[]string{"requests per test", "SET payload (bytes)", "pipeline depth (requests per round trip)"},
+		[]string{strconv.Itoa(spec.Ops), strconv.Itoa(spec.Payload), strconv.Itoa(spec.Depth)},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitBenchmarkForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	var spec BenchSpec
+	var err error
+	if spec.Ops, err = strconv.Atoi(values[0]); err != nil || spec.Ops <= 0 || spec.Ops > maxBenchOps {
+		m.Form.Err = fmt.Sprintf("requests must be between 1 and %d", maxBenchOps)
+		return m, nil
+	}
+	if spec.Payload, err = strconv.Atoi(values[1]); err != nil || spec.Payload < 0 || spec.Payload > maxBenchPayload {
+		m.Form.Err = fmt.Sprintf("payload must be between 0 and %d bytes", maxBenchPayload)
+		return m, nil
+	}
+	if spec.Depth, err = strconv.Atoi(values[2]); err != nil || spec.Depth <= 0 || spec.Depth > maxBenchDepth {
+		m.Form.Err = fmt.Sprintf("pipeline depth must be between 1 and %d", maxBenchDepth)
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.pushState(StateForm)
+	return m.runBenchmark(spec)
+}
+
+func (m Model) runBenchmark(spec BenchSpec) (tea.Model, tea.Cmd) {
+	m.stopBenchmark()
+	m.Benchmark = BenchmarkModel{Spec: spec, Gen: m.Benchmark.Gen + 1, Running: true}
+	return m.switchToLoadingAndDial(openBenchConnection(m, m.Benchmark.Gen))
+}
+
+func openBenchConnection(m Model, gen int) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m)
+		return BenchConnMsg{Gen: gen, Conn: conn, Reader: reader, Error: err}
+	}
+}
+
+func handleBenchConn(m Model, msg BenchConnMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.Benchmark.Gen {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close()
+		}
+		return m, nil
+	}
+	if msg.Error != nil {
+		m.Result = Result{Type: "error", Err: "Could not open a connection for the benchmark: " + msg.Error.Error()}
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	b := &m.Benchmark
+	b.Conn, b.Reader = msg.Conn, msg.Reader
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateBenchmark
+	}
+	return m, runBenchTest(b.Conn, b.Reader, b.Spec, benchTests[0], b.Gen)
+}
+
+// runBenchTest sends spec.Ops requests of one test in round trips of
+// spec.Depth and times each round trip.
+func runBenchTest(conn net.Conn, reader *bufio.Reader, spec BenchSpec, test string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		var cmd redis.RedisCmd
+		switch test {
+		case "PING":
+			cmd = redis.Ping()
+		case "SET":
+			cmd = redis.Set(benchKey, strings.Repeat("x", spec.Payload))
+		case "GET":
+			cmd = redis.Get(benchKey)
+		}
+		// Encode once and write whole batches: the benchmark should measure
+		// the server and the network, not the client's encoder.
+		one := cmd.ToBytes()
+		full := []byte(strings.Repeat(string(one), spec.Depth))
+
+		latencies := make([]time.Duration, 0, spec.Ops)
+		start := time.Now()
+		for sent := 0; sent < spec.Ops; {
+			n := min(spec.Depth, spec.Ops-sent)
+			t0 := time.Now()
+			if _, err := conn.Write(full[:n*len(one)]); err != nil {
+				return BenchResultMsg{Gen: gen, Error: err}
+			}
+			_ = conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+			for range n {
+				r, err := redis.ReadResp(reader)
+				if err != nil {
+					return BenchResultMsg{Gen: gen, Error: err}
+				}
+				if s, ok := r.(string); ok && redis.IsErrorReply(s) {
+					return BenchResultMsg{Gen: gen, Error: fmt.Errorf("%s: %s", test, s)}
+				}
+			}
+			rtt := time.Since(t0)
+			for range n {
+				latencies = append(latencies, rtt)
+			}
+			sent += n
+		}
+		_ = conn.SetReadDeadline(time.Time{})
+		if test == "GET" {
+			// Best effort: the benchmark key shouldn't outlive the run.
+			_, _ = pipeline(conn, reader, []redis.RedisCmd{redis.Del(benchKey)})
+		}
+		return BenchResultMsg{Gen: gen, Result: summarizeBench(test, time.Since(start), latencies)}
+	}
+}
+
+func summarizeBench(test string, elapsed time.Duration, latencies []time.Duration) BenchResult {
+	slices.Sort(latencies)
+	pct := func(p float64) time.Duration {
+		if len(latencies) == 0 {
+			return 0
+		}
+		return latencies[min(int(p*float64(len(latencies))), len(latencies)-1)]
+	}
+	r := BenchResult{Test: test, Ops: len(latencies), Elapsed: elapsed, P50: pct(0.50), P95: pct(0.95), P99: pct(0.99)}
+	if len(latencies) > 0 {
+		r.Max = latencies[len(latencies)-1]
+	}
+	return r
+}
+
+func handleBenchResult(m Model, msg BenchResultMsg) (tea.Model, tea.Cmd) {
+	b := &m.Benchmark
+	if msg.Gen != b.Gen || b.Conn == nil {
+		return m, nil
+	}
+	if msg.Error != nil {
+		b.Err = msg.Error.Error()
+		m.stopBenchmark()
+		return m, nil
+	}
+	b.Results = append(b.Results, msg.Result)
+	if len(b.Results) < len(benchTests) {
+		return m, runBenchTest(b.Conn, b.Reader, b.Spec, benchTests[len(b.Results)], b.Gen)
+	}
+	m.stopBenchmark()
+	return m, nil
+}
+
+// stopBenchmark closes the benchmark connection; a test still in flight
+// fails on the closed connection and is dropped by the Gen bump.
+func (m *Model) stopBenchmark() {
+	b := &m.Benchmark
+	if b.Conn != nil {
+		_ = b.Conn.Close()
+	}
+	b.Conn, b.Reader = nil, nil
+	b.Running = false
+	b.Gen++
+}
+
+func handleStateBenchmarkKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch keyMsg.String() {
+	case "esc":
+		m.stopBenchmark()
+		m.CurrentState = m.popState()
+	case "r":
+		if !m.Benchmark.Running {
+			return m.runBenchmark(m.Benchmark.Spec)
+		}
+	}
+	return m, nil
+}
+
+// formatLatency shows sub-millisecond latencies in µs, the rest in ms.
+func formatLatency(d time.Duration) string {
+	if d < time.Millisecond {
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	}
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
+
+func (m Model) benchmarkView() string {
+	b := m.Benchmark
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	yellow := lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+
+	s := b.Spec
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnInfo)).Bold(true).Render("BENCHMARK") +
+		dim.Render(fmt.Sprintf(" · %s · %d requests · %d-byte payload · pipeline %d", m.RedisAddress, s.Ops, s.Payload, s.Depth))
+
+	rows := []string{dim.Render(fmt.Sprintf("%-6s %12s %10s %10s %10s %10s", "test", "ops/s", "p50", "p95", "p99", "max"))}
+	for i, test := range benchTests {
+		if i < len(b.Results) {
+			r := b.Results[i]
+			rows = append(rows, text.Render(fmt.Sprintf("%-6s ", test))+green.Render(fmt.Sprintf("%12.0f", r.OpsPerSec()))+
+				subtle.Render(fmt.Sprintf(" %10s %10s %10s %10s", formatLatency(r.P50), formatLatency(r.P95), formatLatency(r.P99), formatLatency(r.Max))))
+			continue
+		}
+		label, style := "pending", dim
+		if b.Running && i == len(b.Results) {
+			label, style = "running…", yellow
+		}
+		rows = append(rows, text.Render(fmt.Sprintf("%-6s ", test))+style.Render(fmt.Sprintf("%12s", label)))
+	}
+
+	body := "  " + title + "\n\n" + indentLines(strings.Join(rows, "\n"), 2)
+	if b.Err != "" {
+		body += "\n\n  " + red.Render("stopped: "+b.Err)
+	}
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(benchmarkKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
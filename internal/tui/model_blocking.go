@@ -0,0 +1,285 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxBlockingEvents caps the arrival log so a busy queue can't grow it forever.
+const maxBlockingEvents = 200
+
+// BlockingModel is the state of the BLPOP/BRPOP watcher. The pops run on their
+// own connection: a blocking command parks the connection server-side, so
+// issuing it on the shared one would freeze every other screen until it
+// returned.
+type BlockingModel struct {
+	Key     string
+	Command string // "BLPOP" or "BRPOP"
+	Timeout int    // seconds per call; 0 blocks until something arrives
+
+	Conn    net.Conn
+	Reader  *bufio.Reader
+	Waiting bool      // a pop is in flight
+	Since   time.Time // when the in-flight pop was issued
+	Paused  bool
+	Err     string
+
+	Events []BlockingEvent // newest first
+}
+
+// BlockingEvent is one completed pop: either a value or a timeout.
+type BlockingEvent struct {
+	At       time.Time
+	Value    string
+	TimedOut bool
+	Waited   time.Duration
+}
+
+// BlockingConnMsg delivers the dedicated connection opened for the watcher.
+type BlockingConnMsg struct {
+	Conn   net.Conn
+	Reader *bufio.Reader
+	Error  error
+}
+
+// BlockingPopMsg is the outcome of one BLPOP/BRPOP call. Conn identifies the
+// connection it ran on so results from an already-closed watcher are dropped.
+type BlockingPopMsg struct {
+	Conn     net.Conn
+	Value    string
+	TimedOut bool
+	Waited   time.Duration
+	Error    error
+}
+
+// startBlockingForm opens the watcher's parameter form.
+func (m Model) startBlockingForm() (tea.Model, tea.Cmd) {
+	m.Form = NewForm(
+		"BLPOP / BRPOP · wait for pushes on a dedicated connection",
+		[]string{"list key", "timeout per pop (seconds, 0 = forever)", "side (left = BLPOP, right = BRPOP)"},
+		[]string{"", "5", "left"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+// submitBlockingForm validates the form and opens the dedicated connection.
+func submitBlockingForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	key, timeoutStr, side := values[0], values[1], strings.ToLower(values[2])
+	if key == "" {
+		m.Form.Err = "a list key is required"
+		return m, nil
+	}
+	timeout, err := strconv.Atoi(timeoutStr)
+	if err != nil || timeout < 0 {
+		m.Form.Err = "timeout must be a whole number of seconds (0 or more)"
+		return m, nil
+	}
+	command := "BLPOP"
+	switch side {
+	case "left", "l", "":
+	case "right", "r":
+		command = "BRPOP"
+	default:
+		m.Form.Err = `side must be "left" or "right"`
+		return m, nil
+	}
+
+	m.Form.Err = ""
+	m.Blocking = BlockingModel{Key: key, Command: command, Timeout: timeout}
+	m.pushState(StateForm)
+	return m.switchToLoadingAndDial(openBlockingConnection(m))
+}
+
+func openBlockingConnection(m Model) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m)
+		return BlockingConnMsg{Conn: conn, Reader: reader, Error: err}
+	}
+}
+
+func handleBlockingConn(m Model, msg BlockingConnMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.Result = Result{Type: "error", Err: "Could not open a dedicated connection: " + msg.Error.Error()}
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	// The user may have backed out while the connection was being opened.
+	if m.CurrentState != StateLoading {
+		_ = msg.Conn.Close()
+		return m, nil
+	}
+	m.Blocking.Conn = msg.Conn
+	m.Blocking.Reader = msg.Reader
+	m.CurrentState = StateBlocking
+	return m, m.armBlockingPop()
+}
+
+// armBlockingPop issues the next pop and marks the watcher as waiting.
+func (m *Model) armBlockingPop() tea.Cmd {
+	b := &m.Blocking
+	b.Waiting = true
+	b.Since = time.Now()
+	return blockingPop(b.Conn, b.Reader, b.Command, b.Key, b.Timeout, m.ReadTimeout)
+}
+
+func blockingPop(conn net.Conn, reader *bufio.Reader, command, key string, timeout int, readTimeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		start := time.Now()
+		cmd := redis.RedisCmd{Name: command, Args: []string{key, strconv.Itoa(timeout)}}
+		if _, err := conn.Write(cmd.ToBytes()); err != nil {
+			return BlockingPopMsg{Conn: conn, Error: err}
+		}
+		// The server holds the reply for up to timeout seconds, so the usual
+		// read deadline is granted on top of it (and dropped for timeout 0).
+		if timeout > 0 {
+			if readTimeout == 0 {
+				readTimeout = defaultReadTimeout
+			}
+			_ = conn.SetReadDeadline(time.Now().Add(time.Duration(timeout)*time.Second + readTimeout))
+		}
+		resp, err := redis.ReadResp(reader)
+		_ = conn.SetReadDeadline(time.Time{})
+		waited := time.Since(start)
+		if err != nil {
+			return BlockingPopMsg{Conn: conn, Error: err, Waited: waited}
+		}
+
+		switch r := resp.(type) {
+		case []any:
+			// [list-key, value]
+			if len(r) == 2 {
+				if v, ok := r[1].(string); ok {
+					return BlockingPopMsg{Conn: conn, Value: v, Waited: waited}
+				}
+			}
+		case string:
+			if r == "(nil)" {
+				return BlockingPopMsg{Conn: conn, TimedOut: true, Waited: waited}
+			}
+			return BlockingPopMsg{Conn: conn, Error: fmt.Errorf("%s", r), Waited: waited}
+		}
+		return BlockingPopMsg{Conn: conn, Error: fmt.Errorf("unexpected %s reply", command), Waited: waited}
+	}
+}
+
+func handleBlockingPop(m Model, msg BlockingPopMsg) (tea.Model, tea.Cmd) {
+	if msg.Conn == nil || msg.Conn != m.Blocking.Conn {
+		return m, nil // watcher already stopped
+	}
+	b := &m.Blocking
+	b.Waiting = false
+
+	if msg.Error != nil {
+		b.Err = msg.Error.Error()
+		_ = b.Conn.Close()
+		b.Conn, b.Reader = nil, nil
+		return m, nil
+	}
+
+	b.Events = append([]BlockingEvent{{
+		At:       time.Now(),
+		Value:    msg.Value,
+		TimedOut: msg.TimedOut,
+		Waited:   msg.Waited,
+	}}, b.Events...)
+	if len(b.Events) > maxBlockingEvents {
+		b.Events = b.Events[:maxBlockingEvents]
+	}
+
+	if m.CurrentState == StateBlocking && !b.Paused {
+		return m, m.armBlockingPop()
+	}
+	return m, nil
+}
+
+// stopBlocking closes the dedicated connection, which also unblocks any pop
+// still parked on the server; its eventual error is dropped by the Conn check
+// in handleBlockingPop.
+func (m *Model) stopBlocking() {
+	if m.Blocking.Conn != nil {
+		_ = m.Blocking.Conn.Close()
+	}
+	m.Blocking.Conn, m.Blocking.Reader = nil, nil
+	m.Blocking.Waiting = false
+}
+
+func handleStateBlockingKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch keyMsg.String() {
+	case "esc":
+		m.stopBlocking()
+		m.CurrentState = m.popState()
+		return m, nil
+	case "p":
+		// Pausing can't recall a pop already parked on the server — that one
+		// may still deliver a value — but no new pop is issued until resumed.
+		m.Blocking.Paused = !m.Blocking.Paused
+		if !m.Blocking.Paused && !m.Blocking.Waiting && m.Blocking.Conn != nil {
+			return m, m.armBlockingPop()
+		}
+	case "c":
+		m.Blocking.Events = nil
+	}
+	return m, nil
+}
+
+func (m Model) blockingView() string {
+	b := m.Blocking
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	faint := lipgloss.NewStyle().Foreground(lipgloss.Color(tnFaint))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+
+	timeout := "forever"
+	if b.Timeout > 0 {
+		timeout = strconv.Itoa(b.Timeout) + "s"
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnPurple)).Bold(true).Render(b.Command) +
+		dim.Render(" · ") + subtle.Render(b.Key) + dim.Render("   timeout "+timeout+" · dedicated connection")
+
+	var status string
+	switch {
+	case b.Err != "":
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render("✗ stopped: " + b.Err)
+	case b.Paused:
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow)).Render("⏸ paused")
+	case b.Waiting:
+		status = green.Render("●") + " " + subtle.Render("waiting since "+b.Since.Format("15:04:05"))
+	default:
+		status = subtle.Render("idle")
+	}
+
+	rows := []string{}
+	for _, ev := range b.Events {
+		at := faint.Render(ev.At.Format("15:04:05.000"))
+		waited := dim.Render(fmt.Sprintf("after %.1fs", ev.Waited.Seconds()))
+		if ev.TimedOut {
+			rows = append(rows, at+"   "+dim.Render("timeout — nothing arrived")+"   "+waited)
+		} else {
+			rows = append(rows, at+"   "+text.Render(strconv.Quote(ev.Value))+"   "+waited)
+		}
+	}
+	if len(rows) == 0 {
+		rows = append(rows, dim.Render("nothing popped yet — push to "+b.Key+" from another client"))
+	}
+	// header(2) + blank + title + status + blank + footer(2)
+	if avail := m.WindowHeight - 8; avail > 0 && len(rows) > avail {
+		rows = rows[:avail]
+	}
+
+	body := "  " + title + "\n  " + status + "\n\n" + indentLines(strings.Join(rows, "\n"), 2)
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(blockingKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
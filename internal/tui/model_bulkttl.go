@@ -0,0 +1,359 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// EXPIRE_ALL sets one TTL on every key matching a pattern — typically a
+// namespace written without one. A first SCAN pass counts the keys it would
+// change, and the confirmation shows that count; a second pass applies it.
+// Both go one SCAN page per round trip on a connection of their own, so
+// progress shows as it goes and x stops it between pages.
+
+// BulkTTLSpec is what the EXPIRE_ALL form asks for.
+type BulkTTLSpec struct {
+	Pattern string
+	TTL     int  // seconds; 0 removes the expiry (PERSIST)
+	OnlyNew bool // only keys that have no TTL yet
+}
+
+// BulkTTLModel is the state of a count or apply pass.
+type BulkTTLModel struct {
+	Spec     BulkTTLSpec
+	Conn     net.Conn
+	Reader   *bufio.Reader
+	Gen      int
+	Counted  bool // the count pass is through
+	Applying bool // counted and confirmed; now setting TTLs
+	Count    int  // SCAN COUNT hint for the next page
+	Matched  int  // keys matching the pattern, from the count pass
+	Eligible int  // of those, the ones the TTL would be set on
+	Done     int  // TTLs set by the apply pass
+	Errors   int
+	LastErr  string
+	Started  time.Time
+	Finished time.Time
+	Err      string // the pass stopped on this
+}
+
+// BulkTTLConnMsg delivers the pass's dedicated connection.
+type BulkTTLConnMsg struct {
+	Gen    int
+	Conn   net.Conn
+	Reader *bufio.Reader
+	Error  error
+}
+
+// BulkTTLPageMsg reports one SCAN page of a pass.
+type BulkTTLPageMsg struct {
+	Gen      int
+	Cursor   string
+	Took     time.Duration
+	Matched  int
+	Eligible int
+	Applied  int
+	Errors   int
+	LastErr  string
+	Error    error
+}
+
+func (m Model) startBulkTTLForm() (tea.Model, tea.Cmd) {
+	if m.ReadOnly {
+		return m, m.notify("EXPIRE_ALL is disabled: this session is read-only", true)
+	}
+	m.Form = NewForm(
+		"EXPIRE_ALL · set a TTL on every key matching a pattern",
+		[]string{"key pattern", "TTL in seconds (0 = remove expiry / PERSIST)", "only keys without a TTL yet? (y/n)"},
+		[]string{m.LastPattern, "86400", "y"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitBulkTTLForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	spec := BulkTTLSpec{Pattern: values[0]}
+	var err error
+	if spec.Pattern == "" {
+		m.Form.Err = "enter a key pattern"
+		return m, nil
+	}
+	if spec.TTL, err = strconv.Atoi(values[1]); err != nil || spec.TTL < 0 {
+		m.Form.Err = "TTL must be a whole number of seconds, 0 to remove expiry"
+		return m, nil
+	}
+	switch strings.ToLower(values[2]) {
+	case "y", "yes":
+		spec.OnlyNew = true
+	case "n", "no":
+	default:
+		m.Form.Err = "answer y or n: only keys without a TTL yet?"
+		return m, nil
+	}
+	if spec.OnlyNew && spec.TTL == 0 {
+		m.Form.Err = "keys without a TTL have no expiry to remove; answer n to remove every key's"
+		return m, nil
+	}
+
+	m.Form.Err = ""
+	m.stopBulkTTL()
+	m.BulkTTL = BulkTTLModel{Spec: spec, Gen: m.BulkTTL.Gen + 1, Count: firstScanCount(m.scanCountMax()), Started: time.Now()}
+	return m.switchToLoadingAndDial(openBulkTTLConnection(m, m.BulkTTL.Gen))
+}
+
+func openBulkTTLConnection(m Model, gen int) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m)
+		return BulkTTLConnMsg{Gen: gen, Conn: conn, Reader: reader, Error: err}
+	}
+}
+
+func handleBulkTTLConn(m Model, msg BulkTTLConnMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.BulkTTL.Gen {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close()
+		}
+		return m, nil
+	}
+	if msg.Error != nil {
+		m.Result = Result{Type: "error", Err: "Could not open a connection for EXPIRE_ALL: " + msg.Error.Error()}
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	b := &m.BulkTTL
+	b.Conn, b.Reader = msg.Conn, msg.Reader
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateBulkTTL
+	}
+	return m, bulkTTLPage(b.Conn, b.Reader, b.Spec, "0", b.Count, false, b.Gen)
+}
+
+// bulkTTLPage scans one page from cursor and picks the keys the TTL applies
+// to; with apply it sets it on them too, all in three round trips at most.
+func bulkTTLPage(conn net.Conn, reader *bufio.Reader, spec BulkTTLSpec, cursor string, count int, apply bool, gen int) tea.Cmd {
+	return func() tea.Msg {
+		msg := BulkTTLPageMsg{Gen: gen}
+		start := time.Now()
+		resp, err := readResp(conn, reader, redis.Scan(cursor, spec.Pattern, count))
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		msg.Took = time.Since(start)
+		var keys []string
+		if msg.Cursor, keys, err = scanPage(resp); err != nil {
+			msg.Error = err
+			return msg
+		}
+		msg.Matched = len(keys)
+
+		targets := keys
+		if spec.OnlyNew && len(keys) > 0 {
+			cmds := make([]redis.RedisCmd, len(keys))
+			for i, k := range keys {
+				cmds[i] = redis.TTL(k)
+			}
+			replies, err := pipeline(conn, reader, cmds)
+			if err != nil {
+				msg.Error = err
+				return msg
+			}
+			targets = nil
+			for i, r := range replies {
+				if ttl, ok := r.(int); ok && ttl == -1 {
+					targets = append(targets, keys[i])
+				}
+			}
+		}
+		msg.Eligible = len(targets)
+		if !apply || len(targets) == 0 {
+			return msg
+		}
+
+		cmds := make([]redis.RedisCmd, len(targets))
+		for i, k := range targets {
+			cmds[i] = redis.Expire(k, spec.TTL)
+			if spec.TTL == 0 {
+				cmds[i] = redis.Persist(k)
+			}
+		}
+		replies, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		for _, r := range replies {
+			switch r := r.(type) {
+			case int:
+				msg.Applied += r // 0: gone since the SCAN, or nothing to persist
+			case string:
+				if redis.IsErrorReply(r) {
+					msg.Errors++
+					msg.LastErr = r
+				}
+			}
+		}
+		return msg
+	}
+}
+
+func handleBulkTTLPage(m Model, msg BulkTTLPageMsg) (tea.Model, tea.Cmd) {
+	b := &m.BulkTTL
+	if msg.Gen != b.Gen || b.Conn == nil {
+		return m, nil
+	}
+	if msg.Error != nil {
+		b.Err = msg.Error.Error()
+		m.stopBulkTTL()
+		return m, nil
+	}
+	b.Count = nextScanCount(b.Count, m.scanCountMax(), msg.Took)
+	b.Errors += msg.Errors
+	if msg.LastErr != "" {
+		b.LastErr = msg.LastErr
+	}
+	if b.Applying {
+		b.Done += msg.Applied
+	} else {
+		b.Matched += msg.Matched
+		b.Eligible += msg.Eligible
+	}
+	if msg.Cursor != "0" && msg.Cursor != "" {
+		return m, bulkTTLPage(b.Conn, b.Reader, b.Spec, msg.Cursor, b.Count, b.Applying, b.Gen)
+	}
+
+	b.Counted = true
+	if b.Applying || b.Eligible == 0 {
+		b.Finished = time.Now()
+		m.stopBulkTTL()
+		return m, nil
+	}
+	// Counted: ask before changing anything.
+	m.SelectedOp = OpBulkTTL
+	m.pushState(StateBulkTTL)
+	m.ask(m.bulkTTLDialog())
+	return m, nil
+}
+
+// confirmBulkTTL starts the apply pass, on the connection the count used.
+func confirmBulkTTL(m Model) (tea.Model, tea.Cmd) {
+	m.CurrentState = m.popState()
+	b := &m.BulkTTL
+	if b.Conn == nil {
+		return m, nil // closed meanwhile
+	}
+	b.Applying = true
+	b.Count = firstScanCount(m.scanCountMax())
+	b.Started = time.Now()
+	return m, bulkTTLPage(b.Conn, b.Reader, b.Spec, "0", b.Count, true, b.Gen)
+}
+
+// bulkTTLDialog asks before the apply pass.
+func (m Model) bulkTTLDialog() Dialog {
+	b := m.BulkTTL
+	label := fmt.Sprintf("set a TTL of %d s on", b.Spec.TTL)
+	if b.Spec.TTL == 0 {
+		label = "remove the expiry (PERSIST) of"
+	}
+	value := fmt.Sprintf("%d keys matching %s", b.Eligible, b.Spec.Pattern)
+	if b.Spec.OnlyNew {
+		value += fmt.Sprintf(" that have no TTL (of %d matched)", b.Matched)
+	}
+	return Dialog{Title: "confirm EXPIRE_ALL", Label: label, Value: value, Destructive: true,
+		Target: m.orDB(b.Spec.Pattern), Choices: confirmChoice(confirmBulkTTL)}
+}
+
+// stopBulkTTL closes the pass's connection; an in-flight page is dropped by
+// the Gen bump.
+func (m *Model) stopBulkTTL() {
+	b := &m.BulkTTL
+	if b.Conn != nil {
+		_ = b.Conn.Close()
+	}
+	b.Conn, b.Reader = nil, nil
+	b.Gen++
+}
+
+func handleStateBulkTTLKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch keyMsg.String() {
+	case "esc":
+		m.stopBulkTTL()
+		m.CurrentState = m.popState()
+	case "x":
+		if m.BulkTTL.Conn != nil {
+			m.BulkTTL.Err = "stopped"
+			m.stopBulkTTL()
+		}
+	}
+	return m, nil
+}
+
+func (m Model) bulkTTLView() string {
+	b := m.BulkTTL
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+
+	what := fmt.Sprintf("TTL %ds", b.Spec.TTL)
+	if b.Spec.TTL == 0 {
+		what = "PERSIST"
+	}
+	if b.Spec.OnlyNew {
+		what += " on keys without one"
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Bold(true).Render("EXPIRE_ALL") +
+		dim.Render(" · "+b.Spec.Pattern+" · "+what)
+
+	end := b.Finished
+	if end.IsZero() {
+		end = time.Now()
+	}
+	elapsed := end.Sub(b.Started)
+
+	var lines []string
+	if b.Applying {
+		w := max(min(m.WindowWidth-20, 60), 10)
+		done := min(b.Done, b.Eligible)
+		filled := w * done / max(b.Eligible, 1)
+		bar := green.Render(strings.Repeat("█", filled)) + dim.Render(strings.Repeat("░", w-filled))
+		lines = append(lines, "  "+bar+subtle.Render(fmt.Sprintf(" %3d%%", 100*done/max(b.Eligible, 1))))
+	}
+
+	var status string
+	switch {
+	case b.Err != "" && b.Applying:
+		status = red.Render(fmt.Sprintf("stopped after %d keys: %s", b.Done, b.Err))
+	case b.Err != "":
+		status = red.Render(fmt.Sprintf("stopped counting after %d keys: %s", b.Matched, b.Err))
+	case b.Applying && !b.Finished.IsZero():
+		status = green.Render(fmt.Sprintf("done: TTL set on %d keys in %s", b.Done, elapsed.Round(time.Millisecond)))
+	case b.Applying:
+		status = subtle.Render(fmt.Sprintf("%d / %d keys", b.Done, b.Eligible))
+	case !b.Finished.IsZero():
+		status = subtle.Render(fmt.Sprintf("%d keys matched; none to change", b.Matched))
+	case b.Counted:
+		status = subtle.Render(fmt.Sprintf("counted %d keys to change; nothing was changed", b.Eligible))
+	default:
+		status = subtle.Render(fmt.Sprintf("counting… %d keys matched, %d to change", b.Matched, b.Eligible))
+	}
+	lines = append(lines, "  "+status)
+	if b.Errors > 0 {
+		lines = append(lines, "  "+red.Render(fmt.Sprintf("%d error replies, last: %s", b.Errors, b.LastErr)))
+	}
+
+	body := "  " + title + "\n\n" + strings.Join(lines, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(bulkTTLKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
@@ -0,0 +1,501 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const clusterSlots = 16384
+
+// ClusterNode is one line of CLUSTER NODES.
+type ClusterNode struct {
+	ID     string
+	Addr   string // host:port, without the cluster bus port or hostname
+	Flags  []string
+	Master string // master's ID for a replica, "-" otherwise
+	Link   string
+	Slots  [][2]int // inclusive ranges
+}
+
+func (n ClusterNode) hasFlag(f string) bool {
+	for _, x := range n.Flags {
+		if x == f {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReplica reports whether the node replicates a master ("slave" in the
+// flags, which Redis still uses on the wire).
+func (n ClusterNode) IsReplica() bool { return n.hasFlag("slave") }
+
+func (n ClusterNode) slotCount() int {
+	c := 0
+	for _, r := range n.Slots {
+		c += r[1] - r[0] + 1
+	}
+	return c
+}
+
+// ClusterModel is the state of the cluster overview screen.
+type ClusterModel struct {
+	Info   map[string]string // CLUSTER INFO fields
+	Nodes  []ClusterNode
+	Cursor int
+	Notice string
+	Failed bool   // Notice reports a failure
+	Target string // node the last per-node action (failover, INFO, console) went to
+}
+
+// ClusterResultMsg carries CLUSTER INFO and CLUSTER NODES.
+type ClusterResultMsg struct {
+	Info  map[string]string
+	Nodes []ClusterNode
+	Error error
+}
+
+// ClusterFailoverMsg reports a CLUSTER FAILOVER sent to a replica.
+type ClusterFailoverMsg struct {
+	Addr  string
+	Reply string
+	Error error
+}
+
+func (m Model) startCluster() (tea.Model, tea.Cmd) {
+	m.Cluster = ClusterModel{}
+	return m.switchToLoadingAndExecute(loadCluster(m.Conn, m.Reader))
+}
+
+func loadCluster(conn net.Conn, reader *bufio.Reader) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return ClusterResultMsg{Error: fmt.Errorf("no connection to Redis")}
+		}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{
+			{Name: "CLUSTER", Args: []string{"INFO"}},
+			{Name: "CLUSTER", Args: []string{"NODES"}},
+		})
+		if err != nil {
+			return ClusterResultMsg{Error: err}
+		}
+		info, _ := replies[0].(string)
+		nodes, _ := replies[1].(string)
+		// A standalone server answers both with an error string.
+		if strings.HasPrefix(info, "ERR") {
+			return ClusterResultMsg{Error: fmt.Errorf("not a cluster node: %s", info)}
+		}
+		return ClusterResultMsg{Info: parseInfo(info), Nodes: parseClusterNodes(nodes)}
+	}
+}
+
+// parseClusterNodes parses CLUSTER NODES output. Slot entries describing a
+// migration in progress ("[slot->-id]") are skipped — the slot still belongs
+// to the node that lists it plainly.
+func parseClusterNodes(raw string) []ClusterNode {
+	var nodes []ClusterNode
+	for _, line := range strings.Split(raw, "\n") {
+		f := strings.Fields(line)
+		if len(f) < 8 {
+			continue
+		}
+		addr := f[1]
+		if i := strings.IndexAny(addr, "@,"); i >= 0 {
+			addr = addr[:i]
+		}
+		n := ClusterNode{ID: f[0], Addr: addr, Flags: strings.Split(f[2], ","), Master: f[3], Link: f[7]}
+		for _, s := range f[8:] {
+			if strings.HasPrefix(s, "[") {
+				continue
+			}
+			lo, hi, isRange := strings.Cut(s, "-")
+			a, err := strconv.Atoi(lo)
+			if err != nil {
+				continue
+			}
+			b := a
+			if isRange {
+				if b, err = strconv.Atoi(hi); err != nil {
+					continue
+				}
+			}
+			n.Slots = append(n.Slots, [2]int{a, b})
+		}
+		nodes = append(nodes, n)
+	}
+	// Masters first (by address), each followed by its replicas.
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].Addr < nodes[j].Addr })
+	var ordered []ClusterNode
+	for _, n := range nodes {
+		if n.IsReplica() {
+			continue
+		}
+		ordered = append(ordered, n)
+		for _, r := range nodes {
+			if r.IsReplica() && r.Master == n.ID {
+				ordered = append(ordered, r)
+			}
+		}
+	}
+	// Replicas whose master isn't listed (e.g. it was forgotten) go last.
+	for _, r := range nodes {
+		if r.IsReplica() && !containsNode(ordered, r.ID) {
+			ordered = append(ordered, r)
+		}
+	}
+	return ordered
+}
+
+func containsNode(nodes []ClusterNode, id string) bool {
+	for _, n := range nodes {
+		if n.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func handleClusterResult(m Model, msg ClusterResultMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		if m.CurrentState == StateCluster {
+			m.Cluster.Notice, m.Cluster.Failed = msg.Error.Error(), true
+			return m, nil
+		}
+		m.Result = errorResult(msg.Error)
+		m.ActiveTTL = ""
+		m.CopyStatus = ""
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	c := &m.Cluster
+	c.Info, c.Nodes = msg.Info, msg.Nodes
+	if c.Cursor >= len(c.Nodes) {
+		c.Cursor = 0
+	}
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateCluster
+	}
+	return m, nil
+}
+
+// clusterFailover sends CLUSTER FAILOVER to the replica at addr, which has to
+// receive it itself — so it goes over a short-lived connection to that node.
+func clusterFailover(m Model, addr string) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m.nodeModel(addr))
+		if err != nil {
+			return ClusterFailoverMsg{Addr: addr, Error: err}
+		}
+		defer conn.Close()
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{{Name: "CLUSTER", Args: []string{"FAILOVER"}}})
+		if err != nil {
+			return ClusterFailoverMsg{Addr: addr, Error: err}
+		}
+		reply, _ := replies[0].(string)
+		return ClusterFailoverMsg{Addr: addr, Reply: reply}
+	}
+}
+
+// NodeInfoMsg carries INFO from one cluster node.
+type NodeInfoMsg struct {
+	Output string
+	Error  error
+}
+
+// nodeInfo reads INFO from the node at addr over a short-lived connection —
+// the session's own connection only ever sees the node it's attached to.
+func nodeInfo(m Model, addr string) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m.nodeModel(addr))
+		if err != nil {
+			return NodeInfoMsg{Error: fmt.Errorf("%s: %w", addr, err)}
+		}
+		defer conn.Close()
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{{Name: "INFO"}})
+		if err != nil {
+			return NodeInfoMsg{Error: fmt.Errorf("%s: %w", addr, err)}
+		}
+		out, _ := replies[0].(string)
+		return NodeInfoMsg{Output: out}
+	}
+}
+
+func handleNodeInfo(m Model, msg NodeInfoMsg) (tea.Model, tea.Cmd) {
+	m.ActiveTTL = ""
+	m.CopyStatus = ""
+	if msg.Error != nil {
+		m.Result = errorResult(msg.Error)
+	} else {
+		m.Result = textResult(msg.Output)
+	}
+	m.CurrentState = StateOutput
+	return m, nil
+}
+
+// handleClusterFailover goes back to the overview and reloads it. The
+// promotion itself takes a moment, so the refreshed roles may still show the
+// old layout — 'r' picks up the change.
+func handleClusterFailover(m Model, msg ClusterFailoverMsg) (tea.Model, tea.Cmd) {
+	m.CurrentState = m.popState()
+	c := &m.Cluster
+	switch {
+	case msg.Error != nil:
+		c.Notice, c.Failed = fmt.Sprintf("failover on %s: %v", msg.Addr, msg.Error), true
+	case msg.Reply != "OK":
+		c.Notice, c.Failed = fmt.Sprintf("failover on %s: %s", msg.Addr, msg.Reply), true
+	default:
+		c.Notice, c.Failed = "failover requested on "+msg.Addr+" — press r to see the new roles", false
+	}
+	return m, m.exec(loadCluster(m.Conn, m.Reader))
+}
+
+func handleStateClusterKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := &m.Cluster
+	switch keyMsg.String() {
+	case "esc":
+		m.CurrentState = m.popState()
+	case "up", "k":
+		if c.Cursor > 0 {
+			c.Cursor--
+		}
+	case "down", "j":
+		if c.Cursor < len(c.Nodes)-1 {
+			c.Cursor++
+		}
+	case "r":
+		c.Notice = ""
+		return m, m.exec(loadCluster(m.Conn, m.Reader))
+	case "f":
+		if c.Cursor >= len(c.Nodes) {
+			break
+		}
+		n := c.Nodes[c.Cursor]
+		switch {
+		case m.ReadOnly:
+			c.Notice, c.Failed = "read-only session: failover is disabled", true
+		case !n.IsReplica():
+			c.Notice, c.Failed = "CLUSTER FAILOVER runs on a replica — select one", true
+		default:
+			c.Target = n.Addr
+			m.SelectedOp = OpClusterFailover
+			m.pushState(StateCluster)
+			m.ask(Dialog{
+				Title: "confirm failover", Label: "promote this replica to master (CLUSTER FAILOVER)", Value: n.Addr,
+				Destructive: true, Target: n.Addr,
+				Choices: confirmChoice(func(m Model) (tea.Model, tea.Cmd) {
+					return m.switchToLoadingAndDial(clusterFailover(m, m.Cluster.Target))
+				}),
+			})
+		}
+	case "c", "i":
+		if c.Cursor >= len(c.Nodes) {
+			break
+		}
+		c.Target = c.Nodes[c.Cursor].Addr
+		if keyMsg.String() == "c" {
+			return m.startNodeConsole(c.Target)
+		}
+		m.SelectedOp = OpNodeInfo
+		m.pushState(StateCluster)
+		return m.switchToLoadingAndDial(nodeInfo(m, c.Target))
+	case "p":
+		m.Result = textResult(renderReshardPreview(c.Nodes))
+		m.SelectedOp = OpClusterReshard
+		m.ActiveTTL = ""
+		m.CopyStatus = ""
+		m.pushState(StateCluster)
+		m.CurrentState = StateOutput
+		return withOutputViewport(m, nil)
+	}
+	return m, nil
+}
+
+// renderReshardPreview lists each master's slot ranges and how many slots it
+// would give or take to even out the cluster. Nothing is moved; it's what an
+// operator would plan a `redis-cli --cluster reshard` from.
+func renderReshardPreview(nodes []ClusterNode) string {
+	var masters []ClusterNode
+	for _, n := range nodes {
+		if !n.IsReplica() && !n.hasFlag("fail") {
+			masters = append(masters, n)
+		}
+	}
+	if len(masters) == 0 {
+		return "no masters in the cluster"
+	}
+
+	var b strings.Builder
+	b.WriteString("slot ownership\n\n")
+	assigned := 0
+	for _, n := range masters {
+		count := n.slotCount()
+		assigned += count
+		var ranges []string
+		for _, r := range n.Slots {
+			if r[0] == r[1] {
+				ranges = append(ranges, strconv.Itoa(r[0]))
+			} else {
+				ranges = append(ranges, fmt.Sprintf("%d-%d", r[0], r[1]))
+			}
+		}
+		if len(ranges) == 0 {
+			ranges = []string{"(none)"}
+		}
+		fmt.Fprintf(&b, "  %-21s %5d slots  %5.1f%%  %s\n", n.Addr, count, 100*float64(count)/clusterSlots, strings.Join(ranges, " "))
+	}
+	if assigned < clusterSlots {
+		fmt.Fprintf(&b, "\n  %d slots are not assigned to any master\n", clusterSlots-assigned)
+	}
+
+	// An even split gives the first clusterSlots % n masters one extra slot.
+	b.WriteString("\nto balance\n\n")
+	balanced := true
+	for i, n := range masters {
+		target := clusterSlots / len(masters)
+		if i < clusterSlots%len(masters) {
+			target++
+		}
+		switch diff := n.slotCount() - target; {
+		case diff > 0:
+			balanced = false
+			fmt.Fprintf(&b, "- %-21s gives %d slots (→ %d)\n", n.Addr, diff, target)
+		case diff < 0:
+			balanced = false
+			fmt.Fprintf(&b, "+ %-21s takes %d slots (→ %d)\n", n.Addr, -diff, target)
+		default:
+			fmt.Fprintf(&b, "  %-21s keeps %d slots\n", n.Addr, target)
+		}
+	}
+	if balanced {
+		b.WriteString("\n✓ slots are already evenly distributed")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// clusterPalette colors masters in the slot bar and legend.
+var clusterPalette = []string{tnBlue, tnPurple, tnGreen, tnOrange, tnYellow, tnAccent, tnInfo}
+
+// slotBar renders slot coverage in width cells: each cell takes its owner's
+// color, and a cell containing any unassigned slot is drawn red.
+func slotBar(nodes []ClusterNode, width int) string {
+	owner := make([]int, clusterSlots)
+	for i := range owner {
+		owner[i] = -1
+	}
+	mi := 0
+	for _, n := range nodes {
+		if n.IsReplica() {
+			continue
+		}
+		for _, r := range n.Slots {
+			for s := max(r[0], 0); s <= r[1] && s < clusterSlots; s++ {
+				owner[s] = mi
+			}
+		}
+		mi++
+	}
+	if width <= 0 {
+		width = 64
+	}
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+	var b strings.Builder
+	for cell := 0; cell < width; cell++ {
+		lo, hi := cell*clusterSlots/width, (cell+1)*clusterSlots/width
+		who := owner[lo]
+		for s := lo; s < hi; s++ {
+			if owner[s] == -1 {
+				who = -1
+				break
+			}
+		}
+		if who == -1 {
+			b.WriteString(red.Render("░"))
+			continue
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(clusterPalette[who%len(clusterPalette)])).Render("█"))
+	}
+	return b.String()
+}
+
+func (m Model) clusterView() string {
+	c := m.Cluster
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+
+	state := c.Info["cluster_state"]
+	stateView := green.Render(state)
+	if state != "ok" {
+		stateView = red.Render(state)
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnInfo)).Bold(true).Render("CLUSTER") + "  " + stateView +
+		dim.Render(fmt.Sprintf(" · %s/%d slots ok · %s pfail · %s fail · %s nodes · %s shards",
+			c.Info["cluster_slots_ok"], clusterSlots, c.Info["cluster_slots_pfail"], c.Info["cluster_slots_fail"],
+			c.Info["cluster_known_nodes"], c.Info["cluster_size"]))
+
+	w := m.WindowWidth
+	if w <= 0 {
+		w = 80
+	}
+	bar := "  " + slotBar(c.Nodes, w-4)
+
+	rows := []string{"  " + dim.Render(fmt.Sprintf("  %-21s %-8s %-10s %6s  %s", "node", "id", "role", "slots", "flags"))}
+	mi := 0
+	for i, n := range c.Nodes {
+		marker := "  "
+		if i == c.Cursor {
+			marker = accent.Render("▌ ")
+		}
+		role, swatch := "master", "  "
+		if n.IsReplica() {
+			role = "└ replica"
+		} else {
+			swatch = lipgloss.NewStyle().Foreground(lipgloss.Color(clusterPalette[mi%len(clusterPalette)])).Render("█ ")
+			mi++
+		}
+		flags := strings.Join(n.Flags, ",")
+		if n.Link != "connected" {
+			flags += " · link " + n.Link
+		}
+		flagStyle := subtle
+		if n.hasFlag("fail") || n.hasFlag("fail?") || n.Link != "connected" {
+			flagStyle = red
+		}
+		rows = append(rows, marker+swatch+text.Render(fmt.Sprintf("%-21s ", n.Addr))+dim.Render(fmt.Sprintf("%-8s ", truncateText(n.ID, 8)))+
+			subtle.Render(fmt.Sprintf("%-10s %6d  ", role, n.slotCount()))+flagStyle.Render(flags))
+	}
+	if len(c.Nodes) == 0 {
+		rows = append(rows, "  "+dim.Render("no nodes reported"))
+	}
+	if avail := m.WindowHeight - 10; avail > 0 && len(rows) > avail+1 {
+		start := 0
+		if c.Cursor >= avail {
+			start = c.Cursor - avail + 1
+		}
+		rows = append(rows[:1], rows[1+start:1+start+avail]...)
+	}
+
+	body := "  " + title + "\n\n" + bar + "\n\n" + strings.Join(rows, "\n")
+	if c.Notice != "" {
+		style := subtle
+		if c.Failed {
+			style = red
+		}
+		body += "\n\n  " + style.Render(c.Notice)
+	}
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(clusterKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
@@ -0,0 +1,386 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// COLD_KEYS reads OBJECT IDLETIME for a sample of the keys matching a
+// pattern and lists those idle for at least a threshold, coldest first:
+// housekeeping by hand for a server that has no eviction policy, or one that
+// should never need it. Neither SCAN nor OBJECT touches a key, so reading
+// the report doesn't warm anything up. The listed keys can then all be
+// deleted or given a TTL, after a confirmation.
+
+const (
+	defaultColdSample = 10000
+	maxColdSample     = 1000000
+	coldBatch         = 1000 // commands per pipelined round trip
+	coldPreviewKeys   = 10   // keys named on the confirmation
+)
+
+// ColdKey is a key and how long it has gone unread and unwritten.
+type ColdKey struct {
+	Key  string
+	Idle int // seconds
+}
+
+// ColdKeysModel is the state of the cold key report.
+type ColdKeysModel struct {
+	Pattern  string
+	MinIdle  int    // seconds
+	IdleText string // as entered, for the form
+	Sample   int
+	Sampled  int
+	Complete bool // every matching key was read, not just a sample
+	Keys     []ColdKey
+	Cursor   int
+	TTL      int    // for the expire action
+	Notice   string // what the last action did
+}
+
+// ColdKeysMsg reports the keys read for the report.
+type ColdKeysMsg struct {
+	Sampled  int
+	Complete bool
+	Keys     []ColdKey
+	Error    error
+}
+
+// ColdKeysActionMsg reports the listed keys deleted or expired.
+type ColdKeysActionMsg struct {
+	Op      Op
+	Done    int
+	Errors  int
+	LastErr string
+	Error   error
+}
+
+// parseIdle reads an idle threshold: seconds, or a number with an s, m, h
+// or d suffix.
+func parseIdle(s string) (int, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	unit := 1
+	if s != "" {
+		switch s[len(s)-1] {
+		case 's':
+			s = s[:len(s)-1]
+		case 'm':
+			unit, s = 60, s[:len(s)-1]
+		case 'h':
+			unit, s = 3600, s[:len(s)-1]
+		case 'd':
+			unit, s = 86400, s[:len(s)-1]
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("idle time must be seconds, or a number with s, m, h or d (like 7d)")
+	}
+	return n * unit, nil
+}
+
+func (m Model) startColdKeysForm() (tea.Model, tea.Cmd) {
+	m.setColdKeysForm(m.LastPattern, "7d", defaultColdSample)
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func (m *Model) setColdKeysForm(pattern, idle string, sample int) {
+	m.SelectedOp = OpColdKeys
+	m.Form = NewForm(
+		"COLD_KEYS · keys nobody has touched in a while",
+		[]string{"key pattern", "idle for at least (like 3600, 30m, 7d)", "keys to sample"},
+		[]string{pattern, idle, strconv.Itoa(sample)},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+}
+
+func submitColdKeysForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	if values[0] == "" {
+		m.Form.Err = "enter a key pattern"
+		return m, nil
+	}
+	idle, err := parseIdle(values[1])
+	if err != nil {
+		m.Form.Err = err.Error()
+		return m, nil
+	}
+	n, err := strconv.Atoi(values[2])
+	if err != nil || n <= 0 || n > maxColdSample {
+		m.Form.Err = fmt.Sprintf("sample size must be between 1 and %d keys", maxColdSample)
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.ColdKeys = ColdKeysModel{Pattern: values[0], MinIdle: idle, IdleText: values[1], Sample: n}
+	return m.switchToLoadingAndExecute(readColdKeys(m.Conn, m.Reader, m.ColdKeys, m.scanCountMax()))
+}
+
+// readColdKeys scans up to c.Sample keys matching c.Pattern and reads their
+// idle times in pipelined batches.
+func readColdKeys(conn net.Conn, reader *bufio.Reader, c ColdKeysModel, scanCount int) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return ColdKeysMsg{Error: fmt.Errorf("no connection to Redis")}
+		}
+		keys, err := scanAllKeys(conn, reader, c.Pattern, c.Sample, scanCount)
+		if err != nil {
+			return ColdKeysMsg{Error: err}
+		}
+		if len(keys) == 0 {
+			return ColdKeysMsg{Error: fmt.Errorf("no keys match %s", c.Pattern)}
+		}
+		msg := ColdKeysMsg{Complete: len(keys) < c.Sample}
+		for start := 0; start < len(keys); start += coldBatch {
+			batch := keys[start:min(start+coldBatch, len(keys))]
+			cmds := make([]redis.RedisCmd, len(batch))
+			for i, k := range batch {
+				cmds[i] = redis.RedisCmd{Name: "OBJECT", Args: []string{"IDLETIME", k}}
+			}
+			replies, err := pipeline(conn, reader, cmds)
+			if err != nil {
+				return ColdKeysMsg{Error: err}
+			}
+			for i, r := range replies {
+				switch v := r.(type) {
+				case int:
+					msg.Sampled++
+					if v >= c.MinIdle {
+						msg.Keys = append(msg.Keys, ColdKey{Key: batch[i], Idle: v})
+					}
+				case string:
+					if redis.IsErrorReply(v) {
+						// An LFU maxmemory-policy keeps access counts instead of times.
+						return ColdKeysMsg{Error: fmt.Errorf("OBJECT IDLETIME: %s", v)}
+					}
+				}
+				// nil: gone since the SCAN
+			}
+		}
+		sort.SliceStable(msg.Keys, func(i, j int) bool { return msg.Keys[i].Idle > msg.Keys[j].Idle })
+		return msg
+	}
+}
+
+// handleColdKeys shows the report, or sends the form back with what went
+// wrong. esc from the report returns to the form.
+func handleColdKeys(m Model, msg ColdKeysMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.Form.Err = msg.Error.Error()
+		m.CurrentState = StateForm
+		return m, nil
+	}
+	c := &m.ColdKeys
+	c.Sampled, c.Complete, c.Keys = msg.Sampled, msg.Complete, msg.Keys
+	c.Cursor, c.Notice = 0, ""
+	m.pushState(StateForm)
+	m.CurrentState = StateColdKeys
+	return m, nil
+}
+
+// confirmColdDelete asks before deleting every listed key.
+func (m Model) confirmColdDelete() (tea.Model, tea.Cmd) {
+	if m.ReadOnly {
+		return m, m.notify("deleting is disabled: this session is read-only", true)
+	}
+	m.SelectedOp = OpColdDelete
+	m.pushState(StateColdKeys)
+	m.ask(m.coldKeysDialog())
+	return m, nil
+}
+
+// startColdExpire asks for the TTL to give every listed key.
+func (m Model) startColdExpire() (tea.Model, tea.Cmd) {
+	if m.ReadOnly {
+		return m, m.notify("EXPIRE is disabled: this session is read-only", true)
+	}
+	m.SelectedOp = OpColdExpire
+	m.Form = NewForm(
+		fmt.Sprintf("COLD_KEYS · expire %d cold keys", len(m.ColdKeys.Keys)),
+		[]string{"TTL in seconds"},
+		[]string{"86400"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.pushState(StateColdKeys)
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitColdExpireForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	ttl, err := strconv.Atoi(values[0])
+	if err != nil || ttl <= 0 {
+		m.Form.Err = "TTL must be a positive number of seconds"
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.ColdKeys.TTL = ttl
+	m.pushState(StateForm)
+	m.ask(m.coldKeysDialog())
+	return m, nil
+}
+
+// coldKeysDialog asks before deleting or expiring the listed keys, showing
+// the first few.
+func (m Model) coldKeysDialog() Dialog {
+	c := m.ColdKeys
+	heading, label := "confirm delete", fmt.Sprintf("delete %d keys idle for at least %s", len(c.Keys), formatTTL(c.MinIdle))
+	if m.SelectedOp == OpColdExpire {
+		heading = "confirm EXPIRE"
+		label = fmt.Sprintf("set a TTL of %d s on %d keys idle for at least %s", c.TTL, len(c.Keys), formatTTL(c.MinIdle))
+	}
+	var names []string
+	for i, k := range c.Keys {
+		if i == coldPreviewKeys {
+			names = append(names, fmt.Sprintf("… %d more", len(c.Keys)-i))
+			break
+		}
+		names = append(names, k.Key)
+	}
+	return Dialog{Title: heading, Label: label, Value: strings.Join(names, "\n  "), Destructive: true,
+		Target: m.orDB(c.Pattern), Choices: confirmChoice(confirmColdAction)}
+}
+
+// runColdAction deletes or expires keys, one command each so a cluster
+// doesn't refuse them as cross-slot, pipelined in batches.
+func runColdAction(conn net.Conn, reader *bufio.Reader, op Op, keys []ColdKey, ttl int) tea.Cmd {
+	return func() tea.Msg {
+		msg := ColdKeysActionMsg{Op: op}
+		if conn == nil {
+			msg.Error = fmt.Errorf("no connection to Redis")
+			return msg
+		}
+		for start := 0; start < len(keys); start += coldBatch {
+			batch := keys[start:min(start+coldBatch, len(keys))]
+			cmds := make([]redis.RedisCmd, len(batch))
+			for i, k := range batch {
+				cmds[i] = redis.Del(k.Key)
+				if op == OpColdExpire {
+					cmds[i] = redis.Expire(k.Key, ttl)
+				}
+			}
+			replies, err := pipeline(conn, reader, cmds)
+			if err != nil {
+				msg.Error = err
+				return msg
+			}
+			for _, r := range replies {
+				switch v := r.(type) {
+				case int:
+					msg.Done += v
+				case string:
+					if redis.IsErrorReply(v) {
+						msg.Errors++
+						msg.LastErr = v
+					}
+				}
+			}
+		}
+		return msg
+	}
+}
+
+func confirmColdAction(m Model) (tea.Model, tea.Cmd) {
+	return m.switchToLoadingAndExecute(runColdAction(m.Conn, m.Reader, m.SelectedOp, m.ColdKeys.Keys, m.ColdKeys.TTL))
+}
+
+// handleColdKeysAction goes back to the report, emptied of the keys just
+// dealt with: deleted, or given a TTL and so no longer left to linger.
+func handleColdKeysAction(m Model, msg ColdKeysActionMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.Result = errorResult(msg.Error)
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	if msg.Op == OpColdExpire {
+		m.popState() // the TTL form
+	}
+	m.CurrentState = m.popState()
+	c := &m.ColdKeys
+	verb := "deleted"
+	if msg.Op == OpColdExpire {
+		verb = fmt.Sprintf("given a TTL of %d s", c.TTL)
+	}
+	c.Notice = fmt.Sprintf("%d of %d keys %s", msg.Done, len(c.Keys), verb)
+	if msg.Errors > 0 {
+		c.Notice += fmt.Sprintf("; %d error replies, last: %s", msg.Errors, msg.LastErr)
+	}
+	c.Keys, c.Cursor = nil, 0
+	return m, m.notify(c.Notice, msg.Errors > 0)
+}
+
+func handleStateColdKeysKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := &m.ColdKeys
+	switch keyMsg.String() {
+	case "esc":
+		// The expire action's TTL form may have replaced the report's.
+		m.setColdKeysForm(c.Pattern, c.IdleText, c.Sample)
+		m.CurrentState = m.popState()
+	case "up", "k":
+		if c.Cursor > 0 {
+			c.Cursor--
+		}
+	case "down", "j":
+		if c.Cursor < len(c.Keys)-1 {
+			c.Cursor++
+		}
+	case "r":
+		m.popState() // handleColdKeys pushes the form again
+		return m.switchToLoadingAndExecute(readColdKeys(m.Conn, m.Reader, *c, m.scanCountMax()))
+	case "d":
+		if len(c.Keys) > 0 {
+			return m.confirmColdDelete()
+		}
+	case "e":
+		if len(c.Keys) > 0 {
+			return m.startColdExpire()
+		}
+	}
+	return m, nil
+}
+
+func (m Model) coldKeysView() string {
+	c := m.ColdKeys
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+
+	sampled := fmt.Sprintf("all %d keys", c.Sampled)
+	if !c.Complete {
+		sampled = fmt.Sprintf("a sample of %d keys", c.Sampled)
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Bold(true).Render("COLD_KEYS") +
+		dim.Render(fmt.Sprintf(" · %s · idle ≥ %s · %d of %s", c.Pattern, formatTTL(c.MinIdle), len(c.Keys), sampled))
+
+	rowsAvail := max(m.WindowHeight-12, 3)
+	first := max(c.Cursor-rowsAvail+1, 0)
+	rows := []string{"  " + dim.Render(fmt.Sprintf("%8s   %s", "idle", "key"))}
+	for i := first; i < len(c.Keys) && i < first+rowsAvail; i++ {
+		k := c.Keys[i]
+		marker, name := "  ", text.Render(k.Key)
+		if i == c.Cursor {
+			marker, name = accent.Render(pointerGlyph), accent.Bold(true).Render(k.Key)
+		}
+		rows = append(rows, marker+subtle.Render(fmt.Sprintf("%8s", formatTTL(k.Idle)))+"   "+name)
+	}
+	if len(c.Keys) == 0 {
+		rows = append(rows, "  "+dim.Render("no keys idle that long"))
+	}
+
+	body := "  " + title + "\n\n" + strings.Join(rows, "\n")
+	if c.Notice != "" {
+		body += "\n\n  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)).Render(c.Notice)
+	}
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(coldKeysKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+)
+
+// CompareResultMsg carries the rendered diff of one key across two servers.
+type CompareResultMsg struct {
+	Output string
+	Error  error
+}
+
+// compareSide is one end of a comparison: where to connect and what to call it.
+type compareSide struct {
+	Label    string
+	Endpoint Endpoint
+}
+
+func (m Model) startCompareForm() (tea.Model, tea.Cmd) {
+	other := ""
+	for _, name := range m.Config.Names() {
+		if name != m.ProfileName {
+			other = name
+			break
+		}
+	}
+	m.Form = NewForm(
+		"COMPARE · one key on two connections",
+		[]string{"key", "A (profile, redis:// URL, or current)", "B (profile, redis:// URL, or current)"},
+		[]string{m.ActiveKey, "current", other},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitCompareForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	key := values[0]
+	if key == "" {
+		m.Form.Err = "a key is required"
+		return m, nil
+	}
+	return m.withTargets(values[1:3], func(m Model, resolved []compareSide, err error) (tea.Model, tea.Cmd) {
+		if err != nil {
+			m.Form.Err = err.Error()
+			m.CurrentState = StateForm
+			return m, nil
+		}
+		m.Form.Err = ""
+		m.ActiveKey = key
+		m.pushState(StateForm)
+		return m.switchToLoadingAndDial(compareKey(m, key, [2]compareSide(resolved)))
+	})
+}
+
+// compareKey fetches key from both sides over short-lived connections of their
+// own — the session's connection stays free, and side A may be a different
+// server than the one this tab is on.
+func compareKey(m Model, key string, sides [2]compareSide) tea.Cmd {
+	return func() tea.Msg {
+		var values [2]keyValue
+		for i, side := range sides {
+			conn, reader, _, err := openConnection(m.withEndpoint(side.Endpoint))
+			if err != nil {
+				return CompareResultMsg{Error: fmt.Errorf("%s: %w", side.Label, err)}
+			}
+			values[i], err = fetchKeyValue(conn, reader, key)
+			_ = conn.Close()
+			if err != nil {
+				return CompareResultMsg{Error: fmt.Errorf("%s: %w", side.Label, err)}
+			}
+		}
+		return CompareResultMsg{Output: renderCompare(key, sides, values)}
+	}
+}
+
+// describeValue summarizes a fetched key for the comparison header.
+func describeValue(v keyValue) string {
+	if v.Type == "none" {
+		return "(missing)"
+	}
+	unit := "elements"
+	switch v.Type {
+	case "string":
+		unit = "bytes"
+	case "hash":
+		unit = "fields"
+	case "set", "zset":
+		unit = "members"
+	}
+	ttl := "no expiry"
+	if v.TTL >= 0 {
+		ttl = fmt.Sprintf("TTL %d s", v.TTL)
+	}
+	return fmt.Sprintf("%s · %d %s · %s", v.Type, v.size(), unit, ttl)
+}
+
+// renderCompare lays out the two summaries followed by a type-appropriate
+// diff: lines for strings and lists, fields for hashes, members for sets,
+// member scores for sorted sets.
+func renderCompare(key string, sides [2]compareSide, v [2]keyValue) string {
+	width := max(runewidth.StringWidth(sides[0].Label), runewidth.StringWidth(sides[1].Label))
+	var b strings.Builder
+	fmt.Fprintf(&b, "A  %s  %s\n", padCells(sides[0].Label, width), describeValue(v[0]))
+	fmt.Fprintf(&b, "B  %s  %s\n\n", padCells(sides[1].Label, width), describeValue(v[1]))
+
+	switch {
+	case v[0].Type == "none" && v[1].Type == "none":
+		b.WriteString("key exists on neither side")
+		return b.String()
+	case v[0].Type == "none" || v[1].Type == "none":
+		b.WriteString("key exists on one side only")
+		return b.String()
+	case v[0].Type != v[1].Type:
+		fmt.Fprintf(&b, "types differ: A is %s, B is %s", v[0].Type, v[1].Type)
+		return b.String()
+	}
+
+	var lines []string
+	same := 0
+	switch v[0].Type {
+	case "string":
+		lines = lineDiff(strings.Split(tryPrettyJSON(v[0].String), "\n"), strings.Split(tryPrettyJSON(v[1].String), "\n"))
+	case "list":
+		lines = lineDiff(v[0].Items, v[1].Items)
+	case "hash", "zset":
+		lines, same = mapDiff(v[0].Fields, v[1].Fields)
+	case "set":
+		lines, same = mapDiff(setOf(v[0].Items), setOf(v[1].Items))
+		for i, l := range lines {
+			lines[i] = strings.TrimSuffix(l, " = ") // members have no value
+		}
+	}
+
+	changed := 0
+	for _, l := range lines {
+		if !strings.HasPrefix(l, "  ") {
+			changed++
+		}
+	}
+	if changed == 0 {
+		b.WriteString("✓ values are identical")
+		return b.String()
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+	if same > 0 {
+		fmt.Fprintf(&b, "\n\n%d identical", same)
+	}
+	return b.String()
+}
+
+func setOf(items []string) map[string]string {
+	s := make(map[string]string, len(items))
+	for _, it := range items {
+		s[it] = ""
+	}
+	return s
+}
+
+func handleCompareResult(m Model, msg CompareResultMsg) (tea.Model, tea.Cmd) {
+	m.ActiveTTL = ""
+	m.CopyStatus = ""
+	if msg.Error != nil {
+		m.Result = errorResult(msg.Error)
+	} else {
+		m.Result = textResult(msg.Output)
+	}
+	m.CurrentState = StateOutput
+	return m, nil
+}
@@ -0,0 +1,661 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	maxConsoleLog     = 500 // entries kept in the console scrollback
+	maxConsoleHistory = 200 // command lines kept for ↑/↓
+)
+
+// ConsoleModel is the state of the raw command console. It talks over the
+// session's connection, unless it is pinned to a cluster node — then it owns
+// a dedicated connection to that node, since per-node commands (MEMORY,
+// SLOWLOG, CONFIG) are meaningless when routed anywhere else.
+type ConsoleModel struct {
+	Input  textinput.Model
+	Label  string // what the console is talking to, for the title
+	Log    []ConsoleEntry
+	Busy   bool // waiting for a reply; input is ignored meanwhile
+	Pinned bool // Conn/Reader are the console's own
+
+	Conn   net.Conn
+	Reader *bufio.Reader
+	Gen    int // retires replies and connections from a closed console
+
+	// History is the lines sent, oldest first, read from HistoryPath when
+	// the console first opens (see console_history.go). HistoryPos is the
+	// line ↑/↓ has reached (len(History) when back at the one being typed,
+	// kept in Draft).
+	History     []string
+	HistoryPath string
+	HistoryPos  int
+	Draft       string
+
+	// Ctrl+R reverse search: Search is the query, Match the index in
+	// History of the line it found, -1 for none.
+	Searching bool
+	Search    string
+	Match     int
+
+	// Key completion (see console_keys.go): KeyBase is the line before the
+	// argument whose keys are offered, KeyHint what the scan found.
+	KeyBase string
+	KeyHint string
+
+	// Confirm is a write held back on a production profile until the next
+	// line names its key (see model_production.go).
+	Confirm []string
+}
+
+// ConsoleEntry is one command and its formatted reply.
+type ConsoleEntry struct {
+	Command string
+	Reply   string
+	Raw     any // the reply as read, for Ctrl+S
+	Failed  bool
+}
+
+// ConsoleConnMsg delivers a pinned console's connection.
+type ConsoleConnMsg struct {
+	Gen    int
+	Conn   net.Conn
+	Reader *bufio.Reader
+	Error  error
+}
+
+// ConsoleReplyMsg carries the reply to one console command.
+type ConsoleReplyMsg struct {
+	Gen   int
+	Args  []string
+	Reply any
+	Error error
+}
+
+// consoleRefused lists commands the console won't send, with the reason.
+// Subscriptions and MONITOR never stop replying, which would desync the
+// connection for everything after them.
+var consoleRefused = map[string]string{
+	"SUBSCRIBE":  "subscriptions keep the connection streaming; not supported in the console",
+	"PSUBSCRIBE": "subscriptions keep the connection streaming; not supported in the console",
+	"SSUBSCRIBE": "subscriptions keep the connection streaming; not supported in the console",
+	"MONITOR":    "MONITOR keeps the connection streaming; not supported in the console",
+}
+
+func newConsoleInput() textinput.Model {
+	in := textinput.New()
+	in.Prompt = "› "
+	in.CharLimit = 0
+	// Completions are the commands and subcommands the server reported
+	// (see discovery.go); Tab takes the one shown, Ctrl+N/Ctrl+P cycle
+	// through the others. ↑/↓ are history, not completions.
+	in.ShowSuggestions = true
+	in.KeyMap.NextSuggestion = key.NewBinding(key.WithKeys("ctrl+n"))
+	in.KeyMap.PrevSuggestion = key.NewBinding(key.WithKeys("ctrl+p"))
+	in.Focus()
+	return in
+}
+
+// newConsole is a fresh console that keeps the session's history, and the
+// command reading it from disk the first time (or after a server switch).
+func (m Model) newConsole(label string) (ConsoleModel, tea.Cmd) {
+	c := ConsoleModel{Input: newConsoleInput(), Label: label, Gen: m.Console.Gen + 1, HistoryPath: m.historyPath()}
+	var load tea.Cmd
+	if c.HistoryPath == m.Console.HistoryPath {
+		c.History = m.Console.History
+	} else if c.HistoryPath != "" {
+		load = loadConsoleHistory(c.HistoryPath)
+	}
+	c.HistoryPos = len(c.History)
+	c.Input.SetSuggestions(consoleCompletions(m.Commands))
+	return c, load
+}
+
+// startConsole opens the console on the session's own connection.
+func (m Model) startConsole() (tea.Model, tea.Cmd) {
+	var load tea.Cmd
+	m.Console, load = m.newConsole(m.RedisAddress)
+	m.Browser.Cache = nil // commands typed here may write anything
+	m.CurrentState = StateConsole
+	return m, tea.Batch(textinput.Blink, load)
+}
+
+// startNodeConsole opens the console pinned to a cluster node.
+func (m Model) startNodeConsole(addr string) (tea.Model, tea.Cmd) {
+	var load tea.Cmd
+	m.Console, load = m.newConsole(addr)
+	m.Console.Pinned, m.Console.Busy = true, true
+	m.pushState(m.CurrentState)
+	m.CurrentState = StateConsole
+	return m, tea.Batch(openNodeConnection(m, addr, m.Console.Gen), load)
+}
+
+// nodeModel returns a copy of m pointed at one cluster node. Cluster nodes
+// only have database 0.
+func (m Model) nodeModel(addr string) Model {
+	e := m.endpoint()
+	e.Address, e.DB = addr, 0
+	return m.withEndpoint(e)
+}
+
+func openNodeConnection(m Model, addr string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m.nodeModel(addr))
+		return ConsoleConnMsg{Gen: gen, Conn: conn, Reader: reader, Error: err}
+	}
+}
+
+func handleConsoleConn(m Model, msg ConsoleConnMsg) (tea.Model, tea.Cmd) {
+	c := &m.Console
+	if msg.Gen != c.Gen || m.CurrentState != StateConsole {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close()
+		}
+		return m, nil
+	}
+	c.Busy = false
+	if msg.Error != nil {
+		c.Log = append(c.Log, ConsoleEntry{Reply: "could not connect: " + msg.Error.Error(), Failed: true})
+		return m, nil
+	}
+	c.Conn, c.Reader = msg.Conn, msg.Reader
+	return m, nil
+}
+
+// closeConsole releases a pinned console's connection.
+func (m *Model) closeConsole() {
+	c := &m.Console
+	if c.Pinned && c.Conn != nil {
+		_ = c.Conn.Close()
+	}
+	c.Conn, c.Reader = nil, nil
+	c.Gen++
+}
+
+// splitCommandLine splits a console line into arguments the way redis-cli
+// does: on whitespace, with "double" or 'single' quotes grouping words and
+// backslash escapes inside double quotes.
+func splitCommandLine(line string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg := false
+	var quote rune
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			switch {
+			case r == quote:
+				quote = 0
+			case r == '\\' && quote == '"' && i+1 < len(runes):
+				i++
+				switch runes[i] {
+				case 'n':
+					cur.WriteRune('\n')
+				case 't':
+					cur.WriteRune('\t')
+				default:
+					cur.WriteRune(runes[i])
+				}
+			default:
+				cur.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unbalanced quotes")
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
+
+// consoleRefusal explains why the console won't send args, or returns "".
+// It holds the console to the same rules as the rest of the UI: lifecycle
+// commands need an admin profile, and read-only sessions can't start
+// background saves or failovers.
+func (m Model) consoleRefusal(args []string) string {
+	if reason := m.productionRefusal(args); reason != "" {
+		return reason
+	}
+	name := strings.ToUpper(args[0])
+	if reason, ok := consoleRefused[name]; ok {
+		return reason
+	}
+	if m.Proxy && proxyRefusedCommands[name] {
+		return name + " isn't forwarded by proxies; disabled for this profile (\"proxy\": true)"
+	}
+	switch name {
+	case "SHUTDOWN", "DEBUG":
+		return m.lifecycleRefusal()
+	case "BGSAVE", "BGREWRITEAOF", "SAVE":
+		if m.ReadOnly {
+			return "read-only session: " + name + " is disabled"
+		}
+	case "CLUSTER":
+		if m.ReadOnly && len(args) > 1 && strings.EqualFold(args[1], "FAILOVER") {
+			return "read-only session: CLUSTER FAILOVER is disabled"
+		}
+	}
+	return ""
+}
+
+func (c *ConsoleModel) addLog(entry ConsoleEntry) {
+	c.Log = append(c.Log, entry)
+	if len(c.Log) > maxConsoleLog {
+		c.Log = c.Log[len(c.Log)-maxConsoleLog:]
+	}
+}
+
+// sendConsole sends args on the console's connection and marks it busy
+// until the reply arrives.
+func (m *Model) sendConsole(args []string) tea.Cmd {
+	c := &m.Console
+	c.Busy = true
+	conn, reader := m.consoleConn()
+	cmd := sendConsoleCmd(conn, reader, args, c.Gen)
+	if !c.Pinned {
+		cmd = m.exec(cmd)
+	}
+	return cmd
+}
+
+func (m Model) consoleConn() (net.Conn, *bufio.Reader) {
+	if m.Console.Pinned {
+		return m.Console.Conn, m.Console.Reader
+	}
+	return m.Conn, m.Reader
+}
+
+func sendConsoleCmd(conn net.Conn, reader *bufio.Reader, args []string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return ConsoleReplyMsg{Gen: gen, Args: args, Error: fmt.Errorf("no connection to Redis")}
+		}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{{Name: args[0], Args: args[1:]}})
+		if err != nil {
+			_ = conn.Close() // the reply stream is desynced; reconnect rather than misread
+			return ConsoleReplyMsg{Gen: gen, Args: args, Error: err}
+		}
+		return ConsoleReplyMsg{Gen: gen, Args: args, Reply: replies[0]}
+	}
+}
+
+func handleConsoleReply(m Model, msg ConsoleReplyMsg) (tea.Model, tea.Cmd) {
+	c := &m.Console
+	if msg.Gen != c.Gen {
+		return m, nil
+	}
+	c.Busy = false
+	last := &c.Log[len(c.Log)-1]
+	if msg.Error != nil {
+		last.Reply, last.Failed = msg.Error.Error(), true
+		if !c.Pinned {
+			// Same recovery as any other command on the session connection.
+			m.pushState(StateConsole)
+			m.CurrentState = StateLoading
+			return m, connectToRedis(m)
+		}
+		return m, nil
+	}
+	last.Reply, last.Raw = formatReply(msg.Reply, ""), msg.Reply
+	if header, rows, ok := flatRows(msg.Reply, last.Command); ok && len(header) == 2 && len(rows) > 0 {
+		last.Reply = formatPairs(header, rows)
+	}
+	if s, ok := msg.Reply.(string); ok && redis.IsErrorReply(s) {
+		last.Failed = true
+	}
+	if msg.Reply == "OK" && !c.Pinned {
+		m.trackSessionState(msg.Args)
+	}
+	return m, nil
+}
+
+// trackSessionState records connection state changed from the console so a
+// reconnect replays it, instead of silently landing in another database or
+// unauthenticated.
+func (m *Model) trackSessionState(args []string) {
+	switch strings.ToUpper(args[0]) {
+	case "SELECT":
+		if len(args) == 2 {
+			if db, err := strconv.Atoi(args[1]); err == nil {
+				m.DB = db
+			}
+		}
+	case "AUTH":
+		switch len(args) {
+		case 2:
+			m.Username, m.Password = "", args[1]
+		case 3:
+			m.Username, m.Password = args[1], args[2]
+		}
+	case "CLIENT":
+		if len(args) == 3 && strings.EqualFold(args[1], "SETNAME") {
+			m.ClientName = args[2]
+		}
+	}
+}
+
+// maxPairName caps the first column of a pair table, so one long field name
+// doesn't push every value off screen.
+const maxPairName = 40
+
+// formatPairs renders a name/value reply (HGETALL, CONFIG GET) as a table:
+// numbered rows with the values lined up, under the column names.
+func formatPairs(header []string, rows [][]string) string {
+	w := lipgloss.Width(header[0])
+	for _, r := range rows {
+		w = max(w, min(lipgloss.Width(oneLine(r[0])), maxPairName))
+	}
+	num := len(strconv.Itoa(len(rows)))
+	pad := func(s string) string {
+		return s + strings.Repeat(" ", max(w-lipgloss.Width(s), 0))
+	}
+	lines := []string{strings.Repeat(" ", num+2) + pad(header[0]) + "  " + header[1]}
+	for i, r := range rows {
+		lines = append(lines, fmt.Sprintf("%*d) ", num, i+1)+pad(oneLine(r[0]))+"  "+oneLine(r[1]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// oneLine keeps a table cell on its row.
+func oneLine(s string) string {
+	return strings.NewReplacer("\n", `\n`, "\r", `\r`).Replace(s)
+}
+
+// formatReply renders a reply the way redis-cli does: integers tagged,
+// arrays numbered, nested arrays indented under their index.
+func formatReply(v any, indent string) string {
+	switch r := v.(type) {
+	case int:
+		return fmt.Sprintf("(integer) %d", r)
+	case []any:
+		if len(r) == 0 {
+			return "(empty array)"
+		}
+		width := len(fmt.Sprint(len(r)))
+		var lines []string
+		for i, item := range r {
+			prefix := fmt.Sprintf("%*d) ", width, i+1)
+			pad := indent + strings.Repeat(" ", len(prefix))
+			body := formatReply(item, pad)
+			if i == 0 {
+				lines = append(lines, prefix+body)
+			} else {
+				lines = append(lines, indent+prefix+body)
+			}
+		}
+		return strings.Join(lines, "\n")
+	default:
+		return fmt.Sprint(r)
+	}
+}
+
+func handleStateConsoleKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := &m.Console
+	if c.Searching {
+		return handleConsoleSearchKey(m, keyMsg)
+	}
+	switch keyMsg.String() {
+	case "ctrl+r":
+		c.Searching, c.Search, c.Match = true, "", -1
+		return m, nil
+	case "esc":
+		m.closeConsole()
+		m.CurrentState = m.popState()
+		return m, nil
+	case "ctrl+l":
+		c.Log = nil
+		return m, nil
+	case "ctrl+y":
+		// The last reply as CSV, for a spreadsheet.
+		for i := len(c.Log) - 1; i >= 0; i-- {
+			e := c.Log[i]
+			if e.Command == "" || e.Raw == nil {
+				continue
+			}
+			note := ConsoleEntry{Reply: "the last reply isn't a flat list; nothing copied", Failed: true}
+			if header, rows, ok := flatRows(e.Raw, e.Command); ok {
+				note = ConsoleEntry{Reply: fmt.Sprintf("copied %d rows as CSV", len(rows))}
+				if err := clipboard.WriteAll(csvText(header, rows)); err != nil {
+					note = ConsoleEntry{Reply: clipboardErrorHint(), Failed: true}
+				}
+			}
+			c.Log = append(c.Log, note)
+			break
+		}
+		return m, nil
+	case "ctrl+s":
+		for i := len(c.Log) - 1; i >= 0; i-- {
+			if e := c.Log[i]; e.Command != "" && e.Raw != nil {
+				return m.startResultExport(replyExport(e.Raw, e.Command, e.Reply), "console")
+			}
+		}
+		return m, nil
+	case "tab":
+		// The whole name, in the server's spelling, rather than the
+		// textinput's own accept, which keeps the case of what was typed.
+		if s := c.Input.CurrentSuggestion(); s != "" && len(s) >= len(c.Input.Value()) {
+			c.Input.SetValue(s + " ")
+			c.Input.CursorEnd()
+			m.dropKeyCompletions()
+			return m, nil
+		}
+		return m, m.startKeyCompletion()
+	case "up":
+		if c.HistoryPos > 0 {
+			if c.HistoryPos == len(c.History) {
+				c.Draft = c.Input.Value()
+			}
+			c.HistoryPos--
+			c.Input.SetValue(c.History[c.HistoryPos])
+			c.Input.CursorEnd()
+		}
+		return m, nil
+	case "down":
+		if c.HistoryPos < len(c.History) {
+			c.HistoryPos++
+			if c.HistoryPos == len(c.History) {
+				c.Input.SetValue(c.Draft)
+			} else {
+				c.Input.SetValue(c.History[c.HistoryPos])
+			}
+			c.Input.CursorEnd()
+		}
+		return m, nil
+	case "enter":
+		if c.Busy {
+			return m, nil
+		}
+		line := strings.TrimSpace(c.Input.Value())
+		if line == "" {
+			return m, nil
+		}
+		c.Input.SetValue("")
+		m.dropKeyCompletions()
+		if c.Confirm != nil {
+			return m.confirmConsoleWrite(line)
+		}
+		c.addHistory(line)
+		save := appendConsoleHistory(c.HistoryPath, line)
+		args, err := splitCommandLine(line)
+		if err == nil && len(args) == 0 {
+			return m, save
+		}
+		entry := ConsoleEntry{Command: line}
+		if err != nil {
+			entry.Reply, entry.Failed = err.Error(), true
+		} else if err := (redis.RedisCmd{Name: args[0]}).Validate(); err != nil {
+			entry.Reply, entry.Failed = err.Error(), true
+		} else if reason := m.consoleRefusal(args); reason != "" {
+			entry.Reply, entry.Failed = reason, true
+		} else if m.Production && redis.IsWriteCommand(redis.RedisCmd{Name: args[0], Args: args[1:]}) {
+			c.Confirm = args
+			entry.Reply = "production: type " + consoleWriteTarget(args) + " and press enter to send this; anything else cancels it"
+		}
+		c.addLog(entry)
+		if entry.Failed || c.Confirm != nil {
+			return m, save
+		}
+		return m, tea.Batch(m.sendConsole(args), save)
+	}
+	var cmd tea.Cmd
+	before := c.Input.Value()
+	c.Input, cmd = c.Input.Update(keyMsg)
+	if c.Input.Value() != before {
+		m.dropKeyCompletions()
+	}
+	return m, cmd
+}
+
+// handleConsoleSearchKey drives Ctrl+R search: typing narrows it to the
+// newest line containing the query, Ctrl+R again steps to older ones, and
+// Enter puts the line found in the input to edit or send; Esc leaves the
+// input as it was.
+func handleConsoleSearchKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := &m.Console
+	switch keyMsg.Type {
+	case tea.KeyCtrlR:
+		from := len(c.History)
+		if c.Match >= 0 {
+			from = c.Match
+		}
+		if i := searchHistory(c.History, c.Search, from); i >= 0 {
+			c.Match = i
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		c.Search += string(keyMsg.Runes)
+		from := len(c.History)
+		if c.Match >= 0 {
+			from = c.Match + 1 // the current line may still match
+		}
+		c.Match = searchHistory(c.History, c.Search, from)
+	case tea.KeyBackspace:
+		if r := []rune(c.Search); len(r) > 0 {
+			c.Search = string(r[:len(r)-1])
+		}
+		c.Match = -1
+		if c.Search != "" {
+			c.Match = searchHistory(c.History, c.Search, len(c.History))
+		}
+	case tea.KeyEnter, tea.KeyTab, tea.KeyRight, tea.KeyLeft:
+		if c.Match >= 0 {
+			if c.HistoryPos == len(c.History) {
+				c.Draft = c.Input.Value()
+			}
+			c.HistoryPos = c.Match
+			c.Input.SetValue(c.History[c.Match])
+			c.Input.CursorEnd()
+		}
+		c.Searching = false
+	case tea.KeyEsc, tea.KeyCtrlG, tea.KeyCtrlC:
+		c.Searching = false
+	}
+	return m, nil
+}
+
+// addHistory records a sent line, skipping a repeat of the last one, and
+// returns ↑/↓ to the line being typed.
+func (c *ConsoleModel) addHistory(line string) {
+	if n := len(c.History); n == 0 || c.History[n-1] != line {
+		c.History = append(c.History, line)
+		if len(c.History) > maxConsoleHistory {
+			c.History = c.History[len(c.History)-maxConsoleHistory:]
+		}
+	}
+	c.HistoryPos, c.Draft = len(c.History), ""
+}
+
+func (m Model) consoleView() string {
+	c := m.Console
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnInfo)).Bold(true).Render("CONSOLE") + dim.Render(" · "+c.Label)
+	if c.Pinned {
+		title += dim.Render(" (pinned node)")
+	}
+	if c.Busy {
+		title += "   " + subtle.Render("waiting…")
+	}
+
+	var lines []string
+	for _, e := range c.Log {
+		if e.Command != "" {
+			lines = append(lines, accent.Render("› ")+text.Render(e.Command))
+		}
+		style := subtle
+		if e.Failed {
+			style = red
+		}
+		if e.Reply != "" || !c.Busy {
+			for _, l := range strings.Split(e.Reply, "\n") {
+				lines = append(lines, "  "+style.Render(l))
+			}
+		}
+	}
+	// header(2) + blank + title + blank + input + hint + footer(2)
+	if avail := m.WindowHeight - 9; avail > 0 && len(lines) > avail {
+		lines = lines[len(lines)-avail:]
+	}
+	if len(lines) == 0 {
+		lines = append(lines, dim.Render("type a command, e.g. INFO memory or SLOWLOG GET 10"))
+	}
+
+	in := c.Input
+	in.Width = max(m.WindowWidth-8, 10)
+	body := "  " + title + "\n\n" + indentLines(strings.Join(lines, "\n"), 2) + "\n\n  "
+	h := m.Help
+	h.Width = m.WindowWidth
+	var help string
+	if c.Searching {
+		prompt, match := "(reverse-i-search)", ""
+		if c.Match >= 0 {
+			match = c.History[c.Match]
+		} else if c.Search != "" {
+			prompt = "(failing reverse-i-search)"
+		}
+		body += subtle.Render(prompt+"`") + text.Render(c.Search) + subtle.Render("': ") + text.Render(truncateText(match, max(m.WindowWidth-len(prompt)-len(c.Search)-8, 10)))
+		help = h.View(consoleSearchKeys)
+	} else {
+		body += in.View()
+		hint := c.KeyHint
+		if hint == "" {
+			hint = consoleHint(m.Commands, c.Input.Value())
+		}
+		if hint != "" {
+			body += "\n  " + dim.Render(truncateText(hint, max(m.WindowWidth-4, 10)))
+		}
+		help = h.View(consoleKeys)
+	}
+	foot := footerSep(m.WindowWidth) + "\n  " + help
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/ajxv/redis-tui/internal/redis"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -18,14 +21,43 @@ const (
 	StateMenu AppState = iota
 	StateInputKey
 	StateInputField
-	StateFieldSelect
 	StateInputValue
 	StateOutput
 	StateBrowser
 	StateLoading
 	StateConfirmation
-	StateInfo
 	StateInputFilePath
+	StateForm          // multi-field tool form (FormModel)
+	StateBlocking      // BLPOP/BRPOP watcher
+	StateQueues        // list-based job queue dashboard
+	StateCounters      // counter / rate-limit inspector
+	StateDBDiff        // database diff report
+	StateSnapshot      // scheduled snapshot status
+	StatePersistence   // RDB/AOF status and background save controls
+	StateCluster       // cluster overview: nodes, slot coverage
+	StateConsole       // raw command console
+	StateReconnect     // connection lost: backoff countdown, retry, change server
+	StateTemplates     // key template picker
+	StateGenerate      // test data generator progress
+	StateBenchmark     // PING/SET/GET benchmark results
+	StateTimeline      // keyspace event timeline for a pattern
+	StateStream        // large string value shown as it arrives
+	StateTour          // guided tour of the basics
+	StateMacros        // macro picker for the selected key
+	StateKeyMenu       // popup of actions on the selected key
+	StateCreateKey     // type picker for a key created from the browser
+	StateBulkTTL       // TTL set on every key matching a pattern: progress
+	StateGrowth        // keyspace growth monitor
+	StateColdKeys      // keys idle past a threshold, coldest first
+	StateReplace       // find and replace across string values: progress
+	StateAudit         // audit log of the commands sent, writes first
+	StateTail          // live tail of a stream key
+	StateGroups        // consumer groups of a stream
+	StatePending       // pending entries of a consumer group
+	StateSearch        // values matching a search, as the scan finds them
+	StateSavedSearches // popup of the profile's saved key patterns and filters
+	StateFlags         // keys holding 0/1 or true/false, as feature flags
+	StateLeaderboard   // top of a sorted set by score, re-read periodically
 )
 
 type Op int
@@ -54,7 +86,8 @@ func NewGroupedMenuDelegate() groupedMenuDelegate {
 	return groupedMenuDelegate{DefaultDelegate: list.NewDefaultDelegate()}
 }
 
-// Height is one tight line per command (fits all 15 in an 80×24 terminal).
+// Height is one tight line per command, so a short terminal shows as many
+// as it can and the list pages through the rest.
 // Grouping is carried by the type color of each command name.
 func (d groupedMenuDelegate) Height() int  { return 1 }
 func (d groupedMenuDelegate) Spacing() int { return 0 }
@@ -74,13 +107,28 @@ func (d groupedMenuDelegate) Render(w io.Writer, m list.Model, index int, item l
 	isSelected := index == m.Index()
 	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(commandColor(li.title))).Width(menuNameCol)
 	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnFaint))
+	desc := li.desc
+	if li.missing != "" {
+		nameStyle = nameStyle.Foreground(lipgloss.Color(tnDim))
+		desc = "unavailable: this server has no " + li.missing
+	}
+	desc = menuShortcut(index) + desc
 
 	if isSelected {
 		marker := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render("❯ ")
-		fmt.Fprintf(w, "%s%s %s", marker, nameStyle.Bold(true).Render(li.title), descStyle.Render(li.desc))
+		fmt.Fprintf(w, "%s%s %s", marker, nameStyle.Bold(true).Render(li.title), descStyle.Render(desc))
 	} else {
-		fmt.Fprintf(w, "  %s %s", nameStyle.Render(li.title), descStyle.Render(li.desc))
+		fmt.Fprintf(w, "  %s %s", nameStyle.Render(li.title), descStyle.Render(desc))
+	}
+}
+
+// menuShortcut labels the description of the index'th item shown: its digit
+// for the first nine, which run it from the menu, else matching blank space.
+func menuShortcut(index int) string {
+	if index < 9 {
+		return strconv.Itoa(index+1) + " "
 	}
+	return "  "
 }
 
 // StyleList strips the Bubbles list chrome down to a clean, background-free
@@ -100,23 +148,25 @@ func StyleList(l *list.Model) {
 // commandColor maps a menu command name to its type color (matches the v2 spec).
 func commandColor(name string) string {
 	switch name {
-	case "EXPLORE":
+	case "EXPLORE", "TOUR":
 		return tnAccent
 	case "SET", "GET":
 		return tnBlue
 	case "HSET", "HGET":
 		return tnOrange
-	case "RPUSH", "LPUSH":
+	case "RPUSH", "LPUSH", "BLPOP", "QUEUES":
 		return tnPurple
-	case "SADD":
+	case "SADD", "TEMPLATE", "CONVERT", "GENERATE":
 		return tnGreen
 	case "ZADD":
 		return tnYellow
-	case "DELETE":
+	case "COUNTERS":
+		return tnBlue
+	case "DELETE", "LIFECYCLE", "REPLACE_ALL":
 		return tnRed
-	case "EXPORT", "IMPORT", "EXPORT_DB", "IMPORT_DB":
+	case "EXPORT", "IMPORT", "EXPORT_DB", "IMPORT_DB", "COMPARE", "DIFF_DB", "SNAPSHOT", "TIMELINE", "EXPIRE_ALL", "TTL_FORECAST", "COLD_KEYS":
 		return tnSubtle
-	case "INFO":
+	case "INFO", "PERSISTENCE", "CLUSTER", "CONSOLE", "BENCHMARK", "GROWTH", "AUDIT":
 		return tnInfo
 	default:
 		return tnText
@@ -158,11 +208,78 @@ const (
 	OpExportDB
 	OpImportDB
 	OpExpireAfterSet
-	OpAddItem     // generic add (HSET/ZADD/SADD/RPUSH) from the browser overlay
-	OpExportField // export a single hash field / list / set / zset entry
-	OpImportField // import a single field/member from a FieldExport file
+	OpAddItem         // generic add (HSET/ZADD/SADD/RPUSH) from the browser overlay
+	OpExportField     // export a single hash field / list / set / zset entry
+	OpImportField     // import a single field/member from a FieldExport file
+	OpBlockingPop     // BLPOP/BRPOP watcher on a dedicated connection
+	OpQueues          // job queue dashboard
+	OpQueuePush       // LPUSH onto the queue selected in the dashboard
+	OpQueuePop        // RPOP the oldest job of the queue selected in the dashboard
+	OpCounters        // counter / rate-limit inspector
+	OpCompare         // one key compared across two connections
+	OpDBDiff          // keys compared in bulk across two connections
+	OpDBDiffExport    // write the diff report to a file
+	OpSnapshot        // scheduled snapshots of keys to disk
+	OpPersistence     // RDB/AOF status screen
+	OpBGSave          // BGSAVE, confirmed from the persistence screen
+	OpBGRewriteAOF    // BGREWRITEAOF, confirmed from the persistence screen
+	OpLifecycle       // SHUTDOWN / DEBUG RESTART for admin profiles
+	OpCluster         // cluster overview
+	OpClusterFailover // CLUSTER FAILOVER, confirmed from the overview
+	OpClusterReshard  // read-only reshard preview
+	OpNodeInfo        // INFO from one cluster node
+	OpConsole         // raw command console
+	OpTemplate        // new key from a template
+	OpGenerate        // bulk test data generator
+	OpBenchmark       // PING/SET/GET throughput and latency
+	OpTimeline        // keyspace event timeline
+	OpLargeValue      // confirm fetching a value over the size limit
+	OpLargePreview    // GETRANGE / HSCAN preview of a large value
+	OpTour            // guided tour
+	OpFullValue       // a field/member too long for its browser row, shown in full
+	OpExportResult    // save what's on screen to a file
+	OpHashTable       // a hash opened whole as a table, by a display rule
+	OpMacro           // a configured macro, run on a key after confirmation
+	OpCreateKey       // a key of any type created from the browser
+	OpConvert         // JSON string ↔ hash conversion
+	OpBulkTTL         // one TTL set on every key matching a pattern
+	OpGrowth          // keyspace growth monitor
+	OpTTLForecast     // histogram of when keys matching a pattern expire
+	OpColdKeys        // report of keys idle past a threshold
+	OpColdDelete      // DEL every key on the cold key report, confirmed
+	OpColdExpire      // EXPIRE every key on the cold key report, confirmed
+	OpReplace         // find and replace across the string values matching a pattern
+	OpAudit           // audit log viewer
+	OpSample          // random elements of a hash, set or sorted set
+	OpTail            // live tail of a stream key over XREAD BLOCK
+	OpXAdd            // stream entry form
+	OpGroups          // consumer groups of a stream (XINFO GROUPS)
+	OpGroupCreate     // XGROUP CREATE form
+	OpGroupSetID      // XGROUP SETID form and confirmation
+	OpGroupDestroy    // XGROUP DESTROY confirmation
+	OpAutoClaim       // XAUTOCLAIM form and confirmation
+	OpPending         // pending entries of a group (XPENDING)
+	OpPendingAck      // XACK of the marked pending entries, confirmed
+	OpPendingClaim    // XCLAIM of the marked pending entries: form and confirmation
+	OpSearch          // search for text in the values of keys matching a pattern
+	OpSaveSearch      // saved search form, from the browser's saved searches popup
+	OpFlags           // feature flag list over key patterns
+	OpFlagToggle      // SET of a flag to its opposite, confirmed
+	OpLeaderboard     // top-N view of a sorted set
+	OpLeaderIncr      // ZINCRBY form from the leaderboard
+	OpSession         // a hash opened as a session, by a sessions rule; its invalidation
 )
 
+// isReadOnlyOutput reports whether op's output screen shows something that
+// can't be edited or given a TTL in place (server info, a popped job, a diff).
+func isReadOnlyOutput(op Op) bool {
+	switch op {
+	case OpInfo, OpQueuePop, OpCompare, OpDBDiffExport, OpLifecycle, OpClusterReshard, OpNodeInfo, OpTemplate, OpLargePreview, OpFullValue, OpExportResult, OpHashTable, OpMacro, OpConvert, OpTTLForecast, OpSample, OpTail, OpXAdd, OpGroups, OpGroupCreate, OpGroupSetID, OpGroupDestroy, OpAutoClaim, OpPending, OpPendingAck, OpPendingClaim, OpSearch, OpSaveSearch, OpFlags, OpFlagToggle, OpLeaderboard, OpLeaderIncr, OpSession:
+		return true
+	}
+	return false
+}
+
 // isAddOp reports whether op is an "add to a collection" command, for which the
 // key picker should jump straight into the add form rather than browse the key.
 func isAddOp(op Op) bool {
@@ -241,6 +358,118 @@ func (o Op) String() string {
 		return "EXPORT_DB"
 	case OpImportDB:
 		return "IMPORT_DB"
+	case OpBlockingPop:
+		return "BLPOP"
+	case OpQueues:
+		return "QUEUES"
+	case OpQueuePush:
+		return "LPUSH"
+	case OpQueuePop:
+		return "RPOP"
+	case OpCounters:
+		return "COUNTERS"
+	case OpCompare:
+		return "COMPARE"
+	case OpDBDiff:
+		return "DIFF_DB"
+	case OpSnapshot:
+		return "SNAPSHOT"
+	case OpPersistence:
+		return "PERSISTENCE"
+	case OpBGSave:
+		return "BGSAVE"
+	case OpBGRewriteAOF:
+		return "BGREWRITEAOF"
+	case OpLifecycle:
+		return "LIFECYCLE"
+	case OpCluster:
+		return "CLUSTER"
+	case OpClusterFailover:
+		return "FAILOVER"
+	case OpClusterReshard:
+		return "RESHARD"
+	case OpNodeInfo:
+		return "NODE_INFO"
+	case OpConsole:
+		return "CONSOLE"
+	case OpTemplate:
+		return "TEMPLATE"
+	case OpGenerate:
+		return "GENERATE"
+	case OpBenchmark:
+		return "BENCHMARK"
+	case OpTimeline:
+		return "TIMELINE"
+	case OpLargeValue:
+		return "LARGE_VALUE"
+	case OpLargePreview:
+		return "PREVIEW"
+	case OpTour:
+		return "TOUR"
+	case OpFullValue:
+		return "FULL"
+	case OpExportResult:
+		return "SAVE"
+	case OpHashTable:
+		return "HASH_TABLE"
+	case OpMacro:
+		return "MACRO"
+	case OpCreateKey:
+		return "CREATE_KEY"
+	case OpConvert:
+		return "CONVERT"
+	case OpBulkTTL:
+		return "EXPIRE_ALL"
+	case OpGrowth:
+		return "GROWTH"
+	case OpTTLForecast:
+		return "TTL_FORECAST"
+	case OpColdKeys:
+		return "COLD_KEYS"
+	case OpColdDelete:
+		return "COLD_DELETE"
+	case OpColdExpire:
+		return "COLD_EXPIRE"
+	case OpReplace:
+		return "REPLACE_ALL"
+	case OpSearch:
+		return "SEARCH_VALUES"
+	case OpSaveSearch:
+		return "SAVE_SEARCH"
+	case OpFlags:
+		return "FLAGS"
+	case OpFlagToggle:
+		return "FLAG_TOGGLE"
+	case OpLeaderboard:
+		return "LEADERBOARD"
+	case OpLeaderIncr:
+		return "ZINCRBY"
+	case OpSession:
+		return "SESSION"
+	case OpAudit:
+		return "AUDIT"
+	case OpSample:
+		return "SAMPLE"
+	case OpTail:
+		return "TAIL"
+	case OpXAdd:
+		return "XADD"
+	case OpGroups:
+		return "XINFO GROUPS"
+	case OpGroupCreate:
+		return "XGROUP CREATE"
+	case OpGroupSetID:
+		return "XGROUP SETID"
+	case OpGroupDestroy:
+		return "XGROUP DESTROY"
+	case OpAutoClaim:
+		return "XAUTOCLAIM"
+	case OpPending:
+		return "XPENDING"
+	case OpPendingAck:
+		return "XACK"
+	case OpPendingClaim:
+		return "XCLAIM"
 	}
 	return "UNKNOWN"
 }
@@ -265,6 +494,8 @@ func ParseOp(s string) Op {
 		return OpSAdd
 	case "ZADD":
 		return OpZAdd
+	case "XADD":
+		return OpXAdd
 	case "DELETE", "DEL", "HDEL", "LREM", "SREM", "ZREM":
 		return OpDelete // Maps to general delete in menu
 	case "EXPLORE", "EXPLORE_LIST", "EXPLORE_SET", "EXPLORE_ZSET":
@@ -285,6 +516,56 @@ func ParseOp(s string) Op {
 		return OpExportDB
 	case "IMPORT_DB":
 		return OpImportDB
+	case "BLPOP":
+		return OpBlockingPop
+	case "QUEUES":
+		return OpQueues
+	case "COUNTERS":
+		return OpCounters
+	case "FLAGS":
+		return OpFlags
+	case "LEADERBOARD":
+		return OpLeaderboard
+	case "COMPARE":
+		return OpCompare
+	case "DIFF_DB":
+		return OpDBDiff
+	case "SNAPSHOT":
+		return OpSnapshot
+	case "PERSISTENCE":
+		return OpPersistence
+	case "LIFECYCLE":
+		return OpLifecycle
+	case "CLUSTER":
+		return OpCluster
+	case "CONSOLE":
+		return OpConsole
+	case "TEMPLATE":
+		return OpTemplate
+	case "CONVERT":
+		return OpConvert
+	case "EXPIRE_ALL":
+		return OpBulkTTL
+	case "GROWTH":
+		return OpGrowth
+	case "TTL_FORECAST":
+		return OpTTLForecast
+	case "COLD_KEYS":
+		return OpColdKeys
+	case "REPLACE_ALL":
+		return OpReplace
+	case "SEARCH_VALUES":
+		return OpSearch
+	case "AUDIT":
+		return OpAudit
+	case "GENERATE":
+		return OpGenerate
+	case "BENCHMARK":
+		return OpBenchmark
+	case "TIMELINE":
+		return OpTimeline
+	case "TOUR":
+		return OpTour
 	}
 	return OpNone
 }
@@ -331,8 +612,6 @@ func typeDescStyle(desc string) lipgloss.Style {
 // type name (color-coded via typeDescStyle).
 func fieldDescStyle(desc string) (string, lipgloss.Style) {
 	switch {
-	case strings.HasPrefix(desc, "score:"):
-		return desc[len("score:"):], lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow))
 	case desc == "field" || strings.HasPrefix(desc, "idx "):
 		return desc, lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
 	default:
@@ -342,7 +621,7 @@ func fieldDescStyle(desc string) (string, lipgloss.Style) {
 
 // isKeyTypeDesc reports whether desc is one of the bare Redis type names used
 // for top-level key rows, as opposed to a field/member descriptor ("field",
-// "idx N", "score:N"). Used to gate the TTL badge so it only ever appears on
+// "idx N", "rank N"). Used to gate the TTL badge so it only ever appears on
 // keys — which are the only thing ListItem.ttl is populated for.
 func isKeyTypeDesc(desc string) bool {
 	switch desc {
@@ -374,26 +653,43 @@ var helpTextStyle = lipgloss.NewStyle().
 type ScanResult struct {
 	Cursor string
 	Keys   []list.Item
+	Count  int           // the COUNT hint this page was scanned with
+	Took   time.Duration // the SCAN round trip, for the next page's hint
 }
 
 type RedisResultMsg struct {
-	Result any
-	Error  error
+	Result  any
+	Error   error
+	Command string        // the command as issued, for the output header
+	Latency time.Duration // write-to-reply round trip
 }
 
 type RedisTTLResultMsg struct {
 	TTL int
 }
 
+// StringInspectMsg is the combined GET + TTL + OBJECT IDLETIME lookup used when
+// a string key is opened. Idle is -1 when the server can't report it.
+type StringInspectMsg struct {
+	Value   any
+	TTL     int
+	Idle    int
+	Error   error
+	Command string
+	Latency time.Duration
+}
+
 type ClearCopyStatusMsg struct{}
 
 type RedisConnectionMsg struct {
-	Conn  net.Conn
-	Error error
+	Conn     net.Conn
+	ClientID int              // CLIENT ID of Conn, 0 if the server didn't say
+	Server   redis.ServerInfo // from INFO server; zero if the server didn't say
+	Error    error
 	// Fatal indicates a permanent error (wrong password, invalid DB) that
 	// should not be retried. The app transitions to StateOutput with the error.
 	Fatal bool
 }
 
 // A message to tell us the wait time is over
-type TickMsg struct{}
+type TickMsg struct{ Gen int }
@@ -0,0 +1,314 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+)
+
+// CONVERT rewrites a string holding a JSON object as a hash, one field per
+// member, or a hash as a string holding it as a JSON object. The key is read
+// and the writes previewed first; the confirmation then keeps or deletes the
+// original. Converting in place replaces the key in one MULTI/EXEC, so it is
+// never left half written.
+
+// convertPreviewLines caps the fields or JSON lines shown for confirmation.
+const convertPreviewLines = 15
+
+// ConvertPlan is a conversion read and ready to confirm.
+type ConvertPlan struct {
+	Key    string
+	Target string // == Key when converting in place
+	From   string // "string" or "hash"
+	To     string
+	Fields map[string]string // the hash to write, or the one read
+	JSON   string            // the string to write, or the one read
+	TTL    int               // seconds, carried over to Target; -1 none
+}
+
+// ConvertPlanMsg reports the key read for a conversion.
+type ConvertPlanMsg struct {
+	Plan  ConvertPlan
+	Error error
+}
+
+// ConvertResultMsg reports a conversion written.
+type ConvertResultMsg struct {
+	Plan    ConvertPlan
+	Deleted bool // the original was deleted (always, in place)
+	Error   error
+}
+
+func (p ConvertPlan) inPlace() bool { return p.Target == p.Key }
+
+// jsonFields reads a JSON object as hash fields. String members are stored
+// as they are; anything else (numbers, booleans, null, nested objects and
+// arrays) as its JSON text, so nothing is lost converting back.
+func jsonFields(s string) (map[string]string, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil || obj == nil || dec.More() {
+		return nil, errors.New("the value is not a JSON object")
+	}
+	if len(obj) == 0 {
+		return nil, errors.New("the value is an empty JSON object; a hash needs at least one field")
+	}
+	fields := make(map[string]string, len(obj))
+	for k, v := range obj {
+		if str, ok := v.(string); ok {
+			fields[k] = str
+			continue
+		}
+		data, _ := json.Marshal(v) // decoded from JSON; can't fail
+		fields[k] = string(data)
+	}
+	return fields, nil
+}
+
+func (m Model) startConvertForm(key string) (tea.Model, tea.Cmd) {
+	if m.ReadOnly {
+		return m, m.notify("CONVERT is disabled: this session is read-only", true)
+	}
+	m.SelectedOp = OpConvert
+	m.Form = NewForm(
+		"CONVERT · JSON string ↔ hash",
+		[]string{"key", "write to (blank = convert in place)"},
+		[]string{key, ""},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitConvertForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	key, target := values[0], values[1]
+	if key == "" {
+		m.Form.Err = "a key is required"
+		return m, nil
+	}
+	if target == "" {
+		target = key
+	}
+	if target == key && m.Proxy {
+		m.Form.Err = "converting in place needs MULTI, which proxies don't forward; write to another key"
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.ActiveKey = key
+	return m.switchToLoadingAndExecute(readConversion(m.Conn, m.Reader, key, target))
+}
+
+// readConversion reads key and works out what converting it writes.
+func readConversion(conn net.Conn, reader *bufio.Reader, key, target string) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return ConvertPlanMsg{Error: fmt.Errorf("no connection to Redis")}
+		}
+		v, err := fetchKeyValue(conn, reader, key)
+		if err != nil {
+			return ConvertPlanMsg{Error: err}
+		}
+		p := ConvertPlan{Key: key, Target: target, From: v.Type, TTL: v.TTL}
+		switch v.Type {
+		case "none":
+			return ConvertPlanMsg{Error: fmt.Errorf("%s does not exist", key)}
+		case "string":
+			p.To, p.JSON = "hash", v.String
+			if p.Fields, err = jsonFields(v.String); err != nil {
+				return ConvertPlanMsg{Error: fmt.Errorf("%s: %w", key, err)}
+			}
+		case "hash":
+			data, _ := json.Marshal(v.Fields) // strings only; can't fail
+			p.To, p.Fields, p.JSON = "string", v.Fields, string(data)
+		default:
+			return ConvertPlanMsg{Error: fmt.Errorf("%s is a %s; only JSON strings and hashes convert", key, v.Type)}
+		}
+		if !p.inPlace() {
+			replies, err := pipeline(conn, reader, []redis.RedisCmd{redis.Exists(target)})
+			if err != nil {
+				return ConvertPlanMsg{Error: err}
+			}
+			if n, _ := replies[0].(int); n > 0 {
+				return ConvertPlanMsg{Error: fmt.Errorf("%s already exists — choose another key to write to", target)}
+			}
+		}
+		return ConvertPlanMsg{Plan: p}
+	}
+}
+
+// handleConvertPlan shows the plan for confirmation, or sends the form back
+// with what stood in the way.
+func handleConvertPlan(m Model, msg ConvertPlanMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.Form.Err = msg.Error.Error()
+		m.CurrentState = StateForm
+		return m, nil
+	}
+	m.Convert = msg.Plan
+	m.SelectedOp = OpConvert
+	m.pushState(StateForm)
+	m.ask(m.convertDialog())
+	return m, nil
+}
+
+// writes are the commands that create the converted key.
+func (p ConvertPlan) writes() []redis.RedisCmd {
+	var cmds []redis.RedisCmd
+	if p.To == "hash" {
+		args := []string{p.Target}
+		for _, f := range slices.Sorted(maps.Keys(p.Fields)) {
+			args = append(args, f, p.Fields[f])
+		}
+		cmds = append(cmds, redis.RedisCmd{Name: "HSET", Args: args})
+	} else {
+		cmds = append(cmds, redis.Set(p.Target, p.JSON))
+	}
+	if p.TTL > 0 {
+		cmds = append(cmds, redis.Expire(p.Target, p.TTL))
+	}
+	return cmds
+}
+
+// runConversion writes the plan. In place, the old key is deleted and the
+// new one written in one transaction; otherwise the new key is written only
+// if it still doesn't exist, and the original deleted after if asked.
+func runConversion(conn net.Conn, reader *bufio.Reader, p ConvertPlan, deleteOriginal bool) tea.Cmd {
+	return func() tea.Msg {
+		res := ConvertResultMsg{Plan: p, Deleted: deleteOriginal || p.inPlace()}
+		if conn == nil {
+			res.Error = fmt.Errorf("no connection to Redis")
+			return res
+		}
+		if !p.inPlace() {
+			cmds := p.writes()
+			if deleteOriginal {
+				cmds = append(cmds, redis.Del(p.Key))
+			}
+			exists, err := createKey(conn, reader, p.Target, cmds)
+			if err == nil && exists {
+				err = fmt.Errorf("%s was created meanwhile; nothing was written", p.Target)
+			}
+			res.Error = err
+			return res
+		}
+
+		cmds := append([]redis.RedisCmd{{Name: "MULTI"}, redis.Del(p.Key)}, p.writes()...)
+		cmds = append(cmds, redis.RedisCmd{Name: "EXEC"})
+		replies, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			_ = conn.Close() // the reply stream may be desynced; reconnect rather than misread
+			res.Error = err
+			return res
+		}
+		exec, ok := replies[len(replies)-1].([]any)
+		if !ok {
+			res.Error = errors.New("the transaction was aborted; nothing was changed")
+			return res
+		}
+		for _, r := range append(replies[:len(replies)-1], exec...) {
+			if s, ok := r.(string); ok && redis.IsErrorReply(s) {
+				res.Error = fmt.Errorf("%s", s)
+				return res
+			}
+		}
+		return res
+	}
+}
+
+func handleConvertResult(m Model, msg ConvertResultMsg) (tea.Model, tea.Cmd) {
+	p := msg.Plan
+	m.popState() // the form: esc from the output goes back to where it came from
+	m.invalidate(p.Key)
+	m.invalidate(p.Target)
+	m.ActiveTTL = ""
+	m.CopyStatus = ""
+	if msg.Error != nil {
+		m.Result = errorResult(msg.Error)
+	} else {
+		out := fmt.Sprintf("Converted %s from %s to %s", p.Key, p.From, p.To)
+		if !p.inPlace() {
+			out += " as " + p.Target
+		}
+		if p.To == "hash" {
+			out += fmt.Sprintf(" (%d fields)", len(p.Fields))
+		}
+		if p.TTL > 0 {
+			out += fmt.Sprintf(", TTL %d s kept", p.TTL)
+		}
+		switch {
+		case p.inPlace():
+		case msg.Deleted:
+			out += "; " + p.Key + " deleted"
+		default:
+			out += "; " + p.Key + " kept"
+		}
+		m.Result = textResult(out)
+	}
+	m.Result.Command = "convert " + p.Key
+	m.CurrentState = StateOutput
+	return m, nil
+}
+
+// convertDialog asks before the conversion, with a preview of the writes.
+func (m Model) convertDialog() Dialog {
+	p := m.Convert
+	heading := fmt.Sprintf("convert %s → %s", p.From, p.To)
+	label := "rewrite " + p.Key + " as"
+	if !p.inPlace() {
+		label = "write " + p.Key + " to " + p.Target + " as"
+	}
+
+	var lines []string
+	if p.To == "hash" {
+		lines = append(lines, formatCommand(redis.RedisCmd{Name: "HSET", Args: []string{p.Target}}))
+		names := slices.Sorted(maps.Keys(p.Fields))
+		width := 0
+		for _, f := range names {
+			width = max(width, runewidth.StringWidth(f))
+		}
+		width = min(width, 24)
+		for i, f := range names {
+			if i == convertPreviewLines {
+				lines = append(lines, fmt.Sprintf("  … %d more fields", len(names)-i))
+				break
+			}
+			lines = append(lines, "  "+padCells(truncateText(f, width), width)+"  "+truncateText(p.Fields[f], 60))
+		}
+	} else {
+		lines = append(lines, formatCommand(redis.RedisCmd{Name: "SET", Args: []string{p.Target}}))
+		pretty := strings.Split(tryPrettyJSON(p.JSON), "\n")
+		for i, l := range pretty {
+			if i == convertPreviewLines {
+				lines = append(lines, fmt.Sprintf("  … %d more lines", len(pretty)-i))
+				break
+			}
+			lines = append(lines, "  "+truncateText(l, 80))
+		}
+	}
+	if p.TTL > 0 {
+		lines = append(lines, formatCommand(redis.Expire(p.Target, p.TTL)))
+	}
+	convert := func(deleteOriginal bool) func(Model) (tea.Model, tea.Cmd) {
+		return func(m Model) (tea.Model, tea.Cmd) {
+			return m.switchToLoadingAndExecute(runConversion(m.Conn, m.Reader, m.Convert, deleteOriginal))
+		}
+	}
+	choices := []DialogChoice{{Key: "y", Label: "replace " + p.Key, Destructive: true, Run: convert(false)}}
+	if !p.inPlace() {
+		choices = []DialogChoice{
+			{Key: "y", Label: "convert, keep the original", Run: convert(false)},
+			{Key: "d", Label: "convert, delete the original", Destructive: true, Run: convert(true)},
+		}
+	}
+	return Dialog{Title: heading, Label: label, Value: strings.Join(lines, "\n  "), Destructive: true, Target: p.Key, Choices: choices}
+}
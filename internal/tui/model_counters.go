@@ -0,0 +1,330 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	maxCounters    = 200 // keys sampled per refresh across all patterns
+	counterNameCol = 36
+)
+
+// CountersModel is the state of the counter inspector: a live table of every
+// key matching the patterns, with value and remaining TTL. Rate limiters built
+// on INCR+EXPIRE create and expire keys constantly, so the patterns are
+// re-scanned on every refresh rather than resolved once.
+type CountersModel struct {
+	Patterns []string
+	Interval time.Duration
+	Rows     []CounterRow
+	ByValue  bool // sort by value (desc) instead of key
+	Cursor   int
+	Sampled  time.Time
+	Err      string
+
+	Active bool
+	Gen    int
+}
+
+// CounterRow is one sampled key. Delta is the change since the previous
+// sample, so a counter that is being hit shows its rate at a glance.
+type CounterRow struct {
+	Key      string
+	Value    string
+	Numeric  bool
+	Delta    int64
+	HasDelta bool
+	PTTL     int64 // milliseconds; -1 no expiry, -2 gone
+}
+
+// CounterSampleMsg carries one refresh of the counter table.
+type CounterSampleMsg struct {
+	Gen   int
+	Rows  []CounterRow
+	Error error
+	Rearm bool
+}
+
+// CounterTickMsg fires every Interval while the inspector is active.
+type CounterTickMsg struct{ Gen int }
+
+func (m Model) startCountersForm() (tea.Model, tea.Cmd) {
+	m.Form = NewForm(
+		"COUNTERS · watch rate-limit / counter keys",
+		[]string{"key patterns (comma-separated)", "refresh every (seconds)"},
+		[]string{"ratelimit:*", "1"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitCountersForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	var patterns []string
+	for _, s := range strings.Split(values[0], ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			patterns = append(patterns, s)
+		}
+	}
+	if len(patterns) == 0 {
+		m.Form.Err = "enter at least one key pattern"
+		return m, nil
+	}
+	secs, err := strconv.Atoi(values[1])
+	if err != nil || secs <= 0 {
+		m.Form.Err = "refresh interval must be a positive number of seconds"
+		return m, nil
+	}
+
+	m.Form.Err = ""
+	m.Counters = CountersModel{
+		Patterns: patterns,
+		Interval: time.Duration(secs) * time.Second,
+		Active:   true,
+		Gen:      m.Counters.Gen + 1,
+	}
+	m.pushState(StateForm)
+	return m.switchToLoadingAndExecute(sampleCounters(m.Conn, m.Reader, patterns, m.scanCountMax(), m.Counters.Gen, true))
+}
+
+func counterTick(gen int, every time.Duration) tea.Cmd {
+	return tea.Tick(every, func(time.Time) tea.Msg { return CounterTickMsg{Gen: gen} })
+}
+
+// sampleCounters scans the patterns and reads GET + PTTL for every match in
+// one pipelined batch.
+func sampleCounters(conn net.Conn, reader *bufio.Reader, patterns []string, scanCount, gen int, rearm bool) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return CounterSampleMsg{Gen: gen, Rearm: rearm, Error: fmt.Errorf("no connection to Redis")}
+		}
+		seen := map[string]bool{}
+		var keys []string
+		for _, p := range patterns {
+			matched, err := scanAllKeys(conn, reader, p, maxCounters-len(keys), scanCount)
+			if err != nil {
+				return CounterSampleMsg{Gen: gen, Rearm: rearm, Error: err}
+			}
+			for _, k := range matched {
+				if !seen[k] {
+					seen[k] = true
+					keys = append(keys, k)
+				}
+			}
+			if len(keys) >= maxCounters {
+				break
+			}
+		}
+
+		var cmds []redis.RedisCmd
+		for _, k := range keys {
+			cmds = append(cmds,
+				redis.Get(k),
+				redis.PTTL(k))
+		}
+		replies, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			return CounterSampleMsg{Gen: gen, Rearm: rearm, Error: err}
+		}
+		rows := make([]CounterRow, 0, len(keys))
+		for i, k := range keys {
+			row := CounterRow{Key: k, PTTL: -2}
+			if ttl, ok := replies[2*i+1].(int); ok {
+				row.PTTL = int64(ttl)
+			}
+			if row.PTTL == -2 {
+				continue // expired between SCAN and GET
+			}
+			switch v, _ := replies[2*i].(string); {
+			case strings.HasPrefix(v, "WRONGTYPE"):
+				row.Value = "(not a string)"
+			default:
+				row.Value = v
+				_, err := strconv.ParseInt(v, 10, 64)
+				row.Numeric = err == nil
+			}
+			rows = append(rows, row)
+		}
+		return CounterSampleMsg{Gen: gen, Rows: rows, Rearm: rearm}
+	}
+}
+
+func handleCounterSample(m Model, msg CounterSampleMsg) (tea.Model, tea.Cmd) {
+	c := &m.Counters
+	if !c.Active || msg.Gen != c.Gen {
+		return m, nil
+	}
+	var next tea.Cmd
+	if msg.Rearm {
+		next = counterTick(c.Gen, c.Interval)
+	}
+
+	if msg.Error != nil {
+		if m.CurrentState == StateLoading {
+			c.Active = false
+			m.Result = errorResult(msg.Error)
+			m.CurrentState = StateOutput
+			return m, nil
+		}
+		c.Err = msg.Error.Error()
+		return m, next
+	}
+
+	prev := make(map[string]CounterRow, len(c.Rows))
+	for _, r := range c.Rows {
+		prev[r.Key] = r
+	}
+	for i := range msg.Rows {
+		r := &msg.Rows[i]
+		if p, ok := prev[r.Key]; ok && p.Numeric && r.Numeric {
+			before, _ := strconv.ParseInt(p.Value, 10, 64)
+			now, _ := strconv.ParseInt(r.Value, 10, 64)
+			r.Delta, r.HasDelta = now-before, true
+		}
+	}
+	c.Rows = msg.Rows
+	c.sortRows()
+	if c.Cursor >= len(c.Rows) {
+		c.Cursor = max(len(c.Rows)-1, 0)
+	}
+	c.Err = ""
+	c.Sampled = time.Now()
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateCounters
+	}
+	return m, next
+}
+
+func (c *CountersModel) sortRows() {
+	sort.SliceStable(c.Rows, func(i, j int) bool {
+		a, b := c.Rows[i], c.Rows[j]
+		if c.ByValue {
+			av, _ := strconv.ParseInt(a.Value, 10, 64)
+			bv, _ := strconv.ParseInt(b.Value, 10, 64)
+			if av != bv {
+				return av > bv
+			}
+		}
+		return a.Key < b.Key
+	})
+}
+
+func handleCounterTick(m Model, msg CounterTickMsg) (tea.Model, tea.Cmd) {
+	c := m.Counters
+	if !c.Active || msg.Gen != c.Gen {
+		return m, nil
+	}
+	if m.CurrentState != StateCounters {
+		return m, counterTick(c.Gen, c.Interval)
+	}
+	return m, m.exec(sampleCounters(m.Conn, m.Reader, c.Patterns, m.scanCountMax(), c.Gen, true))
+}
+
+func handleStateCountersKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := &m.Counters
+	switch keyMsg.String() {
+	case "esc":
+		c.Active = false
+		m.CurrentState = m.popState()
+	case "up", "k":
+		if c.Cursor > 0 {
+			c.Cursor--
+		}
+	case "down", "j":
+		if c.Cursor < len(c.Rows)-1 {
+			c.Cursor++
+		}
+	case "s":
+		c.ByValue = !c.ByValue
+		c.sortRows()
+	case "r":
+		return m, m.exec(sampleCounters(m.Conn, m.Reader, c.Patterns, m.scanCountMax(), c.Gen, false))
+	}
+	return m, nil
+}
+
+// formatPTTL renders a PTTL reply for the table.
+func formatPTTL(ms int64) string {
+	switch {
+	case ms == -1:
+		return "no expiry"
+	case ms < 0:
+		return "expired"
+	case ms < 10_000:
+		return fmt.Sprintf("%.1fs", float64(ms)/1000)
+	default:
+		return (time.Duration(ms) * time.Millisecond).Round(time.Second).String()
+	}
+}
+
+func (m Model) countersView() string {
+	c := m.Counters
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+
+	order := "key"
+	if c.ByValue {
+		order = "value"
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnBlue)).Bold(true).Render("COUNTERS") +
+		dim.Render(fmt.Sprintf(" · %s · %d keys · every %s · by %s", strings.Join(c.Patterns, ", "), len(c.Rows), c.Interval, order))
+	if !c.Sampled.IsZero() {
+		title += dim.Render("   sampled " + c.Sampled.Format("15:04:05"))
+	}
+	if c.Err != "" {
+		title += "   " + red.Render("✗ "+c.Err)
+	}
+
+	head := "  " + dim.Render(fmt.Sprintf("%-*s %12s %8s   %s", counterNameCol, "key", "value", "Δ", "ttl"))
+	rows := []string{}
+	for i, r := range c.Rows {
+		name := padCells(r.Key, counterNameCol)
+		marker := "  "
+		nameView := text.Render(name)
+		if i == c.Cursor {
+			marker = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render(pointerGlyph)
+			nameView = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true).Render(name)
+		}
+		delta := dim.Render(fmt.Sprintf("%8s", ""))
+		if r.HasDelta && r.Delta != 0 {
+			delta = green.Render(fmt.Sprintf("%+8d", r.Delta))
+		}
+		// A counter with no expiry is the classic INCR+EXPIRE race (the EXPIRE
+		// never ran), so it's flagged rather than shown neutrally.
+		ttl := subtle.Render(formatPTTL(r.PTTL))
+		if r.PTTL == -1 {
+			ttl = red.Render("no expiry ⚠")
+		}
+		rows = append(rows, marker+nameView+" "+text.Render(fmt.Sprintf("%12s", truncateText(r.Value, 12)))+" "+delta+"   "+ttl)
+	}
+	if len(rows) == 0 {
+		rows = append(rows, "  "+dim.Render("no keys match right now"))
+	}
+	// header(2) + blank + title + blank + column head + footer(2)
+	if avail := m.WindowHeight - 8; avail > 0 && len(rows) > avail {
+		start := 0
+		if c.Cursor >= avail {
+			start = c.Cursor - avail + 1
+		}
+		rows = rows[start : start+avail]
+	}
+
+	body := "  " + title + "\n\n" + head + "\n" + strings.Join(rows, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(countersKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// `+` in the key list creates a key of any type without going back to the
+// menu: pick the type, then fill in its name, its first value (field and
+// value, member and score, …) and a TTL. Once it's written the key list is
+// scanned again, so the new key is there to open and add to with `a`.
+
+// createKeyTypes are the types on offer, with the command that creates each.
+var createKeyTypes = []struct{ Type, Command string }{
+	{"string", "SET"},
+	{"hash", "HSET"},
+	{"list", "RPUSH"},
+	{"set", "SADD"},
+	{"zset", "ZADD"},
+	{"stream", "XADD"},
+}
+
+// CreateKeyModel is the type picker, and the type whose form is open.
+type CreateKeyModel struct {
+	Cursor int
+	Type   string
+}
+
+// CreateKeyRequestMsg asks to create a key from the browser.
+type CreateKeyRequestMsg struct{}
+
+// CreateKeyResultMsg reports a key created from the browser. Exists means the
+// key was already there and nothing was written.
+type CreateKeyResultMsg struct {
+	Key    string
+	Type   string
+	Exists bool
+	Error  error
+}
+
+// createKeyLabels are the form fields between the key and its TTL for keyType:
+// the same steps as adding to an existing key of that type with `a`.
+func createKeyLabels(keyType string) []string {
+	switch keyType {
+	case "string":
+		return []string{"value"}
+	case "stream":
+		return []string{"field name", "value"}
+	}
+	return addStepLabels(keyType)
+}
+
+func (m Model) startCreateKey() (tea.Model, tea.Cmd) {
+	if m.ReadOnly {
+		return m, m.notify("New key is disabled: this session is read-only", true)
+	}
+	m.CreateKey.Type = ""
+	m.pushState(m.CurrentState)
+	m.CurrentState = StateCreateKey
+	return m, nil
+}
+
+func handleStateCreateKeyKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	n := &m.CreateKey
+	switch keyMsg.String() {
+	case "esc":
+		m.CurrentState = m.popState()
+	case "up", "k":
+		if n.Cursor > 0 {
+			n.Cursor--
+		}
+	case "down", "j":
+		if n.Cursor < len(createKeyTypes)-1 {
+			n.Cursor++
+		}
+	case "enter":
+		return m.openCreateKeyForm(createKeyTypes[n.Cursor].Type)
+	}
+	return m, nil
+}
+
+func (m Model) openCreateKeyForm(keyType string) (tea.Model, tea.Cmd) {
+	labels := append(append([]string{"key"}, createKeyLabels(keyType)...), "TTL in seconds (0 = no expiry)")
+	defaults := make([]string, len(labels))
+	defaults[len(defaults)-1] = "0"
+	if keyType == "zset" {
+		defaults[2] = "0" // score
+	}
+
+	m.CreateKey.Type = keyType
+	m.SelectedOp = OpCreateKey
+	m.pushState(StateCreateKey)
+	m.Form = NewForm("NEW KEY · "+keyType, labels, defaults)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitCreateKeyForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	keyType := m.CreateKey.Type
+	key := values[0]
+	if key == "" {
+		m.Form.Err = "key must not be empty"
+		return m, nil
+	}
+	ttl, err := strconv.Atoi(values[len(values)-1])
+	if err != nil || ttl < 0 {
+		m.Form.Err = "TTL must be a whole number of seconds, 0 for none"
+		return m, nil
+	}
+	// Values are read untrimmed, as for templates, so deliberate spaces survive.
+	a := m.Form.Inputs[1].Value()
+	var b string
+	if len(m.Form.Inputs) > 3 {
+		b = m.Form.Inputs[2].Value()
+	}
+	var cmd redis.RedisCmd
+	switch keyType {
+	case "string":
+		cmd = redis.Set(key, a)
+	case "hash":
+		cmd = redis.HSet(key, a, b)
+	case "list":
+		cmd = redis.RPush(key, a)
+	case "set":
+		cmd = redis.SAdd(key, a)
+	case "zset":
+		b = strings.TrimSpace(b)
+		if _, err := strconv.ParseFloat(b, 64); err != nil {
+			m.Form.Err = "score must be a number"
+			return m, nil
+		}
+		cmd = redis.ZAdd(key, b, a)
+	case "stream":
+		cmd = redis.XAdd(key, "*", a, b)
+	}
+	cmds := []redis.RedisCmd{cmd}
+	if ttl > 0 {
+		cmds = append(cmds, redis.Expire(key, ttl))
+	}
+	return m.switchToLoadingAndExecute(sendCreateKey(m.Conn, m.Reader, key, keyType, cmds))
+}
+
+func sendCreateKey(conn net.Conn, reader *bufio.Reader, key, keyType string, cmds []redis.RedisCmd) tea.Cmd {
+	return func() tea.Msg {
+		exists, err := createKey(conn, reader, key, cmds)
+		return CreateKeyResultMsg{Key: key, Type: keyType, Exists: exists, Error: err}
+	}
+}
+
+// handleCreateKeyResult goes back to the key list, scanned again so the new key
+// shows, or back to the form when the name is taken or the write failed.
+func handleCreateKeyResult(m Model, msg CreateKeyResultMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Exists:
+		m.Form.Err = fmt.Sprintf("key %q already exists — choose another name", msg.Key)
+		m.CurrentState = StateForm
+		return m, nil
+	case msg.Error != nil:
+		m.Form.Err = msg.Error.Error()
+		m.CurrentState = StateForm
+		return m, nil
+	}
+	m.popState() // the form's way back to the picker
+	m.popState() // the picker's way back to the browser
+	m.invalidate(msg.Key)
+	toast := m.notify("Created "+msg.Type+" "+msg.Key, false)
+	m.SelectedOp = OpExplore
+	pattern := m.LastPattern
+	if pattern == "" {
+		pattern = "*"
+	}
+	m.Browser.Cursor = "0"
+	m.Browser.Pattern = pattern
+	next, cmd := m.switchToLoadingAndExecute(m.startKeyScan(pattern, "0", ""))
+	return next, tea.Batch(cmd, toast)
+}
+
+func (m Model) createKeyView() string {
+	n := m.CreateKey
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)).Bold(true).Render("NEW KEY") + dim.Render(" · choose its type")
+	rows := make([]string, 0, len(createKeyTypes))
+	for i, t := range createKeyTypes {
+		marker := "  "
+		if i == n.Cursor {
+			marker = accent.Render("▌ ")
+		}
+		rows = append(rows, marker+typeDescStyle(t.Type).Render(fmt.Sprintf("%-8s", t.Type))+subtle.Render(t.Command+" "+strings.Join(createKeyLabels(t.Type), ", ")))
+	}
+
+	body := "  " + title + "\n\n" + strings.Join(rows, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(createKeyKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
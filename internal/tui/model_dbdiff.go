@@ -0,0 +1,437 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	maxDiffKeys = 10_000 // keys scanned per side
+	// ttlSlack is how far two TTLs may drift before the keys count as
+	// different: the two sides are read a moment apart, and keys written by
+	// the same job a few seconds apart are still "the same expiry".
+	ttlSlack = 60
+)
+
+// Diff entry kinds.
+const (
+	DiffOnlyA     = "only in A"
+	DiffOnlyB     = "only in B"
+	DiffDifferent = "different"
+)
+
+// DiffEntry is one line of the database diff report.
+type DiffEntry struct {
+	Key    string `json:"key"`
+	Kind   string `json:"kind"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// DBDiffModel is the state of the database diff report.
+type DBDiffModel struct {
+	Pattern   string
+	Sides     [2]compareSide
+	Values    bool // compare values, not just existence / type / TTL
+	Entries   []DiffEntry
+	Scanned   [2]int
+	Identical int
+	Truncated bool // a side had more than maxDiffKeys matches
+	Filter    string
+	Cursor    int
+}
+
+// DBDiffResultMsg delivers a finished diff.
+type DBDiffResultMsg struct {
+	Result DBDiffModel
+	Error  error
+}
+
+func (m Model) startDBDiffForm() (tea.Model, tea.Cmd) {
+	other := ""
+	for _, name := range m.Config.Names() {
+		if name != m.ProfileName {
+			other = name
+			break
+		}
+	}
+	m.Form = NewForm(
+		"DIFF_DB · compare keys between two instances",
+		[]string{"key pattern", "A (profile, redis:// URL, or current)", "B (profile, redis:// URL, or current)", "compare values too? (y/n)"},
+		[]string{"*", "current", other, "n"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitDBDiffForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	pattern := values[0]
+	if pattern == "" {
+		pattern = "*"
+	}
+	d := DBDiffModel{Pattern: pattern}
+	switch strings.ToLower(values[3]) {
+	case "y", "yes":
+		d.Values = true
+	case "n", "no", "":
+	default:
+		m.Form.Err = `compare values: answer "y" or "n"`
+		return m, nil
+	}
+	return m.withTargets(values[1:3], func(m Model, sides []compareSide, err error) (tea.Model, tea.Cmd) {
+		if err != nil {
+			m.Form.Err = err.Error()
+			m.CurrentState = StateForm
+			return m, nil
+		}
+		d.Sides = [2]compareSide(sides)
+		m.Form.Err = ""
+		m.pushState(StateForm)
+		return m.switchToLoadingAndDial(diffDatabases(m, d))
+	})
+}
+
+// keyMeta is what the diff compares for every key: type and TTL (and the value
+// when asked).
+type keyMeta struct {
+	Type string
+	TTL  int
+}
+
+func diffDatabases(m Model, d DBDiffModel) tea.Cmd {
+	return func() tea.Msg {
+		var conns [2]net.Conn
+		var readers [2]*bufio.Reader
+		var metas [2]map[string]keyMeta
+		for i, side := range d.Sides {
+			conn, reader, _, err := openConnection(m.withEndpoint(side.Endpoint))
+			if err != nil {
+				return DBDiffResultMsg{Error: fmt.Errorf("%s: %w", side.Label, err)}
+			}
+			defer conn.Close()
+			conns[i], readers[i] = conn, reader
+
+			keys, err := scanAllKeys(conn, reader, d.Pattern, maxDiffKeys+1, m.scanCountMax())
+			if err != nil {
+				return DBDiffResultMsg{Error: fmt.Errorf("%s: %w", side.Label, err)}
+			}
+			if len(keys) > maxDiffKeys {
+				keys, d.Truncated = keys[:maxDiffKeys], true
+			}
+			d.Scanned[i] = len(keys)
+			if metas[i], err = fetchKeyMetas(conn, reader, keys); err != nil {
+				return DBDiffResultMsg{Error: fmt.Errorf("%s: %w", side.Label, err)}
+			}
+		}
+
+		for key, a := range metas[0] {
+			b, ok := metas[1][key]
+			if !ok {
+				d.Entries = append(d.Entries, DiffEntry{Key: key, Kind: DiffOnlyA, Detail: a.Type})
+				continue
+			}
+			detail := describeMetaDiff(a, b)
+			if detail == "" && d.Values {
+				va, errA := fetchKeyValue(conns[0], readers[0], key)
+				vb, errB := fetchKeyValue(conns[1], readers[1], key)
+				switch {
+				case errA != nil || errB != nil:
+					detail = "value not compared: " + firstErr(errA, errB).Error()
+				case !sameValue(va, vb):
+					detail = "value differs"
+				}
+			}
+			if detail != "" {
+				d.Entries = append(d.Entries, DiffEntry{Key: key, Kind: DiffDifferent, Detail: detail})
+			} else {
+				d.Identical++
+			}
+		}
+		for key, b := range metas[1] {
+			if _, ok := metas[0][key]; !ok {
+				d.Entries = append(d.Entries, DiffEntry{Key: key, Kind: DiffOnlyB, Detail: b.Type})
+			}
+		}
+		sort.Slice(d.Entries, func(i, j int) bool {
+			if d.Entries[i].Kind != d.Entries[j].Kind {
+				return diffKindOrder(d.Entries[i].Kind) < diffKindOrder(d.Entries[j].Kind)
+			}
+			return d.Entries[i].Key < d.Entries[j].Key
+		})
+		return DBDiffResultMsg{Result: d}
+	}
+}
+
+func diffKindOrder(kind string) int {
+	switch kind {
+	case DiffOnlyA:
+		return 0
+	case DiffOnlyB:
+		return 1
+	}
+	return 2
+}
+
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchKeyMetas pipelines TYPE + TTL for keys in batches. Keys that expired
+// since the scan are left out.
+func fetchKeyMetas(conn net.Conn, reader *bufio.Reader, keys []string) (map[string]keyMeta, error) {
+	const batch = 500
+	out := make(map[string]keyMeta, len(keys))
+	for start := 0; start < len(keys); start += batch {
+		chunk := keys[start:min(start+batch, len(keys))]
+		var cmds []redis.RedisCmd
+		for _, k := range chunk {
+			cmds = append(cmds,
+				redis.Type(k),
+				redis.TTL(k))
+		}
+		replies, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			return nil, err
+		}
+		for i, k := range chunk {
+			t, _ := replies[2*i].(string)
+			if t == "none" || t == "" {
+				continue
+			}
+			ttl, _ := replies[2*i+1].(int)
+			out[k] = keyMeta{Type: t, TTL: ttl}
+		}
+	}
+	return out, nil
+}
+
+// describeMetaDiff explains how two keys' type or expiry differ, or returns ""
+// when they match.
+func describeMetaDiff(a, b keyMeta) string {
+	switch {
+	case a.Type != b.Type:
+		return fmt.Sprintf("type %s vs %s", a.Type, b.Type)
+	case (a.TTL < 0) != (b.TTL < 0):
+		return fmt.Sprintf("TTL %s vs %s", ttlWords(a.TTL), ttlWords(b.TTL))
+	case a.TTL >= 0 && abs(a.TTL-b.TTL) > ttlSlack:
+		return fmt.Sprintf("TTL %s vs %s", ttlWords(a.TTL), ttlWords(b.TTL))
+	}
+	return ""
+}
+
+func ttlWords(ttl int) string {
+	if ttl < 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%ds", ttl)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// sameValue compares two fetched values, ignoring TTL.
+func sameValue(a, b keyValue) bool {
+	return a.Type == b.Type && a.String == b.String &&
+		len(a.Fields) == len(b.Fields) && (len(a.Fields) == 0 || reflect.DeepEqual(a.Fields, b.Fields)) &&
+		len(a.Items) == len(b.Items) && (len(a.Items) == 0 || reflect.DeepEqual(a.Items, b.Items))
+}
+
+func handleDBDiffResult(m Model, msg DBDiffResultMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.Result = errorResult(msg.Error)
+		m.ActiveTTL = ""
+		m.CopyStatus = ""
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	m.DBDiff = msg.Result
+	m.CurrentState = StateDBDiff
+	return m, nil
+}
+
+// visible returns the entries passing the current filter.
+func (d DBDiffModel) visible() []DiffEntry {
+	if d.Filter == "" {
+		return d.Entries
+	}
+	var out []DiffEntry
+	for _, e := range d.Entries {
+		if e.Kind == d.Filter {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (d DBDiffModel) count(kind string) int {
+	n := 0
+	for _, e := range d.Entries {
+		if e.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+func handleStateDBDiffKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := &m.DBDiff
+	rows := d.visible()
+	switch keyMsg.String() {
+	case "esc":
+		m.CurrentState = m.popState()
+	case "up", "k":
+		if d.Cursor > 0 {
+			d.Cursor--
+		}
+	case "down", "j":
+		if d.Cursor < len(rows)-1 {
+			d.Cursor++
+		}
+	case "0", "1", "2", "3":
+		d.Filter = map[string]string{"0": "", "1": DiffOnlyA, "2": DiffOnlyB, "3": DiffDifferent}[keyMsg.String()]
+		d.Cursor = 0
+	case "enter":
+		if d.Cursor >= len(rows) {
+			break
+		}
+		m.ActiveKey = rows[d.Cursor].Key
+		m.SelectedOp = OpCompare
+		m.pushState(StateDBDiff)
+		return m.switchToLoadingAndDial(compareKey(m, m.ActiveKey, d.Sides))
+	case "w":
+		m.SelectedOp = OpDBDiffExport
+		m.Input.Input.Focus()
+		m.Input.Input.SetValue("./redis-diff-" + time.Now().Format("20060102-150405") + ".json")
+		m.Input.Input.CursorEnd()
+		m.Input.Hint = "Destination file (diff report → JSON):"
+		m.Input.Type = InputFilePath
+		m.pushState(StateDBDiff)
+		m.CurrentState = StateInputFilePath
+	}
+	return m, nil
+}
+
+// dbDiffReport is the exported file format.
+type dbDiffReport struct {
+	Generated string      `json:"generated"`
+	Pattern   string      `json:"pattern"`
+	A         string      `json:"a"`
+	B         string      `json:"b"`
+	Values    bool        `json:"values_compared"`
+	Scanned   [2]int      `json:"scanned"`
+	Identical int         `json:"identical"`
+	Truncated bool        `json:"truncated,omitempty"`
+	Entries   []DiffEntry `json:"entries"`
+}
+
+// writeDBDiffReport saves the full (unfiltered) report as JSON.
+func writeDBDiffReport(d DBDiffModel, filePath string) tea.Cmd {
+	return func() tea.Msg {
+		resolved, err := resolveFilePath(filePath, true, "redis-diff.json")
+		if err != nil {
+			return RedisResultMsg{Error: err}
+		}
+		report := dbDiffReport{
+			Generated: time.Now().Format(time.RFC3339),
+			Pattern:   d.Pattern,
+			A:         d.Sides[0].Label,
+			B:         d.Sides[1].Label,
+			Values:    d.Values,
+			Scanned:   d.Scanned,
+			Identical: d.Identical,
+			Truncated: d.Truncated,
+			Entries:   d.Entries,
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return RedisResultMsg{Error: err}
+		}
+		if err := os.WriteFile(resolved, data, 0644); err != nil {
+			return RedisResultMsg{Error: fmt.Errorf("could not write report: %v", err)}
+		}
+		return RedisResultMsg{Result: fmt.Sprintf("Diff report (%d entries) written to %s", len(d.Entries), resolved)}
+	}
+}
+
+func (m Model) dbDiffView() string {
+	d := m.DBDiff
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+	kindStyle := map[string]lipgloss.Style{
+		DiffOnlyA:     lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)),
+		DiffOnlyB:     lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)),
+		DiffDifferent: lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow)),
+	}
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Bold(true).Render("DIFF") +
+		dim.Render(fmt.Sprintf(" · %s · A %s (%d keys) · B %s (%d keys)", d.Pattern, d.Sides[0].Label, d.Scanned[0], d.Sides[1].Label, d.Scanned[1]))
+	if !d.Values {
+		title += dim.Render(" · type/TTL only")
+	}
+
+	tab := func(key, label string, n int, kind string) string {
+		s := fmt.Sprintf("%s %s %d", key, label, n)
+		if d.Filter == kind {
+			return accent.Bold(true).Render(s)
+		}
+		return dim.Render(s)
+	}
+	tabs := tab("0", "all", len(d.Entries), "") + "   " +
+		tab("1", DiffOnlyA, d.count(DiffOnlyA), DiffOnlyA) + "   " +
+		tab("2", DiffOnlyB, d.count(DiffOnlyB), DiffOnlyB) + "   " +
+		tab("3", DiffDifferent, d.count(DiffDifferent), DiffDifferent) + "   " +
+		dim.Render(fmt.Sprintf("identical %d", d.Identical))
+	if d.Truncated {
+		tabs += "   " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow)).Render(fmt.Sprintf("⚠ capped at %d keys per side", maxDiffKeys))
+	}
+
+	rows := []string{}
+	for i, e := range d.visible() {
+		marker := "  "
+		key := text.Render(e.Key)
+		if i == d.Cursor {
+			marker = accent.Render(pointerGlyph)
+			key = accent.Bold(true).Render(e.Key)
+		}
+		rows = append(rows, marker+kindStyle[e.Kind].Render(fmt.Sprintf("%-10s", e.Kind))+" "+key+"  "+subtle.Render(e.Detail))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, "  "+dim.Render("nothing to show — the two sides match for this pattern"))
+	}
+	// header(2) + blank + title + tabs + blank + footer(2)
+	if avail := m.WindowHeight - 8; avail > 0 && len(rows) > avail {
+		start := 0
+		if d.Cursor >= avail {
+			start = d.Cursor - avail + 1
+		}
+		rows = rows[start : start+avail]
+	}
+
+	body := "  " + title + "\n  " + tabs + "\n\n" + strings.Join(rows, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(dbDiffKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
@@ -58,7 +58,7 @@ func ExportField(conn net.Conn, reader *bufio.Reader, key, keyType, field string
 		fe := FieldExport{Key: key, Type: keyType, Field: field}
 		switch keyType {
 		case "hash":
-			resp, err := readResp(conn, reader, redis.RedisCmd{Name: "HGET", Args: []string{key, field}})
+			resp, err := readResp(conn, reader, redis.HGet(key, field))
 			if err != nil {
 				return RedisResultMsg{Error: err}
 			}
@@ -68,7 +68,7 @@ func ExportField(conn net.Conn, reader *bufio.Reader, key, keyType, field string
 			}
 			fe.Value = s
 		case "list":
-			resp, err := readResp(conn, reader, redis.RedisCmd{Name: "LINDEX", Args: []string{key, strconv.Itoa(index)}})
+			resp, err := readResp(conn, reader, redis.LIndex(key, index))
 			if err != nil {
 				return RedisResultMsg{Error: err}
 			}
@@ -79,7 +79,7 @@ func ExportField(conn net.Conn, reader *bufio.Reader, key, keyType, field string
 			fe.Field = ""
 			fe.Value = field
 		case "zset":
-			resp, err := readResp(conn, reader, redis.RedisCmd{Name: "ZSCORE", Args: []string{key, field}})
+			resp, err := readResp(conn, reader, redis.ZScore(key, field))
 			if err != nil {
 				return RedisResultMsg{Error: err}
 			}
@@ -103,7 +103,7 @@ func ExportField(conn net.Conn, reader *bufio.Reader, key, keyType, field string
 		if label == "" {
 			label = fe.Value
 		}
-		return RedisResultMsg{Result: fmt.Sprintf("Exported %s entry '%s' to %s", keyType, label, resolved)}
+		return RedisResultMsg{Result: fmt.Sprintf("Exported %s entry '%s' to %s (%s)", keyType, label, resolved, formatBytes(len(data)))}
 	}
 }
 
@@ -141,17 +141,17 @@ func ImportField(conn net.Conn, reader *bufio.Reader, filePath, targetKey, targe
 			if fe.Field == "" {
 				return RedisResultMsg{Error: fmt.Errorf("hash import requires a 'field' in the file")}
 			}
-			cmd = redis.RedisCmd{Name: "HSET", Args: []string{targetKey, fe.Field, fe.Value}}
+			cmd = redis.HSet(targetKey, fe.Field, fe.Value)
 		case "list":
-			cmd = redis.RedisCmd{Name: "RPUSH", Args: []string{targetKey, fe.Value}}
+			cmd = redis.RPush(targetKey, fe.Value)
 		case "set":
-			cmd = redis.RedisCmd{Name: "SADD", Args: []string{targetKey, fe.Value}}
+			cmd = redis.SAdd(targetKey, fe.Value)
 		case "zset":
 			score := fe.Score
 			if score == "" {
 				score = "0"
 			}
-			cmd = redis.RedisCmd{Name: "ZADD", Args: []string{targetKey, score, fe.Value}}
+			cmd = redis.ZAdd(targetKey, score, fe.Value)
 		default:
 			return RedisResultMsg{Error: fmt.Errorf("unsupported type %q in import file", fe.Type)}
 		}
@@ -211,7 +211,7 @@ func looksLikeDir(path string) bool {
 // fetchKeyExportData fetches a single key's DUMP payload and PTTL from Redis
 // and returns an ExportData ready for JSON serialisation.
 func fetchKeyExportData(conn net.Conn, reader *bufio.Reader, key string) (ExportData, error) {
-	if _, err := conn.Write(redis.RedisCmd{Name: "DUMP", Args: []string{key}}.ToBytes()); err != nil {
+	if _, err := conn.Write(redis.Dump(key).ToBytes()); err != nil {
 		return ExportData{}, fmt.Errorf("DUMP write failed for %q: %w", key, err)
 	}
 	_ = conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
@@ -220,14 +220,14 @@ func fetchKeyExportData(conn net.Conn, reader *bufio.Reader, key string) (Export
 	if err != nil {
 		return ExportData{}, err
 	}
-	dumpPayload, ok := dumpResp.(string)
-	// ReadResp returns the literal string "(nil)" for Redis null bulk strings ($-1).
-	// Treat it the same as a missing key rather than exporting corrupt data.
-	if !ok || dumpPayload == "" || dumpPayload == "(nil)" {
+	// A nil reply means the key is gone; treat it like an empty payload rather
+	// than exporting corrupt data.
+	dumpPayload, err := redis.AsString(dumpResp)
+	if err != nil || dumpPayload == "" {
 		return ExportData{}, fmt.Errorf("key %q does not exist or has no payload", key)
 	}
 
-	if _, err := conn.Write(redis.RedisCmd{Name: "PTTL", Args: []string{key}}.ToBytes()); err != nil {
+	if _, err := conn.Write(redis.PTTL(key).ToBytes()); err != nil {
 		return ExportData{}, fmt.Errorf("PTTL write failed for %q: %w", key, err)
 	}
 	_ = conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
@@ -236,9 +236,9 @@ func fetchKeyExportData(conn net.Conn, reader *bufio.Reader, key string) (Export
 	if err != nil {
 		return ExportData{}, fmt.Errorf("PTTL failed for %q: %w", key, err)
 	}
-	ttl := -1
-	if p, ok := pttlResp.(int); ok {
-		ttl = p
+	ttl, err := redis.AsInt(pttlResp)
+	if err != nil {
+		ttl = -1
 	}
 
 	return ExportData{
@@ -273,7 +273,7 @@ func ExportSingleKey(conn net.Conn, reader *bufio.Reader, key string, filePath s
 			return RedisResultMsg{Error: fmt.Errorf("failed to write file: %v", err)}
 		}
 
-		return RedisResultMsg{Result: fmt.Sprintf("Successfully exported key '%s' to %s", key, resolvedPath)}
+		return RedisResultMsg{Result: fmt.Sprintf("Successfully exported key '%s' to %s (%s)", key, resolvedPath, formatBytes(len(fileData)))}
 	}
 }
 
@@ -310,13 +310,13 @@ func ImportKeys(conn net.Conn, reader *bufio.Reader, filePath string) tea.Cmd {
 				restoreTTL = 0
 			}
 
-			cmd := redis.RedisCmd{
+			cmd := redis.RedisCmdBytes{
 				Name: "RESTORE",
-				Args: []string{
-					item.Key,
-					fmt.Sprintf("%d", restoreTTL),
-					string(decodedDump),
-					"REPLACE",
+				Args: [][]byte{
+					[]byte(item.Key),
+					[]byte(strconv.Itoa(restoreTTL)),
+					decodedDump,
+					[]byte("REPLACE"),
 				},
 			}
 			if _, err := conn.Write(cmd.ToBytes()); err != nil {
@@ -342,7 +342,7 @@ func ImportKeys(conn net.Conn, reader *bufio.Reader, filePath string) tea.Cmd {
 //
 // The file is written to a temporary path first and atomically renamed on
 // success, so a partial or interrupted export never corrupts a previous export.
-func ExportFullDB(conn net.Conn, reader *bufio.Reader, db int, filePath string) tea.Cmd {
+func ExportFullDB(conn net.Conn, reader *bufio.Reader, db, scanCount int, filePath string) tea.Cmd {
 	return func() tea.Msg {
 		if conn == nil {
 			return RedisResultMsg{Error: fmt.Errorf("no connection to Redis")}
@@ -380,9 +380,11 @@ func ExportFullDB(conn net.Conn, reader *bufio.Reader, db int, filePath string)
 		first := true
 		exportedCount := 0
 		cursor := "0"
+		count := firstScanCount(scanCount)
 
 		for {
-			if _, err := conn.Write(redis.RedisCmd{Name: "SCAN", Args: []string{cursor}}.ToBytes()); err != nil {
+			start := time.Now()
+			if _, err := conn.Write(redis.Scan(cursor, "", count).ToBytes()); err != nil {
 				return RedisResultMsg{Error: err}
 			}
 			_ = conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
@@ -391,19 +393,12 @@ func ExportFullDB(conn net.Conn, reader *bufio.Reader, db int, filePath string)
 			if err != nil {
 				return RedisResultMsg{Error: err}
 			}
+			count = nextScanCount(count, scanCount, time.Since(start))
 
 			var keys []string
-			if resp, ok := response.([]any); ok && len(resp) >= 2 {
-				if c, ok := resp[0].(string); ok {
-					cursor = c
-				}
-				if slice, ok := resp[1].([]any); ok {
-					for _, str := range slice {
-						if s, ok := str.(string); ok {
-							keys = append(keys, s)
-						}
-					}
-				}
+			cursor, keys, err = scanPage(response)
+			if err != nil {
+				return RedisResultMsg{Error: err}
 			}
 
 			for _, key := range keys {
@@ -442,6 +437,24 @@ func ExportFullDB(conn net.Conn, reader *bufio.Reader, db int, filePath string)
 		}
 		success = true
 
-		return RedisResultMsg{Result: fmt.Sprintf("Successfully exported %d keys to %s", exportedCount, resolvedPath)}
+		size := ""
+		if fi, err := os.Stat(resolvedPath); err == nil {
+			size = " (" + formatBytes(int(fi.Size())) + ")"
+		}
+		return RedisResultMsg{Result: fmt.Sprintf("Successfully exported %d keys to %s%s", exportedCount, resolvedPath, size)}
 	}
 }
+
+// promptKeyExport asks where to dump key, defaulting to ./<key>.dump.
+func (m Model) promptKeyExport(key string) Model {
+	m.SelectedOp = OpExport
+	m.ActiveKey = key
+	m.pushState(m.CurrentState)
+	m.Input.Input.SetValue("./" + sanitizeFilename(key) + ".dump")
+	m.Input.Input.CursorEnd()
+	m.Input.Input.Focus()
+	m.Input.Type = InputFilePath
+	m.Input.Hint = "Destination file for " + key + ":"
+	m.CurrentState = StateInputFilePath
+	return m
+}
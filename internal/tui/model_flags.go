@@ -0,0 +1,334 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FLAGS lists the keys matching a few patterns as feature flags: strings
+// holding 0/1 or true/false, each shown on or off. Space flips the selected
+// one after a confirmation, writing the opposite in the same spelling with
+// SET … XX KEEPTTL, so the TTL stays and a flag deleted meanwhile isn't
+// recreated. Keys holding anything else are listed but left alone.
+
+const (
+	maxFlags    = 500 // keys listed across all patterns
+	flagNameCol = 40
+)
+
+// FlagsModel is the state of the flag list.
+type FlagsModel struct {
+	Patterns []string
+	Rows     []FlagRow
+	Cursor   int
+	Next     string // the value the confirmed toggle writes
+	Err      string
+}
+
+// FlagRow is one listed key.
+type FlagRow struct {
+	Key   string
+	Value string
+	On    bool
+	Flag  bool // the value is 0, 1, true or false
+}
+
+// FlagsMsg carries one read of the flag list.
+type FlagsMsg struct {
+	Rows  []FlagRow
+	Error error
+}
+
+// parseFlag reads v as a flag, in any case.
+func parseFlag(v string) (on, ok bool) {
+	switch strings.ToLower(v) {
+	case "1", "true":
+		return true, true
+	case "0", "false":
+		return false, true
+	}
+	return false, false
+}
+
+// flipFlag is the opposite of flag value v, spelled the same way: 1 and 0,
+// or true and false in v's case.
+func flipFlag(v string) string {
+	switch v {
+	case "1":
+		return "0"
+	case "0":
+		return "1"
+	}
+	on, _ := parseFlag(v)
+	next := "true"
+	if on {
+		next = "false"
+	}
+	switch {
+	case v == strings.ToUpper(v):
+		return strings.ToUpper(next)
+	case v[:1] == strings.ToUpper(v[:1]):
+		return strings.ToUpper(next[:1]) + next[1:]
+	}
+	return next
+}
+
+func (m Model) startFlagsForm() (tea.Model, tea.Cmd) {
+	patterns := strings.Join(m.Config.Flags, ", ")
+	if patterns == "" {
+		patterns = "feature:*, flag:*"
+	}
+	m.Form = NewForm(
+		"FLAGS · keys holding 0/1 or true/false, toggled in place",
+		[]string{"key patterns (comma-separated)"},
+		[]string{patterns},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitFlagsForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	var patterns []string
+	for _, s := range strings.Split(values[0], ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			patterns = append(patterns, s)
+		}
+	}
+	if len(patterns) == 0 {
+		m.Form.Err = "enter at least one key pattern"
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.Flags = FlagsModel{Patterns: patterns}
+	m.pushState(StateForm)
+	return m.switchToLoadingAndExecute(loadFlags(m.Conn, m.Reader, patterns, m.scanCountMax()))
+}
+
+// loadFlags scans the patterns and reads every match with GET in one
+// pipelined batch.
+func loadFlags(conn net.Conn, reader *bufio.Reader, patterns []string, scanCount int) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return FlagsMsg{Error: fmt.Errorf("no connection to Redis")}
+		}
+		seen := map[string]bool{}
+		var keys []string
+		for _, p := range patterns {
+			matched, err := scanAllKeys(conn, reader, p, maxFlags-len(keys), scanCount)
+			if err != nil {
+				return FlagsMsg{Error: err}
+			}
+			for _, k := range matched {
+				if !seen[k] {
+					seen[k] = true
+					keys = append(keys, k)
+				}
+			}
+			if len(keys) >= maxFlags {
+				break
+			}
+		}
+		sort.Strings(keys)
+
+		cmds := make([]redis.RedisCmd, len(keys))
+		for i, k := range keys {
+			cmds[i] = redis.Get(k)
+		}
+		replies, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			return FlagsMsg{Error: err}
+		}
+		rows := make([]FlagRow, 0, len(keys))
+		for i, k := range keys {
+			v, _ := replies[i].(string)
+			switch {
+			case v == "(nil)":
+				continue // gone since the SCAN
+			case strings.HasPrefix(v, "WRONGTYPE"):
+				v = "(not a string)"
+			}
+			row := FlagRow{Key: k, Value: v}
+			row.On, row.Flag = parseFlag(v)
+			rows = append(rows, row)
+		}
+		return FlagsMsg{Rows: rows}
+	}
+}
+
+func handleFlags(m Model, msg FlagsMsg) (tea.Model, tea.Cmd) {
+	if m.CurrentState != StateLoading && m.CurrentState != StateFlags {
+		return m, nil // left meanwhile
+	}
+	f := &m.Flags
+	if msg.Error != nil {
+		if m.CurrentState == StateLoading {
+			m.Result = errorResult(msg.Error)
+			m.CurrentState = StateOutput
+			return m, nil
+		}
+		f.Err = msg.Error.Error()
+		return m, nil
+	}
+	// Keep the cursor on the same key across a reload.
+	if sel := f.selected(); sel != nil {
+		for i, r := range msg.Rows {
+			if r.Key == sel.Key {
+				f.Cursor = i
+			}
+		}
+	}
+	f.Rows, f.Err = msg.Rows, ""
+	f.Cursor = min(f.Cursor, max(len(f.Rows)-1, 0))
+	m.CurrentState = StateFlags
+	return m, nil
+}
+
+// selected is the row under the cursor, or nil.
+func (f FlagsModel) selected() *FlagRow {
+	if f.Cursor < len(f.Rows) {
+		return &f.Rows[f.Cursor]
+	}
+	return nil
+}
+
+func handleStateFlagsKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := &m.Flags
+	switch keyMsg.String() {
+	case "esc":
+		m.CurrentState = m.popState()
+	case "up", "k":
+		f.Cursor = max(f.Cursor-1, 0)
+	case "down", "j":
+		f.Cursor = min(f.Cursor+1, max(len(f.Rows)-1, 0))
+	case "r":
+		return m, m.exec(loadFlags(m.Conn, m.Reader, f.Patterns, m.scanCountMax()))
+	case " ", "enter", "t":
+		row := f.selected()
+		switch {
+		case row == nil:
+		case m.ReadOnly:
+			return m, m.notify("Toggling flags is disabled: this session is read-only", true)
+		case !row.Flag:
+			return m, m.notify(fmt.Sprintf("%s holds %q, not 0/1 or true/false", row.Key, truncateText(row.Value, 30)), true)
+		default:
+			f.Next = flipFlag(row.Value)
+			m.ActiveKey = row.Key
+			m.SelectedOp = OpFlagToggle
+			m.pushState(StateFlags)
+			m.ask(m.flagDialog())
+		}
+	}
+	return m, nil
+}
+
+// flagDialog asks before flipping the active flag. Flipping it back undoes
+// it, so the dialog isn't a warning.
+func (m Model) flagDialog() Dialog {
+	state := "off"
+	if on, _ := parseFlag(m.Flags.Next); on {
+		state = "on"
+	}
+	return Dialog{Title: "toggle flag", Label: fmt.Sprintf("turn %s: SET to %q, keeping its TTL", state, m.Flags.Next), Value: m.ActiveKey,
+		Target: m.ActiveKey, Choices: []DialogChoice{{Key: "y", Label: "turn " + state, Run: confirmFlagToggle}}}
+}
+
+func confirmFlagToggle(m Model) (tea.Model, tea.Cmd) {
+	cmd := redis.RedisCmd{Name: "SET", Args: []string{m.ActiveKey, m.Flags.Next, "XX", "KEEPTTL"}}
+	return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+}
+
+// handleFlagToggleResult reports the write as a toast over the list, read
+// again in the background in case other flags changed too.
+func handleFlagToggleResult(m Model, reply Result, resp any) (tea.Model, tea.Cmd) {
+	m.CurrentState = m.popState()
+	m.SelectedOp = OpFlags
+	key := m.ActiveKey
+	var toast tea.Cmd
+	switch s, _ := resp.(string); {
+	case reply.Err != "":
+		toast = m.notify(reply.Err, true)
+	case s == "(nil)":
+		toast = m.notify(key+" no longer exists", true)
+	default:
+		m.invalidate(key)
+		if row := m.Flags.selected(); row != nil && row.Key == key {
+			row.Value = m.Flags.Next
+			row.On, _ = parseFlag(row.Value)
+		}
+		state := "off"
+		if on, _ := parseFlag(m.Flags.Next); on {
+			state = "on"
+		}
+		toast = m.notify(fmt.Sprintf("Turned %s %s", key, state), false)
+	}
+	return m, tea.Batch(m.exec(loadFlags(m.Conn, m.Reader, m.Flags.Patterns, m.scanCountMax())), toast)
+}
+
+func (m Model) flagsView() string {
+	f := m.Flags
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+
+	on, flags := 0, 0
+	for _, r := range f.Rows {
+		if r.Flag {
+			flags++
+			if r.On {
+				on++
+			}
+		}
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnBlue)).Bold(true).Render("FLAGS") +
+		dim.Render(fmt.Sprintf(" · %s · %d flags, %d on", strings.Join(f.Patterns, ", "), flags, on))
+	if other := len(f.Rows) - flags; other > 0 {
+		title += dim.Render(fmt.Sprintf(" · %d other keys", other))
+	}
+	if f.Err != "" {
+		title += "   " + red.Render("✗ "+f.Err)
+	}
+
+	rows := []string{}
+	for i, r := range f.Rows {
+		name := padCells(r.Key, flagNameCol)
+		marker, nameView := "  ", text.Render(name)
+		if i == f.Cursor {
+			marker = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render(pointerGlyph)
+			nameView = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true).Render(name)
+		}
+		var state string
+		switch {
+		case !r.Flag:
+			state = dim.Render("     " + truncateText(r.Value, 30))
+		case r.On:
+			state = green.Render("● on ") + subtle.Render(r.Value)
+		default:
+			state = dim.Render("○ off ") + subtle.Render(r.Value)
+		}
+		rows = append(rows, marker+nameView+"  "+state)
+	}
+	if len(rows) == 0 {
+		rows = append(rows, "  "+dim.Render("no keys match"))
+	}
+	// header(2) + blank + title + blank + footer(2)
+	if avail := m.WindowHeight - 7; avail > 0 && len(rows) > avail {
+		start := max(f.Cursor-avail+1, 0)
+		rows = rows[start : start+avail]
+	}
+
+	body := "  " + title + "\n\n" + strings.Join(rows, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(flagsKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
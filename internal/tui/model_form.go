@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FormModel is a small multi-field form for tools that need several inputs at
+// once (key + timeout, pattern + interval, …) rather than a chain of single
+// prompts. tab / shift+tab move between fields, enter advances and submits
+// from the last field, esc cancels.
+type FormModel struct {
+	Title  string
+	Labels []string
+	Inputs []textinput.Model
+	Focus  int
+	// Err is a validation message set by whoever handles FormSubmitMsg; the
+	// form stays open so the user can correct the offending field.
+	Err    string
+	Width  int
+	Height int
+}
+
+// FormSubmitMsg carries the form's values, in field order.
+type FormSubmitMsg struct {
+	Values []string
+}
+
+// NewForm builds a focused form with one input per label, pre-filled from
+// defaults (which may be shorter than labels).
+func NewForm(title string, labels, defaults []string) FormModel {
+	f := FormModel{Title: title, Labels: labels}
+	for i := range labels {
+		ti := textinput.New()
+		ti.CharLimit = 4096
+		if i < len(defaults) {
+			ti.SetValue(defaults[i])
+			ti.CursorEnd()
+		}
+		f.Inputs = append(f.Inputs, ti)
+	}
+	if len(f.Inputs) > 0 {
+		f.Inputs[0].Focus()
+	}
+	return f
+}
+
+// Values returns the current (trimmed) field values in order.
+func (f FormModel) Values() []string {
+	out := make([]string, len(f.Inputs))
+	for i, in := range f.Inputs {
+		out[i] = strings.TrimSpace(in.Value())
+	}
+	return out
+}
+
+func (f *FormModel) setFocus(i int) tea.Cmd {
+	if i < 0 || i >= len(f.Inputs) {
+		return nil
+	}
+	f.Inputs[f.Focus].Blur()
+	f.Focus = i
+	f.Inputs[i].Focus()
+	return textinput.Blink
+}
+
+func (f FormModel) Update(msg tea.Msg) (FormModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			return f, func() tea.Msg { return BackMsg{} }
+		case "tab", "down":
+			return f, f.setFocus((f.Focus + 1) % len(f.Inputs))
+		case "shift+tab", "up":
+			return f, f.setFocus((f.Focus + len(f.Inputs) - 1) % len(f.Inputs))
+		case "enter":
+			if f.Focus < len(f.Inputs)-1 {
+				return f, f.setFocus(f.Focus + 1)
+			}
+			values := f.Values()
+			return f, func() tea.Msg { return FormSubmitMsg{Values: values} }
+		}
+	}
+
+	if len(f.Inputs) == 0 {
+		return f, nil
+	}
+	var cmd tea.Cmd
+	f.Inputs[f.Focus], cmd = f.Inputs[f.Focus].Update(msg)
+	return f, cmd
+}
+
+// View renders the form in the same inline style as the browser's add-item
+// overlay: an accent label + "› " prompt for the focused field, dim ones for
+// the rest, and the validation error (if any) underneath.
+func (f FormModel) View() string {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+	dimmer := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDimmer))
+
+	content := subtle.Render(f.Title)
+	for i, label := range f.Labels {
+		in := f.Inputs[i]
+		in.Width = 60
+		in.Prompt = pointerGlyph
+		if i == f.Focus {
+			content += "\n\n" + accent.Render(label)
+			in.PromptStyle = accent
+			in.TextStyle = text
+		} else {
+			content += "\n\n" + dim.Render(label)
+			in.PromptStyle = dimmer
+			in.TextStyle = subtle
+		}
+		content += "\n" + in.View()
+	}
+	if f.Err != "" {
+		content += "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render(f.Err)
+	}
+
+	hm := NewHelp()
+	hm.Width = f.Width
+	foot := footerSep(f.Width) + "\n  " + hm.View(formKeys)
+	// f.Height is the full window; subtract the 2-line connection header.
+	return bottomFooter(indentLines(content, 2), foot, f.Height-2)
+}
+
+// updateForm runs the tool form on show; its submit is FormSubmitMsg.
+func updateForm(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	form, cmd := m.Form.Update(msg)
+	m.Form = form
+	return m, cmd
+}
+
+func (m Model) formView() string {
+	return m.headerView() + "\n" + m.Form.View()
+}
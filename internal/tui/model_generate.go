@@ -0,0 +1,320 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	maxGenerateKeys  = 1_000_000
+	generateBatch    = 200 // keys per pipelined round trip, and per progress update
+	maxGenerateItems = 10_000
+)
+
+// GenerateSpec is what the GENERATE form asks for.
+type GenerateSpec struct {
+	Pattern string // key name; {{n}} is the key's sequence number
+	Count   int
+	Type    string // string, hash, list, set or zset
+	Items   int    // fields/elements per collection key
+	Value   string // value template; {{n}}, {{i}}, {{rand}}, {{now}}, {{uuid}}
+	TTL     int    // seconds; 0 = no expiry
+}
+
+// GenerateModel is the state of a running (or finished) generator. It writes
+// over its own connection so progress can be shown between batches without
+// holding the session's connection.
+type GenerateModel struct {
+	Spec     GenerateSpec
+	Conn     net.Conn
+	Reader   *bufio.Reader
+	Gen      int
+	Done     int
+	Errors   int // error replies, e.g. WRONGTYPE on an existing key
+	LastErr  string
+	Started  time.Time
+	Finished time.Time
+	Err      string // the run stopped on this
+}
+
+// GenerateConnMsg delivers the generator's dedicated connection.
+type GenerateConnMsg struct {
+	Gen    int
+	Conn   net.Conn
+	Reader *bufio.Reader
+	Error  error
+}
+
+// GenerateBatchMsg reports one written batch.
+type GenerateBatchMsg struct {
+	Gen     int
+	Written int
+	Errors  int
+	LastErr string
+	Error   error
+}
+
+var generatorTypes = map[string]bool{"string": true, "hash": true, "list": true, "set": true, "zset": true}
+
+func (m Model) startGenerateForm() (tea.Model, tea.Cmd) {
+	m.Form = NewForm(
+		"GENERATE · write test keys (existing keys with these names are replaced)",
+		[]string{
+			"key pattern ({{n}} = key number)",
+			"how many keys",
+			"type: string, hash, list, set or zset",
+			"fields / elements per key (collections)",
+			"value ({{n}} key number, {{i}} element number, {{rand}}, {{uuid}}, {{now}})",
+			"TTL in seconds (0 = no expiry)",
+		},
+		[]string{"test:{{n}}", "1000", "string", "10", "{{rand}}", "0"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitGenerateForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	spec := GenerateSpec{Pattern: values[0], Type: strings.ToLower(values[2]), Value: values[4]}
+	var err error
+	switch {
+	case spec.Pattern == "":
+		m.Form.Err = "enter a key pattern"
+		return m, nil
+	case !generatorTypes[spec.Type]:
+		m.Form.Err = "type must be string, hash, list, set or zset"
+		return m, nil
+	}
+	if spec.Count, err = strconv.Atoi(values[1]); err != nil || spec.Count <= 0 || spec.Count > maxGenerateKeys {
+		m.Form.Err = fmt.Sprintf("count must be between 1 and %d", maxGenerateKeys)
+		return m, nil
+	}
+	if spec.Count > 1 && !strings.Contains(spec.Pattern, "{{n}}") {
+		m.Form.Err = "the pattern needs {{n}} so every key gets its own name"
+		return m, nil
+	}
+	if spec.Items, err = strconv.Atoi(values[3]); err != nil || spec.Items <= 0 || spec.Items > maxGenerateItems {
+		m.Form.Err = fmt.Sprintf("fields / elements must be between 1 and %d", maxGenerateItems)
+		return m, nil
+	}
+	if spec.TTL, err = strconv.Atoi(values[5]); err != nil || spec.TTL < 0 {
+		m.Form.Err = "TTL must be a whole number of seconds, 0 for none"
+		return m, nil
+	}
+
+	m.Form.Err = ""
+	m.stopGenerate()
+	m.Generate = GenerateModel{Spec: spec, Gen: m.Generate.Gen + 1, Started: time.Now()}
+	return m.switchToLoadingAndDial(openGenerateConnection(m, m.Generate.Gen))
+}
+
+func openGenerateConnection(m Model, gen int) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m)
+		return GenerateConnMsg{Gen: gen, Conn: conn, Reader: reader, Error: err}
+	}
+}
+
+func handleGenerateConn(m Model, msg GenerateConnMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.Generate.Gen {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close()
+		}
+		return m, nil
+	}
+	if msg.Error != nil {
+		m.Result = Result{Type: "error", Err: "Could not open a connection for the generator: " + msg.Error.Error()}
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	g := &m.Generate
+	g.Conn, g.Reader = msg.Conn, msg.Reader
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateGenerate
+	}
+	return m, writeGenerateBatch(g.Conn, g.Reader, g.Spec, 0, g.Gen)
+}
+
+var generatorPlaceholderRe = regexp.MustCompile(`\{\{\s*(n|i|rand)\s*\}\}`)
+
+// generateValue expands the generator's own placeholders, then the template
+// ones ({{now}}, {{uuid}}).
+func generateValue(tmpl string, n, i int, now time.Time) string {
+	s := generatorPlaceholderRe.ReplaceAllStringFunc(tmpl, func(match string) string {
+		switch generatorPlaceholderRe.FindStringSubmatch(match)[1] {
+		case "n":
+			return strconv.Itoa(n)
+		case "i":
+			return strconv.Itoa(i)
+		}
+		return fmt.Sprintf("%016x", rand.Uint64())
+	})
+	return expandPlaceholders(s, now)
+}
+
+// generateCommands returns the commands that (re)create key number n.
+// Collections are deleted first so a rerun replaces rather than appends.
+func generateCommands(spec GenerateSpec, n int, now time.Time) []redis.RedisCmd {
+	key := generateValue(spec.Pattern, n, 0, now)
+	var cmds []redis.RedisCmd
+	if spec.Type == "string" {
+		cmds = append(cmds, redis.Set(key, generateValue(spec.Value, n, 1, now)))
+	} else {
+		cmds = append(cmds, redis.Del(key))
+		args := []string{key}
+		for i := 1; i <= spec.Items; i++ {
+			v := generateValue(spec.Value, n, i, now)
+			switch spec.Type {
+			case "hash":
+				args = append(args, "field:"+strconv.Itoa(i), v)
+			case "zset":
+				args = append(args, strconv.Itoa(i), v)
+			default:
+				args = append(args, v)
+			}
+		}
+		name := map[string]string{"hash": "HSET", "list": "RPUSH", "set": "SADD", "zset": "ZADD"}[spec.Type]
+		cmds = append(cmds, redis.RedisCmd{Name: name, Args: args})
+	}
+	if spec.TTL > 0 {
+		cmds = append(cmds, redis.Expire(key, spec.TTL))
+	}
+	return cmds
+}
+
+// writeGenerateBatch writes keys from..from+generateBatch-1 (numbered from 1
+// in key names) in one pipelined round trip.
+func writeGenerateBatch(conn net.Conn, reader *bufio.Reader, spec GenerateSpec, from, gen int) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		to := min(from+generateBatch, spec.Count)
+		var cmds []redis.RedisCmd
+		for n := from + 1; n <= to; n++ {
+			cmds = append(cmds, generateCommands(spec, n, now)...)
+		}
+		replies, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			return GenerateBatchMsg{Gen: gen, Error: err}
+		}
+		msg := GenerateBatchMsg{Gen: gen, Written: to - from}
+		for _, r := range replies {
+			if s, ok := r.(string); ok && redis.IsErrorReply(s) {
+				msg.Errors++
+				msg.LastErr = s
+			}
+		}
+		return msg
+	}
+}
+
+func handleGenerateBatch(m Model, msg GenerateBatchMsg) (tea.Model, tea.Cmd) {
+	g := &m.Generate
+	if msg.Gen != g.Gen || g.Conn == nil {
+		return m, nil
+	}
+	if msg.Error != nil {
+		g.Err = msg.Error.Error()
+		m.stopGenerate()
+		return m, nil
+	}
+	g.Done += msg.Written
+	g.Errors += msg.Errors
+	if msg.LastErr != "" {
+		g.LastErr = msg.LastErr
+	}
+	if g.Done >= g.Spec.Count {
+		g.Finished = time.Now()
+		m.stopGenerate()
+		return m, nil
+	}
+	return m, writeGenerateBatch(g.Conn, g.Reader, g.Spec, g.Done, g.Gen)
+}
+
+// stopGenerate closes the generator's connection; an in-flight batch is
+// dropped by the Gen bump.
+func (m *Model) stopGenerate() {
+	g := &m.Generate
+	if g.Conn != nil {
+		_ = g.Conn.Close()
+	}
+	g.Conn, g.Reader = nil, nil
+	g.Gen++
+}
+
+func handleStateGenerateKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch keyMsg.String() {
+	case "esc":
+		m.stopGenerate()
+		m.CurrentState = m.popState()
+	case "x":
+		if m.Generate.Conn != nil {
+			m.Generate.Err = "stopped"
+			m.stopGenerate()
+		}
+	}
+	return m, nil
+}
+
+func (m Model) generateView() string {
+	g := m.Generate
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+
+	s := g.Spec
+	shape := s.Type
+	if s.Type != "string" {
+		shape = fmt.Sprintf("%s × %d", s.Type, s.Items)
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)).Bold(true).Render("GENERATE") +
+		dim.Render(fmt.Sprintf(" · %d %s keys · %s", s.Count, shape, s.Pattern))
+	if s.TTL > 0 {
+		title += dim.Render(fmt.Sprintf(" · TTL %ds", s.TTL))
+	}
+
+	w := max(min(m.WindowWidth-20, 60), 10)
+	filled := w * g.Done / max(s.Count, 1)
+	bar := green.Render(strings.Repeat("█", filled)) + dim.Render(strings.Repeat("░", w-filled))
+	pct := fmt.Sprintf(" %3d%%", 100*g.Done/max(s.Count, 1))
+
+	end := g.Finished
+	if end.IsZero() {
+		end = time.Now()
+	}
+	elapsed := end.Sub(g.Started)
+	rate := float64(g.Done) / max(elapsed.Seconds(), 0.001)
+
+	var status string
+	switch {
+	case g.Err != "":
+		status = red.Render(fmt.Sprintf("stopped after %d keys: %s", g.Done, g.Err))
+	case !g.Finished.IsZero():
+		status = green.Render(fmt.Sprintf("done: %d keys in %s", g.Done, elapsed.Round(time.Millisecond)))
+	default:
+		status = subtle.Render(fmt.Sprintf("%d / %d keys", g.Done, s.Count))
+	}
+	lines := []string{
+		"  " + bar + subtle.Render(pct),
+		"  " + status + dim.Render(fmt.Sprintf("  ·  %.0f keys/s", rate)),
+	}
+	if g.Errors > 0 {
+		lines = append(lines, "  "+red.Render(fmt.Sprintf("%d error replies, last: %s", g.Errors, g.LastErr)))
+	}
+
+	body := "  " + title + "\n\n" + strings.Join(lines, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(generateKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
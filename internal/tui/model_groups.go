@@ -0,0 +1,436 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// C in a stream's tail lists its consumer groups (XINFO GROUPS) with what
+// repairs a stuck one: create a group, move its last-delivered ID, destroy
+// it, or hand entries idle too long to a live consumer with XAUTOCLAIM. All
+// but create ask first, since they change what consumers get next. Enter
+// opens a group's pending entries (model_pending.go).
+
+const (
+	defaultClaimer  = "redis-tui"
+	defaultMinIdle  = 60000 // ms
+	defaultClaimMax = 100
+)
+
+// GroupsModel is the state of a stream's consumer group screen.
+type GroupsModel struct {
+	Key    string
+	Groups []StreamGroup
+	Cursor int
+
+	// The action under way.
+	Name     string // CREATE
+	NewID    string // SETID
+	Consumer string // XAUTOCLAIM
+	MinIdle  int    // ms
+	Count    int
+}
+
+// StreamGroup is one row of XINFO GROUPS.
+type StreamGroup struct {
+	Name          string
+	Consumers     int
+	Pending       int
+	LastDelivered string
+	Lag           string // entries not yet delivered; "" before Redis 7
+}
+
+// selected is the group under the cursor, or nil.
+func (g GroupsModel) selected() *StreamGroup {
+	if g.Cursor < 0 || g.Cursor >= len(g.Groups) {
+		return nil
+	}
+	return &g.Groups[g.Cursor]
+}
+
+// startGroups opens key's group screen over the screen it came from.
+func (m Model) startGroups(key string) (tea.Model, tea.Cmd) {
+	m.Groups = GroupsModel{Key: key}
+	m.pushState(m.CurrentState)
+	return m.loadGroups()
+}
+
+func (m Model) loadGroups() (tea.Model, tea.Cmd) {
+	m.SelectedOp = OpGroups
+	return m.switchToLoadingAndExecute(m.sendRead(redis.XInfoGroups(m.Groups.Key)))
+}
+
+// parseGroups reads XINFO GROUPS: one flat field/value list per group.
+func parseGroups(resp any) ([]StreamGroup, error) {
+	items, ok := resp.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected XINFO GROUPS reply")
+	}
+	groups := make([]StreamGroup, 0, len(items))
+	for _, it := range items {
+		fields, ok := it.([]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected XINFO GROUPS reply")
+		}
+		var g StreamGroup
+		for i := 0; i+1 < len(fields); i += 2 {
+			name, _ := fields[i].(string)
+			v := fields[i+1]
+			switch name {
+			case "name":
+				g.Name = fmt.Sprint(v)
+			case "consumers":
+				g.Consumers, _ = strconv.Atoi(fmt.Sprint(v))
+			case "pending":
+				g.Pending, _ = strconv.Atoi(fmt.Sprint(v))
+			case "last-delivered-id":
+				g.LastDelivered = fmt.Sprint(v)
+			case "lag":
+				if s := fmt.Sprint(v); s != "(nil)" {
+					g.Lag = s
+				}
+			}
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// handleGroupsResult shows the groups read, keeping the cursor on the same
+// group when it is still there.
+func handleGroupsResult(m Model, reply Result, resp any) (tea.Model, tea.Cmd) {
+	if reply.Err != "" {
+		m.Result = reply
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	groups, err := parseGroups(resp)
+	if err != nil {
+		m.Result = reply.withValue(err.Error())
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	g := &m.Groups
+	cursor := 0
+	if sel := g.selected(); sel != nil {
+		for i, ng := range groups {
+			if ng.Name == sel.Name {
+				cursor = i
+			}
+		}
+	}
+	g.Groups, g.Cursor = groups, cursor
+	m.CurrentState = StateGroups
+	return m, nil
+}
+
+// groupWriteRefused notifies why a group write can't be made, if it can't.
+func (m *Model) groupWriteRefused(what string) tea.Cmd {
+	if m.ReadOnly {
+		return m.notify(what+" is disabled: this session is read-only", true)
+	}
+	return nil
+}
+
+// groupStartID checks the ID a group is to read after: $, 0, or an entry ID.
+func groupStartID(s string) (string, error) {
+	switch s {
+	case "", "$":
+		return "$", nil
+	case "0":
+		return "0", nil
+	}
+	if !streamIDText.MatchString(s) || strings.HasSuffix(s, "*") {
+		return "", fmt.Errorf("%q: use $ (new entries only), 0 (the whole stream) or an entry ID", s)
+	}
+	return s, nil
+}
+
+func (m Model) startGroupCreate() (tea.Model, tea.Cmd) {
+	if cmd := m.groupWriteRefused("XGROUP CREATE"); cmd != nil {
+		return m, cmd
+	}
+	m.SelectedOp = OpGroupCreate
+	m.Form = NewForm(
+		"XGROUP CREATE · new consumer group on "+m.Groups.Key,
+		[]string{"group name", "deliver entries after ($ = new only, 0 = whole stream, or an ID)"},
+		[]string{"", "$"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.pushState(StateGroups)
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitGroupCreateForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	name := values[0]
+	if name == "" {
+		m.Form.Err = "a group name is required"
+		return m, nil
+	}
+	id, err := groupStartID(values[1])
+	if err != nil {
+		m.Form.Err = err.Error()
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.Groups.Name = name
+	return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.XGroupCreate(m.Groups.Key, name, id, false), m.ReadTimeout))
+}
+
+func (m Model) startGroupSetID() (tea.Model, tea.Cmd) {
+	sel := m.Groups.selected()
+	if sel == nil {
+		return m, nil
+	}
+	if cmd := m.groupWriteRefused("XGROUP SETID"); cmd != nil {
+		return m, cmd
+	}
+	m.SelectedOp = OpGroupSetID
+	m.Form = NewForm(
+		fmt.Sprintf("XGROUP SETID · move %s, now at %s", sel.Name, sel.LastDelivered),
+		[]string{"deliver entries after ($ = skip to the end, 0 = replay all, or an ID)"},
+		[]string{sel.LastDelivered},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.pushState(StateGroups)
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitGroupSetIDForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	id, err := groupStartID(values[0])
+	if err != nil {
+		m.Form.Err = err.Error()
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.Groups.NewID = id
+	m.pushState(StateForm)
+	m.ask(m.groupDialog())
+	return m, nil
+}
+
+func (m Model) confirmGroupDestroy() (tea.Model, tea.Cmd) {
+	if m.Groups.selected() == nil {
+		return m, nil
+	}
+	if cmd := m.groupWriteRefused("XGROUP DESTROY"); cmd != nil {
+		return m, cmd
+	}
+	m.SelectedOp = OpGroupDestroy
+	m.pushState(StateGroups)
+	m.ask(m.groupDialog())
+	return m, nil
+}
+
+func (m Model) startAutoClaim() (tea.Model, tea.Cmd) {
+	sel := m.Groups.selected()
+	if sel == nil {
+		return m, nil
+	}
+	if cmd := m.groupWriteRefused("XAUTOCLAIM"); cmd != nil {
+		return m, cmd
+	}
+	m.SelectedOp = OpAutoClaim
+	m.Form = NewForm(
+		fmt.Sprintf("XAUTOCLAIM · take over %s's stuck entries (%d pending)", sel.Name, sel.Pending),
+		[]string{"hand them to consumer", "idle at least (ms)", "at most this many entries"},
+		[]string{defaultClaimer, strconv.Itoa(defaultMinIdle), strconv.Itoa(defaultClaimMax)},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.pushState(StateGroups)
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitAutoClaimForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	consumer := values[0]
+	if consumer == "" {
+		m.Form.Err = "a consumer name is required"
+		return m, nil
+	}
+	minIdle, err := strconv.Atoi(values[1])
+	if err != nil || minIdle < 0 {
+		m.Form.Err = "idle time must be a whole number of milliseconds (0 or more)"
+		return m, nil
+	}
+	count, err := strconv.Atoi(values[2])
+	if err != nil || count < 1 {
+		m.Form.Err = "the entry limit must be a whole number from 1"
+		return m, nil
+	}
+	m.Form.Err = ""
+	g := &m.Groups
+	g.Consumer, g.MinIdle, g.Count = consumer, minIdle, count
+	m.pushState(StateForm)
+	m.ask(m.groupDialog())
+	return m, nil
+}
+
+// groupDialog asks before the selected group's SETID, DESTROY or XAUTOCLAIM.
+func (m Model) groupDialog() Dialog {
+	g := m.Groups
+	d := Dialog{Title: "confirm", Value: g.Key, Destructive: true, Target: g.Key, Choices: confirmChoice(confirmGroupAction)}
+	sel := g.selected()
+	switch {
+	case sel == nil:
+	case m.SelectedOp == OpGroupDestroy:
+		d.Title = "confirm XGROUP DESTROY"
+		d.Label = fmt.Sprintf("destroy group %s with %d consumers and %d pending entries (XGROUP DESTROY) on", sel.Name, sel.Consumers, sel.Pending)
+	case m.SelectedOp == OpGroupSetID:
+		d.Title = "confirm XGROUP SETID"
+		d.Label = fmt.Sprintf("move group %s from %s to %s (XGROUP SETID); entries after it are delivered next, on", sel.Name, sel.LastDelivered, g.NewID)
+	default:
+		d.Title = "confirm XAUTOCLAIM"
+		d.Label = fmt.Sprintf("hand up to %d of %s's entries idle for %s or more to %s (XAUTOCLAIM) on", g.Count, sel.Name, time.Duration(g.MinIdle)*time.Millisecond, g.Consumer)
+	}
+	return d
+}
+
+// confirmGroupAction sends the confirmed SETID, DESTROY or XAUTOCLAIM.
+func confirmGroupAction(m Model) (tea.Model, tea.Cmd) {
+	g := m.Groups
+	sel := g.selected()
+	if sel == nil {
+		m.CurrentState = m.popState()
+		return m, nil
+	}
+	var cmd redis.RedisCmd
+	switch m.SelectedOp {
+	case OpGroupDestroy:
+		cmd = redis.XGroupDestroy(g.Key, sel.Name)
+	case OpGroupSetID:
+		cmd = redis.XGroupSetID(g.Key, sel.Name, g.NewID)
+	default:
+		cmd = redis.XAutoClaim(g.Key, sel.Name, g.Consumer, g.MinIdle, "0-0", g.Count)
+	}
+	return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+}
+
+// handleGroupActionResult reports a group write as a toast and reloads the
+// groups. A refused CREATE goes back to its form instead, to fix the name.
+func handleGroupActionResult(m Model, reply Result, resp any) (tea.Model, tea.Cmd) {
+	if reply.Err != "" && m.SelectedOp == OpGroupCreate {
+		m.Form.Err = reply.Err
+		m.CurrentState = StateForm
+		return m, nil
+	}
+	name := ""
+	if sel := m.Groups.selected(); sel != nil {
+		name = sel.Name
+	}
+	var text string
+	switch {
+	case reply.Err != "":
+		text = reply.Err
+	case m.SelectedOp == OpGroupCreate:
+		text = "Created group " + m.Groups.Name
+		// Select it: the reload keeps the cursor on the selected name.
+		g := &m.Groups
+		g.Groups = append(g.Groups, StreamGroup{Name: g.Name})
+		g.Cursor = len(g.Groups) - 1
+	case m.SelectedOp == OpGroupDestroy:
+		text = "Destroyed group " + name
+	case m.SelectedOp == OpGroupSetID:
+		text = fmt.Sprintf("Moved %s to %s", name, m.Groups.NewID)
+	default:
+		text = autoClaimText(resp, m.Groups.Consumer)
+	}
+	for len(m.StateNavigationHistory) > 0 && m.popState() != StateGroups {
+	}
+	toast := m.notify(text, reply.Err != "")
+	model, load := m.loadGroups()
+	return model, tea.Batch(load, toast)
+}
+
+// autoClaimText sums up an XAUTOCLAIM reply: next start, claimed entries,
+// and (from Redis 7) the IDs of entries deleted since delivery.
+func autoClaimText(resp any, consumer string) string {
+	r, ok := resp.([]any)
+	if !ok || len(r) < 2 {
+		return "XAUTOCLAIM: unexpected reply"
+	}
+	claimed, _ := r[1].([]any)
+	text := fmt.Sprintf("Claimed %d entries for %s", len(claimed), consumer)
+	if len(r) > 2 {
+		if deleted, _ := r[2].([]any); len(deleted) > 0 {
+			text += fmt.Sprintf(", dropped %d deleted", len(deleted))
+		}
+	}
+	if next, _ := r[0].(string); next != "" && next != "0-0" {
+		text += "; more remain, claim again"
+	}
+	return text
+}
+
+func handleStateGroupsKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	g := &m.Groups
+	switch keyMsg.String() {
+	case "esc":
+		m.CurrentState = m.popState()
+		return m, nil
+	case "up", "k":
+		g.Cursor = max(g.Cursor-1, 0)
+	case "down", "j":
+		g.Cursor = min(g.Cursor+1, max(len(g.Groups)-1, 0))
+	case "enter":
+		return m.startPending()
+	case "r", "ctrl+r", "f5":
+		return m.loadGroups()
+	case "n":
+		return m.startGroupCreate()
+	case "s":
+		return m.startGroupSetID()
+	case "d":
+		return m.confirmGroupDestroy()
+	case "c":
+		return m.startAutoClaim()
+	}
+	return m, nil
+}
+
+func (m Model) groupsView() string {
+	g := m.Groups
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnPurple)).Bold(true).Render("XINFO GROUPS") +
+		dim.Render(" · ") + subtle.Render(g.Key) + dim.Render(fmt.Sprintf("   %d groups", len(g.Groups)))
+
+	var body string
+	if len(g.Groups) == 0 {
+		body = dim.Render("no consumer groups — n creates one")
+	} else {
+		rows := make([][]string, len(g.Groups))
+		for i, gr := range g.Groups {
+			lag := gr.Lag
+			if lag == "" {
+				lag = "—"
+			}
+			rows[i] = []string{gr.Name, strconv.Itoa(gr.Consumers), strconv.Itoa(gr.Pending), gr.LastDelivered, lag}
+		}
+		lines := strings.Split(tableText([]string{"group", "consumers", "pending", "last delivered", "lag"}, rows), "\n")
+		pointer := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render(pointerGlyph)
+		for i := range lines {
+			// The table's first line is its header.
+			if i-1 == g.Cursor {
+				lines[i] = pointer + lines[i]
+			} else {
+				lines[i] = "  " + lines[i]
+			}
+		}
+		body = strings.Join(lines, "\n")
+	}
+
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(groupsKeys)
+	return bottomFooter(m.headerView()+"\n\n  "+title+"\n\n"+indentLines(body, 2), foot, m.WindowHeight)
+}
@@ -0,0 +1,354 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// GROWTH samples DBSIZE, and the number of keys under a few prefixes, every
+// few seconds while it is on screen, and plots each against time. The growth
+// rate is taken over the last minute of samples; when the whole keyspace
+// grows faster than the alert limit the screen turns red, which is what to
+// watch for while a deploy rolls out.
+
+const (
+	growthHistory      = 120 // samples kept per row for the trend and rate
+	growthRateWindow   = time.Minute
+	growthCountCap     = 100000 // keys counted under a prefix before giving up on an exact count
+	growthMaxPrefixes  = 8
+	defaultGrowthAlert = 1000 // keys/min; the config's growth_alert overrides it
+	growthNameCol      = 22
+	growthSparkCol     = 40
+)
+
+// growthSample is one reading: DBSIZE, then the count under each prefix.
+type growthSample struct {
+	At     time.Time
+	Counts []int
+}
+
+// GrowthModel is the state of the keyspace growth monitor.
+type GrowthModel struct {
+	Labels   []string // as shown; Patterns[i] is what is counted for Labels[i]
+	Patterns []string
+	Interval time.Duration
+	Alert    float64 // keys/min on the whole keyspace; 0 never alerts
+	Samples  []growthSample
+	Capped   []bool // a prefix's count stopped at growthCountCap
+	Peak     float64
+	PeakAt   time.Time
+	Started  time.Time
+	Err      string
+
+	// Active and Gen work as in QueuesModel.
+	Active bool
+	Gen    int
+}
+
+// GrowthSampleMsg carries one sample.
+type GrowthSampleMsg struct {
+	Gen    int
+	Sample growthSample
+	Capped []bool
+	Error  error
+	Rearm  bool // see QueueSampleMsg.Rearm
+}
+
+// GrowthTickMsg fires every Interval while the monitor is active.
+type GrowthTickMsg struct{ Gen int }
+
+func (m Model) startGrowthForm() (tea.Model, tea.Cmd) {
+	alert := m.Config.GrowthAlert
+	if alert == 0 {
+		alert = defaultGrowthAlert
+	}
+	m.Form = NewForm(
+		"GROWTH · keyspace growth monitor",
+		[]string{"key prefixes to count (comma-separated, blank = DBSIZE only)", "sample every (seconds)", "alert above (keys/min, 0 = off)"},
+		[]string{"", "5", strconv.Itoa(alert)},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitGrowthForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	var labels, patterns []string
+	for _, p := range strings.Split(values[0], ",") {
+		p = strings.TrimSpace(p)
+		switch {
+		case p == "":
+			continue
+		case strings.ContainsAny(p, "*?["):
+			labels, patterns = append(labels, p), append(patterns, p)
+		default:
+			labels, patterns = append(labels, p+"*"), append(patterns, globEscape(p)+"*")
+		}
+	}
+	if len(patterns) > growthMaxPrefixes {
+		m.Form.Err = fmt.Sprintf("count at most %d prefixes; each is a SCAN on every sample", growthMaxPrefixes)
+		return m, nil
+	}
+	secs, err := strconv.Atoi(values[1])
+	if err != nil || secs <= 0 {
+		m.Form.Err = "sample interval must be a positive number of seconds"
+		return m, nil
+	}
+	alert, err := strconv.Atoi(values[2])
+	if err != nil || alert < 0 {
+		m.Form.Err = "alert limit must be a whole number of keys per minute, 0 for none"
+		return m, nil
+	}
+
+	m.Form.Err = ""
+	m.Growth = GrowthModel{
+		Labels:   labels,
+		Patterns: patterns,
+		Interval: time.Duration(secs) * time.Second,
+		Alert:    float64(alert),
+		Started:  time.Now(),
+		Active:   true,
+		Gen:      m.Growth.Gen + 1,
+	}
+	m.pushState(StateForm)
+	return m.switchToLoadingAndExecute(sampleGrowth(m.Conn, m.Reader, patterns, m.scanCountMax(), m.Growth.Gen, true))
+}
+
+func growthTick(gen int, every time.Duration) tea.Cmd {
+	return tea.Tick(every, func(time.Time) tea.Msg { return GrowthTickMsg{Gen: gen} })
+}
+
+// sampleGrowth reads DBSIZE and counts the keys matching each pattern.
+func sampleGrowth(conn net.Conn, reader *bufio.Reader, patterns []string, scanCount, gen int, rearm bool) tea.Cmd {
+	return func() tea.Msg {
+		msg := GrowthSampleMsg{Gen: gen, Rearm: rearm}
+		if conn == nil {
+			msg.Error = fmt.Errorf("no connection to Redis")
+			return msg
+		}
+		resp, err := readResp(conn, reader, redis.RedisCmd{Name: "DBSIZE"})
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		size, ok := resp.(int)
+		if !ok {
+			msg.Error = fmt.Errorf("DBSIZE: %v", resp)
+			return msg
+		}
+		counts, capped := []int{size}, []bool{false}
+		for _, p := range patterns {
+			n, full, err := countKeys(conn, reader, p, growthCountCap, scanCount)
+			if err != nil {
+				msg.Error = err
+				return msg
+			}
+			counts, capped = append(counts, n), append(capped, full)
+		}
+		msg.Sample = growthSample{At: time.Now(), Counts: counts}
+		msg.Capped = capped
+		return msg
+	}
+}
+
+// countKeys counts the keys matching pattern, up to limit; capped reports
+// that it stopped there.
+func countKeys(conn net.Conn, reader *bufio.Reader, pattern string, limit, ceiling int) (n int, capped bool, err error) {
+	cursor := "0"
+	count := firstScanCount(ceiling)
+	for {
+		start := time.Now()
+		resp, err := readResp(conn, reader, redis.Scan(cursor, pattern, count))
+		if err != nil {
+			return 0, false, err
+		}
+		count = nextScanCount(count, ceiling, time.Since(start))
+		var batch []string
+		cursor, batch, err = scanPage(resp)
+		if err != nil {
+			return 0, false, err
+		}
+		n += len(batch)
+		if n >= limit {
+			return limit, true, nil
+		}
+		if cursor == "0" || cursor == "" {
+			return n, false, nil
+		}
+	}
+}
+
+func handleGrowthSample(m Model, msg GrowthSampleMsg) (tea.Model, tea.Cmd) {
+	g := &m.Growth
+	if !g.Active || msg.Gen != g.Gen {
+		return m, nil
+	}
+	var next tea.Cmd
+	if msg.Rearm {
+		next = growthTick(g.Gen, g.Interval)
+	}
+
+	if msg.Error != nil {
+		if m.CurrentState == StateLoading {
+			g.Active = false
+			m.Result = errorResult(msg.Error)
+			m.CurrentState = StateOutput
+			return m, nil
+		}
+		g.Err = msg.Error.Error()
+		return m, next
+	}
+
+	g.Err = ""
+	g.Samples = append(g.Samples, msg.Sample)
+	if len(g.Samples) > growthHistory {
+		g.Samples = g.Samples[len(g.Samples)-growthHistory:]
+	}
+	g.Capped = msg.Capped
+	if r, ok := g.rate(0); ok && r > g.Peak {
+		g.Peak, g.PeakAt = r, msg.Sample.At
+	}
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateGrowth
+	}
+	return m, next
+}
+
+// handleGrowthTick samples only while the monitor is on screen, as
+// handleQueueTick does.
+func handleGrowthTick(m Model, msg GrowthTickMsg) (tea.Model, tea.Cmd) {
+	g := m.Growth
+	if !g.Active || msg.Gen != g.Gen {
+		return m, nil
+	}
+	if m.CurrentState != StateGrowth {
+		return m, growthTick(g.Gen, g.Interval)
+	}
+	return m, m.exec(sampleGrowth(m.Conn, m.Reader, g.Patterns, m.scanCountMax(), g.Gen, true))
+}
+
+// rate is row i's growth in keys per minute, from the oldest sample within
+// the last growthRateWindow to the newest. ok is false until there are two.
+func (g GrowthModel) rate(i int) (perMin float64, ok bool) {
+	if len(g.Samples) < 2 {
+		return 0, false
+	}
+	last := g.Samples[len(g.Samples)-1]
+	from := last
+	for _, s := range g.Samples {
+		if last.At.Sub(s.At) <= growthRateWindow {
+			from = s
+			break
+		}
+	}
+	if from.At.Equal(last.At) {
+		from = g.Samples[len(g.Samples)-2]
+	}
+	elapsed := last.At.Sub(from.At).Minutes()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	return float64(last.Counts[i]-from.Counts[i]) / elapsed, true
+}
+
+// alerting reports whether the keyspace is growing faster than the limit.
+func (g GrowthModel) alerting() bool {
+	r, ok := g.rate(0)
+	return ok && g.Alert > 0 && r > g.Alert
+}
+
+func handleStateGrowthKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	g := &m.Growth
+	switch keyMsg.String() {
+	case "esc":
+		g.Active = false
+		m.CurrentState = m.popState()
+	case "r":
+		return m, m.exec(sampleGrowth(m.Conn, m.Reader, g.Patterns, m.scanCountMax(), g.Gen, false))
+	case "c":
+		// Measure from now: the baseline before a deploy starts.
+		g.Samples, g.Peak, g.PeakAt = nil, 0, time.Time{}
+		g.Started = time.Now()
+		return m, m.exec(sampleGrowth(m.Conn, m.Reader, g.Patterns, m.scanCountMax(), g.Gen, false))
+	}
+	return m, nil
+}
+
+// formatRate renders a keys/min rate with its sign.
+func formatRate(r float64) string {
+	if r >= 0 {
+		return fmt.Sprintf("+%.0f", r)
+	}
+	return fmt.Sprintf("%.0f", r)
+}
+
+func (m Model) growthView() string {
+	g := m.Growth
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	purple := lipgloss.NewStyle().Foreground(lipgloss.Color(tnPurple))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+	alerting := g.alerting()
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnInfo)).Bold(true).Render("GROWTH") +
+		dim.Render(fmt.Sprintf(" · db%d · every %s · since %s", m.DB, g.Interval, g.Started.Format("15:04:05")))
+	if n := len(g.Samples); n > 0 {
+		title += dim.Render("   sampled " + g.Samples[n-1].At.Format("15:04:05"))
+	}
+	if g.Err != "" {
+		title += "   " + red.Render("✗ "+g.Err)
+	}
+	limit := "no alert"
+	if g.Alert > 0 {
+		limit = fmt.Sprintf("alert above %.0f keys/min", g.Alert)
+	}
+	if !g.PeakAt.IsZero() {
+		limit += fmt.Sprintf(" · peak %s/min at %s", formatRate(g.Peak), g.PeakAt.Format("15:04:05"))
+	}
+
+	head := "  " + dim.Render(fmt.Sprintf("%-*s %9s %10s   %s", growthNameCol, "keys", "count", "keys/min", "trend"))
+	rows := []string{head}
+	for i, label := range append([]string{"all (DBSIZE)"}, g.Labels...) {
+		var hist []float64
+		for _, s := range g.Samples {
+			hist = append(hist, float64(s.Counts[i]))
+		}
+		count := "—"
+		if len(hist) > 0 {
+			count = strconv.Itoa(int(hist[len(hist)-1]))
+			if i < len(g.Capped) && g.Capped[i] {
+				count = "≥" + count
+			}
+		}
+		rate := "—"
+		if r, ok := g.rate(i); ok {
+			rate = formatRate(r)
+		}
+		rateView := text.Render(fmt.Sprintf("%10s", rate))
+		if i == 0 && alerting {
+			rateView = red.Bold(true).Render(fmt.Sprintf("%10s", rate))
+		}
+		spark := sparkline(hist, growthSparkCol)
+		rows = append(rows, "  "+text.Render(padCells(truncateText(label, growthNameCol), growthNameCol))+" "+
+			text.Render(fmt.Sprintf("%9s", count))+" "+rateView+"   "+purple.Render(spark))
+	}
+
+	body := "  " + title + "\n  " + subtle.Render(limit) + "\n\n" + strings.Join(rows, "\n")
+	if alerting {
+		r, _ := g.rate(0)
+		body += "\n\n  " + red.Bold(true).Render(fmt.Sprintf("▲ the keyspace is growing %.0f keys/min, over the %.0f limit", r, g.Alert))
+	}
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(growthKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
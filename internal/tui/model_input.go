@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 type InputType int
@@ -33,7 +35,11 @@ type InputModel struct {
 	Hint           string // optional override for the prompt label
 	Width          int
 	Height         int
-	RecentPatterns []string // populated by the parent model; shown in scan pattern view
+	RecentPatterns []string    // populated by the parent model; shown in scan pattern view
+	Number         *NumberSpec // the prompt takes a number, checked on enter; see numeric.go
+	Multi          bool        // the prompt takes several elements; see multivalue.go
+	Commas         bool        // ctrl+o: a Multi prompt splits lines at commas too
+	Err            string      // why the last enter was refused, shown under the input
 }
 
 func (m InputModel) Update(msg tea.Msg) (InputModel, tea.Cmd) {
@@ -41,12 +47,40 @@ func (m InputModel) Update(msg tea.Msg) (InputModel, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.Err = ""
 		switch msg.String() {
 		case "esc":
+			m.Commas = false
 			return m, func() tea.Msg { return BackMsg{} }
+		case "ctrl+o":
+			if m.Multi {
+				m.Commas = !m.Commas
+				return m, nil
+			}
+		case "ctrl+j", "alt+enter":
+			// Enter submits, so elements go on new lines with these.
+			if m.Multi {
+				m.Input.InsertString("\n")
+				return m, nil
+			}
 		case "enter":
+			value := m.Input.Value()
+			if m.Multi && m.Commas {
+				value = commaValues(value)
+			}
+			m.Commas = false
+			if m.Number != nil {
+				n, err := ParseNumber(*m.Number, value)
+				switch {
+				case err == nil:
+					value = n
+				case !m.Number.Optional:
+					m.Err = err.Error()
+					return m, nil
+				}
+			}
 			return m, func() tea.Msg {
-				return InputCompleteMsg{Value: m.Input.Value(), Type: m.Type}
+				return InputCompleteMsg{Value: value, Type: m.Type}
 			}
 		case "tab":
 			// Filesystem path completion on the import/export prompts.
@@ -148,7 +182,7 @@ func (m InputModel) View() string {
 	// so only the value prompt grows to fill the window.
 	const reservedLines = 5
 	h := 1
-	if m.Type == InputValue {
+	if m.Type == InputValue && (m.Number == nil || m.Number.Optional) {
 		h = 3
 		if m.Height > reservedLines {
 			h = m.Height - reservedLines
@@ -192,6 +226,19 @@ func (m InputModel) View() string {
 	foot := footerSep(m.Width) + "\n  " + hm.View(keys)
 
 	titleView := "  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Render(title)
+	if m.Multi {
+		n := countValues(m.Input.Value(), m.Commas)
+		noun := "elements"
+		if n == 1 {
+			noun = "element"
+		}
+		commas := "off"
+		if m.Commas {
+			commas = "on, \\, for a literal comma"
+		}
+		titleView += "\n  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim)).
+			Render(fmt.Sprintf("one per line (ctrl+j) · split on commas (ctrl+o): %s · %d %s", commas, n, noun))
+	}
 
 	var body string
 	if m.Type == InputPattern {
@@ -199,6 +246,14 @@ func (m InputModel) View() string {
 	} else {
 		body = titleView + "\n" + indentLines(m.Input.View(), 2)
 	}
+	if m.Err != "" {
+		body += "\n  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render("✗ "+m.Err)
+	} else if m.Number != nil && m.Number.Bases {
+		// What enter will send, and the other bases, as the number is typed.
+		if n, err := ParseNumber(*m.Number, m.Input.Value()); err == nil {
+			body += "\n  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim)).Render(strings.TrimSuffix("sends "+n+" · "+baseNote(n), " · "))
+		}
+	}
 	// m.Height is the full window; subtract the 2-line connection header.
 	return bottomFooter(body, foot, m.Height-2)
 }
@@ -209,6 +264,22 @@ func indentLines(s string, n int) string {
 	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
 }
 
+// truncateText shortens s to at most n terminal cells, marking the cut with
+// "…". Widths come from runewidth, so CJK and emoji count as two cells and a
+// wide character that would straddle the limit is dropped whole.
+func truncateText(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	return runewidth.Truncate(s, n, "…")
+}
+
+// padCells truncates s to n cells and pads it with spaces to exactly n, for
+// fixed-width columns; fmt's %-*s pads by runes and misaligns wide text.
+func padCells(s string, n int) string {
+	return runewidth.FillRight(truncateText(s, n), n)
+}
+
 var (
 	patternExampleKeyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true)
 	patternSectionStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
@@ -261,3 +332,16 @@ func prependPattern(patterns []string, p string) []string {
 	}
 	return out
 }
+
+// updateInput runs the key, field, value and file path prompts.
+func updateInput(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.Input.Number, m.Input.Multi = m.numberInput(), m.multiInput()
+	inputModel, cmd := m.Input.Update(msg)
+	m.Input = inputModel
+	return m, cmd
+}
+
+func (m Model) inputView() string {
+	m.Input.Number, m.Input.Multi = m.numberInput(), m.multiInput()
+	return m.headerView() + "\n" + m.Input.View()
+}
@@ -0,0 +1,200 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// `m` in the key list opens a popup over it listing everything that can be
+// done to the selected key, each with its own key where it has one, so the
+// actions stay findable as the letters run out.
+
+// keyAction is one entry of the key menu.
+type keyAction struct {
+	Label string
+	Key   string // runs it from the menu without moving there; "" for none
+	run   func(m Model, key string) (tea.Model, tea.Cmd)
+}
+
+// KeyMenuModel is the popup for one key.
+type KeyMenuModel struct {
+	Key     string
+	Actions []keyAction
+	Cursor  int
+}
+
+// KeyMenuRequestMsg asks for the key menu of Key.
+type KeyMenuRequestMsg struct{ Key string }
+
+// keyActions lists what can be done to key: the browser's own actions, the
+// ones otherwise reached from the main menu, then the macros for it.
+func (m Model) keyActions(key string) []keyAction {
+	meta, known := m.Browser.Meta[key]
+	actions := []keyAction{
+		{"open", "↵", func(m Model, key string) (tea.Model, tea.Cmd) {
+			m.CurrentState = m.popState()
+			return m.Update(SelectKeyMsg{Key: key})
+		}},
+	}
+	if !known || meta.Type == "string" {
+		actions = append(actions, keyAction{"edit value", "e", func(m Model, key string) (tea.Model, tea.Cmd) {
+			m.CurrentState = m.popState()
+			m.EditOnOpen = true
+			return m.Update(SelectKeyMsg{Key: key})
+		}})
+	}
+	actions = append(actions,
+		keyAction{"rename", "r", func(m Model, key string) (tea.Model, tea.Cmd) {
+			m.CurrentState = m.popState()
+			return m.Update(RenameRequestMsg{Key: key})
+		}},
+		keyAction{"copy name", "c", func(m Model, key string) (tea.Model, tea.Cmd) {
+			m.CurrentState = m.popState()
+			if err := clipboard.WriteAll(key); err != nil {
+				return m, m.notify(clipboardErrorHint(), true)
+			}
+			return m, m.notify("Copied "+key, false)
+		}},
+		keyAction{"set TTL", "x", func(m Model, key string) (tea.Model, tea.Cmd) {
+			m.CurrentState = m.popState()
+			m.ActiveKey = key
+			m.PromptOp = m.SelectedOp
+			m.SelectedOp = OpExpirySet
+			m.Input.Input.SetValue("")
+			m.Input.Type = InputValue
+			m.Input.Hint = "TTL in seconds (enter 0 to remove expiry / PERSIST):"
+			m.Input.Input.Focus()
+			m.pushState(m.CurrentState)
+			m.CurrentState = StateInputValue
+			return m, nil
+		}},
+		keyAction{"delete", "d", func(m Model, key string) (tea.Model, tea.Cmd) {
+			m.CurrentState = m.popState()
+			return m.Update(DeleteRequestMsg{Key: key})
+		}},
+		keyAction{"export to file", "w", func(m Model, key string) (tea.Model, tea.Cmd) {
+			m.CurrentState = m.popState()
+			return m.promptKeyExport(key), nil
+		}},
+		keyAction{"watch events", "t", func(m Model, key string) (tea.Model, tea.Cmd) {
+			m.CurrentState = m.popState()
+			m.pushState(m.CurrentState)
+			m.SelectedOp = OpTimeline
+			return m.startTimeline(globEscape(key))
+		}},
+	)
+	if !m.ReadOnly && (!known || meta.Type == "string" || meta.Type == "hash") {
+		actions = append(actions, keyAction{"convert JSON ↔ hash", "v", func(m Model, key string) (tea.Model, tea.Cmd) {
+			m.CurrentState = m.popState()
+			m.pushState(m.CurrentState)
+			return m.startConvertForm(key)
+		}})
+	}
+	for _, mc := range m.Config.Macros {
+		if mc.Keys == "" || redis.GlobMatch(mc.Keys, key) {
+			actions = append(actions, keyAction{"macro: " + mc.Name, "", func(m Model, key string) (tea.Model, tea.Cmd) {
+				// n goes back to the menu, like it does to the picker
+				m.Macros = MacrosModel{Key: key, Items: []Macro{mc}}
+				return m.confirmMacro(mc)
+			}})
+		}
+	}
+	return actions
+}
+
+func (m Model) startKeyMenu(key string) (tea.Model, tea.Cmd) {
+	m.ActiveKey = key
+	m.KeyMenu = KeyMenuModel{Key: key, Actions: m.keyActions(key)}
+	m.pushState(m.CurrentState)
+	m.CurrentState = StateKeyMenu
+	return m, nil
+}
+
+func handleStateKeyMenuKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	km := &m.KeyMenu
+	switch k := keyMsg.String(); k {
+	case "esc", "m", "q":
+		m.CurrentState = m.popState()
+	case "up", "k":
+		if km.Cursor > 0 {
+			km.Cursor--
+		}
+	case "down", "j":
+		if km.Cursor < len(km.Actions)-1 {
+			km.Cursor++
+		}
+	case "enter":
+		if km.Cursor < len(km.Actions) {
+			return km.Actions[km.Cursor].run(m, km.Key)
+		}
+	default:
+		for _, a := range km.Actions {
+			if a.Key == k {
+				return a.run(m, km.Key)
+			}
+		}
+	}
+	return m, nil
+}
+
+// keyMenuView draws the popup over the browser, centred.
+func (m Model) keyMenuView() string {
+	frame := m.headerView() + "\n" + m.Browser.View()
+
+	km := m.KeyMenu
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+	width := 28
+	for _, a := range km.Actions {
+		width = max(width, lipgloss.Width(a.Label)+8)
+	}
+	rows := []string{accent.Bold(true).Render(truncateText(km.Key, width)), ""}
+	for i, a := range km.Actions {
+		marker := "  "
+		if i == km.Cursor {
+			marker = accent.Render("▌ ")
+		}
+		gap := strings.Repeat(" ", max(width-2-lipgloss.Width(a.Label)-lipgloss.Width(a.Key), 1))
+		rows = append(rows, marker+text.Render(a.Label)+gap+dim.Render(a.Key))
+	}
+	rows = append(rows, "", dim.Render("↵ run · esc close"))
+	return m.overlay(frame, strings.Join(rows, "\n"))
+}
+
+// overlay draws content in a bordered box centred over frame.
+func (m Model) overlay(frame, content string) string {
+	box := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(tnBorder)).
+		Padding(0, 1).Render(content)
+
+	w, h := m.WindowWidth, m.WindowHeight
+	if w <= 0 {
+		w = 80
+	}
+	if h <= 0 {
+		h = 24
+	}
+	lines := strings.Split(frame, "\n")
+	for len(lines) < h {
+		lines = append(lines, "")
+	}
+	boxLines := strings.Split(box, "\n")
+	left := max((w-lipgloss.Width(box))/2, 0)
+	top := max((h-len(boxLines))/2, 0)
+	for i, bl := range boxLines {
+		if top+i >= len(lines) {
+			break
+		}
+		row := lines[top+i]
+		before := ansi.Truncate(row, left, "")
+		after := ansi.TruncateLeft(row, left+lipgloss.Width(bl), "")
+		lines[top+i] = before + strings.Repeat(" ", left-lipgloss.Width(before)) + bl + after
+	}
+	return strings.Join(lines, "\n")
+}
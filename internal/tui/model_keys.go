@@ -25,22 +25,26 @@ func NewHelp() help.Model {
 
 // menuKeyMap — main command launcher.
 type menuKeyMap struct {
-	Run    key.Binding
-	Filter key.Binding
-	Quit   key.Binding
+	Run     key.Binding
+	Jump    key.Binding
+	Filter  key.Binding
+	Console key.Binding // vim_keys only
+	Quit    key.Binding
 }
 
 func (k menuKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Run, k.Filter, k.Quit}
+	return []key.Binding{k.Run, k.Jump, k.Filter, k.Console, k.Quit}
 }
 func (k menuKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Run, k.Filter, k.Quit}}
+	return [][]key.Binding{{k.Run, k.Jump, k.Filter, k.Console, k.Quit}}
 }
 
 var menuKeys = menuKeyMap{
-	Run:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "run")),
-	Filter: key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
-	Quit:   key.NewBinding(key.WithKeys("q", "esc"), key.WithHelp("q", "quit")),
+	Run:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "run")),
+	Jump:    key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "run nth")),
+	Filter:  key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+	Console: key.NewBinding(key.WithKeys(":"), key.WithHelp(":", "console")),
+	Quit:    key.NewBinding(key.WithKeys("q", "esc"), key.WithHelp("q", "quit")),
 }
 
 // browserKeyMap — key list (not viewing fields).
@@ -50,15 +54,20 @@ type browserKeyMap struct {
 	Delete  key.Binding
 	Rename  key.Binding
 	More    key.Binding
+	Save    key.Binding
+	Macros  key.Binding
+	Menu    key.Binding
+	New     key.Binding
+	Saved   key.Binding
 	Refresh key.Binding
 	Back    key.Binding
 }
 
 func (k browserKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Open, k.Filter, k.Delete, k.Rename, k.Back}
+	return []key.Binding{k.Open, k.Filter, k.Menu, k.Delete, k.Rename, k.Back}
 }
 func (k browserKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Open, k.Filter, k.Delete}, {k.Rename, k.More}, {k.Refresh, k.Back}}
+	return [][]key.Binding{{k.Open, k.Filter, k.Saved, k.Menu}, {k.New, k.Delete, k.Rename}, {k.More, k.Save, k.Macros}, {k.Refresh, k.Back}}
 }
 
 var browserKeys = browserKeyMap{
@@ -67,6 +76,11 @@ var browserKeys = browserKeyMap{
 	Delete:  key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
 	Rename:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "rename")),
 	More:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "load more")),
+	Save:    key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "save list")),
+	Macros:  key.NewBinding(key.WithKeys("!"), key.WithHelp("!", "macros")),
+	Menu:    key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "actions")),
+	New:     key.NewBinding(key.WithKeys("+"), key.WithHelp("+", "new key")),
+	Saved:   key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "saved searches")),
 	Refresh: key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "refresh")),
 	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
 }
@@ -74,13 +88,17 @@ var browserKeys = browserKeyMap{
 // hashFieldsKeyMap — fields browser for hash keys (includes 'a' to add a field).
 type hashFieldsKeyMap struct {
 	Open    key.Binding
+	Full    key.Binding
 	Filter  key.Binding
 	Add     key.Binding
 	Delete  key.Binding
 	Export  key.Binding
 	Import  key.Binding
+	Sample  key.Binding
 	More    key.Binding
+	Save    key.Binding
 	Refresh key.Binding
+	Live    key.Binding
 	Back    key.Binding
 }
 
@@ -88,31 +106,41 @@ func (k hashFieldsKeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{k.Open, k.Filter, k.Add, k.Delete, k.Export, k.Import, k.Back}
 }
 func (k hashFieldsKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Open, k.Filter, k.Add, k.Delete}, {k.Export, k.Import, k.More}, {k.Refresh, k.Back}}
+	return [][]key.Binding{{k.Open, k.Full, k.Filter, k.Add, k.Delete}, {k.Export, k.Import, k.Sample, k.More, k.Save}, {k.Refresh, k.Live, k.Back}}
 }
 
 var hashFieldsKeys = hashFieldsKeyMap{
 	Open:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "open/edit")),
+	Full:    key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "show full")),
 	Filter:  key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
 	Add:     key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add field")),
 	Delete:  key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
 	Export:  key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "export")),
 	Import:  key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "import")),
+	Sample:  key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "random sample")),
 	More:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "load more")),
+	Save:    key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "save list")),
 	Refresh: key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "refresh")),
+	Live:    key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "live refresh")),
 	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back to keys")),
 }
 
 // otherFieldsKeyMap — fields browser for list / set / zset (add supported).
 type otherFieldsKeyMap struct {
 	Open    key.Binding
+	Full    key.Binding
 	Filter  key.Binding
+	Sort    key.Binding // sorted sets only
+	Dates   key.Binding // sorted sets only
 	Add     key.Binding
 	Delete  key.Binding
 	Export  key.Binding
 	Import  key.Binding
+	Sample  key.Binding
 	More    key.Binding
+	Save    key.Binding
 	Refresh key.Binding
+	Live    key.Binding
 	Back    key.Binding
 }
 
@@ -120,18 +148,24 @@ func (k otherFieldsKeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{k.Open, k.Filter, k.Add, k.Delete, k.Export, k.Import, k.Back}
 }
 func (k otherFieldsKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Open, k.Filter, k.Add, k.Delete}, {k.Export, k.Import, k.More}, {k.Refresh, k.Back}}
+	return [][]key.Binding{{k.Open, k.Full, k.Filter, k.Sort, k.Dates, k.Add, k.Delete}, {k.Export, k.Import, k.Sample, k.More, k.Save}, {k.Refresh, k.Live, k.Back}}
 }
 
 var otherFieldsKeys = otherFieldsKeyMap{
 	Open:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "open")),
+	Full:    key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "show full")),
 	Filter:  key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+	Sort:    key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "sort score/member")),
+	Dates:   key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "dates on/off")),
 	Add:     key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add")),
 	Delete:  key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
 	Export:  key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "export")),
 	Import:  key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "import")),
+	Sample:  key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "random sample")),
 	More:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "load more")),
+	Save:    key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "save list")),
 	Refresh: key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "refresh")),
+	Live:    key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "live refresh")),
 	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back to keys")),
 }
 
@@ -140,7 +174,13 @@ type outputKeyMap struct {
 	Scroll key.Binding
 	Edit   key.Binding
 	Copy   key.Binding
+	Save   key.Binding
 	TTL    key.Binding
+	Dates  key.Binding
+	Sizes  key.Binding
+	View   key.Binding
+	Open   key.Binding
+	Live   key.Binding
 	Back   key.Binding
 }
 
@@ -148,14 +188,20 @@ func (k outputKeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{k.Scroll, k.Copy, k.Edit, k.TTL, k.Back}
 }
 func (k outputKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Scroll, k.Copy, k.Edit, k.TTL, k.Back}}
+	return [][]key.Binding{{k.Scroll, k.Copy, k.Save, k.Edit, k.TTL, k.Dates, k.Sizes, k.View, k.Open, k.Live, k.Back}}
 }
 
 var outputKeys = outputKeyMap{
 	Scroll: key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "scroll")),
 	Edit:   key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit")),
 	Copy:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy")),
+	Save:   key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "save to file")),
 	TTL:    key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "ttl")),
+	Dates:  key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "dates on/off")),
+	Sizes:  key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "exact bytes")),
+	View:   key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "next renderer")),
+	Open:   key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open url")),
+	Live:   key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "live refresh")),
 	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
 }
 
@@ -165,7 +211,10 @@ var outputKeys = outputKeyMap{
 type memberOutputKeyMap struct {
 	Scroll key.Binding
 	Copy   key.Binding
+	Save   key.Binding
 	TTL    key.Binding
+	View   key.Binding
+	Open   key.Binding
 	Back   key.Binding
 }
 
@@ -173,31 +222,94 @@ func (k memberOutputKeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{k.Scroll, k.Copy, k.TTL, k.Back}
 }
 func (k memberOutputKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Scroll, k.Copy, k.TTL, k.Back}}
+	return [][]key.Binding{{k.Scroll, k.Copy, k.Save, k.TTL, k.View, k.Open, k.Back}}
 }
 
 var memberOutputKeys = memberOutputKeyMap{
 	Scroll: key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "scroll")),
 	Copy:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy")),
+	Save:   key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "save to file")),
 	TTL:    key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "ttl")),
+	View:   key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "next renderer")),
+	Open:   key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open url")),
+	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// hashTableOutputKeyMap — a hash opened as a table by a display rule.
+type hashTableOutputKeyMap struct {
+	Scroll key.Binding
+	Fields key.Binding
+	View   key.Binding
+	Copy   key.Binding
+	Save   key.Binding
+	Back   key.Binding
+}
+
+func (k hashTableOutputKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Scroll, k.Fields, k.View, k.Copy, k.Back}
+}
+func (k hashTableOutputKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Scroll, k.Fields, k.View, k.Copy, k.Save, k.Back}}
+}
+
+var hashTableOutputKeys = hashTableOutputKeyMap{
+	Scroll: key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "scroll")),
+	Fields: key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "field list")),
+	View:   key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "next renderer")),
+	Copy:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy")),
+	Save:   key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "save to file")),
 	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
 }
 
+// sessionOutputKeyMap — a hash opened as a session by a sessions rule.
+type sessionOutputKeyMap struct {
+	Scroll     key.Binding
+	Fields     key.Binding
+	Invalidate key.Binding
+	View       key.Binding
+	Copy       key.Binding
+	Save       key.Binding
+	Back       key.Binding
+}
+
+func (k sessionOutputKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Scroll, k.Fields, k.Invalidate, k.Copy, k.Back}
+}
+func (k sessionOutputKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Scroll, k.Fields, k.Invalidate, k.View, k.Copy, k.Save, k.Back}}
+}
+
+var sessionOutputKeys = sessionOutputKeyMap{
+	Scroll:     key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "scroll")),
+	Fields:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "field list")),
+	Invalidate: key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "invalidate")),
+	View:       key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "next renderer")),
+	Copy:       key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy")),
+	Save:       key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "save to file")),
+	Back:       key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
 // infoOutputKeyMap — server INFO screen (read-only, no edit/ttl).
 type infoOutputKeyMap struct {
 	Scroll key.Binding
 	Copy   key.Binding
+	Save   key.Binding
+	Sizes  key.Binding
 	Back   key.Binding
 }
 
-func (k infoOutputKeyMap) ShortHelp() []key.Binding { return []key.Binding{k.Scroll, k.Copy, k.Back} }
+func (k infoOutputKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Scroll, k.Copy, k.Save, k.Sizes, k.Back}
+}
 func (k infoOutputKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Scroll, k.Copy, k.Back}}
+	return [][]key.Binding{{k.Scroll, k.Copy, k.Save, k.Sizes, k.Back}}
 }
 
 var infoOutputKeys = infoOutputKeyMap{
 	Scroll: key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "scroll")),
 	Copy:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy")),
+	Save:   key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "save to file")),
+	Sizes:  key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "exact bytes")),
 	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
 }
 
@@ -236,3 +348,664 @@ var filePathKeys = filePathKeyMap{
 	Complete: key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "complete path")),
 	Back:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
 }
+
+// formKeyMap — multi-field tool forms.
+type formKeyMap struct {
+	Next   key.Binding
+	Submit key.Binding
+	Back   key.Binding
+}
+
+func (k formKeyMap) ShortHelp() []key.Binding { return []key.Binding{k.Next, k.Submit, k.Back} }
+func (k formKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Next, k.Submit, k.Back}}
+}
+
+var formKeys = formKeyMap{
+	Next:   key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next field")),
+	Submit: key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "next / submit")),
+	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+}
+
+// blockingKeyMap — BLPOP/BRPOP watcher.
+type blockingKeyMap struct {
+	Pause key.Binding
+	Clear key.Binding
+	Back  key.Binding
+}
+
+func (k blockingKeyMap) ShortHelp() []key.Binding { return []key.Binding{k.Pause, k.Clear, k.Back} }
+func (k blockingKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Pause, k.Clear, k.Back}}
+}
+
+var blockingKeys = blockingKeyMap{
+	Pause: key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pause/resume")),
+	Clear: key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "clear log")),
+	Back:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "stop & return")),
+}
+
+// tailKeyMap — live tail of a stream.
+type tailKeyMap struct {
+	Add    key.Binding
+	Groups key.Binding
+	Pause  key.Binding
+	Scroll key.Binding
+	Ends   key.Binding
+	Buffer key.Binding
+	Clear  key.Binding
+	Back   key.Binding
+}
+
+func (k tailKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Add, k.Groups, k.Pause, k.Scroll, k.Ends, k.Buffer, k.Clear, k.Back}
+}
+func (k tailKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Add, k.Groups, k.Pause, k.Scroll, k.Ends, k.Buffer, k.Clear, k.Back}}
+}
+
+var tailKeys = tailKeyMap{
+	Add:    key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add entry")),
+	Groups: key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "consumer groups")),
+	Pause:  key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pause/resume")),
+	Scroll: key.NewBinding(key.WithKeys("up", "down", "pgup", "pgdown"), key.WithHelp("↑↓/pgup/pgdn", "scroll")),
+	Ends:   key.NewBinding(key.WithKeys("home", "end"), key.WithHelp("home/end", "oldest/follow")),
+	Buffer: key.NewBinding(key.WithKeys("+", "-"), key.WithHelp("+/-", "buffer size")),
+	Clear:  key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "clear")),
+	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "stop & return")),
+}
+
+// groupsKeyMap — consumer groups of a stream.
+type groupsKeyMap struct {
+	Move    key.Binding
+	Pending key.Binding
+	Create  key.Binding
+	SetID   key.Binding
+	Destroy key.Binding
+	Claim   key.Binding
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+func (k groupsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Pending, k.Create, k.SetID, k.Destroy, k.Claim, k.Refresh, k.Back}
+}
+func (k groupsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Pending, k.Create, k.SetID, k.Destroy, k.Claim, k.Refresh, k.Back}}
+}
+
+var groupsKeys = groupsKeyMap{
+	Move:    key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "select")),
+	Pending: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "pending")),
+	Create:  key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "create")),
+	SetID:   key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "set ID")),
+	Destroy: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "destroy")),
+	Claim:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "autoclaim")),
+	Refresh: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+}
+
+// pendingKeyMap — pending entries of a consumer group.
+type pendingKeyMap struct {
+	Move    key.Binding
+	Mark    key.Binding
+	All     key.Binding
+	Ack     key.Binding
+	Claim   key.Binding
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+func (k pendingKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Mark, k.All, k.Ack, k.Claim, k.Refresh, k.Back}
+}
+func (k pendingKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Mark, k.All, k.Ack, k.Claim, k.Refresh, k.Back}}
+}
+
+var pendingKeys = pendingKeyMap{
+	Move:    key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "select")),
+	Mark:    key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "mark")),
+	All:     key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "mark all")),
+	Ack:     key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "XACK")),
+	Claim:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "XCLAIM")),
+	Refresh: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+}
+
+// queuesKeyMap — job queue dashboard.
+type queuesKeyMap struct {
+	Move    key.Binding
+	Push    key.Binding
+	Pop     key.Binding
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+func (k queuesKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Push, k.Pop, k.Refresh, k.Back}
+}
+func (k queuesKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Push, k.Pop, k.Refresh, k.Back}}
+}
+
+var queuesKeys = queuesKeyMap{
+	Move:    key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "select")),
+	Push:    key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "push")),
+	Pop:     key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "pop oldest")),
+	Refresh: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "rescan keys")),
+	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// growthKeyMap — keyspace growth monitor.
+type growthKeyMap struct {
+	Refresh key.Binding
+	Reset   key.Binding
+	Back    key.Binding
+}
+
+func (k growthKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Refresh, k.Reset, k.Back}
+}
+func (k growthKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Refresh, k.Reset, k.Back}}
+}
+
+var growthKeys = growthKeyMap{
+	Refresh: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "sample now")),
+	Reset:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "restart from now")),
+	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// coldKeysKeyMap — cold key report.
+type coldKeysKeyMap struct {
+	Move    key.Binding
+	Delete  key.Binding
+	Expire  key.Binding
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+func (k coldKeysKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Delete, k.Expire, k.Refresh, k.Back}
+}
+func (k coldKeysKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Delete, k.Expire, k.Refresh, k.Back}}
+}
+
+var coldKeysKeys = coldKeysKeyMap{
+	Move:    key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "scroll")),
+	Delete:  key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete all listed")),
+	Expire:  key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "expire all listed")),
+	Refresh: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "read again")),
+	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// countersKeyMap — counter / rate-limit inspector.
+type countersKeyMap struct {
+	Move    key.Binding
+	Sort    key.Binding
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+func (k countersKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Sort, k.Refresh, k.Back}
+}
+func (k countersKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Sort, k.Refresh, k.Back}}
+}
+
+var countersKeys = countersKeyMap{
+	Move:    key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "scroll")),
+	Sort:    key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "sort key/value")),
+	Refresh: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh now")),
+	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// flagsKeyMap — feature flag list.
+type flagsKeyMap struct {
+	Move    key.Binding
+	Toggle  key.Binding
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+func (k flagsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Toggle, k.Refresh, k.Back}
+}
+func (k flagsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Toggle, k.Refresh, k.Back}}
+}
+
+var flagsKeys = flagsKeyMap{
+	Move:    key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "select")),
+	Toggle:  key.NewBinding(key.WithKeys(" ", "enter", "t"), key.WithHelp("space", "toggle")),
+	Refresh: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "read again")),
+	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// leaderboardKeyMap — sorted set leaderboard.
+type leaderboardKeyMap struct {
+	Move    key.Binding
+	Incr    key.Binding
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+func (k leaderboardKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Incr, k.Refresh, k.Back}
+}
+func (k leaderboardKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Incr, k.Refresh, k.Back}}
+}
+
+var leaderboardKeys = leaderboardKeyMap{
+	Move:    key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "select")),
+	Incr:    key.NewBinding(key.WithKeys("+", "i"), key.WithHelp("+", "ZINCRBY")),
+	Refresh: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh now")),
+	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "stop & return")),
+}
+
+// dbDiffKeyMap — database diff report.
+type dbDiffKeyMap struct {
+	Move    key.Binding
+	Filter  key.Binding
+	Compare key.Binding
+	Export  key.Binding
+	Back    key.Binding
+}
+
+func (k dbDiffKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Filter, k.Compare, k.Export, k.Back}
+}
+func (k dbDiffKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Filter, k.Compare, k.Export, k.Back}}
+}
+
+var dbDiffKeys = dbDiffKeyMap{
+	Move:    key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "select")),
+	Filter:  key.NewBinding(key.WithKeys("0", "1", "2", "3"), key.WithHelp("0-3", "filter")),
+	Compare: key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "compare key")),
+	Export:  key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "write report")),
+	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// snapshotKeyMap — scheduled snapshot status.
+type snapshotKeyMap struct {
+	Now  key.Binding
+	Stop key.Binding
+	Back key.Binding
+}
+
+func (k snapshotKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Now, k.Stop, k.Back}
+}
+func (k snapshotKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Now, k.Stop, k.Back}}
+}
+
+var snapshotKeys = snapshotKeyMap{
+	Now:  key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "snapshot now")),
+	Stop: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "stop")),
+	Back: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return (keeps running)")),
+}
+
+// persistenceKeyMap — RDB/AOF status screen.
+type persistenceKeyMap struct {
+	BGSave  key.Binding
+	Rewrite key.Binding
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+func (k persistenceKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.BGSave, k.Rewrite, k.Refresh, k.Back}
+}
+func (k persistenceKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.BGSave, k.Rewrite, k.Refresh, k.Back}}
+}
+
+var persistenceKeys = persistenceKeyMap{
+	BGSave:  key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "BGSAVE")),
+	Rewrite: key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "BGREWRITEAOF")),
+	Refresh: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh now")),
+	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// clusterKeyMap — cluster overview.
+type clusterKeyMap struct {
+	Move     key.Binding
+	Console  key.Binding
+	Info     key.Binding
+	Failover key.Binding
+	Preview  key.Binding
+	Refresh  key.Binding
+	Back     key.Binding
+}
+
+func (k clusterKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Console, k.Info, k.Failover, k.Preview, k.Refresh, k.Back}
+}
+func (k clusterKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Console, k.Info, k.Failover, k.Preview, k.Refresh, k.Back}}
+}
+
+var clusterKeys = clusterKeyMap{
+	Move:     key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "select node")),
+	Console:  key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "console on node")),
+	Info:     key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "node INFO")),
+	Failover: key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "failover replica")),
+	Preview:  key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "reshard preview")),
+	Refresh:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+	Back:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// consoleKeyMap — raw command console.
+type consoleKeyMap struct {
+	Send     key.Binding
+	Complete key.Binding
+	Search   key.Binding
+	Save     key.Binding
+	CopyCSV  key.Binding
+	Clear    key.Binding
+	Back     key.Binding
+}
+
+func (k consoleKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Send, k.Complete, k.Search, k.Clear, k.Back}
+}
+func (k consoleKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Send, k.Complete, k.Search, k.Save, k.CopyCSV, k.Clear, k.Back}}
+}
+
+var consoleKeys = consoleKeyMap{
+	Send:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "send")),
+	Complete: key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "complete")),
+	Search:   key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "search history")),
+	Save:     key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save reply")),
+	CopyCSV:  key.NewBinding(key.WithKeys("ctrl+y"), key.WithHelp("ctrl+y", "copy as CSV")),
+	Clear:    key.NewBinding(key.WithKeys("ctrl+l"), key.WithHelp("ctrl+l", "clear")),
+	Back:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close")),
+}
+
+// consoleSearchKeyMap — console, during Ctrl+R history search.
+type consoleSearchKeyMap struct {
+	Accept key.Binding
+	Older  key.Binding
+	Cancel key.Binding
+}
+
+func (k consoleSearchKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Accept, k.Older, k.Cancel}
+}
+func (k consoleSearchKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Accept, k.Older, k.Cancel}}
+}
+
+var consoleSearchKeys = consoleSearchKeyMap{
+	Accept: key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "edit match")),
+	Older:  key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "older match")),
+	Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+}
+
+// reconnectKeyMap — connection lost screen.
+type reconnectKeyMap struct {
+	Retry  key.Binding
+	Change key.Binding
+	Quit   key.Binding
+}
+
+func (k reconnectKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Retry, k.Change, k.Quit}
+}
+func (k reconnectKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Retry, k.Change, k.Quit}}
+}
+
+var reconnectKeys = reconnectKeyMap{
+	Retry:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "retry now")),
+	Change: key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "change server")),
+	Quit:   key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+}
+
+// reconnectEditKeyMap — the change-server input on the connection lost screen.
+type reconnectEditKeyMap struct {
+	Connect key.Binding
+	Cancel  key.Binding
+}
+
+func (k reconnectEditKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Connect, k.Cancel}
+}
+func (k reconnectEditKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Connect, k.Cancel}}
+}
+
+var reconnectEditKeys = reconnectEditKeyMap{
+	Connect: key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "connect")),
+	Cancel:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+}
+
+// templatesKeyMap — key template picker.
+type templatesKeyMap struct {
+	Move   key.Binding
+	Select key.Binding
+	Back   key.Binding
+}
+
+func (k templatesKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Select, k.Back}
+}
+func (k templatesKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Select, k.Back}}
+}
+
+var templatesKeys = templatesKeyMap{
+	Move:   key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "select template")),
+	Select: key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "fill in")),
+	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// createKeyKeyMap — type picker for a new key.
+type createKeyKeyMap struct {
+	Move   key.Binding
+	Select key.Binding
+	Back   key.Binding
+}
+
+func (k createKeyKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Select, k.Back}
+}
+func (k createKeyKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Select, k.Back}}
+}
+
+var createKeyKeys = createKeyKeyMap{
+	Move:   key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "select type")),
+	Select: key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "fill in")),
+	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// macrosKeyMap — macro picker.
+type macrosKeyMap struct {
+	Move   key.Binding
+	Select key.Binding
+	Back   key.Binding
+}
+
+func (k macrosKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Select, k.Back}
+}
+func (k macrosKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Select, k.Back}}
+}
+
+var macrosKeys = macrosKeyMap{
+	Move:   key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "select macro")),
+	Select: key.NewBinding(key.WithKeys("enter"), key.WithHelp("↵", "review and run")),
+	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
+
+// generateKeyMap — test data generator progress.
+type generateKeyMap struct {
+	Stop key.Binding
+	Back key.Binding
+}
+
+func (k generateKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Stop, k.Back}
+}
+func (k generateKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Stop, k.Back}}
+}
+
+var generateKeys = generateKeyMap{
+	Stop: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "stop")),
+	Back: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return (stops a running generator)")),
+}
+
+// bulkTTLKeyMap — EXPIRE_ALL progress.
+type bulkTTLKeyMap struct {
+	Stop key.Binding
+	Back key.Binding
+}
+
+func (k bulkTTLKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Stop, k.Back}
+}
+func (k bulkTTLKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Stop, k.Back}}
+}
+
+var bulkTTLKeys = bulkTTLKeyMap{
+	Stop: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "stop")),
+	Back: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return (stops a running pass)")),
+}
+
+// replaceKeys — REPLACE_ALL progress, which stops the same way.
+var replaceKeys = bulkTTLKeys
+
+// searchKeyMap — SEARCH_VALUES hits.
+type searchKeyMap struct {
+	Move key.Binding
+	Open key.Binding
+	Stop key.Binding
+	Back key.Binding
+}
+
+func (k searchKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Move, k.Open, k.Stop, k.Back}
+}
+func (k searchKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Move, k.Open, k.Stop, k.Back}}
+}
+
+var searchKeys = searchKeyMap{
+	Move: key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "select")),
+	Open: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open key (search goes on)")),
+	Stop: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "stop")),
+	Back: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back to the form (stops a running search)")),
+}
+
+// benchmarkKeyMap — benchmark results.
+type benchmarkKeyMap struct {
+	Rerun key.Binding
+	Back  key.Binding
+}
+
+func (k benchmarkKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Rerun, k.Back}
+}
+func (k benchmarkKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Rerun, k.Back}}
+}
+
+var benchmarkKeys = benchmarkKeyMap{
+	Rerun: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "run again")),
+	Back:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back to the form")),
+}
+
+// timelineKeyMap — keyspace event timeline.
+type timelineKeyMap struct {
+	Enable key.Binding
+	Stop   key.Binding
+	Back   key.Binding
+}
+
+func (k timelineKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Enable, k.Stop, k.Back}
+}
+func (k timelineKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Enable, k.Stop, k.Back}}
+}
+
+var timelineKeys = timelineKeyMap{
+	Enable: key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "enable notifications")),
+	Stop:   key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "stop")),
+	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return (keeps recording)")),
+}
+
+// streamKeyMap — large value streamed into view.
+type streamKeyMap struct {
+	Scroll key.Binding
+	Page   key.Binding
+	Ends   key.Binding
+	Sizes  key.Binding
+	Back   key.Binding
+}
+
+func (k streamKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Scroll, k.Page, k.Ends, k.Back}
+}
+func (k streamKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Scroll, k.Page, k.Ends, k.Sizes, k.Back}}
+}
+
+var streamKeys = streamKeyMap{
+	Scroll: key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "scroll")),
+	Page:   key.NewBinding(key.WithKeys("pgup", "pgdown"), key.WithHelp("pgup/pgdn", "page")),
+	Ends:   key.NewBinding(key.WithKeys("home", "end"), key.WithHelp("home/end", "top/bottom")),
+	Sizes:  key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "exact bytes")),
+	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return (stops the transfer)")),
+}
+
+// tourKeyMap — guided tour.
+type tourKeyMap struct {
+	Next key.Binding
+	Prev key.Binding
+	Skip key.Binding
+}
+
+func (k tourKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Next, k.Prev, k.Skip}
+}
+func (k tourKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Next, k.Prev, k.Skip}}
+}
+
+var tourKeys = tourKeyMap{
+	Next: key.NewBinding(key.WithKeys("enter", "right"), key.WithHelp("↵/→", "next")),
+	Prev: key.NewBinding(key.WithKeys("left"), key.WithHelp("←", "back")),
+	Skip: key.NewBinding(key.WithKeys("esc", "q"), key.WithHelp("esc", "close the tour")),
+}
+
+// auditKeyMap — audit log viewer.
+type auditKeyMap struct {
+	Scroll key.Binding
+	All    key.Binding
+	Back   key.Binding
+}
+
+func (k auditKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Scroll, k.All, k.Back}
+}
+func (k auditKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Scroll, k.All, k.Back}}
+}
+
+var auditKeys = auditKeyMap{
+	Scroll: key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑↓", "scroll")),
+	All:    key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "writes / all")),
+	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "return")),
+}
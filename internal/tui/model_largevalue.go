@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// DefaultLargeValueBytes is the -large-value default: values above it
+	// ask before they are fetched.
+	DefaultLargeValueBytes = 1 << 20
+	// previewBytes is how much of a large string the preview shows, and how
+	// much of each hash value.
+	previewBytes  = 4096
+	previewFields = 100
+)
+
+// LargeValue is a key that was not fetched because it is over the limit.
+type LargeValue struct {
+	Key   string
+	Type  string // string or hash
+	Bytes int    // STRLEN for strings, MEMORY USAGE for hashes
+	Items int    // hash fields
+}
+
+// LargeValueMsg stops a fetch that would pull in a LargeValue.
+type LargeValueMsg struct {
+	Value LargeValue
+}
+
+// openString shows the active string key, unless it is over the limit.
+func (m Model) openString() (tea.Model, tea.Cmd) {
+	m.SelectedOp = OpGet
+	if cached := m.fromCache(redis.Get(m.ActiveKey)); cached != nil {
+		return m.switchToLoadingAndExecute(cached) // sized when it was fetched
+	}
+	conn, reader := m.readConn()
+	return m.switchToLoadingAndExecute(guardSize(m, "string", inspectString(conn, reader, m.ActiveKey, m.Server.Supports(redis.CapIdleTime))))
+}
+
+// openHash lists the active hash key's field names (see hashPage), unless
+// it is over the limit.
+func (m Model) openHash() (tea.Model, tea.Cmd) {
+	m.SelectedOp = OpHKeys
+	m.Browser.FieldCursor = ""
+	if cached := m.fromCache(m.hashPage()); cached != nil {
+		return m.switchToLoadingAndExecute(cached)
+	}
+	return m.switchToLoadingAndExecute(guardSize(m, "hash", m.sendRead(m.hashPage())))
+}
+
+// guardSize runs fetch only if the key's size is within m.LargeValueBytes.
+// The size check shares fetch's tea.Cmd, so small keys cost one extra round
+// trip and no extra message. A size that can't be measured (MEMORY disabled
+// on a managed server) lets the fetch through.
+func guardSize(m Model, keyType string, fetch tea.Cmd) tea.Cmd {
+	limit := m.LargeValueBytes
+	if limit <= 0 {
+		return fetch
+	}
+	conn, reader := m.readConn()
+	key := m.ActiveKey
+	if meta, ok := m.freshMeta(key); ok && meta.Type == keyType && meta.Bytes >= 0 {
+		if meta.Bytes > limit {
+			v := LargeValue{Key: key, Type: keyType, Bytes: meta.Bytes, Items: max(meta.Items, 0)}
+			return func() tea.Msg { return LargeValueMsg{Value: v} }
+		}
+		return fetch // sized by the prefetch
+	}
+	return func() tea.Msg {
+		if conn == nil {
+			return fetch()
+		}
+		v := LargeValue{Key: key, Type: keyType}
+		cmds := []redis.RedisCmd{redis.StrLen(key)}
+		if keyType == "hash" {
+			cmds = []redis.RedisCmd{{Name: "MEMORY", Args: []string{"USAGE", key}}, redis.HLen(key)}
+		}
+		replies, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			_ = conn.Close() // stream may be desynced mid-batch; force a reconnect
+			return RedisResultMsg{Error: err}
+		}
+		v.Bytes, _ = redis.AsInt(replies[0])
+		if keyType == "hash" {
+			v.Items, _ = redis.AsInt(replies[1])
+		}
+		if v.Bytes > limit {
+			return LargeValueMsg{Value: v}
+		}
+		return fetch()
+	}
+}
+
+func handleLargeValue(m Model, msg LargeValueMsg) (tea.Model, tea.Cmd) {
+	m.LargeValue = msg.Value
+	m.EditOnOpen = false // a value too large to show is too large to edit here
+	m.SelectedOp = OpLargeValue
+	m.ask(m.largeValueDialog())
+	return m, nil
+}
+
+// largeValueDialog asks whether to preview the value or fetch it all.
+// Neither changes anything, and enter takes the preview, the cheap one.
+func (m Model) largeValueDialog() Dialog {
+	v := m.LargeValue
+	label := fmt.Sprintf("%s is %s", v.Type, sizeText(v.Bytes, m.RawSizes))
+	all := "fetch all"
+	if v.Type == "hash" {
+		label = fmt.Sprintf("hash with %d fields, %s in memory", v.Items, sizeText(v.Bytes, m.RawSizes))
+		all = "browse fields"
+	}
+	preview := func(m Model) (tea.Model, tea.Cmd) { return confirmLargeValue(m, true) }
+	fetch := func(m Model) (tea.Model, tea.Cmd) { return confirmLargeValue(m, false) }
+	return Dialog{Title: "large value", Label: label, Value: v.Key, Default: "p", Choices: []DialogChoice{
+		{Key: "p", Label: "preview", Run: preview},
+		{Key: "y", Label: all, Destructive: true, Run: fetch},
+	}}
+}
+
+// confirmLargeValue handles the large-value prompt: y fetches everything (a
+// hash's field names, values as they are opened), p fetches a preview.
+func confirmLargeValue(m Model, preview bool) (tea.Model, tea.Cmd) {
+	v := m.LargeValue
+	if !preview {
+		if v.Type == "hash" {
+			m.SelectedOp = OpHKeys
+			m.Browser.FieldCursor = ""
+			return m.switchToLoadingAndExecute(m.sendRead(m.hashPage()))
+		}
+		// A string this size is streamed in rather than fetched whole.
+		m.SelectedOp = OpGet
+		return m.startStream(v.Key)
+	}
+	cmd := redis.GetRange(v.Key, 0, previewBytes-1)
+	if v.Type == "hash" {
+		cmd = redis.HScan(v.Key, "0", previewFields)
+	}
+	m.SelectedOp = OpLargePreview
+	return m.switchToLoadingAndExecute(m.sendRead(cmd))
+}
+
+// renderPreview formats a GETRANGE or HSCAN reply, ending with a note of how
+// much was left out.
+func renderPreview(v LargeValue, reply any, rawSizes bool) string {
+	if v.Type != "hash" {
+		s, _ := reply.(string)
+		return s + fmt.Sprintf("\n\n… preview: first %s of %s", sizeText(len(s), rawSizes), sizeText(v.Bytes, rawSizes))
+	}
+	var lines []string
+	if _, pairs, err := scanPage(reply); err == nil {
+		for i := 0; i+1 < len(pairs); i += 2 {
+			field, value := pairs[i], pairs[i+1]
+			if len(value) > previewBytes {
+				value = value[:previewBytes] + "…"
+			}
+			lines = append(lines, field+": "+value)
+		}
+	}
+	return strings.Join(lines, "\n") + fmt.Sprintf("\n\n… preview: %d of %d fields (%s in memory)", len(lines), v.Items, sizeText(v.Bytes, rawSizes))
+}
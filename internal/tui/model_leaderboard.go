@@ -0,0 +1,353 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LEADERBOARD watches the top of a sorted set: the N highest scores with
+// their ranks, read again every few seconds with ZREVRANGE … WITHSCORES and
+// ZCARD in one pipeline. Members that climbed, fell or entered the top since
+// the last read are marked, and + adds to a score with ZINCRBY. A display
+// rule with "zset_view": "leaderboard" opens matching keys here from the
+// browser instead of as a member list.
+
+const (
+	maxLeaderTop       = 1000
+	defaultLeaderTop   = 10
+	defaultLeaderEvery = 2 * time.Second
+	leaderMemberCol    = 32
+)
+
+// LeaderboardModel is the state of the leaderboard.
+type LeaderboardModel struct {
+	Key      string
+	Top      int
+	Interval time.Duration
+	Rows     []LeaderRow
+	Card     int64
+	Cursor   int
+	Sampled  time.Time
+	Err      string
+	Member   string // the member the pending ZINCRBY was sent for
+
+	Active bool
+	Gen    int
+}
+
+// LeaderRow is one ranked member. Moved is how many ranks it climbed since
+// the previous read (negative when it fell).
+type LeaderRow struct {
+	Member string
+	Score  string
+	Rank   int // 1-based
+	Moved  int
+	New    bool // outside the top on the previous read
+}
+
+// LeaderSampleMsg carries one read of the leaderboard.
+type LeaderSampleMsg struct {
+	Gen   int
+	Rows  []LeaderRow
+	Card  int64
+	Error error
+	Rearm bool
+}
+
+// LeaderTickMsg fires every Interval while the leaderboard is active.
+type LeaderTickMsg struct{ Gen int }
+
+func (m Model) startLeaderboardForm() (tea.Model, tea.Cmd) {
+	key := ""
+	if m.Browser.ActiveKeyType == "zset" {
+		key = m.ActiveKey
+	}
+	m.Form = NewForm(
+		"LEADERBOARD · the top of a sorted set by score, kept up to date",
+		[]string{"sorted set key", "show the top", "refresh every (seconds)"},
+		[]string{key, strconv.Itoa(defaultLeaderTop), strconv.Itoa(int(defaultLeaderEvery / time.Second))},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitLeaderboardForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	key := strings.TrimSpace(values[0])
+	if key == "" {
+		m.Form.Err = "enter a sorted set key"
+		return m, nil
+	}
+	top, err := strconv.Atoi(values[1])
+	if err != nil || top <= 0 || top > maxLeaderTop {
+		m.Form.Err = fmt.Sprintf("the top must be between 1 and %d", maxLeaderTop)
+		return m, nil
+	}
+	secs, err := strconv.Atoi(values[2])
+	if err != nil || secs <= 0 {
+		m.Form.Err = "refresh interval must be a positive number of seconds"
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.pushState(StateForm)
+	return m.openLeaderboard(key, top, time.Duration(secs)*time.Second)
+}
+
+// openLeaderboard starts watching key's top entries.
+func (m Model) openLeaderboard(key string, top int, every time.Duration) (tea.Model, tea.Cmd) {
+	m.ActiveKey = key
+	m.SelectedOp = OpLeaderboard
+	m.Leader = LeaderboardModel{
+		Key:      key,
+		Top:      top,
+		Interval: every,
+		Active:   true,
+		Gen:      m.Leader.Gen + 1,
+	}
+	return m.switchToLoadingAndExecute(sampleLeaderboard(m.Conn, m.Reader, key, top, m.Leader.Gen, true))
+}
+
+func leaderTick(gen int, every time.Duration) tea.Cmd {
+	return tea.Tick(every, func(time.Time) tea.Msg { return LeaderTickMsg{Gen: gen} })
+}
+
+// sampleLeaderboard reads the top entries and the set's size in one
+// pipelined batch.
+func sampleLeaderboard(conn net.Conn, reader *bufio.Reader, key string, top, gen int, rearm bool) tea.Cmd {
+	return func() tea.Msg {
+		msg := LeaderSampleMsg{Gen: gen, Rearm: rearm}
+		if conn == nil {
+			msg.Error = fmt.Errorf("no connection to Redis")
+			return msg
+		}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{
+			redis.ZRevRange(key, 0, top-1, true),
+			redis.ZCard(key),
+		})
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		if s, ok := replies[0].(string); ok && redis.IsErrorReply(s) {
+			msg.Error = fmt.Errorf("%s", s)
+			return msg
+		}
+		pairs, _ := replies[0].([]any)
+		for i := 0; i+1 < len(pairs); i += 2 {
+			member, _ := pairs[i].(string)
+			score, _ := pairs[i+1].(string)
+			msg.Rows = append(msg.Rows, LeaderRow{Member: member, Score: score, Rank: i/2 + 1})
+		}
+		if n, ok := replies[1].(int); ok {
+			msg.Card = int64(n)
+		}
+		return msg
+	}
+}
+
+func handleLeaderSample(m Model, msg LeaderSampleMsg) (tea.Model, tea.Cmd) {
+	l := &m.Leader
+	if !l.Active || msg.Gen != l.Gen {
+		return m, nil
+	}
+	var next tea.Cmd
+	if msg.Rearm {
+		next = leaderTick(l.Gen, l.Interval)
+	}
+
+	if msg.Error != nil {
+		if m.CurrentState == StateLoading {
+			l.Active = false
+			m.Result = errorResult(msg.Error)
+			m.CurrentState = StateOutput
+			return m, nil
+		}
+		l.Err = msg.Error.Error()
+		return m, next
+	}
+
+	// The first read has nothing to compare with, so nothing is marked.
+	if !l.Sampled.IsZero() {
+		prev := make(map[string]int, len(l.Rows))
+		for _, r := range l.Rows {
+			prev[r.Member] = r.Rank
+		}
+		for i := range msg.Rows {
+			r := &msg.Rows[i]
+			if rank, ok := prev[r.Member]; ok {
+				r.Moved = rank - r.Rank
+			} else {
+				r.New = true
+			}
+		}
+	}
+	// Keep the cursor on the same member while it stays in the top.
+	if sel := l.selected(); sel != nil {
+		for i, r := range msg.Rows {
+			if r.Member == sel.Member {
+				l.Cursor = i
+			}
+		}
+	}
+	l.Rows, l.Card, l.Err = msg.Rows, msg.Card, ""
+	l.Cursor = min(l.Cursor, max(len(l.Rows)-1, 0))
+	l.Sampled = time.Now()
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateLeaderboard
+	}
+	return m, next
+}
+
+func handleLeaderTick(m Model, msg LeaderTickMsg) (tea.Model, tea.Cmd) {
+	l := m.Leader
+	if !l.Active || msg.Gen != l.Gen {
+		return m, nil
+	}
+	if m.CurrentState != StateLeaderboard {
+		return m, leaderTick(l.Gen, l.Interval)
+	}
+	return m, m.exec(sampleLeaderboard(m.Conn, m.Reader, l.Key, l.Top, l.Gen, true))
+}
+
+// selected is the row under the cursor, or nil.
+func (l LeaderboardModel) selected() *LeaderRow {
+	if l.Cursor < len(l.Rows) {
+		return &l.Rows[l.Cursor]
+	}
+	return nil
+}
+
+func handleStateLeaderboardKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	l := &m.Leader
+	switch keyMsg.String() {
+	case "esc":
+		l.Active = false
+		m.CurrentState = m.popState()
+	case "up", "k":
+		l.Cursor = max(l.Cursor-1, 0)
+	case "down", "j":
+		l.Cursor = min(l.Cursor+1, max(len(l.Rows)-1, 0))
+	case "r":
+		return m, m.exec(sampleLeaderboard(m.Conn, m.Reader, l.Key, l.Top, l.Gen, false))
+	case "+", "i":
+		return m.startLeaderIncr()
+	}
+	return m, nil
+}
+
+// startLeaderIncr opens the ZINCRBY form on the selected member.
+func (m Model) startLeaderIncr() (tea.Model, tea.Cmd) {
+	if m.ReadOnly {
+		return m, m.notify("ZINCRBY is disabled: this session is read-only", true)
+	}
+	member := ""
+	if sel := m.Leader.selected(); sel != nil {
+		member = sel.Member
+	}
+	m.SelectedOp = OpLeaderIncr
+	m.Form = NewForm(
+		"ZINCRBY · add to a score on "+m.Leader.Key,
+		[]string{"member (added if missing)", "increment (negative to subtract)"},
+		[]string{member, "1"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.pushState(StateLeaderboard)
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitLeaderIncrForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	member, incr := values[0], strings.TrimSpace(values[1])
+	if member == "" {
+		m.Form.Err = "enter a member"
+		return m, nil
+	}
+	if f, err := strconv.ParseFloat(incr, 64); err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		m.Form.Err = "the increment must be a number"
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.Leader.Member = member
+	return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.ZIncrBy(m.Leader.Key, incr, member), m.ReadTimeout))
+}
+
+// handleLeaderIncrResult reports the new score as a toast over the
+// leaderboard, read again at once rather than at the next tick. A refused
+// ZINCRBY goes back to its form.
+func handleLeaderIncrResult(m Model, reply Result, resp any) (tea.Model, tea.Cmd) {
+	if reply.Err != "" {
+		m.Form.Err = reply.Err
+		m.CurrentState = StateForm
+		return m, nil
+	}
+	m.CurrentState = m.popState()
+	m.SelectedOp = OpLeaderboard
+	l := &m.Leader
+	m.invalidate(l.Key)
+	score, _ := resp.(string)
+	toast := m.notify(fmt.Sprintf("%s now scores %s", l.Member, score), false)
+	return m, tea.Batch(m.exec(sampleLeaderboard(m.Conn, m.Reader, l.Key, l.Top, l.Gen, false)), toast)
+}
+
+func (m Model) leaderboardView() string {
+	l := m.Leader
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+	yellow := lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow))
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnBlue)).Bold(true).Render("LEADERBOARD") +
+		dim.Render(fmt.Sprintf(" · %s · top %d of %d · every %s", l.Key, min(l.Top, len(l.Rows)), l.Card, l.Interval))
+	if !l.Sampled.IsZero() {
+		title += dim.Render("   sampled " + l.Sampled.Format("15:04:05"))
+	}
+	if l.Err != "" {
+		title += "   " + red.Render("✗ "+l.Err)
+	}
+
+	head := "  " + dim.Render(fmt.Sprintf("%5s  %-*s %14s", "rank", leaderMemberCol, "member", "score"))
+	rows := []string{}
+	for i, r := range l.Rows {
+		name := padCells(truncateText(r.Member, leaderMemberCol), leaderMemberCol)
+		marker, nameView := "  ", text.Render(name)
+		if i == l.Cursor {
+			marker = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render(pointerGlyph)
+			nameView = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true).Render(name)
+		}
+		var move string
+		switch {
+		case r.New:
+			move = yellow.Render("new")
+		case r.Moved > 0:
+			move = green.Render(fmt.Sprintf("▲%d", r.Moved))
+		case r.Moved < 0:
+			move = red.Render(fmt.Sprintf("▼%d", -r.Moved))
+		}
+		rows = append(rows, marker+dim.Render(fmt.Sprintf("%5s", "#"+strconv.Itoa(r.Rank)))+"  "+nameView+" "+
+			text.Render(fmt.Sprintf("%14s", truncateText(r.Score, 14)))+"  "+move)
+	}
+	if len(rows) == 0 {
+		rows = append(rows, "  "+dim.Render("the sorted set is empty or doesn't exist"))
+	}
+	// header(2) + blank + title + blank + column head + footer(2)
+	if avail := m.WindowHeight - 8; avail > 0 && len(rows) > avail {
+		start := max(l.Cursor-avail+1, 0)
+		rows = rows[start : start+avail]
+	}
+
+	body := "  " + title + "\n\n" + head + "\n" + strings.Join(rows, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(leaderboardKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// lifecycleActions maps what the user types in the LIFECYCLE form to the
+// command it sends.
+var lifecycleActions = map[string]redis.RedisCmd{
+	"save":    {Name: "SHUTDOWN", Args: []string{"SAVE"}},
+	"nosave":  {Name: "SHUTDOWN", Args: []string{"NOSAVE"}},
+	"restart": {Name: "DEBUG", Args: []string{"RESTART"}},
+}
+
+// LifecycleResultMsg reports the outcome of a SHUTDOWN or DEBUG RESTART.
+type LifecycleResultMsg struct {
+	Output string
+	Closed bool // the server dropped the connection, as it does when it goes down
+	Error  error
+}
+
+// lifecycleRefusal explains why this session may not stop or restart the
+// server, or returns "" when it may. Both checks are deliberately
+// conservative: a read-only session never can, and only a profile that opts
+// in with "admin": true can — never an ad-hoc -host or -url connection.
+func (m Model) lifecycleRefusal() string {
+	if m.ReadOnly {
+		return "LIFECYCLE is disabled: this session is read-only (-readonly, or \"readonly\" in its profile)."
+	}
+	if m.Production {
+		return fmt.Sprintf("LIFECYCLE is disabled: profile %q is marked \"production\": true.", m.ProfileName)
+	}
+	if m.ProfileName == "" {
+		return "LIFECYCLE is only available when connected with a profile marked \"admin\": true (see -profile)."
+	}
+	if p, ok := m.Config.Find(m.ProfileName); !ok || !p.Admin {
+		return fmt.Sprintf("LIFECYCLE is disabled: profile %q is not marked \"admin\": true in the config file.", m.ProfileName)
+	}
+	return ""
+}
+
+func (m Model) startLifecycleForm() (tea.Model, tea.Cmd) {
+	if reason := m.lifecycleRefusal(); reason != "" {
+		m.Result = textResult(reason)
+		m.ActiveTTL = ""
+		m.CopyStatus = ""
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	m.Form = NewForm(
+		"LIFECYCLE · stop or restart "+m.ProfileName+" ("+m.RedisAddress+")",
+		[]string{"action: save (SHUTDOWN SAVE), nosave (SHUTDOWN NOSAVE) or restart (DEBUG RESTART)", "type the profile name to confirm"},
+		[]string{"save", ""},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitLifecycleForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	action := strings.ToLower(strings.TrimSpace(values[0]))
+	cmd, ok := lifecycleActions[action]
+	if !ok {
+		m.Form.Err = "action must be save, nosave or restart"
+		return m, nil
+	}
+	if action == "restart" && !m.Server.Supports(redis.CapDebugRestart) {
+		m.Form.Err = m.Server.String() + " has no DEBUG RESTART; use save or nosave"
+		return m, nil
+	}
+	if values[1] != m.ProfileName {
+		m.Form.Err = fmt.Sprintf("type %q exactly to confirm", m.ProfileName)
+		return m, nil
+	}
+	// Checked again in case the form was reached some other way.
+	if reason := m.lifecycleRefusal(); reason != "" {
+		m.Form.Err = reason
+		return m, nil
+	}
+
+	m.Form.Err = ""
+	m.pushState(StateForm)
+	return m.switchToLoadingAndExecute(sendLifecycleCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+}
+
+// sendLifecycleCmd sends cmd and reads the reply. A server that stops or
+// restarts closes the connection instead of replying, so EOF here is the
+// success case rather than a dropped connection.
+func sendLifecycleCmd(conn net.Conn, reader *bufio.Reader, cmd redis.RedisCmd, readTimeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return LifecycleResultMsg{Error: fmt.Errorf("no connection to Redis")}
+		}
+		if readTimeout == 0 {
+			readTimeout = defaultReadTimeout
+		}
+		name := cmd.Name + " " + strings.Join(cmd.Args, " ")
+		if _, err := conn.Write(cmd.ToBytes()); err != nil {
+			return LifecycleResultMsg{Error: err}
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(readTimeout))
+		reply, err := redis.ReadResp(reader)
+		_ = conn.SetReadDeadline(time.Time{})
+		if errors.Is(err, io.EOF) {
+			return LifecycleResultMsg{Closed: true, Output: name + " accepted — the server closed the connection.\n\nThe session reconnects on its next command."}
+		}
+		if err != nil {
+			return LifecycleResultMsg{Error: err}
+		}
+		// Any reply at all means the server is still up: SHUTDOWN refused
+		// (e.g. the save failed) or the command is disabled.
+		return LifecycleResultMsg{Output: fmt.Sprintf("%s was not carried out:\n\n%v", name, reply)}
+	}
+}
+
+func handleLifecycleResult(m Model, msg LifecycleResultMsg) (tea.Model, tea.Cmd) {
+	m.ActiveTTL = ""
+	m.CopyStatus = ""
+	if msg.Error != nil {
+		m.Result = errorResult(msg.Error)
+	} else {
+		m.Result = textResult(msg.Output)
+	}
+	if msg.Closed && m.Conn != nil {
+		// Writing to the closed socket fails with a net.Error, which sends the
+		// next command through the usual reconnect-with-backoff path.
+		_ = m.Conn.Close()
+	}
+	m.CurrentState = StateOutput
+	return m, nil
+}
@@ -0,0 +1,240 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// L on an open key (its fields, or a string's value) subscribes to the key's
+// keyspace channel on a dedicated connection and reads the key again when
+// another client changes it. Events are coalesced over liveDelay, so a key
+// written in a burst is read once; the badge then says how long ago.
+
+const (
+	liveDelay   = 300 * time.Millisecond
+	liveJustNow = 10 * time.Second
+)
+
+// LiveModel is the keyspace subscription of the open key.
+type LiveModel struct {
+	Key     string
+	Conn    net.Conn
+	Reader  *bufio.Reader
+	Gen     int
+	Active  bool
+	Due     bool      // a refresh is scheduled
+	Updated time.Time // the last refresh an event caused
+}
+
+// LiveToggleMsg turns live refresh of the key on or off.
+type LiveToggleMsg struct{ Key string }
+
+// LiveConnMsg delivers the subscribed connection, with the server's
+// notify-keyspace-events setting when CONFIG GET is allowed.
+type LiveConnMsg struct {
+	Gen        int
+	Conn       net.Conn
+	Reader     *bufio.Reader
+	Flags      string
+	FlagsKnown bool
+	Error      error
+}
+
+// LiveEventMsg is one notification for the key, by event name.
+type LiveEventMsg struct {
+	Gen   int
+	Event string
+	Error error
+}
+
+// LiveRefreshMsg ends the coalescing delay after an event.
+type LiveRefreshMsg struct{ Gen int }
+
+// LiveAgeMsg redraws the badge once "just now" no longer holds.
+type LiveAgeMsg struct{}
+
+// liveCovers reports whether notify-keyspace-events flags publish keyspace
+// ("K") events for writes to a key, of any type.
+func liveCovers(flags string) bool {
+	return strings.Contains(flags, "K") && strings.ContainsAny(flags, "A$hlszt")
+}
+
+// toggleLive starts watching key, or stops the watch already on it.
+func (m Model) toggleLive(key string) (tea.Model, tea.Cmd) {
+	if m.Live.Active && m.Live.Key == key {
+		m.stopLive()
+		return m, m.notify("Live refresh off for "+key, false)
+	}
+	if m.Proxy {
+		return m, m.notify("Live refresh needs "+m.proxyNeeds("SUBSCRIBE"), true)
+	}
+	m.stopLive()
+	m.Live = LiveModel{Key: key, Gen: m.Live.Gen + 1}
+	return m, openLiveConnection(m, m.Live.Gen)
+}
+
+// openLiveConnection opens the dedicated connection, reads the notification
+// setting and subscribes to the key's channel. SUBSCRIBE, not PSUBSCRIBE,
+// so glob characters in the key name match only themselves.
+func openLiveConnection(m Model, gen int) tea.Cmd {
+	channel := fmt.Sprintf("__keyspace@%d__:%s", m.DB, m.Live.Key)
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m)
+		if err != nil {
+			return LiveConnMsg{Gen: gen, Error: err}
+		}
+		msg := LiveConnMsg{Gen: gen, Conn: conn, Reader: reader}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{{Name: "CONFIG", Args: []string{"GET", "notify-keyspace-events"}}})
+		if err != nil {
+			_ = conn.Close()
+			return LiveConnMsg{Gen: gen, Error: err}
+		}
+		if pair, ok := replies[0].([]any); ok && len(pair) == 2 {
+			msg.Flags, msg.FlagsKnown = fmt.Sprint(pair[1]), true
+		}
+		replies, err = pipeline(conn, reader, []redis.RedisCmd{{Name: "SUBSCRIBE", Args: []string{channel}}})
+		if err == nil {
+			if s, ok := replies[0].(string); ok {
+				err = fmt.Errorf("SUBSCRIBE: %s", s)
+			}
+		}
+		if err != nil {
+			_ = conn.Close()
+			return LiveConnMsg{Gen: gen, Error: err}
+		}
+		return msg
+	}
+}
+
+func handleLiveConn(m Model, msg LiveConnMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.Live.Gen {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close()
+		}
+		return m, nil
+	}
+	if msg.Error != nil {
+		return m, m.notify("Live refresh failed: "+msg.Error.Error(), true)
+	}
+	l := &m.Live
+	l.Conn, l.Reader, l.Active = msg.Conn, msg.Reader, true
+	toast := m.notify("Live refresh on for "+l.Key, false)
+	if msg.FlagsKnown && !liveCovers(msg.Flags) {
+		toast = m.notify(fmt.Sprintf("Keyspace notifications are off (notify-keyspace-events %q): no changes will arrive", msg.Flags), true)
+	}
+	return m, tea.Batch(readLiveEvent(l.Reader, l.Gen), toast)
+}
+
+// readLiveEvent waits for the next message on the channel, with no read
+// deadline: a key may go unchanged for hours.
+func readLiveEvent(reader *bufio.Reader, gen int) tea.Cmd {
+	return func() tea.Msg {
+		for {
+			v, err := redis.ReadResp(reader)
+			if err != nil {
+				return LiveEventMsg{Gen: gen, Error: err}
+			}
+			parts, ok := v.([]any)
+			if !ok || len(parts) != 3 || parts[0] != "message" {
+				continue
+			}
+			event, _ := parts[2].(string)
+			return LiveEventMsg{Gen: gen, Event: event}
+		}
+	}
+}
+
+// showingLive reports whether the watched key is on screen now, in a state
+// a refresh may replace: not while it is being edited or added to.
+func (m Model) showingLive() bool {
+	if m.Live.Key != m.ActiveKey {
+		return false
+	}
+	switch m.CurrentState {
+	case StateBrowser:
+		return m.Browser.ViewingFields && !m.Browser.AddingField && m.Browser.FieldsList.FilterState() != list.Filtering
+	case StateOutput:
+		return m.SelectedOp == OpGet
+	}
+	return false
+}
+
+func handleLiveEvent(m Model, msg LiveEventMsg) (tea.Model, tea.Cmd) {
+	l := &m.Live
+	if msg.Gen != l.Gen || !l.Active {
+		return m, nil
+	}
+	if msg.Error != nil {
+		m.stopLive()
+		return m, m.notify("Live refresh stopped: "+msg.Error.Error(), true)
+	}
+	if l.Key != m.ActiveKey {
+		// The user has moved on to another key.
+		m.stopLive()
+		return m, nil
+	}
+	next := readLiveEvent(l.Reader, l.Gen)
+	if l.Due {
+		return m, next
+	}
+	l.Due = true
+	gen := l.Gen
+	return m, tea.Batch(next, tea.Tick(liveDelay, func(time.Time) tea.Msg { return LiveRefreshMsg{Gen: gen} }))
+}
+
+// handleLiveRefresh reads the key again the way ctrl+r does, if it is still
+// on screen; changes made while it isn't show when it is next opened.
+func handleLiveRefresh(m Model, msg LiveRefreshMsg) (tea.Model, tea.Cmd) {
+	l := &m.Live
+	if msg.Gen != l.Gen || !l.Active {
+		return m, nil
+	}
+	l.Due = false
+	if !m.showingLive() {
+		return m, nil
+	}
+	l.Updated = time.Now()
+	age := tea.Tick(liveJustNow, func(time.Time) tea.Msg { return LiveAgeMsg{} })
+	if m.CurrentState == StateOutput {
+		m.invalidate(l.Key)
+		next, cmd := m.switchToLoadingAndExecute(m.sendRead(redis.Get(l.Key)))
+		return next, tea.Batch(cmd, age)
+	}
+	return m, tea.Batch(func() tea.Msg { return RefreshMsg{} }, age)
+}
+
+// stopLive closes the subscription, which also ends the pending read.
+func (m *Model) stopLive() {
+	l := &m.Live
+	if l.Conn != nil {
+		_ = l.Conn.Close()
+	}
+	l.Conn, l.Reader = nil, nil
+	l.Active, l.Due = false, false
+	l.Gen++
+}
+
+// liveBadge is the indicator shown beside key while it is watched.
+func (m Model) liveBadge(key string) string {
+	l := m.Live
+	if !l.Active || l.Key != key {
+		return ""
+	}
+	text := "● live"
+	switch age := time.Since(l.Updated); {
+	case l.Updated.IsZero():
+	case age < liveJustNow:
+		text += " · updated just now"
+	default:
+		text += " · updated " + formatTTL(int(age.Seconds())) + " ago"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)).Render(text)
+}
@@ -0,0 +1,219 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Macro is a named sequence of console-style command lines, run on the key
+// selected in the browser with `!` after a confirmation. {key} in a line is
+// replaced by the key's name, as one argument whatever it contains.
+type Macro struct {
+	Name     string   `json:"name"`
+	Keys     string   `json:"keys,omitempty"` // glob of keys it's offered for; every key when empty
+	Commands []string `json:"commands"`
+}
+
+// validate reports what is wrong with a configured macro.
+func (mc Macro) validate() error {
+	if mc.Name == "" {
+		return errors.New("every macro needs a name")
+	}
+	if len(mc.Commands) == 0 {
+		return fmt.Errorf("macro %q: needs commands", mc.Name)
+	}
+	for _, line := range mc.Commands {
+		args, err := splitCommandLine(line)
+		if err == nil && len(args) == 0 {
+			err = errors.New("empty command")
+		}
+		if err == nil && consoleRefused[strings.ToUpper(args[0])] != "" {
+			err = errors.New(consoleRefused[strings.ToUpper(args[0])])
+		}
+		if err != nil {
+			return fmt.Errorf("macro %q: %q: %w", mc.Name, line, err)
+		}
+	}
+	return nil
+}
+
+// expand is the macro's commands for key.
+func (mc Macro) expand(key string) [][]string {
+	cmds := make([][]string, 0, len(mc.Commands))
+	for _, line := range mc.Commands {
+		args, _ := splitCommandLine(line) // checked by validate
+		for i, a := range args {
+			args[i] = strings.ReplaceAll(a, "{key}", key)
+		}
+		cmds = append(cmds, args)
+	}
+	return cmds
+}
+
+// MacrosModel is the macro picker for one key.
+type MacrosModel struct {
+	Key    string
+	Items  []Macro
+	Cursor int
+	Active Macro // the macro being confirmed or run
+}
+
+// MacroRequestMsg asks for the macros that apply to Key.
+type MacroRequestMsg struct{ Key string }
+
+// MacroResultMsg reports a macro run: each command that was sent, with its
+// reply. Error is set when one failed, and the rest weren't sent.
+type MacroResultMsg struct {
+	Name  string
+	Key   string
+	Lines []string
+	Error error
+}
+
+func (m Model) startMacros(key string) (tea.Model, tea.Cmd) {
+	var items []Macro
+	for _, mc := range m.Config.Macros {
+		if mc.Keys == "" || redis.GlobMatch(mc.Keys, key) {
+			items = append(items, mc)
+		}
+	}
+	if len(items) == 0 {
+		return m, m.notify("No macros for "+key+`; define them under "macros" in the config`, true)
+	}
+	m.ActiveKey = key
+	m.Macros = MacrosModel{Key: key, Items: items}
+	m.pushState(m.CurrentState)
+	m.CurrentState = StateMacros
+	return m, nil
+}
+
+func handleStateMacrosKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.Macros
+	switch keyMsg.String() {
+	case "esc":
+		m.CurrentState = m.popState()
+	case "up", "k":
+		if p.Cursor > 0 {
+			p.Cursor--
+		}
+	case "down", "j":
+		if p.Cursor < len(p.Items)-1 {
+			p.Cursor++
+		}
+	case "enter":
+		if p.Cursor >= len(p.Items) {
+			break
+		}
+		return m.confirmMacro(p.Items[p.Cursor])
+	}
+	return m, nil
+}
+
+// confirmMacro asks to run mc on m.Macros.Key, unless the session refuses
+// one of its commands. A confirmation or a refusal comes back to the screen
+// it was asked from.
+func (m Model) confirmMacro(mc Macro) (tea.Model, tea.Cmd) {
+	for _, args := range mc.expand(m.Macros.Key) {
+		if reason := m.consoleRefusal(args); reason != "" {
+			return m, m.notify(mc.Name+": "+reason, true)
+		}
+	}
+	m.Macros.Active = mc
+	m.SelectedOp = OpMacro
+	m.pushState(m.CurrentState)
+	m.ask(m.macroDialog())
+	return m, nil
+}
+
+// runMacro sends the commands one at a time, stopping at the first error
+// reply so later steps don't act on a half-done change.
+func runMacro(conn net.Conn, reader *bufio.Reader, name, key string, cmds [][]string) tea.Cmd {
+	return func() tea.Msg {
+		res := MacroResultMsg{Name: name, Key: key}
+		if conn == nil {
+			res.Error = errors.New("no connection to Redis")
+			return res
+		}
+		for _, args := range cmds {
+			replies, err := pipeline(conn, reader, []redis.RedisCmd{{Name: args[0], Args: args[1:]}})
+			if err != nil {
+				_ = conn.Close() // the reply stream may be desynced; reconnect rather than misread
+				res.Error = err
+				return res
+			}
+			line := "› " + formatCommand(redis.RedisCmd{Name: args[0], Args: args[1:]}) + "\n  " + formatReply(replies[0], "  ")
+			res.Lines = append(res.Lines, line)
+			if s, ok := replies[0].(string); ok && redis.IsErrorReply(s) {
+				res.Error = fmt.Errorf("stopped at %s: %s", strings.ToUpper(args[0]), s)
+				return res
+			}
+		}
+		return res
+	}
+}
+
+func handleMacroResult(m Model, msg MacroResultMsg) (tea.Model, tea.Cmd) {
+	m.invalidate(msg.Key)
+	m.popState() // the picker or key menu: esc from the output goes back to the browser
+	m.ActiveTTL = ""
+	m.CopyStatus = ""
+	text := strings.Join(msg.Lines, "\n")
+	switch {
+	case msg.Error == nil:
+		m.Result = textResult(text)
+	case len(msg.Lines) == 0:
+		m.Result = errorResult(msg.Error)
+	default:
+		m.Result = Result{Type: "error", Err: text + "\n\n" + msg.Error.Error()}
+	}
+	m.Result.Command = "macro " + msg.Name
+	m.CurrentState = StateOutput
+	return m, nil
+}
+
+// macroDialog asks before running the active macro: the key, then each
+// command as it will be sent.
+func (m Model) macroDialog() Dialog {
+	mc := m.Macros.Active
+	var lines []string
+	for _, args := range mc.expand(m.Macros.Key) {
+		lines = append(lines, formatCommand(redis.RedisCmd{Name: args[0], Args: args[1:]}))
+	}
+	run := func(m Model) (tea.Model, tea.Cmd) {
+		mc := m.Macros.Active
+		return m.switchToLoadingAndExecute(runMacro(m.Conn, m.Reader, mc.Name, m.Macros.Key, mc.expand(m.Macros.Key)))
+	}
+	return Dialog{Title: "run macro " + mc.Name, Label: "on " + m.Macros.Key + ", send", Value: strings.Join(lines, "\n  "),
+		Destructive: true, Target: m.Macros.Key, Choices: confirmChoice(run)}
+}
+
+func (m Model) macrosView() string {
+	p := m.Macros
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)).Bold(true).Render("MACROS") + dim.Render(" · "+p.Key)
+	rows := make([]string, 0, len(p.Items))
+	for i, mc := range p.Items {
+		marker := "  "
+		if i == p.Cursor {
+			marker = accent.Render("▌ ")
+		}
+		rows = append(rows, marker+text.Render(padCells(mc.Name, 24)+" ")+subtle.Render(truncateText(strings.Join(mc.Commands, "; "), max(m.WindowWidth-32, 20))))
+	}
+
+	body := "  " + title + "\n\n" + strings.Join(rows, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(macrosKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
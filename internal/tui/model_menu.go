@@ -0,0 +1,291 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// updateMenu runs the command menu: enter or a digit starts the selected
+// command's flow, and typing filters the list.
+func updateMenu(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if next, cmd, ok := m.vimKey(keyMsg); ok {
+			return next, cmd
+		}
+		isFiltering := m.MenuList.FilterState() == list.Filtering
+
+		switch keyMsg.String() {
+		case "enter":
+			// While the filter input is active, let the list handle Enter
+			// (it confirms the filter and moves to FilterApplied state).
+			if !isFiltering {
+				selectedItem := m.MenuList.SelectedItem()
+				if selectedItem, ok := selectedItem.(ListItem); ok {
+					m.SelectedOp = ParseOp(selectedItem.title)
+
+					// save state history
+					m.pushState(m.CurrentState)
+					m.Browser.Cache = nil // the command may write anything
+
+					reason := m.proxyRefusal(m.SelectedOp)
+					if reason == "" {
+						reason = m.commandRefusal(m.SelectedOp)
+					}
+					if reason != "" {
+						m.Result = textResult(reason)
+						m.ActiveTTL = ""
+						m.CopyStatus = ""
+						m.CurrentState = StateOutput
+						return m, nil
+					}
+
+					switch m.SelectedOp {
+					case OpSet, OpGet, OpDelete:
+						m.Input.Input.Focus()
+						m.Input.Input.SetValue("") // Clear previous input
+						m.CurrentState = StateInputKey
+						m.Input.Type = InputKey
+					case OpHSet, OpHGet, OpSAdd, OpZAdd, OpRPush, OpLPush:
+						// Key picker: choose an existing key of the matching
+						// type (or "＋ new key…"). Browse via the Explore machinery.
+						m.PickerOp = m.SelectedOp
+						m.Browser.Picking = true
+						m.Browser.PickerType = collectionType(m.SelectedOp)
+						m.Browser.ViewingFields = false
+						m.Browser.Cursor = "0"
+						m.Browser.Pattern = "*"
+						m.SelectedOp = OpExplore
+						scan := m.startKeyScan("*", "0", m.Browser.PickerType)
+						return m.switchToLoadingAndExecute(scan)
+					case OpExport:
+						// Key picker over all keys; selecting one pre-fills a
+						// sensible ./<key>.dump destination path.
+						m.PickerOp = OpExport
+						m.Browser.Picking = true
+						m.Browser.PickerType = "" // any type
+						m.Browser.ViewingFields = false
+						m.Browser.Cursor = "0"
+						m.Browser.Pattern = "*"
+						m.SelectedOp = OpExplore
+						scan := m.startKeyScan("*", "0", "")
+						return m.switchToLoadingAndExecute(scan)
+					case OpExportDB:
+						m.Input.Input.Focus()
+						m.Input.Input.SetValue(fmt.Sprintf("./redis-db%d.json", m.DB))
+						m.Input.Input.CursorEnd()
+						m.Input.Hint = "Destination file (database → JSON):"
+						m.CurrentState = StateInputFilePath
+						m.Input.Type = InputFilePath
+					case OpImport:
+						m.Input.Input.Focus()
+						m.Input.Input.SetValue("")
+						m.Input.Hint = "Source .dump file to restore:"
+						m.CurrentState = StateInputFilePath
+						m.Input.Type = InputFilePath
+					case OpImportDB:
+						m.Input.Input.Focus()
+						m.Input.Input.SetValue("")
+						m.Input.Hint = "Source .json file to import:"
+						m.CurrentState = StateInputFilePath
+						m.Input.Type = InputFilePath
+					case OpExplore:
+						m.Input.Input.Focus()
+						m.Input.Input.SetValue("*") // Default search is everything
+						m.CurrentState = StateInputKey
+						m.Input.Type = InputPattern
+					case OpInfo:
+						cmd := redis.RedisCmd{
+							Name: "INFO",
+						}
+						return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+					case OpBlockingPop:
+						return m.startBlockingForm()
+					case OpXAdd:
+						return m.startXAddForm("")
+					case OpQueues:
+						return m.startQueuesForm()
+					case OpCounters:
+						return m.startCountersForm()
+					case OpFlags:
+						return m.startFlagsForm()
+					case OpLeaderboard:
+						return m.startLeaderboardForm()
+					case OpCompare:
+						return m.startCompareForm()
+					case OpDBDiff:
+						return m.startDBDiffForm()
+					case OpSnapshot:
+						return m.startSnapshot()
+					case OpPersistence:
+						return m.startPersistence()
+					case OpLifecycle:
+						return m.startLifecycleForm()
+					case OpCluster:
+						return m.startCluster()
+					case OpConsole:
+						return m.startConsole()
+					case OpTemplate:
+						return m.startTemplates()
+					case OpConvert:
+						return m.startConvertForm(m.ActiveKey)
+					case OpBulkTTL:
+						return m.startBulkTTLForm()
+					case OpGrowth:
+						return m.startGrowthForm()
+					case OpTTLForecast:
+						return m.startTTLForecastForm()
+					case OpColdKeys:
+						return m.startColdKeysForm()
+					case OpReplace:
+						return m.startReplaceForm()
+					case OpSearch:
+						return m.startSearchForm()
+					case OpAudit:
+						return m.startAudit()
+					case OpGenerate:
+						return m.startGenerateForm()
+					case OpBenchmark:
+						return m.startBenchmarkForm()
+					case OpTimeline:
+						return m.startTimeline("*")
+					case OpTour:
+						return m.startTour()
+					}
+				}
+			}
+		case "q":
+			// q quits directly from the main menu (no confirmation).
+			// While filtering, let the list handle it as filter input.
+			if !isFiltering {
+				return m, tea.Quit
+			}
+		case "esc":
+			// esc cancels the filter while typing, or clears an
+			// already-applied filter — either way, let the list handle
+			// it. isFiltering above only covers the actively-typing case,
+			// so it's checked separately here: without this, esc on a
+			// filtered-but-not-currently-typing menu (i.e. right after
+			// pressing Enter to accept a filter) would do nothing at all,
+			// permanently stranding the menu on the filtered subset with
+			// no way back short of quitting the app.
+			if m.MenuList.FilterState() != list.Unfiltered {
+				break
+			}
+			// On the idle, unfiltered menu esc is a no-op — the menu is
+			// the root screen, nothing to go back to.
+			return m, nil
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			// A digit runs the item shown with that number, as if it had
+			// been selected and Enter pressed. While filtering it's filter
+			// input like any other character.
+			if !isFiltering {
+				n := int(keyMsg.Runes[0] - '0')
+				if n > len(m.MenuList.VisibleItems()) {
+					return m, nil
+				}
+				m.MenuList.Select(n - 1)
+				return updateMenu(m, tea.KeyMsg{Type: tea.KeyEnter})
+			}
+		default:
+			// Any printable character while the filter is not yet active
+			// automatically opens the filter input, then the character(s)
+			// are forwarded so they appear immediately in the search box.
+			// Runes can arrive batched (fast typing, paste, or laggy input)
+			// rather than one KeyMsg per character, so this must not
+			// require exactly one rune or those keystrokes are silently
+			// dropped instead of opening the filter.
+			if !isFiltering && len(keyMsg.Runes) >= 1 {
+				m.MenuList, _ = m.MenuList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+			}
+		}
+	}
+
+	updatedModel, cmd := m.MenuList.Update(msg)
+	m.MenuList = updatedModel
+	return m, cmd
+}
+
+func (m Model) menuScreenView() string {
+	h := m.Help
+	h.Width = m.WindowWidth
+	avail := m.WindowHeight - 4 // header(2) + footer rule(1) + help(1)
+	body := m.headerView() + "\n" + m.menuView(avail)
+	keys := menuKeys
+	keys.Console.SetEnabled(m.Config.VimKeys)
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(keys)
+	return bottomFooter(body, foot, m.WindowHeight)
+}
+
+// menuView renders the main command menu with grouped, spaced sections exactly
+// like the redesign: a "Select a command" hint (or live filter), the promoted
+// EXPLORE row, then each type group under a dim label. The item rows scroll to
+// keep the selection visible when they exceed the available height.
+func (m Model) menuView(avail int) string {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	faint := lipgloss.NewStyle().Foreground(lipgloss.Color(tnFaint))
+
+	var head string
+	switch m.MenuList.FilterState() {
+	case list.Filtering:
+		head = "  " + m.MenuList.FilterInput.View()
+	case list.FilterApplied:
+		// Once a filter is accepted (Enter), the input box above disappears —
+		// without this, "Select a command" would show unchanged and give no
+		// hint that a filter is still narrowing the list, or that esc clears it.
+		head = "  " + dim.Render("Filter: ") + faint.Render(m.MenuList.FilterInput.Value()) + dim.Render("  (esc to clear)")
+	default:
+		head = "  " + dim.Render("Select a command")
+	}
+
+	items := m.MenuList.VisibleItems()
+	filtering := m.MenuList.FilterState() != list.Unfiltered
+	selTitle := ""
+	if sel, ok := m.MenuList.SelectedItem().(ListItem); ok {
+		selTitle = sel.title
+	}
+
+	var lines []string
+	selLine := 0
+	lastGroup := ""
+	for i, it := range items {
+		li, ok := it.(ListItem)
+		if !ok {
+			continue
+		}
+		desc := faint.Render(menuShortcut(i) + li.desc)
+		// Group label above the first item of each group (hidden while filtering).
+		if !filtering && li.group != "" && li.group != lastGroup {
+			lines = append(lines, "  "+dim.Render(li.group))
+			lastGroup = li.group
+		}
+		nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(commandColor(li.title))).Width(menuNameCol)
+		var row string
+		if li.title == selTitle {
+			marker := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render(pointerGlyph)
+			row = marker + nameStyle.Bold(true).Render(li.title) + " " + desc
+			selLine = len(lines)
+		} else {
+			row = "  " + nameStyle.Render(li.title) + " " + desc
+		}
+		lines = append(lines, row, "") // blank line between rows for breathing room
+	}
+
+	// Scroll the item rows to keep the selection visible. head + blank take 2 rows.
+	body := avail - 2
+	if body > 0 && len(lines) > body {
+		start := selLine - body/2
+		if start < 0 {
+			start = 0
+		}
+		if start > len(lines)-body {
+			start = len(lines) - body
+		}
+		lines = lines[start : start+body]
+	}
+	return head + "\n\n" + strings.Join(lines, "\n")
+}
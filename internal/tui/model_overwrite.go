@@ -0,0 +1,38 @@
+package tui
+
+import "github.com/ajxv/redis-tui/internal/redis"
+
+// setCmd is the SET for the value just typed. Editing an open string asks
+// for the value it replaces where the server can give it: SET ... GET
+// returns the old value in the same round trip, so a concurrent writer
+// can't slip in between a read and the overwrite. The plain SET from the
+// menu doesn't, as GET would make it fail on a key of another type instead
+// of replacing it.
+func (m Model) setCmd() redis.RedisCmd {
+	cmd := redis.RedisCmd{Name: "SET", Args: []string{m.ActiveKey, m.ActiveValue}}
+	if m.CaptureOld {
+		cmd.Args = append(cmd.Args, "GET")
+	}
+	return cmd
+}
+
+// captureOverwrite shows the previous value from a SET ... GET reply under
+// the OK the plain SET would have given, and has the audit log note it
+// against the SET.
+func (m *Model) captureOverwrite(reply Result) {
+	cmd := m.setCmd()
+	m.CaptureOld = false
+	if reply.Err != "" {
+		return
+	}
+	old, _ := reply.Reply.(string)
+	if reply.Type == "nil" {
+		old = "(nil)"
+	}
+	m.Audit.Replaced(m.ProfileName, m.RedisAddress, cmd, old)
+	if reply.Type == "nil" {
+		m.Result = reply.withValue("OK\n\nThe key had expired or been deleted; nothing was replaced.")
+		return
+	}
+	m.Result = reply.withValue("OK\n\nPrevious value:\n" + tryPrettyJSON(old))
+}
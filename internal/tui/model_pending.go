@@ -0,0 +1,358 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Enter on a consumer group lists its pending entries, longest idle first,
+// with who holds each and how often it was delivered. Marked entries (or the
+// one under the cursor) can be acknowledged with XACK or handed to another
+// consumer with XCLAIM, after a confirmation.
+
+// pendingLimit caps one XPENDING read; a group further behind than this
+// needs fixing at the consumer, not entry by entry.
+const pendingLimit = 1000
+
+// PendingModel is the state of a group's pending entry screen.
+type PendingModel struct {
+	Key     string
+	Group   string
+	Total   int // the group's pending count, which may pass pendingLimit
+	Entries []PendingEntry
+	Cursor  int
+
+	// The action waiting on its confirmation.
+	IDs      []string
+	Consumer string // XCLAIM
+	MinIdle  int    // ms
+}
+
+// PendingEntry is one row of XPENDING's extended form.
+type PendingEntry struct {
+	ID         string
+	Consumer   string
+	Idle       int // ms
+	Deliveries int
+	Marked     bool
+}
+
+// targets are the IDs of the marked entries, or the cursor's when none is.
+func (p PendingModel) targets() []string {
+	var ids []string
+	for _, e := range p.Entries {
+		if e.Marked {
+			ids = append(ids, e.ID)
+		}
+	}
+	if len(ids) == 0 && p.Cursor < len(p.Entries) {
+		ids = []string{p.Entries[p.Cursor].ID}
+	}
+	return ids
+}
+
+// minIdle is the least idle time among ids' entries.
+func (p PendingModel) minIdle(ids []string) int {
+	least := -1
+	for _, e := range p.Entries {
+		for _, id := range ids {
+			if e.ID == id && (least < 0 || e.Idle < least) {
+				least = e.Idle
+			}
+		}
+	}
+	return max(least, 0)
+}
+
+// startPending opens the pending entries of the selected group.
+func (m Model) startPending() (tea.Model, tea.Cmd) {
+	sel := m.Groups.selected()
+	if sel == nil {
+		return m, nil
+	}
+	m.Pending = PendingModel{Key: m.Groups.Key, Group: sel.Name, Total: sel.Pending}
+	m.pushState(StateGroups)
+	return m.loadPending()
+}
+
+func (m Model) loadPending() (tea.Model, tea.Cmd) {
+	m.SelectedOp = OpPending
+	return m.switchToLoadingAndExecute(m.sendRead(redis.XPendingRange(m.Pending.Key, m.Pending.Group, pendingLimit)))
+}
+
+// parsePending reads XPENDING's extended reply: [id, consumer, idle ms,
+// deliveries] per entry.
+func parsePending(resp any) ([]PendingEntry, error) {
+	items, ok := resp.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected XPENDING reply")
+	}
+	entries := make([]PendingEntry, 0, len(items))
+	for _, it := range items {
+		row, ok := it.([]any)
+		if !ok || len(row) < 4 {
+			return nil, fmt.Errorf("unexpected XPENDING reply")
+		}
+		e := PendingEntry{ID: fmt.Sprint(row[0]), Consumer: fmt.Sprint(row[1])}
+		e.Idle, _ = strconv.Atoi(fmt.Sprint(row[2]))
+		e.Deliveries, _ = strconv.Atoi(fmt.Sprint(row[3]))
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// handlePendingResult shows the entries read, keeping the marks and the
+// cursor's entry of the last read where they are still pending.
+func handlePendingResult(m Model, reply Result, resp any) (tea.Model, tea.Cmd) {
+	if reply.Err != "" {
+		m.Result = reply
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	entries, err := parsePending(resp)
+	if err != nil {
+		m.Result = reply.withValue(err.Error())
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Idle > entries[j].Idle })
+	p := &m.Pending
+	marked, at := map[string]bool{}, ""
+	for i, e := range p.Entries {
+		marked[e.ID] = e.Marked
+		if i == p.Cursor {
+			at = e.ID
+		}
+	}
+	cursor := 0
+	for i := range entries {
+		entries[i].Marked = marked[entries[i].ID]
+		if entries[i].ID == at {
+			cursor = i
+		}
+	}
+	p.Entries, p.Cursor = entries, cursor
+	if len(entries) < pendingLimit {
+		p.Total = len(entries)
+	}
+	m.CurrentState = StatePending
+	return m, nil
+}
+
+func (m Model) confirmPendingAck() (tea.Model, tea.Cmd) {
+	if cmd := m.groupWriteRefused("XACK"); cmd != nil {
+		return m, cmd
+	}
+	m.SelectedOp = OpPendingAck
+	m.Pending.IDs = m.Pending.targets()
+	m.pushState(StatePending)
+	m.ask(m.pendingDialog())
+	return m, nil
+}
+
+func (m Model) startPendingClaim() (tea.Model, tea.Cmd) {
+	if cmd := m.groupWriteRefused("XCLAIM"); cmd != nil {
+		return m, cmd
+	}
+	p := &m.Pending
+	p.IDs = p.targets()
+	m.SelectedOp = OpPendingClaim
+	// The least idle time listed still claims every entry, unless another
+	// consumer picked one up since: then that one is left alone.
+	m.Form = NewForm(
+		fmt.Sprintf("XCLAIM · hand %d of %s's entries to another consumer", len(p.IDs), p.Group),
+		[]string{"consumer", "only entries still idle at least (ms)"},
+		[]string{defaultClaimer, strconv.Itoa(p.minIdle(p.IDs))},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.pushState(StatePending)
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitPendingClaimForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	consumer := values[0]
+	if consumer == "" {
+		m.Form.Err = "a consumer name is required"
+		return m, nil
+	}
+	minIdle, err := strconv.Atoi(values[1])
+	if err != nil || minIdle < 0 {
+		m.Form.Err = "idle time must be a whole number of milliseconds (0 or more)"
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.Pending.Consumer, m.Pending.MinIdle = consumer, minIdle
+	m.pushState(StateForm)
+	m.ask(m.pendingDialog())
+	return m, nil
+}
+
+// pendingDialog asks before acknowledging or claiming the selected entries.
+func (m Model) pendingDialog() Dialog {
+	p := m.Pending
+	d := Dialog{Value: p.Key, Destructive: true, Target: p.Key, Choices: confirmChoice(confirmPendingAction)}
+	if m.SelectedOp == OpPendingAck {
+		d.Title = "confirm XACK"
+		d.Label = fmt.Sprintf("acknowledge %d pending entries of group %s (XACK); they will not be delivered again, on", len(p.IDs), p.Group)
+		return d
+	}
+	d.Title = "confirm XCLAIM"
+	d.Label = fmt.Sprintf("hand %d pending entries of group %s to %s (XCLAIM) on", len(p.IDs), p.Group, p.Consumer)
+	return d
+}
+
+// confirmPendingAction sends the confirmed XACK or XCLAIM.
+func confirmPendingAction(m Model) (tea.Model, tea.Cmd) {
+	p := m.Pending
+	cmd := redis.XAck(p.Key, p.Group, p.IDs...)
+	if m.SelectedOp == OpPendingClaim {
+		cmd = redis.XClaim(p.Key, p.Group, p.Consumer, p.MinIdle, p.IDs...)
+	}
+	return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, cmd, m.ReadTimeout))
+}
+
+// handlePendingActionResult reports how many entries XACK or XCLAIM took
+// (fewer than sent when some were acknowledged or picked up meanwhile),
+// clears the marks and reads the entries again.
+func handlePendingActionResult(m Model, reply Result, resp any) (tea.Model, tea.Cmd) {
+	p := &m.Pending
+	var text string
+	switch {
+	case reply.Err != "":
+		text = reply.Err
+	case m.SelectedOp == OpPendingAck:
+		n, _ := resp.(int)
+		text = fmt.Sprintf("Acknowledged %d of %d entries", n, len(p.IDs))
+		p.Total = max(p.Total-n, 0)
+	default:
+		claimed, _ := resp.([]any)
+		text = fmt.Sprintf("Claimed %d of %d entries for %s", len(claimed), len(p.IDs), p.Consumer)
+	}
+	if reply.Err == "" {
+		for i := range p.Entries {
+			p.Entries[i].Marked = false
+		}
+	}
+	for len(m.StateNavigationHistory) > 0 && m.popState() != StatePending {
+	}
+	toast := m.notify(text, reply.Err != "")
+	model, load := m.loadPending()
+	return model, tea.Batch(load, toast)
+}
+
+// idleText is a pending entry's idle time: ms under a second, else formatTTL.
+func idleText(ms int) string {
+	if ms < 1000 {
+		return fmt.Sprintf("%dms", ms)
+	}
+	return formatTTL(ms / 1000)
+}
+
+func handleStatePendingKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.Pending
+	switch keyMsg.String() {
+	case "esc":
+		// Back to fresh counts: the groups' pending totals may have changed.
+		m.CurrentState = m.popState()
+		return m.loadGroups()
+	case "up", "k":
+		p.Cursor = max(p.Cursor-1, 0)
+	case "down", "j":
+		p.Cursor = min(p.Cursor+1, max(len(p.Entries)-1, 0))
+	case " ":
+		if p.Cursor < len(p.Entries) {
+			p.Entries[p.Cursor].Marked = !p.Entries[p.Cursor].Marked
+			p.Cursor = min(p.Cursor+1, len(p.Entries)-1)
+		}
+	case "a":
+		// Mark all, or clear the marks when all are marked.
+		all := true
+		for _, e := range p.Entries {
+			all = all && e.Marked
+		}
+		for i := range p.Entries {
+			p.Entries[i].Marked = !all
+		}
+	case "r", "ctrl+r", "f5":
+		return m.loadPending()
+	case "x":
+		if len(p.Entries) > 0 {
+			return m.confirmPendingAck()
+		}
+	case "c":
+		if len(p.Entries) > 0 {
+			return m.startPendingClaim()
+		}
+	}
+	return m, nil
+}
+
+func (m Model) pendingView() string {
+	p := m.Pending
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+
+	marked := 0
+	for _, e := range p.Entries {
+		if e.Marked {
+			marked++
+		}
+	}
+	count := fmt.Sprintf("   %d pending", p.Total)
+	if len(p.Entries) < p.Total {
+		count += fmt.Sprintf(", the first %d by ID shown", len(p.Entries))
+	}
+	if marked > 0 {
+		count += fmt.Sprintf(" · %d marked", marked)
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnPurple)).Bold(true).Render("XPENDING") +
+		dim.Render(" · ") + subtle.Render(p.Key) + dim.Render(" · ") + subtle.Render(p.Group) + dim.Render(count)
+
+	width := 8
+	for _, e := range p.Entries {
+		width = max(width, len(e.ID), len(e.Consumer))
+	}
+	rowsAvail := max(m.WindowHeight-12, 3)
+	first := max(p.Cursor-rowsAvail+1, 0)
+	rows := []string{"    " + dim.Render(fmt.Sprintf("%-*s  %-*s  %8s  %10s", width, "entry", width, "consumer", "idle", "deliveries"))}
+	for i := first; i < len(p.Entries) && i < first+rowsAvail; i++ {
+		e := p.Entries[i]
+		marker, mark, style := "  ", "  ", text
+		if i == p.Cursor {
+			marker, style = accent.Render(pointerGlyph), accent.Bold(true)
+		}
+		if e.Marked {
+			mark = lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)).Render("✓ ")
+		}
+		// Idle past XAUTOCLAIM's default threshold is what needs attention.
+		idle := subtle
+		if e.Idle >= defaultMinIdle {
+			idle = lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow))
+		}
+		deliveries := subtle
+		if e.Deliveries > 1 {
+			deliveries = lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow))
+		}
+		rows = append(rows, marker+mark+style.Render(fmt.Sprintf("%-*s", width, e.ID))+"  "+
+			text.Render(fmt.Sprintf("%-*s", width, e.Consumer))+"  "+
+			idle.Render(fmt.Sprintf("%8s", idleText(e.Idle)))+"  "+
+			deliveries.Render(fmt.Sprintf("%10d", e.Deliveries)))
+	}
+	if len(p.Entries) == 0 {
+		rows = append(rows, "  "+dim.Render("nothing pending — every delivered entry was acknowledged"))
+	}
+
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(pendingKeys)
+	return bottomFooter(m.headerView()+"\n\n  "+title+"\n\n"+strings.Join(rows, "\n"), foot, m.WindowHeight)
+}
@@ -0,0 +1,312 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// persistenceInterval is short because the screen's main job after a BGSAVE
+// or BGREWRITEAOF is noticing that it finished.
+const persistenceInterval = time.Second
+
+// PersistenceModel is the state of the RDB/AOF status screen.
+type PersistenceModel struct {
+	Info    map[string]string // INFO persistence fields
+	Sampled time.Time
+	Err     string
+
+	// Watching names the background job started from this screen
+	// ("BGSAVE" or "BGREWRITEAOF") until a sample shows it finished; Notice
+	// is the latest thing worth telling the user about it.
+	Watching string
+	Notice   string
+	Failed   bool // Notice reports a failure
+
+	Active bool
+	Gen    int
+}
+
+// PersistenceSampleMsg carries one INFO persistence reading.
+type PersistenceSampleMsg struct {
+	Gen   int
+	Info  map[string]string
+	Error error
+	Rearm bool // see QueueSampleMsg.Rearm
+}
+
+// PersistenceTickMsg fires every persistenceInterval while the screen is open.
+type PersistenceTickMsg struct{ Gen int }
+
+func (m Model) startPersistence() (tea.Model, tea.Cmd) {
+	m.Persistence = PersistenceModel{Active: true, Gen: m.Persistence.Gen + 1}
+	return m.switchToLoadingAndExecute(samplePersistence(m.Conn, m.Reader, m.Persistence.Gen, true))
+}
+
+func samplePersistence(conn net.Conn, reader *bufio.Reader, gen int, rearm bool) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return PersistenceSampleMsg{Gen: gen, Rearm: rearm, Error: fmt.Errorf("no connection to Redis")}
+		}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{{Name: "INFO", Args: []string{"persistence"}}})
+		if err != nil {
+			return PersistenceSampleMsg{Gen: gen, Rearm: rearm, Error: err}
+		}
+		raw, _ := replies[0].(string)
+		return PersistenceSampleMsg{Gen: gen, Info: parseInfo(raw), Rearm: rearm}
+	}
+}
+
+// parseInfo turns an INFO reply into a field → value map, skipping the
+// "# Section" headings.
+func parseInfo(raw string) map[string]string {
+	info := map[string]string{}
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			info[k] = v
+		}
+	}
+	return info
+}
+
+func persistenceTick(gen int) tea.Cmd {
+	return tea.Tick(persistenceInterval, func(time.Time) tea.Msg { return PersistenceTickMsg{Gen: gen} })
+}
+
+func handlePersistenceSample(m Model, msg PersistenceSampleMsg) (tea.Model, tea.Cmd) {
+	p := &m.Persistence
+	if !p.Active || msg.Gen != p.Gen {
+		return m, nil
+	}
+	var next tea.Cmd
+	if msg.Rearm {
+		next = persistenceTick(p.Gen)
+	}
+
+	if msg.Error != nil {
+		if m.CurrentState == StateLoading {
+			p.Active = false
+			m.Result = errorResult(msg.Error)
+			m.CurrentState = StateOutput
+			return m, nil
+		}
+		p.Err = msg.Error.Error()
+		return m, next
+	}
+
+	p.Err = ""
+	p.Info = msg.Info
+	p.Sampled = time.Now()
+	p.checkFinished()
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StatePersistence
+	}
+	return m, next
+}
+
+// checkFinished turns the watched job's completion into a notice once INFO
+// no longer reports it in progress.
+func (p *PersistenceModel) checkFinished() {
+	var running, status, took string
+	switch p.Watching {
+	case "BGSAVE":
+		running, status, took = "rdb_bgsave_in_progress", "rdb_last_bgsave_status", "rdb_last_bgsave_time_sec"
+	case "BGREWRITEAOF":
+		running, status, took = "aof_rewrite_in_progress", "aof_last_bgrewrite_status", "aof_last_rewrite_time_sec"
+	default:
+		return
+	}
+	if p.Info[running] == "1" {
+		return
+	}
+	p.Failed = p.Info[status] != "ok"
+	p.Notice = fmt.Sprintf("%s finished: %s in %s s", p.Watching, p.Info[status], p.Info[took])
+	p.Watching = ""
+}
+
+func handlePersistenceTick(m Model, msg PersistenceTickMsg) (tea.Model, tea.Cmd) {
+	p := m.Persistence
+	if !p.Active || msg.Gen != p.Gen {
+		return m, nil
+	}
+	if m.CurrentState != StatePersistence {
+		return m, persistenceTick(p.Gen)
+	}
+	return m, m.exec(samplePersistence(m.Conn, m.Reader, p.Gen, true))
+}
+
+// busy reports why a new background job can't start right now, if it can't.
+// Redis would only queue ("schedule") a BGSAVE behind a running rewrite, and
+// refuses outright the other way round, so both are blocked up front.
+func (p PersistenceModel) busy() string {
+	switch {
+	case p.Watching != "":
+		return p.Watching + " is still running"
+	case p.Info["rdb_bgsave_in_progress"] == "1":
+		return "a BGSAVE is already in progress"
+	case p.Info["aof_rewrite_in_progress"] == "1":
+		return "an AOF rewrite is already in progress"
+	}
+	return ""
+}
+
+func handleStatePersistenceKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.Persistence
+	switch keyMsg.String() {
+	case "esc":
+		p.Active = false
+		m.CurrentState = m.popState()
+	case "r":
+		return m, m.exec(samplePersistence(m.Conn, m.Reader, p.Gen, false))
+	case "b", "a":
+		if m.ReadOnly {
+			p.Notice, p.Failed = "read-only session: background saves are disabled", true
+			return m, nil
+		}
+		if reason := p.busy(); reason != "" {
+			p.Notice, p.Failed = reason, true
+			return m, nil
+		}
+		m.SelectedOp = OpBGSave
+		d := Dialog{Title: "confirm background save", Label: "fork and write an RDB snapshot of", Value: m.RedisAddress}
+		if keyMsg.String() == "a" {
+			m.SelectedOp = OpBGRewriteAOF
+			d.Title, d.Label = "confirm AOF rewrite", "fork and rewrite the append-only file of"
+		}
+		// A fork is heavy but loses nothing, so it isn't a warning.
+		d.Target = m.RedisAddress
+		d.Choices = []DialogChoice{{Key: "y", Label: "confirm", Run: func(m Model) (tea.Model, tea.Cmd) {
+			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: m.SelectedOp.String()}, m.ReadTimeout))
+		}}}
+		m.pushState(StatePersistence)
+		m.ask(d)
+	}
+	return m, nil
+}
+
+// handleBackgroundJobResult returns to the status screen after BGSAVE or
+// BGREWRITEAOF and starts watching the job. Redis reports refusals ("ERR
+// Background save already in progress") as plain replies, so anything that
+// isn't "Background … started/scheduled" is shown as a failure.
+func handleBackgroundJobResult(m Model, reply any) (tea.Model, tea.Cmd) {
+	m.CurrentState = m.popState()
+	p := &m.Persistence
+	text, _ := reply.(string)
+	if strings.HasPrefix(text, "Background") {
+		p.Watching, p.Notice, p.Failed = m.SelectedOp.String(), text, false
+	} else {
+		p.Notice, p.Failed = text, true
+	}
+	if m.CurrentState != StatePersistence || !p.Active {
+		return m, nil
+	}
+	return m, m.exec(samplePersistence(m.Conn, m.Reader, p.Gen, false))
+}
+
+// formatUnixTime renders a Unix timestamp from INFO with how long ago it was.
+func formatUnixTime(s string) string {
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || sec <= 0 {
+		return "never"
+	}
+	t := time.Unix(sec, 0)
+	return fmt.Sprintf("%s (%s ago)", t.Format("2006-01-02 15:04:05"), time.Since(t).Truncate(time.Second))
+}
+
+// formatByteSize renders a byte count from INFO in binary units.
+func formatByteSize(s string) string {
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	i := 0
+	for n >= 1024 && i < len(units)-1 {
+		n /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f B", n)
+	}
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
+
+func (m Model) persistenceView() string {
+	p := m.Persistence
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+	yellow := lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow))
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnInfo)).Bold(true).Render("PERSISTENCE")
+	if !p.Sampled.IsZero() {
+		title += dim.Render("   sampled " + p.Sampled.Format("15:04:05"))
+	}
+	if p.Err != "" {
+		title += "   " + red.Render("✗ "+p.Err)
+	}
+
+	row := func(label, value string) string {
+		return "    " + subtle.Render(fmt.Sprintf("%-18s", label)) + value
+	}
+	// job renders a background job's state: running, or how the last run went.
+	job := func(running, status, took string) string {
+		if p.Info[running] == "1" {
+			return yellow.Render("● in progress…")
+		}
+		st := p.Info[status]
+		if st == "" {
+			return dim.Render("—")
+		}
+		style := green
+		if st != "ok" {
+			style = red
+		}
+		return style.Render("last "+st) + dim.Render(" · took "+p.Info[took]+" s")
+	}
+
+	lines := []string{
+		"  " + text.Bold(true).Render("RDB"),
+		row("last save", text.Render(formatUnixTime(p.Info["rdb_last_save_time"]))),
+		row("changes since", text.Render(p.Info["rdb_changes_since_last_save"])),
+		row("bgsave", job("rdb_bgsave_in_progress", "rdb_last_bgsave_status", "rdb_last_bgsave_time_sec")),
+		"",
+		"  " + text.Bold(true).Render("AOF"),
+	}
+	if p.Info["aof_enabled"] == "1" {
+		lines = append(lines,
+			row("enabled", green.Render("yes")),
+			row("current size", text.Render(formatByteSize(p.Info["aof_current_size"]))),
+			row("size after rewrite", text.Render(formatByteSize(p.Info["aof_base_size"]))))
+	} else {
+		lines = append(lines, row("enabled", dim.Render("no")))
+	}
+	lines = append(lines, row("rewrite", job("aof_rewrite_in_progress", "aof_last_bgrewrite_status", "aof_last_rewrite_time_sec")))
+
+	if p.Notice != "" {
+		style := subtle
+		if p.Failed {
+			style = red
+		}
+		lines = append(lines, "", "  "+style.Render(p.Notice))
+	}
+
+	body := "  " + title + "\n\n" + strings.Join(lines, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(persistenceKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
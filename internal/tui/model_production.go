@@ -0,0 +1,208 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// A profile marked "production": true is one a slip of the finger must not
+// reach. Every screen carries a red banner; every write waits on the
+// confirmation screen until the key it touches (or the pattern, address or
+// database, for writes without one key) is typed out, so a second look is
+// forced rather than a reflexive y; and FLUSHALL, FLUSHDB, CONFIG SET and
+// SHUTDOWN can't be sent at all.
+
+// ProdConfirm is the typed confirmation in progress on a production profile.
+type ProdConfirm struct {
+	Armed  string // the dialog choice pressed, waiting for the target
+	Typed  string // what has been typed so far
+	Passed bool   // the next confirmation or held write goes through
+}
+
+// productionRefused are the commands a production profile never sends.
+var productionRefused = map[string]bool{"FLUSHALL": true, "FLUSHDB": true, "SHUTDOWN": true, "CONFIG SET": true}
+
+// productionRefusal explains why args can't be sent on a production
+// profile, or returns "".
+func (m Model) productionRefusal(args []string) string {
+	if !m.Production || len(args) == 0 {
+		return ""
+	}
+	name := strings.ToUpper(args[0])
+	if len(args) > 1 && name == "CONFIG" {
+		name += " " + strings.ToUpper(args[1])
+	}
+	if !productionRefused[name] {
+		return ""
+	}
+	return fmt.Sprintf("production profile %q: %s is disabled", m.ProfileName, name)
+}
+
+// productionGuarded reports whether the dialog on show needs its target
+// typed: it has one unless it changes nothing.
+func (m Model) productionGuarded() bool {
+	return m.Production && m.productionTarget() != ""
+}
+
+// productionTarget is what must be typed to confirm the write on show.
+func (m Model) productionTarget() string {
+	return m.dialog().Target
+}
+
+// orDB is pattern, or the database's name when the write has none.
+func (m Model) orDB(pattern string) string {
+	if pattern == "" {
+		return fmt.Sprintf("db%d", m.DB)
+	}
+	return pattern
+}
+
+// productionWrite reports whether msg would write without passing the
+// confirmation screen, with what it does and the key it touches.
+func (m Model) productionWrite(msg tea.Msg) (action, target string, ok bool) {
+	switch msg := msg.(type) {
+	case InputCompleteMsg:
+		switch {
+		case msg.Type == InputValue:
+			switch m.SelectedOp {
+			case OpSet, OpHSet, OpZAdd, OpLSet, OpRPush, OpLPush, OpSAdd, OpQueuePush, OpRename, OpExpirySet:
+				return m.SelectedOp.String(), m.ActiveKey, true
+			}
+		case msg.Type == InputFilePath && (m.SelectedOp == OpImport || m.SelectedOp == OpImportDB):
+			return "import " + msg.Value + " into", m.orDB(""), true
+		case msg.Type == InputFilePath && m.SelectedOp == OpImportField:
+			return "import " + msg.Value + " into", m.ActiveKey, true
+		}
+	case AddItemMsg:
+		return "add to", msg.Key, true
+	case FormSubmitMsg:
+		switch m.SelectedOp {
+		case OpTemplate, OpCreateKey:
+			return "create", msg.Values[0], true
+		case OpGenerate:
+			return "generate keys as", msg.Values[0], true
+		case OpBenchmark:
+			return "benchmark SET on", benchKey, true
+		case OpXAdd:
+			return "XADD to", msg.Values[0], true
+		case OpGroupCreate:
+			return "XGROUP CREATE " + msg.Values[0] + " on", m.Groups.Key, true
+		case OpLeaderIncr:
+			return "ZINCRBY " + msg.Values[0] + " on", m.Leader.Key, true
+		}
+	}
+	return "", "", false
+}
+
+// holdForProduction keeps back a write that has no confirmation screen and
+// asks for its key to be typed first. It reports whether msg was held.
+func (m *Model) holdForProduction(msg tea.Msg) bool {
+	action, target, ok := m.productionWrite(msg)
+	if !ok {
+		return false
+	}
+	if m.Prod.Passed {
+		m.Prod = ProdConfirm{}
+		return false
+	}
+	m.Prod = ProdConfirm{Armed: "y"}
+	m.pushState(m.CurrentState)
+	m.ask(Dialog{Title: "confirm write", Label: action, Value: target, Destructive: true, Target: target,
+		Choices: confirmChoice(func(m Model) (tea.Model, tea.Cmd) {
+			m.Prod.Passed = true
+			m.CurrentState = m.popState()
+			return m.Update(msg)
+		})})
+	return true
+}
+
+// handleProductionConfirmKey drives a confirmation screen on a production
+// profile: y (or d) arms it as usual, then the target is typed and Enter
+// confirms; Esc cancels at any point.
+func handleProductionConfirmKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.Prod
+	if keyMsg.String() == "esc" || p.Armed == "" && strings.EqualFold(keyMsg.String(), "n") {
+		m.Prod = ProdConfirm{}
+		m.Dialog = Dialog{}
+		m.CurrentState = m.popState()
+		return m, nil
+	}
+	if p.Armed == "" {
+		// Enter doesn't arm the default: a write needs its own key first.
+		if c, outcome := m.dialog().Update(keyMsg); outcome == DialogChosen && keyMsg.Type != tea.KeyEnter {
+			p.Armed = c.Key
+		}
+		return m, nil
+	}
+	switch keyMsg.Type {
+	case tea.KeyRunes, tea.KeySpace:
+		p.Typed += string(keyMsg.Runes)
+	case tea.KeyBackspace:
+		if r := []rune(p.Typed); len(r) > 0 {
+			p.Typed = string(r[:len(r)-1])
+		}
+	case tea.KeyEnter:
+		if p.Typed != m.productionTarget() {
+			return m, nil
+		}
+		armed := p.Armed
+		m.Prod = ProdConfirm{Passed: true}
+		return handleStateConfirmationKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(armed)})
+	}
+	return m, nil
+}
+
+// productionBanner replaces the header's rule on a production profile.
+func (m Model) productionBanner(w int) string {
+	text := fmt.Sprintf(" PRODUCTION · %s · writes need the key typed · FLUSH*, CONFIG SET and SHUTDOWN disabled", m.ProfileName)
+	return lipgloss.NewStyle().Background(lipgloss.Color(tnRed)).Foreground(lipgloss.Color(tnBase)).Bold(true).
+		Render(padCells(truncateText(text, w), w))
+}
+
+// productionPrompt is the confirmation screen's line asking for the target.
+func (m Model) productionPrompt() string {
+	target := m.productionTarget()
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	if m.Prod.Armed == "" {
+		return subtle.Render("production: after y, type ") + text.Render(target) + subtle.Render(" and press enter")
+	}
+	typed := text.Render(m.Prod.Typed + "█")
+	if !strings.HasPrefix(target, m.Prod.Typed) {
+		typed = lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render(m.Prod.Typed + "█")
+	}
+	return subtle.Render("production: type ") + text.Render(target) + subtle.Render(" to confirm › ") + typed
+}
+
+// consoleWriteTarget is what must be typed to send a write from the
+// console: its key, for the commands that take one first, or else the
+// command's name.
+func consoleWriteTarget(args []string) string {
+	name := strings.ToUpper(args[0])
+	switch name {
+	case "ACL", "CLUSTER", "CONFIG", "DEBUG", "FUNCTION", "SCRIPT", "XGROUP", "BITOP", "SWAPDB", "MIGRATE",
+		"EVAL", "EVALSHA", "FCALL", "BLMPOP", "BZMPOP", "LMPOP", "ZMPOP":
+		return name
+	}
+	if len(args) > 1 {
+		return args[1]
+	}
+	return name
+}
+
+// confirmConsoleWrite takes the line typed after a console write was held:
+// the write's target sends it, anything else drops it.
+func (m Model) confirmConsoleWrite(line string) (tea.Model, tea.Cmd) {
+	c := &m.Console
+	args, target := c.Confirm, consoleWriteTarget(c.Confirm)
+	c.Confirm = nil
+	if line != target {
+		c.addLog(ConsoleEntry{Command: line, Reply: fmt.Sprintf("doesn't match %s; %s not sent", target, strings.ToUpper(args[0])), Failed: true})
+		return m, nil
+	}
+	c.addLog(ConsoleEntry{Command: strings.Join(args, " ")})
+	return m, m.sendConsole(args)
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Proxy mode ("proxy": true in a profile) keeps the TUI usable behind
+// proxies such as Twemproxy, which forward single-key commands only. Some of
+// them close the connection on anything else, so the commands below are
+// never sent: the handshake skips SELECT and CLIENT, EXPLORE lists keys from
+// the profile's proxy_keys (or KEYS within a namespace) instead of SCAN, and
+// the tools built on the rest are refused up front. So are the parts of
+// other screens that would need them: QUEUES and SNAPSHOT globs (see
+// proxyGlob), live refresh (SUBSCRIBE) and following a stream (XREAD BLOCK).
+
+// proxyRefusedOps are the menu tools that can't work without SCAN,
+// subscriptions, blocking pops or cluster commands.
+var proxyRefusedOps = map[Op]string{
+	OpBlockingPop: "blocking pops",
+	OpCounters:    "SCAN",
+	OpFlags:       "SCAN",
+	OpDBDiff:      "SCAN",
+	OpExportDB:    "SCAN",
+	OpBulkTTL:     "SCAN",
+	OpGrowth:      "SCAN",
+	OpTTLForecast: "SCAN",
+	OpColdKeys:    "SCAN",
+	OpReplace:     "SCAN",
+	OpSearch:      "SCAN",
+	OpTimeline:    "PSUBSCRIBE",
+	OpCluster:     "CLUSTER commands",
+}
+
+// proxyRefusedCommands are the console commands proxies don't forward.
+var proxyRefusedCommands = map[string]bool{
+	"SELECT": true, "SCAN": true, "CLIENT": true, "SWAPDB": true, "MOVE": true,
+	"MULTI": true, "EXEC": true, "DISCARD": true, "WATCH": true, "UNWATCH": true,
+	"BLPOP": true, "BRPOP": true, "BLMOVE": true, "BZPOPMIN": true, "BZPOPMAX": true,
+}
+
+// proxyNeeds explains, in proxy mode, why something that needs what names
+// is unavailable.
+func (m Model) proxyNeeds(what string) string {
+	return fmt.Sprintf("%s, which proxies don't forward (profile %q has \"proxy\": true)", what, m.ProfileName)
+}
+
+// proxyGlob explains, in proxy mode, why the first glob among specs can't be
+// expanded; "" when there is none, or outside proxy mode.
+func (m Model) proxyGlob(specs []string) string {
+	if !m.Proxy {
+		return ""
+	}
+	for _, s := range specs {
+		if strings.ContainsAny(s, "*?[") {
+			return fmt.Sprintf("list the keys by name: %s needs %s", s, m.proxyNeeds("SCAN"))
+		}
+	}
+	return ""
+}
+
+// proxyRefusal explains why op is unavailable in proxy mode, or returns "".
+func (m Model) proxyRefusal(op Op) string {
+	if !m.Proxy {
+		return ""
+	}
+	if needs, ok := proxyRefusedOps[op]; ok {
+		return fmt.Sprintf("%s is disabled: it needs %s.", op, m.proxyNeeds(needs))
+	}
+	return ""
+}
+
+// scanKeys lists one page of keys matching pattern, of wantType if set. Only
+// SCAN pages; the proxy-mode listing is returned whole, with cursor "0".
+func (m Model) scanKeys(pattern, cursor, wantType string) tea.Cmd {
+	count := m.Browser.ScanHint
+	if cursor == "0" || cursor == "" || count <= 0 {
+		count = firstScanCount(m.scanCountMax())
+	}
+	conn, reader := m.readConn()
+	switch {
+	case m.Proxy:
+		return proxyKeys(conn, reader, m.ProxyKeys, pattern, wantType)
+	case wantType != "":
+		return scanRedisKeysOfType(conn, reader, pattern, cursor, wantType, count)
+	}
+	return scanRedisKeys(conn, reader, pattern, cursor, count)
+}
+
+// maxProxyKeys caps a KEYS listing, which unlike SCAN arrives in one reply.
+const maxProxyKeys = 1000
+
+// proxyKeys lists the keys matching pattern without SCAN: from known when
+// the profile lists its keys, otherwise with KEYS, which is only sent for a
+// pattern with a literal prefix so it stays within one namespace. Keys that
+// don't exist are dropped.
+func proxyKeys(conn net.Conn, reader *bufio.Reader, known []string, pattern, wantType string) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return RedisResultMsg{Error: fmt.Errorf("no connection to Redis")}
+		}
+		var names []string
+		if len(known) > 0 {
+			for _, k := range known {
+				if redis.GlobMatch(pattern, k) {
+					names = append(names, k)
+				}
+			}
+		} else {
+			if pattern == "" || strings.IndexAny(pattern, "*?[\\") == 0 {
+				return RedisResultMsg{Error: fmt.Errorf("proxy mode lists keys with KEYS: give a namespace such as user:* rather than %q, or list the keys in the profile's proxy_keys", pattern)}
+			}
+			resp, err := readResp(conn, reader, redis.RedisCmd{Name: "KEYS", Args: []string{pattern}})
+			if err != nil {
+				return RedisResultMsg{Error: err}
+			}
+			if names, err = redis.AsStringSlice(resp); err != nil {
+				return RedisResultMsg{Error: fmt.Errorf("KEYS failed: %w", err)}
+			}
+		}
+		if len(names) > maxProxyKeys {
+			names = names[:maxProxyKeys]
+		}
+
+		var cmds []redis.RedisCmd
+		for _, k := range names {
+			cmds = append(cmds, redis.Type(k), redis.TTL(k))
+		}
+		replies, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			return RedisResultMsg{Error: err}
+		}
+		var keys []list.Item
+		for i, k := range names {
+			typ, _ := redis.AsString(replies[2*i])
+			if typ == "none" || typ == "" || (wantType != "" && typ != wantType) {
+				continue
+			}
+			ttl, err := redis.AsInt(replies[2*i+1])
+			if err != nil {
+				ttl = -1
+			}
+			keys = append(keys, ListItem{title: k, desc: typ, ttl: ttl})
+		}
+		return RedisResultMsg{Result: ScanResult{Cursor: "0", Keys: keys}}
+	}
+}
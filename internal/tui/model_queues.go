@@ -0,0 +1,368 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	maxQueues     = 50 // lists resolved from the dashboard's glob patterns
+	queueHistory  = 60 // length samples kept per queue for the sparkline
+	queueNameCol  = 24
+	queueSparkCol = 30
+)
+
+// QueuesModel is the state of the queue dashboard. Queues are assumed to
+// follow the usual LPUSH-producer / (B)RPOP-consumer convention, so the
+// oldest job sits at the tail — which is what the preview shows and what the
+// pop action removes.
+type QueuesModel struct {
+	Specs    []string // literal keys or glob patterns, as entered
+	Interval time.Duration
+	Keys     []string // resolved list keys
+	Lengths  map[string][]float64
+	Oldest   map[string]string
+	Cursor   int
+	Sampled  time.Time
+	Err      string
+
+	// Active keeps the refresh tick alive while the dashboard is open (also
+	// while a push/pop prompt sits on top of it); Gen retires the tick chain
+	// of a previous dashboard session.
+	Active bool
+	Gen    int
+}
+
+// QueueSampleMsg carries one sample of every queue's length and tail.
+type QueueSampleMsg struct {
+	Gen     int
+	Keys    []string // non-nil when the specs were (re-)resolved
+	Lengths map[string]int
+	Oldest  map[string]string
+	Error   error
+	// Rearm marks the sample that owns the refresh chain, so on-demand
+	// samples (after a push, or 'r') don't start a second one.
+	Rearm bool
+}
+
+// QueueTickMsg fires every Interval while the dashboard is active.
+type QueueTickMsg struct{ Gen int }
+
+func (m Model) startQueuesForm() (tea.Model, tea.Cmd) {
+	m.Form = NewForm(
+		"QUEUES · list-based job queue dashboard",
+		[]string{"queue keys (comma-separated, globs like queue:* allowed)", "refresh every (seconds)"},
+		[]string{"queue:*", "2"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitQueuesForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	var specs []string
+	for _, s := range strings.Split(values[0], ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			specs = append(specs, s)
+		}
+	}
+	if len(specs) == 0 {
+		m.Form.Err = "enter at least one queue key or pattern"
+		return m, nil
+	}
+	if reason := m.proxyGlob(specs); reason != "" {
+		m.Form.Err = reason
+		return m, nil
+	}
+	secs, err := strconv.Atoi(values[1])
+	if err != nil || secs <= 0 {
+		m.Form.Err = "refresh interval must be a positive number of seconds"
+		return m, nil
+	}
+
+	m.Form.Err = ""
+	m.Queues = QueuesModel{
+		Specs:    specs,
+		Interval: time.Duration(secs) * time.Second,
+		Lengths:  map[string][]float64{},
+		Oldest:   map[string]string{},
+		Active:   true,
+		Gen:      m.Queues.Gen + 1,
+	}
+	m.pushState(StateForm)
+	return m.switchToLoadingAndExecute(sampleQueues(m.Conn, m.Reader, specs, nil, m.scanCountMax(), m.Queues.Gen, true))
+}
+
+func queueTick(gen int, every time.Duration) tea.Cmd {
+	return tea.Tick(every, func(time.Time) tea.Msg { return QueueTickMsg{Gen: gen} })
+}
+
+// sampleQueues reads LLEN and the tail element of every queue in one
+// pipelined batch. When keys is nil the specs are resolved first (globs via
+// SCAN, keeping only list-typed keys).
+func sampleQueues(conn net.Conn, reader *bufio.Reader, specs, keys []string, scanCount, gen int, rearm bool) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return QueueSampleMsg{Gen: gen, Rearm: rearm, Error: fmt.Errorf("no connection to Redis")}
+		}
+		var resolved []string
+		if keys == nil {
+			var err error
+			resolved, err = resolveQueueKeys(conn, reader, specs, scanCount)
+			if err != nil {
+				return QueueSampleMsg{Gen: gen, Rearm: rearm, Error: err}
+			}
+			keys = resolved
+		}
+
+		var cmds []redis.RedisCmd
+		for _, k := range keys {
+			cmds = append(cmds,
+				redis.LLen(k),
+				redis.LIndex(k, -1))
+		}
+		replies, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			return QueueSampleMsg{Gen: gen, Rearm: rearm, Error: err}
+		}
+		lengths := make(map[string]int, len(keys))
+		oldest := make(map[string]string, len(keys))
+		for i, k := range keys {
+			if n, ok := replies[2*i].(int); ok {
+				lengths[k] = n
+			}
+			if s, ok := replies[2*i+1].(string); ok && s != "(nil)" {
+				oldest[k] = s
+			}
+		}
+		return QueueSampleMsg{Gen: gen, Keys: resolved, Lengths: lengths, Oldest: oldest, Rearm: rearm}
+	}
+}
+
+// resolveQueueKeys expands the dashboard specs into list keys: literal names
+// are kept as-is (an empty queue doesn't exist in Redis but is still worth
+// watching), glob patterns are scanned and filtered to lists.
+func resolveQueueKeys(conn net.Conn, reader *bufio.Reader, specs []string, scanCount int) ([]string, error) {
+	seen := map[string]bool{}
+	var keys []string
+	for _, spec := range specs {
+		if !strings.ContainsAny(spec, "*?[") {
+			if !seen[spec] {
+				seen[spec] = true
+				keys = append(keys, spec)
+			}
+			continue
+		}
+		matched, err := scanAllKeys(conn, reader, spec, maxQueues, scanCount)
+		if err != nil {
+			return nil, err
+		}
+		var typeCmds []redis.RedisCmd
+		for _, k := range matched {
+			typeCmds = append(typeCmds, redis.Type(k))
+		}
+		types, err := pipeline(conn, reader, typeCmds)
+		if err != nil {
+			return nil, err
+		}
+		for i, k := range matched {
+			if t, _ := types[i].(string); t == "list" && !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	if len(keys) > maxQueues {
+		keys = keys[:maxQueues]
+	}
+	return keys, nil
+}
+
+func handleQueueSample(m Model, msg QueueSampleMsg) (tea.Model, tea.Cmd) {
+	q := &m.Queues
+	if !q.Active || msg.Gen != q.Gen {
+		return m, nil
+	}
+	var next tea.Cmd
+	if msg.Rearm {
+		next = queueTick(q.Gen, q.Interval)
+	}
+
+	if msg.Error != nil {
+		if m.CurrentState == StateLoading {
+			q.Active = false
+			m.Result = errorResult(msg.Error)
+			m.CurrentState = StateOutput
+			return m, nil
+		}
+		q.Err = msg.Error.Error()
+		return m, next
+	}
+
+	q.Err = ""
+	if msg.Keys != nil {
+		q.Keys = msg.Keys
+		if q.Cursor >= len(q.Keys) {
+			q.Cursor = 0
+		}
+	}
+	for _, k := range q.Keys {
+		hist := append(q.Lengths[k], float64(msg.Lengths[k]))
+		if len(hist) > queueHistory {
+			hist = hist[len(hist)-queueHistory:]
+		}
+		q.Lengths[k] = hist
+	}
+	q.Oldest = msg.Oldest
+	q.Sampled = time.Now()
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateQueues
+	}
+	return m, next
+}
+
+// handleQueueTick samples only while the dashboard is on screen — the shared
+// connection belongs to whatever prompt is on top otherwise — but keeps the
+// tick chain alive so refreshing resumes on return.
+func handleQueueTick(m Model, msg QueueTickMsg) (tea.Model, tea.Cmd) {
+	q := m.Queues
+	if !q.Active || msg.Gen != q.Gen {
+		return m, nil
+	}
+	if m.CurrentState != StateQueues {
+		return m, queueTick(q.Gen, q.Interval)
+	}
+	return m, m.exec(sampleQueues(m.Conn, m.Reader, q.Specs, q.Keys, m.scanCountMax(), q.Gen, true))
+}
+
+func (q QueuesModel) selected() (string, bool) {
+	if q.Cursor < 0 || q.Cursor >= len(q.Keys) {
+		return "", false
+	}
+	return q.Keys[q.Cursor], true
+}
+
+func handleStateQueuesKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	q := &m.Queues
+	switch keyMsg.String() {
+	case "esc":
+		q.Active = false
+		m.CurrentState = m.popState()
+	case "up", "k":
+		if q.Cursor > 0 {
+			q.Cursor--
+		}
+	case "down", "j":
+		if q.Cursor < len(q.Keys)-1 {
+			q.Cursor++
+		}
+	case "r":
+		return m, m.exec(sampleQueues(m.Conn, m.Reader, q.Specs, nil, m.scanCountMax(), q.Gen, false))
+	case "p":
+		key, ok := q.selected()
+		if !ok {
+			break
+		}
+		m.ActiveKey = key
+		m.SelectedOp = OpQueuePush
+		m.Input.Input.SetValue("")
+		m.Input.Input.Focus()
+		m.Input.Type = InputValue
+		m.Input.Hint = "Value to LPUSH onto " + key + ":"
+		m.pushState(StateQueues)
+		m.CurrentState = StateInputValue
+	case "o":
+		key, ok := q.selected()
+		if !ok {
+			break
+		}
+		m.ActiveKey = key
+		m.SelectedOp = OpQueuePop
+		m.pushState(StateQueues)
+		m.ask(Dialog{
+			Title: "confirm delete", Label: "pop the oldest job (RPOP) from", Value: key, Destructive: true, Target: key,
+			Choices: confirmChoice(func(m Model) (tea.Model, tea.Cmd) {
+				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: m.SelectedOp.String(), Args: []string{m.ActiveKey}}, m.ReadTimeout))
+			}),
+		})
+	}
+	return m, nil
+}
+
+// handleQueuePushResult finishes a push from the dashboard: straight back to the
+// dashboard with a fresh sample, rather than a bare integer reply screen.
+func handleQueuePushResult(m Model) (tea.Model, tea.Cmd) {
+	m.CurrentState = m.popState()
+	q := m.Queues
+	if m.CurrentState != StateQueues || !q.Active {
+		return m, nil
+	}
+	return m, m.exec(sampleQueues(m.Conn, m.Reader, q.Specs, q.Keys, m.scanCountMax(), q.Gen, false))
+}
+
+func (m Model) queuesView() string {
+	q := m.Queues
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	faint := lipgloss.NewStyle().Foreground(lipgloss.Color(tnFaint))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	purple := lipgloss.NewStyle().Foreground(lipgloss.Color(tnPurple))
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnPurple)).Bold(true).Render("QUEUES") +
+		dim.Render(fmt.Sprintf(" · %d lists · every %s", len(q.Keys), q.Interval))
+	if !q.Sampled.IsZero() {
+		title += dim.Render("   sampled " + q.Sampled.Format("15:04:05"))
+	}
+	if q.Err != "" {
+		title += "   " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render("✗ "+q.Err)
+	}
+
+	w := m.WindowWidth
+	if w <= 0 {
+		w = 80
+	}
+	previewCol := w - 2 - 2 - queueNameCol - 8 - queueSparkCol - 6
+	if previewCol < 10 {
+		previewCol = 10
+	}
+
+	head := "  " + dim.Render(fmt.Sprintf("%-*s %7s   %-*s   %s", queueNameCol, "queue", "len", queueSparkCol, "trend", "oldest (tail)"))
+	rows := []string{head}
+	for i, k := range q.Keys {
+		hist := q.Lengths[k]
+		n := 0
+		if len(hist) > 0 {
+			n = int(hist[len(hist)-1])
+		}
+		name := padCells(k, queueNameCol)
+		marker := "  "
+		nameView := text.Render(name)
+		if i == q.Cursor {
+			marker = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Render(pointerGlyph)
+			nameView = lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true).Render(name)
+		}
+		oldest := faint.Render("—")
+		if v, ok := q.Oldest[k]; ok {
+			oldest = subtle.Render(truncateText(strconv.Quote(v), previewCol))
+		}
+		spark := fmt.Sprintf("%-*s", queueSparkCol, sparkline(hist, queueSparkCol))
+		rows = append(rows, marker+nameView+" "+text.Render(fmt.Sprintf("%7d", n))+"   "+purple.Render(spark)+"   "+oldest)
+	}
+	if len(q.Keys) == 0 {
+		rows = append(rows, "  "+dim.Render("no list keys matched "+strings.Join(q.Specs, ", ")))
+	}
+
+	body := "  " + title + "\n\n" + strings.Join(rows, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(queuesKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
@@ -0,0 +1,237 @@
+package tui
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxReconnectAttempts is how many failed attempts in a row are retried on
+// their own before the user is asked what to do.
+const maxReconnectAttempts = 8
+
+// ReconnectModel is the state of the "connection lost" screen.
+type ReconnectModel struct {
+	At         time.Time // when the next automatic attempt fires
+	Err        string    // why the last attempt failed
+	Connecting bool      // an attempt is in flight
+	GaveUp     bool      // maxReconnectAttempts reached; waiting for the user
+	Gen        int       // bumped on every manual retry so stale ticks are dropped
+	Editing    bool      // the "change server" input is open
+	Input      textinput.Model
+	Notice     string // why the typed server was rejected
+}
+
+// withJitter spreads d by up to 25% so tabs (or several users) that lost the
+// same server don't all hammer it in lockstep.
+func withJitter(d time.Duration) time.Duration {
+	return d + time.Duration(rand.Int64N(int64(d)/4+1))
+}
+
+// reconnectTick wakes the reconnect screen at least once a second so the
+// countdown stays current; the attempt itself fires once At has passed.
+func reconnectTick(gen int, wait time.Duration) tea.Cmd {
+	wait = min(wait, time.Second)
+	return tea.Tick(wait, func(time.Time) tea.Msg { return TickMsg{Gen: gen} })
+}
+
+// scheduleReconnect records a failed attempt and either schedules the next
+// one or, past the cap, stops and leaves the decision to the user.
+func scheduleReconnect(m Model, err error) (tea.Model, tea.Cmd) {
+	m.ReconnectAttempts++
+	r := &m.Reconnect
+	r.Err, r.Connecting = err.Error(), false
+	switch m.CurrentState {
+	case StateReconnect:
+	case StateLoading:
+		// The screen that issued the failed command was pushed already.
+		m.CurrentState = StateReconnect
+	default:
+		m.pushState(m.CurrentState)
+		m.CurrentState = StateReconnect
+	}
+	if m.ReconnectAttempts >= maxReconnectAttempts {
+		r.GaveUp = true
+		return m, nil
+	}
+	wait := withJitter(BackoffDuration(m.ReconnectAttempts))
+	r.At = time.Now().Add(wait)
+	return m, reconnectTick(r.Gen, wait)
+}
+
+func handleReconnectTick(m Model, msg TickMsg) (tea.Model, tea.Cmd) {
+	r := &m.Reconnect
+	if msg.Gen != r.Gen || r.GaveUp || r.Connecting {
+		return m, nil
+	}
+	if m.CurrentState == StateLoading && m.Load.Op.From == StateReconnect {
+		// A switch to another server is resolving its profile behind the
+		// loading screen; the old server waits until it fails or is
+		// cancelled.
+		return m, reconnectTick(r.Gen, time.Second)
+	}
+	if wait := time.Until(r.At); wait > 0 {
+		return m, reconnectTick(r.Gen, wait)
+	}
+	r.Connecting = true
+	return m, connectToRedis(m)
+}
+
+func handleStateReconnectKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	r := &m.Reconnect
+	if r.Editing {
+		switch keyMsg.String() {
+		case "esc":
+			r.Editing, r.Notice = false, ""
+			return m, nil
+		case "enter":
+			return m.switchServer(r.Input.Value())
+		}
+		var cmd tea.Cmd
+		r.Input, cmd = r.Input.Update(keyMsg)
+		return m, cmd
+	}
+
+	switch keyMsg.String() {
+	case "r":
+		if r.Connecting {
+			return m, nil
+		}
+		if r.GaveUp {
+			m.ReconnectAttempts = 0
+		}
+		r.Gen++
+		r.GaveUp, r.Connecting = false, true
+		return m, connectToRedis(m)
+	case "s":
+		in := textinput.New()
+		in.Prompt = "server › "
+		in.Placeholder = "host:port, redis:// URL or profile name"
+		in.SetValue(m.RedisAddress)
+		in.CursorEnd()
+		in.Focus()
+		r.Input, r.Editing, r.Notice = in, true, ""
+		return m, textinput.Blink
+	case "q":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// switchServer points the session at another server. Screens on the stack
+// belong to the old one, so a successful connection lands on the menu. A
+// profile with a password_command is resolved behind the loading screen
+// (see withTargets).
+func (m Model) switchServer(spec string) (tea.Model, tea.Cmd) {
+	spec = strings.TrimSpace(spec)
+	r := &m.Reconnect
+	var e Endpoint
+	switch p, isProfile := m.Config.Find(spec); {
+	case spec == "":
+		r.Notice = "enter host:port, a redis:// URL or a profile name"
+		return m, nil
+	case isProfile:
+		return m.withTargets([]string{spec}, func(m Model, sides []compareSide, err error) (tea.Model, tea.Cmd) {
+			m.CurrentState = StateReconnect
+			if err != nil {
+				m.Reconnect.Notice = err.Error()
+				return m, nil
+			}
+			m.ProfileName = p.Name
+			m.ReadOnly = m.ReadOnly || p.ReadOnly
+			m.Production = p.Production
+			if p.ClientName != "" {
+				m.ClientName = p.ClientName
+			}
+			m.OnConnect, m.ProxyKeys, m.ReplicaAddress = p.OnConnect, p.ProxyKeys, p.Replica
+			return m.switchTo(sides[0].Endpoint)
+		})
+	case strings.Contains(spec, "://"):
+		var err error
+		if e, _, err = m.resolveTarget(spec); err != nil {
+			r.Notice = err.Error()
+			return m, nil
+		}
+		m.ProfileName, m.OnConnect, m.ProxyKeys, m.ReplicaAddress = "", nil, nil, ""
+		m.Production = false
+	case strings.Contains(spec, ":"):
+		// A bare address gets none of the old server's credentials.
+		e = Endpoint{Address: spec}
+		m.ProfileName, m.OnConnect, m.ProxyKeys, m.ReplicaAddress = "", nil, nil, ""
+		m.Production = false
+	default:
+		r.Notice = fmt.Sprintf("no profile %q (use host:port or a redis:// URL)", spec)
+		return m, nil
+	}
+	return m.switchTo(e)
+}
+
+// switchTo drops the old server's connections and connects to e.
+func (m Model) switchTo(e Endpoint) (tea.Model, tea.Cmd) {
+	m = m.withEndpoint(e)
+	m.closeReplica()
+	if m.Conn != nil {
+		_ = m.Conn.Close()
+		m.Conn, m.Reader = nil, nil
+		m.stopWorker()
+	}
+	m.StateNavigationHistory = nil
+	m.Commands = nil // the new server answers for itself once connected
+	m.markUnsupportedMenu()
+	m.ReconnectAttempts = 0
+	m.Reconnect = ReconnectModel{Gen: m.Reconnect.Gen + 1, Connecting: true}
+	return m, connectToRedis(m)
+}
+
+func (m Model) reconnectView() string {
+	r := m.Reconnect
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+	yellow := lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow))
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Bold(true).Render("CONNECTION LOST") + dim.Render(" · "+m.RedisAddress)
+
+	var status string
+	switch {
+	case r.Connecting:
+		status = yellow.Render("connecting…")
+	case r.GaveUp:
+		status = red.Render(fmt.Sprintf("gave up after %d attempts", m.ReconnectAttempts))
+	default:
+		next := time.Until(r.At).Round(time.Second)
+		status = yellow.Render(fmt.Sprintf("reconnecting (attempt %d, next in %s)", m.ReconnectAttempts, max(next, time.Second)))
+	}
+	row := func(label, value string) string {
+		return "    " + subtle.Render(fmt.Sprintf("%-10s", label)) + value
+	}
+	lines := []string{row("status", status)}
+	if r.Err != "" {
+		lines = append(lines, row("error", text.Render(r.Err)))
+	}
+	if r.Editing {
+		in := r.Input
+		in.Width = max(m.WindowWidth-16, 10)
+		lines = append(lines, "", "    "+in.View())
+		if r.Notice != "" {
+			lines = append(lines, "    "+red.Render(r.Notice))
+		}
+	}
+
+	body := "  " + title + "\n\n" + strings.Join(lines, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	var keys help.KeyMap = reconnectKeys
+	if r.Editing {
+		keys = reconnectEditKeys
+	}
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(keys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
@@ -3,9 +3,13 @@ package tui
 import (
 	"bufio"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
+	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ajxv/redis-tui/internal/redis"
@@ -30,77 +34,168 @@ func BackoffDuration(attempt int) time.Duration {
 	return d
 }
 
-// waitForNextConnection waits the backoff duration then signals a reconnect.
-func waitForNextConnection(attempt int) tea.Cmd {
-	return func() tea.Msg {
-		time.Sleep(BackoffDuration(attempt))
-		return TickMsg{}
+// DefaultClientName is the CLIENT SETNAME used unless configured otherwise:
+// redis-tui:<hostname>:<pid>, so sessions can be told apart in CLIENT LIST.
+func DefaultClientName() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
 	}
+	// Client names can't contain spaces.
+	host = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, host)
+	return fmt.Sprintf("redis-tui:%s:%d", host, os.Getpid())
 }
 
 // connectToRedis dials Redis using the connection settings stored in m,
 // performs TLS wrapping when configured, authenticates, and selects the DB.
+// It also asks for the connection's CLIENT ID, which the header shows, and
+// INFO server, which says what the server is and so what it can do.
 func connectToRedis(m Model) tea.Cmd {
 	return func() tea.Msg {
-		dialTimeout := m.DialTimeout
-		if dialTimeout == 0 {
-			dialTimeout = defaultDialTimeout
+		conn, reader, fatal, err := openConnection(m)
+		if err != nil {
+			return RedisConnectionMsg{Error: err, Fatal: fatal}
 		}
-
-		// 1. Dial raw TCP
-		rawConn, err := net.DialTimeout("tcp", m.RedisAddress, dialTimeout)
+		if fatal, err := runOnConnect(conn, reader, m.OnConnect); err != nil {
+			_ = conn.Close()
+			return RedisConnectionMsg{Error: err, Fatal: fatal}
+		}
+		if m.Proxy {
+			return RedisConnectionMsg{Conn: conn}
+		}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{
+			{Name: "CLIENT", Args: []string{"ID"}},
+			{Name: "INFO", Args: []string{"server"}},
+		})
 		if err != nil {
+			_ = conn.Close()
 			return RedisConnectionMsg{Error: err}
 		}
+		id, _ := replies[0].(int) // servers without CLIENT ID just don't get one shown
+		info, _ := replies[1].(string)
+		return RedisConnectionMsg{Conn: conn, ClientID: id, Server: redis.ParseServerInfo(info)}
+	}
+}
 
-		// 2. Wrap with TLS when configured
-		conn := rawConn
-		if m.TLSConfig != nil {
-			tlsConn := tls.Client(rawConn, m.TLSConfig)
-			if err := tlsConn.Handshake(); err != nil {
-				_ = rawConn.Close()
-				return RedisConnectionMsg{Error: fmt.Errorf("TLS handshake failed: %w", err)}
-			}
-			conn = tlsConn
+// runOnConnect sends the profile's on_connect commands in order. They only
+// run on the session connection: side connections (compare, watchers, nodes)
+// open their own state. An error reply is fatal — retrying runs the same
+// command again.
+func runOnConnect(conn net.Conn, reader *bufio.Reader, lines []string) (fatal bool, err error) {
+	for _, line := range lines {
+		args, err := splitCommandLine(line)
+		if err != nil || len(args) == 0 {
+			return true, fmt.Errorf("on_connect %q: invalid command", line)
+		}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{{Name: args[0], Args: args[1:]}})
+		if err != nil {
+			return false, err
+		}
+		if s, ok := replies[0].(string); ok && redis.IsErrorReply(s) {
+			return true, fmt.Errorf("on_connect %q: %s", line, s)
 		}
+	}
+	return false, nil
+}
 
-		reader := bufio.NewReader(conn)
+// openConnection performs the full connection handshake (dial, TLS, AUTH,
+// SELECT, CLIENT SETNAME) and returns a ready-to-use connection. It is shared by the main
+// connection and the dedicated ones opened for blocking commands, which must
+// not tie up the connection the rest of the UI talks over. fatal reports a
+// permanent failure (wrong credentials, invalid DB) that retrying won't fix.
+func openConnection(m Model) (conn net.Conn, reader *bufio.Reader, fatal bool, err error) {
+	dialTimeout := m.DialTimeout
+	if dialTimeout == 0 {
+		dialTimeout = defaultDialTimeout
+	}
 
-		// 3. AUTH — ACL format (username + password) or legacy (password only)
-		if err := sendAuth(conn, reader, m.Username, m.Password); err != nil {
-			_ = conn.Close()
-			// Auth rejection is a permanent failure — wrong credentials won't fix
-			// themselves on retry, so mark it fatal to stop the backoff loop.
-			return RedisConnectionMsg{Error: err, Fatal: true}
-		}
+	// 1. Dial raw TCP
+	rawConn, err := net.DialTimeout("tcp", m.RedisAddress, dialTimeout)
+	if err != nil {
+		return nil, nil, false, err
+	}
 
-		// 4. SELECT database
-		cmd := redis.RedisCmd{
-			Name: "SELECT",
-			Args: []string{strconv.Itoa(m.DB)},
+	// 2. Wrap with TLS when configured
+	conn = rawConn
+	if m.TLSConfig != nil {
+		tlsConn := tls.Client(rawConn, m.TLSConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			_ = rawConn.Close()
+			return nil, nil, false, fmt.Errorf("TLS handshake failed: %w", err)
 		}
-		_, err = conn.Write(cmd.ToBytes())
-		if err != nil {
+		conn = tlsConn
+	}
+	conn = m.Trace.Wrap(conn, rawConn.LocalAddr().String())
+	conn = m.Recorder.Wrap(conn, m.RedisAddress)
+
+	// Inline mode only changes how requests are written; every caller keeps
+	// writing RedisCmd.ToBytes and reading RESP replies.
+	if m.Inline {
+		conn = redis.NewInlineConn(conn)
+	}
+	conn = m.Audit.Wrap(conn, m.ProfileName, m.RedisAddress)
+
+	reader = bufio.NewReader(conn)
+
+	// 3. AUTH — ACL format (username + password) or legacy (password only)
+	if err := sendAuth(conn, reader, m.Username, m.Password); err != nil {
+		_ = conn.Close()
+		// Auth rejection is a permanent failure — wrong credentials won't fix
+		// themselves on retry, so mark it fatal to stop the backoff loop.
+		return nil, nil, true, err
+	}
+
+	// Limited proxies forward neither SELECT nor CLIENT, and some close the
+	// connection on them; they only ever serve database 0.
+	if m.Proxy {
+		if m.DB != 0 {
 			_ = conn.Close()
-			return RedisConnectionMsg{Error: err}
+			return nil, nil, true, fmt.Errorf("proxy profiles can only use database 0, not %d", m.DB)
 		}
-		resp, err := redis.ReadResp(reader)
-		if err != nil {
+		return conn, reader, false, nil
+	}
+
+	// 4. SELECT database
+	cmd := redis.RedisCmd{
+		Name: "SELECT",
+		Args: []string{strconv.Itoa(m.DB)},
+	}
+	if _, err := conn.Write(cmd.ToBytes()); err != nil {
+		_ = conn.Close()
+		return nil, nil, false, err
+	}
+	resp, err := redis.ReadResp(reader)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, false, err
+	}
+	// ReadResp returns the trimmed string for both +OK and -ERR responses;
+	// only a Go error is returned for I/O failures, not Redis-level errors.
+	if str, ok := resp.(string); ok && str != "OK" {
+		_ = conn.Close()
+		return nil, nil, true, fmt.Errorf("SELECT %d failed: %s", m.DB, str)
+	}
+
+	// 5. CLIENT SETNAME — a refusal (proxies often lack CLIENT) isn't worth
+	// failing the connection over, so only I/O errors count.
+	if m.ClientName != "" {
+		cmd := redis.RedisCmd{Name: "CLIENT", Args: []string{"SETNAME", m.ClientName}}
+		if _, err := conn.Write(cmd.ToBytes()); err != nil {
 			_ = conn.Close()
-			return RedisConnectionMsg{Error: err}
+			return nil, nil, false, err
 		}
-		// ReadResp returns the trimmed string for both +OK and -ERR responses;
-		// only a Go error is returned for I/O failures, not Redis-level errors.
-		if str, ok := resp.(string); ok && str != "OK" {
+		if _, err := redis.ReadResp(reader); err != nil {
 			_ = conn.Close()
-			return RedisConnectionMsg{
-				Error: fmt.Errorf("SELECT %d failed: %s", m.DB, str),
-				Fatal: true,
-			}
+			return nil, nil, false, err
 		}
-
-		return RedisConnectionMsg{Conn: conn}
 	}
+
+	return conn, reader, false, nil
 }
 
 // sendAuth sends the appropriate AUTH command based on whether a username is
@@ -108,9 +203,9 @@ func connectToRedis(m Model) tea.Cmd {
 func sendAuth(conn net.Conn, reader *bufio.Reader, username, password string) error {
 	var cmd redis.RedisCmd
 	if username != "" && password != "" {
-		cmd = redis.RedisCmd{Name: "AUTH", Args: []string{username, password}}
+		cmd = redis.Auth(username, password)
 	} else if password != "" {
-		cmd = redis.RedisCmd{Name: "AUTH", Args: []string{password}}
+		cmd = redis.Auth("", password)
 	} else {
 		return nil
 	}
@@ -129,7 +224,7 @@ func sendAuth(conn net.Conn, reader *bufio.Reader, username, password string) er
 	return nil
 }
 
-func scanRedisKeys(conn net.Conn, reader *bufio.Reader, pattern string, cursor string) tea.Cmd {
+func scanRedisKeys(conn net.Conn, reader *bufio.Reader, pattern string, cursor string, count int) tea.Cmd {
 	return func() tea.Msg {
 		if conn == nil {
 			return RedisResultMsg{Error: fmt.Errorf("no connection to Redis")}
@@ -138,10 +233,8 @@ func scanRedisKeys(conn net.Conn, reader *bufio.Reader, pattern string, cursor s
 		filter := pattern
 		var keys []list.Item
 
-		cmd := redis.RedisCmd{
-			Name: "SCAN",
-			Args: []string{cursor, "MATCH", filter},
-		}
+		start := time.Now()
+		cmd := redis.Scan(cursor, filter, count)
 		_, err := conn.Write(cmd.ToBytes())
 		if err != nil {
 			return RedisResultMsg{
@@ -154,66 +247,56 @@ func scanRedisKeys(conn net.Conn, reader *bufio.Reader, pattern string, cursor s
 				Error: err,
 			}
 		}
-		if resp, ok := response.([]any); ok && len(resp) >= 2 {
-			if c, ok := resp[0].(string); ok {
-				cursor = c
+		took := time.Since(start)
+		cursor, rawKeys, err := scanPage(response)
+		if err != nil {
+			return RedisResultMsg{Error: err}
+		}
+		if len(rawKeys) > 0 {
+			// Pipeline TYPE commands
+			for _, k := range rawKeys {
+				cmd := redis.Type(k)
+				if _, err := conn.Write(cmd.ToBytes()); err != nil {
+					return RedisResultMsg{Error: err}
+				}
 			}
 
-			if slice, ok := resp[1].([]any); ok {
-				var rawKeys []string
-				for _, str := range slice {
-					if s, ok := str.(string); ok {
-						rawKeys = append(rawKeys, s)
+			// Read pipelined TYPE responses
+			types := make([]string, len(rawKeys))
+			for i := range rawKeys {
+				types[i] = "key"
+				typeResp, err := redis.ReadResp(reader)
+				if err == nil {
+					if typeStr, err := redis.AsString(typeResp); err == nil {
+						types[i] = typeStr
 					}
 				}
+			}
 
-				if len(rawKeys) > 0 {
-					// Pipeline TYPE commands
-					for _, k := range rawKeys {
-						cmd := redis.RedisCmd{Name: "TYPE", Args: []string{k}}
-						if _, err := conn.Write(cmd.ToBytes()); err != nil {
-							return RedisResultMsg{Error: err}
-						}
-					}
-
-					// Read pipelined TYPE responses
-					types := make([]string, len(rawKeys))
-					for i := range rawKeys {
-						types[i] = "key"
-						typeResp, err := redis.ReadResp(reader)
-						if err == nil {
-							if typeStr, ok := typeResp.(string); ok {
-								types[i] = typeStr
-							}
-						}
-					}
-
-					// Pipeline TTL commands too, so the key list can show a
-					// countdown for keys about to expire without a per-key
-					// round trip — this is one extra batch per SCAN page, not
-					// one extra request per key.
-					for _, k := range rawKeys {
-						cmd := redis.RedisCmd{Name: "TTL", Args: []string{k}}
-						if _, err := conn.Write(cmd.ToBytes()); err != nil {
-							return RedisResultMsg{Error: err}
-						}
-					}
-					for i, k := range rawKeys {
-						ttl := -1
-						ttlResp, err := redis.ReadResp(reader)
-						if err == nil {
-							if t, ok := ttlResp.(int); ok {
-								ttl = t
-							}
-						}
-						keys = append(keys, ListItem{title: k, desc: types[i], ttl: ttl})
+			// Pipeline TTL commands too, so the key list can show a
+			// countdown for keys about to expire without a per-key
+			// round trip — this is one extra batch per SCAN page, not
+			// one extra request per key.
+			for _, k := range rawKeys {
+				cmd := redis.TTL(k)
+				if _, err := conn.Write(cmd.ToBytes()); err != nil {
+					return RedisResultMsg{Error: err}
+				}
+			}
+			for i, k := range rawKeys {
+				ttl := -1
+				ttlResp, err := redis.ReadResp(reader)
+				if err == nil {
+					if t, err := redis.AsInt(ttlResp); err == nil {
+						ttl = t
 					}
 				}
+				keys = append(keys, ListItem{title: k, desc: types[i], ttl: ttl})
 			}
 		}
 
 		return RedisResultMsg{
-			Result: ScanResult{Cursor: cursor, Keys: keys},
+			Result: ScanResult{Cursor: cursor, Keys: keys, Count: count, Took: took},
 		}
 	}
 }
@@ -221,13 +304,14 @@ func scanRedisKeys(conn net.Conn, reader *bufio.Reader, pattern string, cursor s
 // scanRedisKeysOfType scans one page of keys matching pattern and keeps only
 // those whose Redis type equals wantType. Used by the menu key-picker so the
 // user chooses from existing keys of the relevant type.
-func scanRedisKeysOfType(conn net.Conn, reader *bufio.Reader, pattern, cursor, wantType string) tea.Cmd {
+func scanRedisKeysOfType(conn net.Conn, reader *bufio.Reader, pattern, cursor, wantType string, count int) tea.Cmd {
 	return func() tea.Msg {
 		if conn == nil {
 			return RedisResultMsg{Error: fmt.Errorf("no connection to Redis")}
 		}
 
-		cmd := redis.RedisCmd{Name: "SCAN", Args: []string{cursor, "MATCH", pattern}}
+		start := time.Now()
+		cmd := redis.Scan(cursor, pattern, count)
 		if _, err := conn.Write(cmd.ToBytes()); err != nil {
 			return RedisResultMsg{Error: err}
 		}
@@ -235,61 +319,51 @@ func scanRedisKeysOfType(conn net.Conn, reader *bufio.Reader, pattern, cursor, w
 		if err != nil {
 			return RedisResultMsg{Error: err}
 		}
+		took := time.Since(start)
 
+		cursor, rawKeys, err := scanPage(response)
+		if err != nil {
+			return RedisResultMsg{Error: err}
+		}
 		var keys []list.Item
-		if resp, ok := response.([]any); ok && len(resp) >= 2 {
-			if c, ok := resp[0].(string); ok {
-				cursor = c
+		if len(rawKeys) > 0 {
+			for _, k := range rawKeys {
+				tc := redis.Type(k)
+				if _, err := conn.Write(tc.ToBytes()); err != nil {
+					return RedisResultMsg{Error: err}
+				}
 			}
-			if slice, ok := resp[1].([]any); ok {
-				var rawKeys []string
-				for _, str := range slice {
-					if s, ok := str.(string); ok {
-						rawKeys = append(rawKeys, s)
-					}
+			types := make([]string, len(rawKeys))
+			for i := range rawKeys {
+				typeResp, err := redis.ReadResp(reader)
+				if err != nil {
+					return RedisResultMsg{Error: err}
 				}
-				if len(rawKeys) > 0 {
-					for _, k := range rawKeys {
-						tc := redis.RedisCmd{Name: "TYPE", Args: []string{k}}
-						if _, err := conn.Write(tc.ToBytes()); err != nil {
-							return RedisResultMsg{Error: err}
-						}
-					}
-					types := make([]string, len(rawKeys))
-					for i := range rawKeys {
-						typeResp, err := redis.ReadResp(reader)
-						if err != nil {
-							return RedisResultMsg{Error: err}
-						}
-						if typeStr, ok := typeResp.(string); ok {
-							types[i] = typeStr
-						}
-					}
+				types[i], _ = redis.AsString(typeResp)
+			}
 
-					for _, k := range rawKeys {
-						tc := redis.RedisCmd{Name: "TTL", Args: []string{k}}
-						if _, err := conn.Write(tc.ToBytes()); err != nil {
-							return RedisResultMsg{Error: err}
-						}
-					}
-					for i, k := range rawKeys {
-						ttl := -1
-						ttlResp, err := redis.ReadResp(reader)
-						if err != nil {
-							return RedisResultMsg{Error: err}
-						}
-						if t, ok := ttlResp.(int); ok {
-							ttl = t
-						}
-						if types[i] == wantType {
-							keys = append(keys, ListItem{title: k, desc: types[i], ttl: ttl})
-						}
-					}
+			for _, k := range rawKeys {
+				tc := redis.TTL(k)
+				if _, err := conn.Write(tc.ToBytes()); err != nil {
+					return RedisResultMsg{Error: err}
+				}
+			}
+			for i, k := range rawKeys {
+				ttlResp, err := redis.ReadResp(reader)
+				if err != nil {
+					return RedisResultMsg{Error: err}
+				}
+				ttl, err := redis.AsInt(ttlResp)
+				if err != nil {
+					ttl = -1
+				}
+				if types[i] == wantType {
+					keys = append(keys, ListItem{title: k, desc: types[i], ttl: ttl})
 				}
 			}
 		}
 
-		return RedisResultMsg{Result: ScanResult{Cursor: cursor, Keys: keys}}
+		return RedisResultMsg{Result: ScanResult{Cursor: cursor, Keys: keys, Count: count, Took: took}}
 	}
 }
 
@@ -303,6 +377,7 @@ func sendRedisCmd(conn net.Conn, reader *bufio.Reader, cmd redis.RedisCmd, readT
 			readTimeout = defaultReadTimeout
 		}
 
+		start := time.Now()
 		_, err := conn.Write(cmd.ToBytes())
 		if err != nil {
 			return RedisResultMsg{Error: err}
@@ -315,7 +390,7 @@ func sendRedisCmd(conn net.Conn, reader *bufio.Reader, cmd redis.RedisCmd, readT
 			return RedisResultMsg{Error: err}
 		}
 
-		return RedisResultMsg{Result: response}
+		return RedisResultMsg{Result: response, Command: formatCommand(cmd), Latency: time.Since(start)}
 	}
 }
 
@@ -327,10 +402,7 @@ func fetchTTL(conn net.Conn, reader *bufio.Reader, key string, readTimeout time.
 		if readTimeout == 0 {
 			readTimeout = defaultReadTimeout
 		}
-		cmd := redis.RedisCmd{
-			Name: "TTL",
-			Args: []string{key},
-		}
+		cmd := redis.TTL(key)
 		_, err := conn.Write(cmd.ToBytes())
 		if err != nil {
 			return RedisTTLResultMsg{TTL: -2}
@@ -341,9 +413,211 @@ func fetchTTL(conn net.Conn, reader *bufio.Reader, key string, readTimeout time.
 		if err != nil {
 			return RedisTTLResultMsg{TTL: -2}
 		}
-		if ttl, ok := response.(int); ok {
-			return RedisTTLResultMsg{TTL: ttl}
+		ttl, err := redis.AsInt(response)
+		if err != nil {
+			return RedisTTLResultMsg{TTL: -2}
+		}
+		return RedisTTLResultMsg{TTL: ttl}
+	}
+}
+
+// scanAllKeys walks the keyspace with SCAN MATCH pattern until the cursor
+// wraps or limit keys have been collected (limit <= 0 means no limit). Used by
+// the tool screens that need a whole pattern's keys rather than one page. The
+// COUNT hint adapts as it goes, up to ceiling (see nextScanCount).
+func scanAllKeys(conn net.Conn, reader *bufio.Reader, pattern string, limit, ceiling int) ([]string, error) {
+	var keys []string
+	cursor := "0"
+	count := firstScanCount(ceiling)
+	for {
+		start := time.Now()
+		resp, err := readResp(conn, reader, redis.Scan(cursor, pattern, count))
+		if err != nil {
+			return nil, err
+		}
+		count = nextScanCount(count, ceiling, time.Since(start))
+		var batch []string
+		cursor, batch, err = scanPage(resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range batch {
+			keys = append(keys, k)
+			if limit > 0 && len(keys) >= limit {
+				return keys, nil
+			}
+		}
+		if cursor == "0" || cursor == "" {
+			return keys, nil
 		}
-		return RedisTTLResultMsg{TTL: -2}
 	}
 }
+
+// scanPage decodes a SCAN-family reply into its next cursor and items.
+func scanPage(resp any) (string, []string, error) {
+	if s, ok := resp.(string); ok {
+		return "", nil, fmt.Errorf("SCAN failed: %s", s)
+	}
+	page, ok := resp.([]any)
+	if !ok || len(page) != 2 {
+		return "", nil, fmt.Errorf("unexpected SCAN reply")
+	}
+	cursor, err := redis.AsString(page[0])
+	if err != nil {
+		return "", nil, fmt.Errorf("unexpected SCAN cursor: %w", err)
+	}
+	items, err := redis.AsStringSlice(page[1])
+	if err != nil {
+		return "", nil, fmt.Errorf("unexpected SCAN reply: %w", err)
+	}
+	return cursor, items, nil
+}
+
+// pipeline writes every command before reading any reply, so a batch of N
+// lookups costs one round trip instead of N.
+func pipeline(conn net.Conn, reader *bufio.Reader, cmds []redis.RedisCmd) ([]any, error) {
+	for _, c := range cmds {
+		if _, err := conn.Write(c.ToBytes()); err != nil {
+			return nil, err
+		}
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+	defer func() { _ = conn.SetReadDeadline(time.Time{}) }()
+	replies := make([]any, len(cmds))
+	for i := range cmds {
+		r, err := redis.ReadResp(reader)
+		if err != nil {
+			return nil, err
+		}
+		replies[i] = r
+	}
+	return replies, nil
+}
+
+// inspectString reads a string key's idle time, value and TTL in one pipelined
+// round trip. OBJECT IDLETIME goes first: GET itself counts as an access, so
+// asking afterwards would always report 0. Without idle (servers lacking
+// OBJECT IDLETIME) the batch is just GET and TTL.
+func inspectString(conn net.Conn, reader *bufio.Reader, key string, idle bool) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return StringInspectMsg{Error: fmt.Errorf("no connection to Redis")}
+		}
+		get := redis.Get(key)
+		start := time.Now()
+		object := redis.RedisCmd{Name: "OBJECT", Args: []string{"IDLETIME", key}}
+		if !idle {
+			object = redis.Ping() // keeps the reply positions fixed
+		}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{object, get, redis.TTL(key)})
+		if err != nil {
+			_ = conn.Close() // stream may be desynced mid-batch; force a reconnect
+			return StringInspectMsg{Error: err}
+		}
+		// The latency is the batch's: GET rides with OBJECT and TTL in one
+		// round trip, so that is what the user waited for.
+		msg := StringInspectMsg{Value: replies[1], TTL: -2, Idle: -1, Command: formatCommand(get), Latency: time.Since(start)}
+		if ttl, err := redis.AsInt(replies[2]); err == nil {
+			msg.TTL = ttl
+		}
+		// OBJECT IDLETIME errors under an LFU maxmemory-policy; Idle stays -1.
+		if idle, err := redis.AsInt(replies[0]); err == nil {
+			msg.Idle = idle
+		}
+		return msg
+	}
+}
+
+// maxValueItems caps how many elements fetchKeyValue will pull from one
+// collection, so comparing a huge key can't stall the tool or the server.
+const maxValueItems = 10_000
+
+// keyValue is a type-aware copy of one key's value, used by the tools that
+// compare or save keys rather than display them.
+type keyValue struct {
+	Type   string            // "none" when the key doesn't exist
+	String string            // string value
+	Fields map[string]string // hash: field → value; zset: member → score
+	Items  []string          // list: elements in order; set: members, sorted
+	TTL    int               // seconds; -1 no expiry, -2 missing
+}
+
+// size is the element count shown in summaries (bytes for strings).
+func (v keyValue) size() int {
+	switch v.Type {
+	case "string":
+		return len(v.String)
+	case "hash", "zset":
+		return len(v.Fields)
+	}
+	return len(v.Items)
+}
+
+// fetchKeyValue reads key's type, TTL and full value.
+func fetchKeyValue(conn net.Conn, reader *bufio.Reader, key string) (keyValue, error) {
+	head, err := pipeline(conn, reader, []redis.RedisCmd{
+		redis.Type(key),
+		redis.TTL(key),
+	})
+	if err != nil {
+		return keyValue{}, err
+	}
+	v := keyValue{TTL: -2}
+	if v.Type, err = redis.AsString(head[0]); err != nil {
+		return keyValue{}, fmt.Errorf("TYPE %s: %w", key, err)
+	}
+	if ttl, err := redis.AsInt(head[1]); err == nil {
+		v.TTL = ttl
+	}
+
+	var count, fetch redis.RedisCmd
+	switch v.Type {
+	case "none":
+		return v, nil
+	case "string":
+		r, err := readResp(conn, reader, redis.Get(key))
+		if err != nil {
+			return keyValue{}, err
+		}
+		v.String, err = redis.AsString(r)
+		if errors.Is(err, redis.ErrNil) {
+			return keyValue{Type: "none", TTL: -2}, nil // expired between TYPE and GET
+		}
+		return v, err
+	case "hash":
+		count, fetch = redis.HLen(key), redis.HGetAll(key)
+	case "list":
+		count, fetch = redis.LLen(key), redis.LRange(key, 0, -1)
+	case "set":
+		count, fetch = redis.SCard(key), redis.SMembers(key)
+	case "zset":
+		count, fetch = redis.ZCard(key), redis.ZRange(key, 0, -1, true)
+	default:
+		return keyValue{}, fmt.Errorf("%s keys are not supported", v.Type)
+	}
+
+	r, err := readResp(conn, reader, count)
+	if err != nil {
+		return keyValue{}, err
+	}
+	if n, _ := redis.AsInt(r); n > maxValueItems {
+		return keyValue{}, fmt.Errorf("%s has %d elements (limit %d)", key, n, maxValueItems)
+	}
+	r, err = readResp(conn, reader, fetch)
+	if err != nil {
+		return keyValue{}, err
+	}
+	switch v.Type {
+	case "hash", "zset":
+		v.Fields, err = redis.AsMap(r)
+	case "set":
+		v.Items, err = redis.AsStringSlice(r)
+		sort.Strings(v.Items)
+	default:
+		v.Items, err = redis.AsStringSlice(r)
+	}
+	if err != nil {
+		return keyValue{}, fmt.Errorf("%s: %w", key, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,419 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// REPLACE_ALL rewrites the string values of keys matching a pattern with a
+// literal or regular-expression replacement — sed for an emergency data fix.
+// It works like EXPIRE_ALL: a first pass counts the values that would change
+// and keeps a few as before/after samples for the confirmation; a second pass
+// reads each value again and writes the replacement with SET … XX KEEPTTL,
+// so the TTL stays and a key deleted meanwhile isn't recreated. A write that
+// lands between that read and the SET is overwritten. Other types are left
+// alone.
+
+const (
+	replaceSamples     = 5
+	replaceContextRune = 24 // runes shown before the first change in a sample
+	replaceSampleRunes = 72
+)
+
+// ReplaceSpec is what the REPLACE_ALL form asks for.
+type ReplaceSpec struct {
+	Pattern string
+	Find    string
+	With    string
+	Regex   bool
+	re      *regexp.Regexp
+}
+
+// apply returns v with the replacement made.
+func (s ReplaceSpec) apply(v string) string {
+	if s.re != nil {
+		return s.re.ReplaceAllString(v, s.With)
+	}
+	return strings.ReplaceAll(v, s.Find, s.With)
+}
+
+// replaceSample is one value before and after.
+type replaceSample struct {
+	Key, Before, After string
+}
+
+// ReplaceModel is the state of a count or apply pass.
+type ReplaceModel struct {
+	Spec     ReplaceSpec
+	Conn     net.Conn
+	Reader   *bufio.Reader
+	Gen      int
+	Counted  bool
+	Applying bool
+	Count    int // SCAN COUNT hint for the next page
+	Matched  int // keys matching the pattern
+	Strings  int // of those, string values
+	Changing int // of those, values the replacement changes
+	Samples  []replaceSample
+	Done     int // values rewritten by the apply pass
+	Errors   int
+	LastErr  string
+	Started  time.Time
+	Finished time.Time
+	Err      string
+}
+
+// ReplaceConnMsg delivers the pass's dedicated connection.
+type ReplaceConnMsg struct {
+	Gen    int
+	Conn   net.Conn
+	Reader *bufio.Reader
+	Error  error
+}
+
+// ReplacePageMsg reports one SCAN page of a pass.
+type ReplacePageMsg struct {
+	Gen      int
+	Cursor   string
+	Took     time.Duration
+	Matched  int
+	Strings  int
+	Changing int
+	Samples  []replaceSample
+	Written  []string // keys rewritten
+	Errors   int
+	LastErr  string
+	Error    error
+}
+
+func (m Model) startReplaceForm() (tea.Model, tea.Cmd) {
+	if m.ReadOnly {
+		return m, m.notify("REPLACE_ALL is disabled: this session is read-only", true)
+	}
+	m.Form = NewForm(
+		"REPLACE_ALL · find and replace in string values",
+		[]string{"key pattern", "find", "replace with (regex: $1 for a group)", "regular expression? (y/n)"},
+		[]string{m.LastPattern, "", "", "n"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitReplaceForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	spec := ReplaceSpec{Pattern: values[0], Find: values[1], With: values[2]}
+	if spec.Pattern == "" {
+		m.Form.Err = "enter a key pattern"
+		return m, nil
+	}
+	if spec.Find == "" {
+		m.Form.Err = "enter the text to find"
+		return m, nil
+	}
+	switch strings.ToLower(values[3]) {
+	case "y", "yes":
+		re, err := regexp.Compile(spec.Find)
+		if err != nil {
+			m.Form.Err = "not a regular expression: " + err.Error()
+			return m, nil
+		}
+		spec.Regex, spec.re = true, re
+	case "n", "no":
+	default:
+		m.Form.Err = "answer y or n: regular expression?"
+		return m, nil
+	}
+
+	m.Form.Err = ""
+	m.stopReplace()
+	m.Replace = ReplaceModel{Spec: spec, Gen: m.Replace.Gen + 1, Count: firstScanCount(m.scanCountMax()), Started: time.Now()}
+	return m.switchToLoadingAndDial(openReplaceConnection(m, m.Replace.Gen))
+}
+
+func openReplaceConnection(m Model, gen int) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m)
+		return ReplaceConnMsg{Gen: gen, Conn: conn, Reader: reader, Error: err}
+	}
+}
+
+func handleReplaceConn(m Model, msg ReplaceConnMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.Replace.Gen {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close()
+		}
+		return m, nil
+	}
+	if msg.Error != nil {
+		m.Result = Result{Type: "error", Err: "Could not open a connection for REPLACE_ALL: " + msg.Error.Error()}
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	r := &m.Replace
+	r.Conn, r.Reader = msg.Conn, msg.Reader
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateReplace
+	}
+	return m, replacePage(r.Conn, r.Reader, r.Spec, "0", r.Count, false, replaceSamples, r.Gen)
+}
+
+// replacePage scans one page from cursor and reads the values with GET;
+// with apply it writes the changed ones back. samples caps the before/after
+// pairs kept.
+func replacePage(conn net.Conn, reader *bufio.Reader, spec ReplaceSpec, cursor string, count int, apply bool, samples, gen int) tea.Cmd {
+	return func() tea.Msg {
+		msg := ReplacePageMsg{Gen: gen}
+		start := time.Now()
+		resp, err := readResp(conn, reader, redis.Scan(cursor, spec.Pattern, count))
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		msg.Took = time.Since(start)
+		var keys []string
+		if msg.Cursor, keys, err = scanPage(resp); err != nil {
+			msg.Error = err
+			return msg
+		}
+		msg.Matched = len(keys)
+		if len(keys) == 0 {
+			return msg
+		}
+
+		cmds := make([]redis.RedisCmd, len(keys))
+		for i, k := range keys {
+			cmds[i] = redis.Get(k)
+		}
+		replies, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		var writes []redis.RedisCmd
+		var written []string
+		for i, r := range replies {
+			// Error and nil replies come back as strings too. A value that
+			// reads like one is skipped rather than rewritten.
+			v, ok := r.(string)
+			if !ok || v == "(nil)" || redis.IsErrorReply(v) {
+				continue // WRONGTYPE, or gone since the SCAN
+			}
+			msg.Strings++
+			after := spec.apply(v)
+			if after == v {
+				continue
+			}
+			msg.Changing++
+			if len(msg.Samples) < samples {
+				msg.Samples = append(msg.Samples, replaceSample{Key: keys[i], Before: v, After: after})
+			}
+			writes = append(writes, redis.RedisCmd{Name: "SET", Args: []string{keys[i], after, "XX", "KEEPTTL"}})
+			written = append(written, keys[i])
+		}
+		if !apply || len(writes) == 0 {
+			return msg
+		}
+
+		replies, err = pipeline(conn, reader, writes)
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		for i, r := range replies {
+			s, _ := r.(string)
+			switch {
+			case s == "OK":
+				msg.Written = append(msg.Written, written[i])
+			case redis.IsErrorReply(s):
+				msg.Errors++
+				msg.LastErr = s
+			}
+		}
+		return msg
+	}
+}
+
+func handleReplacePage(m Model, msg ReplacePageMsg) (tea.Model, tea.Cmd) {
+	r := &m.Replace
+	if msg.Gen != r.Gen || r.Conn == nil {
+		return m, nil
+	}
+	if msg.Error != nil {
+		r.Err = msg.Error.Error()
+		m.stopReplace()
+		return m, nil
+	}
+	r.Count = nextScanCount(r.Count, m.scanCountMax(), msg.Took)
+	r.Errors += msg.Errors
+	if msg.LastErr != "" {
+		r.LastErr = msg.LastErr
+	}
+	if r.Applying {
+		r.Done += len(msg.Written)
+		for _, k := range msg.Written {
+			m.invalidate(k)
+		}
+	} else {
+		r.Matched += msg.Matched
+		r.Strings += msg.Strings
+		r.Changing += msg.Changing
+		r.Samples = append(r.Samples, msg.Samples...)
+	}
+	if msg.Cursor != "0" && msg.Cursor != "" {
+		return m, replacePage(r.Conn, r.Reader, r.Spec, msg.Cursor, r.Count, r.Applying, replaceSamples-len(r.Samples), r.Gen)
+	}
+
+	r.Counted = true
+	if r.Applying || r.Changing == 0 {
+		r.Finished = time.Now()
+		m.stopReplace()
+		return m, nil
+	}
+	m.SelectedOp = OpReplace
+	m.pushState(StateReplace)
+	m.ask(m.replaceDialog())
+	return m, nil
+}
+
+// confirmReplace starts the apply pass, on the connection the count used.
+func confirmReplace(m Model) (tea.Model, tea.Cmd) {
+	m.CurrentState = m.popState()
+	r := &m.Replace
+	if r.Conn == nil {
+		return m, nil // closed meanwhile
+	}
+	r.Applying = true
+	r.Count = firstScanCount(m.scanCountMax())
+	r.Started = time.Now()
+	return m, replacePage(r.Conn, r.Reader, r.Spec, "0", r.Count, true, 0, r.Gen)
+}
+
+// replaceSnippet shows v from a little before offset, where the first
+// change is, cut to replaceSampleRunes.
+func replaceSnippet(v string, offset int) string {
+	start := offset
+	for n := 0; start > 0 && n < replaceContextRune; n++ {
+		_, size := utf8.DecodeLastRuneInString(v[:start])
+		start -= size
+	}
+	s := strings.ReplaceAll(v[start:], "\n", "⏎")
+	if start > 0 {
+		s = "…" + s
+	}
+	return truncateText(s, replaceSampleRunes)
+}
+
+// replaceDialog asks before the apply pass, showing the samples.
+func (m Model) replaceDialog() Dialog {
+	r := m.Replace
+	label := fmt.Sprintf("rewrite %d of %d string values matching %s (%d keys matched)", r.Changing, r.Strings, r.Spec.Pattern, r.Matched)
+	var lines []string
+	for _, s := range r.Samples {
+		// Both sides start at the first byte that differs, backed up to a rune.
+		p := 0
+		for p < len(s.Before) && p < len(s.After) && s.Before[p] == s.After[p] {
+			p++
+		}
+		for p > 0 && !utf8.RuneStart(s.Before[p]) {
+			p--
+		}
+		lines = append(lines, s.Key, "  - "+replaceSnippet(s.Before, p), "  + "+replaceSnippet(s.After, p))
+	}
+	if more := r.Changing - len(r.Samples); more > 0 {
+		lines = append(lines, fmt.Sprintf("… and %d more", more))
+	}
+	return Dialog{Title: "confirm REPLACE_ALL", Label: label, Value: strings.Join(lines, "\n  "), Destructive: true,
+		Target: m.orDB(r.Spec.Pattern), Choices: confirmChoice(confirmReplace)}
+}
+
+// stopReplace closes the pass's connection; an in-flight page is dropped by
+// the Gen bump.
+func (m *Model) stopReplace() {
+	r := &m.Replace
+	if r.Conn != nil {
+		_ = r.Conn.Close()
+	}
+	r.Conn, r.Reader = nil, nil
+	r.Gen++
+}
+
+func handleStateReplaceKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch keyMsg.String() {
+	case "esc":
+		m.stopReplace()
+		m.CurrentState = m.popState()
+	case "x":
+		if m.Replace.Conn != nil {
+			m.Replace.Err = "stopped"
+			m.stopReplace()
+		}
+	}
+	return m, nil
+}
+
+func (m Model) replaceView() string {
+	r := m.Replace
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+
+	kind := "literal"
+	if r.Spec.Regex {
+		kind = "regex"
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Bold(true).Render("REPLACE_ALL") +
+		dim.Render(fmt.Sprintf(" · %s · %s %q → %q", r.Spec.Pattern, kind, r.Spec.Find, r.Spec.With))
+
+	end := r.Finished
+	if end.IsZero() {
+		end = time.Now()
+	}
+	elapsed := end.Sub(r.Started)
+
+	var lines []string
+	if r.Applying {
+		w := max(min(m.WindowWidth-20, 60), 10)
+		done := min(r.Done, r.Changing)
+		filled := w * done / max(r.Changing, 1)
+		bar := green.Render(strings.Repeat("█", filled)) + dim.Render(strings.Repeat("░", w-filled))
+		lines = append(lines, "  "+bar+subtle.Render(fmt.Sprintf(" %3d%%", 100*done/max(r.Changing, 1))))
+	}
+
+	var status string
+	switch {
+	case r.Err != "" && r.Applying:
+		status = red.Render(fmt.Sprintf("stopped after %d values: %s", r.Done, r.Err))
+	case r.Err != "":
+		status = red.Render(fmt.Sprintf("stopped counting after %d keys: %s", r.Matched, r.Err))
+	case r.Applying && !r.Finished.IsZero():
+		status = green.Render(fmt.Sprintf("done: %d values rewritten in %s", r.Done, elapsed.Round(time.Millisecond)))
+	case r.Applying:
+		status = subtle.Render(fmt.Sprintf("%d / %d values", r.Done, r.Changing))
+	case !r.Finished.IsZero():
+		status = subtle.Render(fmt.Sprintf("%d keys matched, %d strings; none would change", r.Matched, r.Strings))
+	case r.Counted:
+		status = subtle.Render(fmt.Sprintf("counted %d values to change; nothing was changed", r.Changing))
+	default:
+		status = subtle.Render(fmt.Sprintf("counting… %d keys matched, %d to change", r.Matched, r.Changing))
+	}
+	lines = append(lines, "  "+status)
+	if r.Errors > 0 {
+		lines = append(lines, "  "+red.Render(fmt.Sprintf("%d error replies, last: %s", r.Errors, r.LastErr)))
+	}
+
+	body := "  " + title + "\n\n" + strings.Join(lines, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(replaceKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
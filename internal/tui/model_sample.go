@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// R in a hash, set or sorted set's field view samples random elements with
+// HRANDFIELD, SRANDMEMBER or ZRANDMEMBER, for a look at representative data
+// in a structure too big to page through. The elements are distinct, so a
+// sample of a small key is all of it in random order.
+
+const (
+	defaultSample = 10
+	maxSample     = 1000
+	sampleCell    = 120 // runes of a value shown in the table
+)
+
+// SampleRequestMsg asks to sample the key open in the field browser.
+type SampleRequestMsg struct {
+	Key  string
+	Type string
+}
+
+func (m Model) startSample(msg SampleRequestMsg) (tea.Model, tea.Cmd) {
+	m.ActiveKey = msg.Key
+	m.Browser.ActiveKeyType = msg.Type
+	m.SelectedOp = OpSample
+	m.Form = NewForm(
+		"SAMPLE · random elements of "+msg.Key,
+		[]string{fmt.Sprintf("how many (1–%d)", maxSample)},
+		[]string{strconv.Itoa(defaultSample)},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.pushState(m.CurrentState)
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitSampleForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	n, err := strconv.Atoi(strings.TrimSpace(values[0]))
+	if err != nil || n < 1 || n > maxSample {
+		m.Form.Err = fmt.Sprintf("enter a whole number from 1 to %d", maxSample)
+		return m, nil
+	}
+	m.Form.Err = ""
+	var cmd redis.RedisCmd
+	switch m.Browser.ActiveKeyType {
+	case "hash":
+		cmd = redis.HRandField(m.ActiveKey, n, true)
+	case "zset":
+		cmd = redis.ZRandMember(m.ActiveKey, n, true)
+	default:
+		cmd = redis.SRandMember(m.ActiveKey, n)
+	}
+	return m.switchToLoadingAndExecute(m.sendRead(cmd))
+}
+
+// sampleResult lays a sample out as a table: fields with their values,
+// members with their scores, or set members alone.
+func (m Model) sampleResult(reply Result, items []string) Result {
+	header, width := []string{"member"}, 1
+	switch m.Browser.ActiveKeyType {
+	case "hash":
+		header, width = []string{"field", "value"}, 2
+	case "zset":
+		header, width = []string{"member", "score"}, 2
+	}
+	var rows [][]string
+	for i := 0; i+width <= len(items); i += width {
+		row := make([]string, width)
+		for j := range row {
+			row[j] = truncateText(oneLine(items[i+j]), sampleCell)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return reply.withValue(fmt.Sprintf("%s is empty or gone; nothing to sample.", m.ActiveKey))
+	}
+	return reply.withValue(fmt.Sprintf("%d random %ss of %s\n\n", len(rows), header[0], m.ActiveKey) + tableText(header, rows))
+}
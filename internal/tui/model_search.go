@@ -0,0 +1,479 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SEARCH_VALUES greps the values of keys matching a pattern: strings, hash
+// fields and values, and list elements. It scans on its own connection,
+// page by page like REPLACE_ALL, and lists hits as they come; Enter opens a
+// hit's key while the scan goes on. Every read is bounded: values over
+// searchMaxBytes or collections over searchMaxElems are skipped rather than
+// fetched, and the scan stops at searchMaxKeys keys or searchMaxHits hits.
+
+const (
+	searchMaxKeys  = 100000
+	searchMaxHits  = 1000
+	searchMaxBytes = 1 << 20
+	searchMaxElems = 10000
+)
+
+// searchTypes are the types SEARCH_VALUES reads, as the form accepts them.
+var searchTypes = []string{"string", "hash", "list"}
+
+// SearchSpec is what the SEARCH_VALUES form asks for.
+type SearchSpec struct {
+	Pattern string
+	Type    string // "" for every searchable type
+	Find    string
+	Regex   bool
+	re      *regexp.Regexp
+}
+
+// match returns where v first matches, or -1.
+func (s SearchSpec) match(v string) int {
+	if s.re != nil {
+		if loc := s.re.FindStringIndex(v); loc != nil {
+			return loc[0]
+		}
+		return -1
+	}
+	return strings.Index(v, s.Find)
+}
+
+// wants reports whether keys of type t are read.
+func (s SearchSpec) wants(t string) bool {
+	if s.Type != "" {
+		return t == s.Type
+	}
+	return searchable(t)
+}
+
+// searchable reports whether SEARCH_VALUES can read keys of type t.
+func searchable(t string) bool {
+	for _, st := range searchTypes {
+		if t == st {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchHit is the first match in one key.
+type SearchHit struct {
+	Key     string
+	Type    string
+	Where   string // hash field or list index; "" for a string
+	Snippet string
+	Matches int // matching elements in the key
+}
+
+// SearchModel is the state of a SEARCH_VALUES scan.
+type SearchModel struct {
+	Spec     SearchSpec
+	Conn     net.Conn
+	Reader   *bufio.Reader
+	Gen      int
+	Count    int // SCAN COUNT hint for the next page
+	Scanned  int
+	Read     int // values fetched and searched
+	Skipped  int // too large to fetch
+	Hits     []SearchHit
+	Cursor   int
+	Started  time.Time
+	Finished time.Time
+	Limit    string // which limit stopped the scan
+	Err      string
+}
+
+// SearchConnMsg delivers the scan's dedicated connection.
+type SearchConnMsg struct {
+	Gen    int
+	Conn   net.Conn
+	Reader *bufio.Reader
+	Error  error
+}
+
+// SearchPageMsg reports one SCAN page.
+type SearchPageMsg struct {
+	Gen     int
+	Cursor  string
+	Took    time.Duration
+	Scanned int
+	Read    int
+	Skipped int
+	Hits    []SearchHit
+	Error   error
+}
+
+func (m Model) startSearchForm() (tea.Model, tea.Cmd) {
+	pattern := m.LastPattern
+	if pattern == "" {
+		pattern = "*"
+	}
+	m.Form = NewForm(
+		"SEARCH_VALUES · find text in string, hash and list values",
+		[]string{"key pattern", "type (string, hash, list; blank = all three)", "find", "regular expression? (y/n)"},
+		[]string{pattern, "", "", "n"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitSearchForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	spec := SearchSpec{Pattern: values[0], Type: strings.ToLower(values[1]), Find: values[2]}
+	if spec.Pattern == "" {
+		m.Form.Err = "enter a key pattern (* for every key)"
+		return m, nil
+	}
+	if spec.Type != "" && !searchable(spec.Type) {
+		m.Form.Err = fmt.Sprintf("type %q: use string, hash or list, or leave it blank", values[1])
+		return m, nil
+	}
+	if spec.Find == "" {
+		m.Form.Err = "enter the text to find"
+		return m, nil
+	}
+	switch strings.ToLower(values[3]) {
+	case "y", "yes":
+		re, err := regexp.Compile(spec.Find)
+		if err != nil {
+			m.Form.Err = "not a regular expression: " + err.Error()
+			return m, nil
+		}
+		spec.Regex, spec.re = true, re
+	case "n", "no":
+	default:
+		m.Form.Err = "answer y or n: regular expression?"
+		return m, nil
+	}
+
+	m.Form.Err = ""
+	m.stopSearch()
+	m.Search = SearchModel{Spec: spec, Gen: m.Search.Gen + 1, Count: firstScanCount(m.scanCountMax()), Started: time.Now()}
+	m.pushState(StateForm)
+	return m.switchToLoadingAndDial(openSearchConnection(m, m.Search.Gen))
+}
+
+func openSearchConnection(m Model, gen int) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m)
+		return SearchConnMsg{Gen: gen, Conn: conn, Reader: reader, Error: err}
+	}
+}
+
+func handleSearchConn(m Model, msg SearchConnMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.Search.Gen {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close()
+		}
+		return m, nil
+	}
+	if msg.Error != nil {
+		m.Result = Result{Type: "error", Err: "Could not open a connection for SEARCH_VALUES: " + msg.Error.Error()}
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	s := &m.Search
+	s.Conn, s.Reader = msg.Conn, msg.Reader
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateSearch
+	}
+	return m, searchPage(s.Conn, s.Reader, s.Spec, "0", s.Count, s.Gen)
+}
+
+// searchSize is the command sizing a key of type t before it is read.
+func searchSize(key, t string) redis.RedisCmd {
+	switch t {
+	case "hash":
+		return redis.HLen(key)
+	case "list":
+		return redis.LLen(key)
+	}
+	return redis.StrLen(key)
+}
+
+// searchRead is the command reading a key of type t whole.
+func searchRead(key, t string) redis.RedisCmd {
+	switch t {
+	case "hash":
+		return redis.HGetAll(key)
+	case "list":
+		return redis.LRange(key, 0, -1)
+	}
+	return redis.Get(key)
+}
+
+// searchPage scans one page from cursor, then in three pipelines reads the
+// keys' types, the wanted ones' sizes, and the values small enough to fetch.
+func searchPage(conn net.Conn, reader *bufio.Reader, spec SearchSpec, cursor string, count, gen int) tea.Cmd {
+	return func() tea.Msg {
+		msg := SearchPageMsg{Gen: gen}
+		start := time.Now()
+		resp, err := readResp(conn, reader, redis.Scan(cursor, spec.Pattern, count))
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		msg.Took = time.Since(start)
+		var keys []string
+		if msg.Cursor, keys, err = scanPage(resp); err != nil {
+			msg.Error = err
+			return msg
+		}
+		msg.Scanned = len(keys)
+		if len(keys) == 0 {
+			return msg
+		}
+
+		cmds := make([]redis.RedisCmd, len(keys))
+		for i, k := range keys {
+			cmds[i] = redis.Type(k)
+		}
+		types, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		var wanted, wantedTypes []string
+		cmds = cmds[:0]
+		for i, t := range types {
+			if t, _ := t.(string); spec.wants(t) {
+				wanted, wantedTypes = append(wanted, keys[i]), append(wantedTypes, t)
+				cmds = append(cmds, searchSize(keys[i], t))
+			}
+		}
+		if len(wanted) == 0 {
+			return msg
+		}
+		sizes, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		var read, readTypes []string
+		cmds = cmds[:0]
+		for i, size := range sizes {
+			n, _ := size.(int)
+			limit := searchMaxElems
+			if wantedTypes[i] == "string" {
+				limit = searchMaxBytes
+			}
+			if n > limit {
+				msg.Skipped++
+				continue
+			}
+			read, readTypes = append(read, wanted[i]), append(readTypes, wantedTypes[i])
+			cmds = append(cmds, searchRead(wanted[i], wantedTypes[i]))
+		}
+		if len(read) == 0 {
+			return msg
+		}
+		values, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		for i, v := range values {
+			if hit, ok := searchValue(spec, read[i], readTypes[i], v); ok {
+				msg.Hits = append(msg.Hits, hit)
+			}
+			msg.Read++
+		}
+		return msg
+	}
+}
+
+// searchValue looks for spec in one key's value: a string, a hash's fields
+// and values, or a list's elements.
+func searchValue(spec SearchSpec, key, t string, v any) (SearchHit, bool) {
+	hit := SearchHit{Key: key, Type: t}
+	found := func(where, s string, at int) {
+		if hit.Matches == 0 {
+			hit.Where, hit.Snippet = where, replaceSnippet(s, at)
+		}
+		hit.Matches++
+	}
+	switch v := v.(type) {
+	case string:
+		// Error and nil replies read as strings too: a key gone or changed
+		// type since TYPE.
+		if t != "string" || v == "(nil)" || redis.IsErrorReply(v) {
+			return hit, false
+		}
+		if at := spec.match(v); at >= 0 {
+			found("", v, at)
+		}
+	case []any:
+		for i, e := range v {
+			s := fmt.Sprint(e)
+			where := "[" + strconv.Itoa(i) + "]"
+			if t == "hash" {
+				if i%2 == 1 {
+					continue // values are checked with their field
+				}
+				where = s
+				if at := spec.match(s); at >= 0 {
+					found(where, s, at)
+					continue
+				}
+				if i+1 < len(v) {
+					s = fmt.Sprint(v[i+1])
+				}
+			}
+			if at := spec.match(s); at >= 0 {
+				found(where, s, at)
+			}
+		}
+	}
+	return hit, hit.Matches > 0
+}
+
+func handleSearchPage(m Model, msg SearchPageMsg) (tea.Model, tea.Cmd) {
+	s := &m.Search
+	if msg.Gen != s.Gen || s.Conn == nil {
+		return m, nil
+	}
+	if msg.Error != nil {
+		s.Err = msg.Error.Error()
+		s.Finished = time.Now()
+		m.stopSearch()
+		return m, nil
+	}
+	s.Count = nextScanCount(s.Count, m.scanCountMax(), msg.Took)
+	s.Scanned += msg.Scanned
+	s.Read += msg.Read
+	s.Skipped += msg.Skipped
+	s.Hits = append(s.Hits, msg.Hits...)
+	switch {
+	case len(s.Hits) >= searchMaxHits:
+		s.Hits = s.Hits[:searchMaxHits]
+		s.Limit = fmt.Sprintf("stopped at %d hits", searchMaxHits)
+	case s.Scanned >= searchMaxKeys:
+		s.Limit = fmt.Sprintf("stopped after %d keys", searchMaxKeys)
+	case msg.Cursor != "0" && msg.Cursor != "":
+		return m, searchPage(s.Conn, s.Reader, s.Spec, msg.Cursor, s.Count, s.Gen)
+	}
+	s.Finished = time.Now()
+	m.stopSearch()
+	return m, nil
+}
+
+// stopSearch closes the scan's connection; an in-flight page is dropped by
+// the Gen bump.
+func (m *Model) stopSearch() {
+	s := &m.Search
+	if s.Conn != nil {
+		_ = s.Conn.Close()
+	}
+	s.Conn, s.Reader = nil, nil
+	s.Gen++
+}
+
+func handleStateSearchKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := &m.Search
+	switch keyMsg.String() {
+	case "esc":
+		m.stopSearch()
+		m.CurrentState = m.popState()
+	case "x":
+		if s.Conn != nil {
+			s.Err, s.Finished = "stopped", time.Now()
+			m.stopSearch()
+		}
+	case "up", "k":
+		s.Cursor = max(s.Cursor-1, 0)
+	case "down", "j":
+		s.Cursor = min(s.Cursor+1, max(len(s.Hits)-1, 0))
+	case "enter":
+		// The scan goes on meanwhile; esc from the key comes back here.
+		if s.Cursor < len(s.Hits) {
+			key := s.Hits[s.Cursor].Key
+			return m, func() tea.Msg { return SelectKeyMsg{Key: key} }
+		}
+	}
+	return m, nil
+}
+
+func (m Model) searchView() string {
+	s := m.Search
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+
+	kind, types := "literal", s.Spec.Type
+	if s.Spec.Regex {
+		kind = "regex"
+	}
+	if types == "" {
+		types = strings.Join(searchTypes, ", ")
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnPurple)).Bold(true).Render("SEARCH_VALUES") +
+		dim.Render(fmt.Sprintf(" · %s · %s · %s %q", s.Spec.Pattern, types, kind, s.Spec.Find))
+
+	end := s.Finished
+	if end.IsZero() {
+		end = time.Now()
+	}
+	counts := fmt.Sprintf("%d keys scanned, %d values searched, %d hits", s.Scanned, s.Read, len(s.Hits))
+	if s.Skipped > 0 {
+		counts += fmt.Sprintf(", %d too large to read", s.Skipped)
+	}
+	var status string
+	switch {
+	case s.Err != "":
+		status = red.Render(s.Err + ": " + counts)
+	case s.Limit != "":
+		status = subtle.Render(s.Limit + ": " + counts)
+	case !s.Finished.IsZero():
+		status = green.Render(fmt.Sprintf("done in %s: %s", end.Sub(s.Started).Round(time.Millisecond), counts))
+	default:
+		status = subtle.Render("searching… " + counts)
+	}
+
+	width := 8
+	for _, h := range s.Hits {
+		width = max(width, min(len(h.Key), 40))
+	}
+	rowsAvail := max(m.WindowHeight-12, 3)
+	first := max(s.Cursor-rowsAvail+1, 0)
+	var rows []string
+	for i := first; i < len(s.Hits) && i < first+rowsAvail; i++ {
+		h := s.Hits[i]
+		marker, name := "  ", text.Render(fmt.Sprintf("%-*s", width, truncateText(h.Key, 40)))
+		if i == s.Cursor {
+			marker, name = accent.Render(pointerGlyph), accent.Bold(true).Render(fmt.Sprintf("%-*s", width, truncateText(h.Key, 40)))
+		}
+		where := h.Type
+		if h.Where != "" {
+			where += " " + truncateText(h.Where, 20)
+		}
+		if h.Matches > 1 {
+			where += fmt.Sprintf(" +%d", h.Matches-1)
+		}
+		rows = append(rows, marker+name+"  "+dim.Render(fmt.Sprintf("%-16s", where))+"  "+subtle.Render(h.Snippet))
+	}
+	if len(s.Hits) == 0 {
+		rows = append(rows, "  "+dim.Render("no hits yet"))
+	}
+
+	body := "  " + title + "\n\n  " + status + "\n\n" + strings.Join(rows, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(searchKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
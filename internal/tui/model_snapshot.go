@@ -0,0 +1,324 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	maxSnapshotKeys  = 1000 // keys written per snapshot across all patterns
+	maxSnapshotFiles = 20   // recent files listed on the status screen
+)
+
+// SnapshotModel is the state of the scheduled snapshotter. It keeps running in
+// the background on its own connection while the user works on other screens,
+// so it never competes for the session's connection.
+type SnapshotModel struct {
+	Patterns []string
+	Interval time.Duration
+	Dir      string
+
+	Conn   net.Conn
+	Reader *bufio.Reader
+	Active bool
+	Busy   bool // a snapshot is being written
+	Gen    int  // retires the tick chain of a stopped snapshotter
+
+	Count int
+	Files []string // newest first
+	Next  time.Time
+	Err   string
+}
+
+// SnapshotConnMsg delivers the snapshotter's dedicated connection.
+type SnapshotConnMsg struct {
+	Gen    int
+	Conn   net.Conn
+	Reader *bufio.Reader
+	Error  error
+}
+
+// SnapshotDoneMsg reports one written snapshot file.
+type SnapshotDoneMsg struct {
+	Gen   int
+	File  string
+	Keys  int
+	Error error
+}
+
+// SnapshotTickMsg fires when the next snapshot is due.
+type SnapshotTickMsg struct{ Gen int }
+
+// snapshotFile is the on-disk format of one snapshot.
+type snapshotFile struct {
+	Taken    string                   `json:"taken"`
+	Patterns []string                 `json:"patterns"`
+	Keys     map[string]snapshotEntry `json:"keys"`
+}
+
+type snapshotEntry struct {
+	Type  string `json:"type"`
+	TTL   int    `json:"ttl"`
+	Value any    `json:"value"`
+}
+
+// startSnapshot opens the form, or the status screen when the snapshotter is
+// already running.
+func (m Model) startSnapshot() (tea.Model, tea.Cmd) {
+	if m.Snapshot.Active {
+		m.CurrentState = StateSnapshot
+		return m, nil
+	}
+	m.Form = NewForm(
+		"SNAPSHOT · periodically save keys to timestamped JSON files",
+		[]string{"keys or patterns (comma-separated)", "every (seconds)", "directory"},
+		[]string{"", "60", "./snapshots"},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitSnapshotForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	var patterns []string
+	for _, s := range strings.Split(values[0], ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			patterns = append(patterns, s)
+		}
+	}
+	if len(patterns) == 0 {
+		m.Form.Err = "enter at least one key or pattern"
+		return m, nil
+	}
+	if reason := m.proxyGlob(patterns); reason != "" {
+		m.Form.Err = reason
+		return m, nil
+	}
+	secs, err := strconv.Atoi(values[1])
+	if err != nil || secs <= 0 {
+		m.Form.Err = "interval must be a positive number of seconds"
+		return m, nil
+	}
+	dir := values[2]
+	if dir == "" {
+		dir = "."
+	}
+
+	m.Form.Err = ""
+	m.stopSnapshot()
+	m.Snapshot = SnapshotModel{
+		Patterns: patterns,
+		Interval: time.Duration(secs) * time.Second,
+		Dir:      dir,
+		Gen:      m.Snapshot.Gen + 1,
+	}
+	m.pushState(StateForm)
+	return m.switchToLoadingAndDial(openSnapshotConnection(m, m.Snapshot.Gen))
+}
+
+func openSnapshotConnection(m Model, gen int) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m)
+		return SnapshotConnMsg{Gen: gen, Conn: conn, Reader: reader, Error: err}
+	}
+}
+
+func handleSnapshotConn(m Model, msg SnapshotConnMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.Snapshot.Gen {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close()
+		}
+		return m, nil
+	}
+	if msg.Error != nil {
+		m.Result = Result{Type: "error", Err: "Could not open a connection for snapshots: " + msg.Error.Error()}
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	s := &m.Snapshot
+	s.Conn, s.Reader, s.Active = msg.Conn, msg.Reader, true
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateSnapshot
+	}
+	return m, m.takeSnapshot()
+}
+
+// takeSnapshot starts writing one snapshot file.
+func (m *Model) takeSnapshot() tea.Cmd {
+	s := &m.Snapshot
+	s.Busy = true
+	return writeSnapshot(s.Conn, s.Reader, s.Patterns, s.Dir, m.scanCountMax(), s.Gen)
+}
+
+func writeSnapshot(conn net.Conn, reader *bufio.Reader, patterns []string, dir string, scanCount, gen int) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		snap := snapshotFile{Taken: now.Format(time.RFC3339), Patterns: patterns, Keys: map[string]snapshotEntry{}}
+		for _, p := range patterns {
+			keys := []string{p} // a key named outright needs no SCAN
+			if strings.ContainsAny(p, "*?[") {
+				var err error
+				if keys, err = scanAllKeys(conn, reader, p, maxSnapshotKeys-len(snap.Keys), scanCount); err != nil {
+					return SnapshotDoneMsg{Gen: gen, Error: err}
+				}
+			}
+			for _, k := range keys {
+				if _, done := snap.Keys[k]; done {
+					continue
+				}
+				v, err := fetchKeyValue(conn, reader, k)
+				if err != nil {
+					// An oversized or unsupported key shouldn't sink the whole
+					// snapshot; record why it's missing instead.
+					snap.Keys[k] = snapshotEntry{Type: "error", Value: err.Error()}
+					continue
+				}
+				if v.Type == "none" {
+					continue // expired since the scan
+				}
+				snap.Keys[k] = snapshotEntry{Type: v.Type, TTL: v.TTL, Value: snapshotValue(v)}
+			}
+			if len(snap.Keys) >= maxSnapshotKeys {
+				break
+			}
+		}
+
+		path, err := resolveFilePath(filepath.Join(dir, "snapshot-"+now.Format("20060102-150405")+".json"), true, "")
+		if err != nil {
+			return SnapshotDoneMsg{Gen: gen, Error: err}
+		}
+		data, err := json.MarshalIndent(snap, "", "  ")
+		if err != nil {
+			return SnapshotDoneMsg{Gen: gen, Error: err}
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return SnapshotDoneMsg{Gen: gen, Error: err}
+		}
+		return SnapshotDoneMsg{Gen: gen, File: path, Keys: len(snap.Keys)}
+	}
+}
+
+// snapshotValue converts a fetched value to its natural JSON shape: a string,
+// an object for hashes and sorted sets (member → score), an array otherwise.
+func snapshotValue(v keyValue) any {
+	switch v.Type {
+	case "string":
+		return v.String
+	case "hash", "zset":
+		return v.Fields
+	}
+	return v.Items
+}
+
+func snapshotTick(gen int, every time.Duration) tea.Cmd {
+	return tea.Tick(every, func(time.Time) tea.Msg { return SnapshotTickMsg{Gen: gen} })
+}
+
+func handleSnapshotDone(m Model, msg SnapshotDoneMsg) (tea.Model, tea.Cmd) {
+	s := &m.Snapshot
+	if !s.Active || msg.Gen != s.Gen {
+		return m, nil
+	}
+	s.Busy = false
+	if msg.Error != nil {
+		// Keep going: a transient error shouldn't end an incident-long capture.
+		s.Err = msg.Error.Error()
+	} else {
+		s.Err = ""
+		s.Count++
+		s.Files = append([]string{fmt.Sprintf("%s  (%d keys)", msg.File, msg.Keys)}, s.Files...)
+		if len(s.Files) > maxSnapshotFiles {
+			s.Files = s.Files[:maxSnapshotFiles]
+		}
+	}
+	s.Next = time.Now().Add(s.Interval)
+	return m, snapshotTick(s.Gen, s.Interval)
+}
+
+func handleSnapshotTick(m Model, msg SnapshotTickMsg) (tea.Model, tea.Cmd) {
+	if !m.Snapshot.Active || msg.Gen != m.Snapshot.Gen || m.Snapshot.Busy {
+		return m, nil
+	}
+	return m, m.takeSnapshot()
+}
+
+// stopSnapshot closes the snapshotter's connection and retires its tick chain.
+func (m *Model) stopSnapshot() {
+	s := &m.Snapshot
+	if s.Conn != nil {
+		_ = s.Conn.Close()
+	}
+	s.Conn, s.Reader = nil, nil
+	s.Active, s.Busy = false, false
+	s.Gen++
+}
+
+func handleStateSnapshotKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch keyMsg.String() {
+	case "esc":
+		// Leaving the screen doesn't stop the snapshotter — that's the point.
+		m.CurrentState = m.popState()
+	case "s":
+		if m.Snapshot.Active && !m.Snapshot.Busy {
+			// The pending tick finds Busy set or a fresh chain; bump Gen so the
+			// old tick is ignored and this snapshot starts the next interval.
+			m.Snapshot.Gen++
+			return m, m.takeSnapshot()
+		}
+	case "x":
+		m.stopSnapshot()
+		m.CurrentState = m.popState()
+	}
+	return m, nil
+}
+
+func (m Model) snapshotView() string {
+	s := m.Snapshot
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Bold(true).Render("SNAPSHOT") +
+		dim.Render(fmt.Sprintf(" · %s · every %s → %s", strings.Join(s.Patterns, ", "), s.Interval, s.Dir))
+
+	var status string
+	switch {
+	case !s.Active:
+		status = dim.Render("stopped")
+	case s.Busy:
+		status = green.Render("●") + " " + subtle.Render("writing snapshot…")
+	default:
+		status = green.Render("●") + " " + subtle.Render(fmt.Sprintf("%d written · next at %s", s.Count, s.Next.Format("15:04:05")))
+	}
+	if s.Err != "" {
+		status += "   " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render("✗ last attempt: "+s.Err)
+	}
+
+	rows := []string{}
+	for _, f := range s.Files {
+		rows = append(rows, text.Render(f))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, dim.Render("no snapshots written yet"))
+	}
+	if avail := m.WindowHeight - 8; avail > 0 && len(rows) > avail {
+		rows = rows[:avail]
+	}
+
+	body := "  " + title + "\n  " + status + "\n\n" + indentLines(strings.Join(rows, "\n"), 2)
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(snapshotKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
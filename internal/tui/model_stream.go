@@ -0,0 +1,283 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// streamChunk is how much of the value each read hands to the screen.
+	streamChunk = 64 << 10
+	// streamMaxLines is how many rows are kept; past it each new row takes
+	// the place of the oldest.
+	streamMaxLines = 100_000
+)
+
+// StreamModel shows a large string value as it arrives. The value is read in
+// chunks over a dedicated connection and kept only as hard-wrapped lines, the
+// last streamMaxLines of them; rendering touches just the rows on screen, so
+// neither the UI nor memory scales with the full reply.
+type StreamModel struct {
+	Key      string
+	Conn     net.Conn
+	Body     *redis.BulkReader
+	Gen      int
+	Width    int      // wrap width, fixed when the stream starts
+	Lines    []string // a ring of rows: the oldest is at First once it is full
+	First    int
+	Dropped  int    // rows let go to stay within streamMaxLines
+	partial  []byte // the row being filled, possibly ending mid-rune
+	Received int
+	Total    int
+	Done     bool
+	Err      string
+	Offset   int // first row on screen
+	Started  time.Time
+	Finished time.Time
+}
+
+// StreamStartMsg delivers the stream's connection with the reply header read.
+// Value is set instead of Body when the reply was not a bulk string.
+type StreamStartMsg struct {
+	Gen   int
+	Conn  net.Conn
+	Body  *redis.BulkReader
+	Value any
+	Error error
+}
+
+// StreamChunkMsg delivers the next piece of the value.
+type StreamChunkMsg struct {
+	Gen   int
+	Data  []byte
+	Done  bool
+	Error error
+}
+
+// startStream fetches key through the stream screen instead of the output
+// screen.
+func (m Model) startStream(key string) (tea.Model, tea.Cmd) {
+	m.stopStream()
+	w, _ := m.outputVPSize()
+	m.Stream = StreamModel{Key: key, Gen: m.Stream.Gen + 1, Width: w, Started: time.Now()}
+	return m.switchToLoadingAndDial(openStream(m.readModel(), key, m.Stream.Gen))
+}
+
+// openStream dials the stream's own connection and sends GET on it; nothing
+// goes over the shared connection.
+func openStream(m Model, key string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m)
+		if err != nil {
+			return StreamStartMsg{Gen: gen, Error: err}
+		}
+		msg := StreamStartMsg{Gen: gen, Conn: conn}
+		if _, err := conn.Write(redis.Get(key).ToBytes()); err != nil {
+			msg.Error = err
+			return msg
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+		msg.Body, msg.Value, msg.Error = redis.ReadRespStream(reader)
+		return msg
+	}
+}
+
+func handleStreamStart(m Model, msg StreamStartMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.Stream.Gen {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close()
+		}
+		return m, nil
+	}
+	s := &m.Stream
+	s.Conn = msg.Conn
+	if msg.Error != nil || msg.Body == nil {
+		// Nothing to stream: a failure, or a nil/error reply (the key changed
+		// since its size was checked). Show it the usual way.
+		m.stopStream()
+		m.SelectedOp = OpLargePreview
+		if msg.Error != nil {
+			m.Result = errorResult(msg.Error)
+		} else {
+			m.Result = replyResult(RedisResultMsg{Result: msg.Value}).withValue(fmt.Sprint(msg.Value))
+		}
+		m.CurrentState = StateOutput
+		return withOutputViewport(m, nil)
+	}
+	s.Body, s.Total = msg.Body, msg.Body.Len
+	m.CurrentState = StateStream
+	return m, readStreamChunk(s.Conn, s.Body, s.Gen)
+}
+
+func readStreamChunk(conn net.Conn, body *redis.BulkReader, gen int) tea.Cmd {
+	return func() tea.Msg {
+		buf := make([]byte, min(streamChunk, body.Remaining()))
+		_ = conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+		n, err := io.ReadFull(body, buf)
+		if err != nil {
+			return StreamChunkMsg{Gen: gen, Error: err}
+		}
+		return StreamChunkMsg{Gen: gen, Data: buf[:n], Done: body.Remaining() == 0}
+	}
+}
+
+func handleStreamChunk(m Model, msg StreamChunkMsg) (tea.Model, tea.Cmd) {
+	s := &m.Stream
+	if msg.Gen != s.Gen || s.Conn == nil {
+		return m, nil
+	}
+	if msg.Error != nil {
+		s.Err = msg.Error.Error()
+		m.stopStream()
+		return m, nil
+	}
+	s.Received += len(msg.Data)
+	s.feed(msg.Data, msg.Done)
+	if msg.Done {
+		s.Done, s.Finished = true, time.Now()
+		m.stopStream()
+		return m, nil
+	}
+	return m, readStreamChunk(s.Conn, s.Body, s.Gen)
+}
+
+// feed pushes data as rows, breaking them at newlines and every Width runes.
+// A row still being filled is held back until it is complete or final is set;
+// a multi-byte rune split across chunks is held back with it.
+func (s *StreamModel) feed(data []byte, final bool) {
+	buf := append(s.partial, data...)
+	start, runes := 0, 0
+	for i := 0; i < len(buf); {
+		if buf[i] == '\n' {
+			s.push(string(buf[start:i]))
+			i++
+			start, runes = i, 0
+			continue
+		}
+		if !final && !utf8.FullRune(buf[i:]) {
+			break
+		}
+		_, size := utf8.DecodeRune(buf[i:])
+		i += size
+		runes++
+		if runes == max(s.Width, 1) {
+			s.push(string(buf[start:i]))
+			start, runes = i, 0
+		}
+	}
+	s.partial = append([]byte(nil), buf[start:]...)
+	if final && len(s.partial) > 0 {
+		s.push(string(s.partial))
+		s.partial = nil
+	}
+}
+
+// push adds a row, in place of the oldest once streamMaxLines are kept. The
+// rows on screen stay put as those above them go.
+func (s *StreamModel) push(line string) {
+	if len(s.Lines) < streamMaxLines {
+		s.Lines = append(s.Lines, line)
+		return
+	}
+	s.Lines[s.First] = line
+	s.First = (s.First + 1) % len(s.Lines)
+	s.Dropped++
+	s.Offset = max(s.Offset-1, 0)
+}
+
+// Rows returns the kept rows from..to-1, oldest first, clipped to those
+// there are.
+func (s StreamModel) Rows(from, to int) []string {
+	to = min(to, len(s.Lines))
+	if from >= to {
+		return nil
+	}
+	rows := make([]string, 0, to-from)
+	for i := from; i < to; i++ {
+		rows = append(rows, s.Lines[(s.First+i)%len(s.Lines)])
+	}
+	return rows
+}
+
+// stopStream closes the stream's connection; a chunk still in flight is
+// dropped by the Gen bump. The lines received so far stay on screen.
+func (m *Model) stopStream() {
+	s := &m.Stream
+	if s.Conn != nil {
+		_ = s.Conn.Close()
+	}
+	s.Conn, s.Body = nil, nil
+	s.Gen++
+}
+
+// streamRows is how many value rows fit on the stream screen.
+func (m Model) streamRows() int {
+	return max(m.WindowHeight-8, 3)
+}
+
+func handleStateStreamKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := &m.Stream
+	last := max(len(s.Lines)-m.streamRows(), 0)
+	switch keyMsg.String() {
+	case "esc":
+		m.stopStream()
+		m.Stream.Lines, m.Stream.First, m.Stream.partial = nil, 0, nil
+		m.CurrentState = m.popState()
+	case "up", "k":
+		s.Offset--
+	case "down", "j":
+		s.Offset++
+	case "pgup":
+		s.Offset -= m.streamRows()
+	case "pgdown", " ":
+		s.Offset += m.streamRows()
+	case "home", "g":
+		s.Offset = 0
+	case "end", "G":
+		s.Offset = last
+	case "b":
+		m.RawSizes = !m.RawSizes
+	}
+	s.Offset = min(max(s.Offset, 0), last)
+	return m, nil
+}
+
+func (m Model) streamView() string {
+	s := m.Stream
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed))
+
+	pct := 100 * s.Received / max(s.Total, 1)
+	progress := fmt.Sprintf(" · %s of %s (%d%%)", sizeText(s.Received, m.RawSizes), sizeText(s.Total, m.RawSizes), pct)
+	if s.Done {
+		progress = fmt.Sprintf(" · %s in %s", sizeText(s.Total, m.RawSizes), s.Finished.Sub(s.Started).Round(time.Millisecond))
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnBlue)).Bold(true).Render("GET") +
+		" " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Render(s.Key) + dim.Render(progress)
+
+	rows := s.Rows(s.Offset, s.Offset+m.streamRows())
+	body := "  " + title + "\n\n" + indentLines(green.Render(strings.Join(rows, "\n")), 2)
+	if len(s.Lines) > m.streamRows() {
+		pos := fmt.Sprintf("lines %d–%d of %d", s.Offset+1, s.Offset+len(rows), len(s.Lines))
+		if s.Dropped > 0 {
+			pos += fmt.Sprintf(" · the first %d dropped to stay within %d", s.Dropped, streamMaxLines)
+		}
+		body += "\n  " + dim.Render(pos)
+	}
+	if s.Err != "" {
+		body += "\n  " + red.Render("stopped: "+s.Err)
+	}
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(streamKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
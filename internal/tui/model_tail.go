@@ -0,0 +1,378 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Opening a stream key tails it: the newest entries are shown, then XREAD
+// BLOCK waits for more on a dedicated connection, for the same reason the
+// BLPOP watcher has one, and appends them as they arrive. Each read picks up
+// after the last entry seen, so pausing never loses entries: they arrive
+// together on resume.
+
+const (
+	tailBacklog       = 20   // entries shown when the tail opens
+	tailBlockMs       = 5000 // how long each XREAD waits before the next
+	tailBatch         = 100  // entries taken per XREAD
+	defaultTailBuffer = 1000
+	minTailBuffer     = 100
+	maxTailBuffer     = 100000
+)
+
+// TailModel is the state of a stream's live tail.
+type TailModel struct {
+	Key     string
+	Conn    net.Conn
+	Reader  *bufio.Reader
+	LastID  string      // the newest entry read; the next XREAD starts after it
+	Entries []TailEntry // oldest first, at most Buffer of them
+	Buffer  int
+	Dropped int // entries let go to stay within Buffer
+	Arrived int // entries read since the tail opened, the backlog aside
+	Waiting bool
+	Paused  bool
+	Offset  int  // first entry on screen
+	Follow  bool // keep the newest entry in view
+	Err     string
+}
+
+// TailEntry is one stream entry: its ID and field/value pairs.
+type TailEntry struct {
+	ID     string
+	Fields []string
+}
+
+// TailConnMsg delivers the tail's connection with the newest entries read.
+type TailConnMsg struct {
+	Conn    net.Conn
+	Reader  *bufio.Reader
+	Backlog []TailEntry // oldest first
+	Error   error
+}
+
+// TailReadMsg is the outcome of one XREAD. Conn identifies the connection it
+// ran on so reads of an already-closed tail are dropped.
+type TailReadMsg struct {
+	Conn    net.Conn
+	Entries []TailEntry
+	Error   error
+}
+
+// startTail opens key's tail from wherever the key was picked.
+func (m Model) startTail(key string) (tea.Model, tea.Cmd) {
+	m.stopTail()
+	m.SelectedOp = OpTail
+	m.Tail = TailModel{Key: key, Buffer: defaultTailBuffer, Follow: true}
+	return m.switchToLoadingAndDial(openTail(m.readModel(), key))
+}
+
+func openTail(m Model, key string) tea.Cmd {
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m)
+		if err != nil {
+			return TailConnMsg{Error: err}
+		}
+		msg := TailConnMsg{Conn: conn, Reader: reader}
+		_ = conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+		if _, err := conn.Write(redis.XRevRange(key, tailBacklog).ToBytes()); err != nil {
+			msg.Error = err
+			return msg
+		}
+		resp, err := redis.ReadResp(reader)
+		_ = conn.SetReadDeadline(time.Time{})
+		if err == nil {
+			msg.Backlog, err = streamEntries(resp)
+		}
+		msg.Error = err
+		for i, j := 0, len(msg.Backlog)-1; i < j; i, j = i+1, j-1 {
+			msg.Backlog[i], msg.Backlog[j] = msg.Backlog[j], msg.Backlog[i]
+		}
+		return msg
+	}
+}
+
+// streamEntries reads an XRANGE-style reply: [[id, [field, value, …]], …].
+func streamEntries(resp any) ([]TailEntry, error) {
+	if s, ok := resp.(string); ok && redis.IsErrorReply(s) {
+		return nil, fmt.Errorf("%s", s)
+	}
+	items, ok := resp.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected stream reply")
+	}
+	entries := make([]TailEntry, 0, len(items))
+	for _, it := range items {
+		pair, ok := it.([]any)
+		if !ok || len(pair) != 2 {
+			return nil, fmt.Errorf("unexpected stream entry")
+		}
+		id, _ := pair[0].(string)
+		fields, err := redis.AsStringSlice(pair[1])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, TailEntry{ID: id, Fields: fields})
+	}
+	return entries, nil
+}
+
+func handleTailConn(m Model, msg TailConnMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close()
+		}
+		m.Result = Result{Type: "error", Err: "Could not tail " + m.Tail.Key + ": " + msg.Error.Error()}
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	// The user may have backed out while the connection was being opened.
+	if m.CurrentState != StateLoading || m.SelectedOp != OpTail {
+		_ = msg.Conn.Close()
+		return m, nil
+	}
+	t := &m.Tail
+	t.Conn, t.Reader = msg.Conn, msg.Reader
+	t.LastID = "0-0" // an empty stream: anything added is new
+	t.add(msg.Backlog)
+	m.CurrentState = StateTail
+	if m.Proxy {
+		// The backlog is an XREVRANGE, which proxies forward; following isn't.
+		m.stopTail()
+		t.Err = "not following: it needs " + m.proxyNeeds("XREAD BLOCK")
+		return m, nil
+	}
+	return m, m.armTailRead()
+}
+
+// armTailRead issues the next XREAD.
+func (m *Model) armTailRead() tea.Cmd {
+	t := &m.Tail
+	t.Waiting = true
+	return tailRead(t.Conn, t.Reader, t.Key, t.LastID, m.ReadTimeout)
+}
+
+func tailRead(conn net.Conn, reader *bufio.Reader, key, after string, readTimeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := conn.Write(redis.XReadBlock(key, after, tailBlockMs, tailBatch).ToBytes()); err != nil {
+			return TailReadMsg{Conn: conn, Error: err}
+		}
+		// The server holds the reply for up to the block time, so the usual
+		// read deadline is granted on top of it.
+		if readTimeout == 0 {
+			readTimeout = defaultReadTimeout
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(tailBlockMs*time.Millisecond + readTimeout))
+		resp, err := redis.ReadResp(reader)
+		_ = conn.SetReadDeadline(time.Time{})
+		if err != nil {
+			return TailReadMsg{Conn: conn, Error: err}
+		}
+		if resp == "(nil)" {
+			return TailReadMsg{Conn: conn} // nothing within the block time
+		}
+		if s, ok := resp.(string); ok && redis.IsErrorReply(s) {
+			return TailReadMsg{Conn: conn, Error: fmt.Errorf("%s", s)}
+		}
+		// [[key, entries]] for the one stream read.
+		streams, ok := resp.([]any)
+		if !ok || len(streams) != 1 {
+			return TailReadMsg{Conn: conn, Error: fmt.Errorf("unexpected XREAD reply")}
+		}
+		pair, ok := streams[0].([]any)
+		if !ok || len(pair) != 2 {
+			return TailReadMsg{Conn: conn, Error: fmt.Errorf("unexpected XREAD reply")}
+		}
+		entries, err := streamEntries(pair[1])
+		return TailReadMsg{Conn: conn, Entries: entries, Error: err}
+	}
+}
+
+func handleTailRead(m Model, msg TailReadMsg) (tea.Model, tea.Cmd) {
+	t := &m.Tail
+	if msg.Conn == nil || msg.Conn != t.Conn {
+		return m, nil // tail already stopped
+	}
+	t.Waiting = false
+	if msg.Error != nil {
+		t.Err = msg.Error.Error()
+		m.stopTail()
+		return m, nil
+	}
+	t.Arrived += len(msg.Entries)
+	t.add(msg.Entries)
+	// Reads go on under the XADD form opened from here, so its entries are
+	// waiting on return.
+	if !t.Paused {
+		return m, m.armTailRead()
+	}
+	return m, nil
+}
+
+// add appends entries, moves LastID past them, and lets the oldest go once
+// there are more than Buffer.
+func (t *TailModel) add(entries []TailEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	t.Entries = append(t.Entries, entries...)
+	t.LastID = entries[len(entries)-1].ID
+	t.trim()
+}
+
+func (t *TailModel) trim() {
+	if over := len(t.Entries) - t.Buffer; over > 0 {
+		t.Entries = append([]TailEntry(nil), t.Entries[over:]...)
+		t.Dropped += over
+		t.Offset = max(t.Offset-over, 0) // keep the same entries on screen
+	}
+}
+
+// stopTail closes the tail's connection, which also ends an XREAD still
+// parked on the server; its error is dropped by the Conn check in
+// handleTailRead.
+func (m *Model) stopTail() {
+	if m.Tail.Conn != nil {
+		_ = m.Tail.Conn.Close()
+	}
+	m.Tail.Conn, m.Tail.Reader = nil, nil
+	m.Tail.Waiting = false
+}
+
+// tailRows is how many entries fit on the tail screen.
+func (m Model) tailRows() int {
+	// header(2) + blank + title + status + blank + position + footer(2)
+	return max(m.WindowHeight-9, 3)
+}
+
+func handleStateTailKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	t := &m.Tail
+	last := max(len(t.Entries)-m.tailRows(), 0)
+	if t.Follow {
+		t.Offset = last
+	}
+	switch keyMsg.String() {
+	case "esc":
+		m.stopTail()
+		m.Tail.Entries = nil
+		m.CurrentState = m.popState()
+		return m, nil
+	case "p":
+		// A read already parked on the server may still deliver, but no new
+		// one is issued until resumed, which starts after the last entry.
+		t.Paused = !t.Paused
+		if !t.Paused && !t.Waiting && t.Conn != nil {
+			return m, m.armTailRead()
+		}
+	case "a":
+		return m.startXAddForm(t.Key)
+	case "C":
+		return m.startGroups(t.Key)
+	case "c":
+		t.Entries, t.Offset = nil, 0
+	case "+", "=":
+		t.Buffer = min(t.Buffer*2, maxTailBuffer)
+	case "-":
+		t.Buffer = max(t.Buffer/2, minTailBuffer)
+		t.trim()
+	case "up", "k":
+		t.Offset--
+	case "down", "j":
+		t.Offset++
+	case "pgup":
+		t.Offset -= m.tailRows()
+	case "pgdown", " ":
+		t.Offset += m.tailRows()
+	case "home", "g":
+		t.Offset = 0
+	case "end", "G":
+		t.Offset = last
+	}
+	last = max(len(t.Entries)-m.tailRows(), 0)
+	t.Offset = min(max(t.Offset, 0), last)
+	t.Follow = t.Offset == last
+	return m, nil
+}
+
+// tailLine splits one entry into its columns: the time in its ID, the ID,
+// and the fields.
+func tailLine(e TailEntry) (at, id, fields string) {
+	var b strings.Builder
+	for i := 0; i+1 < len(e.Fields); i += 2 {
+		if i > 0 {
+			b.WriteString("  ")
+		}
+		b.WriteString(oneLine(e.Fields[i]) + "=" + oneLine(e.Fields[i+1]))
+	}
+	at = strings.Repeat(" ", len("15:04:05.000"))
+	ms, _, _ := strings.Cut(e.ID, "-")
+	if n, err := strconv.ParseInt(ms, 10, 64); err == nil {
+		at = time.UnixMilli(n).Format("15:04:05.000")
+	}
+	return at, e.ID, b.String()
+}
+
+func (m Model) tailView() string {
+	t := m.Tail
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	faint := lipgloss.NewStyle().Foreground(lipgloss.Color(tnFaint))
+	green := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnPurple)).Bold(true).Render("XREAD") +
+		dim.Render(" · ") + subtle.Render(t.Key) +
+		dim.Render(fmt.Sprintf("   BLOCK %ds · buffer %d · dedicated connection", tailBlockMs/1000, t.Buffer))
+
+	var status string
+	switch {
+	case t.Err != "":
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render("✗ stopped: " + t.Err)
+	case t.Paused:
+		status = lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow)).Render("⏸ paused") +
+			dim.Render(" — resuming reads everything added since")
+	default:
+		status = green.Render("●") + " " + subtle.Render(fmt.Sprintf("tailing · %d new since opened", t.Arrived))
+	}
+	if t.Dropped > 0 {
+		status += dim.Render(fmt.Sprintf(" · %d older dropped", t.Dropped))
+	}
+
+	last := max(len(t.Entries)-m.tailRows(), 0)
+	offset := min(t.Offset, last)
+	if t.Follow {
+		offset = last
+	}
+	shown := t.Entries[offset:min(offset+m.tailRows(), len(t.Entries))]
+	width := max(m.WindowWidth-4, 20)
+	rows := make([]string, 0, len(shown))
+	for _, e := range shown {
+		at, id, fields := tailLine(e)
+		rows = append(rows, faint.Render(at)+"  "+dim.Render(id)+"  "+
+			text.Render(truncateText(fields, max(width-len(at)-len(id)-4, 10))))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, dim.Render("no entries yet — XADD to "+t.Key+" from another client"))
+	}
+
+	position := ""
+	if len(t.Entries) > m.tailRows() {
+		position = fmt.Sprintf("entries %d–%d of %d", offset+1, offset+len(shown), len(t.Entries))
+		if !t.Follow {
+			position += " · end to follow new entries"
+		}
+	}
+	body := "  " + title + "\n  " + status + "\n\n" + indentLines(strings.Join(rows, "\n"), 2) + "\n  " + dim.Render(position)
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(tailKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
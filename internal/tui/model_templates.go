@@ -0,0 +1,296 @@
+package tui
+
+import (
+	"bufio"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Template is a named shape for a new key, from the config file's
+// "templates" list: a hash with preset fields or a string with a preset value.
+// Values may contain placeholders, expanded when the form opens.
+type Template struct {
+	Name   string          `json:"name"`
+	Type   string          `json:"type"` // hash or string
+	Key    string          `json:"key"`
+	Fields []TemplateField `json:"fields,omitempty"` // hash
+	Value  string          `json:"value,omitempty"`  // string
+	TTL    int             `json:"ttl,omitempty"`    // seconds; 0 = no expiry
+}
+
+// TemplateField is one hash field; a list keeps the configured order.
+type TemplateField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// defaultTemplates are offered when the config file defines none.
+var defaultTemplates = []Template{
+	{
+		Name: "session hash", Type: "hash", Key: "session:{{uuid}}", TTL: 3600,
+		Fields: []TemplateField{{"user_id", ""}, {"created_at", "{{now}}"}, {"expires_at", "{{now+3600}}"}},
+	},
+	{
+		Name: "user hash", Type: "hash", Key: "user:",
+		Fields: []TemplateField{{"name", ""}, {"email", ""}, {"created_at", "{{now}}"}},
+	},
+	{Name: "JSON document", Type: "string", Key: "doc:{{uuid}}", Value: `{"id": "", "name": ""}`},
+}
+
+// validate reports what is wrong with a configured template.
+func (t Template) validate() error {
+	switch {
+	case t.Name == "":
+		return fmt.Errorf("every template needs a name")
+	case t.Type == "hash" && len(t.Fields) == 0:
+		return fmt.Errorf("template %q: a hash template needs fields", t.Name)
+	case t.Type != "hash" && t.Type != "string":
+		return fmt.Errorf("template %q: type must be hash or string", t.Name)
+	case t.TTL < 0:
+		return fmt.Errorf("template %q: ttl must not be negative", t.Name)
+	}
+	return nil
+}
+
+// TemplatesModel is the template picker.
+type TemplatesModel struct {
+	Items  []Template
+	Cursor int
+	Active Template // the template whose form is open
+}
+
+// TemplateResultMsg reports a key created from a template. Exists means the
+// key was already there and nothing was written.
+type TemplateResultMsg struct {
+	Key    string
+	Exists bool
+	Output string
+	Error  error
+}
+
+var placeholderRe = regexp.MustCompile(`\{\{\s*(now|uuid)\s*(?:([+-])\s*(\d+))?\s*\}\}`)
+
+// expandPlaceholders fills in {{now}} (unix seconds, optionally {{now+N}} or
+// {{now-N}}) and {{uuid}} (a random v4 UUID). Anything else is left for the
+// user to replace in the form.
+func expandPlaceholders(s string, now time.Time) string {
+	return placeholderRe.ReplaceAllStringFunc(s, func(match string) string {
+		sub := placeholderRe.FindStringSubmatch(match)
+		if sub[1] == "uuid" {
+			return newUUID()
+		}
+		t := now.Unix()
+		if sub[3] != "" {
+			n, _ := strconv.ParseInt(sub[3], 10, 64)
+			if sub[2] == "-" {
+				n = -n
+			}
+			t += n
+		}
+		return strconv.FormatInt(t, 10)
+	})
+}
+
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func (m Model) startTemplates() (tea.Model, tea.Cmd) {
+	items := m.Config.Templates
+	if len(items) == 0 {
+		items = defaultTemplates
+	}
+	m.Templates = TemplatesModel{Items: items}
+	m.CurrentState = StateTemplates
+	return m, nil
+}
+
+func handleStateTemplatesKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	t := &m.Templates
+	switch keyMsg.String() {
+	case "esc":
+		m.CurrentState = m.popState()
+	case "up", "k":
+		if t.Cursor > 0 {
+			t.Cursor--
+		}
+	case "down", "j":
+		if t.Cursor < len(t.Items)-1 {
+			t.Cursor++
+		}
+	case "enter":
+		if t.Cursor < len(t.Items) {
+			return m.openTemplateForm(t.Items[t.Cursor])
+		}
+	}
+	return m, nil
+}
+
+// openTemplateForm pre-fills a form with the template's key, fields (or value)
+// and TTL, placeholders expanded, for the user to edit before creating.
+func (m Model) openTemplateForm(t Template) (tea.Model, tea.Cmd) {
+	now := time.Now()
+	labels := []string{"key"}
+	defaults := []string{expandPlaceholders(t.Key, now)}
+	if t.Type == "hash" {
+		for _, f := range t.Fields {
+			labels = append(labels, "field "+f.Name)
+			defaults = append(defaults, expandPlaceholders(f.Value, now))
+		}
+	} else {
+		labels = append(labels, "value")
+		defaults = append(defaults, expandPlaceholders(t.Value, now))
+	}
+	labels = append(labels, "TTL in seconds (0 = no expiry)")
+	defaults = append(defaults, strconv.Itoa(t.TTL))
+
+	m.Templates.Active = t
+	m.SelectedOp = OpTemplate
+	m.pushState(StateTemplates)
+	m.Form = NewForm("TEMPLATE · "+t.Name+" ("+t.Type+")", labels, defaults)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitTemplateForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	t := m.Templates.Active
+	key := values[0]
+	if key == "" {
+		m.Form.Err = "key must not be empty"
+		return m, nil
+	}
+	ttl, err := strconv.Atoi(values[len(values)-1])
+	if err != nil || ttl < 0 {
+		m.Form.Err = "TTL must be a whole number of seconds, 0 for none"
+		return m, nil
+	}
+	// Values are read untrimmed, unlike Values(), so deliberate spaces survive.
+	var cmds []redis.RedisCmd
+	if t.Type == "hash" {
+		args := []string{key}
+		for i, f := range t.Fields {
+			args = append(args, f.Name, m.Form.Inputs[i+1].Value())
+		}
+		cmds = append(cmds, redis.RedisCmd{Name: "HSET", Args: args})
+	} else {
+		cmds = append(cmds, redis.Set(key, m.Form.Inputs[1].Value()))
+	}
+	if ttl > 0 {
+		cmds = append(cmds, redis.Expire(key, ttl))
+	}
+	return m.switchToLoadingAndExecute(createFromTemplate(m.Conn, m.Reader, key, t, cmds))
+}
+
+// createFromTemplate writes the key only if it doesn't exist yet: a template
+// is for new keys, and HSET would otherwise merge into an existing hash.
+func createFromTemplate(conn net.Conn, reader *bufio.Reader, key string, t Template, cmds []redis.RedisCmd) tea.Cmd {
+	return func() tea.Msg {
+		exists, err := createKey(conn, reader, key, cmds)
+		if err != nil || exists {
+			return TemplateResultMsg{Key: key, Exists: exists, Error: err}
+		}
+		out := fmt.Sprintf("Created %s %q from template %q", t.Type, key, t.Name)
+		if t.Type == "hash" {
+			out += fmt.Sprintf(" with %d fields", len(t.Fields))
+		}
+		if len(cmds) > 1 {
+			out += " (TTL " + cmds[1].Args[1] + "s)"
+		}
+		return TemplateResultMsg{Key: key, Output: out}
+	}
+}
+
+// createKey sends cmds unless key already exists, reporting whether it did.
+// An error reply to any of them is returned as the error.
+func createKey(conn net.Conn, reader *bufio.Reader, key string, cmds []redis.RedisCmd) (exists bool, err error) {
+	if conn == nil {
+		return false, fmt.Errorf("no connection to Redis")
+	}
+	replies, err := pipeline(conn, reader, []redis.RedisCmd{redis.Exists(key)})
+	if err != nil {
+		return false, err
+	}
+	if n, _ := replies[0].(int); n > 0 {
+		return true, nil
+	}
+	if replies, err = pipeline(conn, reader, cmds); err != nil {
+		return false, err
+	}
+	for _, r := range replies {
+		if s, ok := r.(string); ok && redis.IsErrorReply(s) {
+			return false, fmt.Errorf("%s", s)
+		}
+	}
+	return false, nil
+}
+
+func handleTemplateResult(m Model, msg TemplateResultMsg) (tea.Model, tea.Cmd) {
+	if msg.Exists {
+		m.Form.Err = fmt.Sprintf("key %q already exists — choose another name", msg.Key)
+		m.CurrentState = StateForm
+		return m, nil
+	}
+	m.ActiveTTL = ""
+	m.CopyStatus = ""
+	if msg.Error != nil {
+		m.Result = errorResult(msg.Error)
+	} else {
+		m.Result = textResult(msg.Output)
+	}
+	m.CurrentState = StateOutput
+	return m, nil
+}
+
+func (m Model) templatesView() string {
+	t := m.Templates
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+
+	source := "built-in"
+	if len(m.Config.Templates) > 0 {
+		source = "from config"
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)).Bold(true).Render("TEMPLATE") + dim.Render(" · new key from a template ("+source+")")
+
+	rows := make([]string, 0, len(t.Items))
+	for i, item := range t.Items {
+		marker := "  "
+		if i == t.Cursor {
+			marker = accent.Render("▌ ")
+		}
+		var shape string
+		if item.Type == "hash" {
+			names := make([]string, len(item.Fields))
+			for j, f := range item.Fields {
+				names[j] = f.Name
+			}
+			shape = strings.Join(names, ", ")
+		} else {
+			shape = truncateText(item.Value, 40)
+		}
+		rows = append(rows, marker+text.Render(padCells(item.Name, 20)+" ")+
+			typeDescStyle(item.Type).Render(fmt.Sprintf("%-7s ", item.Type))+subtle.Render(item.Key)+dim.Render("  "+shape))
+	}
+
+	body := "  " + title + "\n\n" + strings.Join(rows, "\n")
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(templatesKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
@@ -0,0 +1,389 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxTimelineEvents caps the recent-events log; the per-minute counts are
+// kept for the whole session.
+const maxTimelineEvents = 200
+
+// Timeline event classes, in the order the bar chart stacks them.
+const (
+	timelineWrite = iota
+	timelineExpired
+	timelineEvicted
+	timelineDel
+	timelineClasses
+)
+
+var timelineClassNames = [timelineClasses]string{"write", "expired", "evicted", "del"}
+
+// TimelineModel is the state of the key event timeline. It subscribes to
+// keyspace notifications for one pattern on its own connection, which
+// subscription mode takes over, and keeps recording while the user works on
+// other screens.
+type TimelineModel struct {
+	Pattern string
+	Conn    net.Conn
+	Reader  *bufio.Reader
+	Gen     int
+	Active  bool
+	Started time.Time
+
+	Minutes map[int64]*[timelineClasses]int // unix minute → counts per class
+	Total   [timelineClasses]int
+	Events  []TimelineEvent // newest first
+	Notice  string          // e.g. notifications disabled on the server
+	Err     string
+}
+
+// TimelineEvent is one keyspace notification.
+type TimelineEvent struct {
+	At    time.Time
+	Key   string
+	Event string // set, hset, expired, del, …
+}
+
+// TimelineConnMsg delivers the subscribed connection, with the server's
+// notify-keyspace-events setting when CONFIG GET is allowed.
+type TimelineConnMsg struct {
+	Gen        int
+	Conn       net.Conn
+	Reader     *bufio.Reader
+	Flags      string
+	FlagsKnown bool
+	Error      error
+}
+
+// TimelineEventMsg is one notification read from the subscription.
+type TimelineEventMsg struct {
+	Gen   int
+	Event TimelineEvent
+	Error error
+}
+
+// TimelineEnableMsg reports the outcome of turning notifications on.
+type TimelineEnableMsg struct {
+	Flags string
+	Error error
+}
+
+// timelineClass maps a notification event to its chart class. Anything
+// that isn't a removal counts as a write.
+func timelineClass(event string) int {
+	switch event {
+	case "expired":
+		return timelineExpired
+	case "evicted":
+		return timelineEvicted
+	case "del", "unlink":
+		return timelineDel
+	}
+	return timelineWrite
+}
+
+// notificationsCover reports whether notify-keyspace-events flags publish
+// keyspace ("K") events for every class the timeline charts.
+func notificationsCover(flags string) bool {
+	if !strings.Contains(flags, "K") {
+		return false
+	}
+	if strings.Contains(flags, "A") {
+		return true
+	}
+	// g: DEL, x: expired, e: evicted, $: strings; type-specific writes are
+	// optional but the removals are what the chart is for.
+	return strings.Contains(flags, "g") && strings.Contains(flags, "x") && strings.Contains(flags, "e")
+}
+
+// startTimeline opens the timeline, or its form with pattern filled in.
+func (m Model) startTimeline(pattern string) (tea.Model, tea.Cmd) {
+	if m.Timeline.Active {
+		m.CurrentState = StateTimeline
+		return m, nil
+	}
+	m.Form = NewForm(
+		"TIMELINE · chart keyspace events for a pattern over this session",
+		[]string{"key pattern"},
+		[]string{pattern},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitTimelineForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	if values[0] == "" {
+		m.Form.Err = "enter a key pattern (* for every key)"
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.stopTimeline()
+	m.Timeline = TimelineModel{
+		Pattern: values[0],
+		Gen:     m.Timeline.Gen + 1,
+		Started: time.Now(),
+		Minutes: map[int64]*[timelineClasses]int{},
+	}
+	m.pushState(StateForm)
+	return m.switchToLoadingAndDial(openTimelineConnection(m, m.Timeline.Gen))
+}
+
+// openTimelineConnection opens the dedicated connection, reads the
+// notification setting and subscribes to the pattern's keyspace channel.
+func openTimelineConnection(m Model, gen int) tea.Cmd {
+	channel := fmt.Sprintf("__keyspace@%d__:%s", m.DB, m.Timeline.Pattern)
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m)
+		if err != nil {
+			return TimelineConnMsg{Gen: gen, Error: err}
+		}
+		msg := TimelineConnMsg{Gen: gen, Conn: conn, Reader: reader}
+		// Managed services often refuse CONFIG; then we just can't tell.
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{{Name: "CONFIG", Args: []string{"GET", "notify-keyspace-events"}}})
+		if err != nil {
+			_ = conn.Close()
+			return TimelineConnMsg{Gen: gen, Error: err}
+		}
+		if pair, ok := replies[0].([]any); ok && len(pair) == 2 {
+			msg.Flags, msg.FlagsKnown = fmt.Sprint(pair[1]), true
+		}
+		replies, err = pipeline(conn, reader, []redis.RedisCmd{{Name: "PSUBSCRIBE", Args: []string{channel}}})
+		if err == nil {
+			if s, ok := replies[0].(string); ok {
+				err = fmt.Errorf("PSUBSCRIBE: %s", s)
+			}
+		}
+		if err != nil {
+			_ = conn.Close()
+			return TimelineConnMsg{Gen: gen, Error: err}
+		}
+		return msg
+	}
+}
+
+func handleTimelineConn(m Model, msg TimelineConnMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.Timeline.Gen {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close()
+		}
+		return m, nil
+	}
+	if msg.Error != nil {
+		m.Result = Result{Type: "error", Err: "Could not subscribe to keyspace notifications: " + msg.Error.Error()}
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	t := &m.Timeline
+	t.Conn, t.Reader, t.Active = msg.Conn, msg.Reader, true
+	if msg.FlagsKnown && !notificationsCover(msg.Flags) {
+		t.Notice = fmt.Sprintf("keyspace notifications are off or partial (notify-keyspace-events %q) — press e to enable them", msg.Flags)
+	}
+	if m.CurrentState == StateLoading {
+		m.CurrentState = StateTimeline
+	}
+	return m, readTimelineEvent(t.Reader, t.Gen)
+}
+
+// readTimelineEvent waits for the next pmessage. There is no read deadline:
+// a quiet pattern may not see an event for hours.
+func readTimelineEvent(reader *bufio.Reader, gen int) tea.Cmd {
+	return func() tea.Msg {
+		for {
+			v, err := redis.ReadResp(reader)
+			if err != nil {
+				return TimelineEventMsg{Gen: gen, Error: err}
+			}
+			parts, ok := v.([]any)
+			if !ok || len(parts) != 4 || parts[0] != "pmessage" {
+				continue
+			}
+			channel, _ := parts[2].(string)
+			event, _ := parts[3].(string)
+			_, key, _ := strings.Cut(channel, "__:")
+			return TimelineEventMsg{Gen: gen, Event: TimelineEvent{At: time.Now(), Key: key, Event: event}}
+		}
+	}
+}
+
+func handleTimelineEvent(m Model, msg TimelineEventMsg) (tea.Model, tea.Cmd) {
+	t := &m.Timeline
+	if msg.Gen != t.Gen || !t.Active {
+		return m, nil
+	}
+	if msg.Error != nil {
+		t.Err = msg.Error.Error()
+		m.stopTimeline()
+		return m, nil
+	}
+	e := msg.Event
+	class := timelineClass(e.Event)
+	minute := e.At.Unix() / 60
+	counts := t.Minutes[minute]
+	if counts == nil {
+		counts = new([timelineClasses]int)
+		t.Minutes[minute] = counts
+	}
+	counts[class]++
+	t.Total[class]++
+	t.Events = append([]TimelineEvent{e}, t.Events...)
+	if len(t.Events) > maxTimelineEvents {
+		t.Events = t.Events[:maxTimelineEvents]
+	}
+	return m, readTimelineEvent(t.Reader, t.Gen)
+}
+
+// enableNotifications adds keyspace events for every class to the server's
+// existing flags, over the session connection (the timeline's is subscribed).
+func enableNotifications(conn net.Conn, reader *bufio.Reader) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return TimelineEnableMsg{Error: fmt.Errorf("no connection to Redis")}
+		}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{{Name: "CONFIG", Args: []string{"GET", "notify-keyspace-events"}}})
+		if err != nil {
+			return TimelineEnableMsg{Error: err}
+		}
+		flags := "KA"
+		if pair, ok := replies[0].([]any); ok && len(pair) == 2 {
+			flags = fmt.Sprint(pair[1]) + "KA"
+		}
+		replies, err = pipeline(conn, reader, []redis.RedisCmd{{Name: "CONFIG", Args: []string{"SET", "notify-keyspace-events", flags}}})
+		if err != nil {
+			return TimelineEnableMsg{Error: err}
+		}
+		if s, _ := replies[0].(string); s != "OK" {
+			return TimelineEnableMsg{Error: fmt.Errorf("%v", replies[0])}
+		}
+		return TimelineEnableMsg{Flags: flags}
+	}
+}
+
+func handleTimelineEnable(m Model, msg TimelineEnableMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.Timeline.Notice = "could not enable notifications: " + msg.Error.Error()
+	} else {
+		m.Timeline.Notice = "keyspace notifications enabled (notify-keyspace-events " + msg.Flags + ")"
+	}
+	return m, nil
+}
+
+// stopTimeline closes the subscription, which also ends the pending read.
+func (m *Model) stopTimeline() {
+	t := &m.Timeline
+	if t.Conn != nil {
+		_ = t.Conn.Close()
+	}
+	t.Conn, t.Reader = nil, nil
+	t.Active = false
+	t.Gen++
+}
+
+func handleStateTimelineKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch keyMsg.String() {
+	case "esc":
+		// Leaving the screen keeps recording, like the snapshotter.
+		m.CurrentState = m.popState()
+	case "x":
+		m.stopTimeline()
+		m.CurrentState = m.popState()
+	case "e":
+		if m.ReadOnly {
+			m.Timeline.Notice = "read-only session: CONFIG SET is disabled"
+			return m, nil
+		}
+		if m.Production {
+			m.Timeline.Notice = "production profile: CONFIG SET is disabled; set notify-keyspace-events on the server"
+			return m, nil
+		}
+		if !m.Server.Supports(redis.CapKeyspaceEvents) {
+			m.Timeline.Notice = m.Server.String() + " only publishes expired events, set at startup (--notify_keyspace_events=Ex)"
+			return m, nil
+		}
+		return m, m.exec(enableNotifications(m.Conn, m.Reader))
+	}
+	return m, nil
+}
+
+func (m Model) timelineView() string {
+	t := m.Timeline
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	classStyles := [timelineClasses]lipgloss.Style{
+		lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)),
+		lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow)),
+		lipgloss.NewStyle().Foreground(lipgloss.Color(tnOrange)),
+		lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)),
+	}
+
+	state := "recording"
+	if !t.Active {
+		state = "stopped"
+	}
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Bold(true).Render("TIMELINE") +
+		dim.Render(fmt.Sprintf(" · %s · db%d · %s since %s", t.Pattern, m.DB, state, t.Started.Format("15:04:05")))
+
+	var legend []string
+	for c := range timelineClasses {
+		legend = append(legend, classStyles[c].Render("█")+" "+subtle.Render(fmt.Sprintf("%s %d", timelineClassNames[c], t.Total[c])))
+	}
+
+	// One row per minute, newest at the bottom, as many as fit.
+	rowsAvail := max(m.WindowHeight-16, 3)
+	now := time.Now().Unix() / 60
+	first := max(t.Started.Unix()/60, now-int64(rowsAvail)+1)
+	peak := 1
+	for mnt := first; mnt <= now; mnt++ {
+		if c := t.Minutes[mnt]; c != nil {
+			peak = max(peak, c[0]+c[1]+c[2]+c[3])
+		}
+	}
+	barW := max(m.WindowWidth-30, 10)
+	var chart []string
+	for mnt := first; mnt <= now; mnt++ {
+		var counts [timelineClasses]int
+		if c := t.Minutes[mnt]; c != nil {
+			counts = *c
+		}
+		total := counts[0] + counts[1] + counts[2] + counts[3]
+		bar := ""
+		for c := range timelineClasses {
+			// Round up so a single event still shows.
+			if n := (counts[c]*barW + peak - 1) / peak; counts[c] > 0 {
+				bar += classStyles[c].Render(strings.Repeat("█", n))
+			}
+		}
+		chart = append(chart, dim.Render(time.Unix(mnt*60, 0).Format("15:04"))+"  "+bar+" "+subtle.Render(fmt.Sprint(total)))
+	}
+
+	var recent []string
+	for _, e := range t.Events[:min(len(t.Events), 5)] {
+		recent = append(recent, dim.Render(e.At.Format("15:04:05"))+"  "+classStyles[timelineClass(e.Event)].Render(fmt.Sprintf("%-8s", e.Event))+" "+text.Render(e.Key))
+	}
+	if len(recent) == 0 {
+		recent = append(recent, dim.Render("no events yet"))
+	}
+
+	body := "  " + title + "\n  " + strings.Join(legend, "   ") + "\n\n" + indentLines(strings.Join(chart, "\n"), 2) +
+		"\n\n" + indentLines(strings.Join(recent, "\n"), 2)
+	if t.Notice != "" {
+		body += "\n\n  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnYellow)).Render(t.Notice)
+	}
+	if t.Err != "" {
+		body += "\n\n  " + lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render("subscription ended: "+t.Err)
+	}
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(timelineKeys)
+	return bottomFooter(m.headerView()+"\n\n"+body, foot, m.WindowHeight)
+}
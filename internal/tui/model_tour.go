@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TourModel is the guided tour: a few pages on connecting, exploring,
+// editing and deleting, shown on first run and from TOUR in the menu.
+type TourModel struct {
+	Step int
+}
+
+// tourStep is one page of the tour. try is a sample to type at the prompt
+// the page talks about.
+type tourStep struct {
+	title string
+	lines []string
+	try   string
+}
+
+var tourSteps = []tourStep{
+	{
+		title: "Connecting",
+		lines: []string{
+			"The header shows the server, the database and the connection state.",
+			"Start with -url redis://host:port/db, -host and -password, or -profile",
+			"with a named connection from the config file. If the connection drops,",
+			"redis-tui reconnects on its own and shows a countdown meanwhile.",
+			"No server at hand? -demo starts one in memory with sample data.",
+		},
+		try: "redis-tui -demo",
+	},
+	{
+		title: "Exploring",
+		lines: []string{
+			"Pick EXPLORE from the menu and give a key pattern — * for everything.",
+			"↑↓ move through the keys, / filters the list as you type, n loads",
+			"the next page, and Enter opens a key. Hashes, lists, sets and sorted",
+			"sets open as a list of fields or members; Enter opens one of those.",
+		},
+		try: "user:*",
+	},
+	{
+		title: "Editing",
+		lines: []string{
+			"An open value shows its TTL alongside. Press e to edit it in place —",
+			"the TTL is kept — or x to set or clear the expiry (0 persists the key).",
+			"In a field list, a adds a field or member. c copies what you see.",
+		},
+		try: "x, then 3600",
+	},
+	{
+		title: "Deleting",
+		lines: []string{
+			"Press d on a key, or on a field or member, and confirm with y.",
+			"n or Esc cancels. The key list reloads without the deleted key.",
+		},
+		try: "d, then y",
+	},
+	{
+		title: "Getting around",
+		lines: []string{
+			"Esc always goes back one screen. On the menu, typing filters the",
+			"commands. CONSOLE sends any command; Ctrl+T opens another tab.",
+			"TOUR in the menu brings this tour back.",
+		},
+	},
+}
+
+// DefaultTourPath is where the first-run tour records that it was seen,
+// next to the config file.
+func DefaultTourPath() string {
+	cfg := DefaultConfigPath()
+	if cfg == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(cfg), "tour-seen")
+}
+
+// TourSeen reports whether the tour was finished or dismissed before. With
+// no path there is nowhere to remember it, so it counts as seen.
+func TourSeen(path string) bool {
+	if path == "" {
+		return true
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// WithTour returns m showing the tour over the menu, for the first run.
+func (m Model) WithTour() Model {
+	m.pushState(StateMenu)
+	m.Tour = TourModel{}
+	m.CurrentState = StateTour
+	return m
+}
+
+func (m Model) startTour() (tea.Model, tea.Cmd) {
+	m.Tour = TourModel{}
+	m.CurrentState = StateTour
+	return m, nil
+}
+
+// markTourSeen creates the marker file. Failing to is harmless: the tour
+// shows again next time.
+func markTourSeen(path string) tea.Cmd {
+	if path == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err == nil {
+			_ = os.WriteFile(path, nil, 0600)
+		}
+		return nil
+	}
+}
+
+func handleStateTourKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	t := &m.Tour
+	switch keyMsg.String() {
+	case "esc", "q":
+		m.CurrentState = m.popState()
+		return m, markTourSeen(m.TourPath)
+	case "enter", "right", "l", " ":
+		if t.Step == len(tourSteps)-1 {
+			m.CurrentState = m.popState()
+			return m, markTourSeen(m.TourPath)
+		}
+		t.Step++
+	case "left", "h":
+		if t.Step > 0 {
+			t.Step--
+		}
+	}
+	return m, nil
+}
+
+func (m Model) tourView() string {
+	t := m.Tour
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	subtle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+
+	step := tourSteps[min(t.Step, len(tourSteps)-1)]
+	title := accent.Bold(true).Render("TOUR") + dim.Render(fmt.Sprintf(" · %d of %d · ", t.Step+1, len(tourSteps))) + text.Bold(true).Render(step.title)
+
+	var dots []string
+	for i := range tourSteps {
+		if i == t.Step {
+			dots = append(dots, accent.Render("●"))
+		} else {
+			dots = append(dots, dim.Render("○"))
+		}
+	}
+
+	lines := []string{"  " + title, ""}
+	for _, l := range step.lines {
+		lines = append(lines, "    "+text.Render(l))
+	}
+	if step.try != "" {
+		lines = append(lines, "", "    "+subtle.Render("try  ")+accent.Render(step.try))
+	}
+	lines = append(lines, "", "    "+strings.Join(dots, " "))
+
+	h := m.Help
+	h.Width = m.WindowWidth
+	foot := footerSep(m.WindowWidth) + "\n  " + h.View(tourKeys)
+	return bottomFooter(m.headerView()+"\n\n"+strings.Join(lines, "\n"), foot, m.WindowHeight)
+}
@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TTL_FORECAST reads the TTLs of keys matching a pattern, up to a sample
+// size, and buckets them by when they expire. Keys written together with the
+// same TTL also expire together, and whatever they cached is then rebuilt
+// all at once; the busiest minute ahead is called out for that reason.
+
+const (
+	defaultForecastSample = 1000
+	maxForecastSample     = 100000
+	forecastBatch         = 1000 // PTTLs per pipelined round trip
+	forecastBarWidth      = 40
+)
+
+// forecastBuckets name the histogram's rows, soonest first; the last is for
+// keys without a TTL.
+var forecastBuckets = []string{"within a minute", "within an hour", "within a day", "later", "never"}
+
+// TTLForecast is a pattern's keys bucketed by when they expire.
+type TTLForecast struct {
+	Pattern  string
+	Sampled  int
+	Complete bool // every matching key was read, not just a sample
+	Buckets  []int
+	Gone     int // expired between SCAN and PTTL
+	Peak     int // keys expiring in the busiest minute ahead
+	PeakAt   time.Time
+	Taken    time.Time
+}
+
+// TTLForecastMsg reports a forecast read.
+type TTLForecastMsg struct {
+	Forecast TTLForecast
+	Error    error
+}
+
+func (m Model) startTTLForecastForm() (tea.Model, tea.Cmd) {
+	m.Form = NewForm(
+		"TTL_FORECAST · when keys matching a pattern expire",
+		[]string{"key pattern", "keys to sample"},
+		[]string{m.LastPattern, strconv.Itoa(defaultForecastSample)},
+	)
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+func submitTTLForecastForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	pattern := values[0]
+	if pattern == "" {
+		m.Form.Err = "enter a key pattern"
+		return m, nil
+	}
+	n, err := strconv.Atoi(values[1])
+	if err != nil || n <= 0 || n > maxForecastSample {
+		m.Form.Err = fmt.Sprintf("sample size must be between 1 and %d keys", maxForecastSample)
+		return m, nil
+	}
+	m.Form.Err = ""
+	return m.switchToLoadingAndExecute(readTTLForecast(m.Conn, m.Reader, pattern, n, m.scanCountMax()))
+}
+
+// readTTLForecast scans up to limit keys matching pattern and reads their
+// PTTLs in pipelined batches.
+func readTTLForecast(conn net.Conn, reader *bufio.Reader, pattern string, limit, scanCount int) tea.Cmd {
+	return func() tea.Msg {
+		if conn == nil {
+			return TTLForecastMsg{Error: fmt.Errorf("no connection to Redis")}
+		}
+		keys, err := scanAllKeys(conn, reader, pattern, limit, scanCount)
+		if err != nil {
+			return TTLForecastMsg{Error: err}
+		}
+		if len(keys) == 0 {
+			return TTLForecastMsg{Error: fmt.Errorf("no keys match %s", pattern)}
+		}
+		f := TTLForecast{
+			Pattern:  pattern,
+			Complete: len(keys) < limit,
+			Buckets:  make([]int, len(forecastBuckets)),
+			Taken:    time.Now(),
+		}
+		minutes := map[int64]int{}
+		for start := 0; start < len(keys); start += forecastBatch {
+			batch := keys[start:min(start+forecastBatch, len(keys))]
+			cmds := make([]redis.RedisCmd, len(batch))
+			for i, k := range batch {
+				cmds[i] = redis.PTTL(k)
+			}
+			replies, err := pipeline(conn, reader, cmds)
+			if err != nil {
+				return TTLForecastMsg{Error: err}
+			}
+			for _, r := range replies {
+				ms, ok := r.(int)
+				switch {
+				case !ok:
+					return TTLForecastMsg{Error: fmt.Errorf("PTTL: %v", r)}
+				case ms == -2:
+					f.Gone++
+					continue
+				case ms == -1:
+					f.Buckets[4]++
+				case ms <= int(time.Minute/time.Millisecond):
+					f.Buckets[0]++
+				case ms <= int(time.Hour/time.Millisecond):
+					f.Buckets[1]++
+				case ms <= int(24*time.Hour/time.Millisecond):
+					f.Buckets[2]++
+				default:
+					f.Buckets[3]++
+				}
+				f.Sampled++
+				if ms >= 0 {
+					minutes[f.Taken.Add(time.Duration(ms)*time.Millisecond).Unix()/60]++
+				}
+			}
+		}
+		for mnt, n := range minutes {
+			at := time.Unix(mnt*60, 0)
+			if n > f.Peak || n == f.Peak && at.Before(f.PeakAt) {
+				f.Peak, f.PeakAt = n, at
+			}
+		}
+		return TTLForecastMsg{Forecast: f}
+	}
+}
+
+// handleTTLForecast shows the forecast, or sends the form back with what
+// went wrong. esc from the output returns to the form for another pattern.
+func handleTTLForecast(m Model, msg TTLForecastMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.Form.Err = msg.Error.Error()
+		m.CurrentState = StateForm
+		return m, nil
+	}
+	m.pushState(StateForm)
+	m.Result = textResult(renderTTLForecast(msg.Forecast))
+	m.Result.Command = "ttl forecast " + msg.Forecast.Pattern
+	m.CurrentState = StateOutput
+	return m, nil
+}
+
+// renderTTLForecast draws the histogram as text for the output screen.
+func renderTTLForecast(f TTLForecast) string {
+	var b strings.Builder
+	what := fmt.Sprintf("all %d keys", f.Sampled)
+	if !f.Complete {
+		what = fmt.Sprintf("a sample of %d keys", f.Sampled)
+	}
+	fmt.Fprintf(&b, "Expiry of %s matching %s, as of %s\n\n", what, f.Pattern, f.Taken.Format("15:04:05"))
+
+	peak := 1
+	for _, n := range f.Buckets {
+		peak = max(peak, n)
+	}
+	for i, name := range forecastBuckets {
+		n := f.Buckets[i]
+		// Round up so a single key still shows.
+		bar := strings.Repeat("█", (n*forecastBarWidth+peak-1)/peak)
+		pct := 0.0
+		if f.Sampled > 0 {
+			pct = 100 * float64(n) / float64(f.Sampled)
+		}
+		fmt.Fprintf(&b, "  %-15s  %-*s %7d  %5.1f%%\n", name, forecastBarWidth, bar, n, pct)
+	}
+
+	if f.Peak > 1 {
+		fmt.Fprintf(&b, "\nBusiest minute ahead: %d keys expire %s–%s", f.Peak,
+			f.PeakAt.Format("15:04"), f.PeakAt.Add(time.Minute).Format("15:04"))
+		if f.PeakAt.YearDay() != f.Taken.YearDay() || f.PeakAt.Year() != f.Taken.Year() {
+			fmt.Fprintf(&b, " on %s", f.PeakAt.Format("2006-01-02"))
+		}
+		b.WriteString("\n")
+	}
+	if f.Gone > 0 {
+		fmt.Fprintf(&b, "\n%d more keys expired while they were read.\n", f.Gone)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -28,66 +28,107 @@ func handleRedisResult(m Model, msg RedisResultMsg) (tea.Model, tea.Cmd) {
 			return m, connectToRedis(m)
 		}
 
-		m.Output = msg.Error.Error()
+		m.Result = errorResult(msg.Error)
 		m.ActiveTTL = ""
 		m.CopyStatus = ""
 		m.CurrentState = StateOutput
 		return m, nil
 	}
 
+	switch m.SelectedOp {
+	case OpSet, OpHSet, OpLSet, OpExpireAfterSet, OpDelete, OpRPush, OpLPush, OpSAdd, OpZAdd, OpImportField:
+		m.invalidate(m.ActiveKey)
+	case OpImport, OpImportDB:
+		m.Browser.Cache, m.Browser.Meta = nil, nil
+		m.WroteAt = time.Now()
+	}
+
+	reply := replyResult(msg)
 	switch m.SelectedOp {
 	case OpGet, OpHGet, OpInfo:
 		if result, ok := msg.Result.(string); ok {
 			if m.SelectedOp != OpInfo {
-				m.Output = tryPrettyJSON(result)
+				m.Result = m.withRenderer(reply.withValue(tryPrettyJSON(result)), result)
 				if m.SelectedOp == OpGet {
 					m.Browser.ActiveKeyType = "string"
 				}
 			} else {
-				m.Output = result
+				m.Result = reply.withValue(result)
 			}
 			m.CurrentState = StateOutput
 			if m.SelectedOp != OpInfo {
 				m.ActiveTTL = "fetching..."
-				return m, fetchTTL(m.Conn, m.Reader, m.ActiveKey, m.ReadTimeout)
+				conn, reader := m.readConn()
+				return m, m.exec(fetchTTL(conn, reader, m.ActiveKey, m.ReadTimeout))
 			}
 		}
 
+	case OpQueuePush:
+		return handleQueuePushResult(m)
+
+	case OpBGSave, OpBGRewriteAOF:
+		return handleBackgroundJobResult(m, msg.Result)
+
+	case OpQueuePop:
+		// Leave the dashboard entry on the stack: esc from the output goes
+		// straight back to it.
+		if result, ok := msg.Result.(string); ok && result != "(nil)" {
+			m.Result = reply.withValue(tryPrettyJSON(result))
+		} else {
+			m.Result = reply.withValue(m.ActiveKey + " is empty — nothing to pop")
+		}
+		m.ActiveTTL = ""
+		m.CopyStatus = ""
+		m.CurrentState = StateOutput
+
 	case OpAddItem:
 		// Item added; re-check the key type, which reloads the right collection
 		// browser with the new field/member in place.
+		m.invalidate(m.ActiveKey)
 		m.SelectedOp = OpCheckType
 		return m.switchToLoadingAndExecute(sendRedisCmd(
 			m.Conn, m.Reader,
-			redis.RedisCmd{Name: "TYPE", Args: []string{m.ActiveKey}},
+			redis.Type(m.ActiveKey),
 			m.ReadTimeout,
 		))
 
 	case OpHKeys:
-		if result, ok := msg.Result.([]any); ok {
-			var items []list.Item
-			for _, key := range result {
-				if key, ok := key.(string); ok {
-					items = append(items, ListItem{title: key, desc: "field"})
-				}
+		// hashPage sends HSCAN NOVALUES, a page of field names, or HKEYS,
+		// all of them at once; a field's value is read when it is opened.
+		newCursor, fields, err := scanPage(msg.Result)
+		if err != nil {
+			fields, err = redis.AsStringSlice(msg.Result)
+			newCursor = "0"
+		}
+		if err == nil {
+			items := fieldItems(fields, "field", 0)
+			var cmd tea.Cmd
+			if m.Browser.FieldCursor == "" || m.Browser.FieldCursor == "0" {
+				// A fresh view of the key: drop any filter left over from a
+				// previous visit before loading items. SetItems' returned Cmd
+				// (which recomputes filtered results) only matters while a
+				// filter is still active, and ResetFilter makes sure one never
+				// lingers here to silently hide every field behind a stale
+				// query.
+				m.Browser.FieldsList.ResetFilter()
+				m.Browser.FieldsList.SetItems(items)
+				m.remember(m.ActiveKey, msg.Command, msg)
+			} else {
+				cmd = m.Browser.FieldsList.SetItems(append(m.Browser.FieldsList.Items(), items...))
 			}
-			// A fresh view of the key: drop any filter left over from a
-			// previous visit before loading items. SetItems' returned Cmd
-			// (which recomputes filtered results) only matters while a filter
-			// is still active, and ResetFilter makes sure one never lingers
-			// here to silently hide every field behind a stale query.
-			m.Browser.FieldsList.ResetFilter()
-			m.Browser.FieldsList.SetItems(items)
+			m.Browser.FieldCursor = newCursor
+			m.Browser.HasMoreFields = newCursor != "0"
 			m.Browser.ActiveKeyType = "hash"
 			m.Browser.ViewingFields = true
 			m.CurrentState = StateBrowser
+			return m, cmd
 		} else {
 			// ReadResp returns Redis error strings (e.g. WRONGTYPE) as plain string,
 			// not as Go errors. Without this branch the model is stuck in StateLoading.
 			if s, ok := msg.Result.(string); ok && s != "" {
-				m.Output = s
+				m.Result = reply.withValue(s)
 			} else {
-				m.Output = "Unexpected response"
+				m.Result = reply.withValue("Unexpected response")
 			}
 			m.CurrentState = StateOutput
 		}
@@ -107,6 +148,10 @@ func handleRedisResult(m Model, msg RedisResultMsg) (tea.Model, tea.Cmd) {
 					items = append([]list.Item{action}, items...)
 				}
 				cmd = m.Browser.KeyList.SetItems(items)
+				if m.Saved.Filter != "" {
+					m.Browser.KeyList.SetFilterText(m.Saved.Filter)
+					m.Saved.Filter = ""
+				}
 			} else {
 				// Paginating ("load more"): a filter may legitimately still be
 				// active, so the returned Cmd (which recomputes filtered
@@ -119,21 +164,18 @@ func handleRedisResult(m Model, msg RedisResultMsg) (tea.Model, tea.Cmd) {
 			}
 			m.Browser.Cursor = result.Cursor
 			m.Browser.HasMore = result.Cursor != "0"
+			if result.Count > 0 {
+				m.Browser.ScanHint = nextScanCount(result.Count, m.scanCountMax(), result.Took)
+			}
 			m.Browser.ViewingFields = false
 			m.CurrentState = StateBrowser
-			return m, cmd
+			return m, tea.Batch(cmd, m.watchHighlight())
 		}
 
 	case OpLRange:
-		if resp, ok := msg.Result.([]any); ok {
+		if resp, err := redis.AsStringSlice(msg.Result); err == nil {
 			baseIndex := m.Browser.FieldOffset
-			var newItems []list.Item
-			for i, v := range resp {
-				if s, ok := v.(string); ok {
-					idx := baseIndex + i
-					newItems = append(newItems, ListItem{index: idx, title: s, desc: "idx " + strconv.Itoa(idx)})
-				}
-			}
+			newItems := m.listItems(resp)
 			var cmd tea.Cmd
 			if baseIndex == 0 {
 				m.Browser.FieldsList.ResetFilter()
@@ -155,33 +197,26 @@ func handleRedisResult(m Model, msg RedisResultMsg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		} else {
 			if s, ok := msg.Result.(string); ok && s != "" {
-				m.Output = s
+				m.Result = reply.withValue(s)
 			} else {
-				m.Output = "Unexpected response"
+				m.Result = reply.withValue("Unexpected response")
 			}
 			m.CurrentState = StateOutput
 		}
 
 	case OpSMembers:
-		// SSCAN response: []any{cursor_string, []any{member1, member2, ...}}
-		if resp, ok := msg.Result.([]any); ok && len(resp) == 2 {
-			newCursor, _ := resp[0].(string)
-			members, _ := resp[1].([]any)
+		if newCursor, members, err := scanPage(msg.Result); err == nil {
 			isFirstPage := m.Browser.FieldCursor == "" || m.Browser.FieldCursor == "0"
 			baseIndex := 0
 			if !isFirstPage {
 				baseIndex = len(m.Browser.FieldsList.Items())
 			}
-			var newItems []list.Item
-			for i, v := range members {
-				if s, ok := v.(string); ok {
-					newItems = append(newItems, ListItem{index: baseIndex + i, title: s, desc: "idx " + strconv.Itoa(baseIndex+i)})
-				}
-			}
+			newItems := fieldItems(members, "", baseIndex)
 			var cmd tea.Cmd
 			if isFirstPage {
 				m.Browser.FieldsList.ResetFilter()
 				cmd = m.Browser.FieldsList.SetItems(newItems)
+				m.remember(m.ActiveKey, msg.Command, msg)
 			} else {
 				existing := m.Browser.FieldsList.Items()
 				cmd = m.Browser.FieldsList.SetItems(append(existing, newItems...))
@@ -197,36 +232,32 @@ func handleRedisResult(m Model, msg RedisResultMsg) (tea.Model, tea.Cmd) {
 			// Covers: Redis error strings (WRONGTYPE), null arrays returned as
 			// "(nil)" string, and malformed SSCAN responses with len != 2.
 			if s, ok := msg.Result.(string); ok && s != "" {
-				m.Output = s
+				m.Result = reply.withValue(s)
 			} else {
-				m.Output = "Unexpected response"
+				m.Result = reply.withValue("Unexpected response")
 			}
 			m.CurrentState = StateOutput
 		}
 
 	case OpZRange:
-		if resp, ok := msg.Result.([]any); ok {
+		if resp, err := redis.AsStringSlice(msg.Result); err == nil {
 			baseOffset := m.Browser.FieldOffset
 			var newItems []list.Item
 			for i := 0; i+1 < len(resp); i += 2 {
-				member, ok1 := resp[i].(string)
-				score := "unknown"
-				if s, ok2 := resp[i+1].(string); ok2 {
-					score = s
-				}
-				if ok1 {
-					newItems = append(newItems, ListItem{title: member, desc: "score:" + score})
+				rank := baseOffset + i/2
+				item := ListItem{index: rank, title: resp[i], desc: "rank " + strconv.Itoa(rank), score: resp[i+1]}
+				if m.Dates {
+					item.when = dateNote(item.score)
 				}
+				newItems = append(newItems, item)
 			}
 			memberCount := len(resp) / 2
-			var cmd tea.Cmd
 			if baseOffset == 0 {
 				m.Browser.FieldsList.ResetFilter()
-				cmd = m.Browser.FieldsList.SetItems(newItems)
 			} else {
-				existing := m.Browser.FieldsList.Items()
-				cmd = m.Browser.FieldsList.SetItems(append(existing, newItems...))
+				newItems = append(m.Browser.FieldsList.Items(), newItems...)
 			}
+			cmd := m.Browser.FieldsList.SetItems(sortZSetItems(newItems, m.Browser.ZSetByMember))
 			if memberCount >= fieldPageSize {
 				m.Browser.HasMoreFields = true
 				m.Browser.FieldOffset += memberCount
@@ -240,9 +271,9 @@ func handleRedisResult(m Model, msg RedisResultMsg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		} else {
 			if s, ok := msg.Result.(string); ok && s != "" {
-				m.Output = s
+				m.Result = reply.withValue(s)
 			} else {
-				m.Output = "Unexpected response"
+				m.Result = reply.withValue("Unexpected response")
 			}
 			m.CurrentState = StateOutput
 		}
@@ -253,37 +284,49 @@ func handleRedisResult(m Model, msg RedisResultMsg) (tea.Model, tea.Cmd) {
 		m.Browser.FieldCursor = ""
 		m.Browser.HasMoreFields = false
 
-		if str, ok := msg.Result.(string); ok {
+		if str, err := redis.AsString(msg.Result); err == nil {
+			if str != "string" {
+				m.EditOnOpen = false // only strings open into an editor
+			}
 			switch str {
 			case "string":
-				m.SelectedOp = OpGet
-				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: "GET", Args: []string{m.ActiveKey}}, m.ReadTimeout))
+				return m.openString()
 			case "hash":
-				m.SelectedOp = OpHKeys
-				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: "HKEYS", Args: []string{m.ActiveKey}}, m.ReadTimeout))
+				if _, ok := m.Config.sessionRule(m.ActiveKey); ok {
+					return m.openSession()
+				}
+				if m.Config.displayRule(m.ActiveKey).HashView == "table" {
+					return m.openHashTable()
+				}
+				return m.openHash()
 			case "list":
-				m.SelectedOp = OpLRange
-				end := strconv.Itoa(fieldPageSize - 1)
-				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: "LRANGE", Args: []string{m.ActiveKey, "0", end}}, m.ReadTimeout))
+				return m.openList()
 			case "set":
 				m.SelectedOp = OpSMembers
-				count := strconv.Itoa(fieldPageSize)
-				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: "SSCAN", Args: []string{m.ActiveKey, "0", "COUNT", count}}, m.ReadTimeout))
+				sscan := redis.SScan(m.ActiveKey, "0", fieldPageSize)
+				if cached := m.fromCache(sscan); cached != nil {
+					return m.switchToLoadingAndExecute(cached)
+				}
+				return m.switchToLoadingAndExecute(m.sendRead(sscan))
 			case "zset":
+				if m.Config.displayRule(m.ActiveKey).ZSetView == "leaderboard" {
+					return m.openLeaderboard(m.ActiveKey, defaultLeaderTop, defaultLeaderEvery)
+				}
 				m.SelectedOp = OpZRange
-				end := strconv.Itoa(fieldPageSize - 1)
-				return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: "ZRANGE", Args: []string{m.ActiveKey, "0", end, "WITHSCORES"}}, m.ReadTimeout))
+				return m.switchToLoadingAndExecute(m.sendRead(redis.ZRange(m.ActiveKey, 0, fieldPageSize-1, true)))
+			case "stream":
+				return m.startTail(m.ActiveKey)
 			case "none":
-				m.Output = "Key does not exist or has expired."
+				m.Result = reply.withValue("Key does not exist or has expired.")
 				m.CurrentState = StateOutput
 				return m, nil
 			default:
-				m.Output = "Unknown key type: " + str
+				m.Result = reply.withValue("Unknown key type: " + str)
 				m.CurrentState = StateOutput
 				return m, nil
 			}
 		} else {
-			m.Output = "Unexpected response"
+			m.Result = reply.withValue("Unexpected response")
 			m.CurrentState = StateOutput
 		}
 
@@ -291,37 +334,49 @@ func handleRedisResult(m Model, msg RedisResultMsg) (tea.Model, tea.Cmd) {
 		m.SelectedOp = OpSet
 		m.CurrentState = StateOutput
 		m.ActiveTTL = "fetching..."
-		return m, fetchTTL(m.Conn, m.Reader, m.ActiveKey, m.ReadTimeout)
+		return m, m.exec(fetchTTL(m.Conn, m.Reader, m.ActiveKey, m.ReadTimeout))
 
 	case OpImportField:
 		// Field imported into the current key; reload the browser so it shows.
 		m.SelectedOp = OpCheckType
 		return m.switchToLoadingAndExecute(sendRedisCmd(
 			m.Conn, m.Reader,
-			redis.RedisCmd{Name: "TYPE", Args: []string{m.ActiveKey}},
+			redis.Type(m.ActiveKey),
 			m.ReadTimeout,
 		))
 
-	case OpSet, OpLSet, OpRename, OpExpirySet, OpExport, OpImport, OpExportDB, OpImportDB, OpExportField:
+	case OpExport, OpExportDB, OpExportField, OpDBDiffExport:
+		// Back to where the export was asked for, with the outcome as a toast.
+		text, _ := msg.Result.(string)
+		m.CurrentState = m.popState()
+		return m, m.notify(text, false)
+
+	case OpSet, OpLSet, OpRename, OpExpirySet, OpImport, OpImportDB:
+		if back, ok := m.quietOrigin(reply); ok && (m.SelectedOp == OpRename || m.SelectedOp == OpExpirySet) {
+			return quietWrite(m, back, msg.Result)
+		}
 		if str, ok := msg.Result.(string); ok {
-			m.Output = str
+			m.Result = reply.withValue(str)
 		} else if num, ok := msg.Result.(int); ok {
-			m.Output = strconv.Itoa(num)
+			m.Result = reply.withValue(strconv.Itoa(num))
 		} else {
-			m.Output = "Unexpected response"
+			m.Result = reply.withValue("Unexpected response")
 		}
 		m.CurrentState = StateOutput
+		if m.SelectedOp == OpSet && m.CaptureOld {
+			m.captureOverwrite(reply)
+		}
 
 		if m.SelectedOp == OpSet && m.PreservedTTL > 0 {
 			ttl := m.PreservedTTL
 			m.PreservedTTL = 0
 			m.SelectedOp = OpExpireAfterSet
-			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: "EXPIRE", Args: []string{m.ActiveKey, strconv.Itoa(ttl)}}, m.ReadTimeout))
+			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.Expire(m.ActiveKey, ttl), m.ReadTimeout))
 		}
 
 		if m.SelectedOp == OpExpirySet || m.SelectedOp == OpRename {
 			m.ActiveTTL = "fetching..."
-			return m, fetchTTL(m.Conn, m.Reader, m.ActiveKey, m.ReadTimeout)
+			return m, m.exec(fetchTTL(m.Conn, m.Reader, m.ActiveKey, m.ReadTimeout))
 		}
 
 	case OpDel:
@@ -329,48 +384,50 @@ func handleRedisResult(m Model, msg RedisResultMsg) (tea.Model, tea.Cmd) {
 		// delete has actually succeeded — not before, in handleStateConfirmationKey,
 		// or a failed delete would lose its way back to the browser (see OpHDel etc.).
 		m.popState()
-		m.Output = "Deleted Key: " + m.ActiveKey
-		m.SelectedOp = OpExplore
-		pattern := m.LastPattern
-		if pattern == "" {
-			pattern = "*"
-		}
-		m.Browser.Cursor = "0"
-		m.Browser.Pattern = pattern
-		return m.switchToLoadingAndExecute(scanRedisKeys(m.Conn, m.Reader, pattern, "0"))
+		m.invalidate(m.ActiveKey)
+		toast := m.notify("Deleted "+m.ActiveKey, false)
+		next, cmd := m.rescanKeys()
+		return next, tea.Batch(cmd, toast)
 
 	case OpHDel:
 		m.popState()
-		m.Output = "Deleted Hash Key: " + m.ActiveKey
+		m.invalidate(m.ActiveKey)
+		toast := m.notify("Deleted field "+m.ActiveField+" from "+m.ActiveKey, false)
+		m.Browser.FieldCursor = ""
+		m.Browser.HasMoreFields = false
 		m.SelectedOp = OpHKeys
-		return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: "HKEYS", Args: []string{m.ActiveKey}}, m.ReadTimeout))
+		next, cmd := m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, m.hashPage(), m.ReadTimeout))
+		return next, tea.Batch(cmd, toast)
 
 	case OpLRem:
 		m.popState()
-		m.Output = "Removed element from list: " + m.ActiveKey
+		m.invalidate(m.ActiveKey)
+		toast := m.notify("Removed "+m.ActiveField+" from "+m.ActiveKey, false)
 		m.Browser.FieldOffset = 0
 		m.Browser.HasMoreFields = false
 		m.SelectedOp = OpLRange
-		end := strconv.Itoa(fieldPageSize - 1)
-		return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: "LRANGE", Args: []string{m.ActiveKey, "0", end}}, m.ReadTimeout))
+		next, cmd := m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.LRange(m.ActiveKey, 0, fieldPageSize-1), m.ReadTimeout))
+		return next, tea.Batch(cmd, toast)
 
 	case OpSRem:
 		m.popState()
-		m.Output = "Removed element from set: " + m.ActiveKey
+		m.invalidate(m.ActiveKey)
+		toast := m.notify("Removed "+m.ActiveField+" from "+m.ActiveKey, false)
 		m.Browser.FieldCursor = ""
 		m.Browser.HasMoreFields = false
 		m.SelectedOp = OpSMembers
-		count := strconv.Itoa(fieldPageSize)
-		return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: "SSCAN", Args: []string{m.ActiveKey, "0", "COUNT", count}}, m.ReadTimeout))
+		next, cmd := m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.SScan(m.ActiveKey, "0", fieldPageSize), m.ReadTimeout))
+		return next, tea.Batch(cmd, toast)
 
 	case OpZRem:
 		m.popState()
-		m.Output = "Removed element from sorted set: " + m.ActiveKey
+		m.invalidate(m.ActiveKey)
+		toast := m.notify("Removed "+m.ActiveField+" from "+m.ActiveKey, false)
 		m.Browser.FieldOffset = 0
 		m.Browser.HasMoreFields = false
 		m.SelectedOp = OpZRange
-		end := strconv.Itoa(fieldPageSize - 1)
-		return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: "ZRANGE", Args: []string{m.ActiveKey, "0", end, "WITHSCORES"}}, m.ReadTimeout))
+		next, cmd := m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.ZRange(m.ActiveKey, 0, fieldPageSize-1, true), m.ReadTimeout))
+		return next, tea.Batch(cmd, toast)
 
 	case OpHSet:
 		// Reached only by the in-place hash-field edit ('e' on an OpHGet
@@ -378,14 +435,67 @@ func handleRedisResult(m Model, msg RedisResultMsg) (tea.Model, tea.Cmd) {
 		// afterward (OpAddItem) rather than landing here. HSET's raw reply is
 		// just 0 or 1 (new vs. existing field), which reads as a cryptic
 		// number; show a real confirmation instead.
-		m.Output = fmt.Sprintf("Updated %s.%s", m.ActiveKey, m.ActiveField)
+		m.Result = reply.withValue(fmt.Sprintf("Updated %s.%s", m.ActiveKey, m.ActiveField))
+		m.CurrentState = StateOutput
+
+	case OpXAdd:
+		return handleXAddResult(m, reply)
+
+	case OpGroups:
+		return handleGroupsResult(m, reply, msg.Result)
+
+	case OpGroupCreate, OpGroupSetID, OpGroupDestroy, OpAutoClaim:
+		return handleGroupActionResult(m, reply, msg.Result)
+
+	case OpPending:
+		return handlePendingResult(m, reply, msg.Result)
+
+	case OpPendingAck, OpPendingClaim:
+		return handlePendingActionResult(m, reply, msg.Result)
+
+	case OpFlagToggle:
+		return handleFlagToggleResult(m, reply, msg.Result)
+
+	case OpLeaderIncr:
+		return handleLeaderIncrResult(m, reply, msg.Result)
+
+	case OpSample:
+		items, err := redis.AsStringSlice(msg.Result)
+		if err != nil {
+			m.Result = reply.withValue("Unexpected response")
+			m.CurrentState = StateOutput
+			return m, nil
+		}
+		m.Result = m.sampleResult(reply, items)
+		m.CurrentState = StateOutput
+
+	case OpHashTable, OpSession:
+		pairs, err := redis.AsStringSlice(msg.Result)
+		if err != nil {
+			m.Result = reply.withValue("Unexpected response")
+			m.CurrentState = StateOutput
+			return m, nil
+		}
+		m.Browser.ActiveKeyType = "hash"
+		m.Result = hashTableResult(reply, pairs)
+		if rule, ok := m.Config.sessionRule(m.ActiveKey); ok && m.SelectedOp == OpSession {
+			m.Result = sessionResult(reply, pairs, rule)
+		}
+		m.CurrentState = StateOutput
+		m.ActiveTTL = "fetching..."
+		conn, reader := m.readConn()
+		return m, m.exec(fetchTTL(conn, reader, m.ActiveKey, m.ReadTimeout))
+
+	case OpLargePreview:
+		m.Result = reply.withValue(renderPreview(m.LargeValue, msg.Result, m.RawSizes))
+		m.ActiveTTL = ""
 		m.CurrentState = StateOutput
 
 	case OpDelete, OpRPush, OpLPush, OpSAdd, OpZAdd:
-		if res, ok := msg.Result.(int); ok {
-			m.Output = strconv.Itoa(res)
+		if res, err := redis.AsInt(msg.Result); err == nil {
+			m.Result = reply.withValue(strconv.Itoa(res))
 		} else {
-			m.Output = "Unexpected response"
+			m.Result = reply.withValue("Unexpected response")
 		}
 		m.CurrentState = StateOutput
 	}
@@ -398,7 +508,7 @@ func handleStateOutputKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "esc":
 		m.Input.Input.SetValue("")
 		m.Input.Hint = ""
-		m.Output = ""
+		m.Result = Result{}
 		m.ActiveTTL = ""
 		m.CopyStatus = ""
 
@@ -433,8 +543,8 @@ func handleStateOutputKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "e":
 		// Set and ZSet members cannot be edited in-place (SREM+SADD would be needed).
-		// Info output is also read-only.
-		if m.SelectedOp == OpInfo || m.SelectedOp == OpExploreSet || m.SelectedOp == OpExploreZSet {
+		// Info output, popped jobs and diffs are also read-only.
+		if isReadOnlyOutput(m.SelectedOp) || m.SelectedOp == OpExploreSet || m.SelectedOp == OpExploreZSet {
 			break
 		}
 		m.PreservedTTL = 0
@@ -443,10 +553,15 @@ func handleStateOutputKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.PreservedTTL = secs
 			}
 		}
-		m.Input.Input.SetValue(m.Output)
+		m.Input.Input.SetValue(m.Result.Value)
 		switch m.SelectedOp {
 		case OpGet:
 			m.SelectedOp = OpSet
+			m.CaptureOld = m.Server.Supports(redis.CapSetGet)
+			m.Input.Hint = ""
+			if m.EditingInt = isIntValue(m.Result.Value); m.EditingInt {
+				m.Input.Hint = "Input the value (a number may be 0x… hex, 0b… binary or 0o… octal):"
+			}
 		case OpHGet:
 			m.SelectedOp = OpHSet
 		case OpExploreList:
@@ -459,7 +574,7 @@ func handleStateOutputKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.CurrentState = StateInputValue
 
 	case "c":
-		err := clipboard.WriteAll(m.Output)
+		err := clipboard.WriteAll(m.Result.Text())
 		if err != nil {
 			m.CopyStatus = clipboardErrorHint()
 		} else {
@@ -470,10 +585,57 @@ func handleStateOutputKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return ClearCopyStatusMsg{}
 		}
 
+	case "o":
+		value := m.Result.Value
+		if m.Result.Renderer != nil {
+			value = m.Result.Stored
+		}
+		if u, ok := parseURL(value); ok && m.Result.Err == "" {
+			return m, openURL(u.String())
+		}
+
+	case "t":
+		m.Dates = !m.Dates
+
+	case "L":
+		if m.SelectedOp == OpGet && m.ActiveKey != "" {
+			return m.toggleLive(m.ActiveKey)
+		}
+
+	case "w":
+		name := "result"
+		if m.ActiveKey != "" && !isReadOnlyOutput(m.SelectedOp) {
+			name = sanitizeFilename(m.ActiveKey)
+		}
+		return m.startResultExport(m.outputExport(), name)
+
+	case "b":
+		// Only INFO's content changes; keep the scroll position.
+		m.RawSizes = !m.RawSizes
+		m.Viewport.SetContent(wrapOutput(m.renderOutput(), m.Viewport.Width))
+
+	case "enter":
+		if m.SelectedOp == OpHashTable || m.SelectedOp == OpSession {
+			return m.openHash() // the field list, to edit or export one
+		}
+
+	case "i":
+		if m.SelectedOp == OpSession && m.Result.Err == "" {
+			return m.startSessionInvalidate()
+		}
+
+	case "v":
+		if m.Result.Renderer == nil || m.Result.Err != "" {
+			break
+		}
+		m.Result.Renderer = nextRenderer(m.Result.Renderer, m.ActiveKey, m.Browser.ActiveKeyType, m.Result.Stored)
+		m.refreshOutputViewport()
+
 	case "x":
-		if m.SelectedOp == OpInfo {
+		if isReadOnlyOutput(m.SelectedOp) {
 			break
 		}
+		m.PromptOp = m.SelectedOp
 		m.SelectedOp = OpExpirySet
 		m.Input.Input.SetValue("")
 		m.Input.Type = InputValue
@@ -493,33 +655,76 @@ func handleStateOutputKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func handleStateConfirmationKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch keyMsg.String() {
-	case "esc", "n", "N":
-		m.CurrentState = m.popState()
-		return m, nil
+// deleteDialog asks before deleting the active key, or its active field or
+// member, as SelectedOp says.
+func (m Model) deleteDialog() Dialog {
+	d := Dialog{Title: "confirm delete", Destructive: true, Target: m.ActiveKey, Choices: confirmChoice(confirmDelete)}
+	switch m.SelectedOp {
+	case OpDel:
+		d.Label, d.Value = "key", m.ActiveKey
+	case OpHDel:
+		d.Label, d.Value = "field", m.ActiveField
+	case OpLRem:
+		d.Label, d.Value = "element", m.ActiveField
+	case OpSRem, OpZRem:
+		d.Label, d.Value = "member", m.ActiveField
+	case OpQuit:
+		quit := func(m Model) (tea.Model, tea.Cmd) { return m, tea.Quit }
+		return Dialog{Title: "quit", Value: "redis-tui", Choices: []DialogChoice{{Key: "y", Label: "quit", Run: quit}}}
+	default:
+		d.Value = m.SelectedOp.String()
+	}
+	return d
+}
 
-	case "y", "Y":
-		// Don't pop here: if the command below fails, the error lands in
-		// StateOutput and needs this entry still on the stack so Esc can find
-		// its way back to the browser. The success handlers in handleRedisResult
-		// (OpDel, OpHDel, OpLRem, OpSRem, OpZRem) pop it once they know the
-		// delete actually went through.
-		switch m.SelectedOp {
-		case OpQuit:
-			return m, tea.Quit
-		case OpDel:
-			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: m.SelectedOp.String(), Args: []string{m.ActiveKey}}, m.ReadTimeout))
-		case OpHDel:
-			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: m.SelectedOp.String(), Args: []string{m.ActiveKey, m.ActiveField}}, m.ReadTimeout))
-		case OpLRem:
-			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: m.SelectedOp.String(), Args: []string{m.ActiveKey, "1", m.ActiveField}}, m.ReadTimeout))
-		case OpSRem, OpZRem:
-			return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: m.SelectedOp.String(), Args: []string{m.ActiveKey, m.ActiveField}}, m.ReadTimeout))
-		}
+// confirmDelete sends the delete deleteDialog asked about.
+func confirmDelete(m Model) (tea.Model, tea.Cmd) {
+	args := []string{m.ActiveKey, m.ActiveField}
+	switch m.SelectedOp {
+	case OpDel:
+		args = args[:1]
+	case OpLRem:
+		args = []string{m.ActiveKey, "1", m.ActiveField}
+	case OpHDel, OpSRem, OpZRem:
+	default:
+		return m, nil
 	}
+	return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.RedisCmd{Name: m.SelectedOp.String(), Args: args}, m.ReadTimeout))
+}
 
-	return m, nil
+// handleStringInspect shows an opened string key with its TTL and idle time
+// already filled in, instead of following up with a separate TTL lookup.
+func handleStringInspect(m Model, msg StringInspectMsg) (tea.Model, tea.Cmd) {
+	edit := m.EditOnOpen
+	m.EditOnOpen = false
+	model, cmd := handleRedisResult(m, RedisResultMsg{Result: msg.Value, Error: msg.Error, Command: msg.Command, Latency: msg.Latency})
+	next, ok := model.(Model)
+	if msg.Error != nil || !ok || next.CurrentState != StateOutput {
+		return model, cmd
+	}
+	if msg.TTL != -2 {
+		next.remember(next.ActiveKey, msg.Command, msg)
+	}
+	switch {
+	case msg.TTL == -2:
+		// GET already printed (nil); say why, so a cache miss is unmistakable.
+		next.ActiveTTL = "key does not exist (miss)"
+		next.ActiveIdle = ""
+		return next, nil
+	case msg.TTL == -1:
+		next.ActiveTTL = "no expiry"
+	default:
+		next.ActiveTTL = strconv.Itoa(msg.TTL) + " s"
+	}
+	next.ActiveIdle = "n/a"
+	next.ActiveSize = len(next.Result.Value) // STRLEN, without the round trip
+	if msg.Idle >= 0 {
+		next.ActiveIdle = (time.Duration(msg.Idle) * time.Second).String()
+	}
+	if edit {
+		return handleStateOutputKey(next, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	}
+	return next, nil
 }
 
 // clipboardErrorHint returns a platform-specific message when clipboard access fails.
@@ -543,14 +748,14 @@ func handleRedisConnection(m Model, msg RedisConnectionMsg) (tea.Model, tea.Cmd)
 		if msg.Fatal {
 			// Permanent failure (wrong credentials, invalid DB index) — surface
 			// the error immediately and stop retrying.
-			m.Output = msg.Error.Error()
+			m.Reconnect.Connecting = false
+			m.Result = errorResult(msg.Error)
 			m.ActiveTTL = ""
 			m.CopyStatus = ""
 			m.CurrentState = StateOutput
 			return m, nil
 		}
-		m.ReconnectAttempts++
-		return m, waitForNextConnection(m.ReconnectAttempts)
+		return scheduleReconnect(m, msg.Error)
 	}
 
 	conn := msg.Conn
@@ -560,10 +765,36 @@ func handleRedisConnection(m Model, msg RedisConnectionMsg) (tea.Model, tea.Cmd)
 		_ = m.Conn.Close() // close the stale fd before overwriting; safe on a broken connection
 	}
 	m.Conn = conn
+	m.stopWorker()
+	m.Worker = NewWorker(conn)
+	m.ClientID = msg.ClientID
+	m.Server = msg.Server
+	for _, line := range m.OnConnect {
+		args, _ := splitCommandLine(line) // validated by runOnConnect
+		m.trackSessionState(args)
+	}
+	var toast tea.Cmd
+	if m.ReconnectAttempts > 0 || m.CurrentState == StateReconnect {
+		toast = m.notify("Reconnected to "+m.RedisAddress, false)
+	}
 	m.ReconnectAttempts = 0
+	m.Reconnect = ReconnectModel{Gen: m.Reconnect.Gen + 1} // drops pending ticks
+	m.closeReplica()
+	m.ReplicaFound, m.ReplicaCluster = "", false
+	switch {
+	case m.ReplicaAddress == "":
+	case m.Proxy:
+		toast = tea.Batch(toast, dialReplica(m)) // proxies may drop the connection on INFO
+	default:
+		toast = tea.Batch(toast, m.exec(findReplica(conn, reader, m.ReplicaAddress)))
+	}
 
-	if m.CurrentState == StateLoading {
+	if m.CurrentState == StateLoading || m.CurrentState == StateReconnect {
 		m.CurrentState = m.popState()
 	}
-	return m, nil
+	toast = tea.Batch(toast, m.startTelemetry())
+	if m.Proxy {
+		return m, toast // proxies may drop the connection on COMMAND
+	}
+	return m, tea.Batch(toast, m.exec(discoverCommands(conn, reader)))
 }
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// XADD from the menu, or a in a stream's tail, adds an entry with as many
+// field=value pairs as needed. The form stays open after each entry, so a
+// run of test events is one submit each; from the tail, esc goes back to
+// watching them arrive.
+
+// streamIDText is an entry ID XADD takes: ms, ms-seq, or ms-* for the next
+// sequence number within that millisecond.
+var streamIDText = regexp.MustCompile(`^[0-9]+(-([0-9]+|\*))?$`)
+
+// startXAddForm opens the XADD form, for key when it is known.
+func (m Model) startXAddForm(key string) (tea.Model, tea.Cmd) {
+	m.SelectedOp = OpXAdd
+	m.Form = NewForm(
+		"XADD · add an entry to a stream",
+		[]string{"stream key", `fields (field=value, comma-separated; \, for a comma)`, "entry ID (blank = * for the server to pick)"},
+		[]string{key},
+	)
+	if key != "" {
+		m.Form.setFocus(1)
+	}
+	m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+	m.pushState(m.CurrentState)
+	m.CurrentState = StateForm
+	return m, nil
+}
+
+// entryFields reads "field=value, field=value" as XADD's field/value
+// arguments. A value may hold "="; only the first one splits.
+func entryFields(s string) ([]string, error) {
+	pairs := splitCommas(s)
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("add at least one field=value pair")
+	}
+	fields := make([]string, 0, 2*len(pairs))
+	for _, p := range pairs {
+		f, v, ok := strings.Cut(p, "=")
+		if f = strings.TrimSpace(f); !ok || f == "" {
+			return nil, fmt.Errorf("%q: write each pair as field=value", p)
+		}
+		fields = append(fields, f, strings.TrimSpace(v))
+	}
+	return fields, nil
+}
+
+func submitXAddForm(m Model, values []string) (tea.Model, tea.Cmd) {
+	key, id := values[0], values[2]
+	if key == "" {
+		m.Form.Err = "a stream key is required"
+		return m, nil
+	}
+	fields, err := entryFields(values[1])
+	if err != nil {
+		m.Form.Err = err.Error()
+		return m, nil
+	}
+	if id == "" {
+		id = "*"
+	}
+	if id != "*" && !streamIDText.MatchString(id) {
+		m.Form.Err = fmt.Sprintf("entry ID %q: use *, <ms>, <ms>-<seq> or <ms>-*", id)
+		return m, nil
+	}
+	m.Form.Err = ""
+	m.ActiveKey = key
+	return m.switchToLoadingAndExecute(sendRedisCmd(m.Conn, m.Reader, redis.XAdd(key, id, fields...), m.ReadTimeout))
+}
+
+// handleXAddResult goes back to the form either way: with the server's
+// refusal to fix the entry, or with a toast naming the entry added. A custom
+// ID is cleared, since the next entry needs a higher one.
+func handleXAddResult(m Model, reply Result) (tea.Model, tea.Cmd) {
+	m.CurrentState = StateForm
+	if reply.Err != "" {
+		m.Form.Err = reply.Err
+		return m, nil
+	}
+	m.invalidate(m.ActiveKey)
+	if m.Form.Inputs[2].Value() != "*" {
+		m.Form.Inputs[2].SetValue("")
+	}
+	id, _ := reply.Reply.(string)
+	return m, m.notify("Added "+id+" to "+m.ActiveKey, false)
+}
@@ -0,0 +1,82 @@
+package tui
+
+import "strings"
+
+// The RPUSH, LPUSH and SADD prompts, and the field browser's list and set
+// add form, take several elements at once so seeding a collection is one
+// trip through the flow: one element per line, taken as typed. A line is
+// never split at its commas unless ctrl+o turns that on for the prompt, since
+// JSON and prose are full of them; then each line is split at unescaped
+// commas, with \, for a comma that belongs to an element and \\ for a
+// backslash, and the spaces around each element are trimmed.
+
+// splitValues reads s as the elements to push or add, one per line. Blank
+// lines are dropped; input with nothing else is one element, as typed, so
+// pushing "" still works.
+func splitValues(s string) []string {
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSuffix(l, "\r"); strings.TrimSpace(l) != "" {
+			lines = append(lines, l)
+		}
+	}
+	if len(lines) == 0 {
+		return []string{s}
+	}
+	return lines
+}
+
+// commaValues is s typed with commas on: every line split at its unescaped
+// commas, one element per line as splitValues reads them.
+func commaValues(s string) string {
+	var values []string
+	for _, l := range strings.Split(s, "\n") {
+		values = append(values, splitCommas(strings.TrimSuffix(l, "\r"))...)
+	}
+	if len(values) == 0 {
+		return s
+	}
+	return strings.Join(values, "\n")
+}
+
+// countValues is how many elements s sends, with commas on or off.
+func countValues(s string, commas bool) int {
+	if commas {
+		s = commaValues(s)
+	}
+	return len(splitValues(s))
+}
+
+// splitCommas splits s at unescaped commas, trimming each element.
+func splitCommas(s string) []string {
+	var values []string
+	var cur strings.Builder
+	flush := func() {
+		if v := strings.TrimSpace(cur.String()); v != "" {
+			values = append(values, v)
+		}
+		cur.Reset()
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s) && (s[i+1] == ',' || s[i+1] == '\\'):
+			i++
+			cur.WriteByte(s[i])
+		case c == ',':
+			flush()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	flush()
+	return values
+}
+
+// multiInput reports whether the prompt on show takes several elements.
+func (m Model) multiInput() bool {
+	switch m.SelectedOp {
+	case OpRPush, OpLPush, OpSAdd:
+		return m.CurrentState == StateInputValue
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Numbers typed into a prompt are checked here before they are sent, so a
+// TTL of "1.5" or a score of "2,5" is refused with a note under the input
+// rather than coming back as "ERR value is not an integer or out of range"
+// from the server. Parsing follows Redis, not the user's locale: "." is the
+// only decimal point, and grouping separators are refused rather than
+// guessed at, since "1,500" is 1.5 in some locales and 1500 in others.
+//
+// Integers kept as strings are often bitmasks, so the output screen shows one
+// in hex and binary too, and editing one takes 0x, 0b and 0o numbers, sent
+// in decimal as Redis's INCR and friends read them. The value is still a
+// string: text that isn't a number is sent as typed.
+
+// NumberSpec describes the number a prompt accepts.
+type NumberSpec struct {
+	Name     string  // what the number is, for messages: "TTL", "score"
+	Float    bool    // a double as Redis reads one, inf included; else a 64-bit integer
+	Min, Max float64 // inclusive bounds; both zero means any value
+	Bases    bool    // an integer may also be written 0x…, 0b… or 0o…; sent in decimal
+	Optional bool    // anything that isn't a number is sent as typed rather than refused
+}
+
+var (
+	ttlNumber   = NumberSpec{Name: "TTL", Min: 0, Max: math.MaxInt64 / 1000} // Redis keeps expiries in ms
+	scoreNumber = NumberSpec{Name: "score", Float: true}
+	intValue    = NumberSpec{Name: "value", Bases: true, Optional: true}
+)
+
+var (
+	intText   = regexp.MustCompile(`^[+-]?[0-9]+$`)
+	baseText  = regexp.MustCompile(`^[+-]?0([xX][0-9a-fA-F_]+|[bB][01_]+|[oO][0-7_]+)$`)
+	floatText = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+)
+
+// ParseNumber checks s against spec and returns it as it should be sent:
+// trimmed, and otherwise exactly as typed but for a number in another base,
+// which is sent in decimal.
+func ParseNumber(spec NumberSpec, s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if spec.Bases && baseText.MatchString(s) {
+		// Go's prefixes are Redis-free syntax, so _ between digits is fine.
+		n, err := strconv.ParseInt(s, 0, 64)
+		if err != nil {
+			if errors.Is(err, strconv.ErrRange) {
+				return "", fmt.Errorf("%s %q is out of range", spec.Name, s)
+			}
+			return "", fmt.Errorf("%s %q isn't a number", spec.Name, s)
+		}
+		s = strconv.FormatInt(n, 10)
+	}
+	switch {
+	case s == "":
+		return "", fmt.Errorf("enter a %s", spec.Name)
+	case strings.ContainsAny(s, ", '_"):
+		return "", fmt.Errorf("%s %q: use digits only, with . for decimals and no grouping separators", spec.Name, s)
+	}
+	var v float64
+	if spec.Float {
+		switch lower := strings.ToLower(s); {
+		case lower == "inf" || lower == "+inf":
+			v = math.Inf(1)
+		case lower == "-inf":
+			v = math.Inf(-1)
+		case !floatText.MatchString(s):
+			return "", fmt.Errorf("%s %q isn't a number", spec.Name, s)
+		default:
+			var err error
+			if v, err = strconv.ParseFloat(s, 64); err != nil {
+				return "", fmt.Errorf("%s %q is out of range", spec.Name, s)
+			}
+		}
+	} else {
+		if !intText.MatchString(s) {
+			if floatText.MatchString(s) {
+				return "", fmt.Errorf("%s must be a whole number, got %q", spec.Name, s)
+			}
+			return "", fmt.Errorf("%s %q isn't a number", spec.Name, s)
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%s %q is out of range", spec.Name, s)
+		}
+		v = float64(n)
+	}
+	if spec.Min != 0 || spec.Max != 0 {
+		switch {
+		case v < spec.Min:
+			return "", fmt.Errorf("%s must be at least %s", spec.Name, formatBound(spec.Min))
+		case v > spec.Max:
+			return "", fmt.Errorf("%s must be at most %s", spec.Name, formatBound(spec.Max))
+		}
+	}
+	return s, nil
+}
+
+func formatBound(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// numberInput is the number the prompt on show asks for, or nil.
+func (m Model) numberInput() *NumberSpec {
+	switch {
+	case m.CurrentState == StateInputValue && m.SelectedOp == OpExpirySet:
+		return &ttlNumber
+	case m.CurrentState == StateInputField && m.SelectedOp == OpZAdd:
+		return &scoreNumber
+	case m.CurrentState == StateInputValue && m.SelectedOp == OpSet && m.EditingInt:
+		return &intValue
+	}
+	return nil
+}
+
+// isIntValue reports whether a stored value reads as a 64-bit integer.
+func isIntValue(s string) bool {
+	if !intText.MatchString(s) {
+		return false
+	}
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err == nil
+}
+
+// baseNote is a non-negative integer value in hex and binary, with the bits
+// it has set when few enough to read; "" for anything else.
+func baseNote(s string) string {
+	if !isIntValue(s) {
+		return ""
+	}
+	n, _ := strconv.ParseInt(s, 10, 64)
+	if n < 0 {
+		return ""
+	}
+	bin := strconv.FormatInt(n, 2)
+	var grouped strings.Builder
+	for i, c := range bin {
+		if i > 0 && (len(bin)-i)%4 == 0 {
+			grouped.WriteByte('_')
+		}
+		grouped.WriteRune(c)
+	}
+	note := fmt.Sprintf("hex 0x%x · bin 0b%s", n, grouped.String())
+	if set := bits.OnesCount64(uint64(n)); set > 0 && set <= 16 {
+		var pos []string
+		for i := range 63 {
+			if n&(1<<i) != 0 {
+				pos = append(pos, strconv.Itoa(i))
+			}
+		}
+		note += " · bits " + strings.Join(pos, " ")
+	}
+	return note
+}
@@ -0,0 +1,27 @@
+package tui
+
+// A load's reply used to be handled under whatever SelectedOp, ActiveKey,
+// ActiveField and ActiveIndex held when it landed, and a tick or a mid-flow
+// step (OpCheckType turning into OpHashTable, OpExplore into a field list)
+// may have moved them on by then. Each load now records the Operation it was
+// started for and its LoadedMsg brings it back, so the reply is read as the
+// command it answers. SelectedOp stays the operation of the screen shown.
+
+// Operation is what a load was started for.
+type Operation struct {
+	Kind  Op
+	Key   string
+	Field string
+	Index int
+	From  AppState // the screen it was started from
+}
+
+// operation is what the model is on now, started from the screen from.
+func (m Model) operation(from AppState) Operation {
+	return Operation{Kind: m.SelectedOp, Key: m.ActiveKey, Field: m.ActiveField, Index: m.ActiveIndex, From: from}
+}
+
+// resume puts the model back on op to handle its reply.
+func (m *Model) resume(op Operation) {
+	m.SelectedOp, m.ActiveKey, m.ActiveField, m.ActiveIndex = op.Kind, op.Key, op.Field, op.Index
+}
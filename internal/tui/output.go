@@ -0,0 +1,289 @@
+package tui
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxShownArg caps how much of one argument the output header repeats, so a
+// SET with a large value doesn't push the rest of the header off screen.
+const maxShownArg = 40
+
+// Result is what the output screen shows: a value plus where it came from.
+// Messages produced locally (confirmations, tool reports) have no Command.
+type Result struct {
+	Command string        // the command as issued, e.g. `GET user:1`
+	Type    string        // reply kind: string, integer, array, nil or error
+	Value   string        // the rendered value
+	Latency time.Duration // round trip of Command; zero when not timed
+	Err     string        // set when the command or the tool failed
+	Reply   any           // the reply as read, for saving as JSON or CSV; nil for local messages
+	// Renderer shows Stored, a value as kept in Redis, in place of Value;
+	// nil for everything else.
+	Renderer Renderer
+	Stored   string
+}
+
+// textResult is a locally produced message.
+func textResult(s string) Result {
+	return Result{Value: s}
+}
+
+// errorResult is a failure that never reached (or never came back from) Redis.
+func errorResult(err error) Result {
+	return Result{Type: "error", Err: err.Error()}
+}
+
+// replyResult describes a reply before the handler decides how to render its
+// value. Redis error replies arrive as plain strings and are flagged here.
+func replyResult(msg RedisResultMsg) Result {
+	r := Result{Command: msg.Command, Latency: msg.Latency, Reply: msg.Result}
+	switch v := msg.Result.(type) {
+	case string:
+		switch {
+		case v == "(nil)":
+			r.Type = "nil"
+		case redis.IsErrorReply(v):
+			r.Type, r.Err = "error", v
+		default:
+			r.Type = "string"
+		}
+	case int:
+		r.Type = "integer"
+	case []any:
+		r.Type = "array"
+	}
+	return r
+}
+
+// withValue returns r showing v.
+func (r Result) withValue(v string) Result {
+	r.Value = v
+	return r
+}
+
+// Text is the result as plain text: the error if there is one, else the value.
+func (r Result) Text() string {
+	if r.Err != "" {
+		return r.Err
+	}
+	return r.Value
+}
+
+// formatCommand renders cmd the way it would be typed, quoting arguments that
+// need it and shortening long ones.
+func formatCommand(cmd redis.RedisCmd) string {
+	words := []string{strings.ToUpper(cmd.Name)}
+	for _, a := range cmd.Args {
+		if len(a) > maxShownArg {
+			words = append(words, redis.QuoteArg(a[:maxShownArg])+"…")
+			continue
+		}
+		words = append(words, redis.QuoteArg(a))
+	}
+	return strings.Join(words, " ")
+}
+
+// resultHeader is the dim "command · type · latency" summary shown next to the
+// output label; empty for results that carry none of them.
+func resultHeader(r Result) string {
+	var parts []string
+	if r.Command != "" {
+		parts = append(parts, r.Command)
+	}
+	if r.Type != "" {
+		parts = append(parts, r.Type)
+	}
+	if r.Renderer != nil && r.Renderer.Name() != "plain" {
+		parts = append(parts, "as "+r.Renderer.Name())
+	}
+	if r.Latency > 0 {
+		parts = append(parts, formatLatency(r.Latency))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// valueStats sums up a stored value under the output header: its length in
+// bytes, characters and lines, and the start of its SHA-256, so the same key
+// in two environments can be compared without reading both values through.
+// 16 hex digits tell values apart as surely as the whole sum, in half the width.
+func valueStats(v string) string {
+	chars := "not UTF-8"
+	if utf8.ValidString(v) {
+		n := utf8.RuneCountInString(v)
+		chars = fmt.Sprintf("%d char%s", n, plural(n))
+	}
+	lines := 0
+	if v != "" {
+		lines = strings.Count(strings.TrimSuffix(v, "\n"), "\n") + 1
+	}
+	sum := sha256.Sum256([]byte(v))
+	return fmt.Sprintf("%d byte%s · %s · %d line%s · sha256 %x…", len(v), plural(len(v)), chars, lines, plural(lines), sum[:8])
+}
+
+// showsValueStats reports whether the output screen holds one stored value,
+// rather than a reply, a report or a hash laid out whole.
+func (m Model) showsValueStats() bool {
+	return m.Result.Renderer != nil && m.Result.Err == "" && m.SelectedOp != OpHashTable && m.SelectedOp != OpSession
+}
+
+// renderOutput is the output screen's viewport content: the result, with
+// the session's telemetry above server INFO.
+func (m Model) renderOutput() string {
+	out := renderResult(m.Result, m.SelectedOp, m.RawSizes)
+	if m.SelectedOp == OpInfo && m.Result.Err == "" {
+		w, _ := m.outputVPSize()
+		if t := m.telemetryView(w); t != "" {
+			out = t + "\n\n" + out
+		}
+	}
+	return out
+}
+
+// renderResult is the viewport content for r: errors in red, stored values
+// through their renderer, anything else colorized for op.
+func renderResult(r Result, op Op, rawSizes bool) string {
+	if r.Err != "" {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render(r.Err)
+	}
+	if r.Renderer != nil {
+		return r.Renderer.Render(r.Stored)
+	}
+	v := r.Value
+	if (op == OpInfo || op == OpNodeInfo) && !rawSizes {
+		v = humanizeInfo(v)
+	}
+	return colorizeOutput(v, op)
+}
+
+// outputView is the output screen: what produced the result, the result in
+// its scrollable box, and the key's TTL and notes under it.
+func (m Model) outputView() string {
+	header := m.headerView()
+	h := m.Help
+	h.Width = m.WindowWidth
+	var helpView string
+	switch {
+	case m.SelectedOp == OpHashTable:
+		helpView = "  " + h.View(hashTableOutputKeys)
+	case m.SelectedOp == OpSession:
+		helpView = "  " + h.View(sessionOutputKeys)
+	case isReadOnlyOutput(m.SelectedOp):
+		helpView = "  " + h.View(infoOutputKeys)
+	case m.SelectedOp == OpExploreSet || m.SelectedOp == OpExploreZSet:
+		helpView = "  " + h.View(memberOutputKeys)
+	default:
+		keys := outputKeys
+		keys.Live.SetEnabled(m.SelectedOp == OpGet)
+		helpView = "  " + h.View(keys)
+	}
+
+	// "Output: ..." label line. m.ActiveKey is stale for operations that
+	// aren't scoped to a single key (server INFO, whole-database export
+	// /import) — showing it there would just be whatever key was last
+	// browsed, so swap in a label that actually describes the screen.
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	outputSubject := m.ActiveKey
+	switch m.SelectedOp {
+	case OpInfo:
+		outputSubject = "Server INFO"
+	case OpExportDB, OpImportDB:
+		outputSubject = fmt.Sprintf("Database %d", m.DB)
+	case OpQueuePop:
+		outputSubject = "popped from " + m.ActiveKey
+	case OpCompare:
+		outputSubject = "compare " + m.ActiveKey
+	case OpClusterReshard:
+		outputSubject = "reshard preview"
+	case OpTTLForecast:
+		outputSubject = "TTL forecast"
+	case OpNodeInfo:
+		outputSubject = "INFO · " + m.Cluster.Target
+	case OpFullValue:
+		outputSubject = m.ActiveKey + " · full"
+	case OpExportResult:
+		outputSubject = "save"
+	}
+	label := "  " + labelStyle.Render("Output: ") + keyStyle.Render(outputSubject)
+	if header := resultHeader(m.Result); header != "" {
+		label += labelStyle.Render("   " + truncateText(header, max(m.WindowWidth-lipgloss.Width(label)-5, 10)))
+	}
+	if m.showsValueStats() {
+		label += "\n  " + labelStyle.Render(truncateText(valueStats(m.Result.Stored), max(m.WindowWidth-4, 10)))
+	}
+
+	// The value/INFO content lives in a scrollable viewport so long output
+	// never overflows or loses its top. Render from a local copy with the
+	// content set (the scroll offset persists on m.Viewport).
+	contentWidth := m.WindowWidth - 8
+	if contentWidth <= 0 {
+		contentWidth = 72
+	}
+	vp := m.Viewport
+	var maxH int
+	vp.Width, maxH = m.outputVPSize()
+	content := wrapOutput(m.renderOutput(), vp.Width)
+	vp.Height = outputBoxHeight(content, maxH)
+	vp.SetContent(content)
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(tnBorder)).
+		Padding(0, 1).
+		MarginLeft(2).
+		Width(contentWidth).
+		Render(vp.View())
+
+	// Meta row — TTL on the left, copy confirmation right-justified.
+	metaLeft := ""
+	if m.ActiveTTL != "" {
+		metaLeft = labelStyle.Render("TTL: ") + keyStyle.Render(m.ActiveTTL)
+		if m.Dates && strings.HasSuffix(m.ActiveTTL, " s") && !m.ActiveExpiry.IsZero() {
+			metaLeft += labelStyle.Render("  expires ") + keyStyle.Render(formatDate(m.ActiveExpiry))
+		}
+		if m.ActiveIdle != "" {
+			metaLeft += labelStyle.Render("   idle: ") + keyStyle.Render(m.ActiveIdle)
+			metaLeft += labelStyle.Render("   size: ") + keyStyle.Render(sizeText(m.ActiveSize, m.RawSizes))
+		}
+	}
+	if note := dateNote(m.Result.Value); m.Dates && note != "" {
+		if metaLeft != "" {
+			metaLeft += "   "
+		}
+		metaLeft += labelStyle.Render("as date: ") + keyStyle.Render(note)
+	}
+	if note := baseNote(m.Result.Value); note != "" && m.SelectedOp == OpGet {
+		if metaLeft != "" {
+			metaLeft += "   "
+		}
+		metaLeft += keyStyle.Render(note)
+	}
+	if badge := m.liveBadge(m.ActiveKey); badge != "" && m.SelectedOp == OpGet {
+		if metaLeft != "" {
+			metaLeft += "   "
+		}
+		metaLeft += badge
+	}
+	toast := ""
+	if m.CopyStatus != "" {
+		toast = lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)).Render("✓ " + m.CopyStatus)
+	}
+	metaRow := ""
+	if metaLeft != "" || toast != "" {
+		gap := contentWidth - lipgloss.Width(metaLeft) - lipgloss.Width(toast)
+		if gap < 1 {
+			gap = 1
+		}
+		metaRow = "\n  " + metaLeft + strings.Repeat(" ", gap) + toast
+	}
+
+	body := header + "\n\n" + label + "\n" + box + metaRow
+	foot := footerSep(m.WindowWidth) + "\n" + helpView
+	return bottomFooter(body, foot, m.WindowHeight)
+}
@@ -0,0 +1,387 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Profile is a named connection from the config file, e.g. "staging" or
+// "prod". Either URL or Host is used; URL wins when both are set.
+type Profile struct {
+	Name     string `json:"name"`
+	URL      string `json:"url,omitempty"`
+	Host     string `json:"host,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// PasswordEnv names an environment variable holding the password, so the
+	// config file can be committed or shared without the secret in it.
+	PasswordEnv string `json:"password_env,omitempty"`
+	// PasswordCommand is run through the shell and its output used as the
+	// password — e.g. `security find-generic-password -w -s redis-prod` for
+	// the macOS keychain, or `op read op://dev/redis/password`.
+	PasswordCommand string `json:"password_command,omitempty"`
+	DB              int    `json:"db,omitempty"`
+	TLS             bool   `json:"tls,omitempty"`
+	TLSSkipVerify   bool   `json:"tls_skip_verify,omitempty"`
+	TLSCert         string `json:"tls_cert,omitempty"`
+	TLSKey          string `json:"tls_key,omitempty"`
+	TLSCA           string `json:"tls_ca,omitempty"`
+	// ReadOnly makes every session on this profile read-only, as -readonly
+	// does; Admin unlocks server lifecycle controls (SHUTDOWN, DEBUG RESTART),
+	// which are off for every other connection.
+	ReadOnly bool `json:"readonly,omitempty"`
+	Admin    bool `json:"admin,omitempty"`
+	// Production puts a red banner over every screen, makes each write wait
+	// for the key it touches to be typed, and disables FLUSHALL, FLUSHDB,
+	// CONFIG SET and SHUTDOWN outright; see model_production.go.
+	Production bool `json:"production,omitempty"`
+	// Inline sends commands in the inline protocol (plain words + CRLF) for
+	// proxies that reject multi-bulk requests.
+	Inline bool `json:"inline,omitempty"`
+	// Proxy is for limited proxies such as Twemproxy: commands they don't
+	// forward (SELECT, SCAN, MULTI, SUBSCRIBE, CLIENT) are never sent, and
+	// EXPLORE lists ProxyKeys, or uses KEYS within a namespace when empty.
+	Proxy     bool     `json:"proxy,omitempty"`
+	ProxyKeys []string `json:"proxy_keys,omitempty"`
+	// ClientName replaces the default CLIENT SETNAME for this profile.
+	ClientName string `json:"client_name,omitempty"`
+	// OnConnect holds console-style command lines run after every (re)connect
+	// of the session, so it starts in a known state.
+	OnConnect []string `json:"on_connect,omitempty"`
+	// Replica is the host:port of a replica the key browser reads from, to
+	// keep browsing load off the primary, or "auto" to use one the primary
+	// lists in INFO replication.
+	Replica string `json:"replica,omitempty"`
+}
+
+// Config is the on-disk configuration file.
+type Config struct {
+	Profiles []Profile `json:"profiles"`
+	// Templates replace the built-in TEMPLATE choices when set.
+	Templates []Template `json:"templates,omitempty"`
+	// ScanCount caps the SCAN COUNT hint, which otherwise grows to
+	// DefaultScanCount. -scan-count overrides it.
+	ScanCount int `json:"scan_count,omitempty"`
+	// VimKeys gives the menu and the key browser vim's keys; see vim.go.
+	VimKeys bool `json:"vim_keys,omitempty"`
+	// Renderers pick how values under a key pattern are shown; see
+	// renderers.go.
+	Renderers []RenderRule `json:"renderers,omitempty"`
+	// Display sets how keys under a pattern open; see display_rules.go.
+	Display []DisplayRule `json:"display,omitempty"`
+	// Macros are run on a key from the browser; see model_macros.go.
+	Macros []Macro `json:"macros,omitempty"`
+	// Sessions mark hashes as session records; see sessions.go.
+	Sessions []SessionRule `json:"sessions,omitempty"`
+	// Flags are FLAGS's default key patterns; see model_flags.go.
+	Flags []string `json:"flags,omitempty"`
+	// GrowthAlert is GROWTH's default alert limit in keys per minute.
+	GrowthAlert int `json:"growth_alert,omitempty"`
+	// AuditLog is the file every session appends its writes to, as -audit.
+	AuditLog string `json:"audit_log,omitempty"`
+	// TelemetrySeconds turns on INFO sampling at that interval, as
+	// -telemetry; see telemetry.go.
+	TelemetrySeconds int `json:"telemetry_seconds,omitempty"`
+}
+
+// Endpoint is a resolved connection target: everything openConnection needs.
+type Endpoint struct {
+	Address   string
+	Username  string
+	Password  string
+	DB        int
+	TLSConfig *tls.Config
+	Inline    bool
+	Proxy     bool
+}
+
+// DefaultConfigPath returns the per-user config location
+// (e.g. ~/.config/redis-tui/config.json on Linux).
+func DefaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "redis-tui", "config.json")
+}
+
+// LoadConfig reads the config file at path. A missing file is not an error —
+// profiles are optional — and yields an empty Config.
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if cfg.GrowthAlert < 0 {
+		return cfg, fmt.Errorf("%s: growth_alert must not be negative", path)
+	}
+	if cfg.TelemetrySeconds < 0 {
+		return cfg, fmt.Errorf("%s: telemetry_seconds must not be negative", path)
+	}
+	seen := map[string]bool{}
+	for _, p := range cfg.Profiles {
+		if p.Name == "" {
+			return cfg, fmt.Errorf("%s: every profile needs a name", path)
+		}
+		if seen[p.Name] {
+			return cfg, fmt.Errorf("%s: duplicate profile %q", path, p.Name)
+		}
+		seen[p.Name] = true
+		for _, line := range p.OnConnect {
+			if err := checkOnConnect(line); err != nil {
+				return cfg, fmt.Errorf("%s: profile %q: on_connect %q: %w", path, p.Name, line, err)
+			}
+		}
+	}
+	for _, t := range cfg.Templates {
+		if err := t.validate(); err != nil {
+			return cfg, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, r := range cfg.Renderers {
+		if err := r.validate(); err != nil {
+			return cfg, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, r := range cfg.Display {
+		if err := r.validate(); err != nil {
+			return cfg, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, mc := range cfg.Macros {
+		if err := mc.validate(); err != nil {
+			return cfg, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, r := range cfg.Sessions {
+		if err := r.validate(); err != nil {
+			return cfg, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return cfg, nil
+}
+
+// checkOnConnect rejects an on_connect line up front rather than on every
+// connect. Streaming commands would leave the session connection unusable.
+func checkOnConnect(line string) error {
+	args, err := splitCommandLine(line)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("empty command")
+	}
+	if name := strings.ToUpper(args[0]); consoleRefused[name] != "" {
+		return fmt.Errorf("%s keeps the connection streaming; not supported at connect time", name)
+	}
+	return nil
+}
+
+// Find looks up a profile by name.
+func (c Config) Find(name string) (Profile, bool) {
+	for _, p := range c.Profiles {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Profile{}, false
+}
+
+// Names lists the profile names in file order.
+func (c Config) Names() []string {
+	names := make([]string, len(c.Profiles))
+	for i, p := range c.Profiles {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// Endpoint resolves the profile into a connection target, loading TLS
+// material and the password from the environment where configured.
+func (p Profile) Endpoint() (Endpoint, error) {
+	e := Endpoint{Address: p.Host, Username: p.Username, Password: p.Password, DB: p.DB, Inline: p.Inline, Proxy: p.Proxy}
+	useTLS := p.TLS
+	if p.URL != "" {
+		parsed, err := ParseRedisURL(p.URL)
+		if err != nil {
+			return Endpoint{}, fmt.Errorf("profile %q: %w", p.Name, err)
+		}
+		e.Address, e.DB = parsed.Host, parsed.DB
+		if parsed.Username != "" {
+			e.Username = parsed.Username
+		}
+		if parsed.Password != "" {
+			e.Password = parsed.Password
+		}
+		useTLS = useTLS || parsed.TLS
+	}
+	if e.Address == "" {
+		return Endpoint{}, fmt.Errorf("profile %q: needs a url or host", p.Name)
+	}
+	if p.PasswordEnv != "" {
+		e.Password = os.Getenv(p.PasswordEnv)
+	}
+	if p.PasswordCommand != "" {
+		pw, err := runPasswordCommand(p.PasswordCommand)
+		if err != nil {
+			return Endpoint{}, fmt.Errorf("profile %q: password_command: %w", p.Name, err)
+		}
+		e.Password = pw
+	}
+
+	cfg, err := BuildTLSConfig(useTLS, p.TLSSkipVerify, p.TLSCert, p.TLSKey, p.TLSCA)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("profile %q: %w", p.Name, err)
+	}
+	if cfg != nil && !cfg.InsecureSkipVerify && cfg.ServerName == "" {
+		if hostname, _, err := net.SplitHostPort(e.Address); err == nil {
+			cfg.ServerName = hostname
+		}
+	}
+	e.TLSConfig = cfg
+	return e, nil
+}
+
+// passwordCommandTimeout bounds a password_command. Secret managers that ask
+// to be unlocked do so through their own agent or GUI, which takes a moment;
+// stdin belongs to the TUI.
+const passwordCommandTimeout = 20 * time.Second
+
+// runPasswordCommand runs cmdline through the platform shell and returns its
+// output without the trailing newline every CLI tool prints.
+func runPasswordCommand(cmdline string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), passwordCommandTimeout)
+	defer cancel()
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", cmdline)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", cmdline)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			first, _, _ := strings.Cut(msg, "\n")
+			return "", fmt.Errorf("%w: %s", err, first)
+		}
+		return "", err
+	}
+	pw := strings.TrimRight(string(out), "\r\n")
+	if pw == "" {
+		return "", errors.New("printed nothing")
+	}
+	return pw, nil
+}
+
+// endpoint returns the model's own connection target.
+func (m Model) endpoint() Endpoint {
+	return Endpoint{Address: m.RedisAddress, Username: m.Username, Password: m.Password, DB: m.DB, TLSConfig: m.TLSConfig, Inline: m.Inline, Proxy: m.Proxy}
+}
+
+// withEndpoint returns a copy of m pointed at e, for opening side connections
+// (compare, diff) with the usual handshake and timeouts.
+func (m Model) withEndpoint(e Endpoint) Model {
+	m.RedisAddress, m.Username, m.Password, m.DB, m.TLSConfig = e.Address, e.Username, e.Password, e.DB, e.TLSConfig
+	m.Inline, m.Proxy = e.Inline, e.Proxy
+	return m
+}
+
+// resolveTarget turns what the user typed into an endpoint: "current" (or
+// empty) is this session's own server, a redis:// or rediss:// URL is used
+// as-is, anything else is a profile name.
+func (m Model) resolveTarget(spec string) (Endpoint, string, error) {
+	switch {
+	case spec == "" || spec == "current":
+		label := m.ProfileName
+		if label == "" {
+			label = m.RedisAddress
+		}
+		return m.endpoint(), label, nil
+	case strings.HasPrefix(spec, "redis://") || strings.HasPrefix(spec, "rediss://"):
+		e, err := Profile{Name: spec, URL: spec}.Endpoint()
+		if err != nil {
+			return Endpoint{}, "", err
+		}
+		return e, e.Address, nil
+	}
+	p, ok := m.Config.Find(spec)
+	if !ok {
+		if len(m.Config.Profiles) == 0 {
+			return Endpoint{}, "", fmt.Errorf("unknown profile %q (no profiles configured — use a redis:// URL)", spec)
+		}
+		return Endpoint{}, "", fmt.Errorf("unknown profile %q (have: %s)", spec, strings.Join(m.Config.Names(), ", "))
+	}
+	e, err := p.Endpoint()
+	return e, p.Name, err
+}
+
+// TargetsMsg carries the endpoints withTargets resolved in a tea.Cmd, and
+// what to do with them.
+type TargetsMsg struct {
+	Sides []compareSide
+	Error error
+	then  func(Model, []compareSide, error) (tea.Model, tea.Cmd)
+}
+
+// withTargets resolves each spec as resolveTarget does and hands the result
+// to then. A profile's password_command can wait seconds on a keychain or
+// password-manager prompt, so when one has to run the lookup is a tea.Cmd,
+// the loading screen shows meanwhile (esc cancels it), and then runs when
+// the TargetsMsg arrives. Otherwise then runs at once.
+func (m Model) withTargets(specs []string, then func(Model, []compareSide, error) (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	resolve := func() ([]compareSide, error) {
+		sides := make([]compareSide, len(specs))
+		for i, spec := range specs {
+			e, label, err := m.resolveTarget(spec)
+			if err != nil {
+				return nil, err
+			}
+			sides[i] = compareSide{Label: label, Endpoint: e}
+		}
+		return sides, nil
+	}
+	if !slices.ContainsFunc(specs, m.runsPasswordCommand) {
+		sides, err := resolve()
+		return then(m, sides, err)
+	}
+	return m.switchToLoadingAndExecute(func() tea.Msg {
+		sides, err := resolve()
+		return TargetsMsg{Sides: sides, Error: err, then: then}
+	})
+}
+
+// runsPasswordCommand reports whether resolving spec runs a profile's
+// password_command.
+func (m Model) runsPasswordCommand(spec string) bool {
+	if spec == "" || spec == "current" || strings.HasPrefix(spec, "redis://") || strings.HasPrefix(spec, "rediss://") {
+		return false
+	}
+	p, ok := m.Config.Find(spec)
+	return ok && p.PasswordCommand != ""
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// A rename or TTL change asked for from the key browser or an open value
+// goes back there when it succeeds, with a toast saying what changed,
+// instead of replacing the screen with a bare OK or 1. A failure still
+// lands on the output screen, with the prompt's screen stacked under it.
+
+// quietOrigin is the screen a write's prompt was opened from, when the write
+// succeeded and that screen can take it back.
+func (m Model) quietOrigin(reply Result) (AppState, bool) {
+	n := len(m.StateNavigationHistory)
+	if reply.Err != "" || n == 0 {
+		return 0, false
+	}
+	back := m.StateNavigationHistory[n-1]
+	return back, back == StateBrowser || back == StateOutput
+}
+
+// quietWrite returns to back, and the operation it was showing, after a
+// rename or TTL change. A rename in the browser lists the keys again; an
+// open value reads its TTL again.
+func quietWrite(m Model, back AppState, reply any) (tea.Model, tea.Cmd) {
+	m.popState()
+	m.CurrentState = back
+	renamed := m.SelectedOp == OpRename
+	m.SelectedOp = m.PromptOp
+	n, _ := reply.(int)
+	var toast tea.Cmd
+	switch {
+	case renamed:
+		toast = m.notify("Renamed to "+m.ActiveKey, false)
+	case n == 0 && m.ActiveValue == "0":
+		toast = m.notify(m.ActiveKey+" has no expiry to remove", false)
+	case n == 0:
+		toast = m.notify(m.ActiveKey+" does not exist; no TTL set", true)
+	case m.ActiveValue == "0":
+		toast = m.notify("Removed the expiry of "+m.ActiveKey, false)
+	default:
+		secs, _ := strconv.Atoi(m.ActiveValue)
+		toast = m.notify(fmt.Sprintf("%s expires in %s", m.ActiveKey, formatTTL(secs)), false)
+	}
+	if back == StateOutput {
+		m.ActiveTTL = "fetching..."
+		return m, tea.Batch(toast, m.exec(fetchTTL(m.Conn, m.Reader, m.ActiveKey, m.ReadTimeout)))
+	}
+	if renamed {
+		next, cmd := m.rescanKeys()
+		return next, tea.Batch(cmd, toast)
+	}
+	return m, toast
+}
@@ -0,0 +1,262 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// A stored value (a string, a hash field, a list element or a member) is
+// shown by a Renderer. The config's "renderers" rules pick one by key
+// pattern; otherwise the first registered renderer that can show the value
+// does. `v` on the output screen steps through the others that can.
+
+// Renderer shows a stored value on the output screen. CanRender reports
+// whether it is a faithful view of value (JSON for the JSON renderer,
+// printable text for plain); Render returns the styled text.
+type Renderer interface {
+	Name() string
+	CanRender(key, keyType, value string) bool
+	Render(value string) string
+}
+
+// RenderRule picks the renderer for keys matching a glob, e.g.
+// {"keys": "session:*", "renderer": "json"}. A value the renderer can't show
+// falls back to the default choice.
+type RenderRule struct {
+	Keys     string `json:"keys"`
+	Renderer string `json:"renderer"`
+}
+
+// renderers in the order the default choice tries them. image, jwt and url
+// come first as those values would otherwise pass for plain text; hex takes
+// anything, so it catches binary values plain turns down; table is only
+// picked by a rule or by `v`, as json comes first for everything it can show.
+var renderers = []Renderer{imageRenderer{}, jwtRenderer{}, urlRenderer{}, jsonRenderer{}, plainRenderer{}, hexRenderer{}, tableRenderer{}}
+
+// RegisterRenderer adds r ahead of the built-ins, so a build of redis-tui can
+// show its own formats (protobuf, msgpack, …) and name them in rules. It
+// replaces a renderer of the same name; call it before the program starts.
+func RegisterRenderer(r Renderer) {
+	list := []Renderer{r}
+	for _, old := range renderers {
+		if old.Name() != r.Name() {
+			list = append(list, old)
+		}
+	}
+	renderers = list
+}
+
+func lookupRenderer(name string) Renderer {
+	for _, r := range renderers {
+		if r.Name() == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// validate reports what is wrong with a configured rule.
+func (r RenderRule) validate() error {
+	switch {
+	case r.Keys == "":
+		return fmt.Errorf("every renderer rule needs keys")
+	case lookupRenderer(r.Renderer) == nil:
+		return fmt.Errorf("renderer rule %q: unknown renderer %q", r.Keys, r.Renderer)
+	}
+	return nil
+}
+
+// pickRenderer is the renderer for value, stored at key (of keyType).
+func pickRenderer(rules []RenderRule, key, keyType, value string) Renderer {
+	for _, rule := range rules {
+		if !redis.GlobMatch(rule.Keys, key) {
+			continue
+		}
+		if r := lookupRenderer(rule.Renderer); r != nil && r.CanRender(key, keyType, value) {
+			return r
+		}
+		break
+	}
+	for _, r := range renderers {
+		if r.CanRender(key, keyType, value) {
+			return r
+		}
+	}
+	return plainRenderer{}
+}
+
+// nextRenderer is the renderer after cur that can show value, wrapping round.
+func nextRenderer(cur Renderer, key, keyType, value string) Renderer {
+	at := 0
+	for i, r := range renderers {
+		if r.Name() == cur.Name() {
+			at = i
+		}
+	}
+	for step := 1; step < len(renderers); step++ {
+		if r := renderers[(at+step)%len(renderers)]; r.CanRender(key, keyType, value) {
+			return r
+		}
+	}
+	return cur
+}
+
+// withRenderer returns r showing value, as stored at the active key, through
+// the renderer the config picks for it.
+func (m Model) withRenderer(r Result, value string) Result {
+	if r.Type == "nil" {
+		return r
+	}
+	r.Stored = value
+	r.Renderer = pickRenderer(m.Config.Renderers, m.ActiveKey, m.Browser.ActiveKeyType, value)
+	return r
+}
+
+type plainRenderer struct{}
+
+func (plainRenderer) Name() string { return "plain" }
+
+func (plainRenderer) CanRender(_, _, value string) bool {
+	if !utf8.ValidString(value) {
+		return false
+	}
+	for _, c := range value {
+		if unicode.IsControl(c) && c != '\n' && c != '\r' && c != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+func (plainRenderer) Render(value string) string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen)).Render(value)
+}
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) Name() string { return "json" }
+
+func (jsonRenderer) CanRender(_, _, value string) bool {
+	trimmed := strings.TrimSpace(value)
+	return trimmed != "" && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid([]byte(trimmed))
+}
+
+func (jsonRenderer) Render(value string) string {
+	return colorizeJSON(tryPrettyJSON(value))
+}
+
+type hexRenderer struct{}
+
+func (hexRenderer) Name() string { return "hex" }
+
+func (hexRenderer) CanRender(_, _, _ string) bool { return true }
+
+func (hexRenderer) Render(value string) string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle)).Render(strings.TrimSuffix(hex.Dump([]byte(value)), "\n"))
+}
+
+// tableRenderer lays out a JSON object as field/value rows, or an array of
+// objects as one row per element with a column per field.
+type tableRenderer struct{}
+
+func (tableRenderer) Name() string { return "table" }
+
+func (tableRenderer) CanRender(_, _, value string) bool {
+	_, _, ok := tableRows(value)
+	return ok
+}
+
+func (tableRenderer) Render(value string) string {
+	header, rows, ok := tableRows(value)
+	if !ok {
+		return plainRenderer{}.Render(value)
+	}
+	return tableText(header, rows)
+}
+
+// tableText lays out rows in aligned columns under a bold header.
+func tableText(header []string, rows [][]string) string {
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+	line := func(row []string, style lipgloss.Style) string {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = style.Render(cell + strings.Repeat(" ", widths[i]-lipgloss.Width(cell)))
+		}
+		return strings.TrimRight(strings.Join(cells, "  "), " ")
+	}
+	lines := []string{line(header, lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true))}
+	for _, row := range rows {
+		lines = append(lines, line(row, lipgloss.NewStyle().Foreground(lipgloss.Color(tnGreen))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tableRows splits a JSON object or array of objects into a header and rows.
+func tableRows(value string) (header []string, rows [][]string, ok bool) {
+	var object map[string]json.RawMessage
+	if json.Unmarshal([]byte(value), &object) == nil {
+		for _, name := range sortedNames(object) {
+			rows = append(rows, []string{name, tableCell(object[name])})
+		}
+		return []string{"field", "value"}, rows, len(rows) > 0
+	}
+	var objects []map[string]json.RawMessage
+	if json.Unmarshal([]byte(value), &objects) != nil || len(objects) == 0 {
+		return nil, nil, false
+	}
+	seen := map[string]bool{}
+	for _, o := range objects {
+		for _, name := range sortedNames(o) {
+			if !seen[name] {
+				seen[name] = true
+				header = append(header, name)
+			}
+		}
+	}
+	for _, o := range objects {
+		row := make([]string, len(header))
+		for i, name := range header {
+			if v, ok := o[name]; ok {
+				row[i] = tableCell(v)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return header, rows, len(header) > 0
+}
+
+func sortedNames(o map[string]json.RawMessage) []string {
+	names := make([]string, 0, len(o))
+	for name := range o {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tableCell is a JSON value in one cell: strings unquoted, the rest compact.
+func tableCell(v json.RawMessage) string {
+	var s string
+	if json.Unmarshal(v, &s) == nil {
+		return strings.ReplaceAll(s, "\n", " ")
+	}
+	var buf bytes.Buffer
+	if json.Compact(&buf, v) != nil {
+		return string(v)
+	}
+	return buf.String()
+}
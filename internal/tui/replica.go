@@ -0,0 +1,199 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// A profile's "replica" names a replica of its server, or is "auto" to use
+// the first one the primary lists online in INFO replication. While it's
+// connected, what the key browser reads (SCAN, TYPE, TTL, GET, HSCAN,
+// LRANGE, …) goes there and stays off the primary; writes, the console and
+// the tools still use the primary. The replica shares the primary's
+// credentials, TLS settings and database, and when the primary is a cluster
+// node it is sent READONLY, without which a cluster replica redirects reads
+// to its master. A replica that can't be found or reached leaves reads on
+// the primary, with a toast saying so.
+
+const (
+	// replicaLag is how long reads stay on the primary after a write, so the
+	// browser doesn't show the replica's copy from before it.
+	replicaLag = 2 * time.Second
+	// replicaAuto as the replica address finds one through the primary.
+	replicaAuto = "auto"
+)
+
+// ReplicaFoundMsg is what the primary, Conn, said about its replicas: the
+// one to read from ("" when "auto" found none online), and whether it is a
+// cluster node.
+type ReplicaFoundMsg struct {
+	Conn    net.Conn
+	Addr    string
+	Cluster bool
+	Error   error
+}
+
+// ReplicaConnMsg delivers the connection to the replica at Addr.
+type ReplicaConnMsg struct {
+	Addr   string
+	Conn   net.Conn
+	Reader *bufio.Reader
+	Error  error
+}
+
+// replicaAddr is the replica to read from: the profile's, or the one found
+// for "auto" ("" until then).
+func (m Model) replicaAddr() string {
+	if m.ReplicaAddress == replicaAuto {
+		return m.ReplicaFound
+	}
+	return m.ReplicaAddress
+}
+
+// replicaModel is m pointed at its replica, for opening connections there.
+// The profile's on_connect lines are for the primary and aren't sent.
+func (m Model) replicaModel() Model {
+	e := m.endpoint()
+	e.Address = m.replicaAddr()
+	r := m.withEndpoint(e)
+	r.OnConnect = nil
+	return r
+}
+
+// findReplica asks the primary, over its own connection, whether it is a
+// cluster node and which of its replicas are online.
+func findReplica(conn net.Conn, reader *bufio.Reader, configured string) tea.Cmd {
+	return func() tea.Msg {
+		msg := ReplicaFoundMsg{Conn: conn, Addr: configured}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{
+			{Name: "INFO", Args: []string{"cluster"}},
+			{Name: "INFO", Args: []string{"replication"}},
+		})
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		cluster, _ := replies[0].(string)
+		msg.Cluster = parseInfo(cluster)["cluster_enabled"] == "1"
+		if configured == replicaAuto {
+			replication, _ := replies[1].(string)
+			msg.Addr = onlineReplica(parseInfo(replication))
+		}
+		return msg
+	}
+}
+
+// onlineReplica is the first replica INFO replication lists as online
+// (slave0:ip=10.0.0.8,port=6379,state=online,…), or "".
+func onlineReplica(info map[string]string) string {
+	for i := 0; ; i++ {
+		line, ok := info["slave"+strconv.Itoa(i)]
+		if !ok {
+			return ""
+		}
+		fields := map[string]string{}
+		for _, kv := range strings.Split(line, ",") {
+			k, v, _ := strings.Cut(kv, "=")
+			fields[k] = v
+		}
+		if fields["state"] == "online" && fields["ip"] != "" && fields["port"] != "" {
+			return net.JoinHostPort(fields["ip"], fields["port"])
+		}
+	}
+}
+
+func handleReplicaFound(m Model, msg ReplicaFoundMsg) (tea.Model, tea.Cmd) {
+	if msg.Conn != m.Conn || m.ReplicaAddress == "" || m.ReplicaConn != nil {
+		return m, nil // the session moved on meanwhile
+	}
+	switch {
+	case msg.Error != nil:
+		return m, m.notify("Could not look for a replica, reading from the primary: "+msg.Error.Error(), true)
+	case msg.Addr == "":
+		return m, m.notify("No replica online, reading from the primary", true)
+	}
+	if m.ReplicaAddress == replicaAuto {
+		m.ReplicaFound = msg.Addr
+	}
+	m.ReplicaCluster = msg.Cluster
+	return m, dialReplica(m)
+}
+
+func dialReplica(m Model) tea.Cmd {
+	addr, cluster := m.replicaAddr(), m.ReplicaCluster
+	return func() tea.Msg {
+		conn, reader, _, err := openConnection(m.replicaModel())
+		if err == nil && cluster {
+			var replies []any
+			if replies, err = pipeline(conn, reader, []redis.RedisCmd{{Name: "READONLY"}}); err == nil {
+				if s, ok := replies[0].(string); ok && redis.IsErrorReply(s) {
+					err = fmt.Errorf("READONLY: %s", s)
+				}
+			}
+			if err != nil {
+				_ = conn.Close()
+				conn, reader = nil, nil
+			}
+		}
+		return ReplicaConnMsg{Addr: addr, Conn: conn, Reader: reader, Error: err}
+	}
+}
+
+func handleReplicaConn(m Model, msg ReplicaConnMsg) (tea.Model, tea.Cmd) {
+	if msg.Addr != m.replicaAddr() || m.ReplicaConn != nil {
+		if msg.Conn != nil {
+			_ = msg.Conn.Close() // the session moved on meanwhile
+		}
+		return m, nil
+	}
+	if msg.Error != nil {
+		return m, m.notify("Replica "+msg.Addr+" unreachable, reading from the primary: "+msg.Error.Error(), true)
+	}
+	m.ReplicaConn, m.ReplicaReader = msg.Conn, msg.Reader
+	return m, nil
+}
+
+// closeReplica drops the replica connection; the next (re)connect of the
+// primary dials it again.
+func (m *Model) closeReplica() {
+	if m.ReplicaConn != nil {
+		_ = m.ReplicaConn.Close()
+	}
+	m.ReplicaConn, m.ReplicaReader = nil, nil
+}
+
+// readsFromReplica reports whether the browser's reads go to the replica:
+// it's connected and nothing was written in the last replicaLag.
+func (m Model) readsFromReplica() bool {
+	return m.ReplicaConn != nil && time.Since(m.WroteAt) >= replicaLag
+}
+
+// readConn is the connection the browser's reads go over.
+func (m Model) readConn() (net.Conn, *bufio.Reader) {
+	if m.readsFromReplica() {
+		return m.ReplicaConn, m.ReplicaReader
+	}
+	return m.Conn, m.Reader
+}
+
+// readModel is m as the side connections that read (streaming a large
+// value) should dial it.
+func (m Model) readModel() Model {
+	if m.readsFromReplica() {
+		return m.replicaModel()
+	}
+	return m
+}
+
+// sendRead sends a read-only command over readConn.
+func (m Model) sendRead(cmd redis.RedisCmd) tea.Cmd {
+	conn, reader := m.readConn()
+	return sendRedisCmd(conn, reader, cmd, m.ReadTimeout)
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Opening a string, hash or set replays its reply from the last cacheFresh
+// when the same command was answered then, so stepping in and out of keys
+// doesn't repeat GET, HKEYS and the first SSCAN page. Writes made through
+// the browser drop the key's entries; refresh, the console and any menu
+// command drop them all, since they can change anything.
+
+const (
+	cacheFresh = 30 * time.Second
+	cacheMax   = 128 // entries kept before the cache starts over
+)
+
+// cachedReply is a reply to a command on Key, kept as the message that
+// delivered it.
+type cachedReply struct {
+	Key string
+	Msg tea.Msg
+	At  time.Time
+}
+
+// fromCache replays the cached reply to cmd, or returns nil when there is
+// none recent enough.
+func (m Model) fromCache(cmd redis.RedisCmd) tea.Cmd {
+	c, ok := m.Browser.Cache[formatCommand(cmd)]
+	age := time.Since(c.At)
+	if !ok || age >= cacheFresh {
+		return nil
+	}
+	msg := c.Msg
+	switch r := msg.(type) {
+	case StringInspectMsg:
+		// The TTL has run down since, and our GET was the last access.
+		if r.TTL > 0 {
+			r.TTL -= int(age.Seconds())
+			if r.TTL <= 0 {
+				return nil
+			}
+		}
+		if r.Idle >= 0 {
+			r.Idle = int(age.Seconds())
+		}
+		r.Latency = 0
+		msg = r
+	case RedisResultMsg:
+		r.Latency = 0
+		msg = r
+	}
+	return func() tea.Msg { return msg }
+}
+
+// remember caches msg, the reply to command on key. A fresh entry is kept
+// as it is: msg is then its own replay, and re-stamping it would keep it
+// fresh forever.
+func (m *Model) remember(key, command string, msg tea.Msg) {
+	if c, ok := m.Browser.Cache[command]; ok && time.Since(c.At) < cacheFresh {
+		return
+	}
+	cache := make(map[string]cachedReply, len(m.Browser.Cache)+1)
+	if len(m.Browser.Cache) < cacheMax {
+		// A copy: tabs cloned from this session share the map.
+		for k, v := range m.Browser.Cache {
+			cache[k] = v
+		}
+	}
+	cache[command] = cachedReply{Key: key, Msg: msg, At: time.Now()}
+	m.Browser.Cache = cache
+}
+
+// invalidate drops what's known about key, prefetched or cached, after it
+// changed, and keeps reads off the replica until it has caught up.
+func (m *Model) invalidate(key string) {
+	m.WroteAt = time.Now()
+	m.forgetMeta(key)
+	stale := false
+	for _, c := range m.Browser.Cache {
+		if c.Key == key {
+			stale = true
+			break
+		}
+	}
+	if !stale {
+		return
+	}
+	cache := make(map[string]cachedReply, len(m.Browser.Cache))
+	for k, c := range m.Browser.Cache {
+		if c.Key != key {
+			cache[k] = c
+		}
+	}
+	m.Browser.Cache = cache
+}
@@ -0,0 +1,346 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// "w" on the output screen and in the browser, and Ctrl+S in the console,
+// save what's on screen to a file: JSON, CSV when it's a flat list or hash,
+// or plain text, by the extension given. An existing file is only replaced
+// when the same name is submitted twice.
+
+// ResultExport is what's on screen, captured when the save prompt opens.
+type ResultExport struct {
+	Op      Op  // SelectedOp to go back to once the prompt closes
+	JSON    any // the reply as JSON
+	Header  []string
+	Rows    [][]string // CSV rows under Header; nil when the reply isn't flat
+	Text    string
+	Image   *ImageValue // a stored image, written decoded under an image extension
+	Confirm string      // the existing file the user was warned about
+}
+
+// ResultSavedMsg reports a finished save.
+type ResultSavedMsg struct {
+	Path  string
+	Rows  int // CSV rows written; 0 for JSON and text
+	Size  int
+	Error error
+}
+
+// replyJSON converts a reply to the value it stands for in JSON: arrays as
+// arrays, nil as null, error replies as {"error": …}.
+func replyJSON(v any) any {
+	switch r := v.(type) {
+	case []any:
+		out := make([]any, len(r))
+		for i, item := range r {
+			out[i] = replyJSON(item)
+		}
+		return out
+	case string:
+		switch {
+		case r == "(nil)":
+			return nil
+		case redis.IsErrorReply(r):
+			return map[string]string{"error": r}
+		}
+	}
+	return v
+}
+
+// pairHeader names the columns of a reply that alternates names and values
+// (HGETALL, CONFIG GET, … WITHSCORES), or returns nil for any other command.
+func pairHeader(command string) []string {
+	args, err := splitCommandLine(command)
+	if err != nil || len(args) == 0 {
+		return nil
+	}
+	switch strings.ToUpper(args[0]) {
+	case "HGETALL":
+		return []string{"field", "value"}
+	case "CONFIG":
+		if len(args) > 1 && strings.EqualFold(args[1], "GET") {
+			return []string{"parameter", "value"}
+		}
+	}
+	for _, a := range args[1:] {
+		switch strings.ToUpper(a) {
+		case "WITHSCORES":
+			return []string{"member", "score"}
+		case "WITHVALUES":
+			return []string{"field", "value"}
+		}
+	}
+	return nil
+}
+
+// flatRows lays out an array of plain values as CSV rows: two columns for a
+// paired reply, one otherwise. ok is false for anything nested or not an
+// array.
+func flatRows(reply any, command string) (header []string, rows [][]string, ok bool) {
+	items, isArray := reply.([]any)
+	if !isArray {
+		return nil, nil, false
+	}
+	cells := make([]string, len(items))
+	rows = [][]string{} // an empty list is still flat
+	for i, item := range items {
+		switch v := item.(type) {
+		case string:
+			if v != "(nil)" {
+				cells[i] = v
+			}
+		case int:
+			cells[i] = strconv.Itoa(v)
+		default:
+			return nil, nil, false
+		}
+	}
+	if header = pairHeader(command); header != nil && len(cells)%2 == 0 {
+		for i := 0; i < len(cells); i += 2 {
+			rows = append(rows, cells[i:i+2])
+		}
+		return header, rows, true
+	}
+	for _, c := range cells {
+		rows = append(rows, []string{c})
+	}
+	return []string{"value"}, rows, true
+}
+
+// replyExport captures a reply to command, shown as text.
+func replyExport(reply any, command, text string) ResultExport {
+	e := ResultExport{JSON: replyJSON(reply), Text: text}
+	e.Header, e.Rows, _ = flatRows(reply, command)
+	return e
+}
+
+// outputExport captures the output screen's result.
+func (m Model) outputExport() ResultExport {
+	r := m.Result
+	var e ResultExport
+	switch {
+	case r.Err != "":
+		return ResultExport{JSON: map[string]string{"error": r.Err}, Text: r.Err}
+	case r.Reply != nil:
+		e = replyExport(r.Reply, r.Command, r.Text())
+	default:
+		e = ResultExport{JSON: r.Value, Text: r.Value}
+	}
+	if img, ok := detectImage(r.Stored); ok && r.Renderer != nil {
+		e.Image = &img
+	}
+	return e
+}
+
+// browserExport captures the rows loaded in the browser: the keys with
+// their type and TTL, or the open key's fields, elements or members.
+func (m Model) browserExport() ResultExport {
+	b := m.Browser
+	var e ResultExport
+	if !b.ViewingFields {
+		e.Header = []string{"key", "type", "ttl"}
+		var keys []any
+		for _, it := range b.KeyList.Items() {
+			li, ok := it.(ListItem)
+			if !ok || li.action != "" {
+				continue
+			}
+			e.Rows = append(e.Rows, []string{li.title, li.desc, strconv.Itoa(li.ttl)})
+			keys = append(keys, map[string]any{"key": li.title, "type": li.desc, "ttl": li.ttl})
+		}
+		e.JSON = keys
+	} else {
+		for _, it := range b.FieldsList.Items() {
+			li, ok := asListItem(it)
+			if !ok || li.action != "" {
+				continue
+			}
+			switch b.ActiveKeyType {
+			case "list":
+				e.Rows = append(e.Rows, []string{strconv.Itoa(li.index), li.title})
+			case "zset":
+				e.Rows = append(e.Rows, []string{li.title, li.score})
+			default:
+				e.Rows = append(e.Rows, []string{li.title})
+			}
+		}
+		switch b.ActiveKeyType {
+		case "list":
+			e.Header = []string{"index", "element"}
+		case "zset":
+			e.Header = []string{"member", "score"}
+		case "hash":
+			e.Header = []string{"field"}
+		default:
+			e.Header = []string{"member"}
+		}
+		var items []any
+		for _, row := range e.Rows {
+			obj := map[string]any{}
+			for i, col := range e.Header {
+				obj[col] = row[i]
+			}
+			items = append(items, obj)
+		}
+		e.JSON = items
+	}
+	if e.JSON == nil {
+		e.JSON = []any{}
+	}
+	lines := make([]string, len(e.Rows))
+	for i, row := range e.Rows {
+		lines[i] = strings.Join(row, "\t")
+	}
+	e.Text = strings.Join(lines, "\n")
+	return e
+}
+
+// startResultExport opens the save prompt for e, suggesting name.
+func (m Model) startResultExport(e ResultExport, name string) (tea.Model, tea.Cmd) {
+	e.Op = m.SelectedOp
+	m.Export = e
+	m.SelectedOp = OpExportResult
+	m.pushState(m.CurrentState)
+	ext, hint := ".json", exportHint
+	if e.Image != nil {
+		ext, hint = e.Image.Ext(), "Save as ("+e.Image.Ext()+" for the decoded image, .json, or any other name as text):"
+	}
+	m.Input.Input.SetValue("./" + name + "-" + time.Now().Format("20060102-150405") + ext)
+	m.Input.Input.CursorEnd()
+	m.Input.Input.Focus()
+	m.Input.Type = InputFilePath
+	m.Input.Hint = hint
+	m.CurrentState = StateInputFilePath
+	return m, nil
+}
+
+const exportHint = "Save as (.json, .csv for flat lists, any other name as text):"
+
+// submitResultExport checks the chosen name before writing: CSV only for
+// flat replies, and a second Enter before replacing an existing file.
+func submitResultExport(m Model, filePath string) (tea.Model, tea.Cmd) {
+	path, err := resolveFilePath(filePath, false, "redis-result.json")
+	if err != nil {
+		m.Input.Hint = err.Error()
+		return m, nil
+	}
+	if exportFormat(path) == "csv" && m.Export.Rows == nil {
+		m.Input.Hint = "Only a flat list or hash can be saved as CSV; use .json or .txt:"
+		return m, nil
+	}
+	if exportFormat(path) == "image" && m.Export.Image == nil {
+		m.Input.Hint = "Only an image value can be saved as an image; use .json or .txt:"
+		return m, nil
+	}
+	overwrite := m.Export.Confirm == path
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		m.Export.Confirm = path
+		m.Input.Hint = path + " exists. Enter again to replace it, or change the name:"
+		return m, nil
+	}
+	m.SelectedOp = m.Export.Op
+	m.CurrentState = m.popState()
+	return m, writeResultExport(m.Export, path, overwrite)
+}
+
+// csvText renders rows under header as CSV.
+func csvText(header []string, rows [][]string) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(header)
+	_ = w.WriteAll(rows)
+	return buf.String()
+}
+
+// exportFormat is how path is written, by its extension.
+func exportFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".csv":
+		return "csv"
+	case ".png", ".jpg", ".jpeg", ".gif":
+		return "image"
+	}
+	return "text"
+}
+
+// writeResultExport writes e to path. Without overwrite the file must not
+// exist, in case one appeared since the prompt checked.
+func writeResultExport(e ResultExport, path string, overwrite bool) tea.Cmd {
+	return func() tea.Msg {
+		var data []byte
+		rows := 0
+		switch exportFormat(path) {
+		case "json":
+			b, err := json.MarshalIndent(e.JSON, "", "  ")
+			if err != nil {
+				return ResultSavedMsg{Path: path, Error: err}
+			}
+			data = append(b, '\n')
+		case "csv":
+			data, rows = []byte(csvText(e.Header, e.Rows)), len(e.Rows)
+		case "image":
+			data = e.Image.Data
+		default:
+			data = []byte(e.Text + "\n")
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return ResultSavedMsg{Path: path, Error: err}
+		}
+		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if !overwrite {
+			flags |= os.O_EXCL
+		}
+		f, err := os.OpenFile(path, flags, 0600)
+		if err != nil {
+			if errors.Is(err, os.ErrExist) {
+				err = fmt.Errorf("%s already exists", path)
+			}
+			return ResultSavedMsg{Path: path, Error: err}
+		}
+		_, err = f.Write(data)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return ResultSavedMsg{Path: path, Rows: rows, Size: len(data), Error: err}
+	}
+}
+
+// handleResultSaved reports a save where it was asked for: the status line
+// on the output screen, a line in the console, or a toast over a list.
+func handleResultSaved(m Model, msg ResultSavedMsg) (tea.Model, tea.Cmd) {
+	text := "Saved " + msg.Path + " (" + formatBytes(msg.Size) + ")"
+	if msg.Rows > 0 {
+		text = fmt.Sprintf("Saved %d rows to %s (%s)", msg.Rows, msg.Path, formatBytes(msg.Size))
+	}
+	if msg.Error != nil {
+		text = "Save failed: " + msg.Error.Error()
+	}
+	switch m.CurrentState {
+	case StateOutput:
+		m.CopyStatus = text
+		return m, func() tea.Msg {
+			time.Sleep(2 * time.Second)
+			return ClearCopyStatusMsg{}
+		}
+	case StateConsole:
+		m.Console.Log = append(m.Console.Log, ConsoleEntry{Reply: text, Failed: msg.Error != nil})
+		return m, nil
+	}
+	return m, m.notify(text, msg.Error != nil)
+}
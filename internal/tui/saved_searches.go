@@ -0,0 +1,252 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Saved searches are named key patterns with an optional list filter
+// ("prod sessions": session:* filtered to "eu-"), kept per profile in a JSON
+// file next to the config like console history. `s` in the key list opens
+// them in a popup; choosing one scans its pattern and applies its filter
+// once the keys land.
+
+// SavedSearch is one named pattern and filter.
+type SavedSearch struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+	Filter  string `json:"filter,omitempty"`
+}
+
+// SavedSearchesModel is the popup of this profile's saved searches.
+type SavedSearchesModel struct {
+	Path     string
+	Searches []SavedSearch
+	Loaded   bool
+	Cursor   int
+	// Filter is the chosen search's filter, applied when its scan lands.
+	Filter string
+}
+
+// SavedSearchesRequestMsg asks for the saved searches popup.
+type SavedSearchesRequestMsg struct{}
+
+// SavedSearchesMsg delivers the searches read from Path.
+type SavedSearchesMsg struct {
+	Path     string
+	Searches []SavedSearch
+	Err      error
+}
+
+// SavedSearchesWrittenMsg reports writing the file: Done on success.
+type SavedSearchesWrittenMsg struct {
+	Done string
+	Err  error
+}
+
+// DefaultSearchesDir is where saved searches live.
+func DefaultSearchesDir() string {
+	cfg := DefaultConfigPath()
+	if cfg == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(cfg), "searches")
+}
+
+// loadSavedSearches reads path. A missing file is no searches yet.
+func loadSavedSearches(path string) tea.Cmd {
+	return func() tea.Msg {
+		msg := SavedSearchesMsg{Path: path}
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return msg
+		}
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		if err := json.Unmarshal(data, &msg.Searches); err != nil {
+			msg.Err = fmt.Errorf("parse %s: %w", path, err)
+		}
+		return msg
+	}
+}
+
+// writeSavedSearches replaces path with searches, reporting done.
+func writeSavedSearches(path string, searches []SavedSearch, done string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := json.MarshalIndent(searches, "", "  ")
+		if err == nil {
+			err = os.MkdirAll(filepath.Dir(path), 0700)
+		}
+		if err == nil {
+			err = os.WriteFile(path, append(data, '\n'), 0600)
+		}
+		return SavedSearchesWrittenMsg{Done: done, Err: err}
+	}
+}
+
+func (m Model) startSavedSearches() (tea.Model, tea.Cmd) {
+	path := m.profileFile(m.SearchesDir, ".json")
+	if path == "" {
+		return m, m.notify("No config directory to keep saved searches in", true)
+	}
+	m.Saved = SavedSearchesModel{Path: path}
+	m.pushState(m.CurrentState)
+	m.CurrentState = StateSavedSearches
+	return m, loadSavedSearches(path)
+}
+
+func handleSavedSearches(m Model, msg SavedSearchesMsg) (tea.Model, tea.Cmd) {
+	s := &m.Saved
+	if msg.Path != s.Path {
+		return m, nil
+	}
+	s.Loaded = true
+	if msg.Err != nil {
+		return m, m.notify("Could not read saved searches: "+msg.Err.Error(), true)
+	}
+	s.Searches = msg.Searches
+	return m, nil
+}
+
+func handleSavedSearchesWritten(m Model, msg SavedSearchesWrittenMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.notify("Could not write saved searches: "+msg.Err.Error(), true)
+	}
+	return m, m.notify(msg.Done, false)
+}
+
+func handleStateSavedSearchesKey(m Model, keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := &m.Saved
+	switch keyMsg.String() {
+	case "esc", "q":
+		m.SelectedOp = OpExplore
+		m.CurrentState = m.popState()
+	case "up", "k":
+		s.Cursor = max(s.Cursor-1, 0)
+	case "down", "j":
+		s.Cursor = min(s.Cursor+1, max(len(s.Searches)-1, 0))
+	case "enter":
+		if s.Cursor < len(s.Searches) {
+			return m.applySavedSearch(s.Searches[s.Cursor])
+		}
+	case "s":
+		if !s.Loaded {
+			break // saving now would drop the searches still being read
+		}
+		pattern := m.Browser.Pattern
+		if pattern == "" {
+			pattern = "*"
+		}
+		m.Form = NewForm(
+			"Save search · "+m.profileLabel(),
+			[]string{"name", "key pattern", "filter (blank for none)"},
+			[]string{"", pattern, m.Browser.KeyList.FilterValue()},
+		)
+		m.Form.Width, m.Form.Height = m.WindowWidth, m.WindowHeight
+		m.SelectedOp = OpSaveSearch
+		m.pushState(StateSavedSearches)
+		m.CurrentState = StateForm
+	case "d":
+		if s.Cursor < len(s.Searches) {
+			name := s.Searches[s.Cursor].Name
+			s.Searches = append(s.Searches[:s.Cursor:s.Cursor], s.Searches[s.Cursor+1:]...)
+			s.Cursor = min(s.Cursor, max(len(s.Searches)-1, 0))
+			return m, writeSavedSearches(s.Path, s.Searches, "Deleted saved search "+name)
+		}
+	}
+	return m, nil
+}
+
+// submitSaveSearch adds the search, or replaces the one of the same name.
+func submitSaveSearch(m Model, values []string) (tea.Model, tea.Cmd) {
+	search := SavedSearch{Name: values[0], Pattern: values[1], Filter: values[2]}
+	if search.Name == "" {
+		m.Form.Err = "enter a name"
+		return m, nil
+	}
+	if search.Pattern == "" {
+		m.Form.Err = "enter a key pattern (* for every key)"
+		return m, nil
+	}
+	m.Form.Err = ""
+	s := &m.Saved
+	s.Cursor = len(s.Searches)
+	for i, old := range s.Searches {
+		if old.Name == search.Name {
+			s.Cursor = i
+		}
+	}
+	if s.Cursor == len(s.Searches) {
+		s.Searches = append(s.Searches, search)
+	} else {
+		s.Searches[s.Cursor] = search
+	}
+	m.SelectedOp = OpExplore
+	m.CurrentState = m.popState()
+	return m, writeSavedSearches(s.Path, s.Searches, "Saved search "+search.Name)
+}
+
+// applySavedSearch lists the keys matching search's pattern, the way a
+// pattern typed into EXPLORE does.
+func (m Model) applySavedSearch(search SavedSearch) (tea.Model, tea.Cmd) {
+	m.CurrentState = m.popState()
+	m.SelectedOp = OpExplore
+	m.Saved.Filter = search.Filter
+	m.LastPattern = search.Pattern
+	m.Input.RecentPatterns = prependPattern(m.Input.RecentPatterns, search.Pattern)
+	m.Browser.Cursor = "0"
+	m.Browser.Pattern = search.Pattern
+	return m.switchToLoadingAndExecute(m.startKeyScan(search.Pattern, "0", ""))
+}
+
+// profileLabel names this session's profile, or its address without one.
+func (m Model) profileLabel() string {
+	if m.ProfileName != "" {
+		return m.ProfileName
+	}
+	return m.RedisAddress
+}
+
+// savedSearchesView draws the popup over the key list.
+func (m Model) savedSearchesView() string {
+	frame := m.headerView() + "\n" + m.Browser.View()
+
+	s := m.Saved
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent))
+	rows := []string{accent.Bold(true).Render("Saved searches · " + truncateText(m.profileLabel(), 30)), ""}
+	width := 0
+	for _, search := range s.Searches {
+		width = max(width, min(lipgloss.Width(search.Name), 24))
+	}
+	for i, search := range s.Searches {
+		marker := "  "
+		if i == s.Cursor {
+			marker = accent.Render("▌ ")
+		}
+		what := search.Pattern
+		if search.Filter != "" {
+			what += " / " + search.Filter
+		}
+		name := truncateText(search.Name, 24)
+		rows = append(rows, marker+text.Render(name)+strings.Repeat(" ", width-lipgloss.Width(name)+2)+dim.Render(truncateText(what, 40)))
+	}
+	switch {
+	case !s.Loaded:
+		rows = append(rows, dim.Render("reading…"))
+	case len(s.Searches) == 0:
+		rows = append(rows, dim.Render("none yet: s saves the current pattern and filter"))
+	}
+	rows = append(rows, "", dim.Render("↵ apply · s save current · d delete · esc close"))
+	return m.overlay(frame, strings.Join(rows, "\n"))
+}
@@ -0,0 +1,42 @@
+package tui
+
+import "time"
+
+// SCAN's COUNT is a hint for how many slots the server walks per call. A
+// scan starts at scanCountStart so the first page of a huge keyspace shows at
+// once, then doubles on every page up to the ceiling (-scan-count, or
+// "scan_count" in the config file), so a sparse MATCH over millions of keys
+// doesn't take thousands of round trips. A page slower than scanSlowPage
+// halves it again: the server is busy, and each SCAN blocks it.
+const (
+	DefaultScanCount = 1000
+	scanCountStart   = 50
+	scanCountFloor   = 10 // Redis's own default
+	scanSlowPage     = 100 * time.Millisecond
+)
+
+// scanCountMax is the ceiling the COUNT hint grows to: the -scan-count flag,
+// else the config file's scan_count, else DefaultScanCount.
+func (m Model) scanCountMax() int {
+	switch {
+	case m.ScanCount > 0:
+		return m.ScanCount
+	case m.Config.ScanCount > 0:
+		return m.Config.ScanCount
+	}
+	return DefaultScanCount
+}
+
+// firstScanCount is the hint for the first page of a scan.
+func firstScanCount(ceiling int) int {
+	return min(scanCountStart, ceiling)
+}
+
+// nextScanCount is the hint for the page after one sent with count that took
+// took to answer.
+func nextScanCount(count, ceiling int, took time.Duration) int {
+	if took > scanSlowPage {
+		return max(count/2, min(scanCountFloor, ceiling))
+	}
+	return min(count*2, ceiling)
+}
@@ -0,0 +1,66 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// screen is the handler and view of one state. Each screen keeps its state
+// in its own sub-model (BrowserModel, InputModel, QueuesModel, …) and its
+// handlers beside it, in browser.go, model_input.go, model_<tool>.go and so
+// on; Update and View route to them through the screens table rather than
+// growing a case per state. The browser and confirmations go further:
+// BrowserModel and Dialog have an Update and View of their own, and their
+// handlers here only carry what those return to and from the root model.
+// Messages that aren't keys and belong to no
+// screen, replies above all, are handled in Update before it routes.
+type screen struct {
+	// update takes every message the router passes on, for screens built
+	// on bubbles components that need their blinks and filter messages too;
+	// a screen without one gets key presses only, through key.
+	update func(Model, tea.Msg) (tea.Model, tea.Cmd)
+	key    func(Model, tea.KeyMsg) (tea.Model, tea.Cmd)
+	view   func(Model) string
+}
+
+// screens maps each state to its screen.
+var screens = map[AppState]screen{
+	StateMenu:          {update: updateMenu, view: Model.menuScreenView},
+	StateInputKey:      {update: updateInput, view: Model.inputView},
+	StateInputField:    {update: updateInput, view: Model.inputView},
+	StateInputValue:    {update: updateInput, view: Model.inputView},
+	StateInputFilePath: {update: updateInput, view: Model.inputView},
+	StateOutput:        {key: handleStateOutputKey, view: Model.outputView},
+	StateBrowser:       {update: updateBrowser, view: Model.browserView},
+	StateConfirmation:  {key: handleStateConfirmationKey, view: Model.dialogView},
+	StateLoading:       {key: handleStateLoadingKey, view: Model.loadingView},
+	StateForm:          {update: updateForm, view: Model.formView},
+
+	StateBlocking:      {key: handleStateBlockingKey, view: Model.blockingView},
+	StateQueues:        {key: handleStateQueuesKey, view: Model.queuesView},
+	StateGrowth:        {key: handleStateGrowthKey, view: Model.growthView},
+	StateColdKeys:      {key: handleStateColdKeysKey, view: Model.coldKeysView},
+	StateReplace:       {key: handleStateReplaceKey, view: Model.replaceView},
+	StateSearch:        {key: handleStateSearchKey, view: Model.searchView},
+	StateSavedSearches: {key: handleStateSavedSearchesKey, view: Model.savedSearchesView},
+	StateFlags:         {key: handleStateFlagsKey, view: Model.flagsView},
+	StateLeaderboard:   {key: handleStateLeaderboardKey, view: Model.leaderboardView},
+	StateAudit:         {key: handleStateAuditKey, view: Model.auditView},
+	StateTail:          {key: handleStateTailKey, view: Model.tailView},
+	StateGroups:        {key: handleStateGroupsKey, view: Model.groupsView},
+	StatePending:       {key: handleStatePendingKey, view: Model.pendingView},
+	StateCounters:      {key: handleStateCountersKey, view: Model.countersView},
+	StateDBDiff:        {key: handleStateDBDiffKey, view: Model.dbDiffView},
+	StateSnapshot:      {key: handleStateSnapshotKey, view: Model.snapshotView},
+	StatePersistence:   {key: handleStatePersistenceKey, view: Model.persistenceView},
+	StateCluster:       {key: handleStateClusterKey, view: Model.clusterView},
+	StateConsole:       {key: handleStateConsoleKey, view: Model.consoleView},
+	StateReconnect:     {key: handleStateReconnectKey, view: Model.reconnectView},
+	StateTemplates:     {key: handleStateTemplatesKey, view: Model.templatesView},
+	StateGenerate:      {key: handleStateGenerateKey, view: Model.generateView},
+	StateBenchmark:     {key: handleStateBenchmarkKey, view: Model.benchmarkView},
+	StateTimeline:      {key: handleStateTimelineKey, view: Model.timelineView},
+	StateStream:        {key: handleStateStreamKey, view: Model.streamView},
+	StateTour:          {key: handleStateTourKey, view: Model.tourView},
+	StateMacros:        {key: handleStateMacrosKey, view: Model.macrosView},
+	StateKeyMenu:       {key: handleStateKeyMenuKey, view: Model.keyMenuView},
+	StateCreateKey:     {key: handleStateCreateKeyKey, view: Model.createKeyView},
+	StateBulkTTL:       {key: handleStateBulkTTLKey, view: Model.bulkTTLView},
+}
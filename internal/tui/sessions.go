@@ -0,0 +1,252 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Session stores keep a hash per session. The config's "sessions" rules name
+// the fields holding the user, the expiry and the last activity, and hashes
+// matching one open as a session: those three first, times as dates with how
+// far off they are, then the rest of the fields. i invalidates the session
+// after a confirmation: DEL, then a PUBLISH of the key's name to the rule's
+// channel, when it has one, for apps that cache sessions in memory.
+
+// The field names a session rule falls back to.
+const (
+	defaultSessionUser     = "user_id"
+	defaultSessionExpires  = "expires_at"
+	defaultSessionLastSeen = "last_seen"
+)
+
+// SessionRule marks the hashes under a glob as sessions; the first match
+// applies.
+type SessionRule struct {
+	Keys     string `json:"keys"`
+	User     string `json:"user_field,omitempty"`
+	Expires  string `json:"expires_field,omitempty"`
+	LastSeen string `json:"last_seen_field,omitempty"`
+	// Publish is the channel the key's name is sent to on invalidation.
+	Publish string `json:"publish,omitempty"`
+}
+
+// validate reports what is wrong with a configured rule.
+func (r SessionRule) validate() error {
+	if r.Keys == "" {
+		return fmt.Errorf("every session rule needs keys")
+	}
+	return nil
+}
+
+// fields are the rule's field names, defaults filled in.
+func (r SessionRule) fields() (user, expires, lastSeen string) {
+	user, expires, lastSeen = r.User, r.Expires, r.LastSeen
+	if user == "" {
+		user = defaultSessionUser
+	}
+	if expires == "" {
+		expires = defaultSessionExpires
+	}
+	if lastSeen == "" {
+		lastSeen = defaultSessionLastSeen
+	}
+	return user, expires, lastSeen
+}
+
+// sessionRule is the rule for key, if one matches.
+func (c Config) sessionRule(key string) (SessionRule, bool) {
+	for _, r := range c.Sessions {
+		if redis.GlobMatch(r.Keys, key) {
+			return r, true
+		}
+	}
+	return SessionRule{}, false
+}
+
+// SessionInvalidatedMsg reports an invalidation: whether the key was
+// deleted and, when published, how many clients received its name.
+type SessionInvalidatedMsg struct {
+	Key       string
+	Deleted   bool
+	Channel   string
+	Receivers int
+	Error     error
+}
+
+// openSession reads the active session hash whole, unless it is over the
+// size limit, which opens the field list instead.
+func (m Model) openSession() (tea.Model, tea.Cmd) {
+	m.SelectedOp = OpSession
+	return m.switchToLoadingAndExecute(guardSize(m, "hash", m.sendRead(redis.HGetAll(m.ActiveKey))))
+}
+
+// sessionResult shows an HGETALL reply through the session renderer.
+func sessionResult(r Result, pairs []string, rule SessionRule) Result {
+	r = hashTableResult(r, pairs)
+	r.Renderer = sessionRenderer{rule: rule}
+	return r
+}
+
+func (m Model) startSessionInvalidate() (tea.Model, tea.Cmd) {
+	if m.ReadOnly {
+		return m, m.notify("Invalidating sessions is disabled: this session is read-only", true)
+	}
+	m.pushState(StateOutput)
+	m.ask(m.sessionDialog())
+	return m, nil
+}
+
+// sessionDialog asks before invalidating the active session.
+func (m Model) sessionDialog() Dialog {
+	label := "DEL the session"
+	if rule, _ := m.Config.sessionRule(m.ActiveKey); rule.Publish != "" {
+		label += ", then PUBLISH its name to " + rule.Publish
+	}
+	return Dialog{Title: "invalidate session", Label: label, Value: m.ActiveKey, Destructive: true,
+		Target: m.ActiveKey, Choices: confirmChoice(confirmSessionInvalidate)}
+}
+
+func confirmSessionInvalidate(m Model) (tea.Model, tea.Cmd) {
+	rule, _ := m.Config.sessionRule(m.ActiveKey)
+	return m.switchToLoadingAndExecute(invalidateSession(m.Conn, m.Reader, m.ActiveKey, rule.Publish))
+}
+
+// invalidateSession deletes key and publishes its name to channel, when
+// set, in one pipelined batch.
+func invalidateSession(conn net.Conn, reader *bufio.Reader, key, channel string) tea.Cmd {
+	return func() tea.Msg {
+		msg := SessionInvalidatedMsg{Key: key, Channel: channel}
+		if conn == nil {
+			msg.Error = fmt.Errorf("no connection to Redis")
+			return msg
+		}
+		cmds := []redis.RedisCmd{redis.Del(key)}
+		if channel != "" {
+			cmds = append(cmds, redis.Publish(channel, key))
+		}
+		replies, err := pipeline(conn, reader, cmds)
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		for _, r := range replies {
+			if s, ok := r.(string); ok && redis.IsErrorReply(s) {
+				msg.Error = fmt.Errorf("%s", s)
+				return msg
+			}
+		}
+		n, _ := replies[0].(int)
+		msg.Deleted = n > 0
+		if channel != "" {
+			msg.Receivers, _ = replies[1].(int)
+		}
+		return msg
+	}
+}
+
+// handleSessionInvalidated goes back to where the session was opened from,
+// listing the keys again when that was the browser.
+func handleSessionInvalidated(m Model, msg SessionInvalidatedMsg) (tea.Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.Result = errorResult(msg.Error)
+		m.CurrentState = StateOutput
+		return m, nil
+	}
+	m.invalidate(msg.Key)
+	text := "Invalidated " + msg.Key
+	if !msg.Deleted {
+		text = msg.Key + " was already gone"
+	}
+	if msg.Channel != "" {
+		text += fmt.Sprintf(", published to %s (%d receivers)", msg.Channel, msg.Receivers)
+	}
+	toast := m.notify(text, false)
+	m.Result = Result{}
+	m.ActiveTTL = ""
+
+	m.popState() // the session's output screen, left for the confirmation
+	if prev := m.popState(); prev != StateBrowser {
+		m.CurrentState = prev
+		return m, toast
+	}
+	next, cmd := m.rescanKeys()
+	return next, tea.Batch(cmd, toast)
+}
+
+// sessionRenderer shows a session hash, stored as a JSON object by
+// hashTableResult, with its rule's fields first.
+type sessionRenderer struct{ rule SessionRule }
+
+func (sessionRenderer) Name() string { return "session" }
+
+func (sessionRenderer) CanRender(_, keyType, value string) bool {
+	var fields map[string]string
+	return keyType == "hash" && json.Unmarshal([]byte(value), &fields) == nil
+}
+
+func (r sessionRenderer) Render(value string) string {
+	var fields map[string]string
+	if json.Unmarshal([]byte(value), &fields) != nil {
+		return plainRenderer{}.Render(value)
+	}
+	now := time.Now()
+	user, expires, lastSeen := r.rule.fields()
+	cell := func(name string, when func(string) string) string {
+		v, ok := fields[name]
+		switch {
+		case !ok:
+			return "(no " + name + " field)"
+		case when == nil:
+			return v
+		}
+		return when(v)
+	}
+	highlights := [][]string{
+		{"user", cell(user, nil)},
+		{"expires", cell(expires, func(v string) string { return sessionTime(v, now, true) })},
+		{"last seen", cell(lastSeen, func(v string) string { return sessionTime(v, now, false) })},
+	}
+	var rest [][]string
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		if name != user && name != expires && name != lastSeen {
+			rest = append(rest, []string{name, fields[name]})
+		}
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	out := tableText([]string{"session", "value"}, highlights)
+	if len(rest) > 0 {
+		out += "\n\n" + tableText([]string{"field", "value"}, rest)
+	}
+	return out + "\n\n" + dim.Render(fmt.Sprintf("fields %s, %s and %s per the sessions rule %q · i invalidates", user, expires, lastSeen, r.rule.Keys))
+}
+
+// sessionTime is a session timestamp (unix seconds or milliseconds, or
+// RFC 3339) as its date and how far off it is; anything else as it is.
+func sessionTime(v string, now time.Time, expiry bool) string {
+	t, ok := asTimestamp(v)
+	if !ok {
+		parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(v))
+		if err != nil {
+			return v
+		}
+		t = parsed
+	}
+	when := "in " + formatTTL(int(t.Sub(now).Seconds()))
+	if !t.After(now) {
+		when = formatTTL(int(now.Sub(t).Seconds())) + " ago"
+		if expiry {
+			when = "expired " + when
+		}
+	}
+	return formatDate(t) + " (" + when + ")"
+}
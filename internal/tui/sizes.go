@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// formatBytes renders n in binary units: B, KiB, MiB, GiB or TiB.
+func formatBytes(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	f, suffix := float64(n)/unit, "KiB"
+	for _, s := range []string{"MiB", "GiB", "TiB"} {
+		if f < unit {
+			break
+		}
+		f, suffix = f/unit, s
+	}
+	return fmt.Sprintf("%.1f %s", f, suffix)
+}
+
+// sizeText is n as formatBytes, or as an exact byte count when raw (b in the
+// output view toggles it).
+func sizeText(n int, raw bool) string {
+	if raw {
+		return strconv.Itoa(n) + " B"
+	}
+	return formatBytes(n)
+}
+
+// isInfoByteField reports whether an INFO field counts bytes. The server's
+// own *_human fields are left as they are.
+func isInfoByteField(k string) bool {
+	switch {
+	case strings.HasSuffix(k, "_human"), strings.HasSuffix(k, "_perc"), strings.HasSuffix(k, "_ratio"):
+		return false
+	case strings.HasPrefix(k, "used_memory"), strings.HasPrefix(k, "mem_"), strings.HasPrefix(k, "allocator_"),
+		strings.HasSuffix(k, "_bytes"), strings.HasSuffix(k, "_cow_size"):
+		return true
+	}
+	switch k {
+	case "maxmemory", "total_system_memory", "aof_current_size", "aof_base_size":
+		return true
+	}
+	return false
+}
+
+// humanizeInfo follows every byte count of 1 KiB or more in an INFO reply
+// with its size in binary units, e.g. "used_memory:1048576 (1.0 MiB)".
+func humanizeInfo(info string) string {
+	lines := strings.Split(info, "\n")
+	for i, line := range lines {
+		k, v, ok := strings.Cut(strings.TrimRight(line, "\r"), ":")
+		if !ok || !isInfoByteField(k) {
+			continue
+		}
+		if n, err := strconv.Atoi(v); err == nil && n >= 1024 {
+			lines[i] = k + ":" + v + " (" + formatBytes(n) + ")"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// spawnReadyTimeout bounds how long a spawned server may take to answer PING.
+const spawnReadyTimeout = 5 * time.Second
+
+// SpawnedServer is a redis-server child process started by -spawn, with its
+// own config and working directory under the system temp dir.
+type SpawnedServer struct {
+	Addr string
+	dir  string
+	cmd  *exec.Cmd
+	done chan error // receives the process's exit
+}
+
+// SpawnServer starts binary (a redis-server) on a free loopback port with
+// persistence off, and waits until it answers PING. Stop shuts it down and
+// removes its directory.
+func SpawnServer(binary string) (*SpawnedServer, error) {
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("-spawn needs %s: %w", binary, err)
+	}
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "redis-tui-spawn-")
+	if err != nil {
+		return nil, err
+	}
+	conf := strings.Join([]string{
+		"bind 127.0.0.1",
+		fmt.Sprintf("port %d", port),
+		fmt.Sprintf("dir %q", dir),
+		fmt.Sprintf("logfile %q", filepath.Join(dir, "redis.log")),
+		`save ""`,
+		"appendonly no",
+		"daemonize no",
+	}, "\n") + "\n"
+	confPath := filepath.Join(dir, "redis.conf")
+	if err := os.WriteFile(confPath, []byte(conf), 0600); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	s := &SpawnedServer{
+		Addr: net.JoinHostPort("127.0.0.1", fmt.Sprint(port)),
+		dir:  dir,
+		cmd:  exec.Command(path, confPath),
+		done: make(chan error, 1),
+	}
+	if err := s.cmd.Start(); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	go func() { s.done <- s.cmd.Wait() }()
+
+	if err := s.waitReady(); err != nil {
+		s.Stop()
+		return nil, err
+	}
+	return s, nil
+}
+
+// freePort asks the OS for an unused port. Another process could take it
+// before redis-server binds, in which case the server exits and waitReady
+// reports its log.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+func (s *SpawnedServer) waitReady() error {
+	deadline := time.Now().Add(spawnReadyTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case err := <-s.done:
+			s.done <- err // for Stop
+			return fmt.Errorf("redis-server exited: %v%s", err, s.logTail())
+		default:
+		}
+		if conn, err := net.DialTimeout("tcp", s.Addr, 100*time.Millisecond); err == nil {
+			_ = conn.SetDeadline(time.Now().Add(time.Second))
+			_, err = conn.Write(redis.Ping().ToBytes())
+			var reply any
+			if err == nil {
+				reply, err = redis.ReadResp(bufio.NewReader(conn))
+			}
+			_ = conn.Close()
+			if err == nil && reply == "PONG" {
+				return nil
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return fmt.Errorf("redis-server on %s did not answer PING within %s%s", s.Addr, spawnReadyTimeout, s.logTail())
+}
+
+// logTail returns the last lines of the server's log, for startup errors.
+func (s *SpawnedServer) logTail() string {
+	data, err := os.ReadFile(filepath.Join(s.dir, "redis.log"))
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	return "\n" + strings.Join(lines[max(len(lines)-5, 0):], "\n")
+}
+
+// Stop shuts the server down — politely, then by force — and deletes its
+// directory. Safe to call more than once.
+func (s *SpawnedServer) Stop() {
+	if s.cmd.Process != nil {
+		select {
+		case err := <-s.done:
+			s.done <- err
+		default:
+			if err := s.cmd.Process.Signal(os.Interrupt); err != nil && !errors.Is(err, os.ErrProcessDone) {
+				_ = s.cmd.Process.Kill()
+			}
+			select {
+			case err := <-s.done:
+				s.done <- err
+			case <-time.After(spawnReadyTimeout):
+				_ = s.cmd.Process.Kill()
+				s.done <- <-s.done
+			}
+		}
+	}
+	_ = os.RemoveAll(s.dir)
+}
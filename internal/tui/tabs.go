@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TabsModel is the program's root model: a row of independent sessions, each
+// a full Model with its own connection and navigation stack. With a single tab
+// it is transparent — every message goes straight to that tab.
+type TabsModel struct {
+	Tabs   []Model
+	IDs    []int
+	Active int
+
+	// base is the pristine startup model new tabs are cloned from, so a clone
+	// starts at the menu rather than mid-way through another tab's flow.
+	base   Model
+	nextID int
+}
+
+// tabMsg routes an async result back to the tab whose command produced it.
+// Without it, a reply from a background tab would land on whichever tab
+// happens to be active.
+type tabMsg struct {
+	ID  int
+	Msg tea.Msg
+}
+
+// NewTabs wraps the startup model as the first (and only) tab.
+func NewTabs(m Model) TabsModel {
+	return TabsModel{Tabs: []Model{m}, IDs: []int{0}, base: m, nextID: 1}
+}
+
+// tagCmd wraps cmd so its message comes back addressed to tab id. Batches are
+// unpacked so each inner command is tagged, and Quit passes through untouched.
+func tagCmd(id int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case nil:
+			return nil
+		case tea.QuitMsg:
+			return msg
+		case tea.BatchMsg:
+			out := make(tea.BatchMsg, len(msg))
+			for i, c := range msg {
+				out[i] = tagCmd(id, c)
+			}
+			return out
+		default:
+			return tabMsg{ID: id, Msg: msg}
+		}
+	}
+}
+
+func (t TabsModel) Init() tea.Cmd {
+	return tagCmd(t.IDs[t.Active], t.Tabs[t.Active].Init())
+}
+
+// updateTab forwards msg to tab i and tags whatever it returns.
+func (t TabsModel) updateTab(i int, msg tea.Msg) (TabsModel, tea.Cmd) {
+	next, cmd := t.Tabs[i].Update(msg)
+	if m, ok := next.(Model); ok {
+		t.Tabs[i] = m
+	}
+	return t, tagCmd(t.IDs[i], cmd)
+}
+
+func (t TabsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tabMsg:
+		for i, id := range t.IDs {
+			if id == msg.ID {
+				return t.updateTab(i, msg.Msg)
+			}
+		}
+		return t, nil // tab was closed while its command ran
+
+	case tea.WindowSizeMsg:
+		var cmds []tea.Cmd
+		for i := range t.Tabs {
+			var cmd tea.Cmd
+			t, cmd = t.updateTab(i, msg)
+			cmds = append(cmds, cmd)
+		}
+		return t, tea.Batch(cmds...)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+t":
+			return t.cloneActive()
+		case "ctrl+right":
+			if len(t.Tabs) > 1 {
+				t.Active = (t.Active + 1) % len(t.Tabs)
+				return t, nil
+			}
+		case "ctrl+left":
+			if len(t.Tabs) > 1 {
+				t.Active = (t.Active + len(t.Tabs) - 1) % len(t.Tabs)
+				return t, nil
+			}
+		case "ctrl+w":
+			if len(t.Tabs) > 1 {
+				return t.closeActive(), nil
+			}
+		}
+	}
+	return t.updateTab(t.Active, msg)
+}
+
+// cloneActive opens a new tab on the active tab's server and database, with a
+// connection and navigation state of its own, and switches to it.
+func (t TabsModel) cloneActive() (tea.Model, tea.Cmd) {
+	cur := t.Tabs[t.Active]
+	m := t.base
+	m.RedisAddress = cur.RedisAddress
+	m.Username = cur.Username
+	m.Password = cur.Password
+	m.DB = cur.DB
+	m.ClientName = cur.ClientName
+	m.OnConnect = cur.OnConnect
+	m.ProfileName = cur.ProfileName
+	m.ReadOnly = cur.ReadOnly
+	m.Production = cur.Production
+	m.Inline = cur.Inline
+	m.Proxy = cur.Proxy
+	m.ProxyKeys = cur.ProxyKeys
+	m.ReplicaAddress = cur.ReplicaAddress
+	m.TLSConfig = cur.TLSConfig
+	m.DialTimeout = cur.DialTimeout
+	m.ReadTimeout = cur.ReadTimeout
+	m.StateNavigationHistory = nil
+
+	id := t.nextID
+	t.nextID++
+	t.Tabs = append(t.Tabs, m)
+	t.IDs = append(t.IDs, id)
+	t.Active = len(t.Tabs) - 1
+
+	t, sizeCmd := t.updateTab(t.Active, tea.WindowSizeMsg{Width: cur.WindowWidth, Height: cur.WindowHeight})
+	return t, tea.Batch(sizeCmd, tagCmd(id, t.Tabs[t.Active].Init()))
+}
+
+// closeActive drops the active tab and its connections. Results still in
+// flight for it are discarded by the ID lookup in Update.
+func (t TabsModel) closeActive() TabsModel {
+	m := t.Tabs[t.Active]
+	m.stopBlocking()
+	m.stopTail()
+	m.stopLive()
+	m.stopSnapshot()
+	m.stopGenerate()
+	m.stopBenchmark()
+	m.stopTimeline()
+	m.stopSearch()
+	m.stopStream()
+	m.closeConsole()
+	m.closeReplica()
+	m.stopWorker()
+	if m.Conn != nil {
+		_ = m.Conn.Close()
+	}
+	t.Tabs = append(t.Tabs[:t.Active:t.Active], t.Tabs[t.Active+1:]...)
+	t.IDs = append(t.IDs[:t.Active:t.Active], t.IDs[t.Active+1:]...)
+	if t.Active >= len(t.Tabs) {
+		t.Active = len(t.Tabs) - 1
+	}
+	return t
+}
+
+// tabLabel is how a session is named in the tab bar: its profile when it
+// was opened from one, otherwise the address.
+func (m Model) tabLabel() string {
+	name := m.RedisAddress
+	if m.ProfileName != "" {
+		name = m.ProfileName
+	}
+	return fmt.Sprintf("%s · db%d", name, m.DB)
+}
+
+// tabBar renders the tabs for the header line, the active one highlighted.
+func (t TabsModel) tabBar() string {
+	active := lipgloss.NewStyle().Foreground(lipgloss.Color(tnText)).Background(lipgloss.Color(tnBorder)).Bold(true)
+	idle := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	bar := ""
+	for i, tab := range t.Tabs {
+		label := " " + strconv.Itoa(i+1) + " " + tab.tabLabel() + " "
+		if i == t.Active {
+			bar += active.Render(label)
+		} else {
+			bar += idle.Render(label)
+		}
+	}
+	return bar
+}
+
+func (t TabsModel) View() string {
+	m := t.Tabs[t.Active]
+	if len(t.Tabs) > 1 {
+		m.TabBar = t.tabBar()
+	}
+	return m.View()
+}
@@ -0,0 +1,218 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Telemetry, when -telemetry (or the config's telemetry_seconds) sets an
+// interval, reads INFO's ops/sec, memory and client count every Interval for
+// as long as the session lasts, reconnects included. The header shows a
+// short sparkline of each wherever the user is, and the INFO screen the
+// whole series above the server's reply. It is off by default: every sample
+// is a round trip on the session's connection.
+
+const (
+	telemetryHistory     = 720 // samples kept per series: an hour at 5s
+	telemetryHeaderSpark = 8
+	telemetryNameCol     = 10
+)
+
+// telemetrySample is one INFO reading.
+type telemetrySample struct {
+	At      time.Time
+	Ops     float64 // instantaneous_ops_per_sec
+	Memory  int     // used_memory
+	Clients int     // connected_clients
+}
+
+// TelemetryModel is the session's INFO sampling.
+type TelemetryModel struct {
+	Interval time.Duration // 0 = off
+	Samples  []telemetrySample
+	Err      string
+
+	// Active is set once the tick chain is started, on the first connect;
+	// Gen retires a chain, as in GrowthModel.
+	Active bool
+	Gen    int
+}
+
+// TelemetrySampleMsg carries one sample.
+type TelemetrySampleMsg struct {
+	Gen    int
+	Sample telemetrySample
+	Error  error
+}
+
+// TelemetryTickMsg fires every Interval once telemetry is started.
+type TelemetryTickMsg struct{ Gen int }
+
+func telemetryTick(gen int, every time.Duration) tea.Cmd {
+	return tea.Tick(every, func(time.Time) tea.Msg { return TelemetryTickMsg{Gen: gen} })
+}
+
+// startTelemetry starts the tick chain on the first connect; later connects
+// find it running and keep adding to the same series.
+func (m *Model) startTelemetry() tea.Cmd {
+	t := &m.Telemetry
+	if t.Interval <= 0 || t.Active {
+		return nil
+	}
+	t.Active = true
+	t.Gen++
+	return telemetryTick(t.Gen, t.Interval)
+}
+
+// sampleTelemetry reads the stats, memory and clients sections of INFO in
+// one pipelined batch.
+func sampleTelemetry(conn net.Conn, reader *bufio.Reader, gen int) tea.Cmd {
+	return func() tea.Msg {
+		msg := TelemetrySampleMsg{Gen: gen}
+		if conn == nil {
+			msg.Error = fmt.Errorf("no connection to Redis")
+			return msg
+		}
+		replies, err := pipeline(conn, reader, []redis.RedisCmd{
+			{Name: "INFO", Args: []string{"stats"}},
+			{Name: "INFO", Args: []string{"memory"}},
+			{Name: "INFO", Args: []string{"clients"}},
+		})
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		info := map[string]string{}
+		for _, r := range replies {
+			raw, _ := r.(string)
+			if redis.IsErrorReply(raw) {
+				msg.Error = fmt.Errorf("%s", raw)
+				return msg
+			}
+			for k, v := range parseInfo(raw) {
+				info[k] = v
+			}
+		}
+		msg.Sample.At = time.Now()
+		msg.Sample.Ops, _ = strconv.ParseFloat(info["instantaneous_ops_per_sec"], 64)
+		msg.Sample.Memory, _ = strconv.Atoi(info["used_memory"])
+		msg.Sample.Clients, _ = strconv.Atoi(info["connected_clients"])
+		return msg
+	}
+}
+
+// handleTelemetryTick samples unless the session is between connections,
+// when the chain waits for the next tick.
+func handleTelemetryTick(m Model, msg TelemetryTickMsg) (tea.Model, tea.Cmd) {
+	t := m.Telemetry
+	if !t.Active || msg.Gen != t.Gen {
+		return m, nil
+	}
+	if m.Conn == nil {
+		return m, telemetryTick(t.Gen, t.Interval)
+	}
+	return m, m.exec(sampleTelemetry(m.Conn, m.Reader, t.Gen))
+}
+
+func handleTelemetrySample(m Model, msg TelemetrySampleMsg) (tea.Model, tea.Cmd) {
+	t := &m.Telemetry
+	if !t.Active || msg.Gen != t.Gen {
+		return m, nil
+	}
+	next := telemetryTick(t.Gen, t.Interval)
+	if msg.Error != nil {
+		t.Err = msg.Error.Error()
+		return m, next
+	}
+	t.Err = ""
+	t.Samples = append(t.Samples, msg.Sample)
+	if len(t.Samples) > telemetryHistory {
+		t.Samples = t.Samples[len(t.Samples)-telemetryHistory:]
+	}
+	if m.CurrentState == StateOutput && m.SelectedOp == OpInfo {
+		// As b does: new content, same scroll position.
+		m.Viewport.SetContent(wrapOutput(m.renderOutput(), m.Viewport.Width))
+	}
+	return m, next
+}
+
+// telemetrySeries is one sampled field: its name, every reading, and how to
+// print one.
+type telemetrySeries struct {
+	Name   string
+	Values []float64
+	Format func(float64) string
+}
+
+func (t TelemetryModel) series() []telemetrySeries {
+	ops := make([]float64, len(t.Samples))
+	mem := make([]float64, len(t.Samples))
+	clients := make([]float64, len(t.Samples))
+	for i, s := range t.Samples {
+		ops[i], mem[i], clients[i] = s.Ops, float64(s.Memory), float64(s.Clients)
+	}
+	count := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	return []telemetrySeries{
+		{"ops/sec", ops, count},
+		{"memory", mem, func(v float64) string { return formatBytes(int(v)) }},
+		{"clients", clients, count},
+	}
+}
+
+// telemetryStatus is the header's sparklines, "" before the first sample.
+func (m Model) telemetryStatus() string {
+	if len(m.Telemetry.Samples) == 0 {
+		return ""
+	}
+	spark := lipgloss.NewStyle().Foreground(lipgloss.Color(tnBlue))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	short := map[string]string{"ops/sec": "ops", "memory": "mem", "clients": "cl"}
+	var parts []string
+	for _, s := range m.Telemetry.series() {
+		last := s.Values[len(s.Values)-1]
+		parts = append(parts, dim.Render(short[s.Name]+" ")+spark.Render(sparkline(s.Values, telemetryHeaderSpark))+dim.Render(" "+s.Format(last)))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// telemetryView is the whole series of each field, as wide as width allows,
+// for the top of the INFO screen; "" when telemetry is off.
+func (m Model) telemetryView(width int) string {
+	t := m.Telemetry
+	if t.Interval <= 0 {
+		return ""
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	sub := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	head := fmt.Sprintf("# Telemetry (every %s, this session)", t.Interval)
+	if len(t.Samples) == 0 {
+		return dim.Render(head) + "\n" + sub.Render("no samples yet")
+	}
+	first := t.Samples[0].At
+	lines := []string{dim.Render(head), sub.Render(fmt.Sprintf("%d sample%s since %s", len(t.Samples), plural(len(t.Samples)), first.Format("15:04:05")))}
+	sparkW := width - telemetryNameCol - 40
+	if sparkW < 8 {
+		sparkW = 8
+	}
+	spark := lipgloss.NewStyle().Foreground(lipgloss.Color(tnBlue))
+	for _, s := range t.series() {
+		lo, hi := s.Values[0], s.Values[0]
+		for _, v := range s.Values {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+		stats := fmt.Sprintf("  last %s  min %s  max %s", s.Format(s.Values[len(s.Values)-1]), s.Format(lo), s.Format(hi))
+		lines = append(lines, sub.Render(padCells(s.Name, telemetryNameCol))+spark.Render(sparkline(s.Values, sparkW))+sub.Render(stats))
+	}
+	if t.Err != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color(tnRed)).Render("last sample failed: "+t.Err))
+	}
+	return strings.Join(lines, "\n")
+}
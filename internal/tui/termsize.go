@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Below minWidth×minHeight the header, footer and list rows no longer fit
+// and lists render cut off mid-row, so a notice asking for a bigger window
+// is shown instead. The next WindowSizeMsg redraws the screen underneath,
+// which kept its state.
+const (
+	minWidth  = 60
+	minHeight = 15
+)
+
+// tooSmall reports whether the window is known and below the minimum. Before
+// the first WindowSizeMsg the size is 0×0 and the screens draw at 80×24.
+func (m Model) tooSmall() bool {
+	if m.WindowWidth <= 0 || m.WindowHeight <= 0 {
+		return false
+	}
+	return m.WindowWidth < minWidth || m.WindowHeight < minHeight
+}
+
+func (m Model) tooSmallView() string {
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Bold(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnDim))
+	body := lipgloss.JoinVertical(lipgloss.Center,
+		title.Render("Terminal too small"),
+		"",
+		dim.Render(fmt.Sprintf("%d×%d, needs %d×%d", m.WindowWidth, m.WindowHeight, minWidth, minHeight)),
+		dim.Render("Resize the window to continue"),
+		dim.Render("ctrl+c quits"),
+	)
+	return lipgloss.Place(m.WindowWidth, m.WindowHeight, lipgloss.Center, lipgloss.Center, body)
+}
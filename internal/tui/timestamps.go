@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Numbers read as unix times must land in this window, in seconds or in
+// milliseconds. Counters and IDs mostly fall outside it, and anything that
+// doesn't can be shown raw by turning dates off (t).
+var (
+	timestampMin = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	timestampMax = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+)
+
+// asTimestamp reads s as a unix time in seconds or milliseconds, fractions
+// allowed (sorted-set scores are floats). ok is false for anything else.
+func asTimestamp(s string) (t time.Time, ok bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || len(s) > 20 {
+		return time.Time{}, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	switch {
+	case f >= float64(timestampMin) && f < float64(timestampMax):
+		return time.UnixMilli(int64(f * 1000)), true
+	case f >= float64(timestampMin)*1000 && f < float64(timestampMax)*1000:
+		return time.UnixMilli(int64(f)), true
+	}
+	return time.Time{}, false
+}
+
+// formatDate renders t in the local zone, to the second.
+func formatDate(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04:05 MST")
+}
+
+// dateNote is the human date for s when it looks like a timestamp, or "".
+func dateNote(s string) string {
+	if t, ok := asTimestamp(s); ok {
+		return formatDate(t)
+	}
+	return ""
+}
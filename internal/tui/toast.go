@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Outcomes of background work that need no answer — a reconnect, a finished
+// export, a delete — are reported in a toast at the bottom right of whatever
+// screen is showing, instead of taking the user to the output screen. It
+// clears itself after toastDuration.
+
+const toastDuration = 3 * time.Second
+
+// Toast is the notice being shown. Seq tells its expiry from that of an
+// earlier one it replaced.
+type Toast struct {
+	Text   string
+	Failed bool
+	Seq    int
+}
+
+// ToastExpiredMsg clears toast Seq, if it's still the one shown.
+type ToastExpiredMsg struct{ Seq int }
+
+// notify shows text as a toast and returns the command that clears it.
+func (m *Model) notify(text string, failed bool) tea.Cmd {
+	seq := m.Toast.Seq + 1
+	m.Toast = Toast{Text: text, Failed: failed, Seq: seq}
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg { return ToastExpiredMsg{Seq: seq} })
+}
+
+// withToast draws the toast over the row above the footer, right-aligned,
+// cutting the row underneath short.
+func (m Model) withToast(frame string) string {
+	if m.Toast.Text == "" {
+		return frame
+	}
+	w, h := m.WindowWidth, m.WindowHeight
+	if w <= 0 {
+		w = 80
+	}
+	if h <= 0 {
+		h = 24
+	}
+	mark, color := "✓ ", tnGreen
+	if m.Toast.Failed {
+		mark, color = "✗ ", tnRed
+	}
+	toast := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).
+		Render(ansi.Truncate(mark+m.Toast.Text, w-4, "…"))
+	lines := strings.Split(frame, "\n")
+	for len(lines) < h {
+		lines = append(lines, "")
+	}
+	row := h - 3 // above the footer rule and key hints
+	left := max(w-lipgloss.Width(toast)-2, 0)
+	under := ansi.Truncate(lines[row], left, "")
+	lines[row] = under + strings.Repeat(" ", left-lipgloss.Width(under)) + toast
+	return strings.Join(lines, "\n")
+}
@@ -81,3 +81,56 @@ func ParseRedisURL(rawURL string) (ParsedURL, error) {
 
 	return result, nil
 }
+
+// ConnFlags is the connection the command line describes once a -profile,
+// -demo or -spawn has filled it in. Set names the flags given explicitly.
+type ConnFlags struct {
+	URL      string
+	Host     string
+	Username string
+	Password string
+	DB       int
+	TLS      bool
+	Set      map[string]bool
+}
+
+// ResolveConnFlags applies the environment under the flags, then the URL
+// over both. $REDIS_URL stands in for -url unless -url, -host, -profile,
+// -demo or -spawn picked a server; $REDISCLI_AUTH, as redis-cli reads it,
+// then the older $REDIS_PASSWORD, stand in for -password unless it, a
+// profile or a built-in server supplied one. A password in the URL beats
+// them all. getenv is os.Getenv outside tests.
+func ResolveConnFlags(f ConnFlags, getenv func(string) string) (ConnFlags, error) {
+	picked := func(names ...string) bool {
+		for _, n := range names {
+			if f.Set[n] {
+				return true
+			}
+		}
+		return false
+	}
+	if env := getenv("REDIS_URL"); env != "" && !picked("url", "host", "profile", "demo", "spawn") {
+		f.URL = env
+	}
+	if !picked("password", "profile", "demo", "spawn") {
+		if f.Password = getenv("REDISCLI_AUTH"); f.Password == "" {
+			f.Password = getenv("REDIS_PASSWORD")
+		}
+	}
+	if f.URL == "" {
+		return f, nil
+	}
+	parsed, err := ParseRedisURL(f.URL)
+	if err != nil {
+		return f, err
+	}
+	f.Host, f.DB = parsed.Host, parsed.DB
+	if parsed.Password != "" {
+		f.Password = parsed.Password
+	}
+	if parsed.Username != "" {
+		f.Username = parsed.Username
+	}
+	f.TLS = f.TLS || parsed.TLS
+	return f, nil
+}
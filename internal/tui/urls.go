@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"net/url"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// A value that is a single http(s) URL is shown by the url renderer with
+// its query decoded, one parameter per row, and "o" on the output screen
+// opens it in the browser.
+
+// URLOpenedMsg reports an attempt to open a URL.
+type URLOpenedMsg struct {
+	URL   string
+	Error error
+}
+
+// parseURL reads value as an absolute http or https URL, alone on its line.
+func parseURL(value string) (*url.URL, bool) {
+	s := strings.TrimSpace(value)
+	if s == "" || len(s) > 8<<10 || strings.ContainsAny(s, " \t\r\n") {
+		return nil, false
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, false
+	}
+	return u, true
+}
+
+type urlRenderer struct{}
+
+func (urlRenderer) Name() string { return "url" }
+
+func (urlRenderer) CanRender(_, _, value string) bool {
+	_, ok := parseURL(value)
+	return ok
+}
+
+func (urlRenderer) Render(value string) string {
+	u, ok := parseURL(value)
+	if !ok {
+		return plainRenderer{}.Render(value)
+	}
+	rows := [][]string{{"host", u.Host}}
+	if u.Path != "" {
+		rows = append(rows, []string{"path", u.Path})
+	}
+	query := u.Query()
+	for _, name := range sortedKeys(query) {
+		for _, v := range query[name] {
+			rows = append(rows, []string{"?" + name, v})
+		}
+	}
+	if u.Fragment != "" {
+		rows = append(rows, []string{"#", u.Fragment})
+	}
+	link := lipgloss.NewStyle().Foreground(lipgloss.Color(tnAccent)).Underline(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color(tnSubtle))
+	return link.Render(strings.TrimSpace(value)) + "\n\n" +
+		tableText([]string{"part", "value"}, rows) + "\n\n" +
+		dim.Render("o opens it in the browser · c copies it")
+}
+
+func sortedKeys(values url.Values) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// openURL hands u to the desktop's opener without waiting for the browser.
+func openURL(u string) tea.Cmd {
+	return func() tea.Msg {
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", u)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+		default:
+			cmd = exec.Command("xdg-open", u)
+		}
+		err := cmd.Start()
+		if err == nil {
+			go func() { _ = cmd.Wait() }()
+		}
+		return URLOpenedMsg{URL: u, Error: err}
+	}
+}
+
+// handleURLOpened reports on the output screen's status line. Without an
+// opener (a server over SSH, say) the URL goes to the clipboard instead.
+func handleURLOpened(m Model, msg URLOpenedMsg) (tea.Model, tea.Cmd) {
+	m.CopyStatus = "Opened " + msg.URL
+	if msg.Error != nil {
+		m.CopyStatus = "Couldn't open a browser: " + msg.Error.Error()
+		if clipboard.WriteAll(msg.URL) == nil {
+			m.CopyStatus += "; copied the URL instead"
+		}
+	}
+	return m, func() tea.Msg {
+		time.Sleep(2 * time.Second)
+		return ClearCopyStatusMsg{}
+	}
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// With "vim_keys": true in the config file, the menu and the key browser
+// take vim's keys: j/k move, gg and G jump to the first and last row, /
+// filters, dd deletes the selected row (after the usual confirmation) and :
+// opens the console. Letters on the menu no longer start the filter, since
+// j and k are taken; / does.
+
+// vimChord folds a key into the chord being typed: "gg" or "dd" once the
+// second key arrives, "" while the first waits for it, else the key itself.
+func (m *Model) vimChord(k string) string {
+	pending := m.VimPending
+	m.VimPending = ""
+	if pending != "" && k == pending {
+		return pending + k
+	}
+	if k == "g" || k == "d" {
+		m.VimPending = k
+		return ""
+	}
+	return k
+}
+
+// vimKey handles a key on the menu or in the browser when vim_keys is on.
+// ok is false for keys the screen handles as usual.
+func (m *Model) vimKey(msg tea.KeyMsg) (next tea.Model, cmd tea.Cmd, ok bool) {
+	if !m.Config.VimKeys {
+		return *m, nil, false
+	}
+	browsing := m.CurrentState == StateBrowser
+	l := &m.MenuList
+	if browsing {
+		if m.Browser.AddingField {
+			return *m, nil, false
+		}
+		l = &m.Browser.KeyList
+		if m.Browser.ViewingFields {
+			l = &m.Browser.FieldsList
+		}
+	}
+	if l.FilterState() == list.Filtering {
+		m.VimPending = ""
+		return *m, nil, false
+	}
+	switch m.vimChord(msg.String()) {
+	case "":
+		return *m, nil, true
+	case "gg":
+		l.Select(0)
+		return *m, nil, true
+	case "dd":
+		if browsing {
+			m.Browser, cmd = m.Browser.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+		}
+		return *m, cmd, true
+	case ":":
+		m.SelectedOp = OpConsole
+		m.pushState(m.CurrentState)
+		next, cmd = m.startConsole()
+		return next, cmd, true
+	case "j", "k", "G":
+		if browsing {
+			return *m, nil, false // the list's own bindings
+		}
+		*l, cmd = l.Update(msg)
+		return *m, cmd, true
+	}
+	// On the menu other letters would start the filter; only / does.
+	if !browsing && msg.Type == tea.KeyRunes && !strings.ContainsAny(msg.String(), "123456789q/") {
+		return *m, nil, true
+	}
+	return *m, nil, false
+}
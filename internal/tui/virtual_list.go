@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// A hash, list or set can hold hundreds of thousands of entries, and a
+// ListItem per entry (a dozen fields, its "idx N" label formatted up front)
+// made opening one slow and heavy. A reply's strings are kept as they came
+// in a fieldBlock instead, the list holds one small fieldRow per entry
+// loaded, all allocated together, and the ListItem is only built for rows
+// drawn or selected. This makes rows lighter, not fewer: how many entries
+// are loaded is up to the paging (fieldPageSize; a hash pages only where
+// HSCAN NOVALUES is supported, see hashPage).
+
+// fieldBlock is one reply's worth of field names, elements or members.
+type fieldBlock struct {
+	values []string
+	desc   string // the label of every row; "" labels each by its index
+	base   int    // index of values[0] in the whole collection
+	back   bool   // indices count down from base (a list read from its tail)
+}
+
+// fieldRow is entry i of its block.
+type fieldRow struct {
+	block *fieldBlock
+	i     int
+}
+
+// fieldItems lays values out as list rows starting at index base, labelled
+// desc, or by index when desc is "".
+func fieldItems(values []string, desc string, base int) []list.Item {
+	return blockItems(&fieldBlock{values: values, desc: desc, base: base})
+}
+
+// tailItems lays values out as list rows indexed base, base-1, … — the
+// elements of a list read from its tail, last first, with negative indices.
+func tailItems(values []string, base int) []list.Item {
+	return blockItems(&fieldBlock{values: values, base: base, back: true})
+}
+
+func blockItems(b *fieldBlock) []list.Item {
+	rows := make([]fieldRow, len(b.values))
+	items := make([]list.Item, len(b.values))
+	for i := range rows {
+		rows[i] = fieldRow{block: b, i: i}
+		items[i] = &rows[i]
+	}
+	return items
+}
+
+func (r *fieldRow) Title() string       { return r.block.values[r.i] }
+func (r *fieldRow) FilterValue() string { return r.block.values[r.i] }
+
+func (r *fieldRow) Description() string {
+	if r.block.desc != "" {
+		return r.block.desc
+	}
+	return "idx " + strconv.Itoa(r.index())
+}
+
+// index is the row's position in the whole collection.
+func (r *fieldRow) index() int {
+	if r.block.back {
+		return r.block.base - r.i
+	}
+	return r.block.base + r.i
+}
+
+// item is the row as the ListItem it stands for.
+func (r *fieldRow) item() ListItem {
+	return ListItem{index: r.index(), title: r.Title(), desc: r.Description()}
+}
+
+// asListItem is it as a ListItem, whether stored as one or as a fieldRow.
+func asListItem(it list.Item) (ListItem, bool) {
+	switch v := it.(type) {
+	case ListItem:
+		return v, true
+	case *fieldRow:
+		return v.item(), true
+	}
+	return ListItem{}, false
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"errors"
+	"net"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// errWorkerStopped answers requests queued on a connection that has since
+// been replaced or closed.
+var errWorkerStopped = errors.New("connection closed")
+
+// Worker is the one goroutine that talks on a session's shared connection.
+// Commands built over m.Conn are queued to it instead of running on their own
+// tea.Cmd goroutines, so a dashboard tick and a keypress can no longer
+// interleave their writes and reads on the same socket. Each request's reply
+// still reaches Update as an ordinary tea.Msg.
+type Worker struct {
+	Conn net.Conn
+	reqs chan workerReq
+	done chan struct{}
+}
+
+type workerReq struct {
+	run   tea.Cmd
+	reply chan tea.Msg
+}
+
+// NewWorker starts a worker for conn. Stop it when the connection goes away.
+func NewWorker(conn net.Conn) *Worker {
+	w := &Worker{Conn: conn, reqs: make(chan workerReq), done: make(chan struct{})}
+	go w.loop()
+	return w
+}
+
+func (w *Worker) loop() {
+	for {
+		select {
+		case req := <-w.reqs:
+			req.reply <- req.run()
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// Do returns a tea.Cmd that runs cmd on the worker, after any request queued
+// before it, and delivers its message. A nil worker runs cmd as is.
+func (w *Worker) Do(cmd tea.Cmd) tea.Cmd {
+	if w == nil || cmd == nil {
+		return cmd
+	}
+	return func() tea.Msg {
+		req := workerReq{run: cmd, reply: make(chan tea.Msg, 1)}
+		select {
+		case w.reqs <- req:
+			return <-req.reply
+		case <-w.done:
+			return RedisResultMsg{Error: errWorkerStopped}
+		}
+	}
+}
+
+// Stop ends the worker. The request it is running finishes; those still
+// waiting get errWorkerStopped. Stop does not close the connection.
+func (w *Worker) Stop() {
+	if w == nil {
+		return
+	}
+	select {
+	case <-w.done:
+	default:
+		close(w.done)
+	}
+}
+
+// exec queues cmd on the session's worker. Models built without one (tests,
+// or before the first connection) run cmd directly.
+func (m Model) exec(cmd tea.Cmd) tea.Cmd {
+	if m.Worker == nil || m.Worker.Conn != m.Conn {
+		return cmd
+	}
+	return m.Worker.Do(cmd)
+}
+
+// stopWorker ends the worker for the current connection.
+func (m *Model) stopWorker() {
+	m.Worker.Stop()
+	m.Worker = nil
+}
@@ -0,0 +1,95 @@
+package redis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// TestAuditor_TagsWrites verifies every command is tagged with the user and
+// profile, writes reach the log as JSON lines, and secrets never do.
+func TestAuditor_TagsWrites(t *testing.T) {
+	var log bytes.Buffer
+	a := redis.NewAuditor(10, &log, "ada")
+	c, s := net.Pipe()
+	t.Cleanup(func() { _ = c.Close(); _ = s.Close() })
+	go func() { _, _ = io.Copy(io.Discard, s) }()
+	conn := a.Wrap(c, "staging", "10.0.0.5:6379")
+
+	for _, cmd := range []redis.RedisCmd{
+		{Name: "AUTH", Args: []string{"admin", "hunter2"}},
+		redis.Get("user:1"),
+		{Name: "SET", Args: []string{"user:1", "ada"}},
+		{Name: "CONFIG", Args: []string{"GET", "maxmemory"}},
+		{Name: "CONFIG", Args: []string{"SET", "requirepass", "s3cret"}},
+	} {
+		if _, err := conn.Write(cmd.ToBytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writes := a.Entries(false)
+	if len(writes) != 2 || strings.Join(writes[0].Command, " ") != "SET user:1 ada" {
+		t.Fatalf("want SET and CONFIG SET as the writes, got %+v", writes)
+	}
+	if w := writes[0]; w.User != "ada" || w.Profile != "staging" || w.Addr != "10.0.0.5:6379" || w.At.IsZero() {
+		t.Errorf("want the write tagged, got %+v", w)
+	}
+	if all := a.Entries(true); len(all) != 5 || all[0].Command[0] != "AUTH" || all[0].Write {
+		t.Errorf("want every command, oldest first, got %+v", all)
+	}
+
+	if strings.Contains(log.String(), "hunter2") || strings.Contains(log.String(), "s3cret") {
+		t.Errorf("secrets reached the log: %s", log.String())
+	}
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	var first redis.AuditEntry
+	if len(lines) != 2 || json.Unmarshal([]byte(lines[0]), &first) != nil || first.Profile != "staging" || !first.Write {
+		t.Errorf("want two JSON lines for the writes, got %q", log.String())
+	}
+}
+
+// TestAuditor_Replaced verifies the value a write replaced is noted on the
+// write and logged on a line of its own, cut like any argument.
+func TestAuditor_Replaced(t *testing.T) {
+	var log bytes.Buffer
+	a := redis.NewAuditor(10, &log, "ada")
+	c, s := net.Pipe()
+	t.Cleanup(func() { _ = c.Close(); _ = s.Close() })
+	go func() { _, _ = io.Copy(io.Discard, s) }()
+	set := redis.RedisCmd{Name: "SET", Args: []string{"user:1", "new", "GET"}}
+	if _, err := a.Wrap(c, "staging", "10.0.0.5:6379").Write(set.ToBytes()); err != nil {
+		t.Fatal(err)
+	}
+	a.Replaced("staging", "10.0.0.5:6379", set, strings.Repeat("o", 300))
+
+	writes := a.Entries(false)
+	if len(writes) != 1 || !strings.HasPrefix(writes[0].Replaced, strings.Repeat("o", 256)+"… (300 bytes)") {
+		t.Fatalf("want the SET noted with what it replaced, cut, got %+v", writes)
+	}
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	var second redis.AuditEntry
+	if len(lines) != 2 || json.Unmarshal([]byte(lines[1]), &second) != nil || second.Replaced != writes[0].Replaced || strings.Join(second.Command, " ") != "SET user:1 new GET" {
+		t.Errorf("want the SET then its replaced value logged, got %q", log.String())
+	}
+}
+
+func TestIsWriteCommand(t *testing.T) {
+	for cmd, want := range map[string]bool{
+		"SET k v": true, "del k": true, "FLUSHALL": true, "GET k": false, "SCAN 0": false,
+		"CONFIG SET maxmemory 1gb": true, "CONFIG GET maxmemory": false, "XGROUP CREATE s g $": true,
+		"ACL WHOAMI": false, "CONFIG": false, "BGSAVE": true, "REPLICAOF NO ONE": true, "XSETID s 0-1": true,
+		"SCRIPT LOAD return 1": true, "script exists abc": false, "FUNCTION DELETE lib": true, "FUNCTION LIST": false,
+		"CLIENT KILL ID 7": true, "CLIENT LIST": false, "CONFIG REWRITE": true, "DEBUG SLEEP 0": true,
+	} {
+		words := strings.Fields(cmd)
+		if got := redis.IsWriteCommand(redis.RedisCmd{Name: words[0], Args: words[1:]}); got != want {
+			t.Errorf("%s: got %v, want %v", cmd, got, want)
+		}
+	}
+}
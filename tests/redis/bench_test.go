@@ -0,0 +1,129 @@
+package redis_test
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// readRespBaseline is ReadResp as it was before headers were parsed in place
+// and payloads copied straight from the buffer; the benchmarks compare the
+// two on the same input.
+func readRespBaseline(reader *bufio.Reader) (any, error) {
+	prefix, err := reader.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	switch prefix {
+	case '+', '-':
+		msg, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(msg), nil
+	case '$':
+		lengthStr, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(lengthStr))
+		if err != nil {
+			return "", err
+		}
+		if n == -1 {
+			return "(nil)", nil
+		}
+		data := make([]byte, n)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return "", err
+		}
+		if _, err := reader.ReadString('\n'); err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case '*':
+		lengthStr, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(lengthStr))
+		if err != nil {
+			return "", err
+		}
+		if n == -1 {
+			return "(nil)", nil
+		}
+		var items []any
+		for range n {
+			item, err := readRespBaseline(reader)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case ':':
+		msg, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		return strconv.Atoi(strings.TrimSpace(msg))
+	}
+	return "", fmt.Errorf("unknown RESP prefix byte: %q", prefix)
+}
+
+// scanPage is a SCAN reply with 1000 keys, the shape the key browser and the
+// bulk tools read over and over.
+func scanPage() []byte {
+	var b bytes.Buffer
+	b.WriteString("*2\r\n$4\r\n1234\r\n*1000\r\n")
+	for i := range 1000 {
+		k := fmt.Sprintf("user:session:%08d", i)
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(k), k)
+	}
+	return b.Bytes()
+}
+
+func largeBulk() []byte {
+	v := strings.Repeat("x", 1<<20)
+	return []byte(fmt.Sprintf("$%d\r\n%s\r\n", len(v), v))
+}
+
+func benchRead(b *testing.B, data []byte, read func(*bufio.Reader) (any, error)) {
+	src := bytes.NewReader(data)
+	br := bufio.NewReader(src)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for b.Loop() {
+		src.Reset(data)
+		br.Reset(src)
+		if _, err := read(br); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadResp_ScanPage(b *testing.B)         { benchRead(b, scanPage(), redis.ReadResp) }
+func BenchmarkReadResp_ScanPageBaseline(b *testing.B) { benchRead(b, scanPage(), readRespBaseline) }
+func BenchmarkReadResp_LargeBulk(b *testing.B)        { benchRead(b, largeBulk(), redis.ReadResp) }
+func BenchmarkReadResp_LargeBulkBaseline(b *testing.B) {
+	benchRead(b, largeBulk(), readRespBaseline)
+}
+
+// TestReadResp_MatchesBaseline guards the optimisations: both readers must
+// agree on the benchmark inputs.
+func TestReadResp_MatchesBaseline(t *testing.T) {
+	for _, data := range [][]byte{scanPage(), largeBulk()} {
+		got, err := redis.ReadResp(bufio.NewReader(bytes.NewReader(data)))
+		want, _ := readRespBaseline(bufio.NewReader(bytes.NewReader(data)))
+		if err != nil || fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("ReadResp disagrees with the baseline (err %v)", err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ package redis_test
 import (
 	"bufio"
 	"bytes"
+	"net"
 	"strings"
 	"testing"
 
@@ -82,6 +83,24 @@ func TestReadResp_UnknownPrefix(t *testing.T) {
 	}
 }
 
+// TestReadResp_LongLine verifies a line longer than the reader's buffer
+// comes back whole, the part that filled the buffer included.
+func TestReadResp_LongLine(t *testing.T) {
+	want := strings.Repeat("a", 4096) + strings.Repeat("b", 100)
+	reader := bufio.NewReaderSize(strings.NewReader("-"+want+"\r\n+OK\r\n"), 4096)
+	got, err := redis.ReadResp(reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		s, _ := got.(string)
+		t.Fatalf("want %d a's then 100 b's, got %d bytes starting %q", 4096, len(s), s[:min(len(s), 16)])
+	}
+	if next, err := redis.ReadResp(reader); err != nil || next != "OK" {
+		t.Errorf("want the next reply intact, got %v, %v", next, err)
+	}
+}
+
 func TestReadResp_ArrayCommand(t *testing.T) {
 	input := "*3\r\n$3\r\nSET\r\n$5\r\nmykey\r\n$5\r\nmyval\r\n"
 	reader := bufio.NewReader(bytes.NewReader([]byte(input)))
@@ -98,3 +117,74 @@ func TestReadResp_ArrayCommand(t *testing.T) {
 		t.Errorf("unexpected array contents: %v", arr)
 	}
 }
+
+func TestRedisCmd_ToInline(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  redis.RedisCmd
+		want string
+	}{
+		{name: "plain words", cmd: redis.RedisCmd{Name: "GET", Args: []string{"user:1"}}, want: "GET user:1\r\n"},
+		{name: "spaces and quotes", cmd: redis.RedisCmd{Name: "SET", Args: []string{"k", `say "hi"`}}, want: "SET k \"say \\\"hi\\\"\"\r\n"},
+		{name: "empty and control bytes", cmd: redis.RedisCmd{Name: "SET", Args: []string{"", "a\r\nb\x00"}}, want: "SET \"\" \"a\\r\\nb\\x00\"\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.cmd.ToInline()); got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestInlineConn_RewritesRequests verifies multi-bulk writes, including
+// several pipelined commands in one write, leave the wrapper inline.
+func TestInlineConn_RewritesRequests(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	got := make(chan string, 1)
+	go func() {
+		buf := make([]byte, 256)
+		n, _ := server.Read(buf)
+		got <- string(buf[:n])
+	}()
+
+	payload := append(redis.RedisCmd{Name: "SELECT", Args: []string{"2"}}.ToBytes(),
+		redis.RedisCmd{Name: "GET", Args: []string{"a b"}}.ToBytes()...)
+	n, err := redis.NewInlineConn(client).Write(payload)
+	if err != nil || n != len(payload) {
+		t.Fatalf("write: n=%d err=%v", n, err)
+	}
+	if s := <-got; s != "SELECT 2\r\nGET \"a b\"\r\n" {
+		t.Errorf("server received %q", s)
+	}
+}
+
+// TestRedisCmdBytes_BinaryRoundTrip verifies raw argument bytes — CRLF, NUL,
+// invalid UTF-8 — arrive exactly as sent.
+func TestRedisCmdBytes_BinaryRoundTrip(t *testing.T) {
+	blob := []byte{0x00, '\r', '\n', 0xff, 0xfe, '$', '*'}
+	cmd := redis.RedisCmdBytes{Name: "RESTORE", Args: [][]byte{[]byte("k"), []byte("0"), blob}}
+
+	got, err := redis.ReadResp(bufio.NewReader(bytes.NewReader(cmd.ToBytes())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	arr, ok := got.([]any)
+	if !ok || len(arr) != 4 || arr[0] != "RESTORE" || arr[3] != string(blob) {
+		t.Errorf("binary argument corrupted: %q", got)
+	}
+	if s := (redis.RedisCmd{Name: "RESTORE", Args: []string{"k", "0", string(blob)}}).ToBytes(); !bytes.Equal(s, cmd.ToBytes()) {
+		t.Error("string and byte arguments should encode identically")
+	}
+}
+
+func TestRedisCmd_Validate(t *testing.T) {
+	for name, ok := range map[string]bool{"GET": true, "CLIENT": true, "": false, "GET KEY": false, "GET\nKEY": false} {
+		if err := (redis.RedisCmd{Name: name}).Validate(); (err == nil) != ok {
+			t.Errorf("%q: want valid=%v, got %v", name, ok, err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package redis_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// TestParseCommandTable verifies entries are keyed by upper-case name with
+// their arity, flags and key positions, and COMMAND INFO's nulls for unknown commands are
+// skipped.
+func TestParseCommandTable(t *testing.T) {
+	reply := []any{
+		[]any{"get", 2, []any{"readonly", "fast"}, 1, 1, 1},
+		"(nil)",
+		[]any{"hset", -4, []any{"write"}, 1, 1, 1},
+	}
+	table, err := redis.ParseCommandTable(reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := redis.CommandInfo{Name: "GET", Arity: 2, Flags: []string{"readonly", "fast"}, FirstKey: 1, LastKey: 1, Step: 1}
+	if got := table["GET"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("GET: want %+v, got %+v", want, got)
+	}
+	if !table.Has("hset") || table.Has("PSUBSCRIBE") {
+		t.Errorf("Has: want HSET only, got %v", table.Names())
+	}
+	if got := table.Names(); !reflect.DeepEqual(got, []string{"GET", "HSET"}) {
+		t.Errorf("Names: got %v", got)
+	}
+
+	if _, err := redis.ParseCommandTable("ERR unknown command 'COMMAND'"); err == nil {
+		t.Error("want an error for a refused COMMAND")
+	}
+}
+
+// TestParseCommandTable_Subcommands verifies Redis 7's subcommand entries
+// ("config|get") become the container's Subcommands, sorted.
+func TestParseCommandTable_Subcommands(t *testing.T) {
+	reply := []any{
+		[]any{"config", -2, []any{}, 0, 0, 0, []any{}, []any{}, []any{}, []any{
+			[]any{"config|set", -4, []any{"admin"}, 0, 0, 0},
+			[]any{"config|get", -3, []any{"admin"}, 0, 0, 0},
+		}},
+	}
+	table, err := redis.ParseCommandTable(reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := table["CONFIG"].Subcommands; !reflect.DeepEqual(got, []string{"GET", "SET"}) {
+		t.Errorf("want GET and SET, got %v", got)
+	}
+}
+
+// TestCommandInfo_IsKeyArg verifies key positions are read from first, last
+// and step, with a negative last counted as still ahead, and that a command
+// reporting no keys leaves it to the caller.
+func TestCommandInfo_IsKeyArg(t *testing.T) {
+	mset := redis.CommandInfo{Name: "MSET", FirstKey: 1, LastKey: -1, Step: 2}
+	for i, want := range []bool{false, true, false, true, false, true} {
+		if got, ok := mset.IsKeyArg(i); got != want || !ok {
+			t.Errorf("MSET arg %d: want %v, got %v (known %v)", i, want, got, ok)
+		}
+	}
+	lmove := redis.CommandInfo{Name: "LMOVE", FirstKey: 1, LastKey: 2, Step: 1}
+	if got, _ := lmove.IsKeyArg(3); got {
+		t.Error("LMOVE arg 3 is the direction, not a key")
+	}
+	if _, ok := (redis.CommandInfo{Name: "EVAL"}).IsKeyArg(3); ok {
+		t.Error("EVAL reports no key positions; want unknown")
+	}
+}
+
+// TestCommandTable_NilHasEverything verifies an unknown table disables
+// nothing.
+func TestCommandTable_NilHasEverything(t *testing.T) {
+	var table redis.CommandTable
+	if !table.Has("PSUBSCRIBE") {
+		t.Error("a nil table should report every command")
+	}
+}
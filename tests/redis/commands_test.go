@@ -0,0 +1,45 @@
+package redis_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+func TestCommandBuilders(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  redis.RedisCmd
+		want []string
+	}{
+		{"auth legacy", redis.Auth("", "secret"), []string{"AUTH", "secret"}},
+		{"auth acl", redis.Auth("alice", "secret"), []string{"AUTH", "alice", "secret"}},
+		{"scan bare", redis.Scan("0", "", 0), []string{"SCAN", "0"}},
+		{"scan match count", redis.Scan("17", "user:*", 500), []string{"SCAN", "17", "MATCH", "user:*", "COUNT", "500"}},
+		{"expire", redis.Expire("k", 60), []string{"EXPIRE", "k", "60"}},
+		{"lrange", redis.LRange("k", 0, -1), []string{"LRANGE", "k", "0", "-1"}},
+		{"sscan", redis.SScan("k", "0", 100), []string{"SSCAN", "k", "0", "COUNT", "100"}},
+		{"zadd", redis.ZAdd("k", "+inf", "m"), []string{"ZADD", "k", "+inf", "m"}},
+		{"zrange", redis.ZRange("k", 0, 9, false), []string{"ZRANGE", "k", "0", "9"}},
+		{"zrange scores", redis.ZRange("k", 0, 9, true), []string{"ZRANGE", "k", "0", "9", "WITHSCORES"}},
+		{"zrevrange scores", redis.ZRevRange("k", 0, 9, true), []string{"ZREVRANGE", "k", "0", "9", "WITHSCORES"}},
+		{"zincrby", redis.ZIncrBy("k", "-2.5", "m"), []string{"ZINCRBY", "k", "-2.5", "m"}},
+		{"hrandfield values", redis.HRandField("k", 5, true), []string{"HRANDFIELD", "k", "5", "WITHVALUES"}},
+		{"srandmember", redis.SRandMember("k", 5), []string{"SRANDMEMBER", "k", "5"}},
+		{"zrandmember", redis.ZRandMember("k", 5, false), []string{"ZRANDMEMBER", "k", "5"}},
+		{"publish", redis.Publish("sessions:invalidated", "session:1"), []string{"PUBLISH", "sessions:invalidated", "session:1"}},
+		{"del many", redis.Del("a", "b", "c"), []string{"DEL", "a", "b", "c"}},
+		{"xpending range", redis.XPendingRange("s", "g", 500), []string{"XPENDING", "s", "g", "-", "+", "500"}},
+		{"xack many", redis.XAck("s", "g", "1-1", "2-1"), []string{"XACK", "s", "g", "1-1", "2-1"}},
+		{"xclaim many", redis.XClaim("s", "g", "c2", 1000, "1-1", "2-1"), []string{"XCLAIM", "s", "g", "c2", "1000", "1-1", "2-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := append([]string{tt.cmd.Name}, tt.cmd.Args...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package redis_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// Integration tests run against a real server, named by REDIS_TEST_ADDR
+// (host:port), and are skipped without one. `make integration` starts a
+// throwaway Redis in Docker and runs them. Every key they write starts with
+// itPrefix and is deleted afterwards, so a shared dev instance is safe.
+
+const itPrefix = "redis-tui-it:"
+
+func dialIntegration(t *testing.T) *mockClient {
+	t.Helper()
+	addr := os.Getenv("REDIS_TEST_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_TEST_ADDR not set")
+	}
+	c := dialAddr(t, addr)
+	if pw := os.Getenv("REDIS_TEST_PASSWORD"); pw != "" {
+		if s, _ := c.do("AUTH", pw).(string); s != "OK" {
+			t.Fatalf("AUTH: %s", s)
+		}
+	}
+	// A test may leave c unusable (killed, or mid-BLPOP), so the cleanup
+	// dials its own connection.
+	t.Cleanup(func() { deleteTestKeys(addr) })
+	return c
+}
+
+func deleteTestKeys(addr string) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	send := func(cmd redis.RedisCmd) any {
+		_, _ = conn.Write(cmd.ToBytes())
+		v, _ := redis.ReadResp(r)
+		return v
+	}
+	if pw := os.Getenv("REDIS_TEST_PASSWORD"); pw != "" {
+		send(redis.Auth("", pw))
+	}
+	keys, _ := redis.AsStringSlice(send(redis.RedisCmd{Name: "KEYS", Args: []string{itPrefix + "*"}}))
+	if len(keys) > 0 {
+		send(redis.Del(keys[0], keys[1:]...))
+	}
+}
+
+// TestIntegration_ReplyTypes checks ReadResp against real replies of every
+// RESP2 type, including the edge cases a fake server is likely to get right
+// by construction: empty and null values, nested arrays, binary payloads.
+func TestIntegration_ReplyTypes(t *testing.T) {
+	c := dialIntegration(t)
+	k := func(s string) string { return itPrefix + s }
+
+	binary := "a\r\nb\x00c\xff"
+	c.do("SET", k("str"), binary)
+	c.do("RPUSH", k("list"), "x", "y")
+	c.do("HSET", k("hash"), "f", "v")
+	c.do("ZADD", k("zset"), "1.5", "m")
+	c.do("XADD", k("stream"), "1-1", "field", "value")
+
+	checks := []struct {
+		name string
+		args []string
+		want any
+	}{
+		{"simple string", []string{"PING"}, "PONG"},
+		{"bulk with CRLF and NUL", []string{"GET", k("str")}, binary},
+		{"empty bulk", []string{"ECHO", ""}, ""},
+		{"null bulk", []string{"GET", k("missing")}, "(nil)"},
+		{"integer", []string{"LLEN", k("list")}, 2},
+		{"negative integer", []string{"TTL", k("missing")}, -2},
+		{"array", []string{"LRANGE", k("list"), "0", "-1"}, []any{"x", "y"}},
+		{"empty array", []string{"LRANGE", k("missing"), "0", "-1"}, []any{}},
+		{"scores as bulk", []string{"ZRANGE", k("zset"), "0", "-1", "WITHSCORES"}, []any{"m", "1.5"}},
+		{"nested array", []string{"XRANGE", k("stream"), "-", "+"}, []any{[]any{"1-1", []any{"field", "value"}}}},
+	}
+	for _, tt := range checks {
+		if got := c.do(tt.args...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: %v: want %#v, got %#v", tt.name, tt.args, tt.want, got)
+		}
+	}
+
+	got := c.do("LPUSH", k("str"), "x")
+	if s, _ := got.(string); !redis.IsErrorReply(s) || !strings.HasPrefix(s, "WRONGTYPE") {
+		t.Errorf("error reply: want WRONGTYPE, got %#v", got)
+	}
+	if m, err := redis.AsMap(c.do("HGETALL", k("hash"))); err != nil || m["f"] != "v" {
+		t.Errorf("HGETALL: got %v, %v", m, err)
+	}
+}
+
+// TestIntegration_LargeValue reads a value bigger than the reader's buffer
+// both whole and streamed.
+func TestIntegration_LargeValue(t *testing.T) {
+	c := dialIntegration(t)
+	key := itPrefix + "large"
+	value := strings.Repeat("0123456789abcdef", 1<<16) // 1 MB
+	c.do("SET", key, value)
+
+	if got := c.do("GET", key); got != value {
+		t.Fatalf("GET: got %d bytes", len(fmt.Sprint(got)))
+	}
+
+	if _, err := c.conn.Write(redis.Get(key).ToBytes()); err != nil {
+		t.Fatal(err)
+	}
+	body, _, err := redis.ReadRespStream(c.r)
+	if err != nil || body == nil {
+		t.Fatalf("ReadRespStream: %v, %v", body, err)
+	}
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, body); err != nil || buf.String() != value {
+		t.Fatalf("streamed %d bytes, err %v", buf.Len(), err)
+	}
+	if got := c.do("PING"); got != "PONG" {
+		t.Errorf("connection out of step after the stream: got %#v", got)
+	}
+}
+
+// TestIntegration_ScanPaging walks a few hundred keys with small SCAN pages
+// and checks every key is seen, whatever order and duplicates the server
+// returns.
+func TestIntegration_ScanPaging(t *testing.T) {
+	c := dialIntegration(t)
+	const n = 250
+	want := make(map[string]bool, n)
+	for i := range n {
+		key := itPrefix + "scan:" + strconv.Itoa(i)
+		c.do("SET", key, "v")
+		want[key] = true
+	}
+
+	seen := map[string]bool{}
+	cursor, pages := "0", 0
+	for {
+		cmd := redis.Scan(cursor, itPrefix+"scan:*", 20)
+		page, ok := c.do(append([]string{cmd.Name}, cmd.Args...)...).([]any)
+		if !ok || len(page) != 2 {
+			t.Fatalf("unexpected SCAN reply %#v", page)
+		}
+		keys, err := redis.AsStringSlice(page[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, k := range keys {
+			seen[k] = true
+		}
+		pages++
+		if cursor, _ = page[0].(string); cursor == "0" {
+			break
+		}
+		if pages > 10*n {
+			t.Fatal("SCAN cursor never returned to 0")
+		}
+	}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("want %d keys, saw %d", len(want), len(seen))
+	}
+	if pages < 2 {
+		t.Errorf("want more than one page with COUNT 20, got %d", pages)
+	}
+}
+
+// TestIntegration_ConnectionKilled checks that a connection the server drops
+// surfaces as a read error — what the TUI's reconnect logic waits for —
+// rather than a hang or a garbage reply, and that a fresh dial works.
+func TestIntegration_ConnectionKilled(t *testing.T) {
+	victim := dialIntegration(t)
+	killer := dialIntegration(t)
+
+	id, err := redis.AsInt(victim.do("CLIENT", "ID"))
+	if err != nil {
+		t.Skipf("CLIENT ID unavailable: %v", err)
+	}
+	n, err := redis.AsInt(killer.do("CLIENT", "KILL", "ID", strconv.Itoa(id)))
+	var replyErr redis.ReplyError
+	if errors.As(err, &replyErr) {
+		t.Skipf("CLIENT KILL refused: %v", err) // proxies and managed servers
+	}
+	if err != nil || n != 1 {
+		t.Fatalf("CLIENT KILL: %d, %v", n, err)
+	}
+
+	_ = victim.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _ = victim.conn.Write(redis.Ping().ToBytes())
+	if v, err := redis.ReadResp(victim.r); err == nil {
+		t.Fatalf("want a read error on the killed connection, got %#v", v)
+	} else if isTimeout(err) {
+		t.Fatalf("want the close noticed, got a timeout: %v", err)
+	}
+
+	again := dialIntegration(t)
+	if got := again.do("PING"); got != "PONG" {
+		t.Errorf("after redial: got %#v", got)
+	}
+}
+
+// TestIntegration_ReadDeadline checks that a reply that never comes ends in
+// a timeout error, which the TUI answers by closing the connection.
+func TestIntegration_ReadDeadline(t *testing.T) {
+	c := dialIntegration(t)
+	if _, err := c.conn.Write(redis.RedisCmd{Name: "BLPOP", Args: []string{itPrefix + "never", "5"}}.ToBytes()); err != nil {
+		t.Fatal(err)
+	}
+	_ = c.conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	_, err := redis.ReadResp(bufio.NewReader(c.conn))
+	if !isTimeout(err) {
+		t.Fatalf("want a timeout, got %v", err)
+	}
+}
+
+func isTimeout(err error) bool {
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
@@ -0,0 +1,268 @@
+package redis_test
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/redis/mock"
+)
+
+type mockClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func dialMock(t *testing.T, srv *mock.Server) *mockClient {
+	t.Helper()
+	addr, err := srv.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = srv.Close() })
+	return dialAddr(t, addr)
+}
+
+func dialAddr(t *testing.T, addr string) *mockClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return &mockClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+}
+
+func (c *mockClient) do(args ...string) any {
+	c.t.Helper()
+	if _, err := c.conn.Write(redis.RedisCmd{Name: args[0], Args: args[1:]}.ToBytes()); err != nil {
+		c.t.Fatal(err)
+	}
+	v, err := redis.ReadResp(c.r)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return v
+}
+
+// TestMock_Types verifies every data type round-trips and TYPE reports it.
+func TestMock_Types(t *testing.T) {
+	c := dialMock(t, mock.NewServer())
+	c.do("SET", "s", "v")
+	c.do("HSET", "h", "f", "1", "g", "2")
+	c.do("RPUSH", "l", "a", "b", "c")
+	c.do("SADD", "st", "x", "y")
+	c.do("ZADD", "z", "2", "b", "1", "a")
+	c.do("XADD", "x", "1-1", "k", "v")
+
+	for key, want := range map[string]string{"s": "string", "h": "hash", "l": "list", "st": "set", "z": "zset", "x": "stream", "nope": "none"} {
+		if got := c.do("TYPE", key); got != want {
+			t.Errorf("TYPE %s: want %s, got %v", key, want, got)
+		}
+	}
+	checks := []struct {
+		args []string
+		want any
+	}{
+		{[]string{"GET", "s"}, "v"},
+		{[]string{"ECHO", "hi"}, "hi"},
+		{[]string{"HGETALL", "h"}, []any{"f", "1", "g", "2"}},
+		{[]string{"LRANGE", "l", "-2", "-1"}, []any{"b", "c"}},
+		{[]string{"SMEMBERS", "st"}, []any{"x", "y"}},
+		{[]string{"ZRANGE", "z", "0", "-1", "WITHSCORES"}, []any{"a", "1", "b", "2"}},
+		{[]string{"ZREVRANGE", "z", "0", "0", "WITHSCORES"}, []any{"b", "2"}},
+		{[]string{"ZINCRBY", "z", "1.5", "a"}, "2.5"},
+		{[]string{"ZINCRBY", "z", "3", "c"}, "3"},
+		{[]string{"ZREVRANGE", "z", "0", "-1"}, []any{"c", "a", "b"}},
+		{[]string{"XRANGE", "x", "-", "+"}, []any{[]any{"1-1", []any{"k", "v"}}}},
+		{[]string{"GET", "h"}, "WRONGTYPE Operation against a key holding the wrong kind of value"},
+	}
+	for _, tc := range checks {
+		if got := c.do(tc.args...); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: want %v, got %v", tc.args, tc.want, got)
+		}
+	}
+}
+
+// TestMock_ScanMatchAndType verifies SCAN walks the whole keyspace across
+// pages and applies MATCH and TYPE.
+func TestMock_ScanMatchAndType(t *testing.T) {
+	c := dialMock(t, mock.NewServer())
+	for _, k := range []string{"user:1", "user:2", "user:3", "order:1"} {
+		c.do("SET", k, "x")
+	}
+	c.do("HSET", "user:h", "f", "v")
+
+	var found []string
+	cursor := "0"
+	for {
+		reply := c.do("SCAN", cursor, "MATCH", "user:*", "COUNT", "2", "TYPE", "string").([]any)
+		for _, k := range reply[1].([]any) {
+			found = append(found, k.(string))
+		}
+		if cursor = reply[0].(string); cursor == "0" {
+			break
+		}
+	}
+	if strings.Join(found, ",") != "user:1,user:2,user:3" {
+		t.Errorf("got %v", found)
+	}
+}
+
+// TestMock_Expiry verifies TTLs count down and expired keys disappear.
+func TestMock_Expiry(t *testing.T) {
+	c := dialMock(t, mock.NewServer())
+	c.do("SET", "k", "v", "PX", "50")
+	if ttl := c.do("PTTL", "k").(int); ttl <= 0 || ttl > 50 {
+		t.Errorf("PTTL: got %d", ttl)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if got := c.do("GET", "k"); got != "(nil)" {
+		t.Errorf("want the key expired, got %v", got)
+	}
+	if got := c.do("TTL", "k"); got != -2 {
+		t.Errorf("TTL of a missing key: want -2, got %v", got)
+	}
+}
+
+// TestMock_DumpRestore verifies DUMP output restores, with REPLACE needed to
+// overwrite.
+func TestMock_DumpRestore(t *testing.T) {
+	c := dialMock(t, mock.NewServer())
+	c.do("HSET", "h", "f", "v")
+	payload := c.do("DUMP", "h").(string)
+
+	if got := c.do("RESTORE", "h", "0", payload); !strings.HasPrefix(got.(string), "BUSYKEY") {
+		t.Errorf("want BUSYKEY without REPLACE, got %v", got)
+	}
+	c.do("RESTORE", "h2", "0", payload)
+	if got := c.do("HGET", "h2", "f"); got != "v" {
+		t.Errorf("restored hash: got %v", got)
+	}
+	if got := c.do("RESTORE", "h3", "0", "not a dump"); !strings.Contains(got.(string), "checksum") {
+		t.Errorf("want a payload error, got %v", got)
+	}
+}
+
+// TestMock_Auth verifies a configured password is enforced.
+func TestMock_Auth(t *testing.T) {
+	srv := mock.NewServer()
+	srv.Password = "s3cret"
+	c := dialMock(t, srv)
+
+	if got := c.do("GET", "k"); !strings.HasPrefix(got.(string), "NOAUTH") {
+		t.Errorf("want NOAUTH, got %v", got)
+	}
+	if got := c.do("AUTH", "wrong"); !strings.HasPrefix(got.(string), "WRONGPASS") {
+		t.Errorf("want WRONGPASS, got %v", got)
+	}
+	if got := c.do("AUTH", "default", "s3cret"); got != "OK" {
+		t.Errorf("AUTH: got %v", got)
+	}
+}
+
+// TestMock_InlineCommands verifies the inline protocol is understood,
+// including quoted arguments.
+func TestMock_InlineCommands(t *testing.T) {
+	c := dialMock(t, mock.NewServer())
+	if _, err := c.conn.Write(redis.RedisCmd{Name: "SET", Args: []string{"greeting", "hello world\n"}}.ToInline()); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := redis.ReadResp(c.r); v != "OK" {
+		t.Fatalf("inline SET: got %v", v)
+	}
+	if got := c.do("GET", "greeting"); got != "hello world\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+// TestMock_BlockingPop verifies BLPOP waits for a push from another client
+// and times out with a null reply.
+func TestMock_BlockingPop(t *testing.T) {
+	srv := mock.NewServer()
+	c := dialMock(t, srv)
+	if got := c.do("BLPOP", "jobs", "0.05"); got != "(nil)" {
+		t.Errorf("timeout: want nil, got %v", got)
+	}
+
+	srv.Do(0, "SET", "unrelated", "x")
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		srv.Do(0, "RPUSH", "jobs", "job-1")
+	}()
+	if got := c.do("BLPOP", "jobs", "2"); !reflect.DeepEqual(got, []any{"jobs", "job-1"}) {
+		t.Errorf("want the pushed job, got %v", got)
+	}
+}
+
+// TestMock_StreamRead verifies XREVRANGE replies newest first and XREAD
+// BLOCK waits for an entry added after the ID it was given.
+func TestMock_StreamRead(t *testing.T) {
+	srv := mock.NewServer()
+	c := dialMock(t, srv)
+	srv.Do(0, "XADD", "events", "1-1", "n", "1")
+	srv.Do(0, "XADD", "events", "2-1", "n", "2")
+	if got := c.do("XREVRANGE", "events", "+", "-", "COUNT", "1"); !reflect.DeepEqual(got, []any{[]any{"2-1", []any{"n", "2"}}}) {
+		t.Errorf("XREVRANGE: want the newest entry, got %v", got)
+	}
+	if got := c.do("XREAD", "BLOCK", "50", "STREAMS", "events", "$"); got != "(nil)" {
+		t.Errorf("timeout: want nil, got %v", got)
+	}
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		srv.Do(0, "XADD", "events", "3-1", "n", "3")
+	}()
+	want := []any{[]any{"events", []any{[]any{"3-1", []any{"n", "3"}}}}}
+	if got := c.do("XREAD", "COUNT", "10", "BLOCK", "2000", "STREAMS", "events", "2-1"); !reflect.DeepEqual(got, want) {
+		t.Errorf("want the added entry, got %v", got)
+	}
+}
+
+// TestMock_ConsumerGroups verifies XREADGROUP delivers new entries once and
+// records them as pending, and XAUTOCLAIM moves them to another consumer.
+func TestMock_ConsumerGroups(t *testing.T) {
+	srv := mock.NewServer()
+	c := dialMock(t, srv)
+	srv.Do(0, "XADD", "events", "1-1", "n", "1")
+	if got := c.do("XGROUP", "CREATE", "events", "g", "0"); got != "OK" {
+		t.Fatalf("XGROUP CREATE: got %v", got)
+	}
+	if got := c.do("XGROUP", "CREATE", "events", "g", "$"); !strings.HasPrefix(fmt.Sprint(got), "BUSYGROUP") {
+		t.Errorf("want BUSYGROUP for a taken name, got %v", got)
+	}
+	want := []any{[]any{"events", []any{[]any{"1-1", []any{"n", "1"}}}}}
+	if got := c.do("XREADGROUP", "GROUP", "g", "c1", "STREAMS", "events", ">"); !reflect.DeepEqual(got, want) {
+		t.Errorf("XREADGROUP: want the entry, got %v", got)
+	}
+	if got := c.do("XREADGROUP", "GROUP", "g", "c1", "STREAMS", "events", ">"); got != "(nil)" {
+		t.Errorf("XREADGROUP again: want nil, got %v", got)
+	}
+	claimed := c.do("XAUTOCLAIM", "events", "g", "c2", "0", "0-0")
+	if r, ok := claimed.([]any); !ok || len(r) != 3 || r[0] != "0-0" || len(r[1].([]any)) != 1 {
+		t.Errorf("XAUTOCLAIM: want the entry claimed, got %v", claimed)
+	}
+	groups := fmt.Sprint(c.do("XINFO", "GROUPS", "events"))
+	if !strings.Contains(groups, "consumers 2 pending 1 last-delivered-id 1-1") {
+		t.Errorf("XINFO GROUPS: got %v", groups)
+	}
+}
+
+// TestMock_Seed verifies the demo data covers every type.
+func TestMock_Seed(t *testing.T) {
+	srv := mock.NewServer()
+	srv.Seed()
+	seen := map[any]bool{}
+	for _, k := range srv.Do(0, "KEYS", "*").([]any) {
+		seen[srv.Do(0, "TYPE", k.(string))] = true
+	}
+	if len(seen) != 6 {
+		t.Errorf("want all six types seeded, got %v", seen)
+	}
+}
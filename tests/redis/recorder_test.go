@@ -0,0 +1,69 @@
+package redis_test
+
+import (
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// recorded returns the client end of a pipe wrapped by rec, and the server
+// end.
+func recorded(t *testing.T, rec *redis.Recorder) (client, server net.Conn) {
+	t.Helper()
+	c, s := net.Pipe()
+	t.Cleanup(func() { _ = c.Close(); _ = s.Close() })
+	return rec.Wrap(c, "conn1"), s
+}
+
+// TestRecorder_KeepsTurns verifies reads in a row are kept as one exchange,
+// and only the latest exchanges are kept.
+func TestRecorder_KeepsTurns(t *testing.T) {
+	rec := redis.NewRecorder(2)
+	client, server := recorded(t, rec)
+	go func() {
+		buf := make([]byte, 64)
+		_, _ = server.Read(buf)
+		_, _ = server.Write([]byte("+OK\r\n"))
+		_, _ = server.Write([]byte(":1\r\n"))
+	}()
+	if _, err := client.Write(redis.Get("greeting").ToBytes()); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 64)
+	for _, want := range []string{"+OK\r\n", ":1\r\n"} {
+		if n, err := client.Read(buf); err != nil || string(buf[:n]) != want {
+			t.Fatalf("read through the recorder: %q, %v", buf[:n], err)
+		}
+	}
+
+	got := rec.Exchanges()
+	if len(got) != 2 || !got[0].Sent || got[1].Sent {
+		t.Fatalf("want a write and a read, got %+v", got)
+	}
+	if string(got[1].Data) != "+OK\r\n:1\r\n" || got[1].Label != "conn1" {
+		t.Errorf("want both replies in one exchange, got %q", got[1].Data)
+	}
+
+	go func() { _, _ = io.Copy(io.Discard, server) }()
+	if _, err := client.Write(redis.Ping().ToBytes()); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Exchanges(); len(got) != 2 || got[0].Sent || !strings.Contains(string(got[1].Data), "PING") {
+		t.Errorf("want the oldest exchange dropped, got %+v", got)
+	}
+}
+
+func TestRecorder_RedactsAuth(t *testing.T) {
+	rec := redis.NewRecorder(10)
+	client, server := recorded(t, rec)
+	go func() { _, _ = io.Copy(io.Discard, server) }()
+	if _, err := client.Write(redis.Auth("alice", "s3cret").ToBytes()); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(rec.Exchanges()[0].Data); strings.Contains(got, "s3cret") || !strings.Contains(got, "redacted") {
+		t.Errorf("AUTH not redacted: %q", got)
+	}
+}
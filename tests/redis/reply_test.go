@@ -0,0 +1,68 @@
+package redis_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+func TestAsString(t *testing.T) {
+	if s, err := redis.AsString("hello"); err != nil || s != "hello" {
+		t.Errorf("got %q, %v", s, err)
+	}
+	if _, err := redis.AsString("(nil)"); !errors.Is(err, redis.ErrNil) {
+		t.Errorf("null bulk: want ErrNil, got %v", err)
+	}
+	var replyErr redis.ReplyError
+	if _, err := redis.AsString("WRONGTYPE Operation against a key holding the wrong kind of value"); !errors.As(err, &replyErr) {
+		t.Errorf("error reply: want ReplyError, got %v", err)
+	}
+	if _, err := redis.AsString(3); err == nil {
+		t.Error("integer: want an error")
+	}
+}
+
+func TestAsInt(t *testing.T) {
+	tests := []struct {
+		in      any
+		want    int
+		wantErr bool
+	}{
+		{in: 42, want: 42},
+		{in: "17", want: 17},
+		{in: "abc", wantErr: true},
+		{in: "(nil)", wantErr: true},
+		{in: []any{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := redis.AsInt(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("AsInt(%v) = %d, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestAsStringSlice(t *testing.T) {
+	got, err := redis.AsStringSlice([]any{"a", "(nil)", "c"})
+	if err != nil || !reflect.DeepEqual(got, []string{"a", "", "c"}) {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if got, err := redis.AsStringSlice([]any{}); err != nil || len(got) != 0 {
+		t.Errorf("empty array: got %q, %v", got, err)
+	}
+	if _, err := redis.AsStringSlice([]any{"a", 1}); err == nil {
+		t.Error("mixed array: want an error")
+	}
+}
+
+func TestAsMap(t *testing.T) {
+	got, err := redis.AsMap([]any{"f1", "v1", "f2", "v2"})
+	if err != nil || !reflect.DeepEqual(got, map[string]string{"f1": "v1", "f2": "v2"}) {
+		t.Errorf("got %v, %v", got, err)
+	}
+	if _, err := redis.AsMap([]any{"f1"}); err == nil {
+		t.Error("odd length: want an error")
+	}
+}
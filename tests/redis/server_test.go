@@ -0,0 +1,60 @@
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+func TestParseServerInfo(t *testing.T) {
+	cases := map[string]struct {
+		info string
+		want redis.ServerInfo
+	}{
+		"redis":     {"# Server\r\nredis_version:7.2.4\r\nredis_mode:standalone\r\n", redis.ServerInfo{Flavor: redis.FlavorRedis, Version: "7.2.4"}},
+		"valkey":    {"# Server\r\nredis_version:7.2.4\r\nserver_name:valkey\r\nvalkey_version:8.0.1\r\n", redis.ServerInfo{Flavor: redis.FlavorValkey, Version: "8.0.1"}},
+		"dragonfly": {"# Server\r\nredis_version:7.2.0\r\ndragonfly_version:df-v1.21.2\r\n", redis.ServerInfo{Flavor: redis.FlavorDragonfly, Version: "1.21.2"}},
+		"keydb":     {"# Server\r\nredis_version:6.3.4\r\nexecutable:/usr/local/bin/keydb-server\r\n", redis.ServerInfo{Flavor: redis.FlavorKeyDB, Version: "6.3.4"}},
+		"not info":  {"ERR unknown command 'INFO'", redis.ServerInfo{}},
+	}
+	for name, tc := range cases {
+		if got := redis.ParseServerInfo(tc.info); got != tc.want {
+			t.Errorf("%s: want %+v, got %+v", name, tc.want, got)
+		}
+	}
+}
+
+func TestServerInfo_Supports(t *testing.T) {
+	df := redis.ServerInfo{Flavor: redis.FlavorDragonfly, Version: "1.21.2"}
+	if df.Supports(redis.CapIdleTime) || df.Supports(redis.CapDebugRestart) {
+		t.Error("Dragonfly should lack OBJECT IDLETIME and DEBUG RESTART")
+	}
+	for _, s := range []redis.ServerInfo{{Flavor: redis.FlavorRedis}, {Flavor: redis.FlavorValkey}, {}} {
+		if !s.Supports(redis.CapIdleTime) {
+			t.Errorf("%q: unknown gaps should be assumed supported", s)
+		}
+	}
+	if got := df.String(); got != "dragonfly 1.21.2" {
+		t.Errorf("String: got %q", got)
+	}
+}
+
+func TestServerInfo_SupportsByVersion(t *testing.T) {
+	for _, tc := range []struct {
+		server redis.ServerInfo
+		want   bool
+	}{
+		{redis.ServerInfo{Flavor: redis.FlavorRedis, Version: "6.2.0"}, true},
+		{redis.ServerInfo{Flavor: redis.FlavorRedis, Version: "7.0.15"}, true},
+		{redis.ServerInfo{Flavor: redis.FlavorRedis, Version: "6.0.20"}, false},
+		{redis.ServerInfo{Flavor: redis.FlavorRedis, Version: "6.10.1"}, true},
+		{redis.ServerInfo{Flavor: redis.FlavorRedis, Version: "7.4.0-rc1"}, true},
+		{redis.ServerInfo{Flavor: redis.FlavorValkey, Version: "8.0.1"}, true},
+		{redis.ServerInfo{Flavor: redis.FlavorRedis}, false},
+		{redis.ServerInfo{}, false},
+	} {
+		if got := tc.server.Supports(redis.CapSetGet); got != tc.want {
+			t.Errorf("%q %q: Supports(SET ... GET) = %v, want %v", tc.server.Flavor, tc.server.Version, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package redis_test
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// TestReadRespStream_Bulk verifies a bulk payload can be read in pieces and
+// that the connection is positioned at the next reply afterwards.
+func TestReadRespStream_Bulk(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("$11\r\nhello world\r\n+OK\r\n"))
+	body, v, err := redis.ReadRespStream(r)
+	if err != nil || v != nil || body == nil || body.Len != 11 {
+		t.Fatalf("want a bulk stream of 11 bytes, got %v %v %v", body, v, err)
+	}
+
+	var got strings.Builder
+	buf := make([]byte, 4)
+	for body.Remaining() > 0 {
+		n, err := body.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got.Write(buf[:n])
+	}
+	if got.String() != "hello world" {
+		t.Errorf("payload: got %q", got.String())
+	}
+	if n, err := body.Read(buf); n != 0 || err != io.EOF {
+		t.Errorf("want EOF after the payload, got %d %v", n, err)
+	}
+	if next, err := redis.ReadResp(r); next != "OK" || err != nil {
+		t.Errorf("next reply: want OK, got %v %v", next, err)
+	}
+}
+
+// TestReadRespStream_NotBulk verifies other replies are returned whole.
+func TestReadRespStream_NotBulk(t *testing.T) {
+	for data, want := range map[string]any{"$-1\r\n": "(nil)", ":7\r\n": 7, "-ERR nope\r\n": "ERR nope"} {
+		body, v, err := redis.ReadRespStream(bufio.NewReader(strings.NewReader(data)))
+		if body != nil || v != want || err != nil {
+			t.Errorf("%q: want %v, got %v %v %v", data, want, body, v, err)
+		}
+	}
+}
+
+// TestReadRespStream_Truncated verifies a connection lost mid-payload is an
+// error rather than a short value.
+func TestReadRespStream_Truncated(t *testing.T) {
+	body, _, err := redis.ReadRespStream(bufio.NewReader(strings.NewReader("$10\r\nabc")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(body); err != io.ErrUnexpectedEOF {
+		t.Errorf("want ErrUnexpectedEOF, got %v", err)
+	}
+}
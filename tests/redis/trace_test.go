@@ -0,0 +1,82 @@
+package redis_test
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// traced returns the client end of a pipe wrapped by a Tracer logging to
+// log, and the server end.
+func traced(t *testing.T, log *bytes.Buffer) (client, server net.Conn) {
+	t.Helper()
+	c, s := net.Pipe()
+	t.Cleanup(func() { _ = c.Close(); _ = s.Close() })
+	return redis.NewTracer(log).Wrap(c, "conn1"), s
+}
+
+func TestTracer_LogsBothDirections(t *testing.T) {
+	var log bytes.Buffer
+	client, server := traced(t, &log)
+
+	go func() {
+		buf := make([]byte, 64)
+		_, _ = server.Read(buf)
+		_, _ = server.Write([]byte("$5\r\nhello\r\n"))
+	}()
+	if _, err := client.Write(redis.Get("greeting").ToBytes()); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 64)
+	n, err := client.Read(buf)
+	if err != nil || string(buf[:n]) != "$5\r\nhello\r\n" {
+		t.Fatalf("read through the tracer: %q, %v", buf[:n], err)
+	}
+
+	out := log.String()
+	for _, want := range []string{
+		"conn1 > 27 bytes",
+		"conn1 < 11 bytes",
+		"2a 32 0d 0a",   // *2\r\n as hex
+		"|$5..hello..|", // the printable column
+		"|.greeting..|",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("trace missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTracer_RedactsAuth(t *testing.T) {
+	cases := map[string][]byte{
+		"multibulk": redis.Auth("alice", "s3cret").ToBytes(),
+		"inline":    []byte("auth s3cret\r\n"),
+	}
+	for name, frame := range cases {
+		t.Run(name, func(t *testing.T) {
+			var log bytes.Buffer
+			client, server := traced(t, &log)
+			go func() { _, _ = io.Copy(io.Discard, server) }()
+			if _, err := client.Write(frame); err != nil {
+				t.Fatal(err)
+			}
+			if out := log.String(); strings.Contains(out, "s3cret") || !strings.Contains(out, "redacted") {
+				t.Errorf("AUTH not redacted:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestTracer_NilIsNoop(t *testing.T) {
+	c, s := net.Pipe()
+	defer c.Close()
+	defer s.Close()
+	var tr *redis.Tracer
+	if got := tr.Wrap(c, "x"); got != c {
+		t.Errorf("nil Tracer should return the conn unchanged, got %T", got)
+	}
+}
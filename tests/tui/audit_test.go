@@ -0,0 +1,53 @@
+package tui_test
+
+import (
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestAudit_WritesOnlyThenAll verifies AUDIT opens on the writes, tagged
+// with user and profile, and that a brings in the reads.
+func TestAudit_WritesOnlyThenAll(t *testing.T) {
+	a := redis.NewAuditor(tui.AuditEntries, nil, "ada")
+	c, s := net.Pipe()
+	t.Cleanup(func() { _ = c.Close(); _ = s.Close() })
+	go func() { _, _ = io.Copy(io.Discard, s) }()
+	conn := a.Wrap(c, "staging", "127.0.0.1:6379")
+	for _, cmd := range []redis.RedisCmd{redis.Get("user:1"), {Name: "DEL", Args: []string{"user:1"}}} {
+		if _, err := conn.Write(cmd.ToBytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := newPickerMenuModel("AUDIT")
+	m.Audit = a
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateAudit {
+		t.Fatalf("state: want StateAudit, got %v", m.CurrentState)
+	}
+	view := m.View()
+	if !strings.Contains(view, "writes only · 1 kept") || !strings.Contains(view, "DEL user:1") || strings.Contains(view, "GET user:1") {
+		t.Fatalf("want the DEL alone, got:\n%s", view)
+	}
+	if !strings.Contains(view, "ada") || !strings.Contains(view, "staging") {
+		t.Errorf("want the write tagged with user and profile, got:\n%s", view)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if view := m.View(); !strings.Contains(view, "all commands · 2 kept") || !strings.Contains(view, "GET user:1") {
+		t.Errorf("a should show the reads too, got:\n%s", view)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateMenu {
+		t.Errorf("esc should return to the menu, got %v", m.CurrentState)
+	}
+}
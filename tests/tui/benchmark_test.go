@@ -0,0 +1,55 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestBenchmark_RunsAllTests runs PING, SET and GET against the mock server
+// with a depth that doesn't divide the request count, and checks the key is
+// cleaned up afterwards.
+func TestBenchmark_RunsAllTests(t *testing.T) {
+	srv, addr := startMock(t)
+
+	m := newPickerMenuModel("BENCHMARK")
+	m.RedisAddress = addr
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"50", "16", "7"}})
+	for cmd != nil {
+		msg := loadResult(cmd)
+		if msg == nil {
+			break
+		}
+		m, cmd = send(m, msg)
+	}
+
+	b := m.Benchmark
+	if b.Err != "" || len(b.Results) != 3 {
+		t.Fatalf("want three results, got %+v", b)
+	}
+	for _, r := range b.Results {
+		if r.Ops != 50 || r.P50 <= 0 || r.P99 < r.P50 || r.Max < r.P99 {
+			t.Errorf("%s: implausible result %+v", r.Test, r)
+		}
+	}
+	if m.CurrentState != tui.StateBenchmark || !strings.Contains(m.View(), "p99") {
+		t.Errorf("want the results table, got state %v", m.CurrentState)
+	}
+	if n := srv.Do(0, "DBSIZE"); n != 0 {
+		t.Errorf("benchmark key left behind: DBSIZE %v", n)
+	}
+}
+
+// TestBenchmark_ValidatesDepth verifies a zero pipeline depth is refused.
+func TestBenchmark_ValidatesDepth(t *testing.T) {
+	m := newPickerMenuModel("BENCHMARK")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = send(m, tui.FormSubmitMsg{Values: []string{"100", "64", "0"}})
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "pipeline depth") {
+		t.Errorf("want a depth error, got %v %q", m.CurrentState, m.Form.Err)
+	}
+}
@@ -0,0 +1,126 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestMenu_BlockingPop_OpensForm verifies that choosing BLPOP from the menu
+// opens the parameter form rather than a single-key prompt.
+func TestMenu_BlockingPop_OpensForm(t *testing.T) {
+	m := newPickerMenuModel("BLPOP")
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m2.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v", m2.CurrentState)
+	}
+	if len(m2.Form.Inputs) != 3 {
+		t.Errorf("form fields: want 3, got %d", len(m2.Form.Inputs))
+	}
+}
+
+// TestBlockingForm_InvalidTimeout_StaysOnForm verifies that a malformed
+// timeout is rejected inline instead of opening a connection.
+func TestBlockingForm_InvalidTimeout_StaysOnForm(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpBlockingPop
+	m.CurrentState = tui.StateForm
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"jobs", "soon", "left"}})
+
+	if m2.CurrentState != tui.StateForm {
+		t.Errorf("state: want StateForm, got %v", m2.CurrentState)
+	}
+	if m2.Form.Err == "" {
+		t.Error("expected a validation message on the form")
+	}
+	if cmd != nil {
+		t.Error("expected no command for an invalid form")
+	}
+}
+
+// TestBlockingForm_Valid_OpensConnection verifies a valid submission records
+// the watcher parameters and starts loading the dedicated connection.
+func TestBlockingForm_Valid_OpensConnection(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpBlockingPop
+	m.CurrentState = tui.StateForm
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"jobs", "3", "right"}})
+
+	if m2.CurrentState != tui.StateLoading {
+		t.Errorf("state: want StateLoading, got %v", m2.CurrentState)
+	}
+	if m2.Blocking.Command != "BRPOP" || m2.Blocking.Key != "jobs" || m2.Blocking.Timeout != 3 {
+		t.Errorf("unexpected watcher params: %+v", m2.Blocking)
+	}
+	if cmd == nil {
+		t.Error("expected a non-nil cmd")
+	}
+}
+
+// TestBlockingPop_ValueLoggedAndRearmed verifies a popped value is logged and
+// the next pop is issued on the same dedicated connection.
+func TestBlockingPop_ValueLoggedAndRearmed(t *testing.T) {
+	conn, reader := newMockConn("*2\r\n$4\r\njobs\r\n$2\r\nv2\r\n")
+	m := newTestModel()
+	m.CurrentState = tui.StateBlocking
+	m.Blocking = tui.BlockingModel{Key: "jobs", Command: "BLPOP", Timeout: 1, Conn: conn, Reader: reader, Waiting: true}
+
+	m2, cmd := send(m, tui.BlockingPopMsg{Conn: conn, Value: "v1"})
+
+	if len(m2.Blocking.Events) != 1 || m2.Blocking.Events[0].Value != "v1" {
+		t.Fatalf("events: want [v1], got %+v", m2.Blocking.Events)
+	}
+	if cmd == nil {
+		t.Fatal("expected the next pop to be issued")
+	}
+	next, ok := cmd().(tui.BlockingPopMsg)
+	if !ok || next.Value != "v2" {
+		t.Errorf("next pop: want value v2, got %#v", next)
+	}
+	if !strings.HasPrefix(conn.writtenData.String(), "*3\r\n$5\r\nBLPOP\r\n$4\r\njobs\r\n") {
+		t.Errorf("unexpected command written: %q", conn.writtenData.String())
+	}
+}
+
+// TestBlockingPop_StaleConnIgnored verifies a pop result from a watcher that
+// has already been stopped does not touch the log.
+func TestBlockingPop_StaleConnIgnored(t *testing.T) {
+	old, _ := newMockConn("")
+	m := newTestModel()
+	m.CurrentState = tui.StateMenu
+
+	m2, cmd := send(m, tui.BlockingPopMsg{Conn: old, Value: "late"})
+
+	if len(m2.Blocking.Events) != 0 {
+		t.Errorf("stale result should be dropped, got %+v", m2.Blocking.Events)
+	}
+	if cmd != nil {
+		t.Error("expected no follow-up command")
+	}
+}
+
+// TestBlocking_Esc_StopsAndReturns verifies esc closes the watcher and goes
+// back to the previous screen.
+func TestBlocking_Esc_StopsAndReturns(t *testing.T) {
+	conn, reader := newMockConn("")
+	m := newTestModel()
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu, tui.StateForm}
+	m.CurrentState = tui.StateBlocking
+	m.Blocking = tui.BlockingModel{Key: "jobs", Command: "BLPOP", Conn: conn, Reader: reader}
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEscape})
+
+	if m2.CurrentState != tui.StateForm {
+		t.Errorf("state: want StateForm, got %v", m2.CurrentState)
+	}
+	if m2.Blocking.Conn != nil {
+		t.Error("dedicated connection should be released")
+	}
+}
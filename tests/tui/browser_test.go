@@ -0,0 +1,57 @@
+package tui_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestBrowserModel_UpdateSendsRequests drives the browser on its own: keys
+// on the key list and the field list come back as messages for the root
+// model, and esc steps from the fields to the keys before leaving.
+func TestBrowserModel_UpdateSendsRequests(t *testing.T) {
+	b := newTestModel().Browser
+	b.KeyList.SetItems([]list.Item{tui.NewListItem("user:1", "hash")})
+	b.FieldsList.SetItems([]list.Item{tui.NewListItem("name", "ada")})
+
+	hit := func(key string) tea.Msg {
+		t.Helper()
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		var cmd tea.Cmd
+		b, cmd = b.Update(msg)
+		if cmd == nil {
+			return nil
+		}
+		return cmd()
+	}
+
+	if got := hit("enter"); got != (tui.SelectKeyMsg{Key: "user:1"}) || b.ActiveKey != "user:1" {
+		t.Fatalf("enter on a key: want SelectKeyMsg for user:1, got %#v", got)
+	}
+	if got := hit("d"); got != (tui.DeleteRequestMsg{Key: "user:1"}) {
+		t.Errorf("d on a key: want DeleteRequestMsg, got %#v", got)
+	}
+
+	b.ViewingFields, b.ActiveKeyType = true, "hash"
+	if got := hit("d"); got != (tui.DeleteRequestMsg{Key: "user:1", Field: "name"}) {
+		t.Errorf("d on a field: want DeleteRequestMsg for the field, got %#v", got)
+	}
+	if got := hit("v"); got != (tui.ExpandFieldMsg{Key: "user:1", Field: "name"}) {
+		t.Errorf("v on a field: want ExpandFieldMsg, got %#v", got)
+	}
+	if got := hit("esc"); got != nil || b.ViewingFields {
+		t.Errorf("esc on the fields should go back to the keys, got %#v", got)
+	}
+	if got := hit("esc"); got != (tui.BackMsg{}) {
+		t.Errorf("esc on the keys should leave the browser, got %#v", got)
+	}
+}
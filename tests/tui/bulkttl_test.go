@@ -0,0 +1,85 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestBulkTTL_OnlyKeysWithoutTTL runs EXPIRE_ALL end to end against the mock
+// server: the matching keys without a TTL are counted for confirmation, and
+// only they get one once confirmed.
+func TestBulkTTL_OnlyKeysWithoutTTL(t *testing.T) {
+	srv, addr := startMock(t)
+	for i := range 30 {
+		srv.Do(0, "SET", fmt.Sprintf("cache:%d", i), "v")
+	}
+	for i := range 5 {
+		srv.Do(0, "SET", fmt.Sprintf("cache:ttl:%d", i), "v", "EX", "100")
+	}
+	srv.Do(0, "SET", "user:1", "v")
+
+	m := newPickerMenuModel("EXPIRE_ALL")
+	m.RedisAddress = addr
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v", m.CurrentState)
+	}
+
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"cache:*", "3600", "y"}})
+	for cmd != nil && m.CurrentState != tui.StateConfirmation {
+		msg := loadResult(cmd)
+		if msg == nil {
+			break
+		}
+		m, cmd = send(m, msg)
+	}
+	if m.CurrentState != tui.StateConfirmation {
+		t.Fatalf("want the count confirmed first, got state %v, %+v", m.CurrentState, m.BulkTTL)
+	}
+	if view := m.View(); !strings.Contains(view, "30 keys matching cache:* that have no TTL (of 35 matched)") {
+		t.Errorf("want the count in the confirmation, got:\n%s", view)
+	}
+	if ttl, _ := srv.Do(0, "TTL", "cache:0").(int); ttl != -1 {
+		t.Fatalf("nothing should change before confirming, got TTL %d", ttl)
+	}
+
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	for cmd != nil && m.BulkTTL.Finished.IsZero() {
+		msg := loadResult(cmd)
+		if msg == nil {
+			break
+		}
+		m, cmd = send(m, msg)
+	}
+	if m.BulkTTL.Done != 30 || m.BulkTTL.Errors != 0 || m.BulkTTL.Err != "" {
+		t.Fatalf("want 30 keys changed without errors, got %+v", m.BulkTTL)
+	}
+	if !strings.Contains(m.View(), "done: TTL set on 30 keys") {
+		t.Errorf("progress screen should report completion:\n%s", m.View())
+	}
+	if ttl, _ := srv.Do(0, "TTL", "cache:7").(int); ttl <= 100 || ttl > 3600 {
+		t.Errorf("want cache:7 to expire within the hour, got %d", ttl)
+	}
+	if ttl, _ := srv.Do(0, "TTL", "cache:ttl:0").(int); ttl > 100 {
+		t.Errorf("a key with a TTL should keep it, got %d", ttl)
+	}
+	if ttl, _ := srv.Do(0, "TTL", "user:1").(int); ttl != -1 {
+		t.Errorf("a key outside the pattern should be untouched, got %d", ttl)
+	}
+}
+
+// TestBulkTTL_PersistNeedsEveryKey verifies removing expiry isn't combined
+// with only touching keys that have none.
+func TestBulkTTL_PersistNeedsEveryKey(t *testing.T) {
+	m := newPickerMenuModel("EXPIRE_ALL")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = send(m, tui.FormSubmitMsg{Values: []string{"cache:*", "0", "y"}})
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "answer n") {
+		t.Errorf("want a form error, got %v %q", m.CurrentState, m.Form.Err)
+	}
+}
@@ -0,0 +1,134 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+const clusterInfo = "cluster_state:ok\r\ncluster_slots_ok:16384\r\ncluster_known_nodes:3\r\ncluster_size:2\r\n"
+
+// clusterNodes is CLUSTER NODES output for two masters and one replica,
+// with a slot migration in progress on the first master.
+func clusterNodes(replicaAddr string) string {
+	return "aaaa1111 10.0.0.1:7000@17000 myself,master - 0 0 1 connected 0-10000 [10001->-bbbb2222]\n" +
+		"bbbb2222 10.0.0.2:7000@17000 master - 0 0 2 connected 10001-16383\n" +
+		"cccc3333 " + replicaAddr + "@17000 slave aaaa1111 0 0 1 connected\n"
+}
+
+func loadClusterModel(t *testing.T, replicaAddr string) tui.Model {
+	t.Helper()
+	conn, reader := newMockConn(bulk(clusterInfo) + bulk(clusterNodes(replicaAddr)))
+	m := newPickerMenuModel("CLUSTER")
+	m.Conn, m.Reader = conn, reader
+
+	m2, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m3, _ := send(m2, loadResult(cmd))
+	if m3.CurrentState != tui.StateCluster {
+		t.Fatalf("state: want StateCluster, got %v (%s)", m3.CurrentState, m3.Result.Text())
+	}
+	return m3
+}
+
+// TestCluster_ParsesNodes verifies nodes are listed masters first with their
+// replicas, slot ranges parsed, and migration markers skipped.
+func TestCluster_ParsesNodes(t *testing.T) {
+	m := loadClusterModel(t, "10.0.0.3:7000")
+
+	var got []string
+	for _, n := range m.Cluster.Nodes {
+		got = append(got, n.Addr)
+	}
+	want := []string{"10.0.0.1:7000", "10.0.0.3:7000", "10.0.0.2:7000"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("order: want %v, got %v", want, got)
+	}
+	if s := m.Cluster.Nodes[0].Slots; len(s) != 1 || s[0] != [2]int{0, 10000} {
+		t.Errorf("slots: got %v", s)
+	}
+	if !m.Cluster.Nodes[1].IsReplica() {
+		t.Error("cccc3333 should be a replica")
+	}
+	if m.Cluster.Info["cluster_state"] != "ok" {
+		t.Errorf("info: got %v", m.Cluster.Info)
+	}
+}
+
+// TestCluster_Standalone verifies a non-cluster server gets an explanation.
+func TestCluster_Standalone(t *testing.T) {
+	conn, reader := newMockConn("-ERR This instance has cluster support disabled\r\n-ERR This instance has cluster support disabled\r\n")
+	m := newPickerMenuModel("CLUSTER")
+	m.Conn, m.Reader = conn, reader
+
+	m2, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m3, _ := send(m2, loadResult(cmd))
+
+	if m3.CurrentState != tui.StateOutput || !strings.Contains(m3.Result.Text(), "not a cluster node") {
+		t.Errorf("want an explanation, got %v %q", m3.CurrentState, m3.Result.Text())
+	}
+}
+
+// TestCluster_ReshardPreview verifies the preview balances slots between the
+// masters without sending anything.
+func TestCluster_ReshardPreview(t *testing.T) {
+	m := loadClusterModel(t, "10.0.0.3:7000")
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+
+	if m2.CurrentState != tui.StateOutput {
+		t.Fatalf("state: want StateOutput, got %v", m2.CurrentState)
+	}
+	for _, want := range []string{"10001 slots", "gives 1809 slots (→ 8192)", "takes 1809 slots (→ 8192)"} {
+		if !strings.Contains(m2.Result.Text(), want) {
+			t.Errorf("preview missing %q:\n%s", want, m2.Result.Text())
+		}
+	}
+	m3, _ := send(m2, tea.KeyMsg{Type: tea.KeyEsc})
+	if m3.CurrentState != tui.StateCluster {
+		t.Errorf("esc should return to the overview, got %v", m3.CurrentState)
+	}
+}
+
+// TestCluster_FailoverOnlyOnReplica verifies f refuses a master and asks for
+// confirmation on a replica.
+func TestCluster_FailoverOnlyOnReplica(t *testing.T) {
+	m := loadClusterModel(t, "10.0.0.3:7000")
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if m2.CurrentState != tui.StateCluster || !m2.Cluster.Failed {
+		t.Fatalf("master: want a refusal, got %v %q", m2.CurrentState, m2.Cluster.Notice)
+	}
+
+	m3, _ := send(m2, tea.KeyMsg{Type: tea.KeyDown})
+	m4, _ := send(m3, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if m4.CurrentState != tui.StateConfirmation || m4.Cluster.Target != "10.0.0.3:7000" {
+		t.Errorf("replica: want a confirmation for 10.0.0.3:7000, got %v %q", m4.CurrentState, m4.Cluster.Target)
+	}
+}
+
+// TestCluster_FailoverSentToReplica verifies CLUSTER FAILOVER goes to the
+// selected replica over its own connection.
+func TestCluster_FailoverSentToReplica(t *testing.T) {
+	got := make(chan string, 1)
+	replica := startFakeRedis(t, func(cmd []string) string {
+		got <- strings.Join(cmd, " ")
+		return "+OK\r\n"
+	})
+	m := loadClusterModel(t, replica)
+	m.Cluster.Cursor = 1
+	m.Conn, m.Reader = newMockConn(bulk(clusterInfo) + bulk(clusterNodes(replica)))
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	m3, cmd := send(m2, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m4, _ := send(m3, loadResult(cmd))
+
+	if c := <-got; c != "CLUSTER FAILOVER" {
+		t.Errorf("replica received %q", c)
+	}
+	if m4.CurrentState != tui.StateCluster || m4.Cluster.Failed || !strings.Contains(m4.Cluster.Notice, "failover requested") {
+		t.Errorf("want a success notice on the overview, got %v %q", m4.CurrentState, m4.Cluster.Notice)
+	}
+}
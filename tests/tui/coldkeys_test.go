@@ -0,0 +1,64 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestColdKeys_ListsAndDeletes verifies keys idle past the threshold are
+// listed coldest first, and that d deletes just those after a confirmation.
+func TestColdKeys_ListsAndDeletes(t *testing.T) {
+	scan := "*2\r\n$1\r\n0\r\n*3\r\n$3\r\na:1\r\n$3\r\na:2\r\n$3\r\na:3\r\n"
+	idle := ":10\r\n:90000\r\n:700000\r\n"
+	del := ":1\r\n:1\r\n"
+	conn, reader := newMockConn(scan + idle + del)
+	m := newPickerMenuModel("COLD_KEYS")
+	m.Conn, m.Reader = conn, reader
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"a:*", "1d", "100"}})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateColdKeys {
+		t.Fatalf("want the report, got state %v, form error %q", m.CurrentState, m.Form.Err)
+	}
+	if got := m.ColdKeys.Keys; len(got) != 2 || got[0].Key != "a:3" || got[1].Key != "a:2" {
+		t.Fatalf("want a:3 then a:2, got %+v", got)
+	}
+	if view := m.View(); !strings.Contains(view, "2 of all 3 keys") || strings.Contains(view, "a:1") {
+		t.Errorf("want the two cold keys of three listed, got:\n%s", view)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if m.CurrentState != tui.StateConfirmation || !strings.Contains(m.View(), "delete 2 keys idle for at least 1d") {
+		t.Fatalf("want the delete confirmed first, got state %v:\n%s", m.CurrentState, m.View())
+	}
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m, _ = send(m, loadResult(cmd))
+
+	sent := conn.writtenData.String()
+	if !strings.Contains(sent, "$3\r\nDEL\r\n$3\r\na:3\r\n") || strings.Contains(sent, "$3\r\nDEL\r\n$3\r\na:1\r\n") {
+		t.Errorf("want only the cold keys deleted, sent %q", sent)
+	}
+	if m.CurrentState != tui.StateColdKeys || !strings.Contains(m.ColdKeys.Notice, "2 of 2 keys deleted") {
+		t.Errorf("want the report back with the deletion noted, got state %v, %q", m.CurrentState, m.ColdKeys.Notice)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateForm || m.SelectedOp != tui.OpColdKeys {
+		t.Errorf("esc should return to the report's form, got state %v, op %v", m.CurrentState, m.SelectedOp)
+	}
+}
+
+func TestColdKeys_IdleThreshold(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpColdKeys
+	m.CurrentState = tui.StateForm
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"a:*", "a week", "100"}})
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "like 7d") || cmd != nil {
+		t.Errorf("want a form error, got state %v %q", m.CurrentState, m.Form.Err)
+	}
+}
@@ -0,0 +1,133 @@
+package tui_test
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// hashServer answers TYPE/TTL/HLEN/HGETALL for a single hash key.
+func hashServer(t *testing.T, fields map[string]string) string {
+	return startFakeRedis(t, func(cmd []string) string {
+		switch cmd[0] {
+		case "TYPE":
+			return "+hash\r\n"
+		case "TTL":
+			return ":-1\r\n"
+		case "HLEN":
+			return ":" + strconv.Itoa(len(fields)) + "\r\n"
+		case "HGETALL":
+			out := "*" + strconv.Itoa(2*len(fields)) + "\r\n"
+			for k, v := range fields {
+				out += bulk(k) + bulk(v)
+			}
+			return out
+		}
+		return "-ERR unexpected\r\n"
+	})
+}
+
+func bulk(s string) string { return "$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n" }
+
+// TestMenu_Compare_OpensForm verifies COMPARE opens its form with the other
+// configured profile pre-filled as side B.
+func TestMenu_Compare_OpensForm(t *testing.T) {
+	m := newPickerMenuModel("COMPARE")
+	m.ProfileName = "staging"
+	m.Config = tui.Config{Profiles: []tui.Profile{{Name: "staging", Host: "a:1"}, {Name: "prod", Host: "b:1"}}}
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m2.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v", m2.CurrentState)
+	}
+	if got := m2.Form.Values(); got[1] != "current" || got[2] != "prod" {
+		t.Errorf("sides: want current / prod, got %v", got)
+	}
+}
+
+// TestCompareForm_UnknownProfile verifies an unknown profile is reported on
+// the form with the names that do exist.
+func TestCompareForm_UnknownProfile(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpCompare
+	m.CurrentState = tui.StateForm
+	m.Config = tui.Config{Profiles: []tui.Profile{{Name: "prod", Host: "b:1"}}}
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"k", "current", "prdo"}})
+
+	if m2.CurrentState != tui.StateForm || !strings.Contains(m2.Form.Err, "prod") {
+		t.Errorf("want form error listing prod, got %q", m2.Form.Err)
+	}
+	if cmd != nil {
+		t.Error("expected no command")
+	}
+}
+
+// TestCompareForm_PasswordCommandFails verifies a side whose profile runs
+// a password_command is resolved behind the loading screen, and that its
+// failure comes back to the form.
+func TestCompareForm_PasswordCommandFails(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	m := newTestModel()
+	m.SelectedOp = tui.OpCompare
+	m.CurrentState = tui.StateForm
+	m.Config = tui.Config{Profiles: []tui.Profile{{Name: "prod", Host: "b:1", PasswordCommand: "echo 'vault is locked' >&2; exit 1"}}}
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"k", "current", "prod"}})
+	if m2.CurrentState != tui.StateLoading || cmd == nil {
+		t.Fatalf("want the lookup behind the loading screen, got state %v", m2.CurrentState)
+	}
+	m2, _ = send(m2, loadResult(cmd))
+	if m2.CurrentState != tui.StateForm || !strings.Contains(m2.Form.Err, "vault is locked") {
+		t.Errorf("want the failure on the form, got state %v, %q", m2.CurrentState, m2.Form.Err)
+	}
+}
+
+// TestCompare_HashFieldDiff verifies a hash is diffed field by field across
+// two servers.
+func TestCompare_HashFieldDiff(t *testing.T) {
+	a := hashServer(t, map[string]string{"name": "ada", "role": "admin", "tz": "UTC"})
+	b := hashServer(t, map[string]string{"name": "ada", "role": "viewer", "lang": "en"})
+
+	m := newTestModel()
+	m.SelectedOp = tui.OpCompare
+	m.CurrentState = tui.StateForm
+	m.Config = tui.Config{Profiles: []tui.Profile{{Name: "staging", Host: a}, {Name: "prod", Host: b}}}
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"user:1", "staging", "prod"}})
+	if m2.CurrentState != tui.StateLoading {
+		t.Fatalf("state: want StateLoading, got %v (err %q)", m2.CurrentState, m2.Form.Err)
+	}
+	m3, _ := send(m2, loadResult(cmd))
+
+	if m3.CurrentState != tui.StateOutput {
+		t.Fatalf("state: want StateOutput, got %v", m3.CurrentState)
+	}
+	for _, want := range []string{"- tz = UTC", "+ lang = en", "~ role: admin → viewer", "1 identical"} {
+		if !strings.Contains(m3.Result.Text(), want) {
+			t.Errorf("output missing %q:\n%s", want, m3.Result.Text())
+		}
+	}
+}
+
+// TestCompare_Output_ReadOnly verifies 'e' doesn't turn a diff into an edit.
+func TestCompare_Output_ReadOnly(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpCompare
+	m.CurrentState = tui.StateOutput
+	m.Result = tui.Result{Value: "- a\n+ b"}
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+
+	if m2.CurrentState != tui.StateOutput {
+		t.Errorf("state: want StateOutput, got %v", m2.CurrentState)
+	}
+}
@@ -0,0 +1,98 @@
+package tui_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sendLines types each line into the console and delivers its reply.
+func sendLines(m tui.Model, lines ...string) tui.Model {
+	for _, line := range lines {
+		var cmd tea.Cmd
+		m, cmd = press(m, line, "enter")
+		for _, msg := range runAll(cmd, shortTimers) {
+			m, _ = send(m, msg)
+		}
+	}
+	return m
+}
+
+// TestConsole_HistorySearch verifies Ctrl+R finds the newest line containing
+// the query, steps to older ones, and Enter puts the match in the input
+// without sending it.
+func TestConsole_HistorySearch(t *testing.T) {
+	m, conn := openConsole(t, "+OK\r\n+OK\r\n+OK\r\n")
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m = sendLines(m, "ZADD board 1 alice", "GET a", "ZADD board 2 bob")
+	written := conn.writtenData.Len()
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("zadd")})
+	if view := m.View(); !strings.Contains(view, "(reverse-i-search)`zadd': ZADD board 2 bob") {
+		t.Fatalf("want the newest match shown, got:\n%s", view)
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if got := m.Console.Input.Value(); got != "ZADD board 1 alice" {
+		t.Errorf("want the older match in the input, got %q", got)
+	}
+	if m.Console.Searching || cmd != nil || conn.writtenData.Len() != written {
+		t.Error("accepting a match should leave search without sending")
+	}
+}
+
+// TestConsole_HistorySearchFailing verifies a query nothing matches says so,
+// and Esc leaves the input as it was.
+func TestConsole_HistorySearchFailing(t *testing.T) {
+	m, _ := openConsole(t, "+OK\r\n")
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m = sendLines(m, "GET a")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("dr")})
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("xyz")})
+	if view := m.View(); !strings.Contains(view, "(failing reverse-i-search)`xyz'") {
+		t.Errorf("want a failing search, got:\n%s", view)
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Console.Searching || m.CurrentState != tui.StateConsole || m.Console.Input.Value() != "dr" {
+		t.Errorf("esc should only leave search; input %q", m.Console.Input.Value())
+	}
+}
+
+// TestConsole_HistoryPersisted verifies lines are written to the profile's
+// history file, apart from ones carrying a password, and read back by the
+// next session.
+func TestConsole_HistoryPersisted(t *testing.T) {
+	dir := t.TempDir()
+	conn, reader := newMockConn("+OK\r\n+OK\r\n")
+	m := newPickerMenuModel("CONSOLE")
+	m.Conn, m.Reader = conn, reader
+	m.HistoryDir, m.ProfileName = dir, "prod"
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = sendLines(m, "AUTH admin s3cret", `EVAL "return 1" 0`)
+
+	data, err := os.ReadFile(filepath.Join(dir, "prod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "EVAL \"return 1\" 0\n" {
+		t.Fatalf("history file: got %q", got)
+	}
+
+	next := newPickerMenuModel("CONSOLE")
+	next.HistoryDir, next.ProfileName = dir, "prod"
+	next, cmd := send(next, tea.KeyMsg{Type: tea.KeyEnter})
+	for _, msg := range runAll(cmd, shortTimers) {
+		next, _ = send(next, msg)
+	}
+	next, _ = send(next, tea.KeyMsg{Type: tea.KeyUp})
+	if got := next.Console.Input.Value(); got != `EVAL "return 1" 0` {
+		t.Errorf("want the saved line recalled, got %q", got)
+	}
+}
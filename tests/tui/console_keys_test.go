@@ -0,0 +1,62 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+)
+
+// TestConsole_CompletesKeys verifies Tab on a key argument scans for keys
+// starting with what's typed, fills in what they share, lists them, and a
+// second Tab takes the first.
+func TestConsole_CompletesKeys(t *testing.T) {
+	m, conn := openConsole(t, "*2\r\n$1\r\n0\r\n*2\r\n$7\r\nuser:10\r\n$7\r\nuser:11\r\n")
+	m.WindowWidth, m.WindowHeight = 100, 30
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("GET us")})
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyTab})
+	if cmd == nil {
+		t.Fatal("tab on a key should scan")
+	}
+	m, _ = send(m, loadResult(cmd))
+	if !strings.Contains(conn.writtenData.String(), "$5\r\nMATCH\r\n$3\r\nus*\r\n") {
+		t.Errorf("want SCAN MATCH us*, got %q", conn.writtenData.String())
+	}
+	if got := m.Console.Input.Value(); got != "GET user:1" {
+		t.Errorf("want the shared prefix filled in, got %q", got)
+	}
+	if view := m.View(); !strings.Contains(view, "2 keys: user:10  user:11") {
+		t.Errorf("want the matches listed, got:\n%s", view)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyTab})
+	if got := m.Console.Input.Value(); got != "GET user:10 " {
+		t.Errorf("want the first match taken, got %q", got)
+	}
+}
+
+// TestConsole_CompletesQuotedKey verifies a single match is filled in whole,
+// quoted when it holds a space, and that the server's key positions decide
+// which arguments are keys.
+func TestConsole_CompletesQuotedKey(t *testing.T) {
+	m, _ := openConsole(t, "*2\r\n$1\r\n0\r\n*1\r\n$8\r\nmy queue\r\n")
+	m.Commands = redis.CommandTable{"LMOVE": {Name: "LMOVE", Arity: 5, FirstKey: 1, LastKey: 2, Step: 1}}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("LMOVE a m")})
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyTab})
+	if cmd == nil {
+		t.Fatal("the destination is a key; tab should scan")
+	}
+	m, _ = send(m, loadResult(cmd))
+	if got := m.Console.Input.Value(); got != `LMOVE a "my queue" ` {
+		t.Errorf("want the key quoted, got %q", got)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if _, cmd := send(m, tea.KeyMsg{Type: tea.KeyTab}); cmd != nil {
+		t.Error("the direction isn't a key; tab shouldn't scan")
+	}
+}
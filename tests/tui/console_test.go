@@ -0,0 +1,232 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+func openConsole(t *testing.T, data string) (tui.Model, *mockConn) {
+	t.Helper()
+	conn, reader := newMockConn(data)
+	m := newPickerMenuModel("CONSOLE")
+	m.Conn, m.Reader = conn, reader
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m2.CurrentState != tui.StateConsole {
+		t.Fatalf("state: want StateConsole, got %v", m2.CurrentState)
+	}
+	return m2, conn
+}
+
+// TestConsole_SendsQuotedArgs verifies quoted arguments are kept together
+// and array replies are numbered like redis-cli.
+func TestConsole_SendsQuotedArgs(t *testing.T) {
+	m, conn := openConsole(t, "*2\r\n$1\r\na\r\n*1\r\n:7\r\n")
+
+	m2, cmd := press(m, `HMGET user "full name" 'x y'`, "enter")
+	if !m2.Console.Busy || cmd == nil {
+		t.Fatal("enter should send the command")
+	}
+	m3, _ := send(m2, cmd())
+
+	if !strings.Contains(conn.writtenData.String(), "$9\r\nfull name\r\n$3\r\nx y\r\n") {
+		t.Errorf("quoted args not kept together: %q", conn.writtenData.String())
+	}
+	last := m3.Console.Log[len(m3.Console.Log)-1]
+	if last.Reply != "1) a\n2) 1) (integer) 7" {
+		t.Errorf("reply: got %q", last.Reply)
+	}
+}
+
+// TestConsole_ErrorReply verifies Redis errors are flagged.
+func TestConsole_ErrorReply(t *testing.T) {
+	m, _ := openConsole(t, "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n")
+
+	m2, cmd := press(m, "GET h", "enter")
+	m3, _ := send(m2, cmd())
+
+	if last := m3.Console.Log[0]; !last.Failed {
+		t.Errorf("WRONGTYPE should be flagged, got %+v", last)
+	}
+}
+
+// TestConsole_RefusesUnsafeCommands verifies streaming commands and
+// lifecycle commands without an admin profile are never sent.
+func TestConsole_RefusesUnsafeCommands(t *testing.T) {
+	for _, line := range []string{"SUBSCRIBE news", "monitor", "SHUTDOWN NOSAVE", `GET "unterminated`} {
+		m, conn := openConsole(t, "")
+
+		m2, cmd := press(m, line, "enter")
+
+		if cmd != nil || conn.writtenData.Len() != 0 {
+			t.Errorf("%q: should not be sent", line)
+		}
+		if len(m2.Console.Log) != 1 || !m2.Console.Log[0].Failed {
+			t.Errorf("%q: want a refusal in the log, got %+v", line, m2.Console.Log)
+		}
+	}
+}
+
+// TestCluster_NodeConsole verifies c on a node opens a console with its own
+// connection to that node, and commands go there.
+func TestCluster_NodeConsole(t *testing.T) {
+	got := make(chan string, 1)
+	node := startFakeRedis(t, func(cmd []string) string {
+		got <- strings.Join(cmd, " ")
+		return bulk("slowlog")
+	})
+	m := loadClusterModel(t, node)
+	m.Cluster.Cursor = 1 // the replica, at the fake node's address
+
+	m2, connect := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if m2.CurrentState != tui.StateConsole || !m2.Console.Pinned || m2.Console.Label != node {
+		t.Fatalf("want a console pinned to %s, got state %v label %q", node, m2.CurrentState, m2.Console.Label)
+	}
+	m3, _ := send(m2, connect())
+	t.Cleanup(func() { _ = m3.Console.Conn.Close() })
+
+	m4, cmd := press(m3, "SLOWLOG GET 1", "enter")
+	m5, _ := send(m4, cmd())
+
+	if c := <-got; c != "SLOWLOG GET 1" {
+		t.Errorf("node received %q", c)
+	}
+	if m5.Console.Log[0].Reply != "slowlog" {
+		t.Errorf("reply: got %+v", m5.Console.Log[0])
+	}
+	m6, _ := send(m5, tea.KeyMsg{Type: tea.KeyEsc})
+	if m6.CurrentState != tui.StateCluster || m6.Console.Conn != nil {
+		t.Errorf("esc should close the node connection and return to the overview, got %v", m6.CurrentState)
+	}
+}
+
+// TestCluster_NodeInfo verifies i shows INFO read from the selected node.
+func TestCluster_NodeInfo(t *testing.T) {
+	node := startFakeRedis(t, func(cmd []string) string {
+		return bulk("# Server\r\nrole:slave\r\n")
+	})
+	m := loadClusterModel(t, node)
+	m.Cluster.Cursor = 1
+
+	m2, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m3, _ := send(m2, loadResult(cmd))
+
+	if m3.CurrentState != tui.StateOutput || !strings.Contains(m3.Result.Text(), "role:slave") {
+		t.Fatalf("want the node's INFO, got %v %q", m3.CurrentState, m3.Result.Text())
+	}
+	if !strings.Contains(m3.View(), "INFO · "+node) {
+		t.Error("output should name the node")
+	}
+}
+
+// TestConsole_RejectsBadCommandName verifies a name with an escaped newline
+// is refused locally instead of being sent.
+func TestConsole_RejectsBadCommandName(t *testing.T) {
+	m, conn := openConsole(t, "")
+
+	m2, _ := press(m, `"GET\nx"`, "enter")
+
+	if last := m2.Console.Log[0]; !last.Failed || !strings.Contains(last.Reply, "control characters") {
+		t.Errorf("want a local refusal, got %+v", last)
+	}
+	if conn.writtenData.Len() != 0 {
+		t.Errorf("nothing should be sent, got %q", conn.writtenData.String())
+	}
+}
+
+// TestConsole_CompletesSubcommands verifies Tab completes a container
+// command's subcommand from the server's table.
+func TestConsole_CompletesSubcommands(t *testing.T) {
+	m := newPickerMenuModel("CONSOLE")
+	m.Commands = redis.CommandTable{"CONFIG": {Name: "CONFIG", Arity: -2, Subcommands: []string{"GET", "SET"}}}
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("config s")})
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyTab})
+	if got := m.Console.Input.Value(); got != "CONFIG SET " {
+		t.Errorf("want CONFIG SET completed, got %q", got)
+	}
+	if view := m.View(); !strings.Contains(view, "CONFIG SET parameter value [parameter value ...]") {
+		t.Errorf("want the CONFIG SET syntax hint, got:\n%s", view)
+	}
+}
+
+// TestConsole_SyntaxHint verifies the hint under the input: the syntax of a
+// known command, the arity of one only the server knows, and a note for a
+// command the server doesn't have.
+func TestConsole_SyntaxHint(t *testing.T) {
+	cases := map[string]string{
+		"hset u":   "HSET key field value [field value ...]",
+		"geoadd ":  "GEOADD takes at least 4 arguments",
+		"config ":  "CONFIG GET|SET",
+		"frobnic ": "FROBNIC isn't a command this server knows",
+	}
+	for typed, want := range cases {
+		m, _ := openConsole(t, "")
+		m.WindowWidth, m.WindowHeight = 100, 30
+		m.Commands = redis.CommandTable{
+			"HSET":   {Name: "HSET", Arity: -4},
+			"GEOADD": {Name: "GEOADD", Arity: -5},
+			"CONFIG": {Name: "CONFIG", Arity: -2, Subcommands: []string{"GET", "SET"}},
+		}
+		m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(typed)})
+		if view := m.View(); !strings.Contains(view, want) {
+			t.Errorf("%q: want hint %q, got:\n%s", typed, want, view)
+		}
+	}
+}
+
+// TestConsole_History verifies ↑/↓ walk the lines sent, come back to the
+// one being typed, and survive closing the console.
+func TestConsole_History(t *testing.T) {
+	m, _ := openConsole(t, "+OK\r\n+OK\r\n")
+	for _, line := range []string{"SET a 1", "GET a"} {
+		var cmd tea.Cmd
+		m, cmd = press(m, line, "enter")
+		m, _ = send(m, cmd())
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("dr")})
+
+	up, down := tea.KeyMsg{Type: tea.KeyUp}, tea.KeyMsg{Type: tea.KeyDown}
+	steps := []struct {
+		key  tea.KeyMsg
+		want string
+	}{{up, "GET a"}, {up, "SET a 1"}, {up, "SET a 1"}, {down, "GET a"}, {down, "dr"}, {down, "dr"}}
+	for i, s := range steps {
+		m, _ = send(m, s.key)
+		if got := m.Console.Input.Value(); got != s.want {
+			t.Fatalf("step %d: want %q, got %q", i, s.want, got)
+		}
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	m.MenuList = newPickerMenuModel("CONSOLE").MenuList
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = send(m, up)
+	if got := m.Console.Input.Value(); got != "GET a" {
+		t.Errorf("want history kept after reopening, got %q", got)
+	}
+}
+
+// TestConsole_PairTable verifies a name/value reply is shown as a table with
+// the values lined up, and Ctrl+Y offers it as CSV.
+func TestConsole_PairTable(t *testing.T) {
+	m, _ := openConsole(t, "*4\r\n$4\r\nname\r\n$3\r\nada\r\n$10\r\nlast_login\r\n$3\r\n170\r\n")
+	m = sendLines(m, "HGETALL user:1")
+
+	want := "   field       value\n1) name        ada\n2) last_login  170"
+	if got := m.Console.Log[0].Reply; got != want {
+		t.Errorf("table:\nwant %q\n got %q", want, got)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyCtrlY})
+	last := m.Console.Log[len(m.Console.Log)-1]
+	if last.Command != "" || !(strings.Contains(last.Reply, "copied 2 rows") || strings.Contains(last.Reply, "Clipboard unavailable")) {
+		t.Errorf("want the copy reported, got %+v", last)
+	}
+}
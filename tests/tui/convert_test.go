@@ -0,0 +1,88 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// openConvert opens CONVERT on key from its key menu.
+func openConvert(t *testing.T, m tui.Model) tui.Model {
+	t.Helper()
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	m, _ = send(m, loadResult(cmd))
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if m.CurrentState != tui.StateForm || m.SelectedOp != tui.OpConvert {
+		t.Fatalf("want the CONVERT form, got state %v, op %v", m.CurrentState, m.SelectedOp)
+	}
+	return m
+}
+
+// TestConvert_JSONToHash verifies a JSON string is previewed as hash fields,
+// non-string members kept as JSON text, and that d writes the hash with the
+// TTL and deletes the original.
+func TestConvert_JSONToHash(t *testing.T) {
+	value := `{"name":"ada","age":36,"tags":["x"]}`
+	read := fmt.Sprintf("+string\r\n:60\r\n$%d\r\n%s\r\n:0\r\n", len(value), value)
+	write := ":0\r\n:3\r\n:1\r\n:1\r\n"
+	m, conn := browseKeys(read+write, "user:1")
+
+	m = openConvert(t, m)
+	m, cmd := fillForm(m, "user:1", "user:1:h")
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateConfirmation {
+		t.Fatalf("want the preview, got state %v, form error %q", m.CurrentState, m.Form.Err)
+	}
+	view := m.View()
+	for _, want := range []string{"HSET user:1:h", "age   36", `tags  ["x"]`, "EXPIRE user:1:h 60", "[d] convert, delete the original"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want %q in the preview, got:\n%s", want, view)
+		}
+	}
+
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m, _ = send(m, loadResult(cmd))
+	sent := conn.writtenData.String()
+	if !strings.Contains(sent, "$3\r\nage\r\n$2\r\n36\r\n") || !strings.Contains(sent, "$3\r\nDEL\r\n$6\r\nuser:1\r\n") {
+		t.Errorf("want the fields written and user:1 deleted, sent %q", sent)
+	}
+	if m.CurrentState != tui.StateOutput || !strings.Contains(m.Result.Value, "user:1 deleted") {
+		t.Errorf("want the conversion reported, got %+v", m.Result)
+	}
+}
+
+// TestConvert_HashInPlace verifies a hash converted in place is replaced by
+// its JSON in one transaction.
+func TestConvert_HashInPlace(t *testing.T) {
+	read := "+hash\r\n:-1\r\n:2\r\n*4\r\n$1\r\nb\r\n$1\r\n2\r\n$1\r\na\r\n$1\r\n1\r\n"
+	write := "+OK\r\n+QUEUED\r\n+QUEUED\r\n*2\r\n:1\r\n+OK\r\n"
+	m, conn := browseKeys(read+write, "cfg")
+
+	m = openConvert(t, m)
+	m, cmd := fillForm(m, "cfg", "")
+	m, _ = send(m, loadResult(cmd))
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m, _ = send(m, loadResult(cmd))
+
+	sent := conn.writtenData.String()
+	if !strings.Contains(sent, "MULTI") || !strings.Contains(sent, `{"a":"1","b":"2"}`) || !strings.Contains(sent, "EXEC") {
+		t.Errorf("want MULTI, SET cfg with the JSON, EXEC, sent %q", sent)
+	}
+	if !strings.Contains(m.Result.Value, "Converted cfg from hash to string") {
+		t.Errorf("want the conversion reported, got %+v", m.Result)
+	}
+}
+
+func TestConvert_NotJSONObject(t *testing.T) {
+	m, _ := browseKeys("+string\r\n:-1\r\n$5\r\nhello\r\n", "greeting")
+	m = openConvert(t, m)
+	m, cmd := fillForm(m, "greeting", "")
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "not a JSON object") {
+		t.Errorf("want the form back with an error, got state %v, %q", m.CurrentState, m.Form.Err)
+	}
+}
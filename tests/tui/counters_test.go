@@ -0,0 +1,122 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+func newCountersModel() tui.Model {
+	m := newTestModel()
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu, tui.StateForm}
+	m.CurrentState = tui.StateCounters
+	m.Counters = tui.CountersModel{Patterns: []string{"rl:*"}, Interval: time.Second, Active: true, Gen: 1}
+	return m
+}
+
+// TestMenu_Counters_OpensForm verifies COUNTERS opens its form with the
+// rate-limit preset.
+func TestMenu_Counters_OpensForm(t *testing.T) {
+	m := newPickerMenuModel("COUNTERS")
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m2.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v", m2.CurrentState)
+	}
+	if got := m2.Form.Values(); got[0] != "ratelimit:*" {
+		t.Errorf("pattern default: want ratelimit:*, got %q", got[0])
+	}
+}
+
+// TestCountersForm_Valid_ScansAndSamples verifies a submission scans the
+// pattern, pipelines GET + PTTL, and skips keys that expired mid-sample.
+func TestCountersForm_Valid_ScansAndSamples(t *testing.T) {
+	conn, reader := newMockConn(
+		"*2\r\n$1\r\n0\r\n*2\r\n$4\r\nrl:a\r\n$4\r\nrl:b\r\n" + // SCAN
+			"$1\r\n7\r\n:1500\r\n" + // rl:a
+			"$-1\r\n:-2\r\n") // rl:b expired
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.SelectedOp = tui.OpCounters
+	m.CurrentState = tui.StateForm
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"rl:*", "1"}})
+	if m2.CurrentState != tui.StateLoading {
+		t.Fatalf("state: want StateLoading, got %v", m2.CurrentState)
+	}
+	m3, next := send(m2, loadResult(cmd))
+
+	if m3.CurrentState != tui.StateCounters {
+		t.Fatalf("state: want StateCounters, got %v", m3.CurrentState)
+	}
+	rows := m3.Counters.Rows
+	if len(rows) != 1 || rows[0].Key != "rl:a" || rows[0].Value != "7" || rows[0].PTTL != 1500 {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+	if !strings.Contains(conn.writtenData.String(), "PTTL") {
+		t.Errorf("expected PTTL lookups, got %q", conn.writtenData.String())
+	}
+	if next == nil {
+		t.Error("expected the refresh tick to be scheduled")
+	}
+}
+
+// TestCounterSample_Delta verifies the change since the previous sample is
+// tracked per key.
+func TestCounterSample_Delta(t *testing.T) {
+	m := newCountersModel()
+	m, _ = send(m, tui.CounterSampleMsg{Gen: 1, Rows: []tui.CounterRow{{Key: "rl:a", Value: "3", Numeric: true, PTTL: 900}}})
+	m, _ = send(m, tui.CounterSampleMsg{Gen: 1, Rows: []tui.CounterRow{{Key: "rl:a", Value: "8", Numeric: true, PTTL: 800}}})
+
+	r := m.Counters.Rows[0]
+	if !r.HasDelta || r.Delta != 5 {
+		t.Errorf("delta: want +5, got %+v", r)
+	}
+}
+
+// TestCounters_SortByValue verifies 's' orders the table by value, highest
+// first.
+func TestCounters_SortByValue(t *testing.T) {
+	m := newCountersModel()
+	m, _ = send(m, tui.CounterSampleMsg{Gen: 1, Rows: []tui.CounterRow{
+		{Key: "a", Value: "1", Numeric: true, PTTL: -1},
+		{Key: "b", Value: "9", Numeric: true, PTTL: -1},
+	}})
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+
+	if m.Counters.Rows[0].Key != "b" {
+		t.Errorf("want b first when sorted by value, got %q", m.Counters.Rows[0].Key)
+	}
+}
+
+// TestCounters_View_FlagsMissingExpiry verifies a counter without a TTL is
+// called out in the table.
+func TestCounters_View_FlagsMissingExpiry(t *testing.T) {
+	m := newCountersModel()
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m, _ = send(m, tui.CounterSampleMsg{Gen: 1, Rows: []tui.CounterRow{{Key: "rl:leak", Value: "42", Numeric: true, PTTL: -1}}})
+
+	view := m.View()
+	if !strings.Contains(view, "rl:leak") || !strings.Contains(view, "no expiry") {
+		t.Error("expected the key and a missing-expiry flag in the view")
+	}
+}
+
+// TestCounters_Esc_StopsTick verifies closing the inspector ends its tick.
+func TestCounters_Esc_StopsTick(t *testing.T) {
+	m := newCountersModel()
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEscape})
+	if m2.CurrentState != tui.StateForm || m2.Counters.Active {
+		t.Fatalf("want inactive inspector back on the form, got %v", m2.CurrentState)
+	}
+	if _, cmd := send(m2, tui.CounterTickMsg{Gen: 1}); cmd != nil {
+		t.Error("tick should stop once the inspector is closed")
+	}
+}
@@ -0,0 +1,73 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// fillForm sets the open form's fields and submits them.
+func fillForm(m tui.Model, values ...string) (tui.Model, tea.Cmd) {
+	for i, v := range values {
+		m.Form.Inputs[i].SetValue(v)
+	}
+	return send(m, tui.FormSubmitMsg{Values: m.Form.Values()})
+}
+
+// TestCreateKey_Zset verifies + walks from the type to a form for the key,
+// its first member and score, writes it only once the score is a number,
+// and goes back to a rescanned key list.
+func TestCreateKey_Zset(t *testing.T) {
+	m, conn := browseKeys(":0\r\n:1\r\n:1\r\n", "user:1")
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateCreateKey {
+		t.Fatalf("want the type picker, got state %v", m.CurrentState)
+	}
+	for range 4 {
+		m, _ = send(m, tea.KeyMsg{Type: tea.KeyDown})
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Title, "zset") || len(m.Form.Inputs) != 4 {
+		t.Fatalf("want the zset form, got state %v, %q", m.CurrentState, m.Form.Title)
+	}
+
+	m, _ = fillForm(m, "scores", "ada", "high", "60")
+	if !strings.Contains(m.Form.Err, "score") || conn.writtenData.Len() != 0 {
+		t.Fatalf("want the score refused before anything is sent, got %q, sent %q", m.Form.Err, conn.writtenData.String())
+	}
+
+	m, cmd = fillForm(m, "scores", "ada", "3.5", "60")
+	m, _ = send(m, loadResult(cmd))
+	sent := conn.writtenData.String()
+	for _, want := range []string{"EXISTS", "$4\r\nZADD\r\n$6\r\nscores\r\n$3\r\n3.5\r\n$3\r\nada\r\n", "EXPIRE"} {
+		if !strings.Contains(sent, want) {
+			t.Errorf("want %q sent, sent %q", want, sent)
+		}
+	}
+	if m.CurrentState != tui.StateLoading || !strings.Contains(m.Toast.Text, "Created zset scores") {
+		t.Errorf("want a toast and the key list rescanned, got state %v, toast %q", m.CurrentState, m.Toast.Text)
+	}
+}
+
+// TestCreateKey_Exists verifies an existing name sends the user back to the
+// form without writing anything.
+func TestCreateKey_Exists(t *testing.T) {
+	m, conn := browseKeys(":1\r\n", "greeting")
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	m, _ = send(m, loadResult(cmd))
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	m, cmd = fillForm(m, "greeting", "hi", "0")
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "already exists") {
+		t.Errorf("want the form back with an error, got state %v, %q", m.CurrentState, m.Form.Err)
+	}
+	if strings.Contains(conn.writtenData.String(), "SET") {
+		t.Errorf("nothing should be written, sent %q", conn.writtenData.String())
+	}
+}
@@ -0,0 +1,94 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+func localDate(sec int64) string {
+	return time.Unix(sec, 0).Local().Format("2006-01-02 15:04:05 MST")
+}
+
+func newDatesOutputModel(value string) tui.Model {
+	m := newTestModel()
+	m.Dates = true
+	m.ActiveKey = "job:42:due"
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateLoading
+	m, _ = send(m, tea.WindowSizeMsg{Width: 120, Height: 30})
+	m, _ = send(m, tui.RedisResultMsg{Result: value, Command: "GET job:42:due"})
+	return m
+}
+
+// TestDates_Value verifies a value in unix seconds or milliseconds is shown
+// as a date beside the output, t hides it, and other numbers get none.
+func TestDates_Value(t *testing.T) {
+	want := localDate(1700000000)
+	for _, v := range []string{"1700000000", "1700000000000", "1700000000.25"} {
+		m := newDatesOutputModel(v)
+		if !strings.Contains(m.View(), "as date: "+want) {
+			t.Errorf("%s: want %q beside the value:\n%s", v, want, m.View())
+		}
+		if m.Result.Value != v {
+			t.Errorf("%s: the value itself must stay raw, got %q", v, m.Result.Value)
+		}
+	}
+
+	m := newDatesOutputModel("1700000000")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if m.Dates || strings.Contains(m.View(), "as date:") {
+		t.Error("t should turn dates off")
+	}
+
+	for _, v := range []string{"42", "hello", "99999999999999999"} {
+		if view := newDatesOutputModel(v).View(); strings.Contains(view, "as date:") {
+			t.Errorf("%s read as a date", v)
+		}
+	}
+}
+
+// TestDates_TTL verifies a TTL is followed by the time the key expires.
+func TestDates_TTL(t *testing.T) {
+	m := newDatesOutputModel("hello")
+	m, _ = send(m, tui.RedisTTLResultMsg{TTL: 3600})
+	view := m.View()
+	if !strings.Contains(view, "TTL: 3600 s  expires "+time.Now().Add(time.Hour).Local().Format("2006-01-02")) {
+		t.Errorf("want the expiry date after the TTL:\n%s", view)
+	}
+	m, _ = send(m, tui.RedisTTLResultMsg{TTL: -1})
+	if strings.Contains(m.View(), "expires") {
+		t.Error("a key without expiry has no expiry date")
+	}
+}
+
+// TestDates_ZSetScores verifies scores that are unix times get a date column
+// in the sorted-set browser, toggled with t.
+func TestDates_ZSetScores(t *testing.T) {
+	m := newTestModel()
+	m.Dates = true
+	m.Browser.Width = 100
+	m.Browser.FieldsList.SetSize(100, 20)
+	m = newZSetModelFrom(t, m, []any{"job:1", "1700000000", "job:2", "3"})
+
+	items := m.Browser.FieldsList.Items()
+	if got := items[0].(tui.ListItem).When(); got != localDate(1700000000) {
+		t.Errorf("job:1: want its due date, got %q", got)
+	}
+	if got := items[1].(tui.ListItem).When(); got != "" {
+		t.Errorf("job:2: score 3 is not a date, got %q", got)
+	}
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if cmd == nil {
+		t.Fatal("t should toggle dates")
+	}
+	m, _ = send(m, cmd())
+	if m.Dates || m.Browser.FieldsList.Items()[0].(tui.ListItem).When() != "" {
+		t.Error("dates should be off and cleared")
+	}
+}
@@ -0,0 +1,143 @@
+package tui_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// stringServer serves a keyspace of plain strings with optional TTLs.
+func stringServer(t *testing.T, data map[string]string, ttls map[string]int) string {
+	return startFakeRedis(t, func(cmd []string) string {
+		switch cmd[0] {
+		case "SCAN":
+			keys := make([]string, 0, len(data))
+			for k := range data {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			out := "*2\r\n" + bulk("0") + "*" + strconv.Itoa(len(keys)) + "\r\n"
+			for _, k := range keys {
+				out += bulk(k)
+			}
+			return out
+		case "TYPE":
+			if _, ok := data[cmd[1]]; ok {
+				return "+string\r\n"
+			}
+			return "+none\r\n"
+		case "TTL":
+			if ttl, ok := ttls[cmd[1]]; ok {
+				return ":" + strconv.Itoa(ttl) + "\r\n"
+			}
+			return ":-1\r\n"
+		case "GET":
+			return bulk(data[cmd[1]])
+		}
+		return "-ERR unexpected\r\n"
+	})
+}
+
+func runDBDiff(t *testing.T, a, b string, values string) tui.Model {
+	t.Helper()
+	m := newTestModel()
+	m.SelectedOp = tui.OpDBDiff
+	m.CurrentState = tui.StateForm
+	m.Config = tui.Config{Profiles: []tui.Profile{{Name: "a", Host: a}, {Name: "b", Host: b}}}
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"*", "a", "b", values}})
+	if m2.CurrentState != tui.StateLoading {
+		t.Fatalf("state: want StateLoading, got %v (err %q)", m2.CurrentState, m2.Form.Err)
+	}
+	m3, _ := send(m2, loadResult(cmd))
+	if m3.CurrentState != tui.StateDBDiff {
+		t.Fatalf("state: want StateDBDiff, got %v (%s)", m3.CurrentState, m3.Result.Text())
+	}
+	return m3
+}
+
+// TestDBDiff_ExistenceAndTTL verifies one-sided keys and mismatched expiry
+// are reported, and that values are left alone unless asked for.
+func TestDBDiff_ExistenceAndTTL(t *testing.T) {
+	a := stringServer(t, map[string]string{"same": "1", "gone": "x", "exp": "v", "val": "old"}, map[string]int{"exp": 300})
+	b := stringServer(t, map[string]string{"same": "1", "new": "y", "exp": "v", "val": "new"}, nil)
+
+	m := runDBDiff(t, a, b, "n")
+
+	got := map[string]string{}
+	for _, e := range m.DBDiff.Entries {
+		got[e.Key] = e.Kind
+	}
+	want := map[string]string{"gone": tui.DiffOnlyA, "new": tui.DiffOnlyB, "exp": tui.DiffDifferent}
+	if len(got) != len(want) {
+		t.Fatalf("entries: want %v, got %v", want, got)
+	}
+	for k, kind := range want {
+		if got[k] != kind {
+			t.Errorf("%s: want %q, got %q", k, kind, got[k])
+		}
+	}
+	if m.DBDiff.Identical != 2 {
+		t.Errorf("identical: want 2 (same, val), got %d", m.DBDiff.Identical)
+	}
+}
+
+// TestDBDiff_Values verifies value comparison catches a changed string.
+func TestDBDiff_Values(t *testing.T) {
+	a := stringServer(t, map[string]string{"val": "old", "same": "1"}, nil)
+	b := stringServer(t, map[string]string{"val": "new", "same": "1"}, nil)
+
+	m := runDBDiff(t, a, b, "y")
+
+	if len(m.DBDiff.Entries) != 1 || m.DBDiff.Entries[0].Key != "val" || m.DBDiff.Entries[0].Detail != "value differs" {
+		t.Errorf("want val reported as value differs, got %+v", m.DBDiff.Entries)
+	}
+}
+
+// TestDBDiff_FilterAndExport verifies the filter keys narrow the report and
+// 'w' writes the full report to JSON and returns to the report.
+func TestDBDiff_FilterAndExport(t *testing.T) {
+	m := newTestModel()
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu, tui.StateForm}
+	m.CurrentState = tui.StateDBDiff
+	m.DBDiff = tui.DBDiffModel{Pattern: "*", Entries: []tui.DiffEntry{
+		{Key: "a", Kind: tui.DiffOnlyA},
+		{Key: "b", Kind: tui.DiffOnlyB},
+	}}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	if m.DBDiff.Filter != tui.DiffOnlyB {
+		t.Errorf("filter: want %q, got %q", tui.DiffOnlyB, m.DBDiff.Filter)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if m.CurrentState != tui.StateInputFilePath {
+		t.Fatalf("state: want StateInputFilePath, got %v", m.CurrentState)
+	}
+	path := filepath.Join(t.TempDir(), "report.json")
+	m, cmd := send(m, tui.InputCompleteMsg{Value: path, Type: tui.InputFilePath})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateDBDiff || !strings.Contains(m.Toast.Text, "2 entries") {
+		t.Fatalf("want the report back with a toast, got %v %q", m.CurrentState, m.Toast.Text)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report struct {
+		Entries []tui.DiffEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil || len(report.Entries) != 2 {
+		t.Errorf("report should hold both entries regardless of filter, got %s (%v)", data, err)
+	}
+
+}
@@ -0,0 +1,86 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// newDemoModel connects a model to a seeded in-process mock server, the same
+// setup -demo uses, for end-to-end tests without a Redis.
+func newDemoModel(t *testing.T) tui.Model {
+	t.Helper()
+	srv, addr := startMock(t)
+	srv.Seed()
+
+	m := newTestModel()
+	m.RedisAddress = addr
+	_, connect := send(m, tui.TickMsg{})
+	m, _ = send(m, connect())
+	if m.Conn == nil {
+		t.Fatal("could not connect to the demo server")
+	}
+	t.Cleanup(func() { _ = m.Conn.Close() })
+	return m
+}
+
+// TestDemo_Counters verifies the counter inspector end to end: SCAN, GET and
+// PTTL against the seeded rate limiters, with the one lacking an expiry
+// flagged.
+func TestDemo_Counters(t *testing.T) {
+	m := newDemoModel(t)
+	m.SelectedOp = tui.OpCounters
+	m.CurrentState = tui.StateForm
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"ratelimit:*", "60"}})
+	m3, _ := send(m2, loadResult(cmd))
+
+	rows := m3.Counters.Rows
+	if len(rows) != 2 {
+		t.Fatalf("want both seeded limiters, got %+v", rows)
+	}
+	for _, r := range rows {
+		switch r.Key {
+		case "ratelimit:api:user:1001":
+			if r.Value != "17" || r.PTTL <= 0 {
+				t.Errorf("%s: want 17 with a TTL, got %+v", r.Key, r)
+			}
+		case "ratelimit:api:user:1002":
+			if r.PTTL != -1 {
+				t.Errorf("%s: want no expiry, got %+v", r.Key, r)
+			}
+		}
+	}
+}
+
+// TestDemo_Console verifies console commands reach the demo server and come
+// back formatted.
+func TestDemo_Console(t *testing.T) {
+	m := newDemoModel(t)
+	m.MenuList = newPickerMenuModel("CONSOLE").MenuList
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	m2, cmd := press(m, "ZRANGE leaderboard:weekly -2 -1", "enter")
+	m3, _ := send(m2, cmd())
+
+	if got := m3.Console.Log[0].Reply; got != "1) grace\n2) ada" {
+		t.Errorf("reply: got %q", got)
+	}
+}
+
+// TestDemo_Standalone verifies the cluster screen explains a standalone
+// server, as against a real one.
+func TestDemo_Standalone(t *testing.T) {
+	m := newDemoModel(t)
+	m.MenuList = newPickerMenuModel("CLUSTER").MenuList
+
+	m2, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m3, _ := send(m2, loadResult(cmd))
+
+	if !strings.Contains(m3.Result.Text(), "not a cluster node") {
+		t.Errorf("want an explanation, got %q", m3.Result.Text())
+	}
+}
@@ -0,0 +1,82 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestDialog_EnterTakesTheDefault verifies enter runs a dialog's default
+// choice, and does nothing on a dialog without one.
+func TestDialog_EnterTakesTheDefault(t *testing.T) {
+	m := newTestModel()
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m.StateNavigationHistory = []tui.AppState{tui.StateBrowser}
+	m, _ = send(m, tui.LargeValueMsg{Value: tui.LargeValue{Key: "big", Type: "string", Bytes: 1 << 20}})
+	if view := m.View(); m.CurrentState != tui.StateConfirmation || !strings.Contains(view, "[p / enter] preview") || strings.Contains(view, "⚠") {
+		t.Fatalf("want a plain dialog with preview as its default, got state %v:\n%s", m.CurrentState, view)
+	}
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateLoading || m.SelectedOp != tui.OpLargePreview || cmd == nil {
+		t.Errorf("want the preview fetched, got state %v op %v", m.CurrentState, m.SelectedOp)
+	}
+
+	m = newTestModel()
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m.CurrentState = tui.StateBrowser
+	m, _ = send(m, tui.DeleteRequestMsg{Key: "k"})
+	if view := m.View(); !strings.Contains(view, "⚠  confirm delete") || !strings.Contains(view, "[y] confirm") {
+		t.Fatalf("want a warning dialog, got:\n%s", view)
+	}
+	if m, cmd = send(m, tea.KeyMsg{Type: tea.KeyEnter}); m.CurrentState != tui.StateConfirmation || cmd != nil {
+		t.Errorf("enter should not confirm a delete, got state %v", m.CurrentState)
+	}
+	if m, _ = press(m, "n"); m.CurrentState != tui.StateBrowser {
+		t.Errorf("n should go back to the browser, got state %v", m.CurrentState)
+	}
+}
+
+// TestDialog_UpdateAndView drives a Dialog on its own: the choice each key
+// picks, cancel, keys that do nothing, and what it draws.
+func TestDialog_UpdateAndView(t *testing.T) {
+	d := tui.Dialog{
+		Title: "save", Label: "file", Value: "out.json", Default: "o",
+		Choices: []tui.DialogChoice{{Key: "o", Label: "overwrite", Destructive: true}, {Key: "a", Label: "append"}},
+	}
+	for _, tt := range []struct {
+		key    tea.KeyMsg
+		want   tui.DialogOutcome
+		chosen string
+	}{
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}, tui.DialogChosen, "a"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")}, tui.DialogChosen, "a"},
+		{tea.KeyMsg{Type: tea.KeyEnter}, tui.DialogChosen, "o"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}, tui.DialogCancelled, ""},
+		{tea.KeyMsg{Type: tea.KeyEsc}, tui.DialogCancelled, ""},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}, tui.DialogIgnored, ""},
+	} {
+		if c, got := d.Update(tt.key); got != tt.want || c.Key != tt.chosen {
+			t.Errorf("%s: want %v %q, got %v %q", tt.key, tt.want, tt.chosen, got, c.Key)
+		}
+	}
+	d.Default = ""
+	if _, got := d.Update(tea.KeyMsg{Type: tea.KeyEnter}); got != tui.DialogIgnored {
+		t.Errorf("enter without a default should do nothing, got %v", got)
+	}
+
+	body, keys := d.View()
+	for _, want := range []string{"save", "file", "out.json"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("want %q in the body, got %q", want, body)
+		}
+	}
+	if strings.Contains(body, "⚠") {
+		t.Errorf("a dialog that isn't destructive shouldn't warn, got %q", body)
+	}
+	if !strings.Contains(keys, "[o] overwrite") || !strings.Contains(keys, "[a] append") || !strings.Contains(keys, "[n / esc] cancel") {
+		t.Errorf("want every choice and cancel among the keys, got %q", keys)
+	}
+}
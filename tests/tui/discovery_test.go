@@ -0,0 +1,90 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestDiscovery_DemoServer verifies the session asks the server for its
+// commands once connected, and disables the menu tools it can't run: the
+// demo server has no PSUBSCRIBE, so TIMELINE is off and EXPLORE stays on.
+func TestDiscovery_DemoServer(t *testing.T) {
+	_, addr := startMock(t)
+
+	m := newTestModel()
+	m.RedisAddress = addr
+	m.MenuList = list.New([]list.Item{tui.NewListItem("EXPLORE", "desc"), tui.NewListItem("TIMELINE", "desc")}, tui.NewGroupedMenuDelegate(), 80, 20)
+	_, connect := send(m, tui.TickMsg{})
+	m, discover := send(m, connect())
+	if m.Conn == nil || discover == nil {
+		t.Fatal("want a connection, then discovery")
+	}
+	t.Cleanup(func() { _ = m.Conn.Close() })
+	if m.Commands != nil {
+		t.Fatal("no command table before discovery")
+	}
+	m, _ = send(m, discover())
+	if !m.Commands.Has("SCAN") || m.Commands.Has("PSUBSCRIBE") {
+		t.Fatalf("want the demo server's table, got %v", m.Commands.Names())
+	}
+
+	items := m.MenuList.Items()
+	if got := items[0].(tui.ListItem).Missing(); got != "" {
+		t.Errorf("EXPLORE: want available, got missing %q", got)
+	}
+	if got := items[1].(tui.ListItem).Missing(); got != "PSUBSCRIBE" {
+		t.Errorf("TIMELINE: want missing PSUBSCRIBE, got %q", got)
+	}
+	if view := m.MenuList.View(); !strings.Contains(view, "unavailable: this server has no PSUBSCRIBE") {
+		t.Errorf("want the disabled row explained, got:\n%s", view)
+	}
+}
+
+// TestDiscovery_RefusesDisabledTool verifies Enter on a disabled tool shows
+// why instead of opening it.
+func TestDiscovery_RefusesDisabledTool(t *testing.T) {
+	m := newPickerMenuModel("BLPOP")
+	m, _ = send(m, tui.CommandsMsg{Commands: redis.CommandTable{"LPUSH": {Name: "LPUSH"}}})
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateOutput || !strings.Contains(m.Result.Text(), "doesn't implement BLPOP") {
+		t.Errorf("want a refusal naming BLPOP, got state %v, %q", m.CurrentState, m.Result.Text())
+	}
+}
+
+// TestDiscovery_StaleConnectionIgnored verifies a table from a connection
+// since replaced is dropped.
+func TestDiscovery_StaleConnectionIgnored(t *testing.T) {
+	m := newTestModel()
+	conn, _ := newMockConn("")
+	m.Conn = conn
+	old, _ := newMockConn("")
+
+	m, _ = send(m, tui.CommandsMsg{Conn: old, Commands: redis.CommandTable{}})
+	if m.Commands != nil {
+		t.Errorf("want the stale table ignored, got %v", m.Commands)
+	}
+}
+
+// TestConsole_CompletesServerCommands verifies Tab completes a command name
+// from the server's table, in its spelling.
+func TestConsole_CompletesServerCommands(t *testing.T) {
+	m := newPickerMenuModel("CONSOLE")
+	m.Commands = redis.CommandTable{"HGETALL": {Name: "HGETALL"}, "GET": {Name: "GET"}}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateConsole {
+		t.Fatalf("want the console, got %v", m.CurrentState)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("hgeta")})
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyTab})
+	if got := m.Console.Input.Value(); got != "HGETALL " {
+		t.Errorf("want HGETALL completed, got %q", got)
+	}
+}
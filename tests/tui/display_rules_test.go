@@ -0,0 +1,87 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestDisplayRules_ListFromTail verifies a list under a list_from "tail" rule
+// is read from its last element and listed last first.
+func TestDisplayRules_ListFromTail(t *testing.T) {
+	m, conn := browseKeys("+list\r\n*3\r\n$1\r\na\r\n$1\r\nb\r\n$1\r\nc\r\n", "queue:mail")
+	m.Config.Display = []tui.DisplayRule{{Keys: "queue:*", ListFrom: "tail"}}
+
+	m = openKey(t, m, "queue:mail")
+	if !strings.Contains(conn.writtenData.String(), "$4\r\n-100\r\n$2\r\n-1\r\n") {
+		t.Errorf("want LRANGE queue:mail -100 -1, sent %q", conn.writtenData.String())
+	}
+	items := m.Browser.FieldsList.Items()
+	if len(items) != 3 || items[0].FilterValue() != "c" || items[2].FilterValue() != "a" {
+		t.Fatalf("want c, b, a, got %v", items)
+	}
+	_, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := loadResult(cmd).(tui.SelectFieldMsg); !ok || msg.Field != "c" || msg.Index != -1 {
+		t.Errorf("want c selected at index -1, got %+v", msg)
+	}
+}
+
+// TestDisplayRules_HashTable verifies a hash under a hash_view "table" rule
+// opens as one table, and that enter goes on to its field list.
+func TestDisplayRules_HashTable(t *testing.T) {
+	sized := "+hash\r\n:100\r\n:2\r\n"
+	all := "*4\r\n$4\r\nname\r\n$3\r\nada\r\n$4\r\nrole\r\n$5\r\nadmin\r\n"
+	fields := ":100\r\n:2\r\n*2\r\n$4\r\nname\r\n$4\r\nrole\r\n"
+	m, conn := browseKeys(sized+all+fields, "user:1:profile")
+	m.Config.Display = []tui.DisplayRule{{Keys: "user:*:profile", HashView: "table"}}
+
+	m = openKey(t, m, "user:1:profile")
+	if !strings.Contains(conn.writtenData.String(), "HGETALL") {
+		t.Errorf("want HGETALL, sent %q", conn.writtenData.String())
+	}
+	if view := m.View(); !strings.Contains(view, "as table") || !strings.Contains(view, "role   admin") {
+		t.Fatalf("want the hash as a table, got:\n%s", view)
+	}
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	for i := 0; m.CurrentState == tui.StateLoading && i < 5; i++ {
+		m, cmd = send(m, loadResult(cmd))
+	}
+	if !m.Browser.ViewingFields || len(m.Browser.FieldsList.Items()) != 2 {
+		t.Errorf("enter should open the field list, got state %v", m.CurrentState)
+	}
+}
+
+// TestDisplayRules_ZSetLeaderboard verifies a sorted set under a zset_view
+// "leaderboard" rule opens as its top entries, highest score first.
+func TestDisplayRules_ZSetLeaderboard(t *testing.T) {
+	top := "*4\r\n$3\r\nann\r\n$2\r\n90\r\n$3\r\nbob\r\n$2\r\n70\r\n:12\r\n"
+	m, conn := browseKeys("+zset\r\n"+top, "scores:weekly")
+	m.Config.Display = []tui.DisplayRule{{Keys: "scores:*", ZSetView: "leaderboard"}}
+
+	m = openKey(t, m, "scores:weekly")
+	if !strings.Contains(conn.writtenData.String(), "ZREVRANGE") {
+		t.Errorf("want ZREVRANGE, sent %q", conn.writtenData.String())
+	}
+	if m.CurrentState != tui.StateLeaderboard || !strings.Contains(m.View(), "top 2 of 12") {
+		t.Fatalf("want the leaderboard, got state %v:\n%s", m.CurrentState, m.View())
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateBrowser {
+		t.Errorf("esc should return to the key list, got state %v", m.CurrentState)
+	}
+}
+
+func TestLoadConfig_DisplayRules(t *testing.T) {
+	path := writeConfig(t, `{"display":[{"keys":"queue:*","list_from":"newest"}]}`)
+	if _, err := tui.LoadConfig(path); err == nil || !strings.Contains(err.Error(), "head or tail") {
+		t.Errorf("want a list_from error, got %v", err)
+	}
+	path = writeConfig(t, `{"display":[{"keys":"scores:*","zset_view":"top"}]}`)
+	if _, err := tui.LoadConfig(path); err == nil || !strings.Contains(err.Error(), "members or leaderboard") {
+		t.Errorf("want a zset_view error, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis/mock"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// flagsModel opens FLAGS on feature:* over a mock server holding two flags
+// (one with a TTL) and a key that isn't one.
+func flagsModel(t *testing.T) (tui.Model, *mock.Server) {
+	t.Helper()
+	m, srv := connectMock(t, newPickerMenuModel("FLAGS"))
+	srv.Do(0, "SET", "feature:beta", "1", "EX", "600")
+	srv.Do(0, "SET", "feature:dark", "False")
+	srv.Do(0, "SET", "feature:theme", "blue")
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m.Config.Flags = []string{"feature:*"}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateForm || m.Form.Values()[0] != "feature:*" {
+		t.Fatalf("want the form on the config's patterns, got state %v %v", m.CurrentState, m.Form.Values())
+	}
+	m, cmd := send(m, tui.FormSubmitMsg{Values: m.Form.Values()})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateFlags || len(m.Flags.Rows) != 3 {
+		t.Fatalf("want the three keys listed, got state %v %+v", m.CurrentState, m.Flags.Rows)
+	}
+	return m, srv
+}
+
+// TestFlags_ToggleAfterConfirmation verifies space confirms, then writes the
+// opposite in the same spelling with the TTL kept.
+func TestFlags_ToggleAfterConfirmation(t *testing.T) {
+	m, srv := flagsModel(t)
+	if view := m.View(); !strings.Contains(view, "2 flags, 1 on") || !strings.Contains(view, "1 other keys") {
+		t.Errorf("want the flags counted, got:\n%s", view)
+	}
+
+	m, _ = press(m, " ")
+	if m.CurrentState != tui.StateConfirmation || !strings.Contains(m.View(), `turn off: SET to "0"`) {
+		t.Fatalf("want the toggle confirmed first, got:\n%s", m.View())
+	}
+	if v := srv.Do(0, "GET", "feature:beta"); v != "1" {
+		t.Fatalf("nothing should change before confirming, got %v", v)
+	}
+	m, cmd := press(m, "y")
+	m, cmd = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateFlags || !strings.Contains(m.View(), "Turned feature:beta off") {
+		t.Fatalf("want the list back with a toast, got state %v:\n%s", m.CurrentState, m.View())
+	}
+	if v := srv.Do(0, "GET", "feature:beta"); v != "0" {
+		t.Errorf("want feature:beta 0, got %v", v)
+	}
+	if ttl, _ := srv.Do(0, "TTL", "feature:beta").(int); ttl <= 0 {
+		t.Errorf("feature:beta should keep its TTL, got %d", ttl)
+	}
+	msg := loadResult(cmd)
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		msg = loadResult(func() tea.Msg { return batch })
+	}
+	m, _ = send(m, msg)
+	if r := m.Flags.Rows[0]; r.On || r.Value != "0" {
+		t.Errorf("want the row read again as off, got %+v", r)
+	}
+
+	m, _ = press(m, "down")
+	m, _ = press(m, " ")
+	m, cmd = press(m, "y")
+	m, _ = send(m, loadResult(cmd))
+	if v := srv.Do(0, "GET", "feature:dark"); v != "True" {
+		t.Errorf("want False flipped to True, got %v", v)
+	}
+}
+
+// TestFlags_RefusesOtherValuesAndReadOnly verifies a key that isn't a flag
+// can't be toggled, nor can any in a read-only session.
+func TestFlags_RefusesOtherValuesAndReadOnly(t *testing.T) {
+	m, _ := flagsModel(t)
+	m, _ = press(m, "down")
+	m, _ = press(m, "down")
+	got, _ := press(m, " ")
+	if got.CurrentState != tui.StateFlags || !strings.Contains(got.View(), `feature:theme holds "blue"`) {
+		t.Errorf("want feature:theme refused, got state %v:\n%s", got.CurrentState, got.View())
+	}
+
+	m.ReadOnly = true
+	m, _ = press(m, "up")
+	got, _ = press(m, " ")
+	if got.CurrentState != tui.StateFlags || !strings.Contains(got.View(), "read-only") {
+		t.Errorf("want a read-only refusal, got state %v", got.CurrentState)
+	}
+}
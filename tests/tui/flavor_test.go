@@ -0,0 +1,71 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+var dragonfly = redis.ServerInfo{Flavor: redis.FlavorDragonfly, Version: "1.21.2"}
+
+// TestConnect_DetectsFlavor verifies the server is identified from INFO on
+// connect and a fork is named in the header.
+func TestConnect_DetectsFlavor(t *testing.T) {
+	addr := startFakeRedis(t, func(cmd []string) string {
+		if cmd[0] == "INFO" {
+			info := "# Server\r\nredis_version:7.2.0\r\ndragonfly_version:df-v1.21.2\r\n"
+			return bulk(info)
+		}
+		return "+OK\r\n"
+	})
+	m := newTestModel()
+	m.RedisAddress = addr
+
+	_, connect := send(m, tui.TickMsg{})
+	m2, _ := send(m, connect())
+	t.Cleanup(func() { _ = m2.Conn.Close() })
+
+	if m2.Server != dragonfly || !strings.Contains(m2.View(), "dragonfly 1.21.2") {
+		t.Errorf("want dragonfly 1.21.2 detected and shown, got %+v", m2.Server)
+	}
+}
+
+// TestGet_SkipsIdleWhereUnsupported verifies a string key is inspected
+// without OBJECT IDLETIME on a server that lacks it.
+func TestGet_SkipsIdleWhereUnsupported(t *testing.T) {
+	conn, reader := newMockConn("+PONG\r\n$5\r\nhello\r\n:30\r\n")
+	m := newTestModel()
+	m.Conn, m.Reader, m.Server = conn, reader, dragonfly
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateInputKey
+
+	_, cmd := send(m, tui.InputCompleteMsg{Value: "greeting", Type: tui.InputKey})
+	msg, ok := loadResult(cmd).(tui.StringInspectMsg)
+
+	if strings.Contains(conn.writtenData.String(), "IDLETIME") {
+		t.Errorf("OBJECT IDLETIME sent to %s: %q", dragonfly, conn.writtenData.String())
+	}
+	if !ok || msg.Value != "hello" || msg.TTL != 30 || msg.Idle != -1 {
+		t.Errorf("want value and TTL without idle time, got %+v", msg)
+	}
+}
+
+// TestLifecycle_RestartNeedsCapability verifies DEBUG RESTART isn't offered
+// to a server without it, while SHUTDOWN still is.
+func TestLifecycle_RestartNeedsCapability(t *testing.T) {
+	m := newLifecycleModel(true)
+	m.Server = dragonfly
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"restart", "dev"}})
+	if cmd != nil || !strings.Contains(m2.Form.Err, "DEBUG RESTART") {
+		t.Errorf("want restart refused, got err %q", m2.Form.Err)
+	}
+	if _, cmd := send(m, tui.FormSubmitMsg{Values: []string{"nosave", "dev"}}); cmd == nil {
+		t.Error("SHUTDOWN should still be allowed")
+	}
+}
@@ -0,0 +1,59 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestGenerate_WritesAllBatches runs the generator end to end against the
+// mock server: every batch lands, collections get their elements and TTL.
+func TestGenerate_WritesAllBatches(t *testing.T) {
+	srv, addr := startMock(t)
+
+	m := newPickerMenuModel("GENERATE")
+	m.RedisAddress = addr
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v", m.CurrentState)
+	}
+
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"gen:{{n}}", "450", "hash", "3", "v{{n}}.{{i}}", "60"}})
+	for cmd != nil && m.CurrentState != tui.StateOutput {
+		msg := loadResult(cmd)
+		if msg == nil {
+			break
+		}
+		m, cmd = send(m, msg)
+	}
+
+	if m.Generate.Done != 450 || m.Generate.Errors != 0 || m.Generate.Err != "" {
+		t.Fatalf("want 450 keys without errors, got %+v", m.Generate)
+	}
+	if !strings.Contains(m.View(), "done: 450 keys") {
+		t.Errorf("progress screen should report completion:\n%s", m.View())
+	}
+	if n := srv.Do(0, "DBSIZE"); n != 450 {
+		t.Errorf("want 450 keys on the server, got %v", n)
+	}
+	if v := srv.Do(0, "HGET", "gen:450", "field:3"); v != "v450.3" {
+		t.Errorf("want templated field value, got %v", v)
+	}
+	if ttl, _ := srv.Do(0, "TTL", "gen:1").(int); ttl <= 0 || ttl > 60 {
+		t.Errorf("want a TTL of up to 60s, got %d", ttl)
+	}
+}
+
+// TestGenerate_PatternNeedsNumber verifies several keys can't all be written
+// to the same name.
+func TestGenerate_PatternNeedsNumber(t *testing.T) {
+	m := newPickerMenuModel("GENERATE")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = send(m, tui.FormSubmitMsg{Values: []string{"gen:fixed", "10", "string", "1", "x", "0"}})
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "{{n}}") {
+		t.Errorf("want a pattern error, got %v %q", m.CurrentState, m.Form.Err)
+	}
+}
@@ -0,0 +1,176 @@
+package tui_test
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/exp/golden"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/muesli/termenv"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// Golden snapshots of whole screens. After an intended UI change, refresh
+// them with:
+//
+//	go test ./tests/tui -run Golden -update
+
+const goldenW, goldenH = 100, 30
+
+func init() {
+	// Plain text, whatever terminal the tests run in, so snapshots compare
+	// layout rather than escape codes.
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// newAppModel builds a model the way main does, with a short menu, against
+// a mock server holding one key of a few types. (The -demo seed fills more
+// than one SCAN page, which would put paging into every snapshot.)
+func newAppModel(t *testing.T) tui.Model {
+	t.Helper()
+	srv, addr := startMock(t)
+	srv.Do(0, "SET", "greeting", "hello")
+	srv.Do(0, "RPUSH", "queue:emails", "ada@example.com", "alan@example.com")
+	srv.Do(0, "HSET", "user:1001", "name", "Ada Lovelace", "email", "ada@example.com", "plan", "pro")
+
+	menu := list.New([]list.Item{
+		tui.NewListItem("EXPLORE", "Scan, filter, inspect, edit and delete keys"),
+		tui.NewListItemInGroup("GET", "Get the value of a key", "STRINGS"),
+		tui.NewListItemInGroup("HGET", "Get the value of a hash field", "HASHES"),
+		tui.NewListItemInGroup("INFO", "View Redis server statistics", "SERVER"),
+	}, tui.NewGroupedMenuDelegate(), 0, 0)
+	menu.Title = "Select a command"
+	tui.StyleList(&menu)
+	keys := list.New(nil, tui.BrowserDelegate(), 0, 0)
+	keys.Title = "Select a key"
+	tui.StyleList(&keys)
+	fields := list.New(nil, tui.BrowserDelegate(), 0, 0)
+	fields.Title = "Select a field"
+	tui.StyleList(&fields)
+	input := textarea.New()
+	input.ShowLineNumbers = false
+
+	return tui.Model{
+		CurrentState: tui.StateMenu,
+		MenuList:     menu,
+		Help:         tui.NewHelp(),
+		Browser: tui.BrowserModel{
+			KeyList:    keys,
+			FieldsList: fields,
+			FieldInput: textinput.New(),
+			ValueInput: textinput.New(),
+			Help:       tui.NewHelp(),
+		},
+		Spinner:      spinner.New(spinner.WithSpinner(spinner.Dot)),
+		Viewport:     viewport.New(0, 0),
+		Input:        tui.InputModel{Input: input},
+		RedisAddress: addr,
+	}
+}
+
+var (
+	addrRe     = regexp.MustCompile(`127\.0\.0\.1:\d+`)
+	durationRe = regexp.MustCompile(`\b\d+(\.\d+)?(ns|µs|ms|s)\b`)
+	clientIDRe = regexp.MustCompile(`id \d+`)
+)
+
+// scrub replaces what differs between runs (the mock's port, latencies,
+// client IDs) and trailing padding, so a snapshot only changes with the UI.
+func scrub(view string) []byte {
+	view = addrRe.ReplaceAllString(view, "mock:6379")
+	view = durationRe.ReplaceAllString(view, "<dur>")
+	view = clientIDRe.ReplaceAllString(view, "id <n>")
+	lines := strings.Split(view, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// drive feeds msg to m and then every message its commands produce, until
+// the model settles, the way the Bubble Tea runtime would. Ticks and blinks
+// are dropped so the loop ends.
+func drive(t *testing.T, m tui.Model, msg tea.Msg) tui.Model {
+	t.Helper()
+	queue := []tea.Msg{msg}
+	for n := 0; len(queue) > 0; n++ {
+		if n > 100 {
+			t.Fatal("model did not settle")
+		}
+		msg, queue = queue[0], queue[1:]
+		var cmd tea.Cmd
+		m, cmd = send(m, msg)
+		queue = append(queue, runAll(cmd, skipTimers)...)
+	}
+	return m
+}
+
+// TestGolden_Screens snapshots each core screen on the way through browse →
+// open hash → view field → edit field.
+func TestGolden_Screens(t *testing.T) {
+	m := newAppModel(t)
+	m = drive(t, m, tea.WindowSizeMsg{Width: goldenW, Height: goldenH})
+	for _, msg := range runAll(m.Init(), skipTimers) {
+		m = drive(t, m, msg)
+	}
+	steps := []struct {
+		name string
+		keys []string
+	}{
+		{"menu", nil},
+		{"pattern", []string{"enter"}},
+		{"keys", []string{"enter"}},
+		{"hash", []string{"down", "down", "enter"}},
+		{"field", []string{"enter"}},
+		{"edit", []string{"e"}},
+	}
+	for _, step := range steps {
+		for _, k := range step.keys {
+			m = drive(t, m, keyMsg(k))
+		}
+		t.Run(step.name, func(t *testing.T) {
+			golden.RequireEqual(t, scrub(m.View()))
+		})
+	}
+}
+
+// TestGolden_Program runs the same flow through a real Bubble Tea program
+// and snapshots the last frame, catching what only shows up with the runtime
+// in the loop (window sizing, command batching).
+func TestGolden_Program(t *testing.T) {
+	tm := teatest.NewTestModel(t, newAppModel(t), teatest.WithInitialTermSize(goldenW, goldenH))
+	waitFor := func(s string) {
+		t.Helper()
+		teatest.WaitFor(t, tm.Output(), func(b []byte) bool { return bytes.Contains(b, []byte(s)) },
+			teatest.WithDuration(3*time.Second))
+	}
+	waitFor("● connected")
+	tm.Send(keyMsg("enter"))
+	waitFor("Scan keys by pattern")
+	tm.Send(keyMsg("enter"))
+	waitFor("user:1001")
+	tm.Send(keyMsg("down"))
+	tm.Send(keyMsg("down"))
+	tm.Send(keyMsg("enter"))
+	waitFor("plan")
+	tm.Send(keyMsg("enter"))
+	waitFor("ada@example.com")
+	if err := tm.Quit(); err != nil {
+		t.Fatal(err)
+	}
+	final := tm.FinalModel(t, teatest.WithFinalTimeout(3*time.Second)).(tui.Model)
+	golden.RequireEqual(t, scrub(final.View()))
+	_, _ = io.Copy(io.Discard, tm.FinalOutput(t))
+}
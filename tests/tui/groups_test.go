@@ -0,0 +1,147 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis/mock"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// groupsModel is connected to a mock server holding the stream events, with
+// group workers having read both entries as w1, on the groups screen opened
+// from the stream's tail.
+func groupsModel(t *testing.T) (tui.Model, *mock.Server) {
+	t.Helper()
+	m, srv := connectMock(t, newTestModel())
+	srv.Do(0, "XADD", "events", "1-1", "n", "1")
+	srv.Do(0, "XADD", "events", "2-1", "n", "2")
+	srv.Do(0, "XGROUP", "CREATE", "events", "workers", "0")
+	srv.Do(0, "XREADGROUP", "GROUP", "workers", "w1", "STREAMS", "events", ">")
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m.CurrentState = tui.StateTail
+	m.Tail = tui.TailModel{Key: "events", Buffer: 100, Follow: true}
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateGroups {
+		t.Fatalf("want the groups screen, got state %v %+v", m.CurrentState, m.Result)
+	}
+	return m, srv
+}
+
+// runGroupAction sends the action's result and then the groups reload,
+// which comes batched with the toast.
+func runGroupAction(m tui.Model, cmd tea.Cmd) tui.Model {
+	m, cmd = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateLoading {
+		return m
+	}
+	msg := loadResult(cmd)
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		msg = loadResult(func() tea.Msg { return batch })
+	}
+	m, _ = send(m, msg)
+	return m
+}
+
+// TestGroups_ListCreateAndDestroy verifies the group list, a group created
+// from the form, and DESTROY only after the confirmation.
+func TestGroups_ListCreateAndDestroy(t *testing.T) {
+	m, srv := groupsModel(t)
+	g := m.Groups.Groups
+	if len(g) != 1 || g[0].Name != "workers" || g[0].Pending != 2 || g[0].Consumers != 1 || g[0].LastDelivered != "2-1" || g[0].Lag != "0" {
+		t.Fatalf("want workers with 2 pending, got %+v", g)
+	}
+
+	m, _ = press(m, "n")
+	if m.CurrentState != tui.StateForm || m.SelectedOp != tui.OpGroupCreate {
+		t.Fatalf("want the create form, got %v", m.CurrentState)
+	}
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"audit", "ten"}})
+	if cmd != nil || !strings.Contains(m.Form.Err, "use $") {
+		t.Errorf("want a bad start ID refused, got %q", m.Form.Err)
+	}
+	m, cmd = send(m, tui.FormSubmitMsg{Values: []string{"workers", "$"}})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "BUSYGROUP") {
+		t.Errorf("want a taken name back on the form, got state %v err %q", m.CurrentState, m.Form.Err)
+	}
+	m, cmd = send(m, tui.FormSubmitMsg{Values: []string{"audit", "$"}})
+	m = runGroupAction(m, cmd)
+	if m.CurrentState != tui.StateGroups || len(m.Groups.Groups) != 2 {
+		t.Fatalf("want two groups listed, got state %v %+v", m.CurrentState, m.Groups.Groups)
+	}
+
+	// The new group is selected; destroy it.
+	m, _ = press(m, "d")
+	if m.CurrentState != tui.StateConfirmation || !strings.Contains(m.View(), "destroy group audit") {
+		t.Fatalf("want the destroy confirmation, got:\n%s", m.View())
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateGroups || len(srv.Do(0, "XINFO", "GROUPS", "events").([]any)) != 2 {
+		t.Fatal("esc should leave the group in place")
+	}
+	m, _ = press(m, "d")
+	m, cmd = press(m, "y")
+	m = runGroupAction(m, cmd)
+	if m.CurrentState != tui.StateGroups || len(m.Groups.Groups) != 1 || !strings.Contains(m.View(), "Destroyed group audit") {
+		t.Errorf("want audit gone with a toast, got:\n%s", m.View())
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateTail {
+		t.Errorf("esc should return to the tail, got %v", m.CurrentState)
+	}
+}
+
+// TestGroups_SetIDAndAutoClaim verifies SETID and XAUTOCLAIM go through a
+// form and then a confirmation before anything changes.
+func TestGroups_SetIDAndAutoClaim(t *testing.T) {
+	m, srv := groupsModel(t)
+
+	m, _ = press(m, "s")
+	m, _ = send(m, tui.FormSubmitMsg{Values: []string{"1-1"}})
+	if m.CurrentState != tui.StateConfirmation || !strings.Contains(m.View(), "from 2-1 to 1-1") {
+		t.Fatalf("want the SETID confirmation, got:\n%s", m.View())
+	}
+	m, cmd := press(m, "y")
+	m = runGroupAction(m, cmd)
+	if got := m.Groups.Groups[0]; got.LastDelivered != "1-1" || got.Lag != "1" {
+		t.Errorf("want workers moved back one entry, got %+v", got)
+	}
+
+	m, _ = press(m, "c")
+	if m.CurrentState != tui.StateForm || m.SelectedOp != tui.OpAutoClaim {
+		t.Fatalf("want the XAUTOCLAIM form, got %v", m.CurrentState)
+	}
+	m, _ = send(m, tui.FormSubmitMsg{Values: []string{"rescuer", "0", "10"}})
+	if m.CurrentState != tui.StateConfirmation || !strings.Contains(m.View(), "to rescuer") {
+		t.Fatalf("want the XAUTOCLAIM confirmation, got:\n%s", m.View())
+	}
+	m, cmd = press(m, "y")
+	m = runGroupAction(m, cmd)
+	if !strings.Contains(m.View(), "Claimed 2 entries for rescuer") {
+		t.Errorf("want the claim counted, got:\n%s", m.View())
+	}
+	pending := srv.Do(0, "XINFO", "CONSUMERS", "events", "workers")
+	if !strings.Contains(fmt.Sprint(pending), "rescuer pending 2") {
+		t.Errorf("want both entries with rescuer, got %v", pending)
+	}
+}
+
+// TestGroups_ReadOnlyRefusesWrites verifies a read-only session can list
+// groups but not change them.
+func TestGroups_ReadOnlyRefusesWrites(t *testing.T) {
+	m, _ := groupsModel(t)
+	m.ReadOnly = true
+	for _, k := range []string{"n", "s", "d", "c"} {
+		got, _ := press(m, k)
+		if got.CurrentState != tui.StateGroups || !strings.Contains(got.View(), "read-only") {
+			t.Errorf("%s: want a refusal on the groups screen, got state %v", k, got.CurrentState)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestGrowth_AlertsOnFastGrowth samples the mock server, writes keys, and
+// checks the next sample counts them per prefix and raises the alert.
+func TestGrowth_AlertsOnFastGrowth(t *testing.T) {
+	m, srv := connectMock(t, newTestModel())
+	srv.Do(0, "SET", "user:1", "v")
+	srv.Do(0, "SET", "other", "v")
+	m.SelectedOp = tui.OpGrowth
+	m.CurrentState = tui.StateForm
+
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"user:, session:*", "5", "100"}})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateGrowth {
+		t.Fatalf("state: want StateGrowth, got %v (%+v)", m.CurrentState, m.Result)
+	}
+	if view := m.View(); strings.Contains(view, "growing") {
+		t.Errorf("no alert before the keyspace grows:\n%s", view)
+	}
+
+	for i := range 20 {
+		srv.Do(0, "SET", fmt.Sprintf("session:%d", i), "v")
+	}
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m, _ = send(m, loadResult(cmd))
+
+	if n := len(m.Growth.Samples); n != 2 {
+		t.Fatalf("want 2 samples, got %d", n)
+	}
+	if got := m.Growth.Samples[1].Counts; fmt.Sprint(got) != "[22 1 20]" {
+		t.Errorf("want DBSIZE 22, user:* 1, session:* 20, got %v", got)
+	}
+	view := m.View()
+	for _, want := range []string{"user:*", "session:*", "the keyspace is growing", "over the 100 limit"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want %q on the monitor, got:\n%s", want, view)
+		}
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Growth.Active || m.CurrentState != tui.StateForm {
+		t.Errorf("esc should stop sampling and return to the form, got state %v", m.CurrentState)
+	}
+}
+
+func TestGrowthForm_TooManyPrefixes(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpGrowth
+	m.CurrentState = tui.StateForm
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"a,b,c,d,e,f,g,h,i", "5", "0"}})
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "at most") || cmd != nil {
+		t.Errorf("want a form error, got state %v %q", m.CurrentState, m.Form.Err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"net"
 	"strings"
+	"testing"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -15,6 +16,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/redis/mock"
 	"github.com/ajxv/redis-tui/internal/tui"
 )
 
@@ -71,3 +74,176 @@ type mockNetError struct{}
 func (e *mockNetError) Error() string   { return "mock network error" }
 func (e *mockNetError) Timeout() bool   { return false }
 func (e *mockNetError) Temporary() bool { return true }
+
+// loadResult runs the command batched by switchToLoadingAndExecute and returns
+// the Redis message it produced, skipping the spinner tick.
+func loadResult(cmd tea.Cmd) tea.Msg {
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return unwrapLoad(msg)
+	}
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+		if r := c(); r != nil {
+			if _, isSpin := r.(spinner.TickMsg); !isSpin {
+				return unwrapLoad(r)
+			}
+		}
+	}
+	return nil
+}
+
+// unwrapLoad is the reply a LoadedMsg carries, so tests can inspect it.
+func unwrapLoad(msg tea.Msg) tea.Msg {
+	if l, ok := msg.(tui.LoadedMsg); ok {
+		return l.Msg
+	}
+	return msg
+}
+
+// startFakeRedis serves RESP on a loopback port for tools that open their own
+// connections. reply maps each command (name first) to a raw RESP reply;
+// SELECT and AUTH are acknowledged automatically.
+func startFakeRedis(t *testing.T, reply func(cmd []string) string) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					req, err := redis.ReadResp(r)
+					if err != nil {
+						return
+					}
+					parts, _ := req.([]any)
+					cmd := make([]string, len(parts))
+					for i, p := range parts {
+						cmd[i], _ = p.(string)
+					}
+					out := "+OK\r\n"
+					if len(cmd) > 0 && cmd[0] != "SELECT" && cmd[0] != "AUTH" {
+						out = reply(cmd)
+					}
+					if _, err := conn.Write([]byte(out)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return l.Addr().String()
+}
+
+// startMock starts an in-process mock server on a loopback port, closed
+// when the test ends.
+func startMock(t *testing.T) (*mock.Server, string) {
+	t.Helper()
+	srv := mock.NewServer()
+	addr, err := srv.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = srv.Close() })
+	return srv, addr
+}
+
+// dialMock points m at the server at addr over a connection of its own, as
+// a session is once connected.
+func dialMock(t *testing.T, m tui.Model, addr string) tui.Model {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	m.Conn, m.Reader, m.RedisAddress = conn, bufio.NewReader(conn), addr
+	return m
+}
+
+// connectMock points m at a new mock server; seed it through the server
+// returned.
+func connectMock(t *testing.T, m tui.Model) (tui.Model, *mock.Server) {
+	t.Helper()
+	srv, addr := startMock(t)
+	return dialMock(t, m, addr), srv
+}
+
+// keyMsg is the key press s names: enter, esc, up or down, or else the
+// runes typed.
+func keyMsg(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// press sends each of keys to m as one key press, returning the command
+// the last one gave; press(m, "GET k", "enter") types a line.
+func press(m tui.Model, keys ...string) (tui.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		m, cmd = send(m, keyMsg(k))
+	}
+	return m, cmd
+}
+
+// How long runAll waits on each command before leaving it behind: no
+// timers at all, or those as short as the key highlight and live refresh
+// delays but not blinks or toasts.
+const (
+	skipTimers  = 50 * time.Millisecond
+	shortTimers = 400 * time.Millisecond
+)
+
+// runAll runs cmd and everything it batches, returning the messages
+// produced. A command still running after wait, a timer, is left behind;
+// spinner ticks are dropped.
+func runAll(cmd tea.Cmd, wait time.Duration) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(wait):
+		return nil
+	}
+	switch msg := msg.(type) {
+	case tea.BatchMsg:
+		var out []tea.Msg
+		for _, c := range msg {
+			out = append(out, runAll(c, wait)...)
+		}
+		return out
+	case nil, spinner.TickMsg:
+		return nil
+	}
+	return []tea.Msg{msg}
+}
+
+// first runs the first command of the batch cmd returns.
+func first(cmd tea.Cmd) tea.Msg {
+	return cmd().(tea.BatchMsg)[0]()
+}
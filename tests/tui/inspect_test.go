@@ -0,0 +1,84 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestGet_InspectsIdleBeforeValue verifies opening a string key pipelines
+// OBJECT IDLETIME ahead of GET (GET would reset the idle clock) and TTL after.
+func TestGet_InspectsIdleBeforeValue(t *testing.T) {
+	conn, reader := newMockConn(":75\r\n$5\r\nhello\r\n:30\r\n")
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateInputKey
+
+	m2, cmd := send(m, tui.InputCompleteMsg{Value: "greeting", Type: tui.InputKey})
+	if m2.CurrentState != tui.StateLoading {
+		t.Fatalf("state: want StateLoading, got %v", m2.CurrentState)
+	}
+	m3, follow := send(m2, loadResult(cmd))
+
+	written := conn.writtenData.String()
+	idle, get, ttl := strings.Index(written, "IDLETIME"), strings.Index(written, "GET"), strings.Index(written, "TTL")
+	if idle < 0 || get < 0 || ttl < 0 || !(idle < get && get < ttl) {
+		t.Errorf("want OBJECT IDLETIME, GET, TTL in order, got %q", written)
+	}
+	if m3.CurrentState != tui.StateOutput || m3.Result.Text() != "hello" {
+		t.Fatalf("want value in output, got %v / %q", m3.CurrentState, m3.Result.Text())
+	}
+	if m3.ActiveTTL != "30 s" || m3.ActiveIdle != "1m15s" {
+		t.Errorf("meta: want TTL 30 s / idle 1m15s, got %q / %q", m3.ActiveTTL, m3.ActiveIdle)
+	}
+	if follow != nil {
+		t.Error("TTL is already known; no follow-up lookup expected")
+	}
+}
+
+// TestStringInspect_MissingKey verifies a cache miss is labelled as such
+// rather than shown as a key with no expiry.
+func TestStringInspect_MissingKey(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateLoading
+
+	m2, _ := send(m, tui.StringInspectMsg{Value: "(nil)", TTL: -2, Idle: -1})
+
+	if !strings.Contains(m2.ActiveTTL, "does not exist") {
+		t.Errorf("want a missing-key label, got %q", m2.ActiveTTL)
+	}
+}
+
+// TestStringInspect_IdleUnavailable verifies an LFU server (where OBJECT
+// IDLETIME errors) still shows the value and TTL.
+func TestStringInspect_IdleUnavailable(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateLoading
+	m.WindowWidth, m.WindowHeight = 100, 30
+
+	m2, _ := send(m, tui.StringInspectMsg{Value: "v", TTL: -1, Idle: -1})
+
+	if m2.ActiveTTL != "no expiry" || m2.ActiveIdle != "n/a" {
+		t.Errorf("meta: want no expiry / n/a, got %q / %q", m2.ActiveTTL, m2.ActiveIdle)
+	}
+	if view := m2.View(); !strings.Contains(view, "idle:") {
+		t.Error("expected the idle time in the meta row")
+	}
+}
+
+// TestStringInspect_ClearedByTTLRefresh verifies a later plain TTL lookup
+// (e.g. after changing the expiry) doesn't leave a stale idle time behind.
+func TestStringInspect_ClearedByTTLRefresh(t *testing.T) {
+	m := newTestModel()
+	m.CurrentState = tui.StateOutput
+	m.ActiveIdle = "5s"
+
+	m2, _ := send(m, tui.RedisTTLResultMsg{TTL: 10})
+	if m2.ActiveIdle != "" {
+		t.Errorf("idle should be cleared, got %q", m2.ActiveIdle)
+	}
+}
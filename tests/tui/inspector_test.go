@@ -0,0 +1,38 @@
+package tui_test
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestInspector_ShowsCommandsAndReplies verifies F12 opens a pane with the
+// commands sent and their replies as parsed, and closes it again.
+func TestInspector_ShowsCommandsAndReplies(t *testing.T) {
+	m, conn := browseKeys("+hash\r\n", "user:1")
+	m.RedisAddress = "localhost:6379"
+	m.Recorder = redis.NewRecorder(tui.InspectorExchanges)
+	m.Conn = m.Recorder.Wrap(conn, m.RedisAddress)
+	m.Reader = bufio.NewReader(m.Conn) // as openConnection does, so replies are seen
+
+	_, cmd := send(m, tui.SelectKeyMsg{Key: "user:1"})
+	loadResult(cmd)
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyF12})
+	view := m.View()
+	for _, want := range []string{"protocol inspector", "› TYPE user:1", `‹ "hash"`} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want %q in the pane, got:\n%s", want, view)
+		}
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyF12})
+	if strings.Contains(m.View(), "protocol inspector") {
+		t.Error("a second F12 should close the pane")
+	}
+}
@@ -0,0 +1,69 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// browseKeys is the key list showing keys, with conn answering data.
+func browseKeys(data string, keys ...string) (tui.Model, *mockConn) {
+	conn, reader := newMockConn(data)
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m.LargeValueBytes = 1 << 20
+	m.CurrentState = tui.StateBrowser
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu}
+	items := make([]list.Item, len(keys))
+	for i, k := range keys {
+		items[i] = tui.NewListItem(k, "key")
+	}
+	m.Browser.KeyList = list.New(items, list.NewDefaultDelegate(), 120, 20)
+	m.Browser.KeyList.Title = "Select a key"
+	return m, conn
+}
+
+// TestKeyMeta_PrefetchesHighlighted verifies a key left highlighted has its
+// type, TTL and size fetched and shown, and opening it then skips TYPE and
+// the size check.
+func TestKeyMeta_PrefetchesHighlighted(t *testing.T) {
+	m, conn := browseKeys("+hash\r\n:300\r\n:2048\r\n:12\r\n*2\r\n$1\r\na\r\n$1\r\nb\r\n", "user:1", "user:2")
+
+	m, moved := send(m, tea.KeyMsg{Type: tea.KeyDown})
+	m, rested := send(m, tea.KeyMsg{Type: tea.KeyUp})
+	for _, msg := range runAll(moved, shortTimers) {
+		if _, cmd := send(m, msg); cmd != nil {
+			t.Fatal("a key highlighted only briefly shouldn't be fetched")
+		}
+	}
+	var cmd tea.Cmd
+	for _, msg := range runAll(rested, shortTimers) {
+		m, cmd = send(m, msg)
+	}
+	if cmd == nil {
+		t.Fatal("want the prefetch sent")
+	}
+	m, _ = send(m, cmd())
+	if view := m.View(); !strings.Contains(view, "user:1: hash · 12 fields · 2.0 KiB · expires in 5m") {
+		t.Fatalf("want the metadata in the title, got:\n%s", view)
+	}
+	written := conn.writtenData.String()
+	if !strings.Contains(written, "MEMORY") || !strings.Contains(written, "HLEN") {
+		t.Errorf("want MEMORY USAGE and HLEN sent, got %q", written)
+	}
+
+	conn.writtenData.Reset()
+	m, cmd = send(m, tui.SelectKeyMsg{Key: "user:1"})
+	m, _ = send(m, loadResult(cmd))
+	if got := conn.writtenData.String(); strings.Contains(got, "TYPE") || strings.Contains(got, "MEMORY") || !strings.Contains(got, "HKEYS") {
+		t.Errorf("opening should go straight to HKEYS, sent %q", got)
+	}
+	if m.CurrentState != tui.StateBrowser || !m.Browser.ViewingFields {
+		t.Errorf("want the hash's fields, got state %v", m.CurrentState)
+	}
+}
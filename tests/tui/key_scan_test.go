@@ -0,0 +1,43 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestKeyScan_ProgressAndStop verifies a scan short of keys reports what it
+// has found while it carries on, and esc lists those keys with n able to
+// continue from where it stopped.
+func TestKeyScan_ProgressAndStop(t *testing.T) {
+	conn, reader := newMockConn("*2\r\n$1\r\n7\r\n*1\r\n$6\r\nuser:1\r\n+string\r\n:-1\r\n")
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m.Browser.Pattern = "user:*"
+	m.CurrentState = tui.StateBrowser
+
+	m, cmd := send(m, tui.RefreshMsg{})
+	m, next := send(m, loadResult(cmd))
+	if next == nil || m.CurrentState != tui.StateLoading {
+		t.Fatalf("want the scan carrying on, got state %v", m.CurrentState)
+	}
+	if view := m.View(); !strings.Contains(view, "Scanning user:*… 1 found · 1 pages") {
+		t.Errorf("want the progress shown, got:\n%s", view)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateBrowser || len(m.Browser.KeyList.Items()) != 1 {
+		t.Fatalf("esc should list the key found, got state %v with %d keys", m.CurrentState, len(m.Browser.KeyList.Items()))
+	}
+	if !m.Browser.HasMore || m.Browser.Cursor != "7" {
+		t.Errorf("want n to continue from cursor 7, got %q", m.Browser.Cursor)
+	}
+	late := tui.KeyScanMsg{Gen: m.KeyScan.Gen - 1, Result: tui.RedisResultMsg{Result: tui.ScanResult{Cursor: "0"}}}
+	if m, _ = send(m, late); len(m.Browser.KeyList.Items()) != 1 || !m.Browser.HasMore {
+		t.Error("the page in flight when esc was pressed should be dropped")
+	}
+}
@@ -0,0 +1,69 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestKeyMenu_ListsAndRuns verifies m opens the menu over the selected key,
+// listing its macros with the rest, and that an action's letter runs it.
+func TestKeyMenu_ListsAndRuns(t *testing.T) {
+	m, _ := browseKeys("", "session:1")
+	m.Config.Macros = []tui.Macro{resetSession}
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateKeyMenu {
+		t.Fatalf("want the key menu, got state %v", m.CurrentState)
+	}
+	view := m.View()
+	for _, want := range []string{"session:1", "rename", "set TTL", "macro: reset session"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want %q in the menu, got:\n%s", want, view)
+		}
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if m.CurrentState != tui.StateConfirmation || m.SelectedOp != tui.OpDel || m.ActiveKey != "session:1" {
+		t.Fatalf("d should confirm deleting session:1, got state %v, op %v", m.CurrentState, m.SelectedOp)
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.CurrentState != tui.StateBrowser {
+		t.Errorf("n should return to the browser, got %v", m.CurrentState)
+	}
+}
+
+// TestKeyMenu_EditValue verifies "edit value" opens a string key straight
+// into its editor.
+func TestKeyMenu_EditValue(t *testing.T) {
+	m, _ := browseKeys(stringKey("hello"), "greeting")
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	m, _ = send(m, loadResult(cmd))
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	for i := 0; m.CurrentState == tui.StateLoading && i < 5; i++ {
+		m, cmd = send(m, loadResult(cmd))
+	}
+	if m.CurrentState != tui.StateInputValue || m.SelectedOp != tui.OpSet || m.Input.Input.Value() != "hello" {
+		t.Fatalf("want the editor on hello, got state %v, op %v", m.CurrentState, m.SelectedOp)
+	}
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateOutput {
+		t.Errorf("esc should leave the value shown, got %v", m.CurrentState)
+	}
+}
+
+func TestKeyMenu_EscCloses(t *testing.T) {
+	m, _ := browseKeys("", "greeting")
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	m, _ = send(m, loadResult(cmd))
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateBrowser {
+		t.Errorf("esc should close the menu, got %v", m.CurrentState)
+	}
+}
@@ -0,0 +1,71 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// openLarge asks for key under op with a 16-byte limit and returns the model
+// once the size check has answered.
+func openLarge(t *testing.T, op tui.Op, data string) (tui.Model, *mockConn) {
+	t.Helper()
+	conn, reader := newMockConn(data)
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.LargeValueBytes = 16
+	m.SelectedOp = op
+	m.CurrentState = tui.StateInputKey
+	m, cmd := send(m, tui.InputCompleteMsg{Value: "big", Type: tui.InputKey})
+	m, _ = send(m, loadResult(cmd))
+	return m, conn
+}
+
+// TestLargeValue_StringPreview verifies an oversized string is not fetched:
+// the prompt appears and p shows a GETRANGE preview instead.
+func TestLargeValue_StringPreview(t *testing.T) {
+	m, conn := openLarge(t, tui.OpGet, ":2097152\r\n$4\r\nabcd\r\n")
+	if m.CurrentState != tui.StateConfirmation || !strings.Contains(m.View(), "2.0 MiB") {
+		t.Fatalf("want the size prompt, got %v:\n%s", m.CurrentState, m.View())
+	}
+	if strings.Contains(conn.writtenData.String(), "\r\nGET\r\n") {
+		t.Fatal("the value must not be fetched before confirmation")
+	}
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m, _ = send(m, loadResult(cmd))
+
+	if !strings.Contains(conn.writtenData.String(), "GETRANGE") {
+		t.Errorf("want a GETRANGE preview, got %q", conn.writtenData.String())
+	}
+	if m.CurrentState != tui.StateOutput || !strings.HasPrefix(m.Result.Value, "abcd") || !strings.Contains(m.Result.Value, "of 2.0 MiB") {
+		t.Errorf("want the preview with a note, got %v %q", m.CurrentState, m.Result.Value)
+	}
+}
+
+// TestLargeValue_SmallStringFetched verifies a value within the limit opens
+// as before after the size check.
+func TestLargeValue_SmallStringFetched(t *testing.T) {
+	m, _ := openLarge(t, tui.OpGet, ":5\r\n:0\r\n$5\r\nhello\r\n:-1\r\n")
+	if m.CurrentState != tui.StateOutput || m.Result.Value != "hello" {
+		t.Errorf("want the value, got %v %q", m.CurrentState, m.Result.Text())
+	}
+}
+
+// TestLargeValue_HashFetchAll verifies y on the prompt loads every field.
+func TestLargeValue_HashFetchAll(t *testing.T) {
+	m, conn := openLarge(t, tui.OpHGet, ":5000\r\n:3\r\n*3\r\n$1\r\na\r\n$1\r\nb\r\n$1\r\nc\r\n")
+	if m.CurrentState != tui.StateConfirmation || !strings.Contains(m.View(), "3 fields") {
+		t.Fatalf("want the size prompt, got %v:\n%s", m.CurrentState, m.View())
+	}
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m, _ = send(m, loadResult(cmd))
+
+	if !strings.Contains(conn.writtenData.String(), "HKEYS") || m.CurrentState != tui.StateBrowser {
+		t.Errorf("want the fields loaded, got %v", m.CurrentState)
+	}
+}
@@ -0,0 +1,101 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis/mock"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// leaderboardModel opens LEADERBOARD on the top 3 of a mock server's
+// five-member sorted set.
+func leaderboardModel(t *testing.T) (tui.Model, *mock.Server) {
+	t.Helper()
+	m, srv := connectMock(t, newPickerMenuModel("LEADERBOARD"))
+	srv.Do(0, "ZADD", "scores", "50", "ann", "40", "bob", "30", "cat", "20", "dan", "10", "eve")
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateForm {
+		t.Fatalf("want the form, got state %v", m.CurrentState)
+	}
+	m, _ = send(m, tui.FormSubmitMsg{Values: []string{"scores", "0", "2"}})
+	if m.Form.Err == "" {
+		t.Error("a top of 0 should be refused")
+	}
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"scores", "3", "2"}})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateLeaderboard || len(m.Leader.Rows) != 3 {
+		t.Fatalf("want the top 3 listed, got state %v %+v", m.CurrentState, m.Leader.Rows)
+	}
+	return m, srv
+}
+
+// TestLeaderboard_RanksAndIncrement verifies the top is listed by score with
+// ranks, and that ZINCRBY from the form reorders it with the climb marked.
+func TestLeaderboard_RanksAndIncrement(t *testing.T) {
+	m, srv := leaderboardModel(t)
+	view := m.View()
+	if !strings.Contains(view, "top 3 of 5") || !strings.Contains(view, "#1") || strings.Contains(view, "dan") {
+		t.Errorf("want ann, bob and cat ranked, got:\n%s", view)
+	}
+	if r := m.Leader.Rows[0]; r.Member != "ann" || r.Score != "50" || r.Rank != 1 {
+		t.Errorf("want ann first, got %+v", r)
+	}
+
+	m, _ = press(m, "down")
+	m, _ = press(m, "down")
+	m, _ = press(m, "+")
+	if m.CurrentState != tui.StateForm || m.Form.Values()[0] != "cat" {
+		t.Fatalf("want the ZINCRBY form on cat, got state %v %v", m.CurrentState, m.Form.Values())
+	}
+	m, _ = send(m, tui.FormSubmitMsg{Values: []string{"cat", "lots"}})
+	if m.Form.Err == "" {
+		t.Error("a non-numeric increment should be refused")
+	}
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"cat", "25"}})
+	m, cmd = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateLeaderboard || !strings.Contains(m.View(), "cat now scores 55") {
+		t.Fatalf("want the leaderboard back with a toast, got state %v:\n%s", m.CurrentState, m.View())
+	}
+	if v := srv.Do(0, "ZSCORE", "scores", "cat"); v != "55" {
+		t.Errorf("want cat at 55, got %v", v)
+	}
+	msg := loadResult(cmd)
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		msg = loadResult(func() tea.Msg { return batch })
+	}
+	m, _ = send(m, msg)
+	if r := m.Leader.Rows[0]; r.Member != "cat" || r.Moved != 2 || m.Leader.Cursor != 0 {
+		t.Errorf("want cat first, up 2 and still selected, got %+v cursor %d", r, m.Leader.Cursor)
+	}
+	if !strings.Contains(m.View(), "▲2") {
+		t.Errorf("want the climb marked, got:\n%s", m.View())
+	}
+}
+
+// TestLeaderboard_RefreshStopsOnExit verifies a tick reads the top again
+// while the leaderboard is shown, and that esc stops the refresh.
+func TestLeaderboard_RefreshStopsOnExit(t *testing.T) {
+	m, srv := leaderboardModel(t)
+	srv.Do(0, "ZADD", "scores", "99", "zed")
+
+	m, cmd := send(m, tui.LeaderTickMsg{Gen: m.Leader.Gen})
+	m, next := send(m, loadResult(cmd))
+	if next == nil || m.Leader.Rows[0].Member != "zed" || !m.Leader.Rows[0].New {
+		t.Fatalf("want zed read as a new leader and the tick re-armed, got %+v", m.Leader.Rows)
+	}
+
+	m.ReadOnly = true
+	if got, _ := press(m, "+"); got.CurrentState != tui.StateLeaderboard || !strings.Contains(got.View(), "read-only") {
+		t.Errorf("want ZINCRBY refused in a read-only session, got state %v", got.CurrentState)
+	}
+
+	gen := m.Leader.Gen
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if _, cmd := send(m, tui.LeaderTickMsg{Gen: gen}); cmd != nil || m.Leader.Active {
+		t.Error("a tick after esc should do nothing")
+	}
+}
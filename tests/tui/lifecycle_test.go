@@ -0,0 +1,107 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+func newLifecycleModel(admin bool) tui.Model {
+	m := newPickerMenuModel("LIFECYCLE")
+	m.ProfileName = "dev"
+	m.Config = tui.Config{Profiles: []tui.Profile{{Name: "dev", Host: "localhost:6379", Admin: admin}}}
+	return m
+}
+
+// TestLifecycle_Refused verifies the lifecycle form never opens for a
+// read-only session, a non-admin profile, or a connection without a profile.
+func TestLifecycle_Refused(t *testing.T) {
+	readOnly := newLifecycleModel(true)
+	readOnly.ReadOnly = true
+	noProfile := newLifecycleModel(true)
+	noProfile.ProfileName = ""
+
+	cases := map[string]struct {
+		m    tui.Model
+		want string
+	}{
+		"readonly":   {readOnly, "read-only"},
+		"not admin":  {newLifecycleModel(false), "not marked"},
+		"no profile": {noProfile, "only available"},
+	}
+	for name, tc := range cases {
+		m2, _ := send(tc.m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if m2.CurrentState != tui.StateOutput || !strings.Contains(m2.Result.Text(), tc.want) {
+			t.Errorf("%s: want a refusal mentioning %q, got state %v output %q", name, tc.want, m2.CurrentState, m2.Result.Text())
+		}
+	}
+}
+
+// TestLifecycle_RequiresTypedName verifies the form only submits once the
+// profile name is typed exactly and the action is recognised.
+func TestLifecycle_RequiresTypedName(t *testing.T) {
+	m, _ := send(newLifecycleModel(true), tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v (%s)", m.CurrentState, m.Result.Text())
+	}
+
+	for _, values := range [][]string{{"nosave", "DEV"}, {"nosave", ""}, {"halt", "dev"}} {
+		m2, cmd := send(m, tui.FormSubmitMsg{Values: values})
+		if m2.CurrentState != tui.StateForm || m2.Form.Err == "" || cmd != nil {
+			t.Errorf("%q: want the form to stay open with an error, got %v", values, m2.CurrentState)
+		}
+	}
+}
+
+// TestLifecycle_ShutdownClosesConnection verifies SHUTDOWN NOSAVE is sent
+// and a closed connection is reported as success.
+func TestLifecycle_ShutdownClosesConnection(t *testing.T) {
+	conn, reader := newMockConn("") // the server hangs up instead of replying
+	m, _ := send(newLifecycleModel(true), tea.KeyMsg{Type: tea.KeyEnter})
+	m.Conn, m.Reader = conn, reader
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"nosave", "dev"}})
+	if m2.CurrentState != tui.StateLoading {
+		t.Fatalf("state: want StateLoading, got %v (err %q)", m2.CurrentState, m2.Form.Err)
+	}
+	m3, _ := send(m2, loadResult(cmd))
+
+	if !strings.Contains(conn.writtenData.String(), "SHUTDOWN\r\n$6\r\nNOSAVE") {
+		t.Errorf("want SHUTDOWN NOSAVE, sent %q", conn.writtenData.String())
+	}
+	if m3.CurrentState != tui.StateOutput || !strings.Contains(m3.Result.Text(), "accepted") {
+		t.Errorf("want success output, got %v %q", m3.CurrentState, m3.Result.Text())
+	}
+}
+
+// TestLifecycle_ShutdownRefusedByServer verifies an error reply is shown as
+// a command that wasn't carried out.
+func TestLifecycle_ShutdownRefusedByServer(t *testing.T) {
+	conn, reader := newMockConn("-ERR Errors trying to SHUTDOWN. Check logs.\r\n")
+	m, _ := send(newLifecycleModel(true), tea.KeyMsg{Type: tea.KeyEnter})
+	m.Conn, m.Reader = conn, reader
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"save", "dev"}})
+	m3, _ := send(m2, loadResult(cmd))
+
+	if !strings.Contains(m3.Result.Text(), "was not carried out") || !strings.Contains(m3.Result.Text(), "Check logs") {
+		t.Errorf("want the server's refusal, got %q", m3.Result.Text())
+	}
+}
+
+// TestPersistence_ReadOnlyBlocksSaves verifies -readonly also disables the
+// background save actions.
+func TestPersistence_ReadOnlyBlocksSaves(t *testing.T) {
+	m := newPersistenceModel(map[string]string{})
+	m.ReadOnly = true
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+
+	if m2.CurrentState != tui.StatePersistence || !m2.Persistence.Failed {
+		t.Errorf("want a read-only notice, got state %v notice %q", m2.CurrentState, m2.Persistence.Notice)
+	}
+}
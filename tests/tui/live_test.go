@@ -0,0 +1,102 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// liveServer answers GET with value and SUBSCRIBE with one set event, and
+// reports the channel subscribed to.
+func liveServer(t *testing.T, flags string, value *string) (string, chan string) {
+	subscribed := make(chan string, 1)
+	addr := startFakeRedis(t, func(cmd []string) string {
+		switch cmd[0] {
+		case "CONFIG":
+			return fmt.Sprintf("*2\r\n$22\r\nnotify-keyspace-events\r\n$%d\r\n%s\r\n", len(flags), flags)
+		case "SUBSCRIBE":
+			subscribed <- cmd[1]
+			return fmt.Sprintf("*3\r\n$9\r\nsubscribe\r\n$%d\r\n%s\r\n:1\r\n", len(cmd[1]), cmd[1]) +
+				fmt.Sprintf("*3\r\n$7\r\nmessage\r\n$%d\r\n%s\r\n$3\r\nset\r\n", len(cmd[1]), cmd[1])
+		case "GET":
+			return fmt.Sprintf("$%d\r\n%s\r\n", len(*value), *value)
+		case "TTL":
+			return ":-1\r\n"
+		}
+		return "+OK\r\n"
+	})
+	return addr, subscribed
+}
+
+// TestLive_RefreshesStringOnEvent verifies L subscribes to the key's own
+// channel, and a change event reads the value again and marks it updated.
+func TestLive_RefreshesStringOnEvent(t *testing.T) {
+	value := "v2"
+	addr, subscribed := liveServer(t, "KA", &value)
+	m := dialMock(t, newTestModel(), addr)
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m.CurrentState, m.SelectedOp, m.ActiveKey = tui.StateOutput, tui.OpGet, "greet[1]"
+	m.Result = tui.Result{Type: "string", Value: "v1"}
+
+	m, cmd := press(m, "L")
+	m, cmd = send(m, cmd())
+	if lc := m.Live.Conn; lc != nil {
+		t.Cleanup(func() { _ = lc.Close() })
+	}
+	if got := <-subscribed; got != "__keyspace@0__:greet[1]" {
+		t.Errorf("want the key's own channel, got %s", got)
+	}
+	if !m.Live.Active || !strings.Contains(m.View(), "● live") || !strings.Contains(m.View(), "Live refresh on") {
+		t.Fatalf("want the live badge, got:\n%s", m.View())
+	}
+
+	m, cmd = send(m, first(cmd))
+	if !m.Live.Due {
+		t.Fatal("an event should schedule a refresh")
+	}
+	refresh := cmd().(tea.BatchMsg)[1]()
+	m, cmd = send(m, refresh)
+	if m.CurrentState != tui.StateLoading {
+		t.Fatalf("want the value read again, got state %v", m.CurrentState)
+	}
+	msg := loadResult(cmd)
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		msg = loadResult(func() tea.Msg { return batch })
+	}
+	m, _ = send(m, msg)
+	if m.CurrentState != tui.StateOutput || m.Result.Value != "v2" || !strings.Contains(m.View(), "updated just now") {
+		t.Errorf("want v2 marked as just updated, got state %v:\n%s", m.CurrentState, m.View())
+	}
+
+	m, _ = press(m, "L")
+	if m.Live.Active || m.Live.Conn != nil || strings.Contains(m.View(), "● live") {
+		t.Error("L again should stop the live refresh")
+	}
+}
+
+// TestLive_WarnsWhenNotificationsOff verifies the user is told when the
+// server publishes no keyspace events, and that opening another key ends the
+// subscription.
+func TestLive_WarnsWhenNotificationsOff(t *testing.T) {
+	value := "v"
+	addr, _ := liveServer(t, "", &value)
+	m := newTestModel()
+	m.RedisAddress = addr
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m.CurrentState, m.SelectedOp, m.ActiveKey = tui.StateOutput, tui.OpGet, "greeting"
+
+	m, cmd := press(m, "L")
+	m, _ = send(m, cmd())
+	if !strings.Contains(m.View(), "Keyspace notifications are off") {
+		t.Errorf("want a warning about notifications, got:\n%s", m.View())
+	}
+	m.CurrentState = tui.StateBrowser
+	m, _ = send(m, tui.SelectKeyMsg{Key: "other"})
+	if m.Live.Active || m.Live.Conn != nil {
+		t.Error("opening another key should end the subscription")
+	}
+}
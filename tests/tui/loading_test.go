@@ -0,0 +1,112 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// startGet submits a GET from the key prompt, leaving it loading, and returns
+// the load's command.
+func startGet(t *testing.T, replies string) (tui.Model, *mockConn, tea.Cmd) {
+	t.Helper()
+	conn, reader := newMockConn(replies)
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateInputKey
+	m, cmd := send(m, tui.InputCompleteMsg{Value: "greeting", Type: tui.InputKey})
+	if m.CurrentState != tui.StateLoading || !strings.Contains(m.View(), "esc cancels") {
+		t.Fatalf("want the GET loading, got state %v:\n%s", m.CurrentState, m.View())
+	}
+	return m, conn, cmd
+}
+
+// loaded runs a load's command and returns its LoadedMsg as it reaches Update.
+func loaded(t *testing.T, cmd tea.Cmd) tui.LoadedMsg {
+	t.Helper()
+	for _, c := range cmd().(tea.BatchMsg) {
+		if c == nil {
+			continue
+		}
+		if msg, ok := c().(tui.LoadedMsg); ok {
+			return msg
+		}
+	}
+	t.Fatal("no LoadedMsg in the batch")
+	return tui.LoadedMsg{}
+}
+
+// TestLoad_ReplyAfterEscIsDropped verifies esc goes back to the prompt and
+// the reply, when it lands, changes nothing.
+func TestLoad_ReplyAfterEscIsDropped(t *testing.T) {
+	m, _, cmd := startGet(t, ":-1\r\n$5\r\nhello\r\n:-1\r\n")
+	reply := loaded(t, cmd)
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateInputKey || !strings.Contains(m.View(), "Cancelled") {
+		t.Fatalf("want the key prompt back with a toast, got state %v:\n%s", m.CurrentState, m.View())
+	}
+	m.SelectedOp = tui.OpDel // the user has moved on
+	m, _ = send(m, reply)
+	if m.CurrentState != tui.StateInputKey || m.Result.Value != "" {
+		t.Errorf("a cancelled reply should be dropped, got state %v %+v", m.CurrentState, m.Result)
+	}
+}
+
+// TestLoad_CancelledBeforeSending verifies a load cancelled while still
+// queued never writes its command.
+func TestLoad_CancelledBeforeSending(t *testing.T) {
+	m, conn, cmd := startGet(t, "")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if reply := loaded(t, cmd); reply.Msg != nil {
+		t.Errorf("want no reply, got %+v", reply.Msg)
+	}
+	if sent := conn.writtenData.String(); sent != "" {
+		t.Errorf("nothing should be sent, got %q", sent)
+	}
+
+	m, cmd = send(m, tui.InputCompleteMsg{Value: "greeting", Type: tui.InputKey})
+	if m.CurrentState != tui.StateLoading || cmd == nil {
+		t.Fatalf("a new load should start, got state %v", m.CurrentState)
+	}
+}
+
+// TestLoad_ReplyHandledUnderItsOperation verifies a reply is read as the
+// command it answers even when the model has moved on while it loaded.
+func TestLoad_ReplyHandledUnderItsOperation(t *testing.T) {
+	m, _, cmd := startGet(t, ":-1\r\n$5\r\nhello\r\n:-1\r\n")
+	reply := loaded(t, cmd)
+	if reply.Op.Kind != tui.OpGet || reply.Op.Key != "greeting" || reply.Op.From != tui.StateInputKey {
+		t.Errorf("want the GET's operation carried, got %+v", reply.Op)
+	}
+
+	m.SelectedOp, m.ActiveKey = tui.OpHKeys, "other"
+	m, _ = send(m, reply)
+	if m.CurrentState != tui.StateOutput || m.Result.Value != "hello" || m.SelectedOp != tui.OpGet || m.ActiveKey != "greeting" {
+		t.Errorf("want the GET's reply shown for greeting, got state %v op %v key %q %+v", m.CurrentState, m.SelectedOp, m.ActiveKey, m.Result)
+	}
+}
+
+// TestLoad_SupersededReplyIsDropped verifies that when a second load starts
+// before the first answers, the replies landing out of order leave the
+// second one shown, under its own key.
+func TestLoad_SupersededReplyIsDropped(t *testing.T) {
+	m, _, first := startGet(t, ":-1\r\n$5\r\nworld\r\n:-1\r\n$5\r\nhello\r\n")
+	m.SelectedOp = tui.OpGet
+	m, second := send(m, tui.InputCompleteMsg{Value: "other", Type: tui.InputKey})
+	newer := loaded(t, second) // reads world off the connection first
+	older := loaded(t, first)
+	if older.Op.Key != "greeting" || newer.Op.Key != "other" {
+		t.Fatalf("want each load's key carried, got %q and %q", older.Op.Key, newer.Op.Key)
+	}
+
+	m, _ = send(m, newer)
+	m, _ = send(m, older)
+	if m.CurrentState != tui.StateOutput || m.Result.Value != "world" || m.ActiveKey != "other" || m.SelectedOp != tui.OpGet {
+		t.Errorf("want other's value kept, got state %v op %v key %q %+v", m.CurrentState, m.SelectedOp, m.ActiveKey, m.Result)
+	}
+}
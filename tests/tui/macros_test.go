@@ -0,0 +1,79 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+var resetSession = tui.Macro{Name: "reset session", Keys: "session:*", Commands: []string{"DEL {key}", "SADD invalidated {key}"}}
+
+// TestMacros_ConfirmAndRun verifies ! offers the macros for the selected key,
+// shows the commands with the key filled in, and runs them on y.
+func TestMacros_ConfirmAndRun(t *testing.T) {
+	m, conn := browseKeys(":1\r\n:1\r\n", "session:a b")
+	m.Config.Macros = []tui.Macro{resetSession, {Name: "other", Keys: "user:*", Commands: []string{"DEL {key}"}}}
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateMacros || len(m.Macros.Items) != 1 {
+		t.Fatalf("want the picker with one macro, got state %v, %+v", m.CurrentState, m.Macros.Items)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.View(); !strings.Contains(view, `SADD invalidated "session:a b"`) {
+		t.Fatalf("want the commands to confirm, got:\n%s", view)
+	}
+	if conn.writtenData.Len() != 0 {
+		t.Fatalf("nothing should be sent before y, sent %q", conn.writtenData.String())
+	}
+
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateOutput || !strings.Contains(m.Result.Value, "› SADD") {
+		t.Fatalf("want both replies shown, got %+v", m.Result)
+	}
+	if !strings.Contains(conn.writtenData.String(), "$11\r\nsession:a b\r\n") {
+		t.Errorf("want the key sent as one argument, sent %q", conn.writtenData.String())
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateBrowser {
+		t.Errorf("esc should return to the browser, got %v", m.CurrentState)
+	}
+}
+
+// TestMacros_StopAtError verifies a failing step ends the macro.
+func TestMacros_StopAtError(t *testing.T) {
+	m, conn := browseKeys("-WRONGTYPE Operation against a key holding the wrong kind of value\r\n", "session:1")
+	m.Config.Macros = []tui.Macro{resetSession}
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	m, _ = send(m, loadResult(cmd))
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m, _ = send(m, loadResult(cmd))
+	if !strings.Contains(m.Result.Err, "stopped at DEL") || strings.Contains(conn.writtenData.String(), "SADD") {
+		t.Errorf("want the macro stopped at DEL, got %+v, sent %q", m.Result, conn.writtenData.String())
+	}
+}
+
+func TestMacros_NoneForKey(t *testing.T) {
+	m, _ := browseKeys("", "user:1")
+	m.Config.Macros = []tui.Macro{resetSession}
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateBrowser || !strings.Contains(m.Toast.Text, "No macros for user:1") {
+		t.Errorf("want a toast and no picker, got state %v, toast %q", m.CurrentState, m.Toast.Text)
+	}
+}
+
+func TestLoadConfig_Macros(t *testing.T) {
+	path := writeConfig(t, `{"macros":[{"name":"watch","commands":["SUBSCRIBE {key}"]}]}`)
+	if _, err := tui.LoadConfig(path); err == nil || !strings.Contains(err.Error(), `macro "watch"`) {
+		t.Errorf("want SUBSCRIBE refused, got %v", err)
+	}
+}
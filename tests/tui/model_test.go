@@ -191,6 +191,24 @@ func TestInput_ValueZero_Persist(t *testing.T) {
 	}
 }
 
+// TestInput_ValueNonNumeric_Expire verifies that a TTL that isn't a whole
+// number is rejected locally instead of being sent as EXPIRE.
+func TestInput_ValueNonNumeric_Expire(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpExpirySet
+	m.ActiveKey = "k"
+	m.CurrentState = tui.StateInputValue
+
+	m2, _ := send(m, tui.InputCompleteMsg{Type: tui.InputValue, Value: "10m"})
+
+	if m2.CurrentState != tui.StateOutput {
+		t.Errorf("state: want StateOutput, got %v", m2.CurrentState)
+	}
+	if !strings.Contains(m2.Result.Err, "whole number") {
+		t.Errorf("want a TTL error, got %q", m2.Result.Text())
+	}
+}
+
 // TestInput_Rename_UpdatesActiveKey verifies that a successful rename updates
 // ActiveKey to the new name before the command is dispatched.
 func TestInput_Rename_UpdatesActiveKey(t *testing.T) {
@@ -247,8 +265,8 @@ func TestResult_Get_ShowsOutput(t *testing.T) {
 			if m2.CurrentState != tui.StateOutput {
 				t.Errorf("state: want StateOutput, got %v", m2.CurrentState)
 			}
-			if m2.Output != "hello" {
-				t.Errorf("output: want %q, got %q", "hello", m2.Output)
+			if m2.Result.Text() != "hello" {
+				t.Errorf("output: want %q, got %q", "hello", m2.Result.Text())
 			}
 			if m2.ActiveTTL != "fetching..." {
 				t.Errorf("ActiveTTL: want %q, got %q", "fetching...", m2.ActiveTTL)
@@ -430,8 +448,8 @@ func TestResult_ZRange_LoadsZSetItemsWithScores(t *testing.T) {
 	if len(items) != 2 {
 		t.Fatalf("item count: want 2, got %d", len(items))
 	}
-	if desc := items[0].(tui.ListItem).Description(); desc != "score:1.5" {
-		t.Errorf("description: want %q, got %q", "score:1.5", desc)
+	if score := items[0].(tui.ListItem).Score(); score != "1.5" {
+		t.Errorf("score: want %q, got %q", "1.5", score)
 	}
 }
 
@@ -538,7 +556,7 @@ func TestResult_ExpireAfterSet_ResetsOp(t *testing.T) {
 	m := newTestModel()
 	m.SelectedOp = tui.OpExpireAfterSet
 	m.ActiveKey = "k"
-	m.Output = "OK"
+	m.Result = tui.Result{Value: "OK"}
 
 	m2, cmd := send(m, tui.RedisResultMsg{Result: 1})
 
@@ -566,8 +584,8 @@ func TestResult_IntegerOutput(t *testing.T) {
 			if m2.CurrentState != tui.StateOutput {
 				t.Errorf("state: want StateOutput, got %v", m2.CurrentState)
 			}
-			if m2.Output != "3" {
-				t.Errorf("output: want %q, got %q", "3", m2.Output)
+			if m2.Result.Text() != "3" {
+				t.Errorf("output: want %q, got %q", "3", m2.Result.Text())
 			}
 		})
 	}
@@ -588,11 +606,11 @@ func TestResult_HSet_ShowsFriendlyMessage(t *testing.T) {
 	if m2.CurrentState != tui.StateOutput {
 		t.Errorf("state: want StateOutput, got %v", m2.CurrentState)
 	}
-	if m2.Output == "0" || m2.Output == "1" {
-		t.Errorf("output: want a friendly message, got the raw HSET reply %q", m2.Output)
+	if m2.Result.Text() == "0" || m2.Result.Text() == "1" {
+		t.Errorf("output: want a friendly message, got the raw HSET reply %q", m2.Result.Text())
 	}
-	if !strings.Contains(m2.Output, "myhash") || !strings.Contains(m2.Output, "f1") {
-		t.Errorf("output: want a message naming the key and field, got %q", m2.Output)
+	if !strings.Contains(m2.Result.Text(), "myhash") || !strings.Contains(m2.Result.Text(), "f1") {
+		t.Errorf("output: want a message naming the key and field, got %q", m2.Result.Text())
 	}
 }
 
@@ -608,8 +626,8 @@ func TestResult_ApplicationError_ShowsMessage(t *testing.T) {
 	if m2.CurrentState != tui.StateOutput {
 		t.Errorf("state: want StateOutput, got %v", m2.CurrentState)
 	}
-	if !strings.Contains(m2.Output, "WRONGTYPE") {
-		t.Errorf("output should contain error text, got %q", m2.Output)
+	if !strings.Contains(m2.Result.Text(), "WRONGTYPE") {
+		t.Errorf("output should contain error text, got %q", m2.Result.Text())
 	}
 }
 
@@ -703,8 +721,8 @@ func TestSelectField_List_ShowsDirectOutput(t *testing.T) {
 	if m2.CurrentState != tui.StateOutput {
 		t.Errorf("state: want StateOutput, got %v", m2.CurrentState)
 	}
-	if m2.Output != "item-value" {
-		t.Errorf("output: want %q, got %q", "item-value", m2.Output)
+	if m2.Result.Text() != "item-value" {
+		t.Errorf("output: want %q, got %q", "item-value", m2.Result.Text())
 	}
 	if cmd != nil {
 		t.Error("list item display should not dispatch a Redis command")
@@ -874,7 +892,7 @@ func TestOutputKey_Edit_OpRouting(t *testing.T) {
 		t.Run(tc.currentOp.String(), func(t *testing.T) {
 			m := newTestModel()
 			m.SelectedOp = tc.currentOp
-			m.Output = "current-value"
+			m.Result = tui.Result{Value: "current-value"}
 			m.ActiveTTL = "no expiry"
 			m.StateNavigationHistory = []tui.AppState{tui.StateBrowser}
 			m.CurrentState = tui.StateOutput
@@ -898,7 +916,7 @@ func TestOutputKey_Edit_OpRouting(t *testing.T) {
 func TestOutputKey_Edit_InfoIsNoop(t *testing.T) {
 	m := newTestModel()
 	m.SelectedOp = tui.OpInfo
-	m.Output = "# Server"
+	m.Result = tui.Result{Value: "# Server"}
 	m.CurrentState = tui.StateOutput
 
 	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
@@ -943,7 +961,7 @@ func TestOutputKey_Esc_NavigatesBack(t *testing.T) {
 	m := newTestModel()
 	m.StateNavigationHistory = []tui.AppState{tui.StateBrowser}
 	m.CurrentState = tui.StateOutput
-	m.Output = "val"
+	m.Result = tui.Result{Value: "val"}
 	m.ActiveTTL = "10s"
 	m.CopyStatus = "Copied!"
 
@@ -952,8 +970,8 @@ func TestOutputKey_Esc_NavigatesBack(t *testing.T) {
 	if m2.CurrentState != tui.StateBrowser {
 		t.Errorf("state: want StateBrowser, got %v", m2.CurrentState)
 	}
-	if m2.Output != "" {
-		t.Errorf("Output should be cleared, got %q", m2.Output)
+	if m2.Result.Text() != "" {
+		t.Errorf("Output should be cleared, got %q", m2.Result.Text())
 	}
 	if m2.ActiveTTL != "" {
 		t.Errorf("ActiveTTL should be cleared, got %q", m2.ActiveTTL)
@@ -1017,7 +1035,7 @@ func TestOutputKey_OpLSet_EscResetsToExploreList(t *testing.T) {
 func TestTTL_PreservedWhenEditing(t *testing.T) {
 	m := newTestModel()
 	m.SelectedOp = tui.OpGet
-	m.Output = "val"
+	m.Result = tui.Result{Value: "val"}
 	m.ActiveTTL = "120 s"
 	m.StateNavigationHistory = []tui.AppState{tui.StateBrowser}
 	m.CurrentState = tui.StateOutput
@@ -1036,7 +1054,7 @@ func TestTTL_NotPreservedWhenNoExpiry(t *testing.T) {
 		t.Run(activeTTL, func(t *testing.T) {
 			m := newTestModel()
 			m.SelectedOp = tui.OpGet
-			m.Output = "val"
+			m.Result = tui.Result{Value: "val"}
 			m.ActiveTTL = activeTTL
 			m.StateNavigationHistory = []tui.AppState{tui.StateBrowser}
 			m.CurrentState = tui.StateOutput
@@ -1279,7 +1297,7 @@ func TestNavStack_NoDuplicatePush(t *testing.T) {
 	m.StateNavigationHistory = []tui.AppState{tui.StateBrowser}
 	m.CurrentState = tui.StateOutput
 	m.SelectedOp = tui.OpGet
-	m.Output = "v"
+	m.Result = tui.Result{Value: "v"}
 	m.ActiveTTL = "no expiry"
 
 	// Press 'e' — internally calls pushState(StateOutput).
@@ -1354,7 +1372,7 @@ func TestView_EachState_NonEmpty(t *testing.T) {
 		{"StateOutput", func() tui.Model {
 			m := newTestModel()
 			m.CurrentState = tui.StateOutput
-			m.Output = "hello"
+			m.Result = tui.Result{Value: "hello"}
 			m.SelectedOp = tui.OpGet
 			return m
 		}},
@@ -1391,7 +1409,7 @@ func TestView_Output_ShowsValue(t *testing.T) {
 	m.WindowWidth = 80
 	m.WindowHeight = 24
 	m.CurrentState = tui.StateOutput
-	m.Output = "redis-value-42"
+	m.Result = tui.Result{Value: "redis-value-42"}
 	m.SelectedOp = tui.OpGet
 
 	if !strings.Contains(m.View(), "redis-value-42") {
@@ -1404,7 +1422,7 @@ func TestView_Output_ShowsValue(t *testing.T) {
 func TestView_Output_ShowsTTL(t *testing.T) {
 	m := newTestModel()
 	m.CurrentState = tui.StateOutput
-	m.Output = "v"
+	m.Result = tui.Result{Value: "v"}
 	m.ActiveTTL = "300s"
 	m.SelectedOp = tui.OpGet
 
@@ -1418,7 +1436,7 @@ func TestView_Output_ShowsTTL(t *testing.T) {
 func TestView_Output_InfoHelpText(t *testing.T) {
 	m := newTestModel()
 	m.CurrentState = tui.StateOutput
-	m.Output = "# Server"
+	m.Result = tui.Result{Value: "# Server"}
 	m.SelectedOp = tui.OpInfo
 
 	view := m.View()
@@ -1435,7 +1453,7 @@ func TestView_Output_InfoHelpText(t *testing.T) {
 func TestView_Output_ShowsCopyStatus(t *testing.T) {
 	m := newTestModel()
 	m.CurrentState = tui.StateOutput
-	m.Output = "v"
+	m.Result = tui.Result{Value: "v"}
 	m.CopyStatus = "Copied to clipboard!"
 	m.SelectedOp = tui.OpGet
 
@@ -1740,6 +1758,37 @@ func TestMenu_QWhileFilteringIsNotQuit(t *testing.T) {
 	}
 }
 
+// TestMenu_DigitRunsNthItem verifies a digit runs the item shown with that
+// number, counting within the filtered list once a filter is applied, and
+// does nothing past the last item.
+func TestMenu_DigitRunsNthItem(t *testing.T) {
+	m := newMenuTestModel()
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	if m2.SelectedOp != tui.OpGet || m2.CurrentState != tui.StateInputKey {
+		t.Errorf("2: want the GET key prompt, got op %v state %v", m2.SelectedOp, m2.CurrentState)
+	}
+
+	m2, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'9'}})
+	if m2.CurrentState != tui.StateMenu || m2.SelectedOp != tui.OpNone {
+		t.Errorf("9 with four items should do nothing, got op %v state %v", m2.SelectedOp, m2.CurrentState)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("info")})
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	if m.CurrentState != tui.StateMenu || m.MenuList.FilterValue() != "info1" {
+		t.Fatalf("a digit while filtering should be filter input, got %q", m.MenuList.FilterValue())
+	}
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	for _, msg := range runAll(cmd, shortTimers) {
+		m, _ = send(m, msg)
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	if m.SelectedOp != tui.OpInfo {
+		t.Errorf("1 on the filtered menu should run INFO, got op %v", m.SelectedOp)
+	}
+}
+
 // ============================================================
 // 13. CONFIRMATION → LOADING (SPINNER RESTART)
 // ============================================================
@@ -0,0 +1,124 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// pushValues submits value on op's value prompt and returns what was sent.
+func pushValues(t *testing.T, op tui.Op, value string) string {
+	t.Helper()
+	conn, reader := newMockConn(":3\r\n")
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.SelectedOp = op
+	m.ActiveKey = "k"
+	m.CurrentState = tui.StateInputValue
+	_, cmd := send(m, tui.InputCompleteMsg{Type: tui.InputValue, Value: value})
+	if cmd == nil {
+		t.Fatal("want a command sent")
+	}
+	loadResult(cmd)
+	return conn.writtenData.String()
+}
+
+// TestPush_SeveralValues verifies the push and add prompts send every
+// element in one command, one per line, leaving commas in a line alone.
+func TestPush_SeveralValues(t *testing.T) {
+	cases := []struct {
+		op    tui.Op
+		value string
+		want  redis.RedisCmd
+	}{
+		{tui.OpRPush, "a\nb\n\nc d", redis.RedisCmd{Name: "RPUSH", Args: []string{"k", "a", "b", "c d"}}},
+		{tui.OpRPush, `{"id":1,"to":"a@b.c"}`, redis.RedisCmd{Name: "RPUSH", Args: []string{"k", `{"id":1,"to":"a@b.c"}`}}},
+		{tui.OpSAdd, "Hello, world", redis.RedisCmd{Name: "SADD", Args: []string{"k", "Hello, world"}}},
+		{tui.OpSAdd, "{\"a\":1,\"b\":2}\n{\"c\":3}", redis.RedisCmd{Name: "SADD", Args: []string{"k", `{"a":1,"b":2}`, `{"c":3}`}}},
+		{tui.OpSAdd, "solo", redis.RedisCmd{Name: "SADD", Args: []string{"k", "solo"}}},
+		{tui.OpRPush, "", redis.RedisCmd{Name: "RPUSH", Args: []string{"k", ""}}},
+	}
+	for _, c := range cases {
+		if got := pushValues(t, c.op, c.value); got != string(c.want.ToBytes()) {
+			t.Errorf("%v %q: want %q, got %q", c.op, c.value, c.want.ToBytes(), got)
+		}
+	}
+}
+
+// TestPush_NewLineKeyAndCount verifies ctrl+j starts a new element on the
+// push prompt and the prompt counts what will be sent.
+func TestPush_NewLineKeyAndCount(t *testing.T) {
+	m := newTestModel()
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m.SelectedOp = tui.OpRPush
+	m.Input.Type = tui.InputValue
+	m.Input.Input.Focus()
+	m.CurrentState = tui.StateInputValue
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyCtrlJ})
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if got := m.Input.Input.Value(); got != "a\nb" {
+		t.Errorf("want two lines, got %q", got)
+	}
+	if view := m.View(); !strings.Contains(view, "· 2 elements") {
+		t.Errorf("want the elements counted, got:\n%s", view)
+	}
+}
+
+// TestPush_CommasWhenOn verifies ctrl+o makes the prompt split each line at
+// unescaped commas, and that it is off again for the next prompt.
+func TestPush_CommasWhenOn(t *testing.T) {
+	m := newTestModel()
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m.SelectedOp = tui.OpLPush
+	m.Input.Type = tui.InputValue
+	m.Input.Input.Focus()
+	m.CurrentState = tui.StateInputValue
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(`x, y\,z ,, \\`)})
+	if view := m.View(); !strings.Contains(view, "split on commas (ctrl+o): on") || !strings.Contains(view, "· 3 elements") {
+		t.Errorf("want commas on and 3 elements counted, got:\n%s", view)
+	}
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(tui.InputCompleteMsg); !ok || msg.Value != "x\ny,z\n\\" {
+		t.Errorf("want three elements submitted, got %+v", msg)
+	}
+	if m.Input.Commas {
+		t.Error("want commas off for the next prompt")
+	}
+}
+
+// TestBrowserAdd_SeveralMembers verifies the field browser's set add form
+// sends a line with commas as one member, and splits it with ctrl+o.
+func TestBrowserAdd_SeveralMembers(t *testing.T) {
+	m := newTestModel()
+	m.CurrentState = tui.StateBrowser
+	m.Browser.ViewingFields = true
+	m.Browser.ActiveKey, m.Browser.ActiveKeyType = "tags", "set"
+	m.Browser.StartAdd()
+	_, cmd := press(m, "Hello, world", "enter")
+	if msg, ok := cmd().(tui.AddItemMsg); !ok || msg.A != "Hello, world" {
+		t.Errorf("want one member, got %+v", msg)
+	}
+
+	m.Browser.StartAdd()
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+	_, cmd = press(m, "red, green", "enter")
+	msg, ok := cmd().(tui.AddItemMsg)
+	if !ok || msg.A != "red\ngreen" {
+		t.Fatalf("want two members, got %+v", msg)
+	}
+	conn, reader := newMockConn(":2\r\n")
+	m.Conn, m.Reader = conn, reader
+	_, cmd = send(m, msg)
+	loadResult(cmd)
+	if want := string(redis.SAdd("tags", "red", "green").ToBytes()); conn.writtenData.String() != want {
+		t.Errorf("want %q, got %q", want, conn.writtenData.String())
+	}
+}
@@ -0,0 +1,183 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestParseNumber verifies numbers are read the way Redis reads them, and
+// locale forms are refused rather than guessed at.
+func TestParseNumber(t *testing.T) {
+	ttl := tui.NumberSpec{Name: "TTL", Min: 0, Max: 1e12}
+	score := tui.NumberSpec{Name: "score", Float: true}
+	mask := tui.NumberSpec{Name: "value", Bases: true}
+	cases := []struct {
+		spec tui.NumberSpec
+		in   string
+		want string // "" = refused
+		err  string
+	}{
+		{ttl, " 60 ", "60", ""},
+		{ttl, "0", "0", ""},
+		{ttl, "+5", "+5", ""},
+		{ttl, "1.5", "", "whole number"},
+		{ttl, "-1", "", "at least 0"},
+		{ttl, "1,000", "", "grouping"},
+		{ttl, "1 000", "", "grouping"},
+		{ttl, "99999999999999999999", "", "out of range"},
+		{ttl, "ten", "", "isn't a number"},
+		{ttl, "", "", "enter a TTL"},
+		{score, "2.5", "2.5", ""},
+		{score, ".5", ".5", ""},
+		{score, "1e3", "1e3", ""},
+		{score, "-inf", "-inf", ""},
+		{score, "+INF", "+INF", ""},
+		{score, "2,5", "", "with . for decimals"},
+		{score, "NaN", "", "isn't a number"},
+		{score, "0x10", "", "isn't a number"},
+		{score, "1e999", "", "out of range"},
+		{mask, "42", "42", ""},
+		{mask, "0x2A", "42", ""},
+		{mask, "0b1010_0001", "161", ""},
+		{mask, "-0o17", "-15", ""},
+		{mask, "0x", "", "isn't a number"},
+		{mask, "0b102", "", "isn't a number"},
+		{mask, "0x1_0000_0000_0000_0000", "", "out of range"},
+	}
+	for _, c := range cases {
+		got, err := tui.ParseNumber(c.spec, c.in)
+		if c.want != "" {
+			if err != nil || got != c.want {
+				t.Errorf("%s %q: want %q, got %q, %v", c.spec.Name, c.in, c.want, got, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s %q: want an error mentioning %q, got %q, %v", c.spec.Name, c.in, c.err, got, err)
+		}
+	}
+}
+
+// TestExpirySet_RefusesBadTTLInline verifies a malformed TTL stays on the
+// prompt with a note, and a good one is submitted trimmed.
+func TestExpirySet_RefusesBadTTLInline(t *testing.T) {
+	m := newTestModel()
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m.SelectedOp = tui.OpExpirySet
+	m.ActiveKey = "session:1"
+	m.Input.Type = tui.InputValue
+	m.Input.Input.Focus()
+	m.CurrentState = tui.StateInputValue
+
+	m, cmd := press(m, "1.5", "enter")
+	if cmd != nil {
+		t.Fatalf("a fractional TTL should not be submitted, got %T", cmd())
+	}
+	if view := m.View(); !strings.Contains(view, "TTL must be a whole number") {
+		t.Errorf("want the reason under the input, got:\n%s", view)
+	}
+
+	m.Input.Input.SetValue("")
+	m, cmd = press(m, " 60", "enter")
+	if cmd == nil {
+		t.Fatal("a good TTL should be submitted")
+	}
+	if msg, ok := cmd().(tui.InputCompleteMsg); !ok || msg.Value != "60" {
+		t.Errorf("want the TTL submitted trimmed, got %+v", msg)
+	}
+	if m.Input.Err != "" {
+		t.Errorf("the note should clear once typing resumes, got %q", m.Input.Err)
+	}
+}
+
+// TestBrowserAdd_RefusesBadScore verifies the sorted-set add overlay keeps
+// a malformed score instead of sending ZADD.
+func TestBrowserAdd_RefusesBadScore(t *testing.T) {
+	m := newTestModel()
+	m.CurrentState = tui.StateBrowser
+	m.Browser.ViewingFields = true
+	m.Browser.ActiveKey, m.Browser.ActiveKeyType = "board", "zset"
+	m.Browser.StartAdd()
+
+	m, _ = press(m, "ada", "enter")
+	m, cmd := press(m, "3,5", "enter")
+	if cmd != nil {
+		t.Fatalf("a comma score should not be sent, got %T", cmd())
+	}
+	if !m.Browser.AddingField || !strings.Contains(m.Browser.AddErr, "decimals") {
+		t.Errorf("want the overlay kept with a reason, got adding=%v err=%q", m.Browser.AddingField, m.Browser.AddErr)
+	}
+
+	m.Browser.ValueInput.SetValue("")
+	_, cmd = press(m, "3.5", "enter")
+	if cmd == nil {
+		t.Fatal("a good score should be sent")
+	}
+	if msg, ok := cmd().(tui.AddItemMsg); !ok || msg.A != "ada" || msg.B != "3.5" {
+		t.Errorf("want ZADD board 3.5 ada, got %+v", msg)
+	}
+}
+
+// TestOutput_IntegerInOtherBases verifies an integer string is shown in hex
+// and binary too, and that its editor takes a binary number and sends it in
+// decimal.
+func TestOutput_IntegerInOtherBases(t *testing.T) {
+	m := newTestModel()
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m.CurrentState, m.SelectedOp = tui.StateOutput, tui.OpGet
+	m.ActiveKey = "perms:7"
+	m.Result = tui.Result{Type: "string", Value: "42"}
+	if view := m.View(); !strings.Contains(view, "hex 0x2a · bin 0b10_1010 · bits 1 3 5") {
+		t.Errorf("want the value in other bases, got:\n%s", view)
+	}
+
+	m, _ = press(m, "e")
+	if m.CurrentState != tui.StateInputValue || !strings.Contains(m.View(), "0b… binary") {
+		t.Fatalf("want the editor taking other bases, got state %v:\n%s", m.CurrentState, m.View())
+	}
+	m.Input.Input.SetValue("")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("0b111")})
+	if view := m.View(); !strings.Contains(view, "sends 7 · hex 0x7") {
+		t.Errorf("want what enter sends shown, got:\n%s", view)
+	}
+	_, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(tui.InputCompleteMsg); !ok || msg.Value != "7" {
+		t.Errorf("want 7 submitted, got %+v", msg)
+	}
+
+	m.Result = tui.Result{Type: "string", Value: "hello"}
+	m.CurrentState, m.SelectedOp = tui.StateOutput, tui.OpGet
+	if m, _ = press(m, "e"); m.EditingInt || strings.Contains(m.View(), "binary") {
+		t.Error("a value that isn't an integer should be edited as text")
+	}
+}
+
+// TestOutput_IntegerEditedToText verifies an integer string can still be set
+// to text: the bases are a help, not a check.
+func TestOutput_IntegerEditedToText(t *testing.T) {
+	m, conn := browseKeys("+OK\r\n")
+	m.CurrentState, m.SelectedOp = tui.StateOutput, tui.OpGet
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu, tui.StateBrowser}
+	m.ActiveKey = "perms:7"
+	m.Result = tui.Result{Type: "string", Value: "42"}
+
+	m, _ = press(m, "e")
+	m.Input.Input.SetValue("hello")
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Input.Err != "" {
+		t.Fatalf("want text accepted, got %q", m.Input.Err)
+	}
+	msg, ok := cmd().(tui.InputCompleteMsg)
+	if !ok || msg.Value != "hello" {
+		t.Fatalf("want hello submitted as typed, got %+v", msg)
+	}
+	_, cmd = send(m, msg)
+	loadResult(cmd)
+	if sent := conn.writtenData.String(); !strings.Contains(sent, "$3\r\nSET\r\n$7\r\nperms:7\r\n$5\r\nhello\r\n") {
+		t.Errorf("want SET perms:7 hello, sent %q", sent)
+	}
+}
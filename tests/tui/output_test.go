@@ -0,0 +1,79 @@
+package tui_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestResult_CarriesCommandTypeAndLatency verifies a reply lands in the output
+// pane with the command that produced it, its kind and its round trip.
+func TestResult_CarriesCommandTypeAndLatency(t *testing.T) {
+	conn, reader := newMockConn(":-1\r\n$5\r\nhello\r\n:-1\r\n")
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateInputKey
+
+	m, cmd := send(m, tui.InputCompleteMsg{Value: "my key", Type: tui.InputKey})
+	m, _ = send(m, loadResult(cmd))
+
+	r := m.Result
+	if r.Command != `GET "my key"` || r.Type != "string" || r.Value != "hello" || r.Err != "" {
+		t.Errorf("unexpected result: %+v", r)
+	}
+	if r.Latency <= 0 {
+		t.Errorf("want a measured latency, got %v", r.Latency)
+	}
+
+	m, _ = send(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+	if view := m.View(); !strings.Contains(view, `GET "my key" · string`) {
+		t.Errorf("output header missing:\n%s", view)
+	}
+}
+
+// TestResult_ErrorReplyFlagged verifies a Redis error reply, which arrives as
+// a plain string, is recorded as an error rather than a value.
+func TestResult_ErrorReplyFlagged(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpHKeys
+	m.CurrentState = tui.StateLoading
+
+	m, _ = send(m, tui.RedisResultMsg{Result: "WRONGTYPE Operation against a key holding the wrong kind of value", Command: "HKEYS k"})
+
+	if m.CurrentState != tui.StateOutput || m.Result.Type != "error" || !strings.HasPrefix(m.Result.Err, "WRONGTYPE") {
+		t.Errorf("want an error result, got %v %+v", m.CurrentState, m.Result)
+	}
+}
+
+// TestResult_ValueStats verifies the header counts a value's bytes,
+// characters and lines apart and starts its SHA-256, and that an error shows
+// none of it.
+func TestResult_ValueStats(t *testing.T) {
+	value := "héllo\nwörld\n"
+	conn, reader := newMockConn(fmt.Sprintf(":-1\r\n$%d\r\n%s\r\n:-1\r\n", len(value), value))
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateInputKey
+	m, _ = send(m, tea.WindowSizeMsg{Width: 120, Height: 30})
+
+	m, cmd := send(m, tui.InputCompleteMsg{Value: "greeting", Type: tui.InputKey})
+	m, _ = send(m, loadResult(cmd))
+	sum := sha256.Sum256([]byte(value))
+	want := fmt.Sprintf("14 bytes · 12 chars · 2 lines · sha256 %x…", sum[:8])
+	if view := m.View(); !strings.Contains(view, want) {
+		t.Errorf("want %q, got:\n%s", want, view)
+	}
+
+	m.CurrentState = tui.StateLoading
+	m, _ = send(m, tui.RedisResultMsg{Result: "ERR no such key", Command: "GET greeting"})
+	if view := m.View(); m.Result.Err == "" || strings.Contains(view, "sha256") {
+		t.Errorf("an error should show no value stats, got:\n%s", view)
+	}
+}
@@ -0,0 +1,65 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/redis/mock"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// editString opens key's value on the output screen, presses e and submits
+// value, returning the model once the SET's reply is shown.
+func editString(t *testing.T, server redis.ServerInfo, key, value string) (tui.Model, *mock.Server, string) {
+	t.Helper()
+	m, srv := connectMock(t, newTestModel())
+	srv.Do(0, "SET", key, "before")
+	srv.Do(0, "SET", "other", "x")
+	m.Audit = redis.NewAuditor(10, nil, "ada")
+	m.Conn = m.Audit.Wrap(m.Conn, "", m.RedisAddress)
+	m.Server = server
+	m.SelectedOp = tui.OpGet
+	m.ActiveKey = key
+	m.Result = tui.Result{Value: "before"}
+	m.StateNavigationHistory = []tui.AppState{tui.StateBrowser}
+	m.CurrentState = tui.StateOutput
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	// Someone else writes between the read and the edit.
+	srv.Do(0, "SET", key, "theirs")
+	m, cmd := send(m, tui.InputCompleteMsg{Type: tui.InputValue, Value: value})
+	m, _ = send(m, loadResult(cmd))
+	return m, srv, m.Result.Value
+}
+
+// TestEdit_CapturesPreviousValue verifies an edit on a server with SET ...
+// GET keeps the value it actually replaced, not the one shown when the
+// edit began.
+func TestEdit_CapturesPreviousValue(t *testing.T) {
+	m, srv, out := editString(t, redis.ServerInfo{Flavor: redis.FlavorRedis, Version: "7.2.4"}, "k", "after")
+	if v := srv.Do(0, "GET", "k"); v != "after" {
+		t.Fatalf("want k written, got %v", v)
+	}
+	if !strings.Contains(out, "Previous value:\ntheirs") {
+		t.Errorf("want the previous value shown, got %q", out)
+	}
+	if writes := m.Audit.Entries(false); len(writes) != 1 || writes[0].Replaced != "theirs" {
+		t.Errorf("want the audit log to note what the SET replaced, got %+v", writes)
+	}
+	if m.CaptureOld {
+		t.Error("CaptureOld should be spent by the reply")
+	}
+}
+
+func TestEdit_OldServerSendsPlainSet(t *testing.T) {
+	_, srv, out := editString(t, redis.ServerInfo{Flavor: redis.FlavorRedis, Version: "6.0.20"}, "k", "after")
+	if v := srv.Do(0, "GET", "k"); v != "after" {
+		t.Fatalf("want k written, got %v", v)
+	}
+	if out != "OK" {
+		t.Errorf("want a plain SET's OK, got %q", out)
+	}
+}
@@ -0,0 +1,120 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis/mock"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// pendingModel is on the pending entries of workers, opened from the groups
+// screen: 1-1 and 2-1, both delivered to w1.
+func pendingModel(t *testing.T) (tui.Model, *mock.Server) {
+	t.Helper()
+	m, srv := groupsModel(t)
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StatePending || len(m.Pending.Entries) != 2 {
+		t.Fatalf("want the two pending entries, got state %v %+v", m.CurrentState, m.Pending)
+	}
+	return m, srv
+}
+
+// TestPending_MarkAndAck verifies XACK takes the marked entries, or the
+// cursor's when none is marked, and the list is read again after.
+func TestPending_MarkAndAck(t *testing.T) {
+	m, srv := pendingModel(t)
+	if e := m.Pending.Entries[0]; e.Consumer != "w1" || e.Deliveries != 1 {
+		t.Errorf("want w1's first delivery, got %+v", e)
+	}
+	if view := m.View(); !strings.Contains(view, "2 pending") || !strings.Contains(view, "w1") {
+		t.Errorf("want the entries listed, got:\n%s", view)
+	}
+
+	m, _ = press(m, "a")
+	if !m.Pending.Entries[0].Marked || !m.Pending.Entries[1].Marked || !strings.Contains(m.View(), "2 marked") {
+		t.Fatal("a should mark every entry")
+	}
+	m, _ = press(m, "a")
+	if m.Pending.Entries[0].Marked || m.Pending.Entries[1].Marked {
+		t.Fatal("a again should clear the marks")
+	}
+
+	m, _ = press(m, " ")
+	first := m.Pending.Entries[0].ID
+	m, _ = press(m, "x")
+	if m.CurrentState != tui.StateConfirmation || !strings.Contains(m.View(), "acknowledge 1 pending entries of group workers") {
+		t.Fatalf("want the XACK confirmation, got:\n%s", m.View())
+	}
+	m, cmd := press(m, "y")
+	m = runGroupAction(m, cmd)
+	if m.CurrentState != tui.StatePending || len(m.Pending.Entries) != 1 || m.Pending.Entries[0].ID == first {
+		t.Fatalf("want the marked entry gone, got state %v %+v", m.CurrentState, m.Pending.Entries)
+	}
+	if !strings.Contains(m.View(), "Acknowledged 1 of 1 entries") {
+		t.Errorf("want the acknowledgement counted, got:\n%s", m.View())
+	}
+
+	// Nothing marked: the cursor's entry.
+	m, _ = press(m, "x")
+	m, cmd = press(m, "y")
+	m = runGroupAction(m, cmd)
+	if len(m.Pending.Entries) != 0 || !strings.Contains(m.View(), "nothing pending") {
+		t.Errorf("want nothing left pending, got %+v", m.Pending.Entries)
+	}
+	if got := fmt.Sprint(srv.Do(0, "XPENDING", "events", "workers")); !strings.HasPrefix(got, "[0 ") {
+		t.Errorf("want the server's PEL empty, got %v", got)
+	}
+
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateGroups || m.Groups.Groups[0].Pending != 0 {
+		t.Errorf("esc should return to fresh group counts, got state %v %+v", m.CurrentState, m.Groups.Groups)
+	}
+}
+
+// TestPending_Claim verifies XCLAIM hands the marked entries to the consumer
+// from the form, after the confirmation.
+func TestPending_Claim(t *testing.T) {
+	m, _ := pendingModel(t)
+	m, _ = press(m, "a")
+	m, _ = press(m, "c")
+	if m.CurrentState != tui.StateForm || m.SelectedOp != tui.OpPendingClaim {
+		t.Fatalf("want the XCLAIM form, got %v", m.CurrentState)
+	}
+	m, _ = send(m, tui.FormSubmitMsg{Values: []string{"w2", "soon"}})
+	if !strings.Contains(m.Form.Err, "milliseconds") {
+		t.Errorf("want a bad idle time refused, got %q", m.Form.Err)
+	}
+	m, _ = send(m, tui.FormSubmitMsg{Values: []string{"w2", "0"}})
+	if m.CurrentState != tui.StateConfirmation || !strings.Contains(m.View(), "hand 2 pending entries of group workers to w2") {
+		t.Fatalf("want the XCLAIM confirmation, got:\n%s", m.View())
+	}
+	m, cmd := press(m, "y")
+	m = runGroupAction(m, cmd)
+	if !strings.Contains(m.View(), "Claimed 2 of 2 entries for w2") {
+		t.Errorf("want the claim counted, got:\n%s", m.View())
+	}
+	for _, e := range m.Pending.Entries {
+		if e.Consumer != "w2" || e.Deliveries != 2 || e.Marked {
+			t.Errorf("want the entry redelivered to w2 and unmarked, got %+v", e)
+		}
+	}
+}
+
+// TestPending_ReadOnlyRefusesWrites verifies a read-only session can list
+// pending entries but not acknowledge or claim them.
+func TestPending_ReadOnlyRefusesWrites(t *testing.T) {
+	m, _ := pendingModel(t)
+	m.ReadOnly = true
+	for _, k := range []string{"x", "c"} {
+		got, _ := press(m, k)
+		if got.CurrentState != tui.StatePending || !strings.Contains(got.View(), "read-only") {
+			t.Errorf("%s: want a refusal on the pending screen, got state %v", k, got.CurrentState)
+		}
+	}
+}
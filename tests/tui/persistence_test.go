@@ -0,0 +1,119 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// infoReply builds an INFO persistence bulk reply from field lines.
+func infoReply(lines ...string) string {
+	return bulk("# Persistence\r\n" + strings.Join(lines, "\r\n") + "\r\n")
+}
+
+func newPersistenceModel(info map[string]string) tui.Model {
+	m := newTestModel()
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu}
+	m.CurrentState = tui.StatePersistence
+	m.Persistence = tui.PersistenceModel{Info: info, Active: true, Gen: 1}
+	return m
+}
+
+// TestMenu_Persistence_LoadsStatus verifies PERSISTENCE reads INFO
+// persistence and opens the status screen with the fields parsed.
+func TestMenu_Persistence_LoadsStatus(t *testing.T) {
+	conn, reader := newMockConn(infoReply("rdb_changes_since_last_save:42", "aof_enabled:1", "aof_current_size:2048"))
+	m := newPickerMenuModel("PERSISTENCE")
+	m.Conn, m.Reader = conn, reader
+
+	m2, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m2.CurrentState != tui.StateLoading {
+		t.Fatalf("state: want StateLoading, got %v", m2.CurrentState)
+	}
+	m3, next := send(m2, loadResult(cmd))
+
+	if m3.CurrentState != tui.StatePersistence {
+		t.Fatalf("state: want StatePersistence, got %v (%s)", m3.CurrentState, m3.Result.Text())
+	}
+	if got := m3.Persistence.Info["rdb_changes_since_last_save"]; got != "42" {
+		t.Errorf("changes since save: want 42, got %q", got)
+	}
+	if !strings.Contains(conn.writtenData.String(), "persistence") {
+		t.Errorf("want INFO persistence, sent %q", conn.writtenData.String())
+	}
+	if next == nil {
+		t.Error("the refresh tick should be armed")
+	}
+	if view := m3.View(); !strings.Contains(view, "2.0 KiB") {
+		t.Error("view should show the AOF size")
+	}
+}
+
+// TestPersistence_BGSave_ConfirmsAndWatches verifies b asks for confirmation,
+// sends BGSAVE, and reports completion once INFO shows it finished.
+func TestPersistence_BGSave_ConfirmsAndWatches(t *testing.T) {
+	conn, reader := newMockConn("+Background saving started\r\n" +
+		infoReply("rdb_bgsave_in_progress:1") +
+		infoReply("rdb_bgsave_in_progress:0", "rdb_last_bgsave_status:ok", "rdb_last_bgsave_time_sec:3"))
+	m := newPersistenceModel(map[string]string{"rdb_bgsave_in_progress": "0"})
+	m.Conn, m.Reader = conn, reader
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if m2.CurrentState != tui.StateConfirmation || m2.SelectedOp != tui.OpBGSave {
+		t.Fatalf("want a BGSAVE confirmation, got state %v op %v", m2.CurrentState, m2.SelectedOp)
+	}
+	m3, cmd := send(m2, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m4, sample := send(m3, loadResult(cmd))
+
+	if m4.CurrentState != tui.StatePersistence || m4.Persistence.Watching != "BGSAVE" {
+		t.Fatalf("want to watch BGSAVE on the status screen, got state %v watching %q", m4.CurrentState, m4.Persistence.Watching)
+	}
+	if !strings.Contains(conn.writtenData.String(), "BGSAVE") {
+		t.Errorf("BGSAVE not sent: %q", conn.writtenData.String())
+	}
+
+	m5, _ := send(m4, sample())
+	if m5.Persistence.Watching != "BGSAVE" {
+		t.Fatal("still in progress: should keep watching")
+	}
+	m6, _ := send(m5, sample())
+	if m6.Persistence.Watching != "" || !strings.Contains(m6.Persistence.Notice, "BGSAVE finished: ok in 3 s") {
+		t.Errorf("want a completion notice, got watching %q notice %q", m6.Persistence.Watching, m6.Persistence.Notice)
+	}
+}
+
+// TestPersistence_GuardsConcurrentJobs verifies a new job can't be started
+// while a save or rewrite is already running.
+func TestPersistence_GuardsConcurrentJobs(t *testing.T) {
+	for _, k := range []string{"b", "a"} {
+		m := newPersistenceModel(map[string]string{"aof_rewrite_in_progress": "1"})
+
+		m2, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+
+		if m2.CurrentState != tui.StatePersistence || cmd != nil {
+			t.Errorf("%s: should stay on the status screen, got %v", k, m2.CurrentState)
+		}
+		if !m2.Persistence.Failed || !strings.Contains(m2.Persistence.Notice, "in progress") {
+			t.Errorf("%s: want a refusal notice, got %q", k, m2.Persistence.Notice)
+		}
+	}
+}
+
+// TestPersistence_RefusedReply verifies an error reply to BGREWRITEAOF is
+// reported on the status screen instead of being watched.
+func TestPersistence_RefusedReply(t *testing.T) {
+	conn, reader := newMockConn("-ERR Background append only file rewriting already in progress\r\n" + infoReply("aof_rewrite_in_progress:1"))
+	m := newPersistenceModel(map[string]string{})
+	m.Conn, m.Reader = conn, reader
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m3, cmd := send(m2, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m4, _ := send(m3, loadResult(cmd))
+
+	if m4.CurrentState != tui.StatePersistence || m4.Persistence.Watching != "" || !m4.Persistence.Failed {
+		t.Errorf("want a failure notice, got state %v watching %q notice %q", m4.CurrentState, m4.Persistence.Watching, m4.Persistence.Notice)
+	}
+}
@@ -0,0 +1,64 @@
+package tui_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestBrowserDelegate_PreviewWidth verifies a long element is cut at the
+// configured width, not just at the row's edge, and 0 lifts the cap.
+func TestBrowserDelegate_PreviewWidth(t *testing.T) {
+	elem := strings.Repeat("x", 150)
+	item := tui.NewListItem(elem, "idx 0")
+	l := list.New([]list.Item{item}, tui.BrowserDelegate(), 200, 10)
+
+	var capped, uncapped bytes.Buffer
+	tui.BrowserDelegate().WithPreviewWidth(30).Render(&capped, l, 0, item)
+	tui.BrowserDelegate().WithPreviewWidth(0).Render(&uncapped, l, 0, item)
+
+	if !strings.Contains(capped.String(), strings.Repeat("x", 29)+"…") || strings.Contains(capped.String(), strings.Repeat("x", 30)) {
+		t.Errorf("want the element cut at 30 cells, got %q", capped.String())
+	}
+	if !strings.Contains(uncapped.String(), elem) {
+		t.Errorf("with no cap a 150-cell element fits a 200-cell row, got %q", uncapped.String())
+	}
+	if w := lipgloss.Width(capped.String()); w > 200 {
+		t.Errorf("row overflows: %d cells", w)
+	}
+}
+
+// TestBrowser_V_ShowsFullField verifies v opens the selected element,
+// untruncated, in the read-only output view, and esc goes back to the list.
+func TestBrowser_V_ShowsFullField(t *testing.T) {
+	elem := strings.Repeat("long ", 100)
+	m := newTestModel()
+	m.ActiveKey = "jobs"
+	m.SelectedOp = tui.OpLRange
+	m.CurrentState = tui.StateLoading
+	m, _ = send(m, tui.RedisResultMsg{Result: []any{elem}})
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if cmd == nil {
+		t.Fatal("v should request the full element")
+	}
+	m, _ = send(m, cmd())
+
+	if m.CurrentState != tui.StateOutput || m.SelectedOp != tui.OpFullValue {
+		t.Fatalf("want the full-value output, got state %v op %v", m.CurrentState, m.SelectedOp)
+	}
+	if got := m.Result.Value; got != elem {
+		t.Errorf("result: want the whole element, got %q", got)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateBrowser || !m.Browser.ViewingFields {
+		t.Errorf("esc should return to the element list, got %v", m.CurrentState)
+	}
+}
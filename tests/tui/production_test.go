@@ -0,0 +1,153 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis/mock"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// productionModel is connected to a mock server holding user:1, on a
+// profile marked production.
+func productionModel(t *testing.T) (tui.Model, *mock.Server) {
+	t.Helper()
+	m, srv := connectMock(t, newTestModel())
+	srv.Do(0, "SET", "user:1", "ada")
+	m.ProfileName, m.Production = "prod", true
+	m.WindowWidth, m.WindowHeight = 120, 30
+	return m, srv
+}
+
+// TestProduction_DeleteNeedsKeyTyped verifies y alone doesn't delete on a
+// production profile: the key name has to be typed, exactly, first.
+func TestProduction_DeleteNeedsKeyTyped(t *testing.T) {
+	m, srv := productionModel(t)
+	m.CurrentState = tui.StateBrowser
+	if !strings.Contains(m.View(), "PRODUCTION · prod") {
+		t.Errorf("want the production banner, got:\n%s", m.View())
+	}
+
+	m, _ = send(m, tui.DeleteRequestMsg{Key: "user:1"})
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd != nil || m.CurrentState != tui.StateConfirmation {
+		t.Fatalf("y should only ask for the key, got state %v", m.CurrentState)
+	}
+	if view := m.View(); !strings.Contains(view, "type user:1 to confirm") {
+		t.Errorf("want the key asked for, got:\n%s", view)
+	}
+
+	m, cmd = press(m, "user:2", "enter")
+	if cmd != nil || m.CurrentState != tui.StateConfirmation {
+		t.Fatalf("the wrong key should not confirm, got state %v", m.CurrentState)
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	m, cmd = press(m, "1", "enter")
+	if cmd == nil {
+		t.Fatal("typing the key should delete it")
+	}
+	m, _ = send(m, loadResult(cmd))
+	if v := srv.Do(0, "EXISTS", "user:1"); v != 0 {
+		t.Errorf("want user:1 deleted, EXISTS gave %v", v)
+	}
+	if m.Prod != (tui.ProdConfirm{}) {
+		t.Errorf("confirmation state should be cleared, got %+v", m.Prod)
+	}
+}
+
+// TestProduction_SetIsHeldUntilKeyTyped verifies a write with no
+// confirmation of its own is held on the confirmation screen, and sent as
+// it was once the key is typed.
+func TestProduction_SetIsHeldUntilKeyTyped(t *testing.T) {
+	m, srv := productionModel(t)
+	m.SelectedOp = tui.OpSet
+	m.ActiveKey = "user:1"
+	m.CurrentState = tui.StateInputValue
+
+	m, cmd := send(m, tui.InputCompleteMsg{Type: tui.InputValue, Value: "grace"})
+	if cmd != nil || m.CurrentState != tui.StateConfirmation {
+		t.Fatalf("SET should wait for confirmation, got state %v", m.CurrentState)
+	}
+	if view := m.View(); !strings.Contains(view, "confirm write") || !strings.Contains(view, "SET") {
+		t.Errorf("want the write described, got:\n%s", view)
+	}
+
+	m, cmd = press(m, "user:1", "enter")
+	if cmd == nil {
+		t.Fatal("typing the key should send the SET")
+	}
+	m, _ = send(m, loadResult(cmd))
+	if v := srv.Do(0, "GET", "user:1"); v != "grace" {
+		t.Errorf("want user:1 set, got %v", v)
+	}
+	if m.CurrentState != tui.StateOutput {
+		t.Errorf("want the reply shown, got state %v", m.CurrentState)
+	}
+}
+
+// TestProduction_EscCancelsHeldWrite verifies Esc drops a held write and
+// returns to where it was typed.
+func TestProduction_EscCancelsHeldWrite(t *testing.T) {
+	m, srv := productionModel(t)
+	m.SelectedOp = tui.OpSet
+	m.ActiveKey = "user:1"
+	m.CurrentState = tui.StateInputValue
+
+	m, _ = send(m, tui.InputCompleteMsg{Type: tui.InputValue, Value: "grace"})
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil || m.CurrentState != tui.StateInputValue {
+		t.Fatalf("esc should go back to the input, got state %v", m.CurrentState)
+	}
+	if v := srv.Do(0, "GET", "user:1"); v != "ada" {
+		t.Errorf("want user:1 untouched, got %v", v)
+	}
+}
+
+// TestProduction_ConsoleRefusesFlushAndHoldsWrites verifies the console on
+// a production profile never sends FLUSHDB or CONFIG SET, and sends other
+// writes only once the next line names the key.
+func TestProduction_ConsoleRefusesFlushAndHoldsWrites(t *testing.T) {
+	for _, line := range []string{"FLUSHDB", "flushall ASYNC", "CONFIG SET maxmemory 1gb", "SHUTDOWN"} {
+		m, conn := openConsole(t, "")
+		m.ProfileName, m.Production = "prod", true
+
+		m2, cmd := press(m, line, "enter")
+		if cmd != nil || conn.writtenData.Len() != 0 {
+			t.Errorf("%q: should not be sent", line)
+		}
+		if log := m2.Console.Log; len(log) != 1 || !log[0].Failed || !strings.Contains(log[0].Reply, "production") {
+			t.Errorf("%q: want a production refusal, got %+v", line, log)
+		}
+	}
+
+	m, conn := openConsole(t, "+OK\r\n")
+	m.ProfileName, m.Production = "prod", true
+	m, cmd := press(m, "SET user:1 grace", "enter")
+	if cmd != nil || conn.writtenData.Len() != 0 {
+		t.Fatal("a write should wait for its key")
+	}
+	m, cmd = press(m, "user:2", "enter")
+	if cmd != nil || conn.writtenData.Len() != 0 || !m.Console.Log[len(m.Console.Log)-1].Failed {
+		t.Fatalf("the wrong key should drop the write, got %+v", m.Console.Log)
+	}
+
+	m, _ = press(m, "SET user:1 grace", "enter")
+	m, cmd = press(m, "user:1", "enter")
+	if cmd == nil {
+		t.Fatal("typing the key should send the write")
+	}
+	m, _ = send(m, cmd())
+	if !strings.Contains(conn.writtenData.String(), "SET\r\n$6\r\nuser:1") {
+		t.Errorf("want the SET sent, got %q", conn.writtenData.String())
+	}
+	if last := m.Console.Log[len(m.Console.Log)-1]; last.Reply != "OK" {
+		t.Errorf("want the reply logged, got %+v", last)
+	}
+
+	m, cmd = press(m, "GET user:1", "enter")
+	if cmd == nil {
+		t.Error("reads should be sent straight away")
+	}
+}
@@ -0,0 +1,199 @@
+package tui_test
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestLoadConfig_MissingFile verifies profiles are optional.
+func TestLoadConfig_MissingFile(t *testing.T) {
+	cfg, err := tui.LoadConfig(filepath.Join(t.TempDir(), "nope.json"))
+	if err != nil || len(cfg.Profiles) != 0 {
+		t.Errorf("want empty config and no error, got %+v, %v", cfg, err)
+	}
+}
+
+// TestLoadConfig_DuplicateName verifies ambiguous profile names are rejected.
+func TestLoadConfig_DuplicateName(t *testing.T) {
+	path := writeConfig(t, `{"profiles":[{"name":"a","host":"x:1"},{"name":"a","host":"y:1"}]}`)
+	if _, err := tui.LoadConfig(path); err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("want duplicate-profile error, got %v", err)
+	}
+}
+
+// TestProfile_Endpoint_URL verifies a URL profile resolves host, DB and TLS,
+// and that password_env takes the password from the environment.
+func TestProfile_Endpoint_URL(t *testing.T) {
+	t.Setenv("PROD_REDIS_PW", "s3cret")
+	path := writeConfig(t, `{"profiles":[{"name":"prod","url":"rediss://cache.example.com/2","password_env":"PROD_REDIS_PW"}]}`)
+	cfg, err := tui.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, ok := cfg.Find("prod")
+	if !ok {
+		t.Fatal("profile prod not found")
+	}
+	e, err := p.Endpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Address != "cache.example.com:6379" || e.DB != 2 || e.Password != "s3cret" {
+		t.Errorf("unexpected endpoint: %+v", e)
+	}
+	if e.TLSConfig == nil || e.TLSConfig.ServerName != "cache.example.com" {
+		t.Error("rediss:// should enable TLS with the host as ServerName")
+	}
+}
+
+// TestProfile_Endpoint_NoHost verifies a profile without a target is an error.
+func TestProfile_Endpoint_NoHost(t *testing.T) {
+	if _, err := (tui.Profile{Name: "empty"}).Endpoint(); err == nil {
+		t.Error("expected an error for a profile with neither url nor host")
+	}
+}
+
+// TestProfile_Inline verifies "inline": true reaches side connections, whose
+// whole handshake and commands then go out as inline text.
+func TestProfile_Inline(t *testing.T) {
+	e, err := tui.Profile{Name: "proxy", Host: "proxy:6379", Inline: true}.Endpoint()
+	if err != nil || !e.Inline {
+		t.Fatalf("want an inline endpoint, got %+v, %v", e, err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+	lines := make(chan string, 4)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			lines <- strings.TrimRight(line, "\r\n")
+			if _, err := conn.Write([]byte("+OK\r\n")); err != nil {
+				return
+			}
+		}
+	}()
+
+	m := loadClusterModel(t, l.Addr().String())
+	m.Inline = true
+	m.Cluster.Cursor = 1
+	m2, connect := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	m3, _ := send(m2, connect())
+	if m3.Console.Conn == nil {
+		t.Fatal("node console did not connect")
+	}
+	t.Cleanup(func() { _ = m3.Console.Conn.Close() })
+	m4, cmd := press(m3, `SET greeting "hello world"`, "enter")
+	send(m4, cmd())
+
+	for _, want := range []string{"SELECT 0", `SET greeting "hello world"`} {
+		if got := <-lines; got != want {
+			t.Errorf("want inline %q, got %q", want, got)
+		}
+	}
+}
+
+// TestLoadConfig_OnConnectRefusesSubscribe verifies streaming commands are
+// rejected when the config loads, not on every connect.
+func TestLoadConfig_OnConnectRefusesSubscribe(t *testing.T) {
+	path := writeConfig(t, `{"profiles":[{"name":"a","host":"x:1","on_connect":["SELECT 2","subscribe events"]}]}`)
+	if _, err := tui.LoadConfig(path); err == nil || !strings.Contains(err.Error(), "SUBSCRIBE") {
+		t.Errorf("want SUBSCRIBE refused, got %v", err)
+	}
+}
+
+// TestConnect_RunsOnConnect verifies on_connect commands run in order on the
+// session connection and that a SELECT among them moves the header's DB.
+func TestConnect_RunsOnConnect(t *testing.T) {
+	var got []string
+	addr := startFakeRedis(t, func(cmd []string) string {
+		got = append(got, strings.Join(cmd, " "))
+		if cmd[0] == "CLIENT" && cmd[1] == "ID" {
+			return ":7\r\n"
+		}
+		return "+OK\r\n"
+	})
+	m := newTestModel()
+	m.RedisAddress = addr
+	m.OnConnect = []string{"SELECT 2", `SET boot "hello world"`}
+
+	_, connect := send(m, tui.TickMsg{})
+	m2, _ := send(m, connect())
+	if m2.Conn == nil {
+		t.Fatalf("connect failed: %q", m2.Result.Text())
+	}
+	t.Cleanup(func() { _ = m2.Conn.Close() })
+
+	// SELECT is answered by the fake server itself, so only SET shows up.
+	if want := []string{"SET boot hello world", "CLIENT ID", "INFO server"}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if m2.DB != 2 {
+		t.Errorf("want DB 2 after on_connect SELECT, got %d", m2.DB)
+	}
+}
+
+// TestConnect_OnConnectErrorIsFatal verifies a failing on_connect command
+// stops the connection with the error instead of retrying forever.
+func TestConnect_OnConnectErrorIsFatal(t *testing.T) {
+	addr := startFakeRedis(t, func([]string) string {
+		return "-NOPERM this user has no permissions to run the 'config' command\r\n"
+	})
+	m := newTestModel()
+	m.RedisAddress = addr
+	m.OnConnect = []string{"CONFIG SET maxmemory-policy allkeys-lru"}
+
+	_, connect := send(m, tui.TickMsg{})
+	msg, ok := connect().(tui.RedisConnectionMsg)
+	if !ok || !msg.Fatal || msg.Error == nil || !strings.Contains(msg.Error.Error(), "NOPERM") {
+		t.Fatalf("want a fatal NOPERM error, got %+v", msg)
+	}
+}
+
+// TestProfile_PasswordCommand verifies the password comes from the command's
+// output, minus its trailing newline, and that a failing command names the
+// profile and carries its stderr.
+func TestProfile_PasswordCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	e, err := tui.Profile{Name: "prod", Host: "h:1", Password: "stale", PasswordCommand: `printf 's3 cret\n'`}.Endpoint()
+	if err != nil || e.Password != "s3 cret" {
+		t.Errorf("want password from the command, got %q, %v", e.Password, err)
+	}
+
+	_, err = tui.Profile{Name: "prod", Host: "h:1", PasswordCommand: "echo 'item not found' >&2; exit 3"}.Endpoint()
+	if err == nil || !strings.Contains(err.Error(), `profile "prod"`) || !strings.Contains(err.Error(), "item not found") {
+		t.Errorf("want a profile error with stderr, got %v", err)
+	}
+}
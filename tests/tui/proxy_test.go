@@ -0,0 +1,163 @@
+package tui_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+func newProxyMenuModel(cmd string, data string) (tui.Model, *mockConn) {
+	conn, reader := newMockConn(data)
+	m := newPickerMenuModel(cmd)
+	m.Conn, m.Reader = conn, reader
+	m.Proxy = true
+	m.ProfileName = "twem"
+	return m, conn
+}
+
+// explore runs EXPLORE with pattern and returns the model once the listing
+// has loaded.
+func explore(t *testing.T, m tui.Model, pattern string) tui.Model {
+	t.Helper()
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd := send(m, tui.InputCompleteMsg{Type: tui.InputPattern, Value: pattern})
+	if cmd == nil {
+		t.Fatal("the pattern should load keys")
+	}
+	m, _ = send(m, loadResult(cmd))
+	return m
+}
+
+// TestProxy_HandshakeSkipsClient verifies a proxy profile connects without
+// CLIENT SETNAME or CLIENT ID, which Twemproxy answers by closing the
+// connection, and refuses a database other than 0 rather than SELECT it.
+func TestProxy_HandshakeSkipsClient(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	addr := startFakeRedis(t, func(cmd []string) string {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, cmd[0])
+		return "-ERR unsupported\r\n"
+	})
+	m := newTestModel()
+	m.RedisAddress, m.Proxy, m.ClientName = addr, true, "redis-tui:test"
+
+	_, connect := send(m, tui.TickMsg{})
+	if msg, ok := connect().(tui.RedisConnectionMsg); !ok || msg.Error != nil || msg.Conn == nil {
+		t.Fatalf("want a connection, got %+v", msg)
+	}
+	mu.Lock()
+	if len(seen) != 0 {
+		t.Errorf("want nothing sent after the handshake, got %v", seen)
+	}
+	mu.Unlock()
+
+	m.DB = 2
+	_, connect = send(m, tui.TickMsg{})
+	if msg, _ := connect().(tui.RedisConnectionMsg); !msg.Fatal || msg.Error == nil || !strings.Contains(msg.Error.Error(), "database 0") {
+		t.Errorf("want a fatal error for db 2, got %+v", msg)
+	}
+}
+
+// TestProxy_ExploreListsProfileKeys verifies EXPLORE lists the profile's
+// proxy_keys that match the pattern, drops missing ones, and never SCANs.
+func TestProxy_ExploreListsProfileKeys(t *testing.T) {
+	// TYPE and TTL for user:1 and user:2, pipelined.
+	m, conn := newProxyMenuModel("EXPLORE", "+hash\r\n:-1\r\n+none\r\n:-2\r\n")
+	m.ProxyKeys = []string{"user:1", "cart:1", "user:2"}
+
+	m = explore(t, m, "user:*")
+
+	items := m.Browser.KeyList.Items()
+	if len(items) != 1 || items[0].(tui.ListItem).Title() != "user:1" {
+		t.Errorf("want only user:1, got %v", items)
+	}
+	written := conn.writtenData.String()
+	if strings.Contains(written, "SCAN") || strings.Contains(written, "cart:1") {
+		t.Errorf("want TYPE/TTL for the matching keys only, sent %q", written)
+	}
+}
+
+// TestProxy_ExploreKeysNeedsNamespace verifies that without proxy_keys
+// EXPLORE uses KEYS, but only for a pattern with a literal prefix.
+func TestProxy_ExploreKeysNeedsNamespace(t *testing.T) {
+	m, conn := newProxyMenuModel("EXPLORE", "")
+	m = explore(t, m, "*")
+	if !strings.Contains(m.Result.Text(), "namespace") || conn.writtenData.Len() != 0 {
+		t.Errorf("want a refusal for *, got %q (sent %q)", m.Result.Text(), conn.writtenData.String())
+	}
+
+	m, conn = newProxyMenuModel("EXPLORE", "*1\r\n$7\r\nuser:42\r\n+string\r\n:30\r\n")
+	m = explore(t, m, "user:*")
+	if !strings.Contains(conn.writtenData.String(), "KEYS\r\n$6\r\nuser:*") {
+		t.Errorf("want KEYS user:*, sent %q", conn.writtenData.String())
+	}
+	if items := m.Browser.KeyList.Items(); len(items) != 1 {
+		t.Errorf("want user:42 listed, got %v", items)
+	}
+}
+
+// TestProxy_RefusesUnsupportedTools verifies tools built on SCAN or
+// subscriptions are refused from the menu, and the console won't send
+// commands proxies drop.
+func TestProxy_RefusesUnsupportedTools(t *testing.T) {
+	for cmd, want := range map[string]string{"TIMELINE": "PSUBSCRIBE", "DIFF_DB": "SCAN", "BLPOP": "blocking"} {
+		m, _ := newProxyMenuModel(cmd, "")
+		m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+		if m2.CurrentState != tui.StateOutput || !strings.Contains(m2.Result.Text(), want) {
+			t.Errorf("%s: want a refusal mentioning %q, got state %v output %q", cmd, want, m2.CurrentState, m2.Result.Text())
+		}
+	}
+
+	m, conn := newProxyMenuModel("CONSOLE", "")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	for _, line := range []string{"SELECT 1", "multi", "CLIENT LIST"} {
+		m2, cmd := press(m, line, "enter")
+		if cmd != nil || conn.writtenData.Len() != 0 || !m2.Console.Log[len(m2.Console.Log)-1].Failed {
+			t.Errorf("%q: should be refused", line)
+		}
+	}
+}
+
+// TestProxy_RefusesScanAndBlockingInScreens verifies the screens that stay
+// available in proxy mode don't send what proxies drop: a queue glob is
+// refused on the form, live refresh is refused with a toast, and a stream
+// shows its newest entries without following them. A snapshot of named
+// keys is allowed.
+func TestProxy_RefusesScanAndBlockingInScreens(t *testing.T) {
+	m, conn := newProxyMenuModel("QUEUES", "")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"queue:*", "2"}})
+	if cmd != nil || !strings.Contains(m2.Form.Err, "SCAN") {
+		t.Errorf("want a queue glob refused on the form, got %q", m2.Form.Err)
+	}
+	snap, _ := newProxyMenuModel("SNAPSHOT", "")
+	snap, _ = send(snap, tea.KeyMsg{Type: tea.KeyEnter})
+	if s, cmd := send(snap, tui.FormSubmitMsg{Values: []string{"user:*", "60", t.TempDir()}}); cmd != nil || !strings.Contains(s.Form.Err, "SCAN") {
+		t.Errorf("want a snapshot glob refused on the form, got %q", s.Form.Err)
+	}
+	if s, cmd := send(snap, tui.FormSubmitMsg{Values: []string{"user:1, user:2", "60", t.TempDir()}}); cmd == nil || s.Form.Err != "" {
+		t.Errorf("want a snapshot of named keys started, got %q", s.Form.Err)
+	}
+
+	m2, _ = send(m, tui.LiveToggleMsg{Key: "greeting"})
+	if m2.Live.Active || !m2.Toast.Failed || !strings.Contains(m2.Toast.Text, "SUBSCRIBE") {
+		t.Errorf("want live refresh refused, got toast %q", m2.Toast.Text)
+	}
+
+	tail, _ := newMockConn("")
+	m.SelectedOp, m.CurrentState = tui.OpTail, tui.StateLoading
+	m.Tail = tui.TailModel{Key: "events", Buffer: 100}
+	m2, _ = send(m, tui.TailConnMsg{Conn: tail, Backlog: []tui.TailEntry{{ID: "1-0", Fields: []string{"a", "1"}}}})
+	if m2.Tail.Waiting || m2.CurrentState != tui.StateTail || len(m2.Tail.Entries) != 1 || !strings.Contains(m2.Tail.Err, "XREAD BLOCK") {
+		t.Errorf("want the backlog shown without following, got state %v, err %q", m2.CurrentState, m2.Tail.Err)
+	}
+	if conn.writtenData.Len() != 0 || tail.writtenData.Len() != 0 {
+		t.Errorf("want nothing sent, got %q %q", conn.writtenData.String(), tail.writtenData.String())
+	}
+}
@@ -0,0 +1,226 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+func newQueuesModel(keys ...string) tui.Model {
+	m := newTestModel()
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu, tui.StateForm}
+	m.CurrentState = tui.StateQueues
+	m.Queues = tui.QueuesModel{
+		Specs:    keys,
+		Interval: time.Second,
+		Keys:     keys,
+		Lengths:  map[string][]float64{},
+		Oldest:   map[string]string{},
+		Active:   true,
+		Gen:      1,
+	}
+	return m
+}
+
+// TestMenu_Queues_OpensForm verifies QUEUES opens its parameter form.
+func TestMenu_Queues_OpensForm(t *testing.T) {
+	m := newPickerMenuModel("QUEUES")
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m2.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v", m2.CurrentState)
+	}
+	if got := m2.Form.Values(); len(got) != 2 || got[0] != "queue:*" {
+		t.Errorf("unexpected form defaults: %v", got)
+	}
+}
+
+// TestQueuesForm_InvalidInterval_StaysOnForm verifies a bad interval is
+// rejected inline.
+func TestQueuesForm_InvalidInterval_StaysOnForm(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpQueues
+	m.CurrentState = tui.StateForm
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"jobs", "0"}})
+
+	if m2.CurrentState != tui.StateForm || m2.Form.Err == "" {
+		t.Errorf("want form with error, got state %v err %q", m2.CurrentState, m2.Form.Err)
+	}
+	if cmd != nil {
+		t.Error("expected no command for an invalid form")
+	}
+}
+
+// TestQueuesForm_Valid_SamplesLiteralKeys verifies literal keys are sampled
+// with one pipelined LLEN + LINDEX batch and the dashboard opens.
+func TestQueuesForm_Valid_SamplesLiteralKeys(t *testing.T) {
+	conn, reader := newMockConn(":3\r\n$5\r\nfirst\r\n:0\r\n$-1\r\n")
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.SelectedOp = tui.OpQueues
+	m.CurrentState = tui.StateForm
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"jobs, mail", "2"}})
+	if m2.CurrentState != tui.StateLoading || cmd == nil {
+		t.Fatalf("want StateLoading with a cmd, got %v", m2.CurrentState)
+	}
+
+	sample, ok := loadResult(cmd).(tui.QueueSampleMsg)
+	if !ok {
+		t.Fatal("expected a QueueSampleMsg")
+	}
+	written := conn.writtenData.String()
+	if !strings.Contains(written, "LLEN") || !strings.Contains(written, "LINDEX") || strings.Contains(written, "SCAN") {
+		t.Errorf("unexpected commands written: %q", written)
+	}
+
+	m3, next := send(m2, sample)
+	if m3.CurrentState != tui.StateQueues {
+		t.Fatalf("state: want StateQueues, got %v", m3.CurrentState)
+	}
+	if got := m3.Queues.Lengths["jobs"]; len(got) != 1 || got[0] != 3 {
+		t.Errorf("jobs history: want [3], got %v", got)
+	}
+	if m3.Queues.Oldest["jobs"] != "first" {
+		t.Errorf("oldest: want first, got %q", m3.Queues.Oldest["jobs"])
+	}
+	if _, ok := m3.Queues.Oldest["mail"]; ok {
+		t.Error("an empty queue should have no oldest preview")
+	}
+	if next == nil {
+		t.Error("expected the refresh tick to be scheduled")
+	}
+}
+
+// TestQueueSample_HistoryCapped verifies the per-queue history keeps only the
+// most recent samples.
+func TestQueueSample_HistoryCapped(t *testing.T) {
+	m := newQueuesModel("jobs")
+	for i := 0; i < 100; i++ {
+		m, _ = send(m, tui.QueueSampleMsg{Gen: 1, Lengths: map[string]int{"jobs": i}})
+	}
+	hist := m.Queues.Lengths["jobs"]
+	if len(hist) != 60 || hist[len(hist)-1] != 99 {
+		t.Errorf("want 60 samples ending at 99, got %d ending at %v", len(hist), hist[len(hist)-1])
+	}
+}
+
+// TestQueueSample_StaleGenerationIgnored verifies samples from a previous
+// dashboard session are dropped.
+func TestQueueSample_StaleGenerationIgnored(t *testing.T) {
+	m := newQueuesModel("jobs")
+
+	m2, cmd := send(m, tui.QueueSampleMsg{Gen: 0, Lengths: map[string]int{"jobs": 5}, Rearm: true})
+
+	if len(m2.Queues.Lengths["jobs"]) != 0 || cmd != nil {
+		t.Error("stale sample should be ignored")
+	}
+}
+
+// TestQueueTick_OffScreen_DoesNotSample verifies the tick only keeps itself
+// alive while a prompt is on top of the dashboard (the shared connection is
+// busy with that prompt).
+func TestQueueTick_OffScreen_DoesNotSample(t *testing.T) {
+	conn, reader := newMockConn("")
+	m := newQueuesModel("jobs")
+	m.Conn, m.Reader = conn, reader
+	m.CurrentState = tui.StateInputValue
+
+	_, cmd := send(m, tui.QueueTickMsg{Gen: 1})
+
+	if cmd == nil {
+		t.Fatal("expected the tick to be re-scheduled")
+	}
+	if conn.writtenData.Len() != 0 {
+		t.Errorf("nothing should be sent off-screen, got %q", conn.writtenData.String())
+	}
+}
+
+// TestQueues_Pop_ConfirmsThenRPOPs verifies 'o' asks for confirmation and 'y'
+// pops the oldest job from the tail.
+func TestQueues_Pop_ConfirmsThenRPOPs(t *testing.T) {
+	conn, reader := newMockConn("$3\r\njob\r\n")
+	m := newQueuesModel("jobs")
+	m.Conn, m.Reader = conn, reader
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if m2.CurrentState != tui.StateConfirmation || m2.ActiveKey != "jobs" {
+		t.Fatalf("want confirmation for jobs, got %v / %q", m2.CurrentState, m2.ActiveKey)
+	}
+
+	m3, cmd := send(m2, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil {
+		t.Fatal("expected RPOP to be sent")
+	}
+	m4, _ := send(m3, loadResult(cmd))
+	if !strings.Contains(conn.writtenData.String(), "RPOP") {
+		t.Errorf("expected RPOP, got %q", conn.writtenData.String())
+	}
+	if m4.CurrentState != tui.StateOutput || m4.Result.Text() != "job" {
+		t.Errorf("want popped value in output, got %v / %q", m4.CurrentState, m4.Result.Text())
+	}
+
+	m5, _ := send(m4, tea.KeyMsg{Type: tea.KeyEscape})
+	if m5.CurrentState != tui.StateQueues {
+		t.Errorf("esc: want StateQueues, got %v", m5.CurrentState)
+	}
+}
+
+// TestQueues_Push_ReturnsToDashboard verifies a push from the dashboard
+// LPUSHes and goes straight back to the dashboard.
+func TestQueues_Push_ReturnsToDashboard(t *testing.T) {
+	conn, reader := newMockConn(":4\r\n")
+	m := newQueuesModel("jobs")
+	m.Conn, m.Reader = conn, reader
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if m2.CurrentState != tui.StateInputValue {
+		t.Fatalf("state: want StateInputValue, got %v", m2.CurrentState)
+	}
+
+	m3, cmd := send(m2, tui.InputCompleteMsg{Value: "payload", Type: tui.InputValue})
+	if cmd == nil {
+		t.Fatal("expected LPUSH to be sent")
+	}
+	m4, _ := send(m3, loadResult(cmd))
+	if !strings.HasPrefix(conn.writtenData.String(), "*3\r\n$5\r\nLPUSH\r\n$4\r\njobs\r\n$7\r\npayload\r\n") {
+		t.Errorf("unexpected command: %q", conn.writtenData.String())
+	}
+	if m4.CurrentState != tui.StateQueues {
+		t.Errorf("state: want StateQueues, got %v", m4.CurrentState)
+	}
+}
+
+// TestQueues_Esc_Deactivates verifies leaving the dashboard stops its tick.
+func TestQueues_Esc_Deactivates(t *testing.T) {
+	m := newQueuesModel("jobs")
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEscape})
+	if m2.CurrentState != tui.StateForm || m2.Queues.Active {
+		t.Fatalf("want inactive dashboard back on the form, got %v active=%v", m2.CurrentState, m2.Queues.Active)
+	}
+	if _, cmd := send(m2, tui.QueueTickMsg{Gen: 1}); cmd != nil {
+		t.Error("tick should stop once the dashboard is closed")
+	}
+}
+
+// TestQueues_View_ShowsQueuesAndOldest verifies the dashboard lists each queue
+// with its length and oldest-job preview.
+func TestQueues_View_ShowsQueuesAndOldest(t *testing.T) {
+	m := newQueuesModel("jobs", "mail")
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m, _ = send(m, tui.QueueSampleMsg{Gen: 1, Lengths: map[string]int{"jobs": 12}, Oldest: map[string]string{"jobs": "job-1"}})
+
+	view := m.View()
+	for _, want := range []string{"QUEUES", "jobs", "mail", "12", `"job-1"`} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
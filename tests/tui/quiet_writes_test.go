@@ -0,0 +1,56 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestQuietWrite_TTLStaysOnTheValue verifies a TTL set with x on an open
+// value returns to that value with a toast instead of showing the bare 1.
+func TestQuietWrite_TTLStaysOnTheValue(t *testing.T) {
+	conn, reader := newMockConn(":1\r\n:60\r\n")
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu, tui.StateBrowser}
+	m.CurrentState, m.SelectedOp = tui.StateOutput, tui.OpGet
+	m.ActiveKey = "greeting"
+	m.Result = tui.Result{Value: "hello"}
+
+	m, _ = press(m, "x")
+	m, cmd := send(m, tui.InputCompleteMsg{Type: tui.InputValue, Value: "60"})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateOutput || m.SelectedOp != tui.OpGet || m.Result.Value != "hello" {
+		t.Fatalf("want the value back, got state %v op %v %+v", m.CurrentState, m.SelectedOp, m.Result)
+	}
+	if view := m.View(); !strings.Contains(view, "greeting expires in 1m") {
+		t.Errorf("want a toast, got:\n%s", view)
+	}
+	if n := len(m.StateNavigationHistory); n != 2 {
+		t.Errorf("want the prompt's entry unstacked, got %v", m.StateNavigationHistory)
+	}
+}
+
+// TestQuietWrite_RenameListsKeysAgain verifies a rename from the browser
+// goes back to the key list, read again, with a toast.
+func TestQuietWrite_RenameListsKeysAgain(t *testing.T) {
+	m, conn := browseKeys("+OK\r\n*2\r\n$1\r\n0\r\n*1\r\n$3\r\nnew\r\n", "old")
+	m, _ = send(m, tui.RenameRequestMsg{Key: "old"})
+	m, cmd := send(m, tui.InputCompleteMsg{Type: tui.InputValue, Value: "new"})
+	m, cmd = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateLoading || !strings.Contains(m.View(), "Renamed to new") {
+		t.Fatalf("want the keys listed again with a toast, got state %v:\n%s", m.CurrentState, m.View())
+	}
+	msg := loadResult(cmd)
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		msg = loadResult(func() tea.Msg { return batch })
+	}
+	m, _ = send(m, msg)
+	if m.CurrentState != tui.StateBrowser || !strings.Contains(conn.writtenData.String(), "SCAN") {
+		t.Errorf("want the browser back after a SCAN, got state %v, sent %q", m.CurrentState, conn.writtenData.String())
+	}
+}
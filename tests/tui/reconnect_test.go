@@ -0,0 +1,268 @@
+package tui_test
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+func connFailed(m tui.Model) (tui.Model, tea.Cmd) {
+	return send(m, tui.RedisConnectionMsg{Error: &errString{"connection refused"}})
+}
+
+// TestReconnect_ShowsCountdown verifies a failed reconnect leaves the screen
+// that issued the command on the stack and shows the attempt and the wait.
+func TestReconnect_ShowsCountdown(t *testing.T) {
+	m := newTestModel()
+	m.CurrentState = tui.StateLoading
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu, tui.StateBrowser}
+
+	m2, _ := connFailed(m)
+	m3, cmd := connFailed(m2)
+
+	if m3.CurrentState != tui.StateReconnect || cmd == nil {
+		t.Fatalf("want the reconnect screen with a retry scheduled, got %v", m3.CurrentState)
+	}
+	view := m3.View()
+	for _, want := range []string{"reconnecting (attempt 2, next in", "connection refused"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	conn, _ := newMockConn("")
+	m4, _ := send(m3, tui.RedisConnectionMsg{Conn: conn})
+	if m4.CurrentState != tui.StateBrowser || m4.ReconnectAttempts != 0 {
+		t.Errorf("success should return to the browser, got %v (attempts %d)", m4.CurrentState, m4.ReconnectAttempts)
+	}
+	if !strings.HasPrefix(m4.Toast.Text, "Reconnected") {
+		t.Errorf("want a reconnected toast, got %q", m4.Toast.Text)
+	}
+}
+
+// TestReconnect_GivesUp verifies retries stop at the cap until the user asks
+// for another round.
+func TestReconnect_GivesUp(t *testing.T) {
+	m := newTestModel()
+	var cmd tea.Cmd
+	for i := 0; i < 8; i++ {
+		m, cmd = connFailed(m)
+	}
+	if !m.Reconnect.GaveUp || cmd != nil {
+		t.Fatalf("want no more automatic retries after 8 attempts, got cmd=%v", cmd != nil)
+	}
+	if !strings.Contains(m.View(), "gave up after 8 attempts") {
+		t.Error("view should say retrying stopped")
+	}
+
+	m2, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if cmd == nil || !m2.Reconnect.Connecting || m2.ReconnectAttempts != 0 {
+		t.Errorf("r should start a fresh round, got attempts %d", m2.ReconnectAttempts)
+	}
+}
+
+// TestReconnect_RetryNowDropsScheduledTick verifies the pending automatic
+// attempt doesn't fire on top of a manual one.
+func TestReconnect_RetryNowDropsScheduledTick(t *testing.T) {
+	m, _ := connFailed(newTestModel())
+	pending := tui.TickMsg{Gen: m.Reconnect.Gen}
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m2.Reconnect.Connecting = false // the manual attempt came back
+	if _, cmd := send(m2, pending); cmd != nil {
+		t.Error("stale tick should be ignored")
+	}
+}
+
+// TestReconnect_ChangeServer verifies s lets the user type another address,
+// which is dialled without the old credentials and lands on the menu.
+func TestReconnect_ChangeServer(t *testing.T) {
+	addr := startFakeRedis(t, func(cmd []string) string { return "+OK\r\n" })
+	m := newTestModel()
+	m.Password = "old-secret"
+	m.CurrentState = tui.StateBrowser
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu}
+	m, _ = connFailed(m)
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if !m2.Reconnect.Editing {
+		t.Fatal("s should open the server input")
+	}
+	m2.Reconnect.Input.SetValue("")
+	m3, cmd := press(m2, addr, "enter")
+	if m3.RedisAddress != addr || m3.Password != "" || cmd == nil {
+		t.Fatalf("want a connection to %s without the old password, got %q", addr, m3.RedisAddress)
+	}
+	m4, _ := send(m3, cmd())
+	t.Cleanup(func() { _ = m4.Conn.Close() })
+
+	if m4.Conn == nil || m4.CurrentState != tui.StateMenu {
+		t.Errorf("want connected on the menu, got %v", m4.CurrentState)
+	}
+}
+
+// TestReconnect_ChangeServerUnknownProfile verifies a typo is reported
+// instead of dialled.
+func TestReconnect_ChangeServerUnknownProfile(t *testing.T) {
+	m, _ := connFailed(newTestModel())
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m2.Reconnect.Input.SetValue("")
+
+	m3, cmd := press(m2, "stagign", "enter")
+
+	if cmd != nil || !strings.Contains(m3.Reconnect.Notice, `no profile "stagign"`) {
+		t.Errorf("want a notice, got %q", m3.Reconnect.Notice)
+	}
+}
+
+// TestReconnect_ChangeServerPasswordCommand verifies a profile's
+// password_command runs in a tea.Cmd behind the loading screen, not in
+// Update, and that a failing one leaves the reconnect screen with a notice.
+func TestReconnect_ChangeServerPasswordCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	addr := startFakeRedis(t, func(cmd []string) string { return "+OK\r\n" })
+	m, _ := connFailed(newTestModel())
+	m.Config = tui.Config{Profiles: []tui.Profile{
+		{Name: "vault", Host: addr, PasswordCommand: "printf s3cret"},
+		{Name: "locked", Host: addr, PasswordCommand: "echo 'vault is locked' >&2; exit 1"},
+	}}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m.Reconnect.Input.SetValue("")
+
+	m2, cmd := press(m, "vault", "enter")
+	if m2.CurrentState != tui.StateLoading || m2.Password != "" || cmd == nil {
+		t.Fatalf("want the command left to a tea.Cmd, got state %v, password %q", m2.CurrentState, m2.Password)
+	}
+	m2, cmd = send(m2, loadResult(cmd))
+	if m2.CurrentState != tui.StateReconnect || m2.Password != "s3cret" || !m2.Reconnect.Connecting || cmd == nil {
+		t.Fatalf("want a connection with the command's password, got state %v, password %q", m2.CurrentState, m2.Password)
+	}
+	m2, _ = send(m2, cmd())
+	t.Cleanup(func() { _ = m2.Conn.Close() })
+	if m2.Conn == nil || m2.CurrentState != tui.StateMenu || m2.ProfileName != "vault" {
+		t.Errorf("want connected to vault on the menu, got %v", m2.CurrentState)
+	}
+
+	m3, cmd := press(m, "locked", "enter")
+	m3, _ = send(m3, loadResult(cmd))
+	if m3.CurrentState != tui.StateReconnect || !strings.Contains(m3.Reconnect.Notice, "vault is locked") || m3.ProfileName == "locked" {
+		t.Errorf("want the failure noticed on the reconnect screen, got state %v, %q", m3.CurrentState, m3.Reconnect.Notice)
+	}
+}
+
+// recordingRedis answers +OK to everything and reports each command received.
+func recordingRedis(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+	got := make(chan string, 16)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			req, err := redis.ReadResp(r)
+			if err != nil {
+				return
+			}
+			var words []string
+			for _, p := range req.([]any) {
+				words = append(words, p.(string))
+			}
+			got <- strings.Join(words, " ")
+			if _, err := conn.Write([]byte("+OK\r\n")); err != nil {
+				return
+			}
+		}
+	}()
+	return l.Addr().String(), got
+}
+
+// TestReconnect_ReplaysConsoleSessionState verifies SELECT, AUTH and CLIENT
+// SETNAME run in the console survive a reconnect.
+func TestReconnect_ReplaysConsoleSessionState(t *testing.T) {
+	m, _ := openConsole(t, "+OK\r\n+OK\r\n+OK\r\n")
+	for _, line := range []string{"SELECT 3", "AUTH alice pw", "CLIENT SETNAME worker-1"} {
+		var cmd tea.Cmd
+		m, cmd = press(m, line, "enter")
+		m, _ = send(m, cmd())
+	}
+	if m.DB != 3 || m.Username != "alice" || m.ClientName != "worker-1" {
+		t.Fatalf("session state not tracked: db=%d user=%q name=%q", m.DB, m.Username, m.ClientName)
+	}
+
+	addr, got := recordingRedis(t)
+	m.RedisAddress = addr
+	_, connect := send(m, tui.TickMsg{Gen: m.Reconnect.Gen})
+	msg := connect()
+	if c, ok := msg.(tui.RedisConnectionMsg); !ok || c.Error != nil {
+		t.Fatalf("reconnect failed: %+v", msg)
+	} else {
+		t.Cleanup(func() { _ = c.Conn.Close() })
+	}
+	for _, want := range []string{"AUTH alice pw", "SELECT 3", "CLIENT SETNAME worker-1"} {
+		if c := <-got; c != want {
+			t.Errorf("want %q replayed, got %q", want, c)
+		}
+	}
+}
+
+// TestDefaultClientName verifies the default identifies the tool, host and
+// process, with nothing CLIENT SETNAME would reject.
+func TestDefaultClientName(t *testing.T) {
+	name := tui.DefaultClientName()
+	parts := strings.Split(name, ":")
+	if len(parts) != 3 || parts[0] != "redis-tui" || parts[2] != strconv.Itoa(os.Getpid()) {
+		t.Errorf("unexpected name %q", name)
+	}
+	if strings.ContainsAny(name, " \t\n") {
+		t.Errorf("name %q contains whitespace", name)
+	}
+}
+
+// TestConnect_NamesClientAndShowsID verifies the client name is set on
+// connect and the connection's CLIENT ID appears in the header.
+func TestConnect_NamesClientAndShowsID(t *testing.T) {
+	names := make(chan string, 1)
+	addr := startFakeRedis(t, func(cmd []string) string {
+		switch strings.Join(cmd, " ") {
+		case "CLIENT ID":
+			return ":4711\r\n"
+		case "INFO server":
+			return "$0\r\n\r\n"
+		default:
+			names <- strings.Join(cmd, " ")
+			return "+OK\r\n"
+		}
+	})
+	m := newTestModel()
+	m.RedisAddress, m.ClientName = addr, "redis-tui:test:1"
+
+	_, connect := send(m, tui.TickMsg{})
+	m2, _ := send(m, connect())
+	t.Cleanup(func() { _ = m2.Conn.Close() })
+
+	if got := <-names; got != "CLIENT SETNAME redis-tui:test:1" {
+		t.Errorf("want CLIENT SETNAME, got %q", got)
+	}
+	if m2.ClientID != 4711 || !strings.Contains(m2.View(), "id 4711") {
+		t.Errorf("header should show the client id, got %d", m2.ClientID)
+	}
+}
@@ -0,0 +1,137 @@
+package tui_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// stringKey is the TYPE, STRLEN and inspect replies for a string key holding
+// value.
+func stringKey(value string) string {
+	return fmt.Sprintf("+string\r\n:%d\r\n+PONG\r\n$%d\r\n%s\r\n:-1\r\n", len(value), len(value), value)
+}
+
+// TestRenderers_RuleAndCycle verifies a config rule picks the renderer for
+// matching keys, and that v steps on to the next one that can show the value.
+func TestRenderers_RuleAndCycle(t *testing.T) {
+	m, _ := browseKeys(stringKey(`{"user":"ada","ttl":60}`), "session:1")
+	m.Config.Renderers = []tui.RenderRule{{Keys: "session:*", Renderer: "table"}}
+
+	m = openKey(t, m, "session:1")
+	view := m.View()
+	if !strings.Contains(view, "as table") || !strings.Contains(view, "user   ada") {
+		t.Fatalf("want the value as a table, got:\n%s", view)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if view := m.View(); !strings.Contains(view, "as json") || !strings.Contains(view, `"user": "ada"`) {
+		t.Errorf("v should move on to json, got:\n%s", view)
+	}
+}
+
+// TestRenderers_BinaryAsHex verifies a value that isn't text is shown as a
+// hex dump when no rule applies.
+func TestRenderers_BinaryAsHex(t *testing.T) {
+	m, _ := browseKeys(stringKey("\x00\x01\xffpng"), "blob:1")
+	m = openKey(t, m, "blob:1")
+	if view := m.View(); !strings.Contains(view, "as hex") || !strings.Contains(view, "00 01 ff 70 6e 67") {
+		t.Errorf("want a hex dump, got:\n%s", view)
+	}
+}
+
+// pngBytes encodes a blank w×h PNG.
+func pngBytes(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// TestRenderers_Base64Image verifies a base64 thumbnail gets a badge with
+// its dimensions instead of the text, and that w saves the decoded bytes.
+func TestRenderers_Base64Image(t *testing.T) {
+	img := pngBytes(t, 64, 48)
+	m, _ := browseKeys(stringKey("data:image/png;base64,"+base64.StdEncoding.EncodeToString(img)), "thumb:1")
+	m = openKey(t, m, "thumb:1")
+	if view := m.View(); !strings.Contains(view, "as image") || !strings.Contains(view, "PNG image · 64×48") || !strings.Contains(view, "(base64,") {
+		t.Fatalf("want an image badge, got:\n%s", view)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if got := m.Input.Input.Value(); !strings.HasSuffix(got, ".png") {
+		t.Errorf("want a .png suggested, got %q", got)
+	}
+	path := filepath.Join(t.TempDir(), "thumb.png")
+	m = saveAs(m, path)
+	if data, err := os.ReadFile(path); err != nil || !bytes.Equal(data, img) {
+		t.Errorf("want the decoded PNG saved, got %d bytes, %v", len(data), err)
+	}
+}
+
+func TestRenderers_RawImage(t *testing.T) {
+	m, _ := browseKeys(stringKey(string(pngBytes(t, 3, 2))), "thumb:raw")
+	m = openKey(t, m, "thumb:raw")
+	if view := m.View(); !strings.Contains(view, "PNG image · 3×2") || strings.Contains(view, "(base64,") {
+		t.Errorf("want a raw image badge, got:\n%s", view)
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if view := m.View(); !strings.Contains(view, "as hex") {
+		t.Errorf("v should show the bytes, got:\n%s", view)
+	}
+}
+
+// TestRenderers_JWT verifies a token's claims are decoded into a table
+// with its times as dates, saying when it expired.
+func TestRenderers_JWT(t *testing.T) {
+	part := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	now := time.Now().Unix()
+	token := part(`{"alg":"HS256","typ":"JWT"}`) + "." +
+		part(fmt.Sprintf(`{"sub":"ada","roles":["admin"],"iat":%d,"exp":%d}`, now-7200, now-3600)) + ".c2lnbmF0dXJl"
+	m, _ := browseKeys(stringKey(token), "session:1")
+	m = openKey(t, m, "session:1")
+	view := m.View()
+	for _, want := range []string{"as jwt", "alg     HS256", "sub    ada", `roles  ["admin"]`, "expired 1h ago", "(2h ago)", "signature not verified"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want %q in the decoded token, got:\n%s", want, view)
+		}
+	}
+}
+
+// TestRenderers_URL verifies a URL's query is decoded one parameter per row,
+// and that a value that merely contains one isn't taken for a URL.
+func TestRenderers_URL(t *testing.T) {
+	m, _ := browseKeys(stringKey("https://cdn.example.com/img/1.png?sig=a%2Fb&exp=60"), "link:1")
+	m = openKey(t, m, "link:1")
+	view := m.View()
+	for _, want := range []string{"as url", "host  cdn.example.com", "?exp  60", "?sig  a/b", "o opens it"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want %q in the URL, got:\n%s", want, view)
+		}
+	}
+
+	m, _ = browseKeys(stringKey("see https://example.com"), "note:1")
+	if view := openKey(t, m, "note:1").View(); strings.Contains(view, "as url") {
+		t.Errorf("text around a URL should stay plain, got:\n%s", view)
+	}
+}
+
+func TestLoadConfig_Renderers(t *testing.T) {
+	path := writeConfig(t, `{"renderers":[{"keys":"session:*","renderer":"yaml"}]}`)
+	if _, err := tui.LoadConfig(path); err == nil || !strings.Contains(err.Error(), `unknown renderer "yaml"`) {
+		t.Errorf("want an unknown renderer error, got %v", err)
+	}
+}
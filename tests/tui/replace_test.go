@@ -0,0 +1,86 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestReplace_RegexAcrossStrings runs REPLACE_ALL end to end against the
+// mock server: the changing values are counted and sampled before anything
+// is written, then rewritten with their TTLs kept, leaving other types and
+// unmatched values alone.
+func TestReplace_RegexAcrossStrings(t *testing.T) {
+	srv, addr := startMock(t)
+	for i := range 12 {
+		srv.Do(0, "SET", fmt.Sprintf("cfg:%d", i), fmt.Sprintf(`{"host":"old-db-%d.internal","port":5432}`, i))
+	}
+	srv.Do(0, "SET", "cfg:ttl", `{"host":"old-db-9.internal"}`, "EX", "600")
+	srv.Do(0, "SET", "cfg:other", `{"host":"cache.internal"}`)
+	srv.Do(0, "HSET", "cfg:hash", "host", "old-db-1.internal")
+
+	m := newPickerMenuModel("REPLACE_ALL")
+	m.RedisAddress = addr
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v", m.CurrentState)
+	}
+
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"cfg:*", `old-db-(\d+)`, "new-db-$1", "y"}})
+	for cmd != nil && m.CurrentState != tui.StateConfirmation {
+		msg := loadResult(cmd)
+		if msg == nil {
+			break
+		}
+		m, cmd = send(m, msg)
+	}
+	if m.CurrentState != tui.StateConfirmation {
+		t.Fatalf("want the preview first, got state %v, %+v", m.CurrentState, m.Replace)
+	}
+	view := m.View()
+	for _, want := range []string{"rewrite 13 of 14 string values matching cfg:* (15 keys matched)", `- {"host":"old-db-`, `+ {"host":"new-db-`, "… and 8 more"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want %q in the preview, got:\n%s", want, view)
+		}
+	}
+	if v := srv.Do(0, "GET", "cfg:3"); !strings.Contains(fmt.Sprint(v), "old-db-3") {
+		t.Fatalf("nothing should change before confirming, got %v", v)
+	}
+
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	for cmd != nil && m.Replace.Finished.IsZero() {
+		msg := loadResult(cmd)
+		if msg == nil {
+			break
+		}
+		m, cmd = send(m, msg)
+	}
+	if m.Replace.Done != 13 || m.Replace.Errors != 0 || m.Replace.Err != "" {
+		t.Fatalf("want 13 values rewritten without errors, got %+v", m.Replace)
+	}
+	if !strings.Contains(m.View(), "done: 13 values rewritten") {
+		t.Errorf("progress screen should report completion:\n%s", m.View())
+	}
+	if v := srv.Do(0, "GET", "cfg:3"); v != `{"host":"new-db-3.internal","port":5432}` {
+		t.Errorf("want cfg:3 rewritten, got %v", v)
+	}
+	if ttl, _ := srv.Do(0, "TTL", "cfg:ttl").(int); ttl <= 0 {
+		t.Errorf("cfg:ttl should keep its TTL, got %d", ttl)
+	}
+	if v := srv.Do(0, "HGET", "cfg:hash", "host"); v != "old-db-1.internal" {
+		t.Errorf("a hash should be left alone, got %v", v)
+	}
+}
+
+func TestReplace_BadRegex(t *testing.T) {
+	m := newPickerMenuModel("REPLACE_ALL")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"cfg:*", "old-(", "x", "y"}})
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "not a regular expression") || cmd != nil {
+		t.Errorf("want a form error, got %v %q", m.CurrentState, m.Form.Err)
+	}
+}
@@ -0,0 +1,119 @@
+package tui_test
+
+import (
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestReplica_BrowserReadsGoToReplica verifies opening a key reads from the
+// replica, and that right after a write reads go back to the primary.
+func TestReplica_BrowserReadsGoToReplica(t *testing.T) {
+	m, primary := browseKeys("+hash\r\n", "user:1")
+	replica, reader := newMockConn("+hash\r\n")
+	m.ReplicaAddress = "replica:6379"
+	m, _ = send(m, tui.ReplicaConnMsg{Addr: "replica:6379", Conn: replica, Reader: reader})
+
+	_, cmd := send(m, tui.SelectKeyMsg{Key: "user:1"})
+	loadResult(cmd)
+	if !strings.Contains(replica.writtenData.String(), "TYPE") || primary.writtenData.Len() != 0 {
+		t.Fatalf("want TYPE sent to the replica only, primary got %q", primary.writtenData.String())
+	}
+
+	m.ActiveKey, m.ActiveField = "user:1", "name"
+	m.SelectedOp = tui.OpHSet
+	m, _ = send(m, tui.RedisResultMsg{Result: 1})
+	replica.writtenData.Reset()
+	_, cmd = send(m, tui.SelectKeyMsg{Key: "user:1"})
+	loadResult(cmd)
+	if replica.writtenData.Len() != 0 || !strings.Contains(primary.writtenData.String(), "TYPE") {
+		t.Errorf("right after a write, want TYPE on the primary, replica got %q", replica.writtenData.String())
+	}
+}
+
+// TestReplica_Unreachable verifies a replica that can't be dialled leaves
+// reads on the primary and says so.
+func TestReplica_Unreachable(t *testing.T) {
+	m, primary := browseKeys("+hash\r\n", "user:1")
+	m.ReplicaAddress = "replica:6379"
+	m, _ = send(m, tui.ReplicaConnMsg{Addr: "replica:6379", Error: errors.New("connection refused")})
+	if !m.Toast.Failed || !strings.Contains(m.Toast.Text, "reading from the primary") {
+		t.Errorf("want a failure toast, got %+v", m.Toast)
+	}
+
+	_, cmd := send(m, tui.SelectKeyMsg{Key: "user:1"})
+	loadResult(cmd)
+	if !strings.Contains(primary.writtenData.String(), "TYPE") {
+		t.Errorf("want TYPE on the primary, got %q", primary.writtenData.String())
+	}
+}
+
+// TestReplica_AutoOnCluster verifies "auto" reads from the replica the
+// primary lists online in INFO replication, and sends it READONLY because
+// the primary is a cluster node.
+func TestReplica_AutoOnCluster(t *testing.T) {
+	replicaAddr, got := recordingRedis(t)
+	host, port, _ := net.SplitHostPort(replicaAddr)
+	primaryAddr := startFakeRedis(t, func(cmd []string) string {
+		if cmd[0] != "INFO" || len(cmd) < 2 {
+			return "-ERR unknown command\r\n"
+		}
+		var info string
+		switch cmd[1] {
+		case "cluster":
+			info = "# Cluster\r\ncluster_enabled:1\r\n"
+		case "replication":
+			info = "# Replication\r\nrole:master\r\nconnected_slaves:2\r\n" +
+				"slave0:ip=10.0.0.9,port=6379,state=wait_bgsave,offset=0,lag=0\r\n" +
+				"slave1:ip=" + host + ",port=" + port + ",state=online,offset=42,lag=0\r\n"
+		}
+		return bulk(info)
+	})
+	conn, err := net.Dial("tcp", primaryAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	m := newTestModel()
+	m.RedisAddress, m.ReplicaAddress = primaryAddr, "auto"
+	m, cmd := send(m, tui.RedisConnectionMsg{Conn: conn})
+	defer m.Worker.Stop()
+	for _, msg := range runAll(cmd, shortTimers) {
+		if found, ok := msg.(tui.ReplicaFoundMsg); ok {
+			m, cmd = send(m, found)
+		}
+	}
+	if m.ReplicaFound != replicaAddr || !m.ReplicaCluster {
+		t.Fatalf("want %s found on a cluster, got %q (cluster %v)", replicaAddr, m.ReplicaFound, m.ReplicaCluster)
+	}
+	m, _ = send(m, cmd())
+	if m.ReplicaConn == nil {
+		t.Fatalf("want the replica connected, toast %+v", m.Toast)
+	}
+	var sent []string
+	for !slices.Contains(sent, "READONLY") {
+		select {
+		case line := <-got:
+			sent = append(sent, line)
+		case <-time.After(time.Second):
+			t.Fatalf("want READONLY sent to the replica, got %q", sent)
+		}
+	}
+}
+
+// TestReplica_AutoNoneOnline verifies "auto" with no replica online keeps
+// reads on the primary and says so.
+func TestReplica_AutoNoneOnline(t *testing.T) {
+	m, primary := browseKeys("")
+	m.ReplicaAddress = "auto"
+	m, cmd := send(m, tui.ReplicaFoundMsg{Conn: primary})
+	if cmd == nil || m.ReplicaConn != nil || !m.Toast.Failed || !strings.Contains(m.Toast.Text, "No replica online") {
+		t.Errorf("want a toast and no replica, got %+v", m.Toast)
+	}
+}
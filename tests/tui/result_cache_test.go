@@ -0,0 +1,53 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// openKey selects key in the browser and delivers each reply until the
+// browser or output screen shows it.
+func openKey(t *testing.T, m tui.Model, key string) tui.Model {
+	t.Helper()
+	m, cmd := send(m, tui.SelectKeyMsg{Key: key})
+	for i := 0; m.CurrentState == tui.StateLoading; i++ {
+		if cmd == nil || i == 5 {
+			t.Fatalf("%s never opened", key)
+		}
+		m, cmd = send(m, loadResult(cmd))
+	}
+	return m
+}
+
+// TestResultCache_ReplaysUntilRefresh verifies opening a hash again replays
+// its fields instead of sending HKEYS, and that a refresh fetches them anew.
+func TestResultCache_ReplaysUntilRefresh(t *testing.T) {
+	hash := "+hash\r\n:100\r\n:2\r\n*2\r\n$1\r\na\r\n$1\r\nb\r\n"
+	m, conn := browseKeys(hash+"+hash\r\n"+hash, "user:1")
+
+	m = openKey(t, m, "user:1")
+	if !strings.Contains(conn.writtenData.String(), "HKEYS") {
+		t.Fatalf("the first visit should send HKEYS, sent %q", conn.writtenData.String())
+	}
+
+	conn.writtenData.Reset()
+	m.Browser.ViewingFields = false
+	m = openKey(t, m, "user:1")
+	if got := conn.writtenData.String(); strings.Contains(got, "HKEYS") || strings.Contains(got, "MEMORY") {
+		t.Errorf("a second visit should replay the fields, sent %q", got)
+	}
+	if !m.Browser.ViewingFields || len(m.Browser.FieldsList.Items()) != 2 {
+		t.Fatalf("want the 2 cached fields shown, got %d", len(m.Browser.FieldsList.Items()))
+	}
+
+	conn.writtenData.Reset()
+	m, cmd := send(m, tui.RefreshMsg{})
+	for i := 0; cmd != nil && m.CurrentState == tui.StateLoading && i < 5; i++ {
+		m, cmd = send(m, loadResult(cmd))
+	}
+	if got := conn.writtenData.String(); !strings.Contains(got, "HKEYS") {
+		t.Errorf("refresh should fetch the fields again, sent %q", got)
+	}
+}
@@ -0,0 +1,143 @@
+package tui_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// saveAs submits path at the save prompt and delivers the write's outcome.
+func saveAs(m tui.Model, path string) tui.Model {
+	m.Input.Input.SetValue(path)
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, write := send(m, cmd())
+	if write != nil {
+		m, _ = send(m, write())
+	}
+	return m
+}
+
+// hgetallOutput is the output screen showing an HGETALL reply.
+func hgetallOutput() tui.Model {
+	m := newTestModel()
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateOutput
+	m.Result = tui.Result{Command: "HGETALL user:1", Type: "array", Value: "name\nada\nage\n36", Reply: []any{"name", "ada", "age", 36}}
+	return m
+}
+
+// TestResultExport_OutputAsCSV verifies "w" on the output screen saves a
+// field/value reply as two CSV columns and returns to the result.
+func TestResultExport_OutputAsCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user.csv")
+	m, _ := send(hgetallOutput(), tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if m.CurrentState != tui.StateInputFilePath {
+		t.Fatalf("want the save prompt, got state %v", m.CurrentState)
+	}
+	m = saveAs(m, path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "field,value\nname,ada\nage,36\n" {
+		t.Errorf("csv: got %q", got)
+	}
+	if m.CurrentState != tui.StateOutput || m.SelectedOp != tui.OpGet {
+		t.Errorf("want back on the GET output, got state %v op %v", m.CurrentState, m.SelectedOp)
+	}
+	if !strings.Contains(m.CopyStatus, "Saved 2 rows") {
+		t.Errorf("status: got %q", m.CopyStatus)
+	}
+}
+
+// TestResultExport_AsksBeforeOverwrite verifies an existing file is kept
+// until the same name is submitted again, and that a reply that isn't flat
+// can't be saved as CSV.
+func TestResultExport_AsksBeforeOverwrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user.json")
+	if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := send(hgetallOutput(), tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m = saveAs(m, path)
+	if m.CurrentState != tui.StateInputFilePath || !strings.Contains(m.Input.Hint, "exists") {
+		t.Fatalf("want a warning at the prompt, got state %v hint %q", m.CurrentState, m.Input.Hint)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Fatal("file replaced without asking")
+	}
+
+	m = saveAs(m, path)
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"ada"`) || !strings.Contains(string(data), "36") {
+		t.Errorf("want the reply as JSON after confirming, got %q", data)
+	}
+
+	m.Result.Reply = []any{[]any{"nested"}}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m = saveAs(m, filepath.Join(filepath.Dir(path), "nested.csv"))
+	if m.CurrentState != tui.StateInputFilePath || !strings.Contains(m.Input.Hint, "CSV") {
+		t.Errorf("want CSV refused for a nested reply, got hint %q", m.Input.Hint)
+	}
+	m, back := send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = send(m, back())
+	if m.CurrentState != tui.StateOutput || m.SelectedOp != tui.OpGet {
+		t.Errorf("esc should return to the output, got state %v op %v", m.CurrentState, m.SelectedOp)
+	}
+}
+
+// TestResultExport_BrowserKeys verifies "w" in the key list saves the keys
+// loaded, and the outcome is shown in a toast over the list.
+func TestResultExport_BrowserKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.txt")
+	m := newTestModel()
+	m.CurrentState = tui.StateBrowser
+	m.Browser.KeyList.SetItems([]list.Item{tui.NewListItem("a", "string"), tui.NewListItem("b", "hash")})
+
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	for _, msg := range runAll(cmd, shortTimers) {
+		m, _ = send(m, msg)
+	}
+	m = saveAs(m, path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "a\tstring\t0\nb\thash\t0\n" {
+		t.Errorf("text: got %q", got)
+	}
+	if m.CurrentState != tui.StateBrowser || !strings.Contains(m.Toast.Text, "Saved "+path) {
+		t.Errorf("want the outcome in a toast over the list, got state %v %q", m.CurrentState, m.Toast.Text)
+	}
+}
+
+// TestResultExport_Console verifies Ctrl+S saves the console's last reply
+// and reports it in the scrollback.
+func TestResultExport_Console(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reply.json")
+	m, _ := openConsole(t, "*2\r\n$1\r\na\r\n:7\r\n")
+	m = sendLines(m, "MGET a b")
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = saveAs(m, path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "[\n  \"a\",\n  7\n]\n" {
+		t.Errorf("json: got %q", got)
+	}
+	last := m.Console.Log[len(m.Console.Log)-1]
+	if m.CurrentState != tui.StateConsole || !strings.HasPrefix(last.Reply, "Saved") {
+		t.Errorf("want the save logged in the console, got state %v %q", m.CurrentState, last.Reply)
+	}
+}
@@ -0,0 +1,89 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestSample_RandomElements runs R end to end against the mock server for
+// each sampled type: the count is asked for, the sample is tabulated with
+// values or scores, and esc goes back to the field browser.
+func TestSample_RandomElements(t *testing.T) {
+	srv, addr := startMock(t)
+	for i := range 50 {
+		srv.Do(0, "HSET", "user:1", fmt.Sprintf("f%d", i), fmt.Sprintf("v%d", i))
+		srv.Do(0, "SADD", "tags", fmt.Sprintf("t%d", i))
+		srv.Do(0, "ZADD", "board", fmt.Sprint(i), fmt.Sprintf("p%d", i))
+	}
+
+	cases := []struct {
+		key, typ string
+		header   []string
+		title    string
+	}{
+		{"user:1", "hash", []string{"field", "value"}, "3 random fields of user:1"},
+		{"tags", "set", []string{"member"}, "3 random members of tags"},
+		{"board", "zset", []string{"member", "score"}, "3 random members of board"},
+	}
+	for _, c := range cases {
+		m := dialMock(t, newTestModel(), addr)
+		m.CurrentState = tui.StateBrowser
+		m.Browser.ViewingFields = true
+		m.Browser.ActiveKey, m.Browser.ActiveKeyType = c.key, c.typ
+
+		m, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+		if cmd == nil {
+			t.Fatalf("%s: R should ask for a sample", c.typ)
+		}
+		m, _ = send(m, cmd())
+		if m.CurrentState != tui.StateForm || m.SelectedOp != tui.OpSample {
+			t.Fatalf("%s: want the sample form, got state %v op %v", c.typ, m.CurrentState, m.SelectedOp)
+		}
+
+		m, cmd = send(m, tui.FormSubmitMsg{Values: []string{"0"}})
+		if cmd != nil || !strings.Contains(m.Form.Err, "from 1 to") {
+			t.Errorf("%s: want 0 refused on the form, got err %q", c.typ, m.Form.Err)
+		}
+
+		m, cmd = send(m, tui.FormSubmitMsg{Values: []string{"3"}})
+		m, _ = send(m, loadResult(cmd))
+		if m.CurrentState != tui.StateOutput {
+			t.Fatalf("%s: want the sample shown, got state %v", c.typ, m.CurrentState)
+		}
+		out := m.Result.Value
+		if !strings.Contains(out, c.title) {
+			t.Errorf("%s: want %q, got:\n%s", c.typ, c.title, out)
+		}
+		for _, h := range c.header {
+			if !strings.Contains(out, h) {
+				t.Errorf("%s: want a %q column, got:\n%s", c.typ, h, out)
+			}
+		}
+
+		m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+		if m.CurrentState != tui.StateBrowser || !m.Browser.ViewingFields {
+			t.Errorf("%s: esc should return to the field browser, got state %v", c.typ, m.CurrentState)
+		}
+	}
+}
+
+// TestSample_NotOnLists verifies R does nothing in a list's field view,
+// which has no random-element command.
+func TestSample_NotOnLists(t *testing.T) {
+	m := newTestModel()
+	m.CurrentState = tui.StateBrowser
+	m.Browser.ViewingFields = true
+	m.Browser.ActiveKey, m.Browser.ActiveKeyType = "queue", "list"
+	if _, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")}); cmd != nil {
+		if msg := cmd(); msg != nil {
+			if _, ok := msg.(tui.SampleRequestMsg); ok {
+				t.Error("R should not sample a list")
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package tui_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// savedSearchModel is the key list of the prod profile over a mock server
+// holding two sessions and a flag, keeping saved searches in dir.
+func savedSearchModel(t *testing.T, dir string) tui.Model {
+	t.Helper()
+	m, srv := connectMock(t, newTestModel())
+	srv.Do(0, "SET", "session:eu-1", "a")
+	srv.Do(0, "SET", "session:us-2", "b")
+	srv.Do(0, "SET", "flag:beta", "on")
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m.SearchesDir, m.ProfileName = dir, "prod"
+	m.CurrentState, m.SelectedOp = tui.StateBrowser, tui.OpExplore
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu}
+	m.Browser.Pattern = "session:*"
+	m.Browser.KeyList = list.New([]list.Item{tui.NewListItem("session:eu-1", "string")}, list.NewDefaultDelegate(), 120, 20)
+	return m
+}
+
+// openSaved presses s in the key list and delivers the file read.
+func openSaved(t *testing.T, m tui.Model) tui.Model {
+	t.Helper()
+	m, cmd := press(m, "s")
+	m, cmd = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateSavedSearches {
+		t.Fatalf("want the saved searches popup, got state %v", m.CurrentState)
+	}
+	m, _ = send(m, cmd())
+	return m
+}
+
+// TestSavedSearches_SaveAndApply verifies a search saved from the popup is
+// written to the profile's file, and that choosing it in a later session
+// lists its pattern's keys with its filter applied.
+func TestSavedSearches_SaveAndApply(t *testing.T) {
+	dir := t.TempDir()
+	m := openSaved(t, savedSearchModel(t, dir))
+	if !strings.Contains(m.View(), "none yet") {
+		t.Errorf("want an empty popup, got:\n%s", m.View())
+	}
+	m, _ = press(m, "s")
+	if m.CurrentState != tui.StateForm || m.Form.Values()[1] != "session:*" {
+		t.Fatalf("want the save form on the current pattern, got state %v %v", m.CurrentState, m.Form.Values())
+	}
+	m, _ = send(m, tui.FormSubmitMsg{Values: []string{"", "session:*", ""}})
+	if m.Form.Err != "enter a name" {
+		t.Errorf("want a name asked for, got %q", m.Form.Err)
+	}
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"eu sessions", "session:*", "eu-1"}})
+	m, _ = send(m, cmd())
+	if m.CurrentState != tui.StateSavedSearches || !strings.Contains(m.View(), "Saved search eu sessions") {
+		t.Fatalf("want the popup back with a toast, got state %v:\n%s", m.CurrentState, m.View())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "prod.json"))
+	if err != nil || !strings.Contains(string(data), `"pattern": "session:*"`) {
+		t.Fatalf("want the search in prod.json, got %q, %v", data, err)
+	}
+
+	next := openSaved(t, savedSearchModel(t, dir))
+	if !strings.Contains(next.View(), "session:* / eu-1") {
+		t.Fatalf("want the saved search listed, got:\n%s", next.View())
+	}
+	next, cmd = press(next, "enter")
+	for i := 0; next.CurrentState == tui.StateLoading && i < 10; i++ {
+		next, cmd = send(next, loadResult(cmd))
+	}
+	if next.CurrentState != tui.StateBrowser || next.LastPattern != "session:*" {
+		t.Fatalf("want the key list for session:*, got state %v pattern %q", next.CurrentState, next.LastPattern)
+	}
+	if next.Browser.KeyList.FilterState() != list.FilterApplied || len(next.Browser.KeyList.VisibleItems()) != 1 {
+		t.Errorf("want only the eu session shown, got %d of %d", len(next.Browser.KeyList.VisibleItems()), len(next.Browser.KeyList.Items()))
+	}
+}
+
+// TestSavedSearches_ReplaceAndDelete verifies saving under a name in use
+// replaces that search, and d deletes the selected one from the file.
+func TestSavedSearches_ReplaceAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	m := openSaved(t, savedSearchModel(t, dir))
+	for _, values := range [][]string{
+		{"flags", "flag:*", ""},
+		{"sessions", "session:*", ""},
+		{"flags", "flag:beta*", "on"},
+	} {
+		m, _ = press(m, "s")
+		var cmd tea.Cmd
+		m, cmd = send(m, tui.FormSubmitMsg{Values: values})
+		m, _ = send(m, cmd())
+	}
+	if got := m.Saved.Searches; len(got) != 2 || got[0].Pattern != "flag:beta*" || m.Saved.Cursor != 0 {
+		t.Fatalf("want flags replaced in place and selected, got %+v", got)
+	}
+
+	m, cmd := press(m, "d")
+	m, _ = send(m, cmd())
+	data, _ := os.ReadFile(filepath.Join(dir, "prod.json"))
+	if strings.Contains(string(data), "flags") || !strings.Contains(string(data), "sessions") {
+		t.Errorf("want only sessions left, got %s", data)
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateBrowser || m.SelectedOp != tui.OpExplore {
+		t.Errorf("esc should return to the key list, got state %v op %v", m.CurrentState, m.SelectedOp)
+	}
+}
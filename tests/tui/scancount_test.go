@@ -0,0 +1,106 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// emptyScanPage is a SCAN reply with the given cursor and no keys — what a
+// sparse MATCH returns for most pages of a big keyspace.
+func emptyScanPage(cursor string) string {
+	return fmt.Sprintf("*2\r\n$%d\r\n%s\r\n*0\r\n", len(cursor), cursor)
+}
+
+// countArg is how COUNT n appears in a written SCAN.
+func countArg(n string) string {
+	return fmt.Sprintf("$5\r\nCOUNT\r\n$%d\r\n%s\r\n", len(n), n)
+}
+
+// TestScanCount_GrowsAcrossPages verifies the key list starts with a small
+// COUNT and doubles it for each page scanned after.
+func TestScanCount_GrowsAcrossPages(t *testing.T) {
+	conn, reader := newMockConn(emptyScanPage("7") + emptyScanPage("0"))
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.Browser.Pattern = "user:*"
+	m.CurrentState = tui.StateBrowser
+
+	m, cmd := send(m, tui.RefreshMsg{})
+	m, next := send(m, loadResult(cmd))
+	if !strings.Contains(conn.writtenData.String(), countArg("50")) {
+		t.Fatalf("first page: want COUNT 50, got %q", conn.writtenData.String())
+	}
+	if m.Browser.ScanHint != 100 {
+		t.Fatalf("ScanHint: want 100, got %d", m.Browser.ScanHint)
+	}
+	if next == nil {
+		t.Fatal("an empty page short of the end should scan on")
+	}
+
+	conn.writtenData.Reset()
+	m, _ = send(m, next())
+	if !strings.Contains(conn.writtenData.String(), "$1\r\n7\r\n") || !strings.Contains(conn.writtenData.String(), countArg("100")) {
+		t.Errorf("second page: want cursor 7 with COUNT 100, got %q", conn.writtenData.String())
+	}
+	if m.CurrentState != tui.StateBrowser || m.Browser.HasMore {
+		t.Errorf("want the list shown once the cursor wrapped, got state %v", m.CurrentState)
+	}
+}
+
+// TestScanCount_SlowPageShrinks verifies a slow page halves the hint and a
+// fast one never takes it past the ceiling.
+func TestScanCount_SlowPageShrinks(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpExplore
+	m.Browser.Cursor = "3"
+
+	m2, _ := send(m, tui.RedisResultMsg{Result: tui.ScanResult{Cursor: "9", Count: 400, Took: time.Second}})
+	if m2.Browser.ScanHint != 200 {
+		t.Errorf("slow page: want 200, got %d", m2.Browser.ScanHint)
+	}
+
+	m.ScanCount = 600
+	m2, _ = send(m, tui.RedisResultMsg{Result: tui.ScanResult{Cursor: "9", Count: 400, Took: time.Millisecond}})
+	if m2.Browser.ScanHint != 600 {
+		t.Errorf("fast page: want the 600 ceiling, got %d", m2.Browser.ScanHint)
+	}
+}
+
+// TestScanCount_ConfigCeiling verifies the config file's scan_count caps the
+// hint when -scan-count isn't given, even below the starting size.
+func TestScanCount_ConfigCeiling(t *testing.T) {
+	conn, reader := newMockConn(emptyScanPage("0"))
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.Config = tui.Config{ScanCount: 20}
+	m.Browser.Pattern = "*"
+	m.CurrentState = tui.StateBrowser
+
+	_, cmd := send(m, tui.RefreshMsg{})
+	loadResult(cmd)
+	if !strings.Contains(conn.writtenData.String(), countArg("20")) {
+		t.Errorf("want COUNT 20, got %q", conn.writtenData.String())
+	}
+}
+
+// TestScanCount_ToolScansGrow verifies whole-pattern scans (here COUNTERS)
+// grow the hint page by page too.
+func TestScanCount_ToolScansGrow(t *testing.T) {
+	conn, reader := newMockConn(emptyScanPage("5") + emptyScanPage("0"))
+	m := newTestModel()
+	m.Conn, m.Reader = conn, reader
+	m.SelectedOp = tui.OpCounters
+	m.CurrentState = tui.StateForm
+
+	_, cmd := send(m, tui.FormSubmitMsg{Values: []string{"rl:*", "1"}})
+	loadResult(cmd)
+	written := conn.writtenData.String()
+	first, second := strings.Index(written, countArg("50")), strings.Index(written, countArg("100"))
+	if first < 0 || second < first {
+		t.Errorf("want COUNT 50 then COUNT 100, got %q", written)
+	}
+}
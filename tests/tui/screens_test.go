@@ -0,0 +1,119 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+var toolStates = []tui.AppState{
+	tui.StateBlocking, tui.StateQueues, tui.StateCounters, tui.StateDBDiff,
+	tui.StateSnapshot, tui.StatePersistence, tui.StateCluster, tui.StateConsole,
+	tui.StateReconnect, tui.StateTemplates, tui.StateGenerate, tui.StateBenchmark,
+	tui.StateTimeline, tui.StateStream,
+}
+
+// coreStates are the screens every flow passes through.
+var coreStates = []tui.AppState{
+	tui.StateMenu, tui.StateInputKey, tui.StateInputField, tui.StateInputValue,
+	tui.StateInputFilePath, tui.StateOutput, tui.StateBrowser, tui.StateConfirmation,
+	tui.StateLoading, tui.StateForm,
+}
+
+// TestScreens_RenderFromZeroState verifies that every tool screen renders
+// before its first data arrives, the state it is in while loading.
+func TestScreens_RenderFromZeroState(t *testing.T) {
+	for _, state := range toolStates {
+		m := newTestModel()
+		m.WindowWidth, m.WindowHeight = 100, 30
+		m.CurrentState = state
+		if m.View() == "" {
+			t.Errorf("state %v: empty view", state)
+		}
+	}
+}
+
+// TestScreens_IgnoreOtherMessages verifies that the router hands a tool
+// screen only key messages: anything else it doesn't know leaves the model
+// where it was.
+func TestScreens_IgnoreOtherMessages(t *testing.T) {
+	type unknownMsg struct{}
+	for _, state := range toolStates {
+		m := newTestModel()
+		m.CurrentState = state
+		m2, cmd := send(m, unknownMsg{})
+		if m2.CurrentState != state || cmd != nil {
+			t.Errorf("state %v: want no change, got state %v and cmd %v", state, m2.CurrentState, cmd != nil)
+		}
+	}
+}
+
+// TestScreens_EscLeaves verifies that esc takes every tool screen back to
+// where it was opened from.
+func TestScreens_EscLeaves(t *testing.T) {
+	for _, state := range toolStates {
+		if state == tui.StateReconnect {
+			continue // there is nothing to go back to until the server answers
+		}
+		m := newTestModel()
+		m.StateNavigationHistory = []tui.AppState{tui.StateMenu}
+		m.CurrentState = state
+		m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEsc})
+		if m2.CurrentState == state {
+			t.Errorf("state %v: esc did not leave the screen", state)
+		}
+	}
+}
+
+// TestScreens_CoreStatesRender verifies the menu, prompts, output, browser,
+// confirmation, loading and form screens are routed and draw under the
+// header from a fresh model.
+func TestScreens_CoreStatesRender(t *testing.T) {
+	for _, state := range coreStates {
+		m := newTestModel()
+		m.WindowWidth, m.WindowHeight = 100, 30
+		m.RedisAddress = "localhost:6379"
+		m.CurrentState = state
+		if view := m.View(); !strings.Contains(view, "localhost:6379") {
+			t.Errorf("state %v: want the screen under the header, got:\n%s", state, view)
+		}
+	}
+}
+
+// TestScreens_KeyOnlyScreensIgnoreOtherMessages verifies the output,
+// confirmation and loading screens, which take keys only, are left as they
+// were by anything else.
+func TestScreens_KeyOnlyScreensIgnoreOtherMessages(t *testing.T) {
+	type unknownMsg struct{}
+	for _, state := range []tui.AppState{tui.StateOutput, tui.StateConfirmation, tui.StateLoading} {
+		m := newTestModel()
+		m.CurrentState = state
+		m2, cmd := send(m, unknownMsg{})
+		if m2.CurrentState != state || cmd != nil {
+			t.Errorf("state %v: want no change, got state %v and cmd %v", state, m2.CurrentState, cmd != nil)
+		}
+	}
+}
+
+// TestScreens_LoadingEscWithNothingToCancel verifies esc on the loading
+// screen waits when there is no load or key scan to cancel.
+func TestScreens_LoadingEscWithNothingToCancel(t *testing.T) {
+	m := newTestModel()
+	m.CurrentState = tui.StateLoading
+	if m2, cmd := send(m, tea.KeyMsg{Type: tea.KeyEsc}); m2.CurrentState != tui.StateLoading || cmd != nil {
+		t.Errorf("want the loading screen kept, got state %v", m2.CurrentState)
+	}
+}
+
+// TestScreens_MenuDigitRunsCommand verifies the menu's digit shortcut runs
+// the numbered command as enter would.
+func TestScreens_MenuDigitRunsCommand(t *testing.T) {
+	m := newPickerMenuModel("INFO")
+	m2, cmd := press(m, "1")
+	if m2.CurrentState != tui.StateLoading || cmd == nil {
+		t.Errorf("want INFO run from 1, got state %v", m2.CurrentState)
+	}
+}
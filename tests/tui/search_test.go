@@ -0,0 +1,125 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis/mock"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// searchServer holds a string, a hash and a list mentioning example.com, a
+// set that does too, and a string that doesn't.
+func searchServer(t *testing.T) (string, *mock.Server) {
+	t.Helper()
+	srv, addr := startMock(t)
+	srv.Do(0, "SET", "user:1", "alice@example.com")
+	srv.Do(0, "HSET", "user:2", "name", "bob", "email", "bob@example.com")
+	srv.Do(0, "RPUSH", "user:3", "nobody", "carol@example.com", "dave@example.com")
+	srv.Do(0, "SADD", "user:4", "erin@example.com")
+	srv.Do(0, "SET", "user:5", "frank@elsewhere.org")
+	return addr, srv
+}
+
+// runSearch submits the SEARCH_VALUES form and feeds pages back until the
+// scan is over.
+func runSearch(t *testing.T, addr string, values []string) tui.Model {
+	t.Helper()
+	m := newPickerMenuModel("SEARCH_VALUES")
+	m.RedisAddress = addr
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v", m.CurrentState)
+	}
+	m, cmd := send(m, tui.FormSubmitMsg{Values: values})
+	for cmd != nil && m.Search.Finished.IsZero() {
+		msg := loadResult(cmd)
+		if msg == nil {
+			break
+		}
+		m, cmd = send(m, msg)
+	}
+	if m.CurrentState != tui.StateSearch || m.Search.Finished.IsZero() || m.Search.Conn != nil {
+		t.Fatalf("want a finished search, got state %v %+v", m.CurrentState, m.Search)
+	}
+	return m
+}
+
+// TestSearch_FindsAcrossTypes verifies a regex is matched in a string, a
+// hash's values and a list's elements, that sets are not read, and that
+// Enter opens the selected hit's key.
+func TestSearch_FindsAcrossTypes(t *testing.T) {
+	addr, _ := searchServer(t)
+	m := runSearch(t, addr, []string{"user:*", "", `@example\.com$`, "y"})
+
+	hits := map[string]tui.SearchHit{}
+	for _, h := range m.Search.Hits {
+		hits[h.Key] = h
+	}
+	if len(hits) != 3 {
+		t.Fatalf("want hits in user:1, user:2 and user:3, got %+v", m.Search.Hits)
+	}
+	if h := hits["user:2"]; h.Type != "hash" || h.Where != "email" || h.Snippet != "bob@example.com" {
+		t.Errorf("want the hash's email field, got %+v", h)
+	}
+	if h := hits["user:3"]; h.Where != "[1]" || h.Matches != 2 {
+		t.Errorf("want the list's first match at [1] and two in all, got %+v", h)
+	}
+	if m.Search.Scanned != 5 || m.Search.Read != 4 {
+		t.Errorf("want 5 keys scanned and 4 read, got %d and %d", m.Search.Scanned, m.Search.Read)
+	}
+	if view := m.View(); !strings.Contains(view, "3 hits") || !strings.Contains(view, "alice@example.com") {
+		t.Errorf("want the hits listed, got:\n%s", view)
+	}
+
+	m = dialMock(t, m, addr)
+	m, _ = press(m, "down")
+	want := m.Search.Hits[1].Key
+	m, cmd := press(m, "enter")
+	m, _ = send(m, cmd())
+	if m.ActiveKey != want || m.StateNavigationHistory[len(m.StateNavigationHistory)-1] != tui.StateSearch {
+		t.Errorf("enter should open %s over the hits, got %q", want, m.ActiveKey)
+	}
+}
+
+// TestSearch_TypeFilterAndLimits verifies the type filter, and that a list
+// past the element limit is counted as skipped rather than read.
+func TestSearch_TypeFilterAndLimits(t *testing.T) {
+	addr, srv := searchServer(t)
+	big := []string{"RPUSH", "user:big"}
+	for i := range 10001 {
+		big = append(big, fmt.Sprintf("user%d@example.com", i))
+	}
+	srv.Do(0, big...)
+
+	m := runSearch(t, addr, []string{"user:*", "list", "example.com", "n"})
+	if len(m.Search.Hits) != 1 || m.Search.Hits[0].Key != "user:3" {
+		t.Errorf("want only the small list, got %+v", m.Search.Hits)
+	}
+	if m.Search.Skipped != 1 || !strings.Contains(m.View(), "1 too large to read") {
+		t.Errorf("want the big list skipped, got:\n%s", m.View())
+	}
+}
+
+// TestSearch_FormErrors verifies a bad type or regex keeps the form open.
+func TestSearch_FormErrors(t *testing.T) {
+	m := newPickerMenuModel("SEARCH_VALUES")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	for _, tc := range []struct {
+		values []string
+		want   string
+	}{
+		{[]string{"*", "set", "x", "n"}, "use string, hash or list"},
+		{[]string{"*", "", "x(", "y"}, "not a regular expression"},
+		{[]string{"*", "", "", "n"}, "enter the text to find"},
+	} {
+		got, cmd := send(m, tui.FormSubmitMsg{Values: tc.values})
+		if got.CurrentState != tui.StateForm || !strings.Contains(got.Form.Err, tc.want) || cmd != nil {
+			t.Errorf("%v: want %q, got %v %q", tc.values, tc.want, got.CurrentState, got.Form.Err)
+		}
+	}
+}
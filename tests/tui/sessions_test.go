@@ -0,0 +1,89 @@
+package tui_test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// openSessionKey opens session:abc under a sessions rule publishing to
+// sessions:invalidated, its hash expiring in an hour and seen five minutes
+// ago, with replies for an invalidation and the key list read after it.
+func openSessionKey(t *testing.T) (tui.Model, *mockConn) {
+	t.Helper()
+	now := time.Now()
+	exp := strconv.FormatInt(now.Add(time.Hour+time.Minute).Unix(), 10)
+	seen := now.Add(-5 * time.Minute).UTC().Format(time.RFC3339)
+	bulk := func(s string) string { return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s) }
+	all := "*8\r\n" + bulk("uid") + bulk("42") + bulk("exp") + bulk(exp) + bulk("seen") + bulk(seen) + bulk("ip") + bulk("10.0.0.7")
+	scan := "*2\r\n$1\r\n0\r\n*0\r\n"
+	m, conn := browseKeys("+hash\r\n"+all+":1\r\n:2\r\n"+scan, "session:abc")
+	m.LargeValueBytes = 0
+	m.Config.Sessions = []tui.SessionRule{{Keys: "session:*", User: "uid", Expires: "exp", LastSeen: "seen", Publish: "sessions:invalidated"}}
+
+	m = openKey(t, m, "session:abc")
+	if m.CurrentState != tui.StateOutput || m.SelectedOp != tui.OpSession {
+		t.Fatalf("want the session view, got state %v op %v", m.CurrentState, m.SelectedOp)
+	}
+	return m, conn
+}
+
+// TestSessions_HighlightsFields verifies the rule's fields come first, the
+// times as dates with how far off they are, then the other fields.
+func TestSessions_HighlightsFields(t *testing.T) {
+	m, _ := openSessionKey(t)
+	view := m.View()
+	for _, want := range []string{"user       42", "(in 1h)", "(5m ago)", "ip     10.0.0.7", "i invalidate"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want %q, got:\n%s", want, view)
+		}
+	}
+}
+
+// TestSessions_Invalidate verifies i confirms, then deletes the key and
+// publishes its name, and returns to the key list read again.
+func TestSessions_Invalidate(t *testing.T) {
+	m, conn := openSessionKey(t)
+	readOnly := m
+	readOnly.ReadOnly = true
+	if got, _ := press(readOnly, "i"); got.CurrentState != tui.StateOutput || !strings.Contains(got.View(), "read-only") {
+		t.Errorf("want invalidation refused in a read-only session, got state %v", got.CurrentState)
+	}
+
+	m, _ = press(m, "i")
+	if m.CurrentState != tui.StateConfirmation || !strings.Contains(m.View(), "PUBLISH its name to sessions:invalidated") {
+		t.Fatalf("want the invalidation confirmed first, got:\n%s", m.View())
+	}
+	m, cmd := press(m, "y")
+	m, cmd = send(m, loadResult(cmd))
+	sent := conn.writtenData.String()
+	if !strings.Contains(sent, "$3\r\nDEL\r\n$11\r\nsession:abc\r\n") || !strings.Contains(sent, "$7\r\nPUBLISH\r\n$20\r\nsessions:invalidated\r\n$11\r\nsession:abc\r\n") {
+		t.Errorf("want DEL and PUBLISH, sent %q", sent)
+	}
+	for i := 0; m.CurrentState == tui.StateLoading && i < 5; i++ {
+		msg := loadResult(cmd)
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			msg = loadResult(func() tea.Msg { return batch })
+		}
+		m, cmd = send(m, msg)
+	}
+	if m.CurrentState != tui.StateBrowser || !strings.Contains(m.View(), "published to sessions:invalidated (2 receivers)") {
+		t.Errorf("want the key list back with a toast, got state %v:\n%s", m.CurrentState, m.View())
+	}
+	if len(m.StateNavigationHistory) != 1 {
+		t.Errorf("want only the menu behind the key list, got %v", m.StateNavigationHistory)
+	}
+}
+
+func TestLoadConfig_Sessions(t *testing.T) {
+	path := writeConfig(t, `{"sessions":[{"user_field":"uid"}]}`)
+	if _, err := tui.LoadConfig(path); err == nil || !strings.Contains(err.Error(), "needs keys") {
+		t.Errorf("want a missing keys error, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestSizes_Info verifies INFO byte counts get their size in binary units,
+// leaving other numbers and the server's *_human fields alone, and b shows
+// them exact again.
+func TestSizes_Info(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpInfo
+	m.CurrentState = tui.StateLoading
+	m, _ = send(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+	info := "# Memory\r\nused_memory:1048576\r\nused_memory_human:1.00M\r\nmaxmemory:0\r\nmem_fragmentation_ratio:1.52\r\nconnected_clients:5000\r\n"
+	m, _ = send(m, tui.RedisResultMsg{Result: info, Command: "INFO"})
+
+	view := m.View()
+	if !strings.Contains(view, "used_memory:1048576 (1.0 MiB)") {
+		t.Errorf("used_memory not humanized:\n%s", view)
+	}
+	for _, plain := range []string{"used_memory_human:1.00M (", "connected_clients:5000 (", "maxmemory:0 ("} {
+		if strings.Contains(view, plain) {
+			t.Errorf("%q: only byte counts of 1 KiB or more are annotated", plain)
+		}
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if strings.Contains(m.View(), "(1.0 MiB)") {
+		t.Error("b should show exact byte counts")
+	}
+	if m.Result.Value != info {
+		t.Error("the reply itself (what c copies) must not change")
+	}
+}
+
+// TestSizes_StringValue verifies an open string shows its size beside the
+// idle time, humanized or exact.
+func TestSizes_StringValue(t *testing.T) {
+	m := newTestModel()
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateLoading
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m, _ = send(m, tui.StringInspectMsg{Value: strings.Repeat("x", 3000), TTL: -1, Idle: 5})
+
+	if !strings.Contains(m.View(), "size: 2.9 KiB") {
+		t.Errorf("want the size in the meta row:\n%s", m.View())
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if !strings.Contains(m.View(), "size: 3000 B") {
+		t.Errorf("want the exact size after b:\n%s", m.View())
+	}
+}
@@ -0,0 +1,148 @@
+package tui_test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// startSnapshots submits the SNAPSHOT form against addr and returns the model
+// once the dedicated connection is up, along with the first snapshot command.
+func startSnapshots(t *testing.T, addr, dir string) (tui.Model, tea.Cmd) {
+	t.Helper()
+	m := newTestModel()
+	m.RedisAddress = addr
+	m.SelectedOp = tui.OpSnapshot
+	m.CurrentState = tui.StateForm
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu}
+
+	m2, cmd := send(m, tui.FormSubmitMsg{Values: []string{"user:*, cfg", "30", dir}})
+	if m2.CurrentState != tui.StateLoading {
+		t.Fatalf("state: want StateLoading, got %v (err %q)", m2.CurrentState, m2.Form.Err)
+	}
+	m3, first := send(m2, loadResult(cmd))
+	if m3.CurrentState != tui.StateSnapshot || !m3.Snapshot.Active {
+		t.Fatalf("want an active snapshotter, got state %v (%s)", m3.CurrentState, m3.Result.Text())
+	}
+	t.Cleanup(func() {
+		if m3.Snapshot.Conn != nil {
+			_ = m3.Snapshot.Conn.Close()
+		}
+	})
+	return m3, first
+}
+
+// TestMenu_Snapshot_OpensForm verifies SNAPSHOT opens its form when no
+// snapshotter is running.
+func TestMenu_Snapshot_OpensForm(t *testing.T) {
+	m := newPickerMenuModel("SNAPSHOT")
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m2.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v", m2.CurrentState)
+	}
+	if got := m2.Form.Values(); got[1] != "60" || got[2] != "./snapshots" {
+		t.Errorf("defaults: want 60 and ./snapshots, got %q", got)
+	}
+}
+
+// TestSnapshotForm_Invalid verifies bad input keeps the form open with an error.
+func TestSnapshotForm_Invalid(t *testing.T) {
+	for _, values := range [][]string{
+		{" , ", "60", "."},
+		{"user:*", "0", "."},
+		{"user:*", "soon", "."},
+	} {
+		m := newTestModel()
+		m.SelectedOp = tui.OpSnapshot
+		m.CurrentState = tui.StateForm
+
+		m2, cmd := send(m, tui.FormSubmitMsg{Values: values})
+
+		if m2.CurrentState != tui.StateForm || m2.Form.Err == "" || cmd != nil {
+			t.Errorf("%q: want the form to stay open with an error, got state %v err %q", values, m2.CurrentState, m2.Form.Err)
+		}
+	}
+}
+
+// TestSnapshot_WritesTimestampedJSON verifies a snapshot lands in the
+// directory as JSON holding each key's type, TTL and value, and that the
+// next one is scheduled.
+func TestSnapshot_WritesTimestampedJSON(t *testing.T) {
+	addr := stringServer(t, map[string]string{"user:1": "ada", "cfg": "on"}, map[string]int{"user:1": 90})
+	dir := t.TempDir()
+	m, first := startSnapshots(t, addr, dir)
+
+	m2, next := send(m, first())
+
+	if m2.Snapshot.Count != 1 || m2.Snapshot.Err != "" {
+		t.Fatalf("want one snapshot written, got count %d err %q", m2.Snapshot.Count, m2.Snapshot.Err)
+	}
+	if next == nil {
+		t.Error("the next snapshot should be scheduled")
+	}
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 || !strings.HasPrefix(files[0].Name(), "snapshot-") || !strings.HasSuffix(files[0].Name(), ".json") {
+		t.Fatalf("want one snapshot-*.json file, got %v", files)
+	}
+	data, _ := os.ReadFile(dir + "/" + files[0].Name())
+	var snap struct {
+		Keys map[string]struct {
+			Type  string
+			TTL   int
+			Value string
+		}
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		t.Fatalf("snapshot is not JSON: %v", err)
+	}
+	if k := snap.Keys["user:1"]; k.Type != "string" || k.TTL != 90 || k.Value != "ada" {
+		t.Errorf("user:1: got %+v", k)
+	}
+	if k := snap.Keys["cfg"]; k.Value != "on" || k.TTL != -1 {
+		t.Errorf("cfg: got %+v", k)
+	}
+}
+
+// TestSnapshot_EscKeepsRunning verifies leaving the status screen leaves the
+// snapshotter running, and that the header says so.
+func TestSnapshot_EscKeepsRunning(t *testing.T) {
+	addr := stringServer(t, map[string]string{"cfg": "on"}, nil)
+	m, _ := startSnapshots(t, addr, t.TempDir())
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyEsc})
+
+	if !m2.Snapshot.Active {
+		t.Fatal("esc should not stop the snapshotter")
+	}
+	if m2.CurrentState == tui.StateSnapshot {
+		t.Error("esc should leave the status screen")
+	}
+	if !strings.Contains(m2.View(), "snap 0") {
+		t.Error("header should show the running snapshotter")
+	}
+}
+
+// TestSnapshot_StopDiscardsInFlight verifies x stops the snapshotter and a
+// snapshot finishing afterwards neither counts nor schedules another.
+func TestSnapshot_StopDiscardsInFlight(t *testing.T) {
+	addr := stringServer(t, map[string]string{"cfg": "on"}, nil)
+	m, first := startSnapshots(t, addr, t.TempDir())
+	pending := first()
+
+	m2, _ := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m2.Snapshot.Active || m2.Snapshot.Conn != nil {
+		t.Fatal("x should stop the snapshotter and close its connection")
+	}
+	m3, next := send(m2, pending)
+
+	if m3.Snapshot.Count != 0 || next != nil {
+		t.Errorf("stale snapshot should be ignored, got count %d", m3.Snapshot.Count)
+	}
+}
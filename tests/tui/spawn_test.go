@@ -0,0 +1,61 @@
+package tui_test
+
+import (
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestSpawnServer starts a real redis-server, when one is installed, and
+// checks it serves and is cleaned up on Stop.
+func TestSpawnServer(t *testing.T) {
+	if _, err := exec.LookPath("redis-server"); err != nil {
+		t.Skip("redis-server not in PATH")
+	}
+	srv, err := tui.SpawnServer("redis-server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := net.Dial("tcp", srv.Addr)
+	if err != nil {
+		t.Fatalf("dial %s: %v", srv.Addr, err)
+	}
+	_ = conn.Close()
+
+	srv.Stop()
+	srv.Stop() // idempotent
+	if conn, err := net.Dial("tcp", srv.Addr); err == nil {
+		_ = conn.Close()
+		t.Errorf("server on %s still accepting after Stop", srv.Addr)
+	}
+}
+
+func TestSpawnServer_MissingBinary(t *testing.T) {
+	_, err := tui.SpawnServer("redis-server-does-not-exist")
+	if err == nil || !strings.Contains(err.Error(), "-spawn needs") {
+		t.Errorf("want a lookup error, got %v", err)
+	}
+}
+
+// TestSpawnServer_ReportsStartupFailure verifies a server that exits at
+// startup is reported with the end of its log.
+func TestSpawnServer_ReportsStartupFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	fake := filepath.Join(t.TempDir(), "fake-redis-server")
+	script := "#!/bin/sh\nlog=$(sed -n 's/^logfile \"\\(.*\\)\"$/\\1/p' \"$1\")\necho 'FATAL CONFIG FILE ERROR' > \"$log\"\nexit 1\n"
+	if err := os.WriteFile(fake, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	_, err := tui.SpawnServer(fake)
+	if err == nil || !strings.Contains(err.Error(), "exited") || !strings.Contains(err.Error(), "FATAL CONFIG FILE ERROR") {
+		t.Errorf("want the exit and log tail reported, got %v", err)
+	}
+}
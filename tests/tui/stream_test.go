@@ -0,0 +1,131 @@
+package tui_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// streamValue stores value under "big" on a mock server, opens it past the
+// large-value prompt and runs the stream to its end, counting the chunks.
+func streamValue(t *testing.T, value string) (tui.Model, int) {
+	t.Helper()
+	m, srv := connectMock(t, newTestModel())
+	srv.Do(0, "SET", "big", value)
+	m.LargeValueBytes = 1024
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateInputKey
+
+	m, cmd := send(m, tui.InputCompleteMsg{Value: "big", Type: tui.InputKey})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateConfirmation {
+		t.Fatalf("state: want StateConfirmation, got %v", m.CurrentState)
+	}
+
+	m, cmd = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	chunks := 0
+	for cmd != nil {
+		msg := loadResult(cmd)
+		if msg == nil {
+			break
+		}
+		if _, ok := msg.(tui.StreamChunkMsg); ok {
+			chunks++
+		}
+		m, cmd = send(m, msg)
+	}
+	if m.CurrentState != tui.StateStream || !m.Stream.Done || m.Stream.Err != "" {
+		t.Fatalf("want a finished stream, got %v %+v", m.CurrentState, m.Stream.Err)
+	}
+	return m, chunks
+}
+
+// TestStream_LargeStringArrivesInChunks confirms fetching an oversized string
+// and checks the streamed rows add up to the value, with no rune split
+// across a chunk boundary.
+func TestStream_LargeStringArrivesInChunks(t *testing.T) {
+	value := strings.Repeat("é", 100_000) // 200 KB: several chunks, 2-byte runes
+	m, chunks := streamValue(t, value)
+
+	s := m.Stream
+	if chunks < 3 {
+		t.Errorf("want the value in several chunks, got %d", chunks)
+	}
+	for _, line := range s.Lines {
+		if !utf8.ValidString(line) {
+			t.Fatalf("row split a rune: %q", line)
+		}
+	}
+	if got := strings.Join(s.Lines, ""); got != value {
+		t.Errorf("rows don't add up to the value: %d of %d bytes", len(got), len(value))
+	}
+	if !strings.Contains(m.View(), "195.3 KiB in") {
+		t.Errorf("want the size in the title:\n%s", m.View())
+	}
+}
+
+// TestStream_KeepsLastLines verifies a value of more rows than the stream
+// keeps drops its oldest ones, and says so.
+func TestStream_KeepsLastLines(t *testing.T) {
+	const n = 100_050
+	var b strings.Builder
+	for i := range n {
+		b.WriteString(strconv.Itoa(i) + "\n")
+	}
+	m, _ := streamValue(t, b.String())
+
+	s := m.Stream
+	if len(s.Lines) != 100_000 || s.Dropped != 50 {
+		t.Fatalf("want 100000 rows kept and 50 dropped, got %d and %d", len(s.Lines), s.Dropped)
+	}
+	if rows := s.Rows(0, len(s.Lines)); rows[0] != "50" || rows[len(rows)-1] != strconv.Itoa(n-1) {
+		t.Errorf("want rows 50 to %d, got %s to %s", n-1, rows[0], rows[len(rows)-1])
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if view := m.View(); !strings.Contains(view, "of 100000 · the first 50 dropped") || !strings.Contains(view, strconv.Itoa(n-1)) {
+		t.Errorf("want the last rows and the drop noted, got:\n%s", view)
+	}
+}
+
+// TestStream_DialsOutsideTheWorker verifies opening a stream doesn't wait
+// behind a command still running on the shared connection's worker.
+func TestStream_DialsOutsideTheWorker(t *testing.T) {
+	m, srv := connectMock(t, newTestModel())
+	srv.Do(0, "SET", "big", strings.Repeat("x", 4096))
+	m.LargeValueBytes = 1024
+	m.SelectedOp = tui.OpGet
+	m.CurrentState = tui.StateInputKey
+	m, cmd := send(m, tui.InputCompleteMsg{Value: "big", Type: tui.InputKey})
+	m, _ = send(m, loadResult(cmd))
+
+	m.Worker = tui.NewWorker(m.Conn)
+	defer m.Worker.Stop()
+	busy, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	go m.Worker.Do(func() tea.Msg {
+		close(busy)
+		<-release
+		return nil
+	})()
+	<-busy
+
+	_, cmd = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	got := make(chan tea.Msg, 1)
+	go func() { got <- loadResult(cmd) }()
+	select {
+	case msg := <-got:
+		if start, ok := msg.(tui.StreamStartMsg); !ok || start.Error != nil || start.Body == nil {
+			t.Fatalf("want the stream started, got %#v", msg)
+		} else {
+			_ = start.Conn.Close()
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the stream waited for the busy worker")
+	}
+}
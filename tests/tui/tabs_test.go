@@ -0,0 +1,119 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+func sendTabs(t tui.TabsModel, msg tea.Msg) (tui.TabsModel, tea.Cmd) {
+	next, cmd := t.Update(msg)
+	return next.(tui.TabsModel), cmd
+}
+
+func newTabsModel() tui.TabsModel {
+	m := newTestModel()
+	m.RedisAddress = "cache:6379"
+	m.DB = 3
+	m.WindowWidth, m.WindowHeight = 120, 30
+	return tui.NewTabs(m)
+}
+
+// TestTabs_CtrlT_ClonesConnection verifies ctrl+t opens a new tab on the same
+// server and DB, starting from the menu, and switches to it.
+func TestTabs_CtrlT_ClonesConnection(t *testing.T) {
+	tabs := newTabsModel()
+	tabs.Tabs[0].CurrentState = tui.StateBrowser
+	tabs.Tabs[0].StateNavigationHistory = []tui.AppState{tui.StateMenu}
+
+	tabs, cmd := sendTabs(tabs, tea.KeyMsg{Type: tea.KeyCtrlT})
+
+	if len(tabs.Tabs) != 2 || tabs.Active != 1 {
+		t.Fatalf("want 2 tabs with the new one active, got %d / %d", len(tabs.Tabs), tabs.Active)
+	}
+	clone := tabs.Tabs[1]
+	if clone.RedisAddress != "cache:6379" || clone.DB != 3 {
+		t.Errorf("clone should target the same server and DB, got %s db%d", clone.RedisAddress, clone.DB)
+	}
+	if clone.CurrentState != tui.StateMenu || len(clone.StateNavigationHistory) != 0 {
+		t.Errorf("clone should start fresh at the menu, got %v %v", clone.CurrentState, clone.StateNavigationHistory)
+	}
+	if tabs.Tabs[0].CurrentState != tui.StateBrowser {
+		t.Error("original tab's navigation must be untouched")
+	}
+	if cmd == nil {
+		t.Error("expected the clone to start connecting")
+	}
+}
+
+// TestTabs_CtrlArrows_Switch verifies ctrl+left/right cycle the active tab.
+func TestTabs_CtrlArrows_Switch(t *testing.T) {
+	tabs := newTabsModel()
+	tabs, _ = sendTabs(tabs, tea.KeyMsg{Type: tea.KeyCtrlT})
+	tabs, _ = sendTabs(tabs, tea.KeyMsg{Type: tea.KeyCtrlT})
+
+	tabs, _ = sendTabs(tabs, tea.KeyMsg{Type: tea.KeyCtrlRight})
+	if tabs.Active != 0 {
+		t.Errorf("ctrl+right from the last tab should wrap to 0, got %d", tabs.Active)
+	}
+	tabs, _ = sendTabs(tabs, tea.KeyMsg{Type: tea.KeyCtrlLeft})
+	if tabs.Active != 2 {
+		t.Errorf("ctrl+left from the first tab should wrap to 2, got %d", tabs.Active)
+	}
+}
+
+// TestTabs_ResultRoutedToOriginTab verifies an async result lands on the tab
+// that issued it even after the user has switched away.
+func TestTabs_ResultRoutedToOriginTab(t *testing.T) {
+	tabs := newTabsModel()
+	tabs, _ = sendTabs(tabs, tea.KeyMsg{Type: tea.KeyCtrlT})
+	tabs.Tabs[1].CurrentState = tui.StateForm
+	tabs.Tabs[1].StateNavigationHistory = []tui.AppState{tui.StateMenu}
+	tabs.Tabs[0].CurrentState = tui.StateOutput
+
+	// esc on a form cancels via an async BackMsg.
+	tabs, cmd := sendTabs(tabs, tea.KeyMsg{Type: tea.KeyEscape})
+	tabs, _ = sendTabs(tabs, tea.KeyMsg{Type: tea.KeyCtrlLeft})
+	tabs, _ = sendTabs(tabs, cmd())
+
+	if tabs.Tabs[1].CurrentState != tui.StateMenu {
+		t.Errorf("origin tab: want StateMenu, got %v", tabs.Tabs[1].CurrentState)
+	}
+	if tabs.Tabs[0].CurrentState != tui.StateOutput {
+		t.Errorf("active tab must not receive the result, got %v", tabs.Tabs[0].CurrentState)
+	}
+}
+
+// TestTabs_CtrlW_ClosesTab verifies ctrl+w closes the active tab but never the
+// last one.
+func TestTabs_CtrlW_ClosesTab(t *testing.T) {
+	tabs := newTabsModel()
+	tabs, _ = sendTabs(tabs, tea.KeyMsg{Type: tea.KeyCtrlT})
+
+	tabs, _ = sendTabs(tabs, tea.KeyMsg{Type: tea.KeyCtrlW})
+	if len(tabs.Tabs) != 1 || tabs.Active != 0 {
+		t.Fatalf("want 1 tab left, got %d (active %d)", len(tabs.Tabs), tabs.Active)
+	}
+	tabs, _ = sendTabs(tabs, tea.KeyMsg{Type: tea.KeyCtrlW})
+	if len(tabs.Tabs) != 1 {
+		t.Error("the last tab must not be closed")
+	}
+}
+
+// TestTabs_View_ShowsTabBar verifies the tab bar lists every session's server
+// and DB once a second tab exists.
+func TestTabs_View_ShowsTabBar(t *testing.T) {
+	tabs := newTabsModel()
+	if strings.Contains(tabs.View(), "1 cache:6379") {
+		t.Error("no tab bar expected with a single tab")
+	}
+
+	tabs, _ = sendTabs(tabs, tea.KeyMsg{Type: tea.KeyCtrlT})
+	view := tabs.View()
+	if !strings.Contains(view, "1 cache:6379 · db3") || !strings.Contains(view, "2 cache:6379 · db3") {
+		t.Error("expected both tabs in the tab bar")
+	}
+}
@@ -0,0 +1,84 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestTail_StreamKey opens a stream key from the browser against the mock
+// server: the newest entries show first, an entry added from elsewhere is
+// appended by the next XREAD, and esc stops the tail and returns.
+func TestTail_StreamKey(t *testing.T) {
+	m, srv := connectMock(t, newTestModel())
+	srv.Do(0, "XADD", "events", "1-1", "kind", "signup")
+	srv.Do(0, "XADD", "events", "2-1", "kind", "login")
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m.CurrentState = tui.StateBrowser
+
+	m, cmd := send(m, tui.SelectKeyMsg{Key: "events"})
+	for cmd != nil && m.CurrentState != tui.StateTail {
+		m, cmd = send(m, loadResult(cmd))
+	}
+	if m.CurrentState != tui.StateTail {
+		t.Fatalf("want the tail, got state %v %+v", m.CurrentState, m.Result)
+	}
+	if len(m.Tail.Entries) != 2 || m.Tail.Entries[0].ID != "1-1" || m.Tail.LastID != "2-1" {
+		t.Fatalf("want the two entries oldest first, got %+v", m.Tail)
+	}
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		srv.Do(0, "XADD", "events", "3-1", "kind", "logout")
+	}()
+	m, cmd = send(m, loadResult(cmd))
+	if cmd == nil || m.Tail.Arrived != 1 || m.Tail.LastID != "3-1" {
+		t.Fatalf("want the new entry and the next read issued, got %+v", m.Tail)
+	}
+	if view := m.View(); !strings.Contains(view, "kind=logout") || !strings.Contains(view, "1 new since opened") {
+		t.Errorf("want the new entry on screen, got:\n%s", view)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if !m.Tail.Paused || !strings.Contains(m.View(), "paused") {
+		t.Error("p should pause the tail")
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateBrowser || m.Tail.Conn != nil {
+		t.Errorf("esc should close the tail and return, got state %v", m.CurrentState)
+	}
+}
+
+// TestTail_BufferDropsOldest verifies the buffer keeps only the newest
+// entries, shrinks with -, and counts what it let go.
+func TestTail_BufferDropsOldest(t *testing.T) {
+	m := newTestModel()
+	m.WindowWidth, m.WindowHeight = 120, 30
+	m.CurrentState = tui.StateTail
+	m.Tail = tui.TailModel{Key: "events", Buffer: 200, Follow: true}
+	for i := range 150 {
+		m.Tail.Entries = append(m.Tail.Entries, tui.TailEntry{ID: "1-" + strings.Repeat("0", i%3)})
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+	if m.Tail.Buffer != 100 || len(m.Tail.Entries) != 100 || m.Tail.Dropped != 50 {
+		t.Errorf("want 100 kept and 50 dropped, got buffer %d, %d entries, %d dropped", m.Tail.Buffer, len(m.Tail.Entries), m.Tail.Dropped)
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if m.Tail.Buffer != 200 {
+		t.Errorf("want the buffer doubled, got %d", m.Tail.Buffer)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyUp})
+	if m.Tail.Follow || !strings.Contains(m.View(), "end to follow") {
+		t.Error("scrolling up should stop following")
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnd})
+	if !m.Tail.Follow {
+		t.Error("end should follow again")
+	}
+}
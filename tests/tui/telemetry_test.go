@@ -0,0 +1,81 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestTelemetry_SamplesIntoHeaderAndInfo verifies a connect with telemetry
+// on starts sampling, that each sample re-arms the tick and shows in the
+// header, and that the INFO screen lists the series above the reply.
+func TestTelemetry_SamplesIntoHeaderAndInfo(t *testing.T) {
+	dialed, _ := connectMock(t, newTestModel())
+	m := newTestModel()
+	m.WindowWidth, m.WindowHeight = 160, 40
+	m.Telemetry.Interval = 5 * time.Second
+	m, _ = send(m, tui.RedisConnectionMsg{Conn: dialed.Conn})
+	if !m.Telemetry.Active {
+		t.Fatal("want telemetry started on connect")
+	}
+	defer m.Worker.Stop()
+
+	gen := m.Telemetry.Gen
+	for range 2 {
+		var cmd tea.Cmd
+		m, cmd = send(m, tui.TelemetryTickMsg{Gen: gen})
+		m, cmd = send(m, cmd())
+		if cmd == nil {
+			t.Fatal("want the tick re-armed after a sample")
+		}
+	}
+	if n := len(m.Telemetry.Samples); n != 2 {
+		t.Fatalf("want 2 samples, got %d (err %q)", n, m.Telemetry.Err)
+	}
+	if s := m.Telemetry.Samples[1]; s.Memory != 1048576 || s.Clients != 1 {
+		t.Errorf("want 1 MiB used by 1 client, got %+v", s)
+	}
+	if view := m.View(); !strings.Contains(view, "mem ▁▁ 1.0 MiB") || !strings.Contains(view, "cl ▁▁ 1") {
+		t.Errorf("want sparklines in the header, got:\n%s", view)
+	}
+
+	// A stale tick, from before a restart of the chain, does nothing.
+	if _, cmd := send(m, tui.TelemetryTickMsg{Gen: gen - 1}); cmd != nil {
+		t.Error("a tick from a retired chain should do nothing")
+	}
+
+	m.SelectedOp = tui.OpInfo
+	m, _ = send(m, tui.RedisResultMsg{Result: "# Server\r\nredis_version:7.2.0\r\n"})
+	view := m.View()
+	for _, want := range []string{"# Telemetry (every 5s, this session)", "2 samples since", "memory", "last 1.0 MiB", "redis_version"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("want %q on the INFO screen, got:\n%s", want, view)
+		}
+	}
+}
+
+// TestTelemetry_OffByDefault verifies nothing is sampled or shown without an
+// interval.
+func TestTelemetry_OffByDefault(t *testing.T) {
+	m, _ := browseKeys("")
+	m, cmd := send(m, tui.TelemetryTickMsg{})
+	if cmd != nil || m.Telemetry.Active {
+		t.Error("want no sampling when telemetry is off")
+	}
+	m.SelectedOp = tui.OpInfo
+	m, _ = send(m, tui.RedisResultMsg{Result: "# Server\r\nredis_version:7.2.0\r\n"})
+	if strings.Contains(m.View(), "Telemetry") {
+		t.Errorf("want no telemetry on the INFO screen, got:\n%s", m.View())
+	}
+}
+
+func TestLoadConfig_TelemetrySeconds(t *testing.T) {
+	path := writeConfig(t, `{"telemetry_seconds":-1}`)
+	if _, err := tui.LoadConfig(path); err == nil || !strings.Contains(err.Error(), "telemetry_seconds") {
+		t.Errorf("want a negative interval refused, got %v", err)
+	}
+}
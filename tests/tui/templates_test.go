@@ -0,0 +1,89 @@
+package tui_test
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// openTemplate opens the TEMPLATE picker and fills in its first template.
+func openTemplate(t *testing.T, data string) (tui.Model, *mockConn) {
+	t.Helper()
+	conn, reader := newMockConn(data)
+	m := newPickerMenuModel("TEMPLATE")
+	m.Conn, m.Reader = conn, reader
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateTemplates {
+		t.Fatalf("state: want StateTemplates, got %v", m.CurrentState)
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v", m.CurrentState)
+	}
+	return m, conn
+}
+
+// TestTemplate_SessionHash verifies the built-in session template expands its
+// placeholders and creates the hash with its TTL.
+func TestTemplate_SessionHash(t *testing.T) {
+	m, conn := openTemplate(t, ":0\r\n:3\r\n:1\r\n")
+
+	v := m.Form.Values()
+	if !regexp.MustCompile(`^session:[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(v[0]) {
+		t.Errorf("key placeholder not expanded: %q", v[0])
+	}
+	created, _ := strconv.Atoi(v[2])
+	if expires, _ := strconv.Atoi(v[3]); created == 0 || expires != created+3600 {
+		t.Errorf("want expires_at = created_at+3600, got %q / %q", v[2], v[3])
+	}
+
+	m.Form.Inputs[1].SetValue("42")
+	m, cmd := send(m, tui.FormSubmitMsg{Values: m.Form.Values()})
+	m, _ = send(m, loadResult(cmd))
+
+	sent := conn.writtenData.String()
+	for _, want := range []string{"EXISTS", "HSET", "$7\r\nuser_id\r\n$2\r\n42\r\n", "EXPIRE", "$4\r\n3600\r\n"} {
+		if !strings.Contains(sent, want) {
+			t.Errorf("want %q sent, got %q", want, sent)
+		}
+	}
+	if m.CurrentState != tui.StateOutput || !strings.Contains(m.Result.Text(), "Created hash") {
+		t.Errorf("want a confirmation, got %v %q", m.CurrentState, m.Result.Text())
+	}
+}
+
+// TestTemplate_ExistingKeyRefused verifies a template never merges into an
+// existing key: the form stays open with an error.
+func TestTemplate_ExistingKeyRefused(t *testing.T) {
+	m, conn := openTemplate(t, ":1\r\n")
+
+	m, cmd := send(m, tui.FormSubmitMsg{Values: m.Form.Values()})
+	m, _ = send(m, loadResult(cmd))
+
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "already exists") {
+		t.Errorf("want the form back with an error, got %v %q", m.CurrentState, m.Form.Err)
+	}
+	if strings.Contains(conn.writtenData.String(), "HSET") {
+		t.Error("nothing should be written over an existing key")
+	}
+}
+
+// TestLoadConfig_Templates verifies configured templates load in order and
+// that a malformed one is rejected.
+func TestLoadConfig_Templates(t *testing.T) {
+	path := writeConfig(t, `{"templates":[{"name":"flag","type":"string","key":"flag:","value":"on"}]}`)
+	cfg, err := tui.LoadConfig(path)
+	if err != nil || len(cfg.Templates) != 1 || cfg.Templates[0].Value != "on" {
+		t.Fatalf("want one template, got %+v, %v", cfg.Templates, err)
+	}
+
+	path = writeConfig(t, `{"templates":[{"name":"q","type":"list","key":"q:"}]}`)
+	if _, err := tui.LoadConfig(path); err == nil || !strings.Contains(err.Error(), "hash or string") {
+		t.Errorf("want a type error, got %v", err)
+	}
+}
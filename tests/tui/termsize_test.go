@@ -0,0 +1,34 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestTermSize_TooSmall verifies a window below the minimum shows the resize
+// notice instead of the screen, ignores keys, and the screen comes back as it
+// was once the window grows.
+func TestTermSize_TooSmall(t *testing.T) {
+	m := newMenuTestModel()
+	m, _ = send(m, tea.WindowSizeMsg{Width: 40, Height: 10})
+
+	view := m.View()
+	if !strings.Contains(view, "Terminal too small") || !strings.Contains(view, "40×10") {
+		t.Fatalf("want the resize notice, got:\n%s", view)
+	}
+	if strings.Contains(view, "Select a command") {
+		t.Error("the menu shouldn't render cut off underneath")
+	}
+	if _, cmd := send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}); cmd != nil {
+		t.Error("keys should be ignored while the screen isn't shown")
+	}
+
+	m, _ = send(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+	if view := m.View(); !strings.Contains(view, "Select a command") || m.CurrentState != tui.StateMenu {
+		t.Errorf("want the menu back after growing, got:\n%s", view)
+	}
+}
@@ -0,0 +1,61 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+func pmessage(pattern, key, event string) string {
+	channel := "__keyspace@0__:" + key
+	return fmt.Sprintf("*4\r\n$8\r\npmessage\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n",
+		len(pattern), pattern, len(channel), channel, len(event), event)
+}
+
+// TestTimeline_CountsEvents verifies the timeline subscribes to the
+// pattern's keyspace channel, classifies events, and flags notifications
+// that are switched off on the server.
+func TestTimeline_CountsEvents(t *testing.T) {
+	const pattern = "__keyspace@0__:session:*"
+	subscribed := make(chan string, 1)
+	addr := startFakeRedis(t, func(cmd []string) string {
+		switch cmd[0] {
+		case "CONFIG":
+			return "*2\r\n$22\r\nnotify-keyspace-events\r\n$0\r\n\r\n"
+		case "PSUBSCRIBE":
+			subscribed <- cmd[1]
+			return "*3\r\n$10\r\npsubscribe\r\n$24\r\n" + pattern + "\r\n:1\r\n" +
+				pmessage(pattern, "session:1", "set") + pmessage(pattern, "session:1", "expire") +
+				pmessage(pattern, "session:2", "expired") + pmessage(pattern, "session:3", "del")
+		}
+		return "+OK\r\n"
+	})
+
+	m := newPickerMenuModel("TIMELINE")
+	m.RedisAddress = addr
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"session:*"}})
+	m, cmd = send(m, loadResult(cmd))
+	t.Cleanup(func() { _ = m.Timeline.Conn.Close() })
+	if got := <-subscribed; got != pattern {
+		t.Errorf("want PSUBSCRIBE %s, got %s", pattern, got)
+	}
+	for range 4 {
+		m, cmd = send(m, cmd())
+	}
+
+	tl := m.Timeline
+	if tl.Total != [4]int{2, 1, 0, 1} {
+		t.Errorf("want 2 writes, 1 expired, 1 del; got %v", tl.Total)
+	}
+	if len(tl.Events) != 4 || tl.Events[0].Key != "session:3" || tl.Events[0].Event != "del" {
+		t.Errorf("want newest event first, got %+v", tl.Events)
+	}
+	if m.CurrentState != tui.StateTimeline || !strings.Contains(tl.Notice, "press e to enable") {
+		t.Errorf("want a notice that notifications are off, got %v %q", m.CurrentState, tl.Notice)
+	}
+}
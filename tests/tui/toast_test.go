@@ -0,0 +1,42 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestToast_DeleteStaysInBrowser verifies a delete is reported in a toast
+// drawn over the refreshed list, and that only the newest toast's expiry
+// clears it.
+func TestToast_DeleteStaysInBrowser(t *testing.T) {
+	m := newTestModel()
+	m.WindowWidth, m.WindowHeight = 100, 30
+	m.SelectedOp = tui.OpDel
+	m.ActiveKey = "user:1"
+	m.StateNavigationHistory = []tui.AppState{tui.StateMenu, tui.StateBrowser}
+
+	m, cmd := send(m, tui.RedisResultMsg{Result: 1})
+	m, _ = send(m, tui.RedisResultMsg{Result: tui.ScanResult{Cursor: "0"}})
+	if m.CurrentState != tui.StateBrowser {
+		t.Fatalf("want the refreshed key list, got state %v", m.CurrentState)
+	}
+	if view := m.View(); !strings.Contains(view, "✓ Deleted user:1") {
+		t.Fatalf("want the toast drawn, got:\n%s", view)
+	}
+	if cmd == nil {
+		t.Fatal("want the refresh and the toast's expiry scheduled")
+	}
+
+	stale := tui.ToastExpiredMsg{Seq: m.Toast.Seq}
+	m.Toast.Seq++ // a newer toast replaced it
+	m, _ = send(m, stale)
+	if m.Toast.Text == "" {
+		t.Error("an older toast's expiry shouldn't clear a newer one")
+	}
+	m, _ = send(m, tui.ToastExpiredMsg{Seq: m.Toast.Seq})
+	if m.Toast.Text != "" || strings.Contains(m.View(), "Deleted user:1") {
+		t.Error("the toast should clear when it expires")
+	}
+}
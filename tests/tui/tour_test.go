@@ -0,0 +1,72 @@
+package tui_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestTour_FirstRun verifies the tour opens over the menu until dismissed,
+// and that dismissing it records it as seen.
+func TestTour_FirstRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "redis-tui", "tour-seen")
+	if tui.TourSeen(path) {
+		t.Fatal("no marker yet: the tour should be unseen")
+	}
+	m := newTestModel()
+	m.TourPath = path
+	m = m.WithTour()
+	if m.CurrentState != tui.StateTour || !strings.Contains(m.View(), "Connecting") {
+		t.Fatalf("want the tour's first page, got state %v", m.CurrentState)
+	}
+
+	m2, cmd := send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m2.CurrentState != tui.StateMenu {
+		t.Errorf("esc should land on the menu, got %v", m2.CurrentState)
+	}
+	if cmd == nil {
+		t.Fatal("dismissing should record the tour as seen")
+	}
+	cmd()
+	if !tui.TourSeen(path) {
+		t.Error("marker not written")
+	}
+}
+
+// TestTour_FromMenu verifies TOUR reopens the tour, pages move both ways,
+// and Enter on the last page finishes it.
+func TestTour_FromMenu(t *testing.T) {
+	m, _ := send(newPickerMenuModel("TOUR"), tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateTour {
+		t.Fatalf("state: want StateTour, got %v", m.CurrentState)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyLeft})
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyLeft}) // already on the first page
+	if m.Tour.Step != 0 {
+		t.Fatalf("want step 0, got %d", m.Tour.Step)
+	}
+
+	var pages []string
+	for m.CurrentState == tui.StateTour {
+		if len(pages) > 20 {
+			t.Fatal("tour never ended")
+		}
+		pages = append(pages, m.View())
+		m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	if m.CurrentState != tui.StateMenu {
+		t.Errorf("finishing should return to the menu, got %v", m.CurrentState)
+	}
+	all := strings.Join(pages, "\n")
+	for _, topic := range []string{"Connecting", "Exploring", "Editing", "Deleting", "user:*"} {
+		if !strings.Contains(all, topic) {
+			t.Errorf("tour never mentions %q", topic)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package tui_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestTTLForecast_Buckets reads keys with TTLs of every range from the mock
+// server and checks each lands in its bucket, with the herd called out.
+func TestTTLForecast_Buckets(t *testing.T) {
+	m, srv := connectMock(t, newPickerMenuModel("TTL_FORECAST"))
+	for i := range 6 {
+		srv.Do(0, "SET", fmt.Sprintf("s:herd:%d", i), "v", "EX", "1800")
+	}
+	srv.Do(0, "SET", "s:soon", "v", "EX", "30")
+	srv.Do(0, "SET", "s:week", "v", "EX", "604800")
+	srv.Do(0, "SET", "s:forever", "v")
+	srv.Do(0, "SET", "other", "v", "EX", "30")
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateForm {
+		t.Fatalf("state: want StateForm, got %v", m.CurrentState)
+	}
+
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"s:*", "1000"}})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateOutput {
+		t.Fatalf("want the forecast, got state %v, form error %q", m.CurrentState, m.Form.Err)
+	}
+	out := m.Result.Value
+	for _, want := range []string{
+		`all 9 keys matching s:\*`,
+		`within a minute +█+ +1 +11\.1%`,
+		`within an hour +█+ +6 +66\.7%`,
+		`within a day +0 `,
+		`later +█+ +1 `,
+		`never +█+ +1 `,
+		`Busiest minute ahead: 6 keys expire`,
+	} {
+		if !regexp.MustCompile(want).MatchString(out) {
+			t.Errorf("want %q in the forecast, got:\n%s", want, out)
+		}
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateForm {
+		t.Errorf("esc should return to the form, got %v", m.CurrentState)
+	}
+}
+
+func TestTTLForecast_NoKeys(t *testing.T) {
+	m := newTestModel()
+	m.Conn, m.Reader = newMockConn("*2\r\n$1\r\n0\r\n*0\r\n")
+	m.SelectedOp = tui.OpTTLForecast
+	m.CurrentState = tui.StateForm
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"nothing:*", "100"}})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "no keys match") {
+		t.Errorf("want the form back with an error, got state %v, %q", m.CurrentState, m.Form.Err)
+	}
+}
@@ -0,0 +1,74 @@
+package tui_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestVim_MenuNavigation verifies j/k, G and gg move the menu selection
+// instead of starting the filter, and : opens the console.
+func TestVim_MenuNavigation(t *testing.T) {
+	m := newMenuTestModel()
+	m.Config.VimKeys = true
+
+	m, _ = press(m, "j", "j", "k")
+	if m.MenuList.Index() != 1 || m.MenuList.FilterState() != list.Unfiltered {
+		t.Fatalf("want the second row selected and no filter, got %d %v", m.MenuList.Index(), m.MenuList.FilterState())
+	}
+	m, _ = press(m, "G")
+	if m.MenuList.Index() != 3 {
+		t.Errorf("G: want the last row, got %d", m.MenuList.Index())
+	}
+	m, _ = press(m, "g")
+	if m.MenuList.Index() != 3 {
+		t.Errorf("a single g shouldn't move, got %d", m.MenuList.Index())
+	}
+	m, _ = press(m, "g")
+	if m.MenuList.Index() != 0 {
+		t.Errorf("gg: want the first row, got %d", m.MenuList.Index())
+	}
+	m, _ = press(m, "x")
+	if m.MenuList.FilterState() != list.Unfiltered {
+		t.Error("a letter shouldn't start the filter in vim mode")
+	}
+
+	m, _ = press(m, ":")
+	if m.CurrentState != tui.StateConsole {
+		t.Fatalf(": should open the console, got state %v", m.CurrentState)
+	}
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentState != tui.StateMenu {
+		t.Errorf("esc should return to the menu, got state %v", m.CurrentState)
+	}
+}
+
+// TestVim_BrowserDeleteChord verifies dd asks to delete the selected key, and
+// a d followed by anything else doesn't.
+func TestVim_BrowserDeleteChord(t *testing.T) {
+	m := newTestModel()
+	m.Config.VimKeys = true
+	m.CurrentState = tui.StateBrowser
+	m.Browser.KeyList.SetItems([]list.Item{tui.NewListItem("a", "string"), tui.NewListItem("b", "hash")})
+
+	m, cmd := press(m, "d", "j")
+	if cmd != nil {
+		if _, ok := cmd().(tui.DeleteRequestMsg); ok {
+			t.Fatal("d then j shouldn't delete")
+		}
+	}
+	if m.Browser.KeyList.Index() != 1 {
+		t.Errorf("j after d should still move, got %d", m.Browser.KeyList.Index())
+	}
+
+	_, cmd = press(m, "d", "d")
+	if cmd == nil {
+		t.Fatal("dd should ask to delete")
+	}
+	if msg, ok := cmd().(tui.DeleteRequestMsg); !ok || msg.Key != "b" {
+		t.Errorf("want a delete request for b, got %#v", msg)
+	}
+}
@@ -0,0 +1,108 @@
+package tui_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestFieldRows_HugeHash verifies a hash of 100k fields on a server with
+// HSCAN NOVALUES is read a page of names at a time: opening it builds one
+// page of rows, draws them, and opens the field selected, and reaching the
+// end of the list builds the next page.
+func TestFieldRows_HugeHash(t *testing.T) {
+	dialed, srv := connectMock(t, newTestModel())
+	args := []string{"HSET", "big"}
+	for i := range 100000 {
+		args = append(args, "f"+strconv.Itoa(i), "v")
+	}
+	srv.Do(0, args...)
+	m := newTestModel()
+	m.Browser.FieldsList = list.New(nil, tui.BrowserDelegate(), 80, 20)
+	m.Browser.Width = 80
+	m, _ = send(m, tui.RedisConnectionMsg{Conn: dialed.Conn})
+	defer m.Worker.Stop()
+	m.Server = redis.ServerInfo{Flavor: redis.FlavorRedis, Version: "7.4.0"}
+	m, cmd := send(m, tui.SelectKeyMsg{Key: "big"})
+	for i := 0; cmd != nil && m.CurrentState == tui.StateLoading && i < 5; i++ {
+		m, cmd = send(m, loadResult(cmd))
+	}
+
+	if n := len(m.Browser.FieldsList.Items()); n != 100 {
+		t.Fatalf("want one page of 100 rows built, got %d", n)
+	}
+	if !m.Browser.HasMoreFields {
+		t.Error("want more fields to load")
+	}
+	if view := m.Browser.View(); !strings.Contains(view, "f0") || !strings.Contains(view, "field") {
+		t.Errorf("want the first fields drawn, got:\n%s", view)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on a field should fetch it")
+	}
+	if sel, ok := cmd().(tui.SelectFieldMsg); !ok || sel.Field != "f1" {
+		t.Errorf("want f1 opened, got %#v", sel)
+	}
+
+	m, cmd = send(m, tui.LoadMoreFieldsMsg{})
+	m, _ = send(m, loadResult(cmd))
+	if n := len(m.Browser.FieldsList.Items()); n != 200 {
+		t.Errorf("want a second page of rows built, got %d", n)
+	}
+}
+
+// TestFieldRows_HugeHashWithoutNoValues verifies a server older than HSCAN
+// NOVALUES lists a wide hash with HKEYS, names only, in one go.
+func TestFieldRows_HugeHashWithoutNoValues(t *testing.T) {
+	dialed, srv := connectMock(t, newTestModel())
+	args := []string{"HSET", "big"}
+	for i := range 100000 {
+		args = append(args, "f"+strconv.Itoa(i), "v")
+	}
+	srv.Do(0, args...)
+	m := newTestModel()
+	m.Browser.FieldsList = list.New(nil, tui.BrowserDelegate(), 80, 20)
+	m, _ = send(m, tui.RedisConnectionMsg{Conn: dialed.Conn})
+	defer m.Worker.Stop()
+	m.Server = redis.ServerInfo{Flavor: redis.FlavorRedis, Version: "7.2.0"}
+	m, cmd := send(m, tui.SelectKeyMsg{Key: "big"})
+	for i := 0; cmd != nil && m.CurrentState == tui.StateLoading && i < 5; i++ {
+		m, cmd = send(m, loadResult(cmd))
+	}
+
+	if n := len(m.Browser.FieldsList.Items()); n != 100000 {
+		t.Fatalf("want every field listed, got %d", n)
+	}
+	if m.Browser.HasMoreFields {
+		t.Error("HKEYS has no further pages")
+	}
+}
+
+// TestFieldRows_ListPagesKeepIndexes verifies a second LRANGE page is
+// labelled from where the first left off.
+func TestFieldRows_ListPagesKeepIndexes(t *testing.T) {
+	page := make([]any, 100)
+	for i := range page {
+		page[i] = "e"
+	}
+	m := newTestModel()
+	m.SelectedOp = tui.OpLRange
+	m, _ = send(m, tui.RedisResultMsg{Result: page})
+	m.SelectedOp = tui.OpLRange
+	m, _ = send(m, tui.RedisResultMsg{Result: []any{"last"}})
+
+	items := m.Browser.FieldsList.Items()
+	last, ok := items[len(items)-1].(list.DefaultItem)
+	if !ok || last.Title() != "last" || last.Description() != "idx 100" {
+		t.Errorf("want last at idx 100, got %+v", items[len(items)-1])
+	}
+}
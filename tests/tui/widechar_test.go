@@ -0,0 +1,59 @@
+package tui_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestBrowserDelegate_WideKeyFitsRow verifies a key of CJK and emoji is cut
+// by display width, so the row stays inside the list and keeps its type label.
+func TestBrowserDelegate_WideKeyFitsRow(t *testing.T) {
+	key := "用户:" + strings.Repeat("会话🔥", 20)
+	items := []list.Item{tui.NewListItem(key, "hash")}
+	l := list.New(items, tui.BrowserDelegate(), 40, 10)
+
+	var buf bytes.Buffer
+	tui.BrowserDelegate().Render(&buf, l, 0, items[0])
+	row := buf.String()
+
+	if w := lipgloss.Width(row); w > 40 {
+		t.Errorf("row is %d cells wide, want at most 40: %q", w, row)
+	}
+	if !strings.Contains(row, "…  hash") {
+		t.Errorf("want a truncated name and the type label, got %q", row)
+	}
+}
+
+// TestCountersView_WideKeysAlign verifies the value column lines up whether
+// the key is ASCII or double-width.
+func TestCountersView_WideKeysAlign(t *testing.T) {
+	m := newCountersModel()
+	m, _ = send(m, tui.CounterSampleMsg{Gen: 1, Rows: []tui.CounterRow{
+		{Key: "rl:ascii", Value: "1", Numeric: true, PTTL: -1},
+		{Key: "rl:限流", Value: "2", Numeric: true, PTTL: -1},
+		{Key: "rl:" + strings.Repeat("🚦", 30), Value: "3", Numeric: true, PTTL: -1},
+	}})
+
+	// The value column starts at the same cell on every row.
+	col := map[string]int{}
+	for _, line := range strings.Split(m.View(), "\n") {
+		if !strings.Contains(line, "rl:") {
+			continue
+		}
+		trimmed := strings.TrimRight(line, " ")
+		for _, v := range []string{"1", "2", "3"} {
+			if i := strings.Index(trimmed, " "+v+" "); i >= 0 {
+				col[v] = lipgloss.Width(trimmed[:i+1])
+			}
+		}
+	}
+	if len(col) != 3 || col["1"] != col["2"] || col["1"] != col["3"] {
+		t.Errorf("values misaligned: %v\n%s", col, m.View())
+	}
+}
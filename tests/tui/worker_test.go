@@ -0,0 +1,88 @@
+package tui_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// TestWorker_RunsOneAtATime verifies that commands queued on a worker never
+// overlap, however many goroutines deliver them.
+func TestWorker_RunsOneAtATime(t *testing.T) {
+	w := tui.NewWorker(nil)
+	defer w.Stop()
+
+	var active, overlaps int32
+	slow := func() tea.Msg {
+		if atomic.AddInt32(&active, 1) > 1 {
+			atomic.AddInt32(&overlaps, 1)
+		}
+		time.Sleep(2 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		return "done"
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if msg := w.Do(slow)(); msg != "done" {
+				t.Errorf("want the command's message, got %v", msg)
+			}
+		}()
+	}
+	wg.Wait()
+	if overlaps > 0 {
+		t.Errorf("%d commands overlapped on the worker", overlaps)
+	}
+}
+
+// TestWorker_StoppedRefusesWork verifies that a request made after Stop gets
+// an error result instead of hanging.
+func TestWorker_StoppedRefusesWork(t *testing.T) {
+	w := tui.NewWorker(nil)
+	w.Stop()
+	w.Stop() // idempotent
+
+	ran := false
+	msg := w.Do(func() tea.Msg { ran = true; return nil })()
+	res, ok := msg.(tui.RedisResultMsg)
+	if !ok || res.Error == nil || ran {
+		t.Errorf("want an error result without running the command, got %#v (ran=%v)", msg, ran)
+	}
+}
+
+// TestWorker_NilRunsDirectly verifies that models without a worker (before
+// the first connection) still run their commands.
+func TestWorker_NilRunsDirectly(t *testing.T) {
+	var w *tui.Worker
+	if msg := w.Do(func() tea.Msg { return "direct" })(); msg != "direct" {
+		t.Errorf("want the command's message, got %v", msg)
+	}
+}
+
+// TestWorker_StartedOnConnect verifies that each new connection gets its own
+// worker and the previous one is stopped.
+func TestWorker_StartedOnConnect(t *testing.T) {
+	m := newTestModel()
+	conn, _ := newMockConn("")
+	m, _ = send(m, tui.RedisConnectionMsg{Conn: conn})
+	first := m.Worker
+	if first == nil || first.Conn != m.Conn {
+		t.Fatalf("want a worker for the new connection, got %#v", first)
+	}
+	conn, _ = newMockConn("")
+	m, _ = send(m, tui.RedisConnectionMsg{Conn: conn})
+	if m.Worker == first {
+		t.Error("want a fresh worker after reconnecting")
+	}
+	if _, ok := first.Do(func() tea.Msg { return nil })().(tui.RedisResultMsg); !ok {
+		t.Error("want the old worker stopped")
+	}
+}
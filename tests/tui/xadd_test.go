@@ -0,0 +1,94 @@
+package tui_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/redis/mock"
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+// xaddModel is connected to a mock server, on the XADD form for key.
+func xaddModel(t *testing.T, key string) (tui.Model, *mock.Server) {
+	t.Helper()
+	m, srv := connectMock(t, newPickerMenuModel("XADD"))
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentState != tui.StateForm || m.SelectedOp != tui.OpXAdd {
+		t.Fatalf("want the XADD form, got state %v op %v", m.CurrentState, m.SelectedOp)
+	}
+	return m, srv
+}
+
+// TestXAdd_SeveralFieldsAndCustomID verifies the form sends every pair in
+// one XADD, keeps the form open for the next entry, and passes the server's
+// refusal of a stale ID back to the form.
+func TestXAdd_SeveralFieldsAndCustomID(t *testing.T) {
+	m, srv := xaddModel(t, "")
+
+	m, cmd := send(m, tui.FormSubmitMsg{Values: []string{"events", `kind=signup, user=ada, note=a\, b=c`, "5-1"}})
+	m, toast := send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateForm || m.Form.Err != "" || toast == nil {
+		t.Fatalf("want the form back with a toast, got state %v err %q", m.CurrentState, m.Form.Err)
+	}
+	if !strings.Contains(m.View(), "Added 5-1 to events") {
+		t.Errorf("want the entry ID in a toast, got:\n%s", m.View())
+	}
+	want := []any{[]any{"5-1", []any{"kind", "signup", "user", "ada", "note", "a, b=c"}}}
+	if got := srv.Do(0, "XRANGE", "events", "-", "+"); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if m.Form.Inputs[2].Value() != "" {
+		t.Errorf("a custom ID should be cleared for the next entry, got %q", m.Form.Inputs[2].Value())
+	}
+
+	m, cmd = send(m, tui.FormSubmitMsg{Values: []string{"events", "kind=late", "4"}})
+	m, _ = send(m, loadResult(cmd))
+	if m.CurrentState != tui.StateForm || !strings.Contains(m.Form.Err, "equal or smaller") {
+		t.Errorf("want the stale ID refused on the form, got state %v err %q", m.CurrentState, m.Form.Err)
+	}
+}
+
+// TestXAdd_RefusesMalformedInput verifies pairs and IDs are checked before
+// anything is sent.
+func TestXAdd_RefusesMalformedInput(t *testing.T) {
+	m, _ := xaddModel(t, "")
+	cases := []struct {
+		values []string
+		err    string
+	}{
+		{[]string{"", "a=1", ""}, "stream key is required"},
+		{[]string{"events", "", ""}, "at least one field=value"},
+		{[]string{"events", "a=1, justtext", ""}, "field=value"},
+		{[]string{"events", "=1", ""}, "field=value"},
+		{[]string{"events", "a=1", "abc"}, "entry ID"},
+	}
+	for _, c := range cases {
+		got, cmd := send(m, tui.FormSubmitMsg{Values: c.values})
+		if cmd != nil || !strings.Contains(got.Form.Err, c.err) {
+			t.Errorf("%q: want %q and nothing sent, got %q", c.values, c.err, got.Form.Err)
+		}
+	}
+}
+
+// TestTail_AddOpensXAddForKey verifies a in the tail opens the form on the
+// tailed stream, and esc goes back to the tail.
+func TestTail_AddOpensXAddForKey(t *testing.T) {
+	m := newTestModel()
+	m.CurrentState = tui.StateTail
+	m.Tail = tui.TailModel{Key: "events", Buffer: 100, Follow: true}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if m.CurrentState != tui.StateForm || m.Form.Inputs[0].Value() != "events" || m.Form.Focus != 1 {
+		t.Fatalf("want the form on events with the fields focused, got state %v focus %d", m.CurrentState, m.Form.Focus)
+	}
+	m, cmd := send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil {
+		m, _ = send(m, cmd())
+	}
+	if m.CurrentState != tui.StateTail {
+		t.Errorf("esc should return to the tail, got %v", m.CurrentState)
+	}
+}
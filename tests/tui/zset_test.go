@@ -0,0 +1,91 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajxv/redis-tui/internal/tui"
+)
+
+func newZSetModel(t *testing.T, reply []any) tui.Model {
+	t.Helper()
+	m := newTestModel()
+	m.Browser.FieldsList = list.New(nil, tui.BrowserDelegate(), 80, 20)
+	tui.StyleList(&m.Browser.FieldsList)
+	m.Browser.Width = 80
+	return newZSetModelFrom(t, m, reply)
+}
+
+// newZSetModelFrom loads reply, a ZRANGE WITHSCORES page, into m's browser.
+func newZSetModelFrom(t *testing.T, m tui.Model, reply []any) tui.Model {
+	t.Helper()
+	m.SelectedOp = tui.OpZRange
+	m, _ = send(m, tui.RedisResultMsg{Result: reply})
+	if m.Browser.ActiveKeyType != "zset" {
+		t.Fatalf("want the zset browser, got %q", m.Browser.ActiveKeyType)
+	}
+	return m
+}
+
+// TestZSetBrowser_Columns verifies members render as rank | member | score
+// under a heading, with the scores right-aligned.
+func TestZSetBrowser_Columns(t *testing.T) {
+	m := newZSetModel(t, []any{"carol", "7", "alice", "42.5", "bob", "1000"})
+
+	view := m.Browser.View()
+	if !strings.Contains(view, "rank  member") || !strings.Contains(view, "score ▴") {
+		t.Errorf("missing column heading:\n%s", view)
+	}
+	ends := map[int]bool{}
+	for _, line := range strings.Split(view, "\n") {
+		for _, want := range []struct{ rank, member, score string }{{"0", "carol", "7"}, {"1", "alice", "42.5"}, {"2", "bob", "1000"}} {
+			if strings.Contains(line, want.member) {
+				fields := strings.Fields(strings.TrimPrefix(line, "›"))
+				if len(fields) != 3 || fields[0] != want.rank || fields[2] != want.score {
+					t.Errorf("row: want %v, got %q", want, line)
+				}
+				ends[len([]rune(strings.TrimRight(line, " ")))] = true
+			}
+		}
+	}
+	if len(ends) != 1 {
+		t.Errorf("scores should end in the same column, got ends %v:\n%s", ends, view)
+	}
+}
+
+// TestZSetBrowser_SortToggle verifies s reorders the loaded members by name
+// and back by score, keeping each member's rank.
+func TestZSetBrowser_SortToggle(t *testing.T) {
+	m := newZSetModel(t, []any{"carol", "7", "alice", "42.5", "bob", "1000"})
+	order := func(m tui.Model) string {
+		var names []string
+		for _, it := range m.Browser.FieldsList.Items() {
+			names = append(names, it.(tui.ListItem).Title())
+		}
+		return strings.Join(names, ",")
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if got := order(m); got != "alice,bob,carol" {
+		t.Errorf("by member: got %s", got)
+	}
+	if !strings.Contains(m.Browser.View(), "member ▴") {
+		t.Error("heading should mark the member column")
+	}
+
+	// A further page keeps the chosen order.
+	m.SelectedOp = tui.OpZRange
+	m.Browser.FieldOffset = 3
+	m, _ = send(m, tui.RedisResultMsg{Result: []any{"aaron", "2000"}})
+	if got := order(m); got != "aaron,alice,bob,carol" {
+		t.Errorf("after load more: got %s", got)
+	}
+
+	m, _ = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if got := order(m); got != "carol,alice,bob,aaron" {
+		t.Errorf("by score: got %s", got)
+	}
+}